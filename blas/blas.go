@@ -119,6 +119,17 @@ type Float32Level3 interface {
 	Strsm(s Side, ul Uplo, tA Transpose, d Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int)
 }
 
+// Float32Batch implements the single precision real batched BLAS routines.
+// The matrices and vectors in a batch all have the same dimensions and are
+// either given as separate slices or stored at a fixed stride in a single
+// slice.
+type Float32Batch interface {
+	SgemvBatch(tA Transpose, m, n int, alpha float32, a [][]float32, lda int, x [][]float32, incX int, beta float32, y [][]float32, incY int)
+	SgemvStridedBatch(tA Transpose, m, n int, alpha float32, a []float32, lda, strideA int, x []float32, incX, strideX int, beta float32, y []float32, incY, strideY, batchCount int)
+	SgemmBatch(tA, tB Transpose, m, n, k int, alpha float32, a [][]float32, lda int, b [][]float32, ldb int, beta float32, c [][]float32, ldc int)
+	SgemmStridedBatch(tA, tB Transpose, m, n, k int, alpha float32, a []float32, lda, strideA int, b []float32, ldb, strideB int, beta float32, c []float32, ldc, strideC, batchCount int)
+}
+
 // Float64 implements the single precision real BLAS routines.
 type Float64 interface {
 	Float64Level1
@@ -172,6 +183,17 @@ type Float64Level3 interface {
 	Dtrsm(s Side, ul Uplo, tA Transpose, d Diag, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int)
 }
 
+// Float64Batch implements the double precision real batched BLAS routines.
+// The matrices and vectors in a batch all have the same dimensions and are
+// either given as separate slices or stored at a fixed stride in a single
+// slice.
+type Float64Batch interface {
+	DgemvBatch(tA Transpose, m, n int, alpha float64, a [][]float64, lda int, x [][]float64, incX int, beta float64, y [][]float64, incY int)
+	DgemvStridedBatch(tA Transpose, m, n int, alpha float64, a []float64, lda, strideA int, x []float64, incX, strideX int, beta float64, y []float64, incY, strideY, batchCount int)
+	DgemmBatch(tA, tB Transpose, m, n, k int, alpha float64, a [][]float64, lda int, b [][]float64, ldb int, beta float64, c [][]float64, ldc int)
+	DgemmStridedBatch(tA, tB Transpose, m, n, k int, alpha float64, a []float64, lda, strideA int, b []float64, ldb, strideB int, beta float64, c []float64, ldc, strideC, batchCount int)
+}
+
 // Complex64 implements the single precision complex BLAS routines.
 type Complex64 interface {
 	Complex64Level1
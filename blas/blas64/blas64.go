@@ -470,3 +470,151 @@ func Trmm(s blas.Side, tA blas.Transpose, alpha float64, a Triangular, b General
 func Trsm(s blas.Side, tA blas.Transpose, alpha float64, a Triangular, b General) {
 	blas64.Dtrsm(s, a.Uplo, tA, a.Diag, b.Rows, b.Cols, alpha, a.Data, a.Stride, b.Data, b.Stride)
 }
+
+// Batched
+
+const (
+	badBatchLength = "blas64: batch length mismatch"
+	badBatchShape  = "blas64: batch shape mismatch"
+	negBatchCount  = "blas64: negative batch count"
+)
+
+// GemvBatch computes
+//  y[i] = alpha * A[i] * x[i] + beta * y[i]   if t == blas.NoTrans,
+//  y[i] = alpha * A[i]ᵀ * x[i] + beta * y[i]  if t == blas.Trans or blas.ConjTrans,
+// for each i, where A[i] are m×n dense matrices, x[i] and y[i] are vectors, and
+// alpha and beta are scalars.
+//
+// GemvBatch will panic if the lengths of a, x and y do not match, or if the
+// matrices in a do not all have the same dimensions and stride or the vectors
+// in x or y do not all have the same increment.
+func GemvBatch(t blas.Transpose, alpha float64, a []General, x []Vector, beta float64, y []Vector) {
+	if len(a) != len(y) || len(x) != len(y) {
+		panic(badBatchLength)
+	}
+	if len(a) == 0 {
+		return
+	}
+	m, n, lda := a[0].Rows, a[0].Cols, a[0].Stride
+	incX, incY := x[0].Inc, y[0].Inc
+	ad := make([][]float64, len(a))
+	xd := make([][]float64, len(a))
+	yd := make([][]float64, len(a))
+	for i := range a {
+		if a[i].Rows != m || a[i].Cols != n || a[i].Stride != lda || x[i].Inc != incX || y[i].Inc != incY {
+			panic(badBatchShape)
+		}
+		ad[i], xd[i], yd[i] = a[i].Data, x[i].Data, y[i].Data
+	}
+	if bi, ok := blas64.(blas.Float64Batch); ok {
+		bi.DgemvBatch(t, m, n, alpha, ad, lda, xd, incX, beta, yd, incY)
+		return
+	}
+	for i := range a {
+		blas64.Dgemv(t, m, n, alpha, ad[i], lda, xd[i], incX, beta, yd[i], incY)
+	}
+}
+
+// GemvStridedBatch computes
+//  y_i = alpha * A_i * x_i + beta * y_i   if t == blas.NoTrans,
+//  y_i = alpha * A_iᵀ * x_i + beta * y_i  if t == blas.Trans or blas.ConjTrans,
+// for each i in [0, batchCount), where alpha and beta are scalars. The m×n
+// dense matrix A_i is described by a with its data starting at
+// a.Data[i*strideA], and the vectors x_i and y_i are described by x and y with
+// their data starting at x.Data[i*strideX] and y.Data[i*strideY]. The strides
+// of a and x may be zero to use the same matrix or vector for the whole batch.
+//
+// GemvStridedBatch will panic if batchCount is negative.
+func GemvStridedBatch(t blas.Transpose, alpha float64, a General, strideA int, x Vector, strideX int, beta float64, y Vector, strideY int, batchCount int) {
+	if batchCount < 0 {
+		panic(negBatchCount)
+	}
+	if bi, ok := blas64.(blas.Float64Batch); ok {
+		bi.DgemvStridedBatch(t, a.Rows, a.Cols, alpha, a.Data, a.Stride, strideA, x.Data, x.Inc, strideX, beta, y.Data, y.Inc, strideY, batchCount)
+		return
+	}
+	for i := 0; i < batchCount; i++ {
+		blas64.Dgemv(t, a.Rows, a.Cols, alpha, a.Data[i*strideA:], a.Stride, x.Data[i*strideX:], x.Inc, beta, y.Data[i*strideY:], y.Inc)
+	}
+}
+
+// GemmBatch computes
+//  C[i] = alpha * A[i] * B[i] + beta * C[i],
+// for each i, where A[i], B[i], and C[i] are dense matrices, and alpha and beta
+// are scalars. tA and tB specify whether A[i] or B[i] are transposed.
+//
+// GemmBatch will panic if the lengths of a, b and c do not match, or if the
+// matrices in any of a, b or c do not all have the same dimensions and stride.
+func GemmBatch(tA, tB blas.Transpose, alpha float64, a, b []General, beta float64, c []General) {
+	if len(a) != len(c) || len(b) != len(c) {
+		panic(badBatchLength)
+	}
+	if len(a) == 0 {
+		return
+	}
+	var m, n, k int
+	if tA == blas.NoTrans {
+		m, k = a[0].Rows, a[0].Cols
+	} else {
+		m, k = a[0].Cols, a[0].Rows
+	}
+	if tB == blas.NoTrans {
+		n = b[0].Cols
+	} else {
+		n = b[0].Rows
+	}
+	ad := make([][]float64, len(a))
+	bd := make([][]float64, len(a))
+	cd := make([][]float64, len(a))
+	for i := range a {
+		if !sameShape(a[i], a[0]) || !sameShape(b[i], b[0]) || !sameShape(c[i], c[0]) {
+			panic(badBatchShape)
+		}
+		ad[i], bd[i], cd[i] = a[i].Data, b[i].Data, c[i].Data
+	}
+	if bi, ok := blas64.(blas.Float64Batch); ok {
+		bi.DgemmBatch(tA, tB, m, n, k, alpha, ad, a[0].Stride, bd, b[0].Stride, beta, cd, c[0].Stride)
+		return
+	}
+	for i := range a {
+		blas64.Dgemm(tA, tB, m, n, k, alpha, ad[i], a[0].Stride, bd[i], b[0].Stride, beta, cd[i], c[0].Stride)
+	}
+}
+
+// GemmStridedBatch computes
+//  C_i = alpha * A_i * B_i + beta * C_i,
+// for each i in [0, batchCount), where alpha and beta are scalars. The dense
+// matrices A_i, B_i and C_i are described by a, b and c with their data
+// starting at a.Data[i*strideA], b.Data[i*strideB] and c.Data[i*strideC]. tA
+// and tB specify whether A_i or B_i are transposed. The strides of a and b may
+// be zero to use the same matrix for the whole batch.
+//
+// GemmStridedBatch will panic if batchCount is negative.
+func GemmStridedBatch(tA, tB blas.Transpose, alpha float64, a General, strideA int, b General, strideB int, beta float64, c General, strideC int, batchCount int) {
+	if batchCount < 0 {
+		panic(negBatchCount)
+	}
+	var m, n, k int
+	if tA == blas.NoTrans {
+		m, k = a.Rows, a.Cols
+	} else {
+		m, k = a.Cols, a.Rows
+	}
+	if tB == blas.NoTrans {
+		n = b.Cols
+	} else {
+		n = b.Rows
+	}
+	if bi, ok := blas64.(blas.Float64Batch); ok {
+		bi.DgemmStridedBatch(tA, tB, m, n, k, alpha, a.Data, a.Stride, strideA, b.Data, b.Stride, strideB, beta, c.Data, c.Stride, strideC, batchCount)
+		return
+	}
+	for i := 0; i < batchCount; i++ {
+		blas64.Dgemm(tA, tB, m, n, k, alpha, a.Data[i*strideA:], a.Stride, b.Data[i*strideB:], b.Stride, beta, c.Data[i*strideC:], c.Stride)
+	}
+}
+
+// sameShape returns whether a and b have the same dimensions and stride.
+func sameShape(a, b General) bool {
+	return a.Rows == b.Rows && a.Cols == b.Cols && a.Stride == b.Stride
+}
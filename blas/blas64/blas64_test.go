@@ -50,9 +50,15 @@ func TestDsyrk(t *testing.T)  { testblas.DsyrkTest(t, impl) }
 func TestDsyr2k(t *testing.T) { testblas.Dsyr2kTest(t, impl) }
 func TestDtrmm(t *testing.T)  { testblas.DtrmmTest(t, impl) }
 
+func TestDgemvBatch(t *testing.T) { testblas.DgemvBatchTest(t, impl) }
+func TestDgemmBatch(t *testing.T) { testblas.DgemmBatchTest(t, impl) }
+
 type b64 struct{}
 
-var _ blas.Float64 = b64{}
+var (
+	_ blas.Float64      = b64{}
+	_ blas.Float64Batch = b64{}
+)
 
 func (b64) Ddot(n int, x []float64, incX int, y []float64, incY int) float64 {
 	return Dot(Vector{N: n, Inc: incX, Data: x}, Vector{N: n, Inc: incY, Data: y})
@@ -287,3 +293,66 @@ func (b64) Dtrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n
 		Triangular{Uplo: ul, Diag: d, N: k, Data: a, Stride: lda},
 		General{Rows: m, Cols: n, Data: b, Stride: ldb})
 }
+
+func (b64) DgemvBatch(tA blas.Transpose, m, n int, alpha float64, a [][]float64, lda int, x [][]float64, incX int, beta float64, y [][]float64, incY int) {
+	ag := make([]General, len(a))
+	for i := range a {
+		ag[i] = General{Rows: m, Cols: n, Data: a[i], Stride: lda}
+	}
+	xv := make([]Vector, len(x))
+	for i := range x {
+		xv[i] = Vector{Data: x[i], Inc: incX}
+	}
+	yv := make([]Vector, len(y))
+	for i := range y {
+		yv[i] = Vector{Data: y[i], Inc: incY}
+	}
+	GemvBatch(tA, alpha, ag, xv, beta, yv)
+}
+func (b64) DgemvStridedBatch(tA blas.Transpose, m, n int, alpha float64, a []float64, lda, strideA int, x []float64, incX, strideX int, beta float64, y []float64, incY, strideY, batchCount int) {
+	GemvStridedBatch(tA, alpha,
+		General{Rows: m, Cols: n, Data: a, Stride: lda}, strideA,
+		Vector{Data: x, Inc: incX}, strideX,
+		beta,
+		Vector{Data: y, Inc: incY}, strideY,
+		batchCount)
+}
+func (b64) DgemmBatch(tA, tB blas.Transpose, m, n, k int, alpha float64, a [][]float64, lda int, b [][]float64, ldb int, beta float64, c [][]float64, ldc int) {
+	am, an := m, k
+	if tA != blas.NoTrans {
+		am, an = an, am
+	}
+	bm, bn := k, n
+	if tB != blas.NoTrans {
+		bm, bn = bn, bm
+	}
+	ag := make([]General, len(a))
+	for i := range a {
+		ag[i] = General{Rows: am, Cols: an, Data: a[i], Stride: lda}
+	}
+	bg := make([]General, len(b))
+	for i := range b {
+		bg[i] = General{Rows: bm, Cols: bn, Data: b[i], Stride: ldb}
+	}
+	cg := make([]General, len(c))
+	for i := range c {
+		cg[i] = General{Rows: m, Cols: n, Data: c[i], Stride: ldc}
+	}
+	GemmBatch(tA, tB, alpha, ag, bg, beta, cg)
+}
+func (b64) DgemmStridedBatch(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda, strideA int, b []float64, ldb, strideB int, beta float64, c []float64, ldc, strideC, batchCount int) {
+	am, an := m, k
+	if tA != blas.NoTrans {
+		am, an = an, am
+	}
+	bm, bn := k, n
+	if tB != blas.NoTrans {
+		bm, bn = bn, bm
+	}
+	GemmStridedBatch(tA, tB, alpha,
+		General{Rows: am, Cols: an, Data: a, Stride: lda}, strideA,
+		General{Rows: bm, Cols: bn, Data: b, Stride: ldb}, strideB,
+		beta,
+		General{Rows: m, Cols: n, Data: c, Stride: ldc}, strideC,
+		batchCount)
+}
@@ -0,0 +1,139 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"runtime"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/internal/parallel"
+)
+
+// Batched [SD]gemm and [SD]gemv behavior constants. These are kept here to
+// keep them out of the way during single precision code generation.
+const (
+	smallGemm    = 16      // largest dimension handled by the small matrix kernel
+	minBatchWork = 1 << 16 // minimum number of flops per goroutine
+)
+
+// batchParallel calls fn(i) for each i in [0, count). The batch is split into
+// contiguous chunks that are processed concurrently when the total amount of
+// work, given as the work for a single item, is large enough.
+func batchParallel(count, work int, fn func(i int)) {
+	workers := runtime.GOMAXPROCS(0)
+	if count < workers {
+		workers = count
+	}
+	if w := count * work / minBatchWork; w < workers {
+		workers = w
+	}
+	if workers <= 1 {
+		for i := 0; i < count; i++ {
+			fn(i)
+		}
+		return
+	}
+	chunk := (count + workers - 1) / workers
+	parallel.For(workers, blocks(count, chunk), func(c int) {
+		start := c * chunk
+		end := min(start+chunk, count)
+		for i := start; i < end; i++ {
+			fn(i)
+		}
+	})
+}
+
+// checkGemmBatch checks the parameters shared by all matrices of a batched
+// matrix-matrix multiplication and returns whether A and B are transposed and
+// the minimum lengths of the slices holding A, B and C.
+func checkGemmBatch(tA, tB blas.Transpose, m, n, k, lda, ldb, ldc int) (aTrans, bTrans bool, lenA, lenB, lenC int) {
+	switch tA {
+	default:
+		panic(badTranspose)
+	case blas.NoTrans, blas.Trans, blas.ConjTrans:
+	}
+	switch tB {
+	default:
+		panic(badTranspose)
+	case blas.NoTrans, blas.Trans, blas.ConjTrans:
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	aTrans = tA == blas.Trans || tA == blas.ConjTrans
+	if aTrans {
+		if lda < max(1, m) {
+			panic(badLdA)
+		}
+		lenA = (k-1)*lda + m
+	} else {
+		if lda < max(1, k) {
+			panic(badLdA)
+		}
+		lenA = (m-1)*lda + k
+	}
+	bTrans = tB == blas.Trans || tB == blas.ConjTrans
+	if bTrans {
+		if ldb < max(1, k) {
+			panic(badLdB)
+		}
+		lenB = (n-1)*ldb + k
+	} else {
+		if ldb < max(1, n) {
+			panic(badLdB)
+		}
+		lenB = (k-1)*ldb + n
+	}
+	if ldc < max(1, n) {
+		panic(badLdC)
+	}
+	lenC = (m-1)*ldc + n
+	return aTrans, bTrans, max(0, lenA), max(0, lenB), max(0, lenC)
+}
+
+// checkGemvBatch checks the parameters shared by all matrices and vectors of a
+// batched matrix-vector multiplication and returns the number of elements of
+// x and y and the minimum lengths of the slices holding A, x and y.
+func checkGemvBatch(tA blas.Transpose, m, n, lda, incX, incY int) (lenX, lenY, sizeA, sizeX, sizeY int) {
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLdA)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if incY == 0 {
+		panic(zeroIncY)
+	}
+	lenX, lenY = m, n
+	if tA == blas.NoTrans {
+		lenX, lenY = n, m
+	}
+	if m == 0 || n == 0 {
+		return lenX, lenY, 0, 0, 0
+	}
+	return lenX, lenY, lda*(m-1) + n, (lenX-1)*abs(incX) + 1, (lenY-1)*abs(incY) + 1
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
@@ -0,0 +1,389 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/internal/asm/f64"
+)
+
+var _ blas.Float64Batch = Implementation{}
+
+// DgemvBatch computes
+//  y[i] = alpha * A[i] * x[i] + beta * y[i]   if tA = blas.NoTrans
+//  y[i] = alpha * A[i]ᵀ * x[i] + beta * y[i]  if tA = blas.Trans or blas.ConjTrans
+// for each i in the batch, where A[i] are m×n dense matrices, x[i] and y[i] are
+// vectors, and alpha and beta are scalars. All matrices and vectors share the
+// same dimensions, leading dimension and increments. The vectors y[i] must not
+// overlap, and a panic will occur if two of them start at the same element.
+// The batch is processed concurrently when it is large enough.
+func (Implementation) DgemvBatch(tA blas.Transpose, m, n int, alpha float64, a [][]float64, lda int, x [][]float64, incX int, beta float64, y [][]float64, incY int) {
+	lenX, lenY, sizeA, sizeX, sizeY := checkGemvBatch(tA, m, n, lda, incX, incY)
+	if len(a) != len(y) || len(x) != len(y) {
+		panic(badBatch)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return
+	}
+
+	for i := range y {
+		if len(a[i]) < sizeA {
+			panic(shortA)
+		}
+		if len(x[i]) < sizeX {
+			panic(shortX)
+		}
+		if len(y[i]) < sizeY {
+			panic(shortY)
+		}
+	}
+	// The vectors are updated concurrently, so catch the same
+	// vector being passed more than once.
+	seen := make(map[*float64]struct{}, len(y))
+	for _, v := range y {
+		if _, ok := seen[&v[0]]; ok {
+			panic(badOverlap)
+		}
+		seen[&v[0]] = struct{}{}
+	}
+
+	// Quick return if possible.
+	if alpha == 0 && beta == 1 {
+		return
+	}
+
+	aTrans := tA != blas.NoTrans
+	batchParallel(len(y), m*n, func(i int) {
+		dgemvBatchOne(aTrans, m, n, lenX, lenY, alpha, a[i], lda, x[i], incX, beta, y[i], incY)
+	})
+}
+
+// DgemvStridedBatch computes
+//  y_i = alpha * A_i * x_i + beta * y_i   if tA = blas.NoTrans
+//  y_i = alpha * A_iᵀ * x_i + beta * y_i  if tA = blas.Trans or blas.ConjTrans
+// for each i in [0, batchCount), where the m×n dense matrix A_i starts at
+// a[i*strideA], the vector x_i starts at x[i*strideX] and the vector y_i starts
+// at y[i*strideY]. alpha and beta are scalars. The strides of A and x may be
+// zero to use the same matrix or vector for the whole batch. The vectors y_i
+// must not overlap. The batch is processed concurrently when it is large
+// enough.
+func (Implementation) DgemvStridedBatch(tA blas.Transpose, m, n int, alpha float64, a []float64, lda, strideA int, x []float64, incX, strideX int, beta float64, y []float64, incY, strideY, batchCount int) {
+	lenX, lenY, sizeA, sizeX, sizeY := checkGemvBatch(tA, m, n, lda, incX, incY)
+	if batchCount < 0 {
+		panic(batchCountLT0)
+	}
+	if strideA < 0 {
+		panic(badStrideA)
+	}
+	if strideX < 0 {
+		panic(badStrideX)
+	}
+	if batchCount > 1 && strideY < sizeY {
+		panic(badStrideY)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 || batchCount == 0 {
+		return
+	}
+
+	last := batchCount - 1
+	if len(a) < last*strideA+sizeA {
+		panic(shortA)
+	}
+	if len(x) < last*strideX+sizeX {
+		panic(shortX)
+	}
+	if len(y) < last*strideY+sizeY {
+		panic(shortY)
+	}
+
+	// Quick return if possible.
+	if alpha == 0 && beta == 1 {
+		return
+	}
+
+	aTrans := tA != blas.NoTrans
+	batchParallel(batchCount, m*n, func(i int) {
+		dgemvBatchOne(aTrans, m, n, lenX, lenY, alpha, a[i*strideA:], lda, x[i*strideX:], incX, beta, y[i*strideY:], incY)
+	})
+}
+
+// dgemvBatchOne computes a single matrix-vector product of a batch. The
+// parameters must have been checked by the caller.
+func dgemvBatchOne(aTrans bool, m, n, lenX, lenY int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	// First form y = beta * y.
+	if beta != 1 {
+		inc := abs(incY)
+		if beta == 0 {
+			for i := 0; i < lenY*inc; i += inc {
+				y[i] = 0
+			}
+		} else {
+			for i := 0; i < lenY*inc; i += inc {
+				y[i] *= beta
+			}
+		}
+	}
+
+	if alpha == 0 {
+		return
+	}
+
+	var kx, ky int
+	if incX < 0 {
+		kx = -(lenX - 1) * incX
+	}
+	if incY < 0 {
+		ky = -(lenY - 1) * incY
+	}
+
+	// Form y = alpha * A * x + y.
+	if !aTrans {
+		if incX == 1 && incY == 1 {
+			for i := 0; i < m; i++ {
+				y[i] += alpha * f64.DotUnitary(a[lda*i:lda*i+n], x[:n])
+			}
+			return
+		}
+		iy := ky
+		for i := 0; i < m; i++ {
+			y[iy] += alpha * f64.DotInc(x, a[lda*i:lda*i+n], uintptr(n), uintptr(incX), 1, uintptr(kx), 0)
+			iy += incY
+		}
+		return
+	}
+	// Cases where a is transposed.
+	if incX == 1 && incY == 1 {
+		for i := 0; i < m; i++ {
+			tmp := alpha * x[i]
+			if tmp != 0 {
+				f64.AxpyUnitary(tmp, a[lda*i:lda*i+n], y[:n])
+			}
+		}
+		return
+	}
+	ix := kx
+	for i := 0; i < m; i++ {
+		tmp := alpha * x[ix]
+		if tmp != 0 {
+			f64.AxpyInc(tmp, a[lda*i:lda*i+n], y, uintptr(n), 1, uintptr(incY), 0, uintptr(ky))
+		}
+		ix += incX
+	}
+}
+
+// DgemmBatch performs one of the matrix-matrix operations
+//  C[i] = alpha * A[i] * B[i] + beta * C[i]
+//  C[i] = alpha * A[i]ᵀ * B[i] + beta * C[i]
+//  C[i] = alpha * A[i] * B[i]ᵀ + beta * C[i]
+//  C[i] = alpha * A[i]ᵀ * B[i]ᵀ + beta * C[i]
+// for each i in the batch, where A[i] are m×k or k×m dense matrices, B[i] are
+// n×k or k×n dense matrices, C[i] are m×n matrices, and alpha and beta are
+// scalars. tA and tB specify whether A[i] or B[i] are transposed. All matrices
+// of the same operand share the same dimensions and leading dimension. The
+// matrices C[i] must not overlap, and a panic will occur if two of them start
+// at the same element.
+//
+// The batch is processed concurrently when it is large enough, and small
+// matrices are multiplied by an unblocked kernel to reduce the per-matrix
+// overhead.
+func (Implementation) DgemmBatch(tA, tB blas.Transpose, m, n, k int, alpha float64, a [][]float64, lda int, b [][]float64, ldb int, beta float64, c [][]float64, ldc int) {
+	aTrans, bTrans, sizeA, sizeB, sizeC := checkGemmBatch(tA, tB, m, n, k, lda, ldb, ldc)
+	if len(a) != len(c) || len(b) != len(c) {
+		panic(badBatch)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return
+	}
+
+	for i := range c {
+		if len(a[i]) < sizeA {
+			panic(shortA)
+		}
+		if len(b[i]) < sizeB {
+			panic(shortB)
+		}
+		if len(c[i]) < sizeC {
+			panic(shortC)
+		}
+	}
+	// The matrices are updated concurrently, so catch the same
+	// matrix being passed more than once.
+	seen := make(map[*float64]struct{}, len(c))
+	for _, v := range c {
+		if _, ok := seen[&v[0]]; ok {
+			panic(badOverlap)
+		}
+		seen[&v[0]] = struct{}{}
+	}
+
+	// Quick return if possible.
+	if (alpha == 0 || k == 0) && beta == 1 {
+		return
+	}
+
+	batchParallel(len(c), m*n*max(1, k), func(i int) {
+		dgemmBatchOne(aTrans, bTrans, m, n, k, alpha, a[i], lda, b[i], ldb, beta, c[i], ldc)
+	})
+}
+
+// DgemmStridedBatch performs one of the matrix-matrix operations
+//  C_i = alpha * A_i * B_i + beta * C_i
+//  C_i = alpha * A_iᵀ * B_i + beta * C_i
+//  C_i = alpha * A_i * B_iᵀ + beta * C_i
+//  C_i = alpha * A_iᵀ * B_iᵀ + beta * C_i
+// for each i in [0, batchCount), where the m×k or k×m dense matrix A_i starts
+// at a[i*strideA], the n×k or k×n dense matrix B_i starts at b[i*strideB] and
+// the m×n matrix C_i starts at c[i*strideC]. alpha and beta are scalars. The
+// strides of A and B may be zero to use the same matrix for the whole batch.
+// The matrices C_i must not overlap.
+//
+// The batch is processed concurrently when it is large enough, and small
+// matrices are multiplied by an unblocked kernel to reduce the per-matrix
+// overhead.
+func (Implementation) DgemmStridedBatch(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda, strideA int, b []float64, ldb, strideB int, beta float64, c []float64, ldc, strideC, batchCount int) {
+	aTrans, bTrans, sizeA, sizeB, sizeC := checkGemmBatch(tA, tB, m, n, k, lda, ldb, ldc)
+	if batchCount < 0 {
+		panic(batchCountLT0)
+	}
+	if strideA < 0 {
+		panic(badStrideA)
+	}
+	if strideB < 0 {
+		panic(badStrideB)
+	}
+	if batchCount > 1 && strideC < sizeC {
+		panic(badStrideC)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 || batchCount == 0 {
+		return
+	}
+
+	last := batchCount - 1
+	if len(a) < last*strideA+sizeA {
+		panic(shortA)
+	}
+	if len(b) < last*strideB+sizeB {
+		panic(shortB)
+	}
+	if len(c) < last*strideC+sizeC {
+		panic(shortC)
+	}
+
+	// Quick return if possible.
+	if (alpha == 0 || k == 0) && beta == 1 {
+		return
+	}
+
+	batchParallel(batchCount, m*n*max(1, k), func(i int) {
+		dgemmBatchOne(aTrans, bTrans, m, n, k, alpha, a[i*strideA:], lda, b[i*strideB:], ldb, beta, c[i*strideC:], ldc)
+	})
+}
+
+// dgemmBatchOne computes a single matrix-matrix product of a batch. The
+// parameters must have been checked by the caller.
+func dgemmBatchOne(aTrans, bTrans bool, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	if m <= smallGemm && n <= smallGemm && k <= smallGemm {
+		dgemmSmall(aTrans, bTrans, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+		return
+	}
+	if beta != 1 {
+		for i := 0; i < m; i++ {
+			ctmp := c[i*ldc : i*ldc+n]
+			if beta == 0 {
+				for j := range ctmp {
+					ctmp[j] = 0
+				}
+				continue
+			}
+			for j := range ctmp {
+				ctmp[j] *= beta
+			}
+		}
+	}
+	if alpha == 0 || k == 0 {
+		return
+	}
+	dgemmSerial(aTrans, bTrans, m, n, k, a, lda, b, ldb, c, ldc, alpha)
+}
+
+// dgemmSmall computes C = alpha * op(A) * op(B) + beta * C for matrices with
+// dimensions not larger than smallGemm without blocking or packing.
+func dgemmSmall(aTrans, bTrans bool, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	var acol [smallGemm]float64
+	for i := 0; i < m; i++ {
+		ctmp := c[i*ldc : i*ldc+n]
+		switch beta {
+		case 0:
+			for j := range ctmp {
+				ctmp[j] = 0
+			}
+		case 1:
+		default:
+			for j := range ctmp {
+				ctmp[j] *= beta
+			}
+		}
+		if alpha == 0 {
+			continue
+		}
+
+		// Get the i-th row of op(A).
+		var arow []float64
+		if aTrans {
+			arow = acol[:k]
+			for l := range arow {
+				arow[l] = a[l*lda+i]
+			}
+		} else {
+			arow = a[i*lda : i*lda+k]
+		}
+
+		if bTrans {
+			// Form the elements of the row of C as dot products with the
+			// rows of B.
+			for j := range ctmp {
+				var sum float64
+				for l, v := range b[j*ldb : j*ldb+k] {
+					sum += arow[l] * v
+				}
+				ctmp[j] += alpha * sum
+			}
+			continue
+		}
+
+		// Form the elements of the row of C four at a time, keeping the
+		// partial sums in registers.
+		var j int
+		for ; j+4 <= n; j += 4 {
+			var s0, s1, s2, s3 float64
+			for l, v := range arow {
+				btmp := b[l*ldb+j : l*ldb+j+4]
+				s0 += v * btmp[0]
+				s1 += v * btmp[1]
+				s2 += v * btmp[2]
+				s3 += v * btmp[3]
+			}
+			ctmp[j] += alpha * s0
+			ctmp[j+1] += alpha * s1
+			ctmp[j+2] += alpha * s2
+			ctmp[j+3] += alpha * s3
+		}
+		for ; j < n; j++ {
+			var sum float64
+			for l, v := range arow {
+				sum += v * b[l*ldb+j]
+			}
+			ctmp[j] += alpha * sum
+		}
+	}
+}
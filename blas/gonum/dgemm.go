@@ -232,6 +232,10 @@ func dgemmParallel(aTrans, bTrans bool, m, n, k int, a []float64, lda int, b []f
 
 // dgemmSerial is serial matrix multiply
 func dgemmSerial(aTrans, bTrans bool, m, n, k int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int, alpha float64) {
+	if f64.HasGemmKernel && m >= f64.GemmMR && n >= f64.GemmNR {
+		dgemmSerialPacked(aTrans, bTrans, m, n, k, a, lda, b, ldb, c, ldc, alpha)
+		return
+	}
 	switch {
 	case !aTrans && !bTrans:
 		dgemmSerialNotNot(m, n, k, a, lda, b, ldb, c, ldc, alpha)
@@ -309,6 +313,94 @@ func dgemmSerialTransTrans(m, n, k int, a []float64, lda int, b []float64, ldb i
 	}
 }
 
+// dgemmPackPool holds the buffers for the packed panels of A and B used by
+// dgemmSerialPacked.
+var dgemmPackPool = sync.Pool{
+	New: func() interface{} {
+		return make([]float64, 2*blockSize*blockSize)
+	},
+}
+
+// dgemmSerialPacked is serial matrix multiply using the register-blocked
+// microkernel. Blocks of A and B of at most blockSize×blockSize are packed
+// into contiguous panels which are then multiplied by f64.GemmKernel.
+func dgemmSerialPacked(aTrans, bTrans bool, m, n, k int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int, alpha float64) {
+	const (
+		mr = f64.GemmMR
+		nr = f64.GemmNR
+	)
+
+	// Element (i, l) of op(A) is a[i*ars+l*acs] and element (l, j) of
+	// op(B) is b[l*brs+j*bcs].
+	ars, acs := lda, 1
+	if aTrans {
+		ars, acs = 1, lda
+	}
+	brs, bcs := ldb, 1
+	if bTrans {
+		brs, bcs = 1, ldb
+	}
+
+	buf := dgemmPackPool.Get().([]float64)
+	defer dgemmPackPool.Put(buf)
+	packA := buf[:blockSize*blockSize]
+	packB := buf[blockSize*blockSize:]
+
+	// The unpacked Gemm computes op(A)*op(B) for each element of C in order of
+	// increasing k by adding rank-one updates to C, except for A*Bᵀ which it
+	// computes as dot products that are added to C at the end. The kernel
+	// updates C directly in the first case. In the second the product of
+	// the panels is formed in tmp and then added to C, so that the result
+	// does not depend on whether the kernel is available.
+	dot := !aTrans && bTrans
+
+	// tmp holds the blocks of C that are not updated directly.
+	var tmp [mr * nr]float64
+	for j := 0; j < n; j += blockSize {
+		nb := min(blockSize, n-j)
+		for l := 0; l < k; l += blockSize {
+			kb := min(blockSize, k-l)
+			f64.GemmPackB(packB, b[l*brs+j*bcs:], brs, bcs, kb, nb)
+			for i := 0; i < m; i += blockSize {
+				mb := min(blockSize, m-i)
+				f64.GemmPackA(packA, a[i*ars+l*acs:], ars, acs, mb, kb)
+				for jr := 0; jr < nb; jr += nr {
+					pb := packB[jr*kb:]
+					for ir := 0; ir < mb; ir += mr {
+						pa := packA[ir*kb:]
+						ci := (i+ir)*ldc + j + jr
+						if !dot && ir+mr <= mb && jr+nr <= nb {
+							f64.GemmKernel(kb, alpha, pa, pb, c[ci:], ldc)
+							continue
+						}
+						for v := range tmp {
+							tmp[v] = 0
+						}
+						rows, cols := min(mr, mb-ir), min(nr, nb-jr)
+						if dot {
+							f64.GemmKernel(kb, 1, pa, pb, tmp[:], nr)
+							for r := 0; r < rows; r++ {
+								ctmp := c[ci+r*ldc : ci+r*ldc+cols]
+								for v := range ctmp {
+									ctmp[v] += alpha * tmp[r*nr+v]
+								}
+							}
+							continue
+						}
+						for r := 0; r < rows; r++ {
+							copy(tmp[r*nr:r*nr+cols], c[ci+r*ldc:])
+						}
+						f64.GemmKernel(kb, alpha, pa, pb, tmp[:], nr)
+						for r := 0; r < rows; r++ {
+							copy(c[ci+r*ldc:ci+r*ldc+cols], tmp[r*nr:])
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
 func sliceView64(a []float64, lda, i, j, r, c int) []float64 {
 	return a[i*lda+j : (i+r-1)*lda+j+c]
 }
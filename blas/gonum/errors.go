@@ -16,6 +16,8 @@ const (
 	kLLT0 = "blas: kL < 0"
 	kULT0 = "blas: kU < 0"
 
+	batchCountLT0 = "blas: batchCount < 0"
+
 	badUplo      = "blas: illegal triangle"
 	badTranspose = "blas: illegal transpose"
 	badDiag      = "blas: illegal diagonal"
@@ -26,6 +28,14 @@ const (
 	badLdB = "blas: bad leading dimension of B"
 	badLdC = "blas: bad leading dimension of C"
 
+	badStrideA = "blas: bad stride of A"
+	badStrideB = "blas: bad stride of B"
+	badStrideC = "blas: bad stride of C"
+	badStrideX = "blas: bad stride of x"
+	badStrideY = "blas: bad stride of y"
+	badBatch   = "blas: mismatched batch lengths"
+	badOverlap = "blas: overlapping batch output"
+
 	shortX  = "blas: insufficient length of x"
 	shortY  = "blas: insufficient length of y"
 	shortAP = "blas: insufficient length of ap"
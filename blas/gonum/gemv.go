@@ -8,6 +8,7 @@ import (
 	"gonum.org/v1/gonum/blas"
 	"gonum.org/v1/gonum/internal/asm/f32"
 	"gonum.org/v1/gonum/internal/asm/f64"
+	"gonum.org/v1/gonum/internal/parallel"
 )
 
 // TODO(Kunde21):  Merge these methods back into level2double/level2single when Sgemv assembly kernels are merged into f32.
@@ -63,6 +64,11 @@ func (Implementation) Dgemv(tA blas.Transpose, m, n int, alpha float64, a []floa
 		return
 	}
 
+	if useParallel(lenY, m*n) {
+		dgemvParallel(tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+		return
+	}
+
 	if alpha == 0 {
 		// First form y = beta * y
 		if incY > 0 {
@@ -135,6 +141,11 @@ func (Implementation) Sgemv(tA blas.Transpose, m, n int, alpha float32, a []floa
 		return
 	}
 
+	if useParallel(lenY, m*n) {
+		sgemvParallel(tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+		return
+	}
+
 	// First form y = beta * y
 	if incY > 0 {
 		Implementation{}.Sscal(lenY, beta, y, incY)
@@ -188,3 +199,49 @@ func (Implementation) Sgemv(tA blas.Transpose, m, n int, alpha float32, a []floa
 		ix += incX
 	}
 }
+
+// dgemvParallel computes Dgemv by partitioning y into blocks that are computed
+// concurrently. The blocks of y correspond to blocks of rows of A if tA is
+// blas.NoTrans and to blocks of columns of A otherwise.
+func dgemvParallel(tA blas.Transpose, m, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	lenY := n
+	if tA == blas.NoTrans {
+		lenY = m
+	}
+	parallel.For(0, blocks(lenY, blockSize), func(b int) {
+		i := b * blockSize
+		nb := min(blockSize, lenY-i)
+		iy := i * incY
+		if incY < 0 {
+			iy = (i + nb - lenY) * incY
+		}
+		if tA == blas.NoTrans {
+			Implementation{}.Dgemv(tA, nb, n, alpha, a[i*lda:], lda, x, incX, beta, y[iy:], incY)
+			return
+		}
+		Implementation{}.Dgemv(tA, m, nb, alpha, a[i:], lda, x, incX, beta, y[iy:], incY)
+	})
+}
+
+// sgemvParallel computes Sgemv by partitioning y into blocks that are computed
+// concurrently. The blocks of y correspond to blocks of rows of A if tA is
+// blas.NoTrans and to blocks of columns of A otherwise.
+func sgemvParallel(tA blas.Transpose, m, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	lenY := n
+	if tA == blas.NoTrans {
+		lenY = m
+	}
+	parallel.For(0, blocks(lenY, blockSize), func(b int) {
+		i := b * blockSize
+		nb := min(blockSize, lenY-i)
+		iy := i * incY
+		if incY < 0 {
+			iy = (i + nb - lenY) * incY
+		}
+		if tA == blas.NoTrans {
+			Implementation{}.Sgemv(tA, nb, n, alpha, a[i*lda:], lda, x, incX, beta, y[iy:], incY)
+			return
+		}
+		Implementation{}.Sgemv(tA, m, nb, alpha, a[i:], lda, x, incX, beta, y[iy:], incY)
+	})
+}
@@ -20,9 +20,13 @@ const (
 	blockSize   = 64 // b x b matrix
 	minParBlock = 4  // minimum number of blocks needed to go parallel
 	buffMul     = 4  // how big is the buffer relative to the number of workers
+
+	// minParWork is the minimum number of multiply-add operations needed by
+	// the Level 2 and Level 3 routines other than [SD]gemm to go parallel.
+	minParWork = 1 << 18
 )
 
-// subMul is a common type shared by [SD]gemm.
+// subMul is a common type shared by [SD]gemm and parallelTriangle.
 type subMul struct {
 	i, j int // index of block
 }
@@ -5,8 +5,6 @@
 package gonum
 
 import (
-	"math"
-
 	"gonum.org/v1/gonum/blas"
 	"gonum.org/v1/gonum/internal/asm/c128"
 )
@@ -65,58 +63,10 @@ func (Implementation) Dznrm2(n int, x []complex128, incX int) float64 {
 	if (n-1)*incX >= len(x) {
 		panic(shortX)
 	}
-	var (
-		scale float64
-		ssq   float64 = 1
-	)
 	if incX == 1 {
-		for _, v := range x[:n] {
-			re, im := math.Abs(real(v)), math.Abs(imag(v))
-			if re != 0 {
-				if re > scale {
-					ssq = 1 + ssq*(scale/re)*(scale/re)
-					scale = re
-				} else {
-					ssq += (re / scale) * (re / scale)
-				}
-			}
-			if im != 0 {
-				if im > scale {
-					ssq = 1 + ssq*(scale/im)*(scale/im)
-					scale = im
-				} else {
-					ssq += (im / scale) * (im / scale)
-				}
-			}
-		}
-		if math.IsInf(scale, 1) {
-			return math.Inf(1)
-		}
-		return scale * math.Sqrt(ssq)
-	}
-	for ix := 0; ix < n*incX; ix += incX {
-		re, im := math.Abs(real(x[ix])), math.Abs(imag(x[ix]))
-		if re != 0 {
-			if re > scale {
-				ssq = 1 + ssq*(scale/re)*(scale/re)
-				scale = re
-			} else {
-				ssq += (re / scale) * (re / scale)
-			}
-		}
-		if im != 0 {
-			if im > scale {
-				ssq = 1 + ssq*(scale/im)*(scale/im)
-				scale = im
-			} else {
-				ssq += (im / scale) * (im / scale)
-			}
-		}
-	}
-	if math.IsInf(scale, 1) {
-		return math.Inf(1)
+		return c128.L2NormUnitary(x[:n])
 	}
-	return scale * math.Sqrt(ssq)
+	return c128.L2NormInc(x, uintptr(n), uintptr(incX))
 }
 
 // Izamax returns the index of the first element of x having largest |Re(·)|+|Im(·)|.
@@ -14,6 +14,10 @@ func TestDgemv(t *testing.T) {
 	testblas.DgemvTest(t, impl)
 }
 
+func TestDgemvBatch(t *testing.T) {
+	testblas.DgemvBatchTest(t, impl)
+}
+
 func TestDger(t *testing.T) {
 	testblas.DgerTest(t, impl)
 }
@@ -79,6 +79,14 @@ func (Implementation) Strsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		}
 		return
 	}
+
+	if useParallelSide(s, m, n) {
+		parallelSide(s, m, n, func(i, j, mb, nb int) {
+			Implementation{}.Strsm(s, ul, tA, d, mb, nb, alpha, a, lda, b[i*ldb+j:], ldb)
+		})
+		return
+	}
+
 	nonUnit := d == blas.NonUnit
 	if s == blas.Left {
 		if tA == blas.NoTrans {
@@ -292,6 +300,13 @@ func (Implementation) Ssymm(s blas.Side, ul blas.Uplo, m, n int, alpha float32,
 		return
 	}
 
+	if useParallelSide(s, m, n) {
+		parallelSide(s, m, n, func(i, j, mb, nb int) {
+			Implementation{}.Ssymm(s, ul, mb, nb, alpha, a, lda, b[i*ldb+j:], ldb, beta, c[i*ldc+j:], ldc)
+		})
+		return
+	}
+
 	isUpper := ul == blas.Upper
 	if s == blas.Left {
 		for i := 0; i < m; i++ {
@@ -441,6 +456,10 @@ func (Implementation) Ssyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha flo
 		}
 		return
 	}
+	if useParallel(n, n*n*k/2) {
+		ssyrkParallel(ul, tA, n, k, alpha, a, lda, beta, c, ldc)
+		return
+	}
 	if tA == blas.NoTrans {
 		if ul == blas.Upper {
 			for i := 0; i < n; i++ {
@@ -599,6 +618,10 @@ func (Implementation) Ssyr2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha fl
 		}
 		return
 	}
+	if useParallel(n, n*n*k) {
+		ssyr2kParallel(ul, tA, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+		return
+	}
 	if tA == blas.NoTrans {
 		if ul == blas.Upper {
 			for i := 0; i < n; i++ {
@@ -738,6 +761,13 @@ func (Implementation) Strmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		return
 	}
 
+	if useParallelSide(s, m, n) {
+		parallelSide(s, m, n, func(i, j, mb, nb int) {
+			Implementation{}.Strmm(s, ul, tA, d, mb, nb, alpha, a, lda, b[i*ldb+j:], ldb)
+		})
+		return
+	}
+
 	nonUnit := d == blas.NonUnit
 	if s == blas.Left {
 		if tA == blas.NoTrans {
@@ -874,3 +904,53 @@ func (Implementation) Strmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		}
 	}
 }
+
+// ssyrkParallel performs the symmetric rank-k operation by partitioning the
+// ul triangle of C into blocks that are updated concurrently. The diagonal
+// blocks are symmetric rank-k updates and the off-diagonal blocks are general
+// matrix multiplications.
+func ssyrkParallel(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float32, a []float32, lda int, beta float32, c []float32, ldc int) {
+	parallelTriangle(ul, n, func(i, j, ib, jb int) {
+		impl := Implementation{}
+		ci := c[i*ldc+j:]
+		if tA == blas.NoTrans {
+			if i == j {
+				impl.Ssyrk(ul, tA, ib, k, alpha, a[i*lda:], lda, beta, ci, ldc)
+				return
+			}
+			impl.Sgemm(blas.NoTrans, blas.Trans, ib, jb, k, alpha, a[i*lda:], lda, a[j*lda:], lda, beta, ci, ldc)
+			return
+		}
+		if i == j {
+			impl.Ssyrk(ul, tA, ib, k, alpha, a[i:], lda, beta, ci, ldc)
+			return
+		}
+		impl.Sgemm(blas.Trans, blas.NoTrans, ib, jb, k, alpha, a[i:], lda, a[j:], lda, beta, ci, ldc)
+	})
+}
+
+// ssyr2kParallel performs the symmetric rank 2k operation by partitioning the
+// ul triangle of C into blocks that are updated concurrently. The diagonal
+// blocks are symmetric rank 2k updates and the off-diagonal blocks are sums of
+// two general matrix multiplications.
+func ssyr2kParallel(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	parallelTriangle(ul, n, func(i, j, ib, jb int) {
+		impl := Implementation{}
+		ci := c[i*ldc+j:]
+		if tA == blas.NoTrans {
+			if i == j {
+				impl.Ssyr2k(ul, tA, ib, k, alpha, a[i*lda:], lda, b[i*ldb:], ldb, beta, ci, ldc)
+				return
+			}
+			impl.Sgemm(blas.NoTrans, blas.Trans, ib, jb, k, alpha, a[i*lda:], lda, b[j*ldb:], ldb, beta, ci, ldc)
+			impl.Sgemm(blas.NoTrans, blas.Trans, ib, jb, k, alpha, b[i*ldb:], ldb, a[j*lda:], lda, 1, ci, ldc)
+			return
+		}
+		if i == j {
+			impl.Ssyr2k(ul, tA, ib, k, alpha, a[i:], lda, b[i:], ldb, beta, ci, ldc)
+			return
+		}
+		impl.Sgemm(blas.Trans, blas.NoTrans, ib, jb, k, alpha, a[i:], lda, b[j:], ldb, beta, ci, ldc)
+		impl.Sgemm(blas.Trans, blas.NoTrans, ib, jb, k, alpha, b[i:], ldb, a[j:], lda, 1, ci, ldc)
+	})
+}
@@ -75,6 +75,14 @@ func (Implementation) Dtrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		}
 		return
 	}
+
+	if useParallelSide(s, m, n) {
+		parallelSide(s, m, n, func(i, j, mb, nb int) {
+			Implementation{}.Dtrsm(s, ul, tA, d, mb, nb, alpha, a, lda, b[i*ldb+j:], ldb)
+		})
+		return
+	}
+
 	nonUnit := d == blas.NonUnit
 	if s == blas.Left {
 		if tA == blas.NoTrans {
@@ -286,6 +294,13 @@ func (Implementation) Dsymm(s blas.Side, ul blas.Uplo, m, n int, alpha float64,
 		return
 	}
 
+	if useParallelSide(s, m, n) {
+		parallelSide(s, m, n, func(i, j, mb, nb int) {
+			Implementation{}.Dsymm(s, ul, mb, nb, alpha, a, lda, b[i*ldb+j:], ldb, beta, c[i*ldc+j:], ldc)
+		})
+		return
+	}
+
 	isUpper := ul == blas.Upper
 	if s == blas.Left {
 		for i := 0; i < m; i++ {
@@ -433,6 +448,10 @@ func (Implementation) Dsyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha flo
 		}
 		return
 	}
+	if useParallel(n, n*n*k/2) {
+		dsyrkParallel(ul, tA, n, k, alpha, a, lda, beta, c, ldc)
+		return
+	}
 	if tA == blas.NoTrans {
 		if ul == blas.Upper {
 			for i := 0; i < n; i++ {
@@ -589,6 +608,10 @@ func (Implementation) Dsyr2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha fl
 		}
 		return
 	}
+	if useParallel(n, n*n*k) {
+		dsyr2kParallel(ul, tA, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+		return
+	}
 	if tA == blas.NoTrans {
 		if ul == blas.Upper {
 			for i := 0; i < n; i++ {
@@ -726,6 +749,13 @@ func (Implementation) Dtrmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		return
 	}
 
+	if useParallelSide(s, m, n) {
+		parallelSide(s, m, n, func(i, j, mb, nb int) {
+			Implementation{}.Dtrmm(s, ul, tA, d, mb, nb, alpha, a, lda, b[i*ldb+j:], ldb)
+		})
+		return
+	}
+
 	nonUnit := d == blas.NonUnit
 	if s == blas.Left {
 		if tA == blas.NoTrans {
@@ -862,3 +892,53 @@ func (Implementation) Dtrmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		}
 	}
 }
+
+// dsyrkParallel performs the symmetric rank-k operation by partitioning the
+// ul triangle of C into blocks that are updated concurrently. The diagonal
+// blocks are symmetric rank-k updates and the off-diagonal blocks are general
+// matrix multiplications.
+func dsyrkParallel(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float64, a []float64, lda int, beta float64, c []float64, ldc int) {
+	parallelTriangle(ul, n, func(i, j, ib, jb int) {
+		impl := Implementation{}
+		ci := c[i*ldc+j:]
+		if tA == blas.NoTrans {
+			if i == j {
+				impl.Dsyrk(ul, tA, ib, k, alpha, a[i*lda:], lda, beta, ci, ldc)
+				return
+			}
+			impl.Dgemm(blas.NoTrans, blas.Trans, ib, jb, k, alpha, a[i*lda:], lda, a[j*lda:], lda, beta, ci, ldc)
+			return
+		}
+		if i == j {
+			impl.Dsyrk(ul, tA, ib, k, alpha, a[i:], lda, beta, ci, ldc)
+			return
+		}
+		impl.Dgemm(blas.Trans, blas.NoTrans, ib, jb, k, alpha, a[i:], lda, a[j:], lda, beta, ci, ldc)
+	})
+}
+
+// dsyr2kParallel performs the symmetric rank 2k operation by partitioning the
+// ul triangle of C into blocks that are updated concurrently. The diagonal
+// blocks are symmetric rank 2k updates and the off-diagonal blocks are sums of
+// two general matrix multiplications.
+func dsyr2kParallel(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	parallelTriangle(ul, n, func(i, j, ib, jb int) {
+		impl := Implementation{}
+		ci := c[i*ldc+j:]
+		if tA == blas.NoTrans {
+			if i == j {
+				impl.Dsyr2k(ul, tA, ib, k, alpha, a[i*lda:], lda, b[i*ldb:], ldb, beta, ci, ldc)
+				return
+			}
+			impl.Dgemm(blas.NoTrans, blas.Trans, ib, jb, k, alpha, a[i*lda:], lda, b[j*ldb:], ldb, beta, ci, ldc)
+			impl.Dgemm(blas.NoTrans, blas.Trans, ib, jb, k, alpha, b[i*ldb:], ldb, a[j*lda:], lda, 1, ci, ldc)
+			return
+		}
+		if i == j {
+			impl.Dsyr2k(ul, tA, ib, k, alpha, a[i:], lda, b[i:], ldb, beta, ci, ldc)
+			return
+		}
+		impl.Dgemm(blas.Trans, blas.NoTrans, ib, jb, k, alpha, a[i:], lda, b[j:], ldb, beta, ci, ldc)
+		impl.Dgemm(blas.Trans, blas.NoTrans, ib, jb, k, alpha, b[i:], ldb, a[j:], lda, 1, ci, ldc)
+	})
+}
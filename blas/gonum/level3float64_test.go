@@ -14,6 +14,10 @@ func TestDgemm(t *testing.T) {
 	testblas.TestDgemm(t, impl)
 }
 
+func TestDgemmBatch(t *testing.T) {
+	testblas.DgemmBatchTest(t, impl)
+}
+
 func TestDsymm(t *testing.T) {
 	testblas.DsymmTest(t, impl)
 }
@@ -0,0 +1,79 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"runtime"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/internal/parallel"
+)
+
+// useParallel returns whether a problem that needs work multiply-add
+// operations should be computed concurrently by partitioning a dimension of
+// length dim into blocks.
+func useParallel(dim, work int) bool {
+	return runtime.GOMAXPROCS(0) > 1 && blocks(dim, blockSize) >= minParBlock && work >= minParWork
+}
+
+// useParallelSide returns whether the m×n matrix B of a Level 3 operation
+// that multiplies B from side s by a triangular or symmetric matrix should be
+// updated concurrently by parallelSide.
+func useParallelSide(s blas.Side, m, n int) bool {
+	if s == blas.Left {
+		return useParallel(n, m*m*n)
+	}
+	return useParallel(m, n*n*m)
+}
+
+// parallelSide partitions the m×n matrix B of a Level 3 operation that
+// multiplies B from side s by a triangular or symmetric matrix into blocks
+// that can be updated independently. These are blocks of columns if s is
+// blas.Left and blocks of rows if s is blas.Right. parallelSide calls
+// fn(i, j, mb, nb) concurrently for each mb×nb block starting at row i and
+// column j of B.
+func parallelSide(s blas.Side, m, n int, fn func(i, j, mb, nb int)) {
+	dim := n
+	if s == blas.Right {
+		dim = m
+	}
+	// All blocks need the same amount of work, so there is no need for more
+	// blocks than workers, and narrow blocks make the serial updates less
+	// efficient. The blocks must still be small enough for fn to update them
+	// serially.
+	bs := min(max(blockSize, blocks(dim, runtime.GOMAXPROCS(0))), (minParBlock-1)*blockSize)
+	parallel.For(0, blocks(dim, bs), func(b int) {
+		k := b * bs
+		if s == blas.Left {
+			fn(0, k, m, min(bs, n-k))
+			return
+		}
+		fn(k, 0, min(bs, m-k), n)
+	})
+}
+
+// parallelTriangle partitions the ul triangle of an n×n matrix into square
+// blocks and calls fn(i, j, ib, jb) concurrently for each ib×jb block starting
+// at row i and column j. Only the ul triangle of the diagonal blocks, for
+// which i == j, belongs to the triangle of the matrix.
+func parallelTriangle(ul blas.Uplo, n int, fn func(i, j, ib, jb int)) {
+	nb := blocks(n, blockSize)
+	tiles := make([]subMul, 0, nb*(nb+1)/2)
+	for i := 0; i < n; i += blockSize {
+		if ul == blas.Upper {
+			for j := i; j < n; j += blockSize {
+				tiles = append(tiles, subMul{i: i, j: j})
+			}
+		} else {
+			for j := 0; j <= i; j += blockSize {
+				tiles = append(tiles, subMul{i: i, j: j})
+			}
+		}
+	}
+	parallel.For(0, len(tiles), func(b int) {
+		t := tiles[b]
+		fn(t.i, t.j, min(blockSize, n-t.i), min(blockSize, n-t.j))
+	})
+}
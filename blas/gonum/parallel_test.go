@@ -0,0 +1,307 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/floats"
+)
+
+// parallelSideDims are the dimensions of the m×n matrix B used to test the
+// routines that are parallelized by parallelSide. The first is computed
+// concurrently only for blas.Left, the second only for blas.Right and the last
+// for both.
+var parallelSideDims = [][2]int{
+	{blockSize + 7, blockSize*minParBlock + 3},
+	{blockSize*minParBlock + 3, blockSize + 7},
+	{blockSize * minParBlock, blockSize * minParBlock},
+}
+
+// triangular returns a well-conditioned n×n triangular matrix with the given
+// stride. Both triangles are filled so that the matrix can be used with
+// either blas.Upper or blas.Lower.
+func triangular(n, stride int, rnd *rand.Rand) []float64 {
+	a := randmat(n, n, stride, rnd)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			a[i*stride+j] /= float64(n)
+		}
+		a[i*stride+i] = 1 + rnd.Float64()
+	}
+	return a
+}
+
+func TestDtrsmParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	rnd := rand.New(rand.NewSource(1))
+	impl := Implementation{}
+	for _, dims := range parallelSideDims {
+		m, n := dims[0], dims[1]
+		for _, s := range []blas.Side{blas.Left, blas.Right} {
+			k := n
+			if s == blas.Left {
+				k = m
+			}
+			lda := k + 3
+			a := triangular(k, lda, rnd)
+			ldb := n + 5
+			for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+					for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+						name := fmt.Sprintf("m=%v,n=%v,s=%v,ul=%v,tA=%v,d=%v", m, n, s, ul, tA, d)
+						b := randmat(m, n, ldb, rnd)
+						want := make([]float64, len(b))
+						copy(want, b)
+						if s == blas.Left {
+							for j := 0; j < n; j++ {
+								impl.Dtrsm(s, ul, tA, d, m, 1, 2.5, a, lda, want[j:], ldb)
+							}
+						} else {
+							for i := 0; i < m; i++ {
+								impl.Dtrsm(s, ul, tA, d, 1, n, 2.5, a, lda, want[i*ldb:], ldb)
+							}
+						}
+						impl.Dtrsm(s, ul, tA, d, m, n, 2.5, a, lda, b, ldb)
+						if !floats.EqualApprox(b, want, 1e-12) {
+							t.Errorf("%v: unexpected result", name)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDtrmmParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	rnd := rand.New(rand.NewSource(1))
+	impl := Implementation{}
+	for _, dims := range parallelSideDims {
+		m, n := dims[0], dims[1]
+		for _, s := range []blas.Side{blas.Left, blas.Right} {
+			k := n
+			if s == blas.Left {
+				k = m
+			}
+			lda := k + 3
+			a := randmat(k, k, lda, rnd)
+			ldb := n + 5
+			for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+					for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+						name := fmt.Sprintf("m=%v,n=%v,s=%v,ul=%v,tA=%v,d=%v", m, n, s, ul, tA, d)
+						b := randmat(m, n, ldb, rnd)
+						want := make([]float64, len(b))
+						copy(want, b)
+						if s == blas.Left {
+							for j := 0; j < n; j++ {
+								impl.Dtrmm(s, ul, tA, d, m, 1, 2.5, a, lda, want[j:], ldb)
+							}
+						} else {
+							for i := 0; i < m; i++ {
+								impl.Dtrmm(s, ul, tA, d, 1, n, 2.5, a, lda, want[i*ldb:], ldb)
+							}
+						}
+						impl.Dtrmm(s, ul, tA, d, m, n, 2.5, a, lda, b, ldb)
+						if !floats.EqualApprox(b, want, 1e-12) {
+							t.Errorf("%v: unexpected result", name)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDsymmParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	rnd := rand.New(rand.NewSource(1))
+	impl := Implementation{}
+	for _, dims := range parallelSideDims {
+		m, n := dims[0], dims[1]
+		for _, s := range []blas.Side{blas.Left, blas.Right} {
+			k := n
+			if s == blas.Left {
+				k = m
+			}
+			lda := k + 3
+			a := randmat(k, k, lda, rnd)
+			ldb := n + 5
+			b := randmat(m, n, ldb, rnd)
+			ldc := n + 2
+			for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, beta := range []float64{0, 1, -0.5} {
+					name := fmt.Sprintf("m=%v,n=%v,s=%v,ul=%v,beta=%v", m, n, s, ul, beta)
+					c := randmat(m, n, ldc, rnd)
+					want := make([]float64, len(c))
+					copy(want, c)
+					if s == blas.Left {
+						for j := 0; j < n; j++ {
+							impl.Dsymm(s, ul, m, 1, 2.5, a, lda, b[j:], ldb, beta, want[j:], ldc)
+						}
+					} else {
+						for i := 0; i < m; i++ {
+							impl.Dsymm(s, ul, 1, n, 2.5, a, lda, b[i*ldb:], ldb, beta, want[i*ldc:], ldc)
+						}
+					}
+					impl.Dsymm(s, ul, m, n, 2.5, a, lda, b, ldb, beta, c, ldc)
+					if !floats.EqualApprox(c, want, 1e-12) {
+						t.Errorf("%v: unexpected result", name)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDsyrkParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{blockSize * minParBlock, blockSize*minParBlock + 5} {
+		for _, k := range []int{blockSize / 2, blockSize + 3} {
+			if !useParallel(n, n*n*k/2) {
+				t.Fatalf("n=%v,k=%v: unexpected serial computation", n, k)
+			}
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				row, col := k, n
+				if tA == blas.NoTrans {
+					row, col = n, k
+				}
+				lda := col + 3
+				a := randmat(row, col, lda, rnd)
+				for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+					for _, beta := range []float64{0, 1, -0.5} {
+						name := fmt.Sprintf("n=%v,k=%v,tA=%v,ul=%v,beta=%v", n, k, tA, ul, beta)
+						testSyrkParallel(t, name, ul, tA, n, k, a, lda, nil, 0, beta, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDsyr2kParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{blockSize * minParBlock, blockSize*minParBlock + 5} {
+		for _, k := range []int{blockSize / 2, blockSize + 3} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				row, col := k, n
+				if tA == blas.NoTrans {
+					row, col = n, k
+				}
+				lda := col + 3
+				a := randmat(row, col, lda, rnd)
+				ldb := col + 1
+				b := randmat(row, col, ldb, rnd)
+				for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+					for _, beta := range []float64{0, 1, -0.5} {
+						name := fmt.Sprintf("n=%v,k=%v,tA=%v,ul=%v,beta=%v", n, k, tA, ul, beta)
+						testSyrkParallel(t, name, ul, tA, n, k, a, lda, b, ldb, beta, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+// testSyrkParallel tests Dsyrk if b is nil and Dsyr2k otherwise by comparing
+// the result with that of Dgemm.
+func testSyrkParallel(t *testing.T, name string, ul blas.Uplo, tA blas.Transpose, n, k int, a []float64, lda int, b []float64, ldb int, beta float64, rnd *rand.Rand) {
+	const alpha = 2.5
+
+	impl := Implementation{}
+	ldc := n + 2
+	c := randmat(n, n, ldc, rnd)
+	orig := make([]float64, len(c))
+	copy(orig, c)
+	want := make([]float64, len(c))
+	copy(want, c)
+	tB := blas.Trans
+	if tA != blas.NoTrans {
+		tB = blas.NoTrans
+	}
+	if b == nil {
+		impl.Dgemm(tA, tB, n, n, k, alpha, a, lda, a, lda, beta, want, ldc)
+		impl.Dsyrk(ul, tA, n, k, alpha, a, lda, beta, c, ldc)
+	} else {
+		impl.Dgemm(tA, tB, n, n, k, alpha, a, lda, b, ldb, beta, want, ldc)
+		impl.Dgemm(tA, tB, n, n, k, alpha, b, ldb, a, lda, 1, want, ldc)
+		impl.Dsyr2k(ul, tA, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	}
+
+	// Only the ul triangle of C is expected to be updated.
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if ul == blas.Upper && j < i || ul == blas.Lower && j > i {
+				want[i*ldc+j] = orig[i*ldc+j]
+			}
+		}
+	}
+	if !floats.EqualApprox(c, want, 1e-12) {
+		t.Errorf("%v: unexpected result", name)
+	}
+}
+
+func TestDgemvParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	rnd := rand.New(rand.NewSource(1))
+	impl := Implementation{}
+	for _, dims := range [][2]int{
+		{8 * blockSize, 8 * blockSize},
+		{8*blockSize + 7, 9 * blockSize},
+		{9 * blockSize, 8*blockSize + 7},
+	} {
+		m, n := dims[0], dims[1]
+		lda := n + 3
+		a := randmat(m, n, lda, rnd)
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			lenX, lenY := n, m
+			if tA != blas.NoTrans {
+				lenX, lenY = m, n
+			}
+			if !useParallel(lenY, m*n) {
+				t.Fatalf("m=%v,n=%v: unexpected serial computation", m, n)
+			}
+			for _, inc := range [][2]int{{1, 1}, {2, 3}, {-2, 1}, {1, -3}} {
+				incX, incY := inc[0], inc[1]
+				x := randmat(1, (lenX-1)*abs(incX)+1, 0, rnd)
+				for _, beta := range []float64{0, 1, -0.5} {
+					name := fmt.Sprintf("m=%v,n=%v,tA=%v,incX=%v,incY=%v,beta=%v", m, n, tA, incX, incY, beta)
+					y := randmat(1, (lenY-1)*abs(incY)+1, 0, rnd)
+					want := make([]float64, len(y))
+					copy(want, y)
+					for i := 0; i < lenY; i++ {
+						iy := i * incY
+						if incY < 0 {
+							iy = (i - lenY + 1) * incY
+						}
+						if tA == blas.NoTrans {
+							impl.Dgemv(tA, 1, n, 2.5, a[i*lda:], lda, x, incX, beta, want[iy:], 1)
+						} else {
+							impl.Dgemv(tA, m, 1, 2.5, a[i:], lda, x, incX, beta, want[iy:], 1)
+						}
+					}
+					impl.Dgemv(tA, m, n, 2.5, a, lda, x, incX, beta, y, incY)
+					if !floats.EqualApprox(y, want, 1e-12) {
+						t.Errorf("%v: unexpected result", name)
+					}
+				}
+			}
+		}
+	}
+}
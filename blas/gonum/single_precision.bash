@@ -51,6 +51,8 @@ cat level1cmplx128.go \
 | gofmt -r 'c128.DotcUnitary -> c64.DotcUnitary' \
 | gofmt -r 'c128.DotuInc -> c64.DotuInc' \
 | gofmt -r 'c128.DotuUnitary -> c64.DotuUnitary' \
+| gofmt -r 'c128.L2NormInc -> c64.L2NormInc' \
+| gofmt -r 'c128.L2NormUnitary -> c64.L2NormUnitary' \
 | gofmt -r 'c128.ScalInc -> c64.ScalInc' \
 | gofmt -r 'c128.ScalUnitary -> c64.ScalUnitary' \
 | gofmt -r 'dcabs1 -> scabs1' \
@@ -165,6 +167,15 @@ cat level3float64.go \
 \
 | gofmt -r 'float64 -> float32' \
 \
+| gofmt -r 'dsyrkParallel -> ssyrkParallel' \
+| gofmt -r 'dsyr2kParallel -> ssyr2kParallel' \
+| gofmt -r 'x.Dgemm -> x.Sgemm' \
+| gofmt -r 'x.Dsymm -> x.Ssymm' \
+| gofmt -r 'x.Dsyrk -> x.Ssyrk' \
+| gofmt -r 'x.Dsyr2k -> x.Ssyr2k' \
+| gofmt -r 'x.Dtrmm -> x.Strmm' \
+| gofmt -r 'x.Dtrsm -> x.Strsm' \
+\
 | gofmt -r 'f64.AxpyUnitaryTo -> f32.AxpyUnitaryTo' \
 | gofmt -r 'f64.AxpyUnitary -> f32.AxpyUnitary' \
 | gofmt -r 'f64.DotUnitary -> f32.DotUnitary' \
@@ -172,6 +183,7 @@ cat level3float64.go \
 \
 | sed -e "s_^\(func (Implementation) \)D\(.*\)\$_$WARNINGF32\1S\2_" \
       -e 's_^// D_// S_' \
+      -e 's_^// d_// s_' \
       -e 's_"gonum.org/v1/gonum/internal/asm/f64"_"gonum.org/v1/gonum/internal/asm/f32"_' \
 >> level3float32.go
 
@@ -188,10 +200,18 @@ cat dgemm.go \
 | gofmt -r 'dgemmSerialTransNot -> sgemmSerialTransNot' \
 | gofmt -r 'dgemmSerialNotTrans -> sgemmSerialNotTrans' \
 | gofmt -r 'dgemmSerialTransTrans -> sgemmSerialTransTrans' \
+| gofmt -r 'dgemmSerialPacked -> sgemmSerialPacked' \
+| gofmt -r 'dgemmPackPool -> sgemmPackPool' \
 \
 | gofmt -r 'f64.AxpyInc -> f32.AxpyInc' \
 | gofmt -r 'f64.AxpyUnitary -> f32.AxpyUnitary' \
 | gofmt -r 'f64.DotUnitary -> f32.DotUnitary' \
+| gofmt -r 'f64.GemmKernel -> f32.GemmKernel' \
+| gofmt -r 'f64.GemmMR -> f32.GemmMR' \
+| gofmt -r 'f64.GemmNR -> f32.GemmNR' \
+| gofmt -r 'f64.GemmPackA -> f32.GemmPackA' \
+| gofmt -r 'f64.GemmPackB -> f32.GemmPackB' \
+| gofmt -r 'f64.HasGemmKernel -> f32.HasGemmKernel' \
 \
 | sed -e "s_^\(func (Implementation) \)D\(.*\)\$_$WARNINGF32\1S\2_" \
       -e 's_^// D_// S_' \
@@ -199,6 +219,29 @@ cat dgemm.go \
       -e 's_"gonum.org/v1/gonum/internal/asm/f64"_"gonum.org/v1/gonum/internal/asm/f32"_' \
 >> sgemm.go
 
+echo Generating batchfloat32.go
+echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum”; DO NOT EDIT.\n' > batchfloat32.go
+cat batchfloat64.go \
+| gofmt -r 'blas.Float64Batch -> blas.Float32Batch' \
+\
+| gofmt -r 'float64 -> float32' \
+\
+| gofmt -r 'dgemmBatchOne -> sgemmBatchOne' \
+| gofmt -r 'dgemmSerial -> sgemmSerial' \
+| gofmt -r 'dgemmSmall -> sgemmSmall' \
+| gofmt -r 'dgemvBatchOne -> sgemvBatchOne' \
+\
+| gofmt -r 'f64.AxpyInc -> f32.AxpyInc' \
+| gofmt -r 'f64.AxpyUnitary -> f32.AxpyUnitary' \
+| gofmt -r 'f64.DotInc -> f32.DotInc' \
+| gofmt -r 'f64.DotUnitary -> f32.DotUnitary' \
+\
+| sed -e "s_^\(func (Implementation) \)D\(.*\)\$_$WARNINGF32\1S\2_" \
+      -e 's_^// D_// S_' \
+      -e 's_^// d_// s_' \
+      -e 's_"gonum.org/v1/gonum/internal/asm/f64"_"gonum.org/v1/gonum/internal/asm/f32"_' \
+>> batchfloat32.go
+
 echo Generating level3cmplx64.go
 echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum”; DO NOT EDIT.\n' > level3cmplx64.go
 cat level3cmplx128.go \
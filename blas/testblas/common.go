@@ -539,6 +539,16 @@ func zEqualApprox(a, b []complex128, tol float64) bool {
 	return true
 }
 
+// dRandomSlice returns a slice of length n with elements drawn from the
+// standard normal distribution.
+func dRandomSlice(n int, rnd *rand.Rand) []float64 {
+	s := make([]float64, n)
+	for i := range s {
+		s[i] = rnd.NormFloat64()
+	}
+	return s
+}
+
 // rndComplex128 returns a complex128 with random components.
 func rndComplex128(rnd *rand.Rand) complex128 {
 	return complex(rnd.NormFloat64(), rnd.NormFloat64())
@@ -0,0 +1,121 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/floats"
+)
+
+type DgemmBatcher interface {
+	Dgemmer
+	DgemmBatch(tA, tB blas.Transpose, m, n, k int, alpha float64, a [][]float64, lda int, b [][]float64, ldb int, beta float64, c [][]float64, ldc int)
+	DgemmStridedBatch(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda, strideA int, b []float64, ldb, strideB int, beta float64, c []float64, ldc, strideC, batchCount int)
+}
+
+// DgemmBatchTest tests the batched Dgemm routines by comparing their results
+// with those of Dgemm applied to each matrix of the batch.
+func DgemmBatchTest(t *testing.T, impl DgemmBatcher) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, dims := range [][3]int{
+				{0, 3, 2}, {3, 0, 2}, {3, 4, 0}, {1, 1, 1}, {3, 4, 5},
+				{8, 8, 8}, {16, 16, 16}, {17, 5, 20}, {40, 70, 30},
+			} {
+				for _, batch := range []int{0, 1, 7, 50} {
+					for _, ab := range [][2]float64{{1, 0}, {2.5, -1.5}, {0, 0.5}, {0, 1}} {
+						dgemmBatchTest(t, impl, rnd, tA, tB, dims[0], dims[1], dims[2], batch, ab[0], ab[1])
+					}
+				}
+			}
+		}
+	}
+
+	// Passing the same output matrix twice must panic.
+	a := make([]float64, 4)
+	c := make([]float64, 4)
+	if !panics(func() {
+		impl.DgemmBatch(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, [][]float64{a, a}, 2, [][]float64{a, a}, 2, 0, [][]float64{c, c}, 2)
+	}) {
+		t.Errorf("no panic for aliased c")
+	}
+}
+
+func dgemmBatchTest(t *testing.T, impl DgemmBatcher, rnd *rand.Rand, tA, tB blas.Transpose, m, n, k, batch int, alpha, beta float64) {
+	const tol = 1e-12
+
+	ar, ac := m, k
+	if tA != blas.NoTrans {
+		ar, ac = k, m
+	}
+	br, bc := k, n
+	if tB != blas.NoTrans {
+		br, bc = n, k
+	}
+	lda := max(1, ac) + 2
+	ldb := max(1, bc) + 3
+	ldc := max(1, n) + 1
+	sizeA := max(1, ar) * lda
+	sizeB := max(1, br) * ldb
+	sizeC := max(1, m) * ldc
+
+	name := fmt.Sprintf("tA=%v,tB=%v,m=%v,n=%v,k=%v,batch=%v,alpha=%v,beta=%v", transString(tA), transString(tB), m, n, k, batch, alpha, beta)
+
+	// Compute the reference result with Dgemm on contiguous copies.
+	aData := dRandomSlice(batch*sizeA, rnd)
+	bData := dRandomSlice(batch*sizeB, rnd)
+	cData := dRandomSlice(batch*sizeC, rnd)
+	want := make([]float64, len(cData))
+	copy(want, cData)
+	for i := 0; i < batch; i++ {
+		impl.Dgemm(tA, tB, m, n, k, alpha, aData[i*sizeA:], lda, bData[i*sizeB:], ldb, beta, want[i*sizeC:], ldc)
+	}
+
+	// Test the batch given as separate slices.
+	a := make([][]float64, batch)
+	b := make([][]float64, batch)
+	c := make([][]float64, batch)
+	for i := range c {
+		a[i] = aData[i*sizeA : (i+1)*sizeA]
+		b[i] = bData[i*sizeB : (i+1)*sizeB]
+		c[i] = make([]float64, sizeC)
+		copy(c[i], cData[i*sizeC:])
+	}
+	impl.DgemmBatch(tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	for i := range c {
+		if !floats.EqualApprox(c[i], want[i*sizeC:(i+1)*sizeC], tol) {
+			t.Errorf("%v: unexpected result of DgemmBatch for matrix %v", name, i)
+			break
+		}
+	}
+
+	// Test the strided batch.
+	got := make([]float64, len(cData))
+	copy(got, cData)
+	impl.DgemmStridedBatch(tA, tB, m, n, k, alpha, aData, lda, sizeA, bData, ldb, sizeB, beta, got, ldc, sizeC, batch)
+	if !floats.EqualApprox(got, want, tol) {
+		t.Errorf("%v: unexpected result of DgemmStridedBatch", name)
+	}
+
+	// Test the strided batch with a single matrix A for the whole batch.
+	if batch == 0 {
+		return
+	}
+	copy(want, cData)
+	for i := 0; i < batch; i++ {
+		impl.Dgemm(tA, tB, m, n, k, alpha, aData, lda, bData[i*sizeB:], ldb, beta, want[i*sizeC:], ldc)
+	}
+	copy(got, cData)
+	impl.DgemmStridedBatch(tA, tB, m, n, k, alpha, aData[:sizeA], lda, 0, bData, ldb, sizeB, beta, got, ldc, sizeC, batch)
+	if !floats.EqualApprox(got, want, tol) {
+		t.Errorf("%v: unexpected result of DgemmStridedBatch with zero stride of A", name)
+	}
+}
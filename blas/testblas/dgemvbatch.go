@@ -0,0 +1,113 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/floats"
+)
+
+type DgemvBatcher interface {
+	Dgemver
+	DgemvBatch(tA blas.Transpose, m, n int, alpha float64, a [][]float64, lda int, x [][]float64, incX int, beta float64, y [][]float64, incY int)
+	DgemvStridedBatch(tA blas.Transpose, m, n int, alpha float64, a []float64, lda, strideA int, x []float64, incX, strideX int, beta float64, y []float64, incY, strideY, batchCount int)
+}
+
+// DgemvBatchTest tests the batched Dgemv routines by comparing their results
+// with those of Dgemv applied to each matrix of the batch.
+func DgemvBatchTest(t *testing.T, impl DgemvBatcher) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, dims := range [][2]int{{0, 3}, {3, 0}, {1, 1}, {3, 4}, {8, 8}, {17, 5}, {60, 90}} {
+			for _, inc := range [][2]int{{1, 1}, {2, 3}, {-1, 1}, {1, -2}} {
+				for _, batch := range []int{0, 1, 7, 50} {
+					for _, ab := range [][2]float64{{1, 0}, {2.5, -1.5}, {0, 0.5}, {0, 1}} {
+						dgemvBatchTest(t, impl, rnd, tA, dims[0], dims[1], inc[0], inc[1], batch, ab[0], ab[1])
+					}
+				}
+			}
+		}
+	}
+
+	// Passing the same output vector twice must panic.
+	a := make([]float64, 9)
+	x := make([]float64, 3)
+	y := make([]float64, 3)
+	if !panics(func() {
+		impl.DgemvBatch(blas.NoTrans, 3, 3, 1, [][]float64{a, a}, 3, [][]float64{x, x}, 1, 0, [][]float64{y, y}, 1)
+	}) {
+		t.Errorf("no panic for aliased y")
+	}
+}
+
+func dgemvBatchTest(t *testing.T, impl DgemvBatcher, rnd *rand.Rand, tA blas.Transpose, m, n, incX, incY, batch int, alpha, beta float64) {
+	const tol = 1e-12
+
+	lenX, lenY := n, m
+	if tA != blas.NoTrans {
+		lenX, lenY = m, n
+	}
+	lda := max(1, n) + 2
+	sizeA := max(1, m) * lda
+	sizeX := max(1, (lenX-1)*abs(incX)+1)
+	sizeY := max(1, (lenY-1)*abs(incY)+1)
+
+	name := fmt.Sprintf("tA=%v,m=%v,n=%v,incX=%v,incY=%v,batch=%v,alpha=%v,beta=%v", transString(tA), m, n, incX, incY, batch, alpha, beta)
+
+	// Compute the reference result with Dgemv on contiguous copies.
+	aData := dRandomSlice(batch*sizeA, rnd)
+	xData := dRandomSlice(batch*sizeX, rnd)
+	yData := dRandomSlice(batch*sizeY, rnd)
+	want := make([]float64, len(yData))
+	copy(want, yData)
+	for i := 0; i < batch; i++ {
+		impl.Dgemv(tA, m, n, alpha, aData[i*sizeA:], lda, xData[i*sizeX:], incX, beta, want[i*sizeY:], incY)
+	}
+
+	// Test the batch given as separate slices.
+	a := make([][]float64, batch)
+	x := make([][]float64, batch)
+	y := make([][]float64, batch)
+	for i := range y {
+		a[i] = aData[i*sizeA : (i+1)*sizeA]
+		x[i] = xData[i*sizeX : (i+1)*sizeX]
+		y[i] = make([]float64, sizeY)
+		copy(y[i], yData[i*sizeY:])
+	}
+	impl.DgemvBatch(tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+	for i := range y {
+		if !floats.EqualApprox(y[i], want[i*sizeY:(i+1)*sizeY], tol) {
+			t.Errorf("%v: unexpected result of DgemvBatch for vector %v", name, i)
+			break
+		}
+	}
+
+	// Test the strided batch.
+	got := make([]float64, len(yData))
+	copy(got, yData)
+	impl.DgemvStridedBatch(tA, m, n, alpha, aData, lda, sizeA, xData, incX, sizeX, beta, got, incY, sizeY, batch)
+	if !floats.EqualApprox(got, want, tol) {
+		t.Errorf("%v: unexpected result of DgemvStridedBatch", name)
+	}
+
+	// Test the strided batch with a single matrix A for the whole batch.
+	if batch == 0 {
+		return
+	}
+	copy(want, yData)
+	for i := 0; i < batch; i++ {
+		impl.Dgemv(tA, m, n, alpha, aData, lda, xData[i*sizeX:], incX, beta, want[i*sizeY:], incY)
+	}
+	copy(got, yData)
+	impl.DgemvStridedBatch(tA, m, n, alpha, aData[:sizeA], lda, 0, xData, incX, sizeX, beta, got, incY, sizeY, batch)
+	if !floats.EqualApprox(got, want, tol) {
+		t.Errorf("%v: unexpected result of DgemvStridedBatch with zero stride of A", name)
+	}
+}
@@ -0,0 +1,1070 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmplxs
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"strconv"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+const (
+	EqTolerance = 1e-14
+	Small       = 10
+	Medium      = 1000
+	Large       = 100000
+	Huge        = 10000000
+)
+
+func areSlicesEqual(t *testing.T, truth, comp []complex128, str string) {
+	if !EqualApprox(comp, truth, EqTolerance) {
+		t.Errorf(str+". Expected %v, returned %v", truth, comp)
+	}
+}
+
+func areSlicesSame(t *testing.T, truth, comp []complex128, str string) {
+	ok := len(truth) == len(comp)
+	if ok {
+		for i, a := range truth {
+			if !sameOrClose(real(a), real(comp[i])) || !sameOrClose(imag(a), imag(comp[i])) {
+				ok = false
+				break
+			}
+		}
+	}
+	if !ok {
+		t.Errorf(str+". Expected %v, returned %v", truth, comp)
+	}
+}
+
+func sameOrClose(a, b float64) bool {
+	return same(a, b) || floats.EqualWithinAbsOrRel(a, b, EqTolerance, EqTolerance)
+}
+
+func panics(fun func()) (b bool) {
+	defer func() {
+		err := recover()
+		if err != nil {
+			b = true
+		}
+	}()
+	fun()
+	return
+}
+
+func TestAbs(t *testing.T) {
+	s := []complex128{3 + 4i, -1, 2i, 0}
+	dst := make([]float64, len(s))
+	Abs(dst, s)
+	if !floats.Equal(dst, []float64{5, 1, 2, 0}) {
+		t.Errorf("Abs returned %v", dst)
+	}
+	if !panics(func() { Abs(make([]float64, 2), s) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestAdd(t *testing.T) {
+	a := []complex128{1 + 1i, 2 - 1i, 3}
+	b := []complex128{4, 5i, -6 + 2i}
+	c := []complex128{7i, 8, 9}
+	truth := []complex128{5 + 8i, 10 + 4i, 6 + 2i}
+	n := make([]complex128, len(a))
+
+	Add(n, a)
+	Add(n, b)
+	Add(n, c)
+	areSlicesEqual(t, truth, n, "Wrong addition of slices new receiver")
+	Add(a, b)
+	Add(a, c)
+	areSlicesEqual(t, truth, n, "Wrong addition of slices for no new receiver")
+
+	// Test that it panics
+	if !panics(func() { Add(make([]complex128, 2), make([]complex128, 3)) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestAddTo(t *testing.T) {
+	a := []complex128{1 + 1i, 2, 3i}
+	b := []complex128{4, 5 - 1i, 6}
+	truth := []complex128{5 + 1i, 7 - 1i, 6 + 3i}
+	n1 := make([]complex128, len(a))
+
+	n2 := AddTo(n1, a, b)
+	areSlicesEqual(t, truth, n1, "Bad addition from mutator")
+	areSlicesEqual(t, truth, n2, "Bad addition from returned slice")
+
+	// Test that it panics
+	if !panics(func() { AddTo(make([]complex128, 2), make([]complex128, 3), make([]complex128, 3)) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+	if !panics(func() { AddTo(make([]complex128, 3), make([]complex128, 3), make([]complex128, 2)) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestAddConst(t *testing.T) {
+	s := []complex128{3, 4i, 1 + 1i, 7, 5}
+	c := 6 - 1i
+	truth := []complex128{9 - 1i, 6 + 3i, 7, 13 - 1i, 11 - 1i}
+	AddConst(c, s)
+	areSlicesEqual(t, truth, s, "Wrong addition of constant")
+}
+
+func TestAddScaled(t *testing.T) {
+	s := []complex128{3, 4i, 1 + 1i, 7, 5}
+	alpha := 2i
+	dst := []complex128{1, 2, 3, 4, 5}
+	ans := []complex128{1 + 6i, -6, 1 + 2i, 4 + 14i, 5 + 10i}
+	AddScaled(dst, alpha, s)
+	if !EqualApprox(dst, ans, EqTolerance) {
+		t.Errorf("Adding scaled did not match. Expected %v, returned %v", ans, dst)
+	}
+	short := []complex128{1}
+	if !panics(func() { AddScaled(dst, alpha, short) }) {
+		t.Errorf("Doesn't panic if s is smaller than dst")
+	}
+	if !panics(func() { AddScaled(short, alpha, s) }) {
+		t.Errorf("Doesn't panic if dst is smaller than s")
+	}
+}
+
+func TestAddScaledTo(t *testing.T) {
+	s := []complex128{3, 4i, 1 + 1i, 7, 5}
+	alpha := 2i
+	y := []complex128{1, 2, 3, 4, 5}
+	dst1 := make([]complex128, 5)
+	ans := []complex128{1 + 6i, -6, 1 + 2i, 4 + 14i, 5 + 10i}
+	dst2 := AddScaledTo(dst1, y, alpha, s)
+	if !EqualApprox(dst1, ans, EqTolerance) {
+		t.Errorf("AddScaledTo did not match for mutator")
+	}
+	if !EqualApprox(dst2, ans, EqTolerance) {
+		t.Errorf("AddScaledTo did not match for returned slice")
+	}
+	AddScaledTo(dst1, y, alpha, s)
+	if !EqualApprox(dst1, ans, EqTolerance) {
+		t.Errorf("Reusing dst did not match")
+	}
+	short := []complex128{1}
+	if !panics(func() { AddScaledTo(dst1, y, alpha, short) }) {
+		t.Errorf("Doesn't panic if s is smaller than dst")
+	}
+	if !panics(func() { AddScaledTo(short, y, alpha, s) }) {
+		t.Errorf("Doesn't panic if dst is smaller than s")
+	}
+	if !panics(func() { AddScaledTo(dst1, short, alpha, s) }) {
+		t.Errorf("Doesn't panic if y is smaller than dst")
+	}
+}
+
+func TestComplexRealImag(t *testing.T) {
+	re := []float64{1, -2, 0, 4}
+	im := []float64{0, 3, -1, 4}
+	s := Complex(make([]complex128, len(re)), re, im)
+	want := []complex128{1, -2 + 3i, -1i, 4 + 4i}
+	if !Equal(s, want) {
+		t.Errorf("Complex returned %v, want %v", s, want)
+	}
+	gotRe := Real(make([]float64, len(s)), s)
+	if !floats.Equal(gotRe, re) {
+		t.Errorf("Real returned %v, want %v", gotRe, re)
+	}
+	gotIm := Imag(make([]float64, len(s)), s)
+	if !floats.Equal(gotIm, im) {
+		t.Errorf("Imag returned %v, want %v", gotIm, im)
+	}
+	if !panics(func() { Complex(make([]complex128, 4), re, im[:3]) }) {
+		t.Errorf("Complex did not panic with length mismatch")
+	}
+	if !panics(func() { Complex(make([]complex128, 3), re, im) }) {
+		t.Errorf("Complex did not panic with length mismatch")
+	}
+	if !panics(func() { Real(make([]float64, 3), s) }) {
+		t.Errorf("Real did not panic with length mismatch")
+	}
+	if !panics(func() { Imag(make([]float64, 3), s) }) {
+		t.Errorf("Imag did not panic with length mismatch")
+	}
+}
+
+func TestConj(t *testing.T) {
+	s := []complex128{1 + 2i, -3i, 4, -5 + 6i}
+	want := []complex128{1 - 2i, 3i, 4, -5 - 6i}
+	dst := Conj(make([]complex128, len(s)), s)
+	if !Equal(dst, want) {
+		t.Errorf("Conj returned %v, want %v", dst, want)
+	}
+	if !panics(func() { Conj(make([]complex128, 2), s) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestCount(t *testing.T) {
+	s := []complex128{3, 4i, 1 + 1i, 7, 5}
+	f := func(v complex128) bool { return real(v) > 3.5 }
+	truth := 2
+	n := Count(f, s)
+	if n != truth {
+		t.Errorf("Wrong number of elements counted")
+	}
+}
+
+func TestCumProd(t *testing.T) {
+	s := []complex128{1i, 2, 1 + 1i, 4}
+	receiver := make([]complex128, len(s))
+	result := CumProd(receiver, s)
+	truth := []complex128{1i, 2i, -2 + 2i, -8 + 8i}
+	areSlicesEqual(t, truth, receiver, "Wrong cumprod mutated with new receiver")
+	areSlicesEqual(t, truth, result, "Wrong cumprod result with new receiver")
+	CumProd(receiver, s)
+	areSlicesEqual(t, truth, receiver, "Wrong cumprod returned with reused receiver")
+
+	// Test that it panics
+	if !panics(func() { CumProd(make([]complex128, 2), make([]complex128, 3)) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+
+	// Test empty CumProd
+	emptyReceiver := make([]complex128, 0)
+	truth = []complex128{}
+	CumProd(emptyReceiver, emptyReceiver)
+	areSlicesEqual(t, truth, emptyReceiver, "Wrong cumprod returned with empty receiver")
+}
+
+func TestCumSum(t *testing.T) {
+	s := []complex128{1i, 2, 1 + 1i, 4}
+	receiver := make([]complex128, len(s))
+	result := CumSum(receiver, s)
+	truth := []complex128{1i, 2 + 1i, 3 + 2i, 7 + 2i}
+	areSlicesEqual(t, truth, receiver, "Wrong cumsum mutated with new receiver")
+	areSlicesEqual(t, truth, result, "Wrong cumsum returned with new receiver")
+	CumSum(receiver, s)
+	areSlicesEqual(t, truth, receiver, "Wrong cumsum returned with reused receiver")
+
+	// Test that it panics
+	if !panics(func() { CumSum(make([]complex128, 2), make([]complex128, 3)) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+
+	// Test empty CumSum
+	emptyReceiver := make([]complex128, 0)
+	truth = []complex128{}
+	CumSum(emptyReceiver, emptyReceiver)
+	areSlicesEqual(t, truth, emptyReceiver, "Wrong cumsum returned with empty receiver")
+}
+
+func TestDistance(t *testing.T) {
+	norms := []float64{1, 2, 4, math.Inf(1)}
+	slices := []struct {
+		s []complex128
+		t []complex128
+	}{
+		{
+			nil,
+			nil,
+		},
+		{
+			[]complex128{8 + 1i, 9, 10i, -12},
+			[]complex128{8 + 1i, 9, 10i, -12},
+		},
+		{
+			[]complex128{1 + 2i, 2, 4i, 0, -4 - 1i},
+			[]complex128{6 - 1i, 2, 7i, 1 + 1i, -5},
+		},
+		{
+			[]complex128{8 + 3i, 3, 5, 6, 3},
+			[]complex128{5, 2 + 1i, 1e7i, 1e150, -2},
+		},
+	}
+
+	for j, test := range slices {
+		tmp := make([]complex128, len(test.s))
+		for i, L := range norms {
+			dist := Distance(test.s, test.t, L)
+			copy(tmp, test.s)
+			Sub(tmp, test.t)
+			norm := Norm(tmp, L)
+			if dist != norm { // Use equality because they should be identical.
+				t.Errorf("Distance does not match norm for case %v, %v. Expected %v, Found %v.", i, j, norm, dist)
+			}
+		}
+	}
+
+	if !panics(func() { Distance([]complex128{}, []complex128{1}, 1) }) {
+		t.Errorf("Did not panic with unequal lengths")
+	}
+}
+
+func TestDiv(t *testing.T) {
+	s1 := []complex128{5, 12i, 27 + 9i}
+	s2 := []complex128{1, 2i, 3}
+	ans := []complex128{5, 6, 9 + 3i}
+	Div(s1, s2)
+	if !EqualApprox(s1, ans, EqTolerance) {
+		t.Errorf("Div doesn't give correct answer")
+	}
+	s1short := []complex128{1}
+	if !panics(func() { Div(s1short, s2) }) {
+		t.Errorf("Did not panic with unequal lengths")
+	}
+	s2short := []complex128{1}
+	if !panics(func() { Div(s1, s2short) }) {
+		t.Errorf("Did not panic with unequal lengths")
+	}
+}
+
+func TestDivTo(t *testing.T) {
+	s1 := []complex128{5, 12i, 27 + 9i}
+	s1orig := []complex128{5, 12i, 27 + 9i}
+	s2 := []complex128{1, 2i, 3}
+	s2orig := []complex128{1, 2i, 3}
+	dst1 := make([]complex128, 3)
+	ans := []complex128{5, 6, 9 + 3i}
+	dst2 := DivTo(dst1, s1, s2)
+	if !EqualApprox(dst1, ans, EqTolerance) {
+		t.Errorf("DivTo doesn't give correct answer in mutated slice")
+	}
+	if !EqualApprox(dst2, ans, EqTolerance) {
+		t.Errorf("DivTo doesn't give correct answer in returned slice")
+	}
+	if !EqualApprox(s1, s1orig, EqTolerance) {
+		t.Errorf("S1 changes during DivTo")
+	}
+	if !EqualApprox(s2, s2orig, EqTolerance) {
+		t.Errorf("s2 changes during DivTo")
+	}
+	DivTo(dst1, s1, s2)
+	if !EqualApprox(dst1, ans, EqTolerance) {
+		t.Errorf("DivTo doesn't give correct answer reusing dst")
+	}
+	dstShort := []complex128{1}
+	if !panics(func() { DivTo(dstShort, s1, s2) }) {
+		t.Errorf("Did not panic with s1 wrong length")
+	}
+	s1short := []complex128{1}
+	if !panics(func() { DivTo(dst1, s1short, s2) }) {
+		t.Errorf("Did not panic with s1 wrong length")
+	}
+	s2short := []complex128{1}
+	if !panics(func() { DivTo(dst1, s1, s2short) }) {
+		t.Errorf("Did not panic with s2 wrong length")
+	}
+}
+
+func TestDot(t *testing.T) {
+	s1 := []complex128{1 + 1i, 2, 3i, 4}
+	s2 := []complex128{-3, 4i, 5, 7 - 1i}
+	truth := -3 + 3i + 8i - 15i + 28 - 4i
+	ans := Dot(s1, s2)
+	if ans != truth {
+		t.Errorf("Dot product computed incorrectly. Want %v, got %v", truth, ans)
+	}
+	if !EqualWithinAbsOrRel(Dot(s1, s1), complex(Norm(s1, 2)*Norm(s1, 2), 0), EqTolerance, EqTolerance) {
+		t.Errorf("Dot of a slice with itself is not its squared norm")
+	}
+
+	// Test that it panics
+	if !panics(func() { Dot(make([]complex128, 2), make([]complex128, 3)) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	s1 := []complex128{1 + 1i, 2, 3, 4}
+	s2 := []complex128{1 + 1i, 2, 3, 4}
+	if !Equal(s1, s2) {
+		t.Errorf("Equal slices returned as unequal")
+	}
+	s2 = []complex128{1 + 1i, 2, 3, 4 + 1e-14i}
+	if Equal(s1, s2) {
+		t.Errorf("Unequal slices returned as equal")
+	}
+	if Equal(s1, []complex128{}) {
+		t.Errorf("Unequal slice lengths returned as equal")
+	}
+}
+
+func TestEqualApprox(t *testing.T) {
+	s1 := []complex128{2, 5i, 8}
+	s2 := []complex128{2, 5i, 8}
+	if !EqualApprox(s1, s2, EqTolerance) {
+		t.Errorf("Equal slices returned as unequal for absolute")
+	}
+	s2 = []complex128{2, 5 * (1 + 1e-8) * 1i, 8}
+	if EqualApprox(s1, s2, 1e-10) {
+		t.Errorf("Unequal slices returned as equal")
+	}
+	if !EqualApprox(s1, s2, 1e-7) {
+		t.Errorf("Approximately equal slices returned as unequal")
+	}
+	if EqualApprox(s1, s2[:2], EqTolerance) {
+		t.Errorf("Unequal slice lengths returned as equal")
+	}
+	s3 := []complex128{1e12 + 1e12i}
+	s4 := []complex128{1e12 + (1e12+1)*1i}
+	if EqualApprox(s3, s4, 1e-14) {
+		t.Errorf("Relative difference greater than tolerance returned as equal")
+	}
+	if !EqualApprox(s3, s4, 1e-12) {
+		t.Errorf("Relative difference less than tolerance returned as unequal")
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	s1 := []complex128{1, 2, 3, 4}
+	s2 := []complex128{1i, 2i, 3i, 4i}
+	eq := func(x, y complex128) bool { return cmplx.Abs(x) == cmplx.Abs(y) }
+	if !EqualFunc(s1, s2, eq) {
+		t.Errorf("Equal slices returned as unequal")
+	}
+	s2 = []complex128{1i, 2i, 3i, 4 + 1i}
+	if EqualFunc(s1, s2, eq) {
+		t.Errorf("Unequal slices returned as equal")
+	}
+	if EqualFunc(s1, s2[:3], eq) {
+		t.Errorf("Unequal slice lengths returned as equal")
+	}
+}
+
+func TestEqualWithin(t *testing.T) {
+	inf := math.Inf(1)
+	nan := math.NaN()
+	for i, test := range []struct {
+		a, b   complex128
+		tol    float64
+		abs    bool
+		rel    bool
+		absRel bool
+	}{
+		{a: 1, b: 1, tol: 0, abs: true, rel: true, absRel: true},
+		{a: 1 + 1i, b: 1 + 1i, tol: 0, abs: true, rel: true, absRel: true},
+		{a: 1, b: 1i, tol: 1, abs: false, rel: false, absRel: false},
+		{a: 1, b: 1i, tol: 2, abs: true, rel: true, absRel: true},
+		{a: 1e10, b: 1e10 + 1i, tol: 1e-9, abs: false, rel: true, absRel: true},
+		{a: 1e-10, b: 2e-10i, tol: 1e-9, abs: true, rel: false, absRel: true},
+		{a: complex(inf, 0), b: complex(inf, 0), tol: 0, abs: true, rel: true, absRel: true},
+		{a: complex(inf, 0), b: complex(-inf, 0), tol: 1, abs: false, rel: false, absRel: false},
+		{a: complex(inf, 0), b: 1, tol: 1, abs: false, rel: false, absRel: false},
+		{a: complex(nan, 0), b: complex(nan, 0), tol: 1, abs: false, rel: false, absRel: false},
+		{a: complex(0, nan), b: 0, tol: 1, abs: false, rel: false, absRel: false},
+	} {
+		if got := EqualWithinAbs(test.a, test.b, test.tol); got != test.abs {
+			t.Errorf("unexpected EqualWithinAbs result for test %d: got:%t want:%t", i, got, test.abs)
+		}
+		if got := EqualWithinRel(test.a, test.b, test.tol); got != test.rel {
+			t.Errorf("unexpected EqualWithinRel result for test %d: got:%t want:%t", i, got, test.rel)
+		}
+		if got := EqualWithinAbsOrRel(test.a, test.b, test.tol, test.tol); got != test.absRel {
+			t.Errorf("unexpected EqualWithinAbsOrRel result for test %d: got:%t want:%t", i, got, test.absRel)
+		}
+	}
+}
+
+func TestEqualLengths(t *testing.T) {
+	s1 := []complex128{1, 2, 3, 4}
+	s2 := []complex128{1, 2, 3, 4}
+	s3 := []complex128{1, 2, 3}
+	if !EqualLengths(s1, s2) {
+		t.Errorf("Equal lengths returned as unequal")
+	}
+	if EqualLengths(s1, s3) {
+		t.Errorf("Unequal lengths returned as equal")
+	}
+	if !EqualLengths(s1) {
+		t.Errorf("Single slice returned as unequal")
+	}
+	if !EqualLengths() {
+		t.Errorf("No slices returned as unequal")
+	}
+}
+
+func eqIntSlice(one, two []int) string {
+	if len(one) != len(two) {
+		return "Length mismatch"
+	}
+	for i, val := range one {
+		if val != two[i] {
+			return "Index " + strconv.Itoa(i) + " mismatch"
+		}
+	}
+	return ""
+}
+
+func TestFind(t *testing.T) {
+	s := []complex128{3, 4i, 1 + 1i, 7, 5, -2i}
+	f := func(v complex128) bool { return cmplx.Abs(v) > 3.5 }
+	allTrueInds := []int{1, 3, 4}
+
+	// Test finding first two elements
+	inds, err := Find(nil, f, s, 2)
+	if err != nil {
+		t.Errorf("Find first two: Improper error return")
+	}
+	trueInds := allTrueInds[:2]
+	str := eqIntSlice(inds, trueInds)
+	if str != "" {
+		t.Errorf("Find first two: " + str)
+	}
+
+	// Test finding no elements with non nil slice
+	inds = []int{1, 2, 3, 4, 5, 6}
+	inds, err = Find(inds, f, s, 0)
+	if err != nil {
+		t.Errorf("Find no elements: Improper error return")
+	}
+	str = eqIntSlice(inds, []int{})
+	if str != "" {
+		t.Errorf("Find no non-nil: " + str)
+	}
+
+	// Test finding all elements
+	inds, err = Find(nil, f, s, -1)
+	if err != nil {
+		t.Errorf("Find all elements: Improper error return")
+	}
+	str = eqIntSlice(inds, allTrueInds)
+	if str != "" {
+		t.Errorf("Find all: " + str)
+	}
+
+	// Test finding more elements than possible
+	inds, err = Find(nil, f, s, len(allTrueInds)+1)
+	if err == nil {
+		t.Errorf("Request too many: No error returned")
+	}
+	str = eqIntSlice(inds, allTrueInds)
+	if str != "" {
+		t.Errorf("Request too many: Does not match all of the inds: " + str)
+	}
+}
+
+func TestHasNaN(t *testing.T) {
+	nan := math.NaN()
+	inf := math.Inf(1)
+	for i, test := range []struct {
+		s   []complex128
+		ans bool
+	}{
+		{},
+		{
+			s: []complex128{1 + 1i, 2i, 3},
+		},
+		{
+			s:   []complex128{complex(nan, 0), 2, 3},
+			ans: true,
+		},
+		{
+			s:   []complex128{1, complex(0, nan), 3},
+			ans: true,
+		},
+		{
+			s:   []complex128{1, 2, complex(inf, nan)},
+			ans: true,
+		},
+		{
+			s: []complex128{1, complex(inf, 0), 3},
+		},
+	} {
+		b := HasNaN(test.s)
+		if b != test.ans {
+			t.Errorf("HasNaN mismatch case %d. Expected %v, Found %v", i, test.ans, b)
+		}
+	}
+}
+
+func TestMaxAbsAndIdx(t *testing.T) {
+	nan := math.NaN()
+	for _, test := range []struct {
+		in      []complex128
+		wantIdx int
+		wantVal complex128
+		desc    string
+	}{
+		{
+			in:      []complex128{3, 4i, 1 + 1i, 7, 5},
+			wantIdx: 3,
+			wantVal: 7,
+			desc:    "with only finite entries",
+		},
+		{
+			in:      []complex128{3, 4i, 1 + 1i, 7i, 7},
+			wantIdx: 3,
+			wantVal: 7i,
+			desc:    "with equal absolute values",
+		},
+		{
+			in:      []complex128{complex(nan, 0), 4i, 1 + 1i, 7, 5},
+			wantIdx: 3,
+			wantVal: 7,
+			desc:    "with leading NaN",
+		},
+		{
+			in:      []complex128{complex(0, nan), complex(nan, 0)},
+			wantIdx: 0,
+			desc:    "when only NaN elements exist",
+		},
+	} {
+		ind := MaxAbsIdx(test.in)
+		if ind != test.wantIdx {
+			t.Errorf("Wrong index "+test.desc+": got:%d want:%d", ind, test.wantIdx)
+		}
+		val := MaxAbs(test.in)
+		if test.wantIdx != 0 || !isNaN(test.in[0]) {
+			if val != test.wantVal {
+				t.Errorf("Wrong value "+test.desc+": got:%v want:%v", val, test.wantVal)
+			}
+		}
+	}
+	if !panics(func() { MaxAbsIdx([]complex128{}) }) {
+		t.Errorf("Expected panic with zero length")
+	}
+}
+
+func TestMinAbsAndIdx(t *testing.T) {
+	nan := math.NaN()
+	for _, test := range []struct {
+		in      []complex128
+		wantIdx int
+		wantVal complex128
+		desc    string
+	}{
+		{
+			in:      []complex128{3, 4i, 1 + 1i, 7, 5},
+			wantIdx: 2,
+			wantVal: 1 + 1i,
+			desc:    "with only finite entries",
+		},
+		{
+			in:      []complex128{3, 4i, 1i, 7, -1},
+			wantIdx: 2,
+			wantVal: 1i,
+			desc:    "with equal absolute values",
+		},
+		{
+			in:      []complex128{complex(nan, 0), 4i, 1 + 1i, 7, 5},
+			wantIdx: 2,
+			wantVal: 1 + 1i,
+			desc:    "with leading NaN",
+		},
+		{
+			in:      []complex128{complex(0, nan), complex(nan, 0)},
+			wantIdx: 0,
+			desc:    "when only NaN elements exist",
+		},
+	} {
+		ind := MinAbsIdx(test.in)
+		if ind != test.wantIdx {
+			t.Errorf("Wrong index "+test.desc+": got:%d want:%d", ind, test.wantIdx)
+		}
+		val := MinAbs(test.in)
+		if test.wantIdx != 0 || !isNaN(test.in[0]) {
+			if val != test.wantVal {
+				t.Errorf("Wrong value "+test.desc+": got:%v want:%v", val, test.wantVal)
+			}
+		}
+	}
+	if !panics(func() { MinAbsIdx([]complex128{}) }) {
+		t.Errorf("Expected panic with zero length")
+	}
+}
+
+func TestMul(t *testing.T) {
+	s1 := []complex128{1 + 1i, 2, 3i}
+	s2 := []complex128{1 - 1i, 2i, 3}
+	ans := []complex128{2, 4i, 9i}
+	Mul(s1, s2)
+	if !EqualApprox(s1, ans, EqTolerance) {
+		t.Errorf("Mul doesn't give correct answer")
+	}
+	s1short := []complex128{1}
+	if !panics(func() { Mul(s1short, s2) }) {
+		t.Errorf("Did not panic with unequal lengths")
+	}
+	s2short := []complex128{1}
+	if !panics(func() { Mul(s1, s2short) }) {
+		t.Errorf("Did not panic with unequal lengths")
+	}
+}
+
+func TestMulTo(t *testing.T) {
+	s1 := []complex128{1 + 1i, 2, 3i}
+	s1orig := []complex128{1 + 1i, 2, 3i}
+	s2 := []complex128{1 - 1i, 2i, 3}
+	s2orig := []complex128{1 - 1i, 2i, 3}
+	dst1 := make([]complex128, 3)
+	ans := []complex128{2, 4i, 9i}
+	dst2 := MulTo(dst1, s1, s2)
+	if !EqualApprox(dst1, ans, EqTolerance) {
+		t.Errorf("MulTo doesn't give correct answer in mutated slice")
+	}
+	if !EqualApprox(dst2, ans, EqTolerance) {
+		t.Errorf("MulTo doesn't give correct answer in returned slice")
+	}
+	if !EqualApprox(s1, s1orig, EqTolerance) {
+		t.Errorf("S1 changes during multo")
+	}
+	if !EqualApprox(s2, s2orig, EqTolerance) {
+		t.Errorf("s2 changes during multo")
+	}
+	dstShort := []complex128{1}
+	if !panics(func() { MulTo(dstShort, s1, s2) }) {
+		t.Errorf("Did not panic with dst wrong length")
+	}
+	s1short := []complex128{1}
+	if !panics(func() { MulTo(dst1, s1short, s2) }) {
+		t.Errorf("Did not panic with s1 wrong length")
+	}
+}
+
+func TestMulConj(t *testing.T) {
+	s1 := []complex128{1 + 1i, 2, 3i}
+	s2 := []complex128{1 + 1i, 2i, 3}
+	ans := []complex128{2, -4i, 9i}
+	dst := MulConjTo(make([]complex128, 3), s1, s2)
+	if !EqualApprox(dst, ans, EqTolerance) {
+		t.Errorf("MulConjTo doesn't give correct answer: got %v want %v", dst, ans)
+	}
+	MulConj(s1, s2)
+	if !EqualApprox(s1, ans, EqTolerance) {
+		t.Errorf("MulConj doesn't give correct answer: got %v want %v", s1, ans)
+	}
+	if !panics(func() { MulConj(s1[:1], s2) }) {
+		t.Errorf("MulConj did not panic with unequal lengths")
+	}
+	if !panics(func() { MulConjTo(make([]complex128, 1), s1, s2) }) {
+		t.Errorf("MulConjTo did not panic with dst wrong length")
+	}
+}
+
+func TestNearestIdx(t *testing.T) {
+	nan := math.NaN()
+	for i, test := range []struct {
+		in    []complex128
+		query complex128
+		want  int
+		desc  string
+	}{
+		{
+			in:    []complex128{6.2, 3, 5i, 6.2, 8},
+			query: 2,
+			want:  1,
+			desc:  "Wrong index returned when value is less than all of elements",
+		},
+		{
+			in:    []complex128{6.2, 3, 5i, 6.2, 8},
+			query: 4i,
+			want:  2,
+			desc:  "Wrong index returned when value is off the real axis",
+		},
+		{
+			in:    []complex128{6.2, 3, 5i, 6.2, 8},
+			query: 6.2,
+			want:  0,
+			desc:  "Wrong index returned when multiple value is equal",
+		},
+		{
+			in:    []complex128{complex(nan, 0), 3, 2, 1},
+			query: 0,
+			want:  3,
+			desc:  "Wrong index returned with NaN element",
+		},
+		{
+			in:    []complex128{1, 2, 3},
+			query: complex(0, nan),
+			want:  0,
+			desc:  "Wrong index returned with NaN query",
+		},
+	} {
+		ind := NearestIdx(test.in, test.query)
+		if ind != test.want {
+			t.Errorf(test.desc+": test:%d got:%d want:%d", i, ind, test.want)
+		}
+	}
+	if !panics(func() { NearestIdx([]complex128{}, 0) }) {
+		t.Errorf("Expected panic with zero length")
+	}
+}
+
+func TestNorm(t *testing.T) {
+	s := []complex128{3i, 4}
+	val := Norm(s, 2)
+	truth := 5.0
+	if math.Abs(truth-val) > EqTolerance {
+		t.Errorf("Doesn't match for L = 2. %v expected, %v found", truth, val)
+	}
+	s = []complex128{3 + 4i, -5, 12i}
+	val = Norm(s, 1)
+	truth = 22
+	if math.Abs(truth-val) > EqTolerance {
+		t.Errorf("Doesn't match for L = 1. %v expected, %v found", truth, val)
+	}
+	val = Norm(s, math.Inf(1))
+	truth = 12
+	if math.Abs(truth-val) > EqTolerance {
+		t.Errorf("Doesn't match for L = Inf. %v expected, %v found", truth, val)
+	}
+	val = Norm(s, 3)
+	truth = math.Pow(125+125+1728, 1.0/3)
+	if math.Abs(truth-val) > EqTolerance {
+		t.Errorf("Doesn't match for L = 3. %v expected, %v found", truth, val)
+	}
+	if Norm(nil, 2) != 0 {
+		t.Errorf("Non-zero norm for empty slice")
+	}
+
+	// Scaling must avoid overflow and underflow.
+	for _, scale := range []float64{1e-300, 1e300} {
+		s = []complex128{complex(3*scale, 0), complex(0, 4*scale)}
+		val = Norm(s, 2)
+		truth = 5 * scale
+		if math.Abs(truth-val)/truth > EqTolerance {
+			t.Errorf("Doesn't match for L = 2 with scale %v. %v expected, %v found", scale, truth, val)
+		}
+	}
+	if !math.IsInf(Norm([]complex128{1, complex(0, math.Inf(-1))}, 2), 1) {
+		t.Errorf("Norm of slice with infinite element is not +Inf")
+	}
+	if !math.IsNaN(Norm([]complex128{1, complex(math.NaN(), 0)}, 2)) {
+		t.Errorf("Norm of slice with NaN element is not NaN")
+	}
+}
+
+func TestProd(t *testing.T) {
+	s := []complex128{}
+	val := Prod(s)
+	if val != 1 {
+		t.Errorf("Val not returned as default when slice length is zero")
+	}
+	s = []complex128{3i, 4, 1 + 1i, 7, 5}
+	val = Prod(s)
+	if val != -420+420i {
+		t.Errorf("Wrong prod returned. Expected %v returned %v", -420+420i, val)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	for _, s := range [][]complex128{
+		{0},
+		{1i, 0},
+		{2, 1i, 0},
+		{3i, 2, 1, 0},
+		{9, 8i, 7, 6, 5, 4, 3, 2, 1, 0},
+	} {
+		Reverse(s)
+		for i, v := range s {
+			if real(v)+imag(v) != float64(i) {
+				t.Errorf("unexpected values for element %d: got:%v want:%v", i, v, i)
+			}
+		}
+	}
+}
+
+func TestSame(t *testing.T) {
+	nan := math.NaN()
+	s1 := []complex128{1, 2, complex(3, nan), complex(nan, 4)}
+	s2 := []complex128{1, 2, complex(3, nan), complex(nan, 4)}
+	if !Same(s1, s2) {
+		t.Errorf("Same slices returned as unequal")
+	}
+	s2 = []complex128{1, 2, complex(nan, 3), complex(nan, 4)}
+	if Same(s1, s2) {
+		t.Errorf("Slices with NaN in different parts returned as same")
+	}
+	if Same(s1, s2[:3]) {
+		t.Errorf("Unequal slice lengths returned as equal")
+	}
+}
+
+func TestScale(t *testing.T) {
+	s := []complex128{3, 4i, 1 + 1i, 7, 5}
+	c := 5 - 1i
+	truth := []complex128{15 - 3i, 4 + 20i, 6 + 4i, 35 - 7i, 25 - 5i}
+	Scale(c, s)
+	areSlicesEqual(t, truth, s, "Bad scaling")
+}
+
+func TestScaleTo(t *testing.T) {
+	s := []complex128{3, 4i, 1 + 1i, 7, 5}
+	sCopy := make([]complex128, len(s))
+	copy(sCopy, s)
+	c := 5 - 1i
+	truth := []complex128{15 - 3i, 4 + 20i, 6 + 4i, 35 - 7i, 25 - 5i}
+	dst := make([]complex128, len(s))
+	ScaleTo(dst, c, s)
+	if !Same(dst, truth) {
+		t.Errorf("Scale to does not match. Got %v, want %v", dst, truth)
+	}
+	if !Same(s, sCopy) {
+		t.Errorf("Source modified during call. Got %v, want %v", s, sCopy)
+	}
+	if !panics(func() { ScaleTo(dst, 0, []complex128{1}) }) {
+		t.Errorf("Expected panic with different slice lengths")
+	}
+}
+
+func TestScaleReal(t *testing.T) {
+	s := []complex128{3, 4i, 1 + 1i, 7, 5}
+	truth := []complex128{-6, -8i, -2 - 2i, -14, -10}
+	dst := ScaleRealTo(make([]complex128, len(s)), -2, s)
+	if !Same(dst, truth) {
+		t.Errorf("ScaleRealTo does not match. Got %v, want %v", dst, truth)
+	}
+	ScaleReal(-2, s)
+	if !Same(s, truth) {
+		t.Errorf("ScaleReal does not match. Got %v, want %v", s, truth)
+	}
+	if !panics(func() { ScaleRealTo(dst, 0, []complex128{1}) }) {
+		t.Errorf("Expected panic with different slice lengths")
+	}
+}
+
+func TestSpan(t *testing.T) {
+	receiver1 := make([]complex128, 5)
+	truth := []complex128{1, 2 + 0.5i, 3 + 1i, 4 + 1.5i, 5 + 2i}
+	receiver2 := Span(receiver1, 1, 5+2i)
+	areSlicesEqual(t, truth, receiver1, "Improper linspace from mutator")
+	areSlicesEqual(t, truth, receiver2, "Improper linspace from returned slice")
+	receiver1 = make([]complex128, 6)
+	truth = []complex128{0, -0.2i, -0.4i, -0.6i, -0.8i, -1i}
+	Span(receiver1, 0, -1i)
+	areSlicesEqual(t, truth, receiver1, "Improper linspace")
+	if !panics(func() { Span(make([]complex128, 1), 0, 1) }) {
+		t.Errorf("Span accepts input argument that is too small")
+	}
+	if !panics(func() { Span(nil, 0, 1) }) {
+		t.Errorf("Span accepts nil argument")
+	}
+
+	nan := math.NaN()
+	inf := math.Inf(1)
+	for i, test := range []struct {
+		n    int
+		l, u complex128
+		want []complex128
+	}{
+		{
+			n: 4, l: complex(0, inf), u: complex(1, inf),
+			want: []complex128{complex(0, inf), complex(1.0/3, inf), complex(2.0/3, inf), complex(1, inf)},
+		},
+		{
+			n: 3, l: complex(-inf, 0), u: complex(inf, 2),
+			want: []complex128{complex(-inf, 0), 1i, complex(inf, 2)},
+		},
+		{
+			n: 3, l: complex(nan, 0), u: complex(1, 1),
+			want: []complex128{complex(nan, 0), complex(nan, 0.5), 1 + 1i},
+		},
+		{
+			n: 4, l: complex(0, -inf), u: complex(0, 1),
+			want: []complex128{complex(0, -inf), complex(0, -inf), complex(0, -inf), 1i},
+		},
+	} {
+		got := Span(make([]complex128, test.n), test.l, test.u)
+		areSlicesSame(t, test.want, got, fmt.Sprintf("Unexpected slice of length %d returned for case %d", test.n, i))
+	}
+}
+
+func TestSub(t *testing.T) {
+	s := []complex128{3, 4i, 1 + 1i, 7, 5}
+	v := []complex128{1, 2, 3i, 4, 5}
+	truth := []complex128{2, -2 + 4i, 1 - 2i, 3, 0}
+	Sub(s, v)
+	areSlicesEqual(t, truth, s, "Bad subtract")
+	// Test that it panics
+	if !panics(func() { Sub(make([]complex128, 2), make([]complex128, 3)) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestSubTo(t *testing.T) {
+	s := []complex128{3, 4i, 1 + 1i, 7, 5}
+	v := []complex128{1, 2, 3i, 4, 5}
+	truth := []complex128{2, -2 + 4i, 1 - 2i, 3, 0}
+	dst1 := make([]complex128, len(s))
+	dst2 := SubTo(dst1, s, v)
+	areSlicesEqual(t, truth, dst1, "Bad subtract from mutator")
+	areSlicesEqual(t, truth, dst2, "Bad subtract from returned slice")
+	// Test that all mismatch combinations panic
+	if !panics(func() { SubTo(make([]complex128, 2), make([]complex128, 3), make([]complex128, 3)) }) {
+		t.Errorf("Did not panic with dst different length")
+	}
+	if !panics(func() { SubTo(make([]complex128, 3), make([]complex128, 2), make([]complex128, 3)) }) {
+		t.Errorf("Did not panic with subtractor different length")
+	}
+	if !panics(func() { SubTo(make([]complex128, 3), make([]complex128, 3), make([]complex128, 2)) }) {
+		t.Errorf("Did not panic with subtractee different length")
+	}
+}
+
+func TestSum(t *testing.T) {
+	s := []complex128{}
+	val := Sum(s)
+	if val != 0 {
+		t.Errorf("Val not returned as default when slice length is zero")
+	}
+	s = []complex128{3, 4i, 1 + 1i, 7, 5}
+	val = Sum(s)
+	if val != 16+5i {
+		t.Errorf("Wrong sum returned")
+	}
+}
+
+func randomSlice(l int, src rand.Source) []complex128 {
+	rnd := rand.New(src)
+	s := make([]complex128, l)
+	for i := range s {
+		s[i] = complex(rnd.Float64(), rnd.Float64())
+	}
+	return s
+}
+
+func benchmarkAdd(b *testing.B, size int) {
+	src := rand.NewSource(1)
+	s1 := randomSlice(size, src)
+	s2 := randomSlice(size, src)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Add(s1, s2)
+	}
+}
+func BenchmarkAddSmall(b *testing.B) { benchmarkAdd(b, Small) }
+func BenchmarkAddMed(b *testing.B)   { benchmarkAdd(b, Medium) }
+func BenchmarkAddLarge(b *testing.B) { benchmarkAdd(b, Large) }
+func BenchmarkAddHuge(b *testing.B)  { benchmarkAdd(b, Huge) }
+
+func benchmarkDot(b *testing.B, size int) {
+	src := rand.NewSource(1)
+	s1 := randomSlice(size, src)
+	s2 := randomSlice(size, src)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Dot(s1, s2)
+	}
+}
+func BenchmarkDotSmall(b *testing.B) { benchmarkDot(b, Small) }
+func BenchmarkDotMed(b *testing.B)   { benchmarkDot(b, Medium) }
+func BenchmarkDotLarge(b *testing.B) { benchmarkDot(b, Large) }
+func BenchmarkDotHuge(b *testing.B)  { benchmarkDot(b, Huge) }
+
+func benchmarkNorm2(b *testing.B, size int) {
+	src := rand.NewSource(1)
+	s := randomSlice(size, src)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Norm(s, 2)
+	}
+}
+func BenchmarkNorm2Small(b *testing.B) { benchmarkNorm2(b, Small) }
+func BenchmarkNorm2Med(b *testing.B)   { benchmarkNorm2(b, Medium) }
+func BenchmarkNorm2Large(b *testing.B) { benchmarkNorm2(b, Large) }
+func BenchmarkNorm2Huge(b *testing.B)  { benchmarkNorm2(b, Huge) }
@@ -200,6 +200,8 @@ func DivTo(dst, s, t []float64) []float64 {
 // Dot computes the dot product of s1 and s2, i.e.
 // sum_{i = 1}^N s1[i]*s2[i].
 // A panic will occur if lengths of arguments do not match.
+//
+// See Dot2 for a more accurate alternative.
 func Dot(s1, s2 []float64) float64 {
 	if len(s1) != len(s2) {
 		panic("floats: lengths of the slices do not match")
@@ -207,6 +209,30 @@ func Dot(s1, s2 []float64) float64 {
 	return f64.DotUnitary(s1, s2)
 }
 
+// Dot2 computes the dot product of s1 and s2 as if in twice the working
+// precision, using the Dot2 algorithm of Ogita, Rump and Oishi. The result
+// has a relative error of about eps + n^2 eps^2 cond, where eps is the machine
+// epsilon and cond is the condition number of the dot product, so it is
+// accurate to working precision unless the dot product is extremely
+// ill-conditioned. Dot2 is roughly twice as slow as Dot.
+//
+// If the compensated computation does not give a finite result, the result
+// of Dot is returned so that infinities and NaNs propagate in the same way.
+// A panic will occur if lengths of arguments do not match.
+//
+// See "Accurate sum and dot product" by Ogita, Rump and Oishi,
+// SIAM J. Sci. Comput. 26(6) (2005), for details.
+func Dot2(s1, s2 []float64) float64 {
+	if len(s1) != len(s2) {
+		panic("floats: lengths of the slices do not match")
+	}
+	d := f64.Dot2Unitary(s1, s2)
+	if math.IsInf(d, 0) || math.IsNaN(d) {
+		return f64.DotUnitary(s1, s2)
+	}
+	return d
+}
+
 // Equal returns true if the slices have equal lengths and
 // all elements are numerically identical.
 func Equal(s1, s2 []float64) bool {
@@ -899,10 +925,61 @@ func SubTo(dst, s, t []float64) []float64 {
 }
 
 // Sum returns the sum of the elements of the slice.
+//
+// See SumCompensated and SumPairwise for more accurate alternatives.
 func Sum(s []float64) float64 {
 	return f64.Sum(s)
 }
 
+// SumCompensated returns the sum of the elements of the slice calculated
+// with the compensated summation algorithm of Kahan and Babuška as improved
+// by Neumaier. The error of the result is bounded by
+//  eps |sum_i s[i]| + O(n eps^2) sum_i |s[i]|,
+// so the result is accurate to working precision unless n is very large
+// or the sum is extremely ill-conditioned. SumCompensated is about an
+// order of magnitude slower than Sum; SumPairwise is a faster alternative
+// with a weaker error bound.
+//
+// If the sum is not finite, the result is the same as that of the naive
+// summation.
+func SumCompensated(s []float64) float64 {
+	// SumCompensated uses an improved version of Kahan's compensated
+	// summation algorithm proposed by Neumaier.
+	// See https://en.wikipedia.org/wiki/Kahan_summation_algorithm for details.
+	var sum, c float64
+	for _, x := range s {
+		// This type conversion is here to prevent a sufficiently smart compiler
+		// from optimising away these operations.
+		t := float64(sum + x)
+		if math.Abs(sum) >= math.Abs(x) {
+			c += (sum - t) + x
+		} else {
+			c += (x - t) + sum
+		}
+		sum = t
+	}
+	if math.IsInf(sum, 0) || math.IsNaN(sum) {
+		return sum
+	}
+	return sum + c
+}
+
+// pairwiseBlock is the length of the blocks summed directly by SumPairwise.
+const pairwiseBlock = 128
+
+// SumPairwise returns the sum of the elements of the slice calculated by
+// pairwise summation, recursively summing the two halves of s. The error
+// bound of the result grows as O(eps log n) rather than the O(eps n) of
+// naive summation, while the running time is close to that of Sum.
+func SumPairwise(s []float64) float64 {
+	if len(s) <= pairwiseBlock {
+		return f64.Sum(s)
+	}
+	// Split on a block boundary so that the leaves are full blocks.
+	h := (len(s)/2 + pairwiseBlock - 1) / pairwiseBlock * pairwiseBlock
+	return SumPairwise(s[:h]) + SumPairwise(s[h:])
+}
+
 // Within returns the first index i where s[i] <= v < s[i+1]. Within panics if:
 //  - len(s) < 2
 //  - s is not sorted
@@ -7,6 +7,7 @@ package floats
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"strconv"
 	"testing"
 
@@ -339,6 +340,65 @@ func TestDot(t *testing.T) {
 	}
 }
 
+func TestDot2(t *testing.T) {
+	const eps = 1.0 / (1 << 53)
+	s1 := []float64{1, 2, 3, 4}
+	s2 := []float64{-3, 4, 5, -6}
+	if got := Dot2(s1, s2); got != -4 {
+		t.Errorf("unexpected dot product: got:%v want:-4", got)
+	}
+	if !Panics(func() { Dot2(make([]float64, 2), make([]float64, 3)) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+
+	// Ill-conditioned dot products, where each product is cancelled
+	// by its rounded value so that the exact result depends on the
+	// rounding errors of the products.
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{2, 10, 16, 33, 1000} {
+		x := make([]float64, 2*n+1)
+		y := make([]float64, 2*n+1)
+		for i := 0; i < n; i++ {
+			x[2*i] = rnd.NormFloat64() * 1e10
+			y[2*i] = rnd.NormFloat64() * 1e10
+			x[2*i+1] = -x[2*i] * y[2*i]
+			y[2*i+1] = 1
+		}
+		x[2*n] = rnd.Float64()
+		y[2*n] = 1e-3
+
+		var abs float64
+		sum := new(big.Float).SetPrec(4096)
+		for i, v := range x {
+			p := new(big.Float).SetPrec(4096).SetFloat64(v)
+			sum.Add(sum, p.Mul(p, big.NewFloat(y[i])))
+			abs += math.Abs(v * y[i])
+		}
+		want, _ := sum.Float64()
+		got := Dot2(x, y)
+		m := float64(len(x))
+		if math.Abs(got-want) > eps*math.Abs(want)+m*m*eps*eps*abs {
+			t.Errorf("n=%d: unexpected ill-conditioned dot product: got:%v want:%v", n, got, want)
+		}
+	}
+
+	inf := math.Inf(1)
+	for _, test := range []struct {
+		s1, s2 []float64
+		want   float64
+	}{
+		{s1: []float64{1, inf}, s2: []float64{1, 1}, want: inf},
+		{s1: []float64{1, inf}, s2: []float64{1, -1}, want: -inf},
+		{s1: []float64{inf, inf}, s2: []float64{1, -1}, want: math.NaN()},
+		{s1: []float64{1, math.NaN()}, s2: []float64{1, 1}, want: math.NaN()},
+		{s1: []float64{1e200, 1e200}, s2: []float64{1e200, 1e200}, want: inf},
+	} {
+		if got := Dot2(test.s1, test.s2); !same(got, test.want) {
+			t.Errorf("unexpected dot product of %v and %v: got:%v want:%v", test.s1, test.s2, got, test.want)
+		}
+	}
+}
+
 func TestEquals(t *testing.T) {
 	s1 := []float64{1, 2, 3, 4}
 	s2 := []float64{1, 2, 3, 4}
@@ -1488,6 +1548,83 @@ func TestSum(t *testing.T) {
 	}
 }
 
+func TestSumAccurate(t *testing.T) {
+	const eps = 1.0 / (1 << 53)
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		name string
+		fn   func([]float64) float64
+		// bound returns the error bound for a sum of n
+		// elements with the given sum of absolute values.
+		bound func(n int, abs float64) float64
+	}{
+		{
+			name:  "SumCompensated",
+			fn:    SumCompensated,
+			bound: func(n int, abs float64) float64 { return 2*eps*abs + float64(n*n)*eps*eps*abs },
+		},
+		{
+			name:  "SumPairwise",
+			fn:    SumPairwise,
+			bound: func(n int, abs float64) float64 { return 2 * math.Log2(float64(n)+1) * eps * abs },
+		},
+	} {
+		if got := test.fn(nil); got != 0 {
+			t.Errorf("%s: unexpected sum of empty slice: got:%v want:0", test.name, got)
+		}
+		if got := test.fn([]float64{3, 4, 1, 7, 5}); got != 20 {
+			t.Errorf("%s: unexpected sum: got:%v want:20", test.name, got)
+		}
+		for _, n := range []int{1, 2, 10, 127, 128, 129, 300, 1000, 100000} {
+			s := make([]float64, n)
+			for i := range s {
+				s[i] = rnd.NormFloat64() * math.Pow(2, float64(rnd.Intn(40)-20))
+			}
+			want, abs := exactSum(s)
+			got := test.fn(s)
+			if math.Abs(got-want) > math.Abs(want)*eps+test.bound(n, abs) {
+				t.Errorf("%s n=%d: unexpected sum: got:%v want:%v", test.name, n, got, want)
+			}
+		}
+	}
+
+	// Catastrophic cancellation defeats naive summation.
+	s := []float64{1, 1e100, 1, -1e100}
+	if got := SumCompensated(s); got != 2 {
+		t.Errorf("unexpected compensated sum: got:%v want:2", got)
+	}
+
+	inf := math.Inf(1)
+	for _, test := range []struct {
+		s    []float64
+		want float64
+	}{
+		{s: []float64{1, inf, 2}, want: inf},
+		{s: []float64{1, -inf, 2}, want: -inf},
+		{s: []float64{inf, -inf}, want: math.NaN()},
+		{s: []float64{1, math.NaN(), 2}, want: math.NaN()},
+		{s: []float64{math.MaxFloat64, math.MaxFloat64}, want: inf},
+	} {
+		for _, fn := range []func([]float64) float64{SumCompensated, SumPairwise} {
+			if got := fn(test.s); !same(got, test.want) {
+				t.Errorf("unexpected sum of %v: got:%v want:%v", test.s, got, test.want)
+			}
+		}
+	}
+}
+
+// exactSum returns the correctly rounded sum of s and the sum of the
+// absolute values of s.
+func exactSum(s []float64) (sum, abs float64) {
+	acc := new(big.Float).SetPrec(4096)
+	for _, v := range s {
+		acc.Add(acc, big.NewFloat(v))
+		abs += math.Abs(v)
+	}
+	sum, _ = acc.Float64()
+	return sum, abs
+}
+
 func TestWithin(t *testing.T) {
 	for i, test := range []struct {
 		s      []float64
@@ -1757,3 +1894,27 @@ func BenchmarkNorm2Small(b *testing.B)  { benchmarkNorm2(b, Small) }
 func BenchmarkNorm2Medium(b *testing.B) { benchmarkNorm2(b, Medium) }
 func BenchmarkNorm2Large(b *testing.B)  { benchmarkNorm2(b, Large) }
 func BenchmarkNorm2Huge(b *testing.B)   { benchmarkNorm2(b, Huge) }
+
+func benchmarkDot2(b *testing.B, size int) {
+	s1 := randomSlice(size)
+	s2 := randomSlice(size)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Dot2(s1, s2)
+	}
+}
+func BenchmarkDot2Small(b *testing.B)  { benchmarkDot2(b, Small) }
+func BenchmarkDot2Medium(b *testing.B) { benchmarkDot2(b, Medium) }
+func BenchmarkDot2Large(b *testing.B)  { benchmarkDot2(b, Large) }
+func BenchmarkDot2Huge(b *testing.B)   { benchmarkDot2(b, Huge) }
+
+func benchmarkSum(b *testing.B, sum func([]float64) float64, size int) {
+	s := randomSlice(size)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum(s)
+	}
+}
+func BenchmarkSumLarge(b *testing.B)            { benchmarkSum(b, Sum, Large) }
+func BenchmarkSumCompensatedLarge(b *testing.B) { benchmarkSum(b, SumCompensated, Large) }
+func BenchmarkSumPairwiseLarge(b *testing.B)    { benchmarkSum(b, SumPairwise, Large) }
@@ -0,0 +1,49 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func AxpyInc(alpha complex128, x, y []complex128, n, incX, incY, ix, iy uintptr)
+TEXT ·AxpyInc(SB), NOSPLIT, $0-104
+	FMOVD alpha_real+0(FP), F0
+	FMOVD alpha_imag+8(FP), F1
+	MOVD x_base+16(FP), R0
+	MOVD y_base+40(FP), R1
+	MOVD n+64(FP), R3
+	MOVD incX+72(FP), R4
+	MOVD incY+80(FP), R5
+	MOVD ix+88(FP), R7
+	MOVD iy+96(FP), R8
+	ADD  R7<<4, R0 // R0 = &x[ix]
+	ADD  R8<<4, R1 // R1 = &y[iy]
+	MOVD R1, R2     // y is also the destination
+	MOVD R5, R6
+	LSL  $4, R4 // Convert the increments to bytes.
+	LSL  $4, R5
+	LSL  $4, R6
+	CBZ  R3, end
+
+	FNEGD F1, F2
+	VDUP  V0.D[0], V0.D2 // V0 = [real(alpha), real(alpha)]
+	VDUP  V1.D[0], V1.D2
+	VDUP  V2.D[0], V2.D2
+	VZIP1 V1.D2, V2.D2, V1.D2 // V1 = [-imag(alpha), imag(alpha)]
+
+loop: // dst[idst] = alpha * x[ix] + y[iy]
+	VLD1   (R0), [V2.D2]
+	VLD1   (R1), [V6.D2]
+	VEXT   $8, V2.B16, V2.B16, V10.B16
+	VFMLA  V0.D2, V2.D2, V6.D2
+	VFMLA  V1.D2, V10.D2, V6.D2
+	VST1   [V6.D2], (R2)
+	ADD    R4, R0
+	ADD    R5, R1
+	ADD    R6, R2
+	SUB    $1, R3
+	CBNZ   R3, loop
+
+end:
+	RET
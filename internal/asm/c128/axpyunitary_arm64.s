@@ -0,0 +1,65 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func AxpyUnitary(alpha complex128, x, y []complex128)
+TEXT ·AxpyUnitary(SB), NOSPLIT, $0-64
+	FMOVD alpha_real+0(FP), F0
+	FMOVD alpha_imag+8(FP), F1
+	MOVD x_base+16(FP), R0
+	MOVD x_len+24(FP), R3
+	MOVD y_base+40(FP), R1
+	MOVD y_len+48(FP), R4
+	MOVD R1, R2 // y is also the destination
+
+	CMP  R4, R3 // R3 = min(len(x), len(y))
+	CSEL GT, R4, R3, R3
+	CBZ  R3, end
+
+	FNEGD F1, F2
+	VDUP  V0.D[0], V0.D2 // V0 = [real(alpha), real(alpha)]
+	VDUP  V1.D[0], V1.D2
+	VDUP  V2.D[0], V2.D2
+	VZIP1 V1.D2, V2.D2, V1.D2 // V1 = [-imag(alpha), imag(alpha)]
+	LSR  $2, R3, R5 // R5 = n / 4
+	CBZ  R5, tail_start
+
+loop: // dst[i:i+4] = alpha * x[i:i+4] + y[i:i+4]
+	VLD1.P 64(R0), [V2.D2, V3.D2, V4.D2, V5.D2]
+	VLD1.P 64(R1), [V6.D2, V7.D2, V8.D2, V9.D2]
+	VEXT   $8, V2.B16, V2.B16, V10.B16
+	VEXT   $8, V3.B16, V3.B16, V11.B16
+	VEXT   $8, V4.B16, V4.B16, V12.B16
+	VEXT   $8, V5.B16, V5.B16, V13.B16
+	VFMLA  V0.D2, V2.D2, V6.D2
+	VFMLA  V1.D2, V10.D2, V6.D2
+	VFMLA  V0.D2, V3.D2, V7.D2
+	VFMLA  V1.D2, V11.D2, V7.D2
+	VFMLA  V0.D2, V4.D2, V8.D2
+	VFMLA  V1.D2, V12.D2, V8.D2
+	VFMLA  V0.D2, V5.D2, V9.D2
+	VFMLA  V1.D2, V13.D2, V9.D2
+	VST1.P [V6.D2, V7.D2, V8.D2, V9.D2], 64(R2)
+	SUB    $1, R5
+	CBNZ   R5, loop
+
+tail_start:
+	AND $3, R3
+	CBZ R3, end
+
+tail: // dst[i] = alpha * x[i] + y[i]
+	VLD1.P 16(R0), [V2.D2]
+	VLD1.P 16(R1), [V6.D2]
+	VEXT   $8, V2.B16, V2.B16, V10.B16
+	VFMLA  V0.D2, V2.D2, V6.D2
+	VFMLA  V1.D2, V10.D2, V6.D2
+	VST1.P [V6.D2], 16(R2)
+	SUB    $1, R3
+	CBNZ   R3, tail
+
+end:
+	RET
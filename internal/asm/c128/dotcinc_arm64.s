@@ -0,0 +1,47 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func DotcInc(x, y []complex128, n, incX, incY, ix, iy uintptr) (sum complex128)
+TEXT ·DotcInc(SB), NOSPLIT, $0-104
+	MOVD x_base+0(FP), R0
+	MOVD y_base+24(FP), R1
+	MOVD n+48(FP), R3
+	MOVD incX+56(FP), R4
+	MOVD incY+64(FP), R5
+	MOVD ix+72(FP), R7
+	MOVD iy+80(FP), R8
+	ADD  R7<<4, R0 // R0 = &x[ix]
+	ADD  R8<<4, R1 // R1 = &y[iy]
+	LSL  $4, R4    // Convert the increments to bytes.
+	LSL  $4, R5
+
+	VEOR V14.B16, V14.B16, V14.B16 // The partial sums are held in V14-V17.
+	VEOR V15.B16, V15.B16, V15.B16
+	VEOR V16.B16, V16.B16, V16.B16
+	VEOR V17.B16, V17.B16, V17.B16
+	CBZ R3, reduce
+
+loop: // sum += y[iy] * conj(x[ix])
+	VLD1   (R0), [V2.D2]
+	VLD1   (R1), [V6.D2]
+	VEXT   $8, V2.B16, V2.B16, V10.B16
+	VFMLA  V2.D2, V6.D2, V14.D2
+	VFMLA  V10.D2, V6.D2, V16.D2
+	ADD    R4, R0
+	ADD    R5, R1
+	SUB    $1, R3
+	CBNZ   R3, loop
+
+reduce:
+	VEXT $8, V14.B16, V14.B16, V15.B16
+	VEXT $8, V16.B16, V16.B16, V17.B16
+	FADDD F15, F14, F0 // real(sum) = Σ real(x)*real(y) + imag(x)*imag(y)
+	FSUBD F16, F17, F1 // imag(sum) = Σ real(x)*imag(y) - imag(x)*real(y)
+	FMOVD F0, sum_real+88(FP)
+	FMOVD F1, sum_imag+96(FP)
+	RET
@@ -0,0 +1,66 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func DotuUnitary(x, y []complex128) (sum complex128)
+TEXT ·DotuUnitary(SB), NOSPLIT, $0-64
+	MOVD x_base+0(FP), R0
+	MOVD x_len+8(FP), R3
+	MOVD y_base+24(FP), R1
+	MOVD y_len+32(FP), R4
+
+	CMP  R4, R3 // R3 = min(len(x), len(y))
+	CSEL GT, R4, R3, R3
+
+	VEOR V14.B16, V14.B16, V14.B16 // The partial sums are held in V14-V17.
+	VEOR V15.B16, V15.B16, V15.B16
+	VEOR V16.B16, V16.B16, V16.B16
+	VEOR V17.B16, V17.B16, V17.B16
+	LSR  $2, R3, R5 // R5 = n / 4
+	CBZ  R5, tail_start
+
+loop: // sum += x[i:i+4] * y[i:i+4]
+	VLD1.P 64(R0), [V2.D2, V3.D2, V4.D2, V5.D2]
+	VLD1.P 64(R1), [V6.D2, V7.D2, V8.D2, V9.D2]
+	VEXT   $8, V2.B16, V2.B16, V10.B16
+	VEXT   $8, V3.B16, V3.B16, V11.B16
+	VEXT   $8, V4.B16, V4.B16, V12.B16
+	VEXT   $8, V5.B16, V5.B16, V13.B16
+	VFMLA  V2.D2, V6.D2, V14.D2
+	VFMLA  V10.D2, V6.D2, V16.D2
+	VFMLA  V3.D2, V7.D2, V15.D2
+	VFMLA  V11.D2, V7.D2, V17.D2
+	VFMLA  V4.D2, V8.D2, V14.D2
+	VFMLA  V12.D2, V8.D2, V16.D2
+	VFMLA  V5.D2, V9.D2, V15.D2
+	VFMLA  V13.D2, V9.D2, V17.D2
+	SUB    $1, R5
+	CBNZ   R5, loop
+
+tail_start:
+	VFADD V15.D2, V14.D2, V14.D2
+	VFADD V17.D2, V16.D2, V16.D2
+	AND   $3, R3
+	CBZ   R3, reduce
+
+tail:
+	VLD1.P 16(R0), [V2.D2]
+	VLD1.P 16(R1), [V6.D2]
+	VEXT   $8, V2.B16, V2.B16, V10.B16
+	VFMLA  V2.D2, V6.D2, V14.D2
+	VFMLA  V10.D2, V6.D2, V16.D2
+	SUB    $1, R3
+	CBNZ   R3, tail
+
+reduce:
+	VEXT $8, V14.B16, V14.B16, V15.B16
+	VEXT $8, V16.B16, V16.B16, V17.B16
+	FSUBD F15, F14, F0 // real(sum) = Σ real(x)*real(y) - imag(x)*imag(y)
+	FADDD F17, F16, F1 // imag(sum) = Σ real(x)*imag(y) + imag(x)*real(y)
+	FMOVD F0, sum_real+48(FP)
+	FMOVD F1, sum_imag+56(FP)
+	RET
@@ -0,0 +1,29 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func DscalInc(alpha float64, x []complex128, n, inc uintptr)
+TEXT ·DscalInc(SB), NOSPLIT, $0-48
+	FMOVD alpha+0(FP), F0
+	MOVD  x_base+8(FP), R0
+	MOVD  n+32(FP), R3
+	MOVD  inc+40(FP), R4
+	LSL   $4, R4 // Convert the increment to bytes.
+	CBZ   R3, end
+
+	VDUP V0.D[0], V0.D2
+
+loop: // x[i] = complex(real(x[i])*alpha, imag(x[i])*alpha)
+	VLD1  (R0), [V2.D2]
+	VFMUL V0.D2, V2.D2, V2.D2
+	VST1  [V2.D2], (R0)
+	ADD   R4, R0
+	SUB   $1, R3
+	CBNZ  R3, loop
+
+end:
+	RET
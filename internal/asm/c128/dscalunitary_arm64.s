@@ -0,0 +1,43 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func DscalUnitary(alpha float64, x []complex128)
+TEXT ·DscalUnitary(SB), NOSPLIT, $0-32
+	FMOVD alpha+0(FP), F0
+	MOVD  x_base+8(FP), R0
+	MOVD  x_len+16(FP), R3
+	MOVD  R0, R2 // x is also the destination
+	CBZ   R3, end
+
+	VDUP V0.D[0], V0.D2
+	LSR  $2, R3, R5 // R5 = n / 4
+	CBZ  R5, tail_start
+
+loop: // x[i:i+4] = complex(real(x[i:i+4])*alpha, imag(x[i:i+4])*alpha)
+	VLD1.P 64(R0), [V2.D2, V3.D2, V4.D2, V5.D2]
+	VFMUL  V0.D2, V2.D2, V2.D2
+	VFMUL  V0.D2, V3.D2, V3.D2
+	VFMUL  V0.D2, V4.D2, V4.D2
+	VFMUL  V0.D2, V5.D2, V5.D2
+	VST1.P [V2.D2, V3.D2, V4.D2, V5.D2], 64(R2)
+	SUB    $1, R5
+	CBNZ   R5, loop
+
+tail_start:
+	AND $3, R3
+	CBZ R3, end
+
+tail: // x[i] = complex(real(x[i])*alpha, imag(x[i])*alpha)
+	VLD1.P 16(R0), [V2.D2]
+	VFMUL  V0.D2, V2.D2, V2.D2
+	VST1.P [V2.D2], 16(R2)
+	SUB    $1, R3
+	CBNZ   R3, tail
+
+end:
+	RET
@@ -0,0 +1,67 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !arm64 noasm appengine safe
+
+package c128
+
+import "math"
+
+// L2NormUnitary returns the L2-norm of x.
+func L2NormUnitary(x []complex128) (norm float64) {
+	var scale float64
+	sumSquares := 1.0
+	for _, v := range x {
+		re, im := math.Abs(real(v)), math.Abs(imag(v))
+		if re != 0 {
+			if re > scale {
+				sumSquares = 1 + sumSquares*(scale/re)*(scale/re)
+				scale = re
+			} else {
+				sumSquares += (re / scale) * (re / scale)
+			}
+		}
+		if im != 0 {
+			if im > scale {
+				sumSquares = 1 + sumSquares*(scale/im)*(scale/im)
+				scale = im
+			} else {
+				sumSquares += (im / scale) * (im / scale)
+			}
+		}
+	}
+	if math.IsInf(scale, 1) {
+		return math.Inf(1)
+	}
+	return scale * math.Sqrt(sumSquares)
+}
+
+// L2NormInc returns the L2-norm of x.
+func L2NormInc(x []complex128, n, incX uintptr) (norm float64) {
+	var scale float64
+	sumSquares := 1.0
+	for ix := uintptr(0); ix < n*incX; ix += incX {
+		re, im := math.Abs(real(x[ix])), math.Abs(imag(x[ix]))
+		if re != 0 {
+			if re > scale {
+				sumSquares = 1 + sumSquares*(scale/re)*(scale/re)
+				scale = re
+			} else {
+				sumSquares += (re / scale) * (re / scale)
+			}
+		}
+		if im != 0 {
+			if im > scale {
+				sumSquares = 1 + sumSquares*(scale/im)*(scale/im)
+				scale = im
+			} else {
+				sumSquares += (im / scale) * (im / scale)
+			}
+		}
+	}
+	if math.IsInf(scale, 1) {
+		return math.Inf(1)
+	}
+	return scale * math.Sqrt(sumSquares)
+}
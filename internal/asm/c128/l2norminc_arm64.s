@@ -0,0 +1,73 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// L2NormInc returns the L2-norm of x.
+// func L2NormInc(x []complex128, n, incX uintptr) (norm float64)
+TEXT ·L2NormInc(SB), NOSPLIT, $0-48
+	MOVD  x_base+0(FP), R0
+	MOVD  n+24(FP), R1
+	MOVD  incX+32(FP), R3
+	LSL   $4, R3 // Convert the increment to bytes.
+	FMOVD $1.0, F31 // F31 = 1
+	VEOR  V0.B16, V0.B16, V0.B16 // scale = 0
+	FMOVD $1.0, F1  // sumSquares = 1
+	CBZ   R1, ret
+
+loop:
+	FMOVD  (R0), F2
+	FMOVD  8(R0), F5
+	ADD    R3, R0
+	FABSD  F2, F2 // |re| = |real(x[i])|
+	FABSD  F5, F5 // |im| = |imag(x[i])|
+
+	FCMPD  $(0.0), F2
+	BEQ    im         // if |re| == 0 { skip }
+	FCMPD  F0, F2
+	BGT    re_rescale // if |re| > scale { goto re_rescale }
+
+	FDIVD  F0, F2, F3     // s = |re| / scale
+	FMADDD F3, F1, F3, F1 // sumSquares += s * s
+	B      im
+
+re_rescale:
+	FDIVD  F2, F0, F3      // s = scale / |re|
+	FMULD  F3, F1
+	FMADDD F3, F31, F1, F1 // sumSquares = 1 + sumSquares * s * s
+	FMOVD  F2, F0          // scale = |re|
+
+im:
+	FCMPD  $(0.0), F5
+	BEQ    next         // if |im| == 0 { skip }
+	FCMPD  F0, F5
+	BGT    im_rescale // if |im| > scale { goto im_rescale }
+
+	FDIVD  F0, F5, F3     // s = |im| / scale
+	FMADDD F3, F1, F3, F1 // sumSquares += s * s
+	B      next
+
+im_rescale:
+	FDIVD  F5, F0, F3      // s = scale / |im|
+	FMULD  F3, F1
+	FMADDD F3, F31, F1, F1 // sumSquares = 1 + sumSquares * s * s
+	FMOVD  F5, F0          // scale = |im|
+
+next:
+	SUB  $1, R1
+	CBNZ R1, loop
+
+ret:
+	MOVD   $0x7FF0000000000000, R2
+	FMOVD  R2, F4
+	FCMPD  F4, F0
+	BEQ    inf             // if isInf(scale, 1) { return Inf }
+	FSQRTD F1, F1
+	FMULD  F1, F0          // norm = scale * sqrt(sumSquares)
+
+inf:
+	FMOVD F0, norm+40(FP)
+	RET
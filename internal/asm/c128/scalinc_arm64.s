@@ -0,0 +1,36 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func ScalInc(alpha complex128, x []complex128, n, inc uintptr)
+TEXT ·ScalInc(SB), NOSPLIT, $0-56
+	FMOVD alpha_real+0(FP), F0
+	FMOVD alpha_imag+8(FP), F1
+	MOVD x_base+16(FP), R0
+	MOVD n+40(FP), R3
+	MOVD inc+48(FP), R4
+	LSL  $4, R4 // Convert the increment to bytes.
+	CBZ  R3, end
+
+	FNEGD F1, F2
+	VDUP  V0.D[0], V0.D2 // V0 = [real(alpha), real(alpha)]
+	VDUP  V1.D[0], V1.D2
+	VDUP  V2.D[0], V2.D2
+	VZIP1 V1.D2, V2.D2, V1.D2 // V1 = [-imag(alpha), imag(alpha)]
+
+loop: // x[i] *= alpha
+	VLD1   (R0), [V2.D2]
+	VEXT   $8, V2.B16, V2.B16, V10.B16
+	VFMUL  V0.D2, V2.D2, V6.D2
+	VFMLA  V1.D2, V10.D2, V6.D2
+	VST1   [V6.D2], (R0)
+	ADD    R4, R0
+	SUB    $1, R3
+	CBNZ   R3, loop
+
+end:
+	RET
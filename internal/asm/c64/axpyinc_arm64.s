@@ -0,0 +1,49 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func AxpyInc(alpha complex64, x, y []complex64, n, incX, incY, ix, iy uintptr)
+TEXT ·AxpyInc(SB), NOSPLIT, $0-96
+	FMOVS alpha_real+0(FP), F0
+	FMOVS alpha_imag+4(FP), F1
+	MOVD x_base+8(FP), R0
+	MOVD y_base+32(FP), R1
+	MOVD n+56(FP), R3
+	MOVD incX+64(FP), R4
+	MOVD incY+72(FP), R5
+	MOVD ix+80(FP), R7
+	MOVD iy+88(FP), R8
+	ADD  R7<<3, R0 // R0 = &x[ix]
+	ADD  R8<<3, R1 // R1 = &y[iy]
+	MOVD R1, R2     // y is also the destination
+	MOVD R5, R6
+	LSL  $3, R4 // Convert the increments to bytes.
+	LSL  $3, R5
+	LSL  $3, R6
+	CBZ  R3, end
+
+	FNEGS F1, F2
+	VDUP  V0.S[0], V0.S4 // V0 = [real(alpha), real(alpha)]
+	VDUP  V1.S[0], V1.S4
+	VDUP  V2.S[0], V2.S4
+	VZIP1 V1.S4, V2.S4, V1.S4 // V1 = [-imag(alpha), imag(alpha)]
+
+loop: // dst[idst] = alpha * x[ix] + y[iy]
+	FMOVD  (R0), F2
+	FMOVD  (R1), F6
+	VREV64 V2.S4, V10.S4
+	VFMLA  V0.S4, V2.S4, V6.S4
+	VFMLA  V1.S4, V10.S4, V6.S4
+	FMOVD  F6, (R2)
+	ADD    R4, R0
+	ADD    R5, R1
+	ADD    R6, R2
+	SUB    $1, R3
+	CBNZ   R3, loop
+
+end:
+	RET
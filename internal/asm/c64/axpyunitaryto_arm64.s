@@ -0,0 +1,68 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func AxpyUnitaryTo(dst []complex64, alpha complex64, x, y []complex64)
+TEXT ·AxpyUnitaryTo(SB), NOSPLIT, $0-80
+	MOVD dst_base+0(FP), R2
+	MOVD dst_len+8(FP), R3
+	FMOVS alpha_real+24(FP), F0
+	FMOVS alpha_imag+28(FP), F1
+	MOVD x_base+32(FP), R0
+	MOVD x_len+40(FP), R4
+	MOVD y_base+56(FP), R1
+	MOVD y_len+64(FP), R5
+
+	CMP  R4, R3 // R3 = min(len(dst), len(x), len(y))
+	CSEL GT, R4, R3, R3
+	CMP  R5, R3
+	CSEL GT, R5, R3, R3
+	CBZ  R3, end
+
+	FNEGS F1, F2
+	VDUP  V0.S[0], V0.S4 // V0 = [real(alpha), real(alpha)]
+	VDUP  V1.S[0], V1.S4
+	VDUP  V2.S[0], V2.S4
+	VZIP1 V1.S4, V2.S4, V1.S4 // V1 = [-imag(alpha), imag(alpha)]
+	LSR  $3, R3, R5 // R5 = n / 8
+	CBZ  R5, tail_start
+
+loop: // dst[i:i+8] = alpha * x[i:i+8] + y[i:i+8]
+	VLD1.P 64(R0), [V2.S4, V3.S4, V4.S4, V5.S4]
+	VLD1.P 64(R1), [V6.S4, V7.S4, V8.S4, V9.S4]
+	VREV64 V2.S4, V10.S4
+	VREV64 V3.S4, V11.S4
+	VREV64 V4.S4, V12.S4
+	VREV64 V5.S4, V13.S4
+	VFMLA  V0.S4, V2.S4, V6.S4
+	VFMLA  V1.S4, V10.S4, V6.S4
+	VFMLA  V0.S4, V3.S4, V7.S4
+	VFMLA  V1.S4, V11.S4, V7.S4
+	VFMLA  V0.S4, V4.S4, V8.S4
+	VFMLA  V1.S4, V12.S4, V8.S4
+	VFMLA  V0.S4, V5.S4, V9.S4
+	VFMLA  V1.S4, V13.S4, V9.S4
+	VST1.P [V6.S4, V7.S4, V8.S4, V9.S4], 64(R2)
+	SUB    $1, R5
+	CBNZ   R5, loop
+
+tail_start:
+	AND $7, R3
+	CBZ R3, end
+
+tail: // dst[i] = alpha * x[i] + y[i]
+	FMOVD.P 8(R0), F2
+	FMOVD.P 8(R1), F6
+	VREV64 V2.S4, V10.S4
+	VFMLA  V0.S4, V2.S4, V6.S4
+	VFMLA  V1.S4, V10.S4, V6.S4
+	FMOVD.P F6, 8(R2)
+	SUB    $1, R3
+	CBNZ   R3, tail
+
+end:
+	RET
@@ -0,0 +1,51 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func DotcInc(x, y []complex64, n, incX, incY, ix, iy uintptr) (sum complex64)
+TEXT ·DotcInc(SB), NOSPLIT, $0-96
+	MOVD x_base+0(FP), R0
+	MOVD y_base+24(FP), R1
+	MOVD n+48(FP), R3
+	MOVD incX+56(FP), R4
+	MOVD incY+64(FP), R5
+	MOVD ix+72(FP), R7
+	MOVD iy+80(FP), R8
+	ADD  R7<<3, R0 // R0 = &x[ix]
+	ADD  R8<<3, R1 // R1 = &y[iy]
+	LSL  $3, R4    // Convert the increments to bytes.
+	LSL  $3, R5
+
+	VEOR V14.B16, V14.B16, V14.B16 // The partial sums are held in V14-V17.
+	VEOR V15.B16, V15.B16, V15.B16
+	VEOR V16.B16, V16.B16, V16.B16
+	VEOR V17.B16, V17.B16, V17.B16
+	CBZ R3, reduce
+
+loop: // sum += y[iy] * conj(x[ix])
+	FMOVD  (R0), F2
+	FMOVD  (R1), F6
+	VREV64 V2.S4, V10.S4
+	VFMLA  V2.S4, V6.S4, V14.S4
+	VFMLA  V10.S4, V6.S4, V16.S4
+	ADD    R4, R0
+	ADD    R5, R1
+	SUB    $1, R3
+	CBNZ   R3, loop
+
+reduce:
+	VEXT   $8, V14.B16, V14.B16, V15.B16 // Add the upper and lower halves.
+	VEXT   $8, V16.B16, V16.B16, V17.B16
+	VFADD  V15.S4, V14.S4, V14.S4
+	VFADD  V17.S4, V16.S4, V16.S4
+	VREV64 V14.S4, V15.S4
+	VREV64 V16.S4, V17.S4
+	FADDS F15, F14, F0 // real(sum) = Σ real(x)*real(y) + imag(x)*imag(y)
+	FSUBS F16, F17, F1 // imag(sum) = Σ real(x)*imag(y) - imag(x)*real(y)
+	FMOVS F0, sum_real+88(FP)
+	FMOVS F1, sum_imag+92(FP)
+	RET
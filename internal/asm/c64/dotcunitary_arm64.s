@@ -0,0 +1,70 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func DotcUnitary(x, y []complex64) (sum complex64)
+TEXT ·DotcUnitary(SB), NOSPLIT, $0-56
+	MOVD x_base+0(FP), R0
+	MOVD x_len+8(FP), R3
+	MOVD y_base+24(FP), R1
+	MOVD y_len+32(FP), R4
+
+	CMP  R4, R3 // R3 = min(len(x), len(y))
+	CSEL GT, R4, R3, R3
+
+	VEOR V14.B16, V14.B16, V14.B16 // The partial sums are held in V14-V17.
+	VEOR V15.B16, V15.B16, V15.B16
+	VEOR V16.B16, V16.B16, V16.B16
+	VEOR V17.B16, V17.B16, V17.B16
+	LSR  $3, R3, R5 // R5 = n / 8
+	CBZ  R5, tail_start
+
+loop: // sum += y[i:i+8] * conj(x[i:i+8])
+	VLD1.P 64(R0), [V2.S4, V3.S4, V4.S4, V5.S4]
+	VLD1.P 64(R1), [V6.S4, V7.S4, V8.S4, V9.S4]
+	VREV64 V2.S4, V10.S4
+	VREV64 V3.S4, V11.S4
+	VREV64 V4.S4, V12.S4
+	VREV64 V5.S4, V13.S4
+	VFMLA  V2.S4, V6.S4, V14.S4
+	VFMLA  V10.S4, V6.S4, V16.S4
+	VFMLA  V3.S4, V7.S4, V15.S4
+	VFMLA  V11.S4, V7.S4, V17.S4
+	VFMLA  V4.S4, V8.S4, V14.S4
+	VFMLA  V12.S4, V8.S4, V16.S4
+	VFMLA  V5.S4, V9.S4, V15.S4
+	VFMLA  V13.S4, V9.S4, V17.S4
+	SUB    $1, R5
+	CBNZ   R5, loop
+
+tail_start:
+	VFADD V15.S4, V14.S4, V14.S4
+	VFADD V17.S4, V16.S4, V16.S4
+	AND   $7, R3
+	CBZ   R3, reduce
+
+tail:
+	FMOVD.P 8(R0), F2
+	FMOVD.P 8(R1), F6
+	VREV64 V2.S4, V10.S4
+	VFMLA  V2.S4, V6.S4, V14.S4
+	VFMLA  V10.S4, V6.S4, V16.S4
+	SUB    $1, R3
+	CBNZ   R3, tail
+
+reduce:
+	VEXT   $8, V14.B16, V14.B16, V15.B16 // Add the upper and lower halves.
+	VEXT   $8, V16.B16, V16.B16, V17.B16
+	VFADD  V15.S4, V14.S4, V14.S4
+	VFADD  V17.S4, V16.S4, V16.S4
+	VREV64 V14.S4, V15.S4
+	VREV64 V16.S4, V17.S4
+	FADDS F15, F14, F0 // real(sum) = Σ real(x)*real(y) + imag(x)*imag(y)
+	FSUBS F16, F17, F1 // imag(sum) = Σ real(x)*imag(y) - imag(x)*real(y)
+	FMOVS F0, sum_real+48(FP)
+	FMOVS F1, sum_imag+52(FP)
+	RET
@@ -0,0 +1,71 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// L2NormUnitary returns the L2-norm of x.
+// func L2NormUnitary(x []complex64) (norm float32)
+TEXT ·L2NormUnitary(SB), NOSPLIT, $0-28
+	MOVD  x_base+0(FP), R0
+	MOVD  x_len+8(FP), R1
+	FMOVS $1.0, F31 // F31 = 1
+	VEOR  V0.B16, V0.B16, V0.B16 // scale = 0
+	FMOVS $1.0, F1  // sumSquares = 1
+	CBZ   R1, ret
+
+loop:
+	FMOVS  (R0), F2
+	FMOVS  4(R0), F5
+	ADD    $8, R0
+	FABSS  F2, F2 // |re| = |real(x[i])|
+	FABSS  F5, F5 // |im| = |imag(x[i])|
+
+	FCMPS  $(0.0), F2
+	BEQ    im         // if |re| == 0 { skip }
+	FCMPS  F0, F2
+	BGT    re_rescale // if |re| > scale { goto re_rescale }
+
+	FDIVS  F0, F2, F3     // s = |re| / scale
+	FMADDS F3, F1, F3, F1 // sumSquares += s * s
+	B      im
+
+re_rescale:
+	FDIVS  F2, F0, F3      // s = scale / |re|
+	FMULS  F3, F1
+	FMADDS F3, F31, F1, F1 // sumSquares = 1 + sumSquares * s * s
+	FMOVS  F2, F0          // scale = |re|
+
+im:
+	FCMPS  $(0.0), F5
+	BEQ    next         // if |im| == 0 { skip }
+	FCMPS  F0, F5
+	BGT    im_rescale // if |im| > scale { goto im_rescale }
+
+	FDIVS  F0, F5, F3     // s = |im| / scale
+	FMADDS F3, F1, F3, F1 // sumSquares += s * s
+	B      next
+
+im_rescale:
+	FDIVS  F5, F0, F3      // s = scale / |im|
+	FMULS  F3, F1
+	FMADDS F3, F31, F1, F1 // sumSquares = 1 + sumSquares * s * s
+	FMOVS  F5, F0          // scale = |im|
+
+next:
+	SUB  $1, R1
+	CBNZ R1, loop
+
+ret:
+	MOVW   $0x7F800000, R2
+	FMOVS  R2, F4
+	FCMPS  F4, F0
+	BEQ    inf             // if isInf(scale, 1) { return Inf }
+	FSQRTS F1, F1
+	FMULS  F1, F0          // norm = scale * sqrt(sumSquares)
+
+inf:
+	FMOVS F0, norm+24(FP)
+	RET
@@ -0,0 +1,97 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package c64
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	math "gonum.org/v1/gonum/internal/math32"
+)
+
+var l2NormTests = []struct {
+	want float32
+	x    []complex64
+}{
+	{want: 0, x: []complex64{}},
+	{want: 0, x: []complex64{0, 0}},
+	{want: 2, x: []complex64{2}},
+	{want: 2, x: []complex64{-2i}},
+	{want: 5, x: []complex64{3 + 4i}},
+	{want: 3.7416575, x: []complex64{1, 2i, -3}},
+	{want: 5.4772256, x: []complex64{1 + 2i, -3 - 4i}},
+	{want: 1.4142135, x: []complex64{0, 1, 0, -1i, 0}},
+	{want: 1.4142135e20, x: []complex64{1e20, 1e20i}},
+	{want: 1.4142135e-20, x: []complex64{1e-20i, -1e-20}},
+	{want: math.NaN(), x: []complex64{complex(math.NaN(), 1)}},
+	{want: math.NaN(), x: []complex64{1, complex(2, math.NaN()), 3}},
+	{want: math.Inf(1), x: []complex64{1, complex(math.Inf(-1), 2), 3}},
+}
+
+func TestL2NormUnitary(t *testing.T) {
+	const gdVal = 1 + 1i
+	for i, test := range l2NormTests {
+		for _, gdLen := range []int{4, 5} {
+			xg := guardVector(test.x, gdVal, gdLen)
+			x := xg[gdLen : len(xg)-gdLen]
+			got := L2NormUnitary(x)
+			if !closeNorm(got, test.want) {
+				t.Errorf("test %d: unexpected result: got %v want %v", i, got, test.want)
+			}
+			if !isValidGuard(xg, gdVal, gdLen) {
+				t.Errorf("test %d: guard violated", i)
+			}
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < 35; n++ {
+		x := make([]complex64, n)
+		for i := range x {
+			x[i] = complex(float32(rnd.NormFloat64()), float32(rnd.NormFloat64()))
+		}
+		got := L2NormUnitary(x)
+		want := naiveL2Norm(x)
+		if !closeNorm(got, want) {
+			t.Errorf("n=%d: unexpected result: got %v want %v", n, got, want)
+		}
+	}
+}
+
+func TestL2NormInc(t *testing.T) {
+	const gdVal = 1 + 1i
+	for i, test := range l2NormTests {
+		for _, inc := range []int{1, 2, 3, 10} {
+			prefix := fmt.Sprintf("test %d inc=%d", i, inc)
+			gdLen := 4 + i%2
+			xg := guardIncVector(test.x, gdVal, inc, gdLen)
+			x := xg[gdLen : len(xg)-gdLen]
+			got := L2NormInc(x, uintptr(len(test.x)), uintptr(inc))
+			if !closeNorm(got, test.want) {
+				t.Errorf("%s: unexpected result: got %v want %v", prefix, got, test.want)
+			}
+			checkValidIncGuard(t, xg, gdVal, inc, gdLen)
+		}
+	}
+}
+
+// naiveL2Norm returns the L2-norm of x without guarding against overflow.
+func naiveL2Norm(x []complex64) float32 {
+	var sum float32
+	for _, v := range x {
+		sum += real(v)*real(v) + imag(v)*imag(v)
+	}
+	return math.Sqrt(sum)
+}
+
+func closeNorm(got, want float32) bool {
+	const tol = 1e-6
+	if math.IsNaN(want) || math.IsInf(want, 0) {
+		return math.IsNaN(got) && math.IsNaN(want) || got == want
+	}
+	return math.Abs(got-want) <= tol*want
+}
@@ -0,0 +1,80 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func AxpyIncTo(dst []float32, incDst, idst uintptr, alpha float32, x, y []float32, n, incX, incY, ix, iy uintptr)
+TEXT ·AxpyIncTo(SB), NOSPLIT, $0-136
+	MOVD  dst_base+0(FP), R2
+	MOVD  incDst+24(FP), R6
+	MOVD  idst+32(FP), R9
+	FMOVS alpha+40(FP), F0
+	MOVD  x_base+48(FP), R0
+	MOVD  y_base+72(FP), R1
+	MOVD  n+96(FP), R3
+	MOVD  incX+104(FP), R4
+	MOVD  incY+112(FP), R5
+	MOVD  ix+120(FP), R7
+	MOVD  iy+128(FP), R8
+	ADD   R7<<2, R0 // R0 = &x[ix]
+	ADD   R8<<2, R1 // R1 = &y[iy]
+	ADD   R9<<2, R2 // R2 = &dst[idst]
+	LSL   $2, R4 // Convert the increments to bytes.
+	LSL   $2, R5
+	LSL   $2, R6
+
+	LSR $2, R3, R7 // R7 = n / 4
+	CBZ R7, tail_start
+
+loop: // Unroll the loop four times.
+	FMOVS (R0), F1
+	ADD   R4, R0
+	FMOVS (R0), F2
+	ADD   R4, R0
+	FMOVS (R0), F3
+	ADD   R4, R0
+	FMOVS (R0), F4
+	ADD   R4, R0
+	FMOVS (R1), F5
+	ADD   R5, R1
+	FMOVS (R1), F6
+	ADD   R5, R1
+	FMOVS (R1), F7
+	ADD   R5, R1
+	FMOVS (R1), F8
+	ADD   R5, R1
+	FMADDS F0, F5, F1, F5
+	FMADDS F0, F6, F2, F6
+	FMADDS F0, F7, F3, F7
+	FMADDS F0, F8, F4, F8
+	FMOVS F5, (R2)
+	ADD   R6, R2
+	FMOVS F6, (R2)
+	ADD   R6, R2
+	FMOVS F7, (R2)
+	ADD   R6, R2
+	FMOVS F8, (R2)
+	ADD   R6, R2
+	SUB   $1, R7
+	CBNZ  R7, loop
+
+tail_start:
+	AND $3, R3
+	CBZ R3, end
+
+tail:
+	FMOVS  (R0), F1
+	FMOVS  (R1), F2
+	FMADDS F0, F2, F1, F2
+	FMOVS  F2, (R2)
+	ADD    R4, R0
+	ADD    R5, R1
+	ADD    R6, R2
+	SUB    $1, R3
+	CBNZ   R3, tail
+
+end:
+	RET
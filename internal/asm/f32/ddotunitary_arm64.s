@@ -0,0 +1,69 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func DdotUnitary(x, y []float32) (sum float64)
+TEXT ·DdotUnitary(SB), NOSPLIT, $0-56
+	MOVD x_base+0(FP), R0
+	MOVD x_len+8(FP), R2
+	MOVD y_base+24(FP), R1
+	MOVD y_len+32(FP), R3
+
+	CMP  R3, R2 // R2 = min(len(x), len(y))
+	CSEL GT, R3, R2, R2
+
+	VEOR V0.B16, V0.B16, V0.B16 // The four partial sums are held in F0-F3.
+	VEOR V1.B16, V1.B16, V1.B16
+	VEOR V2.B16, V2.B16, V2.B16
+	VEOR V3.B16, V3.B16, V3.B16
+	LSR  $2, R2, R4 // R4 = n / 4
+	CBZ  R4, tail_start
+
+loop: // sum += float64(x[i:i+4]) * float64(y[i:i+4])
+	FMOVS.P 4(R0), F4
+	FMOVS.P 4(R0), F5
+	FMOVS.P 4(R0), F6
+	FMOVS.P 4(R0), F7
+	FMOVS.P 4(R1), F8
+	FMOVS.P 4(R1), F9
+	FMOVS.P 4(R1), F10
+	FMOVS.P 4(R1), F11
+	FCVTSD  F4, F4
+	FCVTSD  F5, F5
+	FCVTSD  F6, F6
+	FCVTSD  F7, F7
+	FCVTSD  F8, F8
+	FCVTSD  F9, F9
+	FCVTSD  F10, F10
+	FCVTSD  F11, F11
+	FMADDD  F4, F0, F8, F0
+	FMADDD  F5, F1, F9, F1
+	FMADDD  F6, F2, F10, F2
+	FMADDD  F7, F3, F11, F3
+	SUB     $1, R4
+	CBNZ    R4, loop
+
+	FADDD F1, F0
+	FADDD F3, F2
+	FADDD F2, F0
+
+tail_start:
+	AND $3, R2
+	CBZ R2, end
+
+tail: // sum += float64(x[i]) * float64(y[i])
+	FMOVS.P 4(R0), F4
+	FMOVS.P 4(R1), F8
+	FCVTSD  F4, F4
+	FCVTSD  F8, F8
+	FMADDD  F4, F0, F8, F0
+	SUB     $1, R2
+	CBNZ    R2, tail
+
+end:
+	FMOVD F0, sum+48(FP)
+	RET
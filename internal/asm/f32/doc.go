@@ -3,4 +3,7 @@
 // license that can be found in the LICENSE file.
 
 // Package f32 provides float32 vector primitives.
+//
+// On arm64 the axpy, dot and Ger kernels are implemented in assembly. The
+// Gemm microkernel uses the pure Go implementation on that architecture.
 package f32 // import "gonum.org/v1/gonum/internal/asm/f32"
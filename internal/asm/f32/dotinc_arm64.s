@@ -0,0 +1,73 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func DotInc(x, y []float32, n, incX, incY, ix, iy uintptr) (sum float32)
+TEXT ·DotInc(SB), NOSPLIT, $0-92
+	MOVD x_base+0(FP), R0
+	MOVD y_base+24(FP), R1
+	MOVD n+48(FP), R2
+	MOVD incX+56(FP), R3
+	MOVD incY+64(FP), R4
+	MOVD ix+72(FP), R5
+	MOVD iy+80(FP), R6
+	ADD  R5<<2, R0 // R0 = &x[ix]
+	ADD  R6<<2, R1 // R1 = &y[iy]
+	LSL  $2, R3    // Convert the increments to bytes.
+	LSL  $2, R4
+
+	VEOR V0.B16, V0.B16, V0.B16 // The four partial sums are held in F0-F3.
+	VEOR V1.B16, V1.B16, V1.B16
+	VEOR V2.B16, V2.B16, V2.B16
+	VEOR V3.B16, V3.B16, V3.B16
+	LSR  $2, R2, R5 // R5 = n / 4
+	CBZ  R5, tail_start
+
+loop: // Unroll the loop four times.
+	FMOVS (R0), F4
+	ADD   R3, R0
+	FMOVS (R0), F5
+	ADD   R3, R0
+	FMOVS (R0), F6
+	ADD   R3, R0
+	FMOVS (R0), F7
+	ADD   R3, R0
+	FMOVS (R1), F8
+	ADD   R4, R1
+	FMOVS (R1), F9
+	ADD   R4, R1
+	FMOVS (R1), F10
+	ADD   R4, R1
+	FMOVS (R1), F11
+	ADD   R4, R1
+	FMADDS F4, F0, F8, F0
+	FMADDS F5, F1, F9, F1
+	FMADDS F6, F2, F10, F2
+	FMADDS F7, F3, F11, F3
+	SUB   $1, R5
+	CBNZ  R5, loop
+
+	FADDS F1, F0
+	FADDS F3, F2
+	FADDS F2, F0
+
+tail_start:
+	AND $3, R2
+	CBZ R2, end
+
+tail:
+	FMOVS  (R0), F4
+	FMOVS  (R1), F8
+	FMADDS F4, F0, F8, F0
+	ADD    R3, R0
+	ADD    R4, R1
+	SUB    $1, R2
+	CBNZ   R2, tail
+
+end:
+	FMOVS F0, sum+88(FP)
+	RET
@@ -0,0 +1,55 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func DotUnitary(x, y []float32) (sum float32)
+TEXT ·DotUnitary(SB), NOSPLIT, $0-52
+	MOVD x_base+0(FP), R0
+	MOVD x_len+8(FP), R2
+	MOVD y_base+24(FP), R1
+	MOVD y_len+32(FP), R3
+
+	CMP  R3, R2 // R2 = min(len(x), len(y))
+	CSEL GT, R3, R2, R2
+
+	VEOR V0.B16, V0.B16, V0.B16 // The partial sums are held in V0-V3.
+	VEOR V1.B16, V1.B16, V1.B16
+	VEOR V2.B16, V2.B16, V2.B16
+	VEOR V3.B16, V3.B16, V3.B16
+	LSR  $4, R2, R4 // R4 = n / 16
+	CBZ  R4, reduce
+
+loop: // sum += x[i:i+16] * y[i:i+16]
+	VLD1.P 64(R0), [V4.S4, V5.S4, V6.S4, V7.S4]
+	VLD1.P 64(R1), [V8.S4, V9.S4, V10.S4, V11.S4]
+	VFMLA  V4.S4, V8.S4, V0.S4
+	VFMLA  V5.S4, V9.S4, V1.S4
+	VFMLA  V6.S4, V10.S4, V2.S4
+	VFMLA  V7.S4, V11.S4, V3.S4
+	SUB    $1, R4
+	CBNZ   R4, loop
+
+reduce: // Add the partial sums.
+	VFADD  V1.S4, V0.S4, V0.S4
+	VFADD  V3.S4, V2.S4, V2.S4
+	VFADD  V2.S4, V0.S4, V0.S4
+	VFADDP V0.S4, V0.S4, V0.S4
+	VFADDP V0.S4, V0.S4, V0.S4
+
+	AND $15, R2
+	CBZ R2, end
+
+tail: // sum += x[i] * y[i]
+	FMOVS.P 4(R0), F4
+	FMOVS.P 4(R1), F8
+	FMADDS  F4, F0, F8, F0
+	SUB    $1, R2
+	CBNZ   R2, tail
+
+end:
+	FMOVS F0, sum+48(FP)
+	RET
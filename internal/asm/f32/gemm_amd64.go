@@ -0,0 +1,28 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+package f32
+
+import "gonum.org/v1/gonum/internal/cpu"
+
+// HasGemmKernel reports whether GemmKernel uses a register-blocked assembly
+// microkernel. It is true when the processor supports AVX2 and FMA.
+var HasGemmKernel = cpu.X86.HasAVX2 && cpu.X86.HasFMA
+
+// GemmKernel computes
+//  C += alpha * A * B
+// where C is a GemmMR×GemmNR block with row stride ldc, A is a GemmMR×k panel
+// packed by GemmPackA and B is a k×GemmNR panel packed by GemmPackB.
+func GemmKernel(k int, alpha float32, a, b, c []float32, ldc int) {
+	if HasGemmKernel {
+		gemmKernel4x16(uintptr(k), alpha, a, b, c, uintptr(ldc))
+		return
+	}
+	gemmKernelGeneric(k, alpha, a, b, c, ldc)
+}
+
+// gemmKernel4x16 is the AVX2/FMA implementation of GemmKernel.
+func gemmKernel4x16(k uintptr, alpha float32, a, b, c []float32, ldc uintptr)
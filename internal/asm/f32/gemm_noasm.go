@@ -0,0 +1,19 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !amd64 noasm appengine safe
+
+package f32
+
+// HasGemmKernel reports whether GemmKernel uses a register-blocked assembly
+// microkernel.
+const HasGemmKernel = false
+
+// GemmKernel computes
+//  C += alpha * A * B
+// where C is a GemmMR×GemmNR block with row stride ldc, A is a GemmMR×k panel
+// packed by GemmPackA and B is a k×GemmNR panel packed by GemmPackB.
+func GemmKernel(k int, alpha float32, a, b, c []float32, ldc int) {
+	gemmKernelGeneric(k, alpha, a, b, c, ldc)
+}
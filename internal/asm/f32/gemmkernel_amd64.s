@@ -0,0 +1,124 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+#define K CX
+#define A_PTR SI
+#define B_PTR DI
+#define C_PTR DX
+#define LDC R8
+#define ROW R9
+
+// func gemmKernel4x16(k uintptr, alpha float32, a, b, c []float32, ldc uintptr)
+TEXT ·gemmKernel4x16(SB), NOSPLIT, $0-96
+	MOVQ k+0(FP), K
+	MOVQ a_base+16(FP), A_PTR
+	MOVQ b_base+40(FP), B_PTR
+	MOVQ c_base+64(FP), C_PTR
+	MOVQ ldc+88(FP), LDC
+	SHLQ $2, LDC
+	VBROADCASTSS alpha+8(FP), Y12
+
+	// Y0-Y7 hold the 4×16 block of C, two registers per row. The rank-one
+	// updates are accumulated into C in order of increasing k, as the
+	// unpacked Gemm does.
+	MOVQ C_PTR, ROW
+	VMOVUPS (ROW), Y0
+	VMOVUPS 32(ROW), Y1
+	ADDQ LDC, ROW
+	VMOVUPS (ROW), Y2
+	VMOVUPS 32(ROW), Y3
+	ADDQ LDC, ROW
+	VMOVUPS (ROW), Y4
+	VMOVUPS 32(ROW), Y5
+	ADDQ LDC, ROW
+	VMOVUPS (ROW), Y6
+	VMOVUPS 32(ROW), Y7
+
+	SHRQ $1, K
+	JZ   tail
+
+loop: // Two rank-one updates per iteration.
+	VMOVUPS 0(B_PTR), Y8
+	VMOVUPS 32(B_PTR), Y9
+	VBROADCASTSS 0(A_PTR), Y10
+	VMULPS Y12, Y10, Y10
+	VFMADD231PS Y8, Y10, Y0
+	VFMADD231PS Y9, Y10, Y1
+	VBROADCASTSS 4(A_PTR), Y11
+	VMULPS Y12, Y11, Y11
+	VFMADD231PS Y8, Y11, Y2
+	VFMADD231PS Y9, Y11, Y3
+	VBROADCASTSS 8(A_PTR), Y10
+	VMULPS Y12, Y10, Y10
+	VFMADD231PS Y8, Y10, Y4
+	VFMADD231PS Y9, Y10, Y5
+	VBROADCASTSS 12(A_PTR), Y11
+	VMULPS Y12, Y11, Y11
+	VFMADD231PS Y8, Y11, Y6
+	VFMADD231PS Y9, Y11, Y7
+	VMOVUPS 64(B_PTR), Y8
+	VMOVUPS 96(B_PTR), Y9
+	VBROADCASTSS 16(A_PTR), Y10
+	VMULPS Y12, Y10, Y10
+	VFMADD231PS Y8, Y10, Y0
+	VFMADD231PS Y9, Y10, Y1
+	VBROADCASTSS 20(A_PTR), Y11
+	VMULPS Y12, Y11, Y11
+	VFMADD231PS Y8, Y11, Y2
+	VFMADD231PS Y9, Y11, Y3
+	VBROADCASTSS 24(A_PTR), Y10
+	VMULPS Y12, Y10, Y10
+	VFMADD231PS Y8, Y10, Y4
+	VFMADD231PS Y9, Y10, Y5
+	VBROADCASTSS 28(A_PTR), Y11
+	VMULPS Y12, Y11, Y11
+	VFMADD231PS Y8, Y11, Y6
+	VFMADD231PS Y9, Y11, Y7
+
+	ADDQ $32, A_PTR
+	ADDQ $128, B_PTR
+	DECQ K
+	JNZ  loop
+
+tail:
+	MOVQ k+0(FP), K
+	ANDQ $1, K
+	JZ   store
+	VMOVUPS 0(B_PTR), Y8
+	VMOVUPS 32(B_PTR), Y9
+	VBROADCASTSS 0(A_PTR), Y10
+	VMULPS Y12, Y10, Y10
+	VFMADD231PS Y8, Y10, Y0
+	VFMADD231PS Y9, Y10, Y1
+	VBROADCASTSS 4(A_PTR), Y11
+	VMULPS Y12, Y11, Y11
+	VFMADD231PS Y8, Y11, Y2
+	VFMADD231PS Y9, Y11, Y3
+	VBROADCASTSS 8(A_PTR), Y10
+	VMULPS Y12, Y10, Y10
+	VFMADD231PS Y8, Y10, Y4
+	VFMADD231PS Y9, Y10, Y5
+	VBROADCASTSS 12(A_PTR), Y11
+	VMULPS Y12, Y11, Y11
+	VFMADD231PS Y8, Y11, Y6
+	VFMADD231PS Y9, Y11, Y7
+
+store:
+	VMOVUPS Y0, (C_PTR)
+	VMOVUPS Y1, 32(C_PTR)
+	ADDQ LDC, C_PTR
+	VMOVUPS Y2, (C_PTR)
+	VMOVUPS Y3, 32(C_PTR)
+	ADDQ LDC, C_PTR
+	VMOVUPS Y4, (C_PTR)
+	VMOVUPS Y5, 32(C_PTR)
+	ADDQ LDC, C_PTR
+	VMOVUPS Y6, (C_PTR)
+	VMOVUPS Y7, 32(C_PTR)
+	VZEROUPPER
+	RET
@@ -0,0 +1,102 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func Ger(m, n uintptr, alpha float32, x []float32, incX uintptr, y []float32, incY uintptr, a []float32, lda uintptr)
+TEXT ·Ger(SB), NOSPLIT, $0-120
+	MOVD  m+0(FP), R0
+	MOVD  n+8(FP), R1
+	FMOVS alpha+16(FP), F28
+	MOVD  x_base+24(FP), R4
+	MOVD  incX+48(FP), R5
+	MOVD  y_base+56(FP), R6
+	MOVD  incY+80(FP), R7
+	MOVD  a_base+88(FP), R2
+	MOVD  lda+112(FP), R3
+	CBZ   R0, end
+	CBZ   R1, end
+
+	LSL $2, R3 // Convert lda and the increments to bytes.
+	LSL $2, R5
+	LSL $2, R7
+
+	TBZ $63, R5, x_start // If incX < 0, start at x[-(m-1)*incX].
+	SUB $1, R0, R8
+	MUL R5, R8
+	SUB R8, R4
+
+x_start:
+	TBZ $63, R7, row // If incY < 0, start at y[-(n-1)*incY].
+	SUB $1, R1, R8
+	MUL R7, R8
+	SUB R8, R6
+
+row: // a[i*lda:i*lda+n] += alpha * x[ix] * y
+	FMOVS (R4), F0
+	ADD   R5, R4
+	FMULS F28, F0
+	MOVD  R6, R10
+	MOVD  R2, R11 // a is also the destination
+	MOVD  R2, R13
+	CMP   $4, R7
+	BNE   inc
+
+	VDUP V0.S[0], V0.S4
+	LSR  $4, R1, R12 // R12 = n / 16
+	CBZ  R12, tail_start
+
+loop: // a[i*lda+j:i*lda+j+16] += alpha * x[ix] * y[j:j+16]
+	// The multiply and add are not fused so that the rounding matches
+	// the other implementations.
+	VLD1.P 64(R10), [V1.S4, V2.S4, V3.S4, V4.S4]
+	VLD1.P 64(R11), [V5.S4, V6.S4, V7.S4, V8.S4]
+	VFMUL  V0.S4, V1.S4, V1.S4
+	VFMUL  V0.S4, V2.S4, V2.S4
+	VFMUL  V0.S4, V3.S4, V3.S4
+	VFMUL  V0.S4, V4.S4, V4.S4
+	VFADD  V1.S4, V5.S4, V5.S4
+	VFADD  V2.S4, V6.S4, V6.S4
+	VFADD  V3.S4, V7.S4, V7.S4
+	VFADD  V4.S4, V8.S4, V8.S4
+	VST1.P [V5.S4, V6.S4, V7.S4, V8.S4], 64(R13)
+	SUB    $1, R12
+	CBNZ   R12, loop
+
+tail_start:
+	AND $15, R1, R12
+	CBZ R12, next
+
+tail: // a[i*lda+j] += alpha * x[ix] * y[j]
+	FMOVS.P 4(R10), F1
+	FMOVS.P 4(R11), F2
+	FMULS   F0, F1
+	FADDS   F1, F2
+	FMOVS.P F2, 4(R13)
+	SUB     $1, R12
+	CBNZ    R12, tail
+	B       next
+
+inc: // a[i*lda+j] += alpha * x[ix] * y[jy]
+	MOVD R1, R12
+
+inc_loop:
+	FMOVS   (R10), F1
+	ADD     R7, R10
+	FMOVS   (R11), F2
+	FMULS   F0, F1
+	FADDS   F1, F2
+	FMOVS.P F2, 4(R11)
+	SUB     $1, R12
+	CBNZ    R12, inc_loop
+
+next:
+	ADD  R3, R2
+	SUB  $1, R0
+	CBNZ R0, row
+
+end:
+	RET
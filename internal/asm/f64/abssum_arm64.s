@@ -0,0 +1,52 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func L1Norm(x []float64) (sum float64)
+TEXT ·L1Norm(SB), NOSPLIT, $0-32
+	MOVD x_base+0(FP), R0
+	MOVD x_len+8(FP), R2
+
+	VEOR V0.B16, V0.B16, V0.B16 // The partial sums are held in V0-V3.
+	VEOR V1.B16, V1.B16, V1.B16
+	VEOR V2.B16, V2.B16, V2.B16
+	VEOR V3.B16, V3.B16, V3.B16
+	LSR  $3, R2, R3 // R3 = n / 8
+	CBZ  R3, reduce
+
+loop: // sum += |x[i:i+8]|
+	VLD1.P 64(R0), [V4.D2, V5.D2, V6.D2, V7.D2]
+	VFABS  V4.D2, V4.D2
+	VFABS  V5.D2, V5.D2
+	VFABS  V6.D2, V6.D2
+	VFABS  V7.D2, V7.D2
+	VFADD  V4.D2, V0.D2, V0.D2
+	VFADD  V5.D2, V1.D2, V1.D2
+	VFADD  V6.D2, V2.D2, V2.D2
+	VFADD  V7.D2, V3.D2, V3.D2
+	SUB    $1, R3
+	CBNZ   R3, loop
+
+reduce: // Add the partial sums.
+	VFADD  V1.D2, V0.D2, V0.D2
+	VFADD  V3.D2, V2.D2, V2.D2
+	VFADD  V2.D2, V0.D2, V0.D2
+	VFADDP V0.D2, V0.D2, V0.D2
+
+	AND $7, R2
+	CBZ R2, end
+
+tail: // sum += |x[i]|
+	FMOVD.P 8(R0), F4
+	FABSD   F4, F4
+	FADDD   F4, F0
+	SUB     $1, R2
+	CBNZ    R2, tail
+
+end:
+	FMOVD F0, sum+24(FP)
+	RET
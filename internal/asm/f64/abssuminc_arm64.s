@@ -0,0 +1,45 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func L1NormInc(x []float64, n, incX int) (sum float64)
+TEXT ·L1NormInc(SB), NOSPLIT, $0-48
+	MOVD x_base+0(FP), R0
+	MOVD n+24(FP), R2
+	MOVD incX+32(FP), R3
+	LSL  $3, R3 // Convert the increment to bytes.
+
+	VEOR V0.B16, V0.B16, V0.B16 // The two partial sums are held in F0 and F1.
+	VEOR V1.B16, V1.B16, V1.B16
+	LSR  $1, R2, R4 // R4 = n / 2
+	CBZ  R4, tail_start
+
+loop: // Unroll the loop two times.
+	FMOVD (R0), F2
+	ADD   R3, R0
+	FMOVD (R0), F3
+	ADD   R3, R0
+	FABSD F2, F2
+	FABSD F3, F3
+	FADDD F2, F0
+	FADDD F3, F1
+	SUB   $1, R4
+	CBNZ  R4, loop
+
+	FADDD F1, F0
+
+tail_start:
+	AND $1, R2
+	CBZ R2, end
+
+	FMOVD (R0), F2
+	FABSD F2, F2
+	FADDD F2, F0
+
+end:
+	FMOVD F0, sum+40(FP)
+	RET
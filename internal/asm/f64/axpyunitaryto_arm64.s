@@ -0,0 +1,53 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func AxpyUnitaryTo(dst []float64, alpha float64, x, y []float64)
+TEXT ·AxpyUnitaryTo(SB), NOSPLIT, $0-80
+	MOVD  dst_base+0(FP), R2
+	MOVD  dst_len+8(FP), R3
+	FMOVD alpha+24(FP), F0
+	MOVD  x_base+32(FP), R0
+	MOVD  x_len+40(FP), R4
+	MOVD  y_base+56(FP), R1
+	MOVD  y_len+64(FP), R5
+
+	CMP  R4, R3 // R3 = min(len(dst), len(x), len(y))
+	CSEL GT, R4, R3, R3
+	CMP  R5, R3
+	CSEL GT, R5, R3, R3
+	CBZ  R3, end
+
+	VDUP V0.D[0], V0.D2
+	LSR  $3, R3, R5 // R5 = n / 8
+	CBZ  R5, tail_start
+
+loop: // dst[i:i+8] = alpha * x[i:i+8] + y[i:i+8]
+	VLD1.P 64(R0), [V1.D2, V2.D2, V3.D2, V4.D2]
+	VLD1.P 64(R1), [V5.D2, V6.D2, V7.D2, V8.D2]
+	VFMLA  V0.D2, V1.D2, V5.D2
+	VFMLA  V0.D2, V2.D2, V6.D2
+	VFMLA  V0.D2, V3.D2, V7.D2
+	VFMLA  V0.D2, V4.D2, V8.D2
+	VST1.P [V5.D2, V6.D2, V7.D2, V8.D2], 64(R2)
+	SUB    $1, R5
+	CBNZ   R5, loop
+
+tail_start:
+	AND $7, R3
+	CBZ R3, end
+
+tail: // dst[i] = alpha * x[i] + y[i]
+	FMOVD.P 8(R0), F1
+	FMOVD.P 8(R1), F2
+	FMADDD  F0, F2, F1, F2
+	FMOVD.P F2, 8(R2)
+	SUB    $1, R3
+	CBNZ   R3, tail
+
+end:
+	RET
@@ -3,4 +3,9 @@
 // license that can be found in the LICENSE file.
 
 // Package f64 provides float64 vector primitives.
+//
+// On arm64 the axpy, dot, scal, L1 and L2 norm, GemvN, GemvT and Ger kernels
+// are implemented in assembly. The remaining primitives, including Add, Sum,
+// CumSum, L1Dist, LinfDist and the Gemm microkernel, use the pure Go
+// implementations on that architecture.
 package f64 // import "gonum.org/v1/gonum/internal/asm/f64"
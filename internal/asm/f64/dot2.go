@@ -0,0 +1,50 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package f64
+
+// dot2 continues the Dot2 accumulation of Ogita, Rump and Oishi over the
+// elements of x and y from the running sum p and accumulated error s, and
+// returns the compensated result p + s.
+func dot2(p, s float64, x, y []float64) float64 {
+	for i, v := range x {
+		h, r := TwoProd(v, y[i])
+		var q float64
+		p, q = TwoSum(p, h)
+		s += q + r
+	}
+	return p + s
+}
+
+// TwoSum returns the floating point sum of a and b and its rounding error,
+// so that sum + err == a + b exactly.
+func TwoSum(a, b float64) (sum, err float64) {
+	sum = a + b
+	z := sum - a
+	err = (a - (sum - z)) + (b - z)
+	return sum, err
+}
+
+// TwoProd returns the floating point product of a and b and its rounding
+// error, so that prod + err == a * b exactly in the absence of underflow
+// and overflow.
+func TwoProd(a, b float64) (prod, err float64) {
+	// The explicit conversions prevent the compiler from fusing the
+	// multiplications and additions, which would invalidate the
+	// error-free transformation.
+	prod = float64(a * b)
+	a1, a2 := split(a)
+	b1, b2 := split(b)
+	err = float64(a2*b2) - (((prod - float64(a1*b1)) - float64(a2*b1)) - float64(a1*b2))
+	return prod, err
+}
+
+// split returns the Veltkamp splitting of a into hi and lo, each with at
+// most 26 significant bits, such that hi + lo == a.
+func split(a float64) (hi, lo float64) {
+	const factor = 1<<27 + 1
+	c := float64(factor * a)
+	hi = c - (c - a)
+	return hi, a - hi
+}
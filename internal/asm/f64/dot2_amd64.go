@@ -0,0 +1,38 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+package f64
+
+import "gonum.org/v1/gonum/internal/cpu"
+
+// useDot2Kernel is whether Dot2Unitary uses the AVX2/FMA assembly kernel.
+var useDot2Kernel = cpu.X86.HasAVX2 && cpu.X86.HasFMA
+
+// Dot2Unitary returns the dot product of x and y computed as if in twice the
+// working precision using the Dot2 algorithm of Ogita, Rump and Oishi.
+// y must be at least as long as x.
+func Dot2Unitary(x, y []float64) float64 {
+	n := len(x) &^ 7
+	if !useDot2Kernel || n == 0 {
+		return dot2(0, 0, x, y)
+	}
+	var acc [16]float64
+	dot2Kernel(x[:n], y[:n], &acc)
+
+	// Combine the lanes of the kernel before accumulating the tail.
+	var p, s float64
+	for i, v := range acc[:8] {
+		var q float64
+		p, q = TwoSum(p, v)
+		s += q + acc[8+i]
+	}
+	return dot2(p, s, x[n:], y[n:len(x)])
+}
+
+// dot2Kernel performs the Dot2 accumulation over x and y, whose length must
+// be a multiple of eight, in eight independent lanes. On return acc[:8] holds
+// the running sums and acc[8:] the accumulated errors of the lanes.
+func dot2Kernel(x, y []float64, acc *[16]float64)
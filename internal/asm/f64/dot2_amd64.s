@@ -0,0 +1,76 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+#define X_PTR SI
+#define Y_PTR DI
+#define ACC DX
+#define LEN CX
+#define IDX AX
+
+// func dot2Kernel(x, y []float64, acc *[16]float64)
+TEXT ·dot2Kernel(SB), NOSPLIT, $0-56
+	MOVQ x_base+0(FP), X_PTR
+	MOVQ x_len+8(FP), LEN
+	MOVQ y_base+24(FP), Y_PTR
+	MOVQ acc+48(FP), ACC
+	XORQ IDX, IDX
+
+	// Y0 and Y1 hold the running sums and Y2 and Y3 the accumulated
+	// errors of two independent groups of four lanes.
+	VXORPD Y0, Y0, Y0
+	VXORPD Y1, Y1, Y1
+	VXORPD Y2, Y2, Y2
+	VXORPD Y3, Y3, Y3
+
+	SHRQ $3, LEN
+	JZ   end
+
+loop:
+	VMOVUPD (X_PTR)(IDX*8), Y4
+	VMOVUPD 32(X_PTR)(IDX*8), Y5
+
+	// TwoProduct: h + r == x * y.
+	VMULPD      (Y_PTR)(IDX*8), Y4, Y6    // h = x * y
+	VMULPD      32(Y_PTR)(IDX*8), Y5, Y7
+	VMOVAPD     Y6, Y8
+	VFMSUB231PD (Y_PTR)(IDX*8), Y4, Y8    // r = x * y - h
+	VMOVAPD     Y7, Y9
+	VFMSUB231PD 32(Y_PTR)(IDX*8), Y5, Y9
+
+	// TwoSum: t + q == p + h, then s += q + r and p = t.
+	VADDPD  Y6, Y0, Y10   // t = p + h
+	VSUBPD  Y0, Y10, Y11  // z = t - p
+	VSUBPD  Y11, Y10, Y12 // t - z
+	VSUBPD  Y12, Y0, Y12  // p - (t - z)
+	VSUBPD  Y11, Y6, Y11  // h - z
+	VADDPD  Y11, Y12, Y12 // q
+	VADDPD  Y8, Y12, Y12  // q + r
+	VADDPD  Y12, Y2, Y2   // s += q + r
+	VMOVAPD Y10, Y0       // p = t
+
+	VADDPD  Y7, Y1, Y13
+	VSUBPD  Y1, Y13, Y14
+	VSUBPD  Y14, Y13, Y15
+	VSUBPD  Y15, Y1, Y15
+	VSUBPD  Y14, Y7, Y14
+	VADDPD  Y14, Y15, Y15
+	VADDPD  Y9, Y15, Y15
+	VADDPD  Y15, Y3, Y3
+	VMOVAPD Y13, Y1
+
+	ADDQ $8, IDX
+	DECQ LEN
+	JNZ  loop
+
+end:
+	VMOVUPD Y0, (ACC)
+	VMOVUPD Y1, 32(ACC)
+	VMOVUPD Y2, 64(ACC)
+	VMOVUPD Y3, 96(ACC)
+	VZEROUPPER
+	RET
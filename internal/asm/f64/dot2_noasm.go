@@ -0,0 +1,14 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !amd64 noasm appengine safe
+
+package f64
+
+// Dot2Unitary returns the dot product of x and y computed as if in twice the
+// working precision using the Dot2 algorithm of Ogita, Rump and Oishi.
+// y must be at least as long as x.
+func Dot2Unitary(x, y []float64) float64 {
+	return dot2(0, 0, x, y)
+}
@@ -0,0 +1,70 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package f64
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+// exactOp returns the exact result of op applied to a and b, and the exact
+// sum of hi and lo.
+func exactOp(op func(z, x, y *big.Float) *big.Float, a, b, hi, lo float64) (want, got *big.Float) {
+	const prec = 2200
+	want = op(new(big.Float).SetPrec(prec), big.NewFloat(a), big.NewFloat(b))
+	got = new(big.Float).SetPrec(prec).Add(big.NewFloat(hi), big.NewFloat(lo))
+	return want, got
+}
+
+func errorFreeInputs(rnd *rand.Rand) [][2]float64 {
+	inputs := [][2]float64{
+		{0, 0},
+		{1, 1},
+		{1, -1},
+		{1, 1.0 / (1 << 53)},
+		{1 + 1.0/(1<<52), 1 - 1.0/(1<<53)},
+		{0.1, 0.3},
+		{1 << 60, -3},
+	}
+	for i := 0; i < 10000; i++ {
+		a := rnd.NormFloat64() * math.Pow(2, float64(rnd.Intn(200)-100))
+		b := rnd.NormFloat64() * math.Pow(2, float64(rnd.Intn(200)-100))
+		inputs = append(inputs, [2]float64{a, b})
+	}
+	return inputs
+}
+
+func TestTwoSum(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, in := range errorFreeInputs(rnd) {
+		a, b := in[0], in[1]
+		sum, err := TwoSum(a, b)
+		if sum != a+b {
+			t.Errorf("unexpected sum for %v + %v: got:%v want:%v", a, b, sum, a+b)
+		}
+		want, got := exactOp((*big.Float).Add, a, b, sum, err)
+		if got.Cmp(want) != 0 {
+			t.Errorf("sum and error of %v + %v not exact: got:%v want:%v", a, b, got, want)
+		}
+	}
+}
+
+func TestTwoProd(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, in := range errorFreeInputs(rnd) {
+		a, b := in[0], in[1]
+		prod, err := TwoProd(a, b)
+		if prod != a*b {
+			t.Errorf("unexpected product for %v * %v: got:%v want:%v", a, b, prod, a*b)
+		}
+		want, got := exactOp((*big.Float).Mul, a, b, prod, err)
+		if got.Cmp(want) != 0 {
+			t.Errorf("product and error of %v * %v not exact: got:%v want:%v", a, b, got, want)
+		}
+	}
+}
@@ -7,6 +7,7 @@ package f64
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"testing"
 
 	"golang.org/x/exp/rand"
@@ -279,3 +280,90 @@ func dotIncBenchmark(b *testing.B, n, inc int) {
 		r = DotInc(x, y, uintptr(n), uintptr(inc), uintptr(inc), uintptr(ini), uintptr(ini))
 	}
 }
+
+func TestDot2Unitary(t *testing.T) {
+	const eps = 1.0 / (1 << 53)
+	rnd := rand.New(rand.NewSource(1))
+	for _, impl := range []struct {
+		name string
+		fn   func(x, y []float64) float64
+	}{
+		{name: "Dot2Unitary", fn: Dot2Unitary},
+		{name: "generic", fn: func(x, y []float64) float64 { return dot2(0, 0, x, y) }},
+	} {
+		for _, n := range []int{0, 1, 2, 3, 7, 8, 9, 15, 16, 17, 31, 64, 101, 1000} {
+			for cancel := 0; cancel < 2; cancel++ {
+				xData := make([]float64, n)
+				yData := make([]float64, n)
+				for i := range xData {
+					xData[i] = rnd.NormFloat64() * math.Pow(2, float64(rnd.Intn(60)-30))
+					yData[i] = rnd.NormFloat64() * math.Pow(2, float64(rnd.Intn(60)-30))
+				}
+				if cancel == 1 {
+					// Make the dot product ill-conditioned by pairing
+					// each product with its negated rounded value, so
+					// that the exact result is the sum of the rounding
+					// errors of the products and a residual term.
+					for i := 0; i+1 < n; i += 2 {
+						xData[i+1] = -xData[i] * yData[i]
+						yData[i+1] = 1
+					}
+					if n%2 == 0 && n > 0 {
+						xData[n-1] = 1e-3
+						yData[n-1] = rnd.Float64()
+					}
+				}
+
+				x, xFront, xBack := newGuardedVector(xData, 1)
+				y, yFront, yBack := newGuardedVector(yData, 1)
+				got := impl.fn(x, y)
+				if !allNaN(xFront) || !allNaN(xBack) || !allNaN(yFront) || !allNaN(yBack) {
+					t.Errorf("%s n=%d: out-of-bounds write", impl.name, n)
+				}
+				if !equalStrided(xData, x, 1) || !equalStrided(yData, y, 1) {
+					t.Errorf("%s n=%d: modified read-only argument", impl.name, n)
+				}
+
+				want, abs := exactDot(xData, yData)
+				tol := eps*math.Abs(want) + float64(n*n)*eps*eps*abs
+				if math.Abs(got-want) > tol {
+					t.Errorf("%s n=%d cancel=%d: unexpected result: got:%v want:%v", impl.name, n, cancel, got, want)
+				}
+			}
+		}
+	}
+}
+
+// exactDot returns the correctly rounded dot product of x and y and the
+// sum of the absolute values of the products.
+func exactDot(x, y []float64) (dot, abs float64) {
+	sum := new(big.Float).SetPrec(4096)
+	p := new(big.Float).SetPrec(4096)
+	for i, v := range x {
+		p.SetFloat64(v)
+		p.Mul(p, new(big.Float).SetFloat64(y[i]))
+		sum.Add(sum, p)
+		abs += math.Abs(v * y[i])
+	}
+	dot, _ = sum.Float64()
+	return dot, abs
+}
+
+func BenchmarkDot2UnitaryN10(b *testing.B)     { dot2UnitaryBenchmark(b, 10) }
+func BenchmarkDot2UnitaryN1000(b *testing.B)   { dot2UnitaryBenchmark(b, 1000) }
+func BenchmarkDot2UnitaryN100000(b *testing.B) { dot2UnitaryBenchmark(b, 100000) }
+
+func dot2UnitaryBenchmark(b *testing.B, n int) {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = rand.Float64()
+	}
+	y := make([]float64, n)
+	for i := range y {
+		y[i] = rand.Float64()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r = Dot2Unitary(x, y)
+	}
+}
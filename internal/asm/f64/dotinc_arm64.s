@@ -0,0 +1,73 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func DotInc(x, y []float64, n, incX, incY, ix, iy uintptr) (sum float64)
+TEXT ·DotInc(SB), NOSPLIT, $0-96
+	MOVD x_base+0(FP), R0
+	MOVD y_base+24(FP), R1
+	MOVD n+48(FP), R2
+	MOVD incX+56(FP), R3
+	MOVD incY+64(FP), R4
+	MOVD ix+72(FP), R5
+	MOVD iy+80(FP), R6
+	ADD  R5<<3, R0 // R0 = &x[ix]
+	ADD  R6<<3, R1 // R1 = &y[iy]
+	LSL  $3, R3    // Convert the increments to bytes.
+	LSL  $3, R4
+
+	VEOR V0.B16, V0.B16, V0.B16 // The four partial sums are held in F0-F3.
+	VEOR V1.B16, V1.B16, V1.B16
+	VEOR V2.B16, V2.B16, V2.B16
+	VEOR V3.B16, V3.B16, V3.B16
+	LSR  $2, R2, R5 // R5 = n / 4
+	CBZ  R5, tail_start
+
+loop: // Unroll the loop four times.
+	FMOVD (R0), F4
+	ADD   R3, R0
+	FMOVD (R0), F5
+	ADD   R3, R0
+	FMOVD (R0), F6
+	ADD   R3, R0
+	FMOVD (R0), F7
+	ADD   R3, R0
+	FMOVD (R1), F8
+	ADD   R4, R1
+	FMOVD (R1), F9
+	ADD   R4, R1
+	FMOVD (R1), F10
+	ADD   R4, R1
+	FMOVD (R1), F11
+	ADD   R4, R1
+	FMADDD F4, F0, F8, F0
+	FMADDD F5, F1, F9, F1
+	FMADDD F6, F2, F10, F2
+	FMADDD F7, F3, F11, F3
+	SUB   $1, R5
+	CBNZ  R5, loop
+
+	FADDD F1, F0
+	FADDD F3, F2
+	FADDD F2, F0
+
+tail_start:
+	AND $3, R2
+	CBZ R2, end
+
+tail:
+	FMOVD  (R0), F4
+	FMOVD  (R1), F8
+	FMADDD F4, F0, F8, F0
+	ADD    R3, R0
+	ADD    R4, R1
+	SUB    $1, R2
+	CBNZ   R2, tail
+
+end:
+	FMOVD F0, sum+88(FP)
+	RET
@@ -0,0 +1,54 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func DotUnitary(x, y []float64) (sum float64)
+TEXT ·DotUnitary(SB), NOSPLIT, $0-56
+	MOVD x_base+0(FP), R0
+	MOVD x_len+8(FP), R2
+	MOVD y_base+24(FP), R1
+	MOVD y_len+32(FP), R3
+
+	CMP  R3, R2 // R2 = min(len(x), len(y))
+	CSEL GT, R3, R2, R2
+
+	VEOR V0.B16, V0.B16, V0.B16 // The partial sums are held in V0-V3.
+	VEOR V1.B16, V1.B16, V1.B16
+	VEOR V2.B16, V2.B16, V2.B16
+	VEOR V3.B16, V3.B16, V3.B16
+	LSR  $3, R2, R4 // R4 = n / 8
+	CBZ  R4, reduce
+
+loop: // sum += x[i:i+8] * y[i:i+8]
+	VLD1.P 64(R0), [V4.D2, V5.D2, V6.D2, V7.D2]
+	VLD1.P 64(R1), [V8.D2, V9.D2, V10.D2, V11.D2]
+	VFMLA  V4.D2, V8.D2, V0.D2
+	VFMLA  V5.D2, V9.D2, V1.D2
+	VFMLA  V6.D2, V10.D2, V2.D2
+	VFMLA  V7.D2, V11.D2, V3.D2
+	SUB    $1, R4
+	CBNZ   R4, loop
+
+reduce: // Add the partial sums.
+	VFADD  V1.D2, V0.D2, V0.D2
+	VFADD  V3.D2, V2.D2, V2.D2
+	VFADD  V2.D2, V0.D2, V0.D2
+	VFADDP V0.D2, V0.D2, V0.D2
+
+	AND $7, R2
+	CBZ R2, end
+
+tail: // sum += x[i] * y[i]
+	FMOVD.P 8(R0), F4
+	FMOVD.P 8(R1), F8
+	FMADDD  F4, F0, F8, F0
+	SUB    $1, R2
+	CBNZ   R2, tail
+
+end:
+	FMOVD F0, sum+48(FP)
+	RET
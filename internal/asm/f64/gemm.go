@@ -0,0 +1,78 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package f64
+
+// GemmMR and GemmNR are the numbers of rows and columns of the block of C
+// updated by a call to GemmKernel.
+const (
+	GemmMR = 4
+	GemmNR = 8
+)
+
+// GemmPackA packs the m×k matrix A, where element (i, p) is a[i*rs+p*cs],
+// into dst as a sequence of panels of GemmMR rows. Within a panel the k
+// columns are stored contiguously one after the other. Rows of the last
+// panel beyond m are filled with zeros. dst must have length at least
+// ceil(m/GemmMR)*GemmMR*k.
+func GemmPackA(dst, a []float64, rs, cs, m, k int) {
+	for i := 0; i < m; i += GemmMR {
+		mr := min(GemmMR, m-i)
+		for p := 0; p < k; p++ {
+			d := dst[p*GemmMR : p*GemmMR+GemmMR]
+			off := i*rs + p*cs
+			for r := 0; r < mr; r++ {
+				d[r] = a[off+r*rs]
+			}
+			for r := mr; r < GemmMR; r++ {
+				d[r] = 0
+			}
+		}
+		dst = dst[GemmMR*k:]
+	}
+}
+
+// GemmPackB packs the k×n matrix B, where element (p, j) is b[p*rs+j*cs],
+// into dst as a sequence of panels of GemmNR columns. Within a panel the k
+// rows are stored contiguously one after the other. Columns of the last
+// panel beyond n are filled with zeros. dst must have length at least
+// ceil(n/GemmNR)*GemmNR*k.
+func GemmPackB(dst, b []float64, rs, cs, k, n int) {
+	for j := 0; j < n; j += GemmNR {
+		nr := min(GemmNR, n-j)
+		for p := 0; p < k; p++ {
+			d := dst[p*GemmNR : p*GemmNR+GemmNR]
+			off := p*rs + j*cs
+			for c := 0; c < nr; c++ {
+				d[c] = b[off+c*cs]
+			}
+			for c := nr; c < GemmNR; c++ {
+				d[c] = 0
+			}
+		}
+		dst = dst[GemmNR*k:]
+	}
+}
+
+// gemmKernelGeneric is the pure Go implementation of GemmKernel. The
+// rank-one updates are accumulated into C in order of increasing k.
+func gemmKernelGeneric(k int, alpha float64, a, b, c []float64, ldc int) {
+	for p := 0; p < k; p++ {
+		bp := b[p*GemmNR : p*GemmNR+GemmNR]
+		for i, av := range a[p*GemmMR : p*GemmMR+GemmMR] {
+			tmp := alpha * av
+			row := c[i*ldc : i*ldc+GemmNR]
+			for j, bv := range bp {
+				row[j] += tmp * bv
+			}
+		}
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,98 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package f64
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestGemmKernel(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, k := range []int{0, 1, 2, 3, 7, 16, 65} {
+		for _, ldc := range []int{GemmNR, GemmNR + 3} {
+			for _, alpha := range []float64{0, 1, -2.5} {
+				name := fmt.Sprintf("k=%d,ldc=%d,alpha=%v", k, ldc, alpha)
+
+				a := randSlice(GemmMR*k+1, 1, rnd)[:GemmMR*k]
+				b := randSlice(GemmNR*k+1, 1, rnd)[:GemmNR*k]
+				c := randSlice((GemmMR-1)*ldc+GemmNR, 1, rnd)
+				want := make([]float64, len(c))
+				copy(want, c)
+				for i := 0; i < GemmMR; i++ {
+					for j := 0; j < GemmNR; j++ {
+						var sum float64
+						for p := 0; p < k; p++ {
+							sum += a[p*GemmMR+i] * b[p*GemmNR+j]
+						}
+						want[i*ldc+j] += alpha * sum
+					}
+				}
+
+				GemmKernel(k, alpha, a, b, c, ldc)
+				for i := range c {
+					if math.Abs(c[i]-want[i]) > 1e-12*float64(k+1) {
+						t.Errorf("%s: unexpected result at %d: got %v, want %v", name, i, c[i], want[i])
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGemmPack(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range []int{1, 3, 4, 5, 9, 17} {
+		for _, k := range []int{1, 2, 5} {
+			for _, trans := range []bool{false, true} {
+				name := fmt.Sprintf("m=%d,k=%d,trans=%t", m, k, trans)
+
+				// The matrix is stored as m×k or k×m with padding.
+				rs, cs := k+2, 1
+				size := m * rs
+				if trans {
+					rs, cs = 1, m+2
+					size = k * cs
+				}
+				a := randSlice(size, 1, rnd)
+
+				pa := make([]float64, (m+GemmMR-1)/GemmMR*GemmMR*k)
+				GemmPackA(pa, a, rs, cs, m, k)
+				for i := 0; i < (m+GemmMR-1)/GemmMR*GemmMR; i++ {
+					for p := 0; p < k; p++ {
+						want := 0.0
+						if i < m {
+							want = a[i*rs+p*cs]
+						}
+						got := pa[i/GemmMR*GemmMR*k+p*GemmMR+i%GemmMR]
+						if got != want {
+							t.Errorf("%s: unexpected packed A(%d,%d): got %v, want %v", name, i, p, got, want)
+						}
+					}
+				}
+
+				// Use the same storage as a k×m matrix B.
+				pb := make([]float64, (m+GemmNR-1)/GemmNR*GemmNR*k)
+				GemmPackB(pb, a, cs, rs, k, m)
+				for p := 0; p < k; p++ {
+					for j := 0; j < (m+GemmNR-1)/GemmNR*GemmNR; j++ {
+						want := 0.0
+						if j < m {
+							want = a[p*cs+j*rs]
+						}
+						got := pb[j/GemmNR*GemmNR*k+p*GemmNR+j%GemmNR]
+						if got != want {
+							t.Errorf("%s: unexpected packed B(%d,%d): got %v, want %v", name, p, j, got, want)
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+#define K CX
+#define A_PTR SI
+#define B_PTR DI
+#define C_PTR DX
+#define LDC R8
+#define ROW R9
+
+// func gemmKernel4x8(k uintptr, alpha float64, a, b, c []float64, ldc uintptr)
+TEXT ·gemmKernel4x8(SB), NOSPLIT, $0-96
+	MOVQ k+0(FP), K
+	MOVQ a_base+16(FP), A_PTR
+	MOVQ b_base+40(FP), B_PTR
+	MOVQ c_base+64(FP), C_PTR
+	MOVQ ldc+88(FP), LDC
+	SHLQ $3, LDC
+	VBROADCASTSD alpha+8(FP), Y12
+
+	// Y0-Y7 hold the 4×8 block of C, two registers per row. The rank-one
+	// updates are accumulated into C in order of increasing k, as the
+	// unpacked Gemm does.
+	MOVQ C_PTR, ROW
+	VMOVUPD (ROW), Y0
+	VMOVUPD 32(ROW), Y1
+	ADDQ LDC, ROW
+	VMOVUPD (ROW), Y2
+	VMOVUPD 32(ROW), Y3
+	ADDQ LDC, ROW
+	VMOVUPD (ROW), Y4
+	VMOVUPD 32(ROW), Y5
+	ADDQ LDC, ROW
+	VMOVUPD (ROW), Y6
+	VMOVUPD 32(ROW), Y7
+
+	SHRQ $1, K
+	JZ   tail
+
+loop: // Two rank-one updates per iteration.
+	VMOVUPD 0(B_PTR), Y8
+	VMOVUPD 32(B_PTR), Y9
+	VBROADCASTSD 0(A_PTR), Y10
+	VMULPD Y12, Y10, Y10
+	VFMADD231PD Y8, Y10, Y0
+	VFMADD231PD Y9, Y10, Y1
+	VBROADCASTSD 8(A_PTR), Y11
+	VMULPD Y12, Y11, Y11
+	VFMADD231PD Y8, Y11, Y2
+	VFMADD231PD Y9, Y11, Y3
+	VBROADCASTSD 16(A_PTR), Y10
+	VMULPD Y12, Y10, Y10
+	VFMADD231PD Y8, Y10, Y4
+	VFMADD231PD Y9, Y10, Y5
+	VBROADCASTSD 24(A_PTR), Y11
+	VMULPD Y12, Y11, Y11
+	VFMADD231PD Y8, Y11, Y6
+	VFMADD231PD Y9, Y11, Y7
+	VMOVUPD 64(B_PTR), Y8
+	VMOVUPD 96(B_PTR), Y9
+	VBROADCASTSD 32(A_PTR), Y10
+	VMULPD Y12, Y10, Y10
+	VFMADD231PD Y8, Y10, Y0
+	VFMADD231PD Y9, Y10, Y1
+	VBROADCASTSD 40(A_PTR), Y11
+	VMULPD Y12, Y11, Y11
+	VFMADD231PD Y8, Y11, Y2
+	VFMADD231PD Y9, Y11, Y3
+	VBROADCASTSD 48(A_PTR), Y10
+	VMULPD Y12, Y10, Y10
+	VFMADD231PD Y8, Y10, Y4
+	VFMADD231PD Y9, Y10, Y5
+	VBROADCASTSD 56(A_PTR), Y11
+	VMULPD Y12, Y11, Y11
+	VFMADD231PD Y8, Y11, Y6
+	VFMADD231PD Y9, Y11, Y7
+
+	ADDQ $64, A_PTR
+	ADDQ $128, B_PTR
+	DECQ K
+	JNZ  loop
+
+tail:
+	MOVQ k+0(FP), K
+	ANDQ $1, K
+	JZ   store
+	VMOVUPD 0(B_PTR), Y8
+	VMOVUPD 32(B_PTR), Y9
+	VBROADCASTSD 0(A_PTR), Y10
+	VMULPD Y12, Y10, Y10
+	VFMADD231PD Y8, Y10, Y0
+	VFMADD231PD Y9, Y10, Y1
+	VBROADCASTSD 8(A_PTR), Y11
+	VMULPD Y12, Y11, Y11
+	VFMADD231PD Y8, Y11, Y2
+	VFMADD231PD Y9, Y11, Y3
+	VBROADCASTSD 16(A_PTR), Y10
+	VMULPD Y12, Y10, Y10
+	VFMADD231PD Y8, Y10, Y4
+	VFMADD231PD Y9, Y10, Y5
+	VBROADCASTSD 24(A_PTR), Y11
+	VMULPD Y12, Y11, Y11
+	VFMADD231PD Y8, Y11, Y6
+	VFMADD231PD Y9, Y11, Y7
+
+store:
+	VMOVUPD Y0, (C_PTR)
+	VMOVUPD Y1, 32(C_PTR)
+	ADDQ LDC, C_PTR
+	VMOVUPD Y2, (C_PTR)
+	VMOVUPD Y3, 32(C_PTR)
+	ADDQ LDC, C_PTR
+	VMOVUPD Y4, (C_PTR)
+	VMOVUPD Y5, 32(C_PTR)
+	ADDQ LDC, C_PTR
+	VMOVUPD Y6, (C_PTR)
+	VMOVUPD Y7, 32(C_PTR)
+	VZEROUPPER
+	RET
@@ -0,0 +1,112 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func GemvN(m, n uintptr, alpha float64, a []float64, lda uintptr, x []float64, incX uintptr, beta float64, y []float64, incY uintptr)
+TEXT ·GemvN(SB), NOSPLIT, $0-128
+	MOVD  m+0(FP), R0
+	MOVD  n+8(FP), R1
+	FMOVD alpha+16(FP), F28
+	MOVD  a_base+24(FP), R2
+	MOVD  lda+48(FP), R3
+	MOVD  x_base+56(FP), R4
+	MOVD  incX+80(FP), R5
+	FMOVD beta+88(FP), F29
+	MOVD  y_base+96(FP), R6
+	MOVD  incY+120(FP), R7
+	CBZ   R0, end
+
+	LSL $3, R3 // Convert lda and the increments to bytes.
+	LSL $3, R5
+	LSL $3, R7
+
+	TBZ $63, R5, x_start // If incX < 0, start at x[-(n-1)*incX].
+	SUB $1, R1, R8
+	MUL R5, R8
+	SUB R8, R4
+
+x_start:
+	TBZ $63, R7, y_start // If incY < 0, start at y[-(m-1)*incY].
+	SUB $1, R0, R8
+	MUL R7, R8
+	SUB R8, R6
+
+y_start:
+	FCMPD $(0.0), F29
+	CSET  EQ, R9 // R9 = beta == 0
+
+row: // F0 = a[i*lda:i*lda+n] · x
+	MOVD R2, R10
+	MOVD R4, R11
+	VEOR V0.B16, V0.B16, V0.B16 // The partial sums are held in V0-V3.
+	VEOR V1.B16, V1.B16, V1.B16
+	VEOR V2.B16, V2.B16, V2.B16
+	VEOR V3.B16, V3.B16, V3.B16
+	CMP  $8, R5
+	BNE  inc
+
+	LSR $3, R1, R12 // R12 = n / 8
+	CBZ R12, reduce
+
+loop: // sum += a[i*lda+j:i*lda+j+8] * x[j:j+8]
+	VLD1.P 64(R10), [V4.D2, V5.D2, V6.D2, V7.D2]
+	VLD1.P 64(R11), [V8.D2, V9.D2, V10.D2, V11.D2]
+	VFMLA  V4.D2, V8.D2, V0.D2
+	VFMLA  V5.D2, V9.D2, V1.D2
+	VFMLA  V6.D2, V10.D2, V2.D2
+	VFMLA  V7.D2, V11.D2, V3.D2
+	SUB    $1, R12
+	CBNZ   R12, loop
+
+reduce: // Add the partial sums.
+	VFADD  V1.D2, V0.D2, V0.D2
+	VFADD  V3.D2, V2.D2, V2.D2
+	VFADD  V2.D2, V0.D2, V0.D2
+	VFADDP V0.D2, V0.D2, V0.D2
+
+	AND $7, R1, R12
+	CBZ R12, store
+
+tail: // sum += a[i*lda+j] * x[j]
+	FMOVD.P 8(R10), F4
+	FMOVD.P 8(R11), F8
+	FMADDD  F4, F0, F8, F0
+	SUB     $1, R12
+	CBNZ    R12, tail
+	B       store
+
+inc: // sum += a[i*lda+j] * x[jx]
+	CBZ  R1, store
+	MOVD R1, R12
+
+inc_loop:
+	FMOVD.P 8(R10), F4
+	FMOVD   (R11), F8
+	ADD     R5, R11
+	FMADDD  F4, F0, F8, F0
+	SUB     $1, R12
+	CBNZ    R12, inc_loop
+
+store: // y[iy] = alpha * sum + beta * y[iy]
+	CBNZ   R9, store_beta0
+	FMOVD  (R6), F4
+	FMULD  F29, F4
+	FMADDD F28, F4, F0, F0
+	B      next
+
+store_beta0: // y[iy] = alpha * sum
+	FMULD F28, F0
+
+next:
+	FMOVD F0, (R6)
+	ADD   R7, R6
+	ADD   R3, R2
+	SUB   $1, R0
+	CBNZ  R0, row
+
+end:
+	RET
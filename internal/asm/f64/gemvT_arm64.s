@@ -0,0 +1,118 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func GemvT(m, n uintptr, alpha float64, a []float64, lda uintptr, x []float64, incX uintptr, beta float64, y []float64, incY uintptr)
+TEXT ·GemvT(SB), NOSPLIT, $0-128
+	MOVD  m+0(FP), R0
+	MOVD  n+8(FP), R1
+	FMOVD alpha+16(FP), F28
+	MOVD  a_base+24(FP), R2
+	MOVD  lda+48(FP), R3
+	MOVD  x_base+56(FP), R4
+	MOVD  incX+80(FP), R5
+	FMOVD beta+88(FP), F29
+	MOVD  y_base+96(FP), R6
+	MOVD  incY+120(FP), R7
+	CBZ   R1, end
+
+	LSL $3, R3 // Convert lda and the increments to bytes.
+	LSL $3, R5
+	LSL $3, R7
+
+	TBZ $63, R5, x_start // If incX < 0, start at x[-(m-1)*incX].
+	SUB $1, R0, R8
+	MUL R5, R8
+	SUB R8, R4
+
+x_start:
+	TBZ $63, R7, y_start // If incY < 0, start at y[-(n-1)*incY].
+	SUB $1, R1, R8
+	MUL R7, R8
+	SUB R8, R6
+
+y_start:
+	MOVD  R6, R10
+	MOVD  R1, R12
+	FCMPD $(0.0), F29
+	BEQ   zero_y
+
+scale_y: // y[iy] *= beta
+	FMOVD (R10), F4
+	FMULD F29, F4
+	FMOVD F4, (R10)
+	ADD   R7, R10
+	SUB   $1, R12
+	CBNZ  R12, scale_y
+	B     rows
+
+zero_y: // y[iy] = 0
+	MOVD ZR, (R10)
+	ADD  R7, R10
+	SUB  $1, R12
+	CBNZ R12, zero_y
+
+rows:
+	CBZ R0, end
+
+row: // y += alpha * x[ix] * a[i*lda:i*lda+n]
+	FMOVD (R4), F0
+	ADD   R5, R4
+	FMULD F28, F0
+	MOVD  R2, R10
+	MOVD  R6, R11 // y is also the destination
+	MOVD  R6, R13
+	CMP   $8, R7
+	BNE   inc
+
+	VDUP V0.D[0], V0.D2
+	LSR  $3, R1, R12 // R12 = n / 8
+	CBZ  R12, tail_start
+
+loop: // y[j:j+8] += alpha * x[ix] * a[i*lda+j:i*lda+j+8]
+	VLD1.P 64(R10), [V1.D2, V2.D2, V3.D2, V4.D2]
+	VLD1.P 64(R11), [V5.D2, V6.D2, V7.D2, V8.D2]
+	VFMLA  V0.D2, V1.D2, V5.D2
+	VFMLA  V0.D2, V2.D2, V6.D2
+	VFMLA  V0.D2, V3.D2, V7.D2
+	VFMLA  V0.D2, V4.D2, V8.D2
+	VST1.P [V5.D2, V6.D2, V7.D2, V8.D2], 64(R13)
+	SUB    $1, R12
+	CBNZ   R12, loop
+
+tail_start:
+	AND $7, R1, R12
+	CBZ R12, next
+
+tail: // y[j] += alpha * x[ix] * a[i*lda+j]
+	FMOVD.P 8(R10), F1
+	FMOVD.P 8(R11), F2
+	FMADDD  F0, F2, F1, F2
+	FMOVD.P F2, 8(R13)
+	SUB     $1, R12
+	CBNZ    R12, tail
+	B       next
+
+inc: // y[jy] += alpha * x[ix] * a[i*lda+j]
+	MOVD R1, R12
+
+inc_loop:
+	FMOVD.P 8(R10), F1
+	FMOVD   (R11), F2
+	FMADDD  F0, F2, F1, F2
+	FMOVD   F2, (R11)
+	ADD     R7, R11
+	SUB     $1, R12
+	CBNZ    R12, inc_loop
+
+next:
+	ADD  R3, R2
+	SUB  $1, R0
+	CBNZ R0, row
+
+end:
+	RET
@@ -0,0 +1,33 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !amd64,!arm64 noasm appengine safe
+
+package f64
+
+import "math"
+
+// L1Norm is
+//  for _, v := range x {
+//  	sum += math.Abs(v)
+//  }
+//  return sum
+func L1Norm(x []float64) (sum float64) {
+	for _, v := range x {
+		sum += math.Abs(v)
+	}
+	return sum
+}
+
+// L1NormInc is
+//  for i := 0; i < n*incX; i += incX {
+//  	sum += math.Abs(x[i])
+//  }
+//  return sum
+func L1NormInc(x []float64, n, incX int) (sum float64) {
+	for i := 0; i < n*incX; i += incX {
+		sum += math.Abs(x[i])
+	}
+	return sum
+}
@@ -0,0 +1,60 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// L2DistanceUnitary returns the L2-norm of x-y.
+// func L2DistanceUnitary(x, y []float64) (norm float64)
+TEXT ·L2DistanceUnitary(SB), NOSPLIT, $0-56
+	MOVD  x_base+0(FP), R0
+	MOVD  x_len+8(FP), R1
+	MOVD  y_base+24(FP), R2
+	FMOVD $1.0, F31  // F31 = 1
+	VEOR  V0.B16, V0.B16, V0.B16 // scale = 0
+	FMOVD $1.0, F1   // sumSquares = 1
+	CBZ   R1, ret
+
+loop:
+	FMOVD.P 8(R0), F2
+	FMOVD.P 8(R2), F4
+	FSUBD   F4, F2
+	FABSD   F2, F2 // absxi = |x[i] - y[i]|
+	FCMPD   $(0.0), F2
+	BEQ     next   // if absxi == 0 { continue }
+	BVS     nan    // if isNaN(absxi) { return NaN }
+	FCMPD   F2, F0
+	BMI     rescale // if scale < absxi { goto rescale }
+
+	FDIVD  F0, F2, F3     // s = absxi / scale
+	FMADDD F3, F1, F3, F1 // sumSquares += s * s
+	B      next
+
+rescale:
+	FDIVD  F2, F0, F3      // s = scale / absxi
+	FMULD  F3, F1
+	FMADDD F3, F31, F1, F1 // sumSquares = 1 + sumSquares * s * s
+	FMOVD  F2, F0          // scale = absxi
+
+next:
+	SUB  $1, R1
+	CBNZ R1, loop
+
+ret:
+	MOVD   $0x7FF0000000000000, R2
+	FMOVD  R2, F4
+	FCMPD  F4, F0
+	BEQ    inf             // if isInf(scale, 1) { return Inf }
+	FSQRTD F1, F1
+	FMULD  F1, F0          // norm = scale * sqrt(sumSquares)
+
+inf:
+	FMOVD F0, norm+48(FP)
+	RET
+
+nan:
+	MOVD $0x7FF8000000000001, R2
+	MOVD R2, norm+48(FP)
+	RET
@@ -0,0 +1,60 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// L2NormInc returns the L2-norm of x.
+// func L2NormInc(x []float64, n, incX uintptr) (norm float64)
+TEXT ·L2NormInc(SB), NOSPLIT, $0-48
+	MOVD  x_base+0(FP), R0
+	MOVD  n+24(FP), R1
+	MOVD  incX+32(FP), R3
+	LSL   $3, R3 // Convert the increment to bytes.
+	FMOVD $1.0, F31  // F31 = 1
+	VEOR  V0.B16, V0.B16, V0.B16 // scale = 0
+	FMOVD $1.0, F1   // sumSquares = 1
+	CBZ   R1, ret
+
+loop:
+	FMOVD (R0), F2
+	ADD   R3, R0
+	FABSD F2, F2 // absxi = |x[ix]|
+	FCMPD $(0.0), F2
+	BEQ   next   // if absxi == 0 { continue }
+	BVS   nan    // if isNaN(absxi) { return NaN }
+	FCMPD F2, F0
+	BMI   rescale // if scale < absxi { goto rescale }
+
+	FDIVD  F0, F2, F3     // s = absxi / scale
+	FMADDD F3, F1, F3, F1 // sumSquares += s * s
+	B      next
+
+rescale:
+	FDIVD  F2, F0, F3      // s = scale / absxi
+	FMULD  F3, F1
+	FMADDD F3, F31, F1, F1 // sumSquares = 1 + sumSquares * s * s
+	FMOVD  F2, F0          // scale = absxi
+
+next:
+	SUB  $1, R1
+	CBNZ R1, loop
+
+ret:
+	MOVD   $0x7FF0000000000000, R2
+	FMOVD  R2, F4
+	FCMPD  F4, F0
+	BEQ    inf             // if isInf(scale, 1) { return Inf }
+	FSQRTD F1, F1
+	FMULD  F1, F0          // norm = scale * sqrt(sumSquares)
+
+inf:
+	FMOVD F0, norm+40(FP)
+	RET
+
+nan:
+	MOVD $0x7FF8000000000001, R2
+	MOVD R2, norm+40(FP)
+	RET
@@ -0,0 +1,61 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func ScalIncTo(dst []float64, incDst uintptr, alpha float64, x []float64, n, incX uintptr)
+TEXT ·ScalIncTo(SB), NOSPLIT, $0-80
+	MOVD  dst_base+0(FP), R1
+	MOVD  incDst+24(FP), R4
+	FMOVD alpha+32(FP), F0
+	MOVD  x_base+40(FP), R0
+	MOVD  n+64(FP), R2
+	MOVD  incX+72(FP), R3
+	LSL   $3, R3 // Convert the increments to bytes.
+	LSL   $3, R4
+
+	LSR $2, R2, R5 // R5 = n / 4
+	CBZ R5, tail_start
+
+loop: // Unroll the loop four times.
+	FMOVD (R0), F1
+	ADD   R3, R0
+	FMOVD (R0), F2
+	ADD   R3, R0
+	FMOVD (R0), F3
+	ADD   R3, R0
+	FMOVD (R0), F4
+	ADD   R3, R0
+	FMULD F0, F1
+	FMULD F0, F2
+	FMULD F0, F3
+	FMULD F0, F4
+	FMOVD F1, (R1)
+	ADD   R4, R1
+	FMOVD F2, (R1)
+	ADD   R4, R1
+	FMOVD F3, (R1)
+	ADD   R4, R1
+	FMOVD F4, (R1)
+	ADD   R4, R1
+	SUB   $1, R5
+	CBNZ  R5, loop
+
+tail_start:
+	AND $3, R2
+	CBZ R2, end
+
+tail:
+	FMOVD (R0), F1
+	FMULD F0, F1
+	FMOVD F1, (R1)
+	ADD   R3, R0
+	ADD   R4, R1
+	SUB   $1, R2
+	CBNZ  R2, tail
+
+end:
+	RET
@@ -0,0 +1,43 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+// func ScalUnitaryTo(dst []float64, alpha float64, x []float64)
+TEXT ·ScalUnitaryTo(SB), NOSPLIT, $0-56
+	MOVD  dst_base+0(FP), R1
+	FMOVD alpha+24(FP), F0
+	MOVD  x_base+32(FP), R0
+	MOVD  x_len+40(FP), R2
+	CBZ   R2, end
+
+	VDUP V0.D[0], V0.D2
+	LSR  $3, R2, R3 // R3 = n / 8
+	CBZ  R3, tail_start
+
+loop: // dst[i:i+8] = alpha * x[i:i+8]
+	VLD1.P 64(R0), [V1.D2, V2.D2, V3.D2, V4.D2]
+	VFMUL  V0.D2, V1.D2, V1.D2
+	VFMUL  V0.D2, V2.D2, V2.D2
+	VFMUL  V0.D2, V3.D2, V3.D2
+	VFMUL  V0.D2, V4.D2, V4.D2
+	VST1.P [V1.D2, V2.D2, V3.D2, V4.D2], 64(R1)
+	SUB    $1, R3
+	CBNZ   R3, loop
+
+tail_start:
+	AND $7, R2
+	CBZ R2, end
+
+tail: // dst[i] = alpha * x[i]
+	FMOVD.P 8(R0), F1
+	FMULD   F0, F1
+	FMOVD.P F1, 8(R1)
+	SUB    $1, R2
+	CBNZ   R2, tail
+
+end:
+	RET
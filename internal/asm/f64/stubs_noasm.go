@@ -8,30 +8,6 @@ package f64
 
 import "math"
 
-// L1Norm is
-//  for _, v := range x {
-//  	sum += math.Abs(v)
-//  }
-//  return sum
-func L1Norm(x []float64) (sum float64) {
-	for _, v := range x {
-		sum += math.Abs(v)
-	}
-	return sum
-}
-
-// L1NormInc is
-//  for i := 0; i < n*incX; i += incX {
-//  	sum += math.Abs(x[i])
-//  }
-//  return sum
-func L1NormInc(x []float64, n, incX int) (sum float64) {
-	for i := 0; i < n*incX; i += incX {
-		sum += math.Abs(x[i])
-	}
-	return sum
-}
-
 // Add is
 //  for i, v := range s {
 //  	dst[i] += v
@@ -0,0 +1,43 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+package cpu
+
+// cpuid executes the CPUID instruction with the given EAX and ECX inputs.
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+// xgetbv returns the contents of the extended control register XCR0.
+func xgetbv() (eax, edx uint32)
+
+func init() {
+	maxID, _, _, _ := cpuid(0, 0)
+	if maxID < 7 {
+		return
+	}
+
+	const (
+		fmaBit     = 1 << 12
+		osxsaveBit = 1 << 27
+		avxBit     = 1 << 28
+		avx2Bit    = 1 << 5
+
+		// The operating system must save the XMM and YMM registers
+		// for the AVX instructions to be usable.
+		xcr0SSE = 1 << 1
+		xcr0AVX = 1 << 2
+	)
+	_, _, ecx1, _ := cpuid(1, 0)
+	if ecx1&osxsaveBit == 0 || ecx1&avxBit == 0 {
+		return
+	}
+	xcr0, _ := xgetbv()
+	if xcr0&(xcr0SSE|xcr0AVX) != xcr0SSE|xcr0AVX {
+		return
+	}
+	_, ebx7, _, _ := cpuid(7, 0)
+	X86.HasAVX2 = ebx7&avx2Bit != 0
+	X86.HasFMA = ecx1&fmaBit != 0
+}
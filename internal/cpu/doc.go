@@ -0,0 +1,18 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cpu provides run-time detection of processor features used by the
+// assembly kernels in gonum.
+package cpu // import "gonum.org/v1/gonum/internal/cpu"
+
+// X86 contains the x86 processor features used by gonum. The fields are only
+// set on amd64 when assembly is enabled.
+var X86 struct {
+	// HasAVX2 reports whether the processor and the operating system
+	// support the AVX2 instructions.
+	HasAVX2 bool
+	// HasFMA reports whether the processor and the operating system
+	// support the FMA3 instructions.
+	HasFMA bool
+}
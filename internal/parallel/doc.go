@@ -0,0 +1,7 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package parallel provides the loop parallelization shared by the gonum
+// BLAS, LAPACK and statistics packages.
+package parallel // import "gonum.org/v1/gonum/internal/parallel"
@@ -0,0 +1,57 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parallel
+
+import (
+	"runtime"
+	"sync"
+)
+
+// For calls fn(i) for each i in [0, n) using at most workers goroutines, or
+// runtime.GOMAXPROCS(0) goroutines if workers is not positive. The calls may
+// be made in any order, and calls for different i must not write to the same
+// memory.
+func For(workers, n int, fn func(i int)) {
+	ForLocal(workers, n, func() func(i int) { return fn })
+}
+
+// ForLocal is like For, except that newFn is called once by each goroutine to
+// obtain the function that is called for the indices handled by that
+// goroutine. This allows the function to hold working storage.
+func ForLocal(workers, n int, newFn func() func(i int)) {
+	if n <= 0 {
+		return
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+	if workers == 1 {
+		fn := newFn()
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+	tasks := make(chan int, n)
+	for i := 0; i < n; i++ {
+		tasks <- i
+	}
+	close(tasks)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn := newFn()
+			for i := range tasks {
+				fn(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,45 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parallel
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestFor(t *testing.T) {
+	for _, workers := range []int{-1, 0, 1, 2, 7, 100} {
+		for _, n := range []int{0, 1, 2, 10, 99} {
+			counts := make([]int32, n)
+			For(workers, n, func(i int) {
+				atomic.AddInt32(&counts[i], 1)
+			})
+			for i, c := range counts {
+				if c != 1 {
+					t.Errorf("workers=%d,n=%d: index %d visited %d times", workers, n, i, c)
+				}
+			}
+		}
+	}
+}
+
+func TestForLocal(t *testing.T) {
+	for _, workers := range []int{0, 1, 3, 50} {
+		const n = 40
+		var calls, goroutines int32
+		ForLocal(workers, n, func() func(i int) {
+			atomic.AddInt32(&goroutines, 1)
+			return func(i int) {
+				atomic.AddInt32(&calls, 1)
+			}
+		})
+		if calls != n {
+			t.Errorf("workers=%d: unexpected number of calls: got %d, want %d", workers, calls, n)
+		}
+		if workers > 0 && int(goroutines) > workers {
+			t.Errorf("workers=%d: too many goroutines: %d", workers, goroutines)
+		}
+	}
+}
@@ -0,0 +1,432 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interp
+
+import (
+	"errors"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	badDegree            = "interp: negative degree"
+	tooFewKnots          = "interp: too few knots"
+	knotsNotIncreasing   = "interp: knots not strictly increasing"
+	badCoefficientLength = "interp: number of coefficients does not match basis"
+	xOutsideKnots        = "interp: x outside knot range"
+	negativeWeight       = "interp: negative weight"
+)
+
+var errNotPositiveDefinite = errors.New("interp: normal equations not positive definite")
+
+// BSplineBasis is a basis of B-splines of a fixed degree on a strictly
+// increasing sequence of knots. The basis spans the piecewise polynomials
+// of the degree between consecutive knots which have continuous
+// derivatives up to order degree-1 at the interior knots.
+//
+// The end knots are repeated degree+1 times in the knot sequence of the
+// B-splines, so the value of the splines at the ends is not constrained.
+// Outside the range of the knots the basis functions take their values at
+// the nearest end knot.
+type BSplineBasis struct {
+	degree int
+
+	// knots holds the distinct knots and t holds the full knot
+	// sequence with the end knots repeated degree+1 times.
+	knots []float64
+	t     []float64
+}
+
+// NewBSplineBasis returns a B-spline basis of the given degree on the
+// knots. The basis has len(knots)+degree-1 elements. NewBSplineBasis
+// panics if degree < 0, len(knots) < 2 or the knots are not strictly
+// increasing.
+func NewBSplineBasis(degree int, knots []float64) *BSplineBasis {
+	if degree < 0 {
+		panic(badDegree)
+	}
+	if len(knots) < 2 {
+		panic(tooFewKnots)
+	}
+	for i := 1; i < len(knots); i++ {
+		if knots[i] <= knots[i-1] {
+			panic(knotsNotIncreasing)
+		}
+	}
+	n := len(knots)
+	t := make([]float64, n+2*degree)
+	for i := 0; i < degree; i++ {
+		t[i] = knots[0]
+		t[n+degree+i] = knots[n-1]
+	}
+	copy(t[degree:], knots)
+	return &BSplineBasis{
+		degree: degree,
+		knots:  t[degree : degree+n : degree+n],
+		t:      t,
+	}
+}
+
+// Len returns the number of functions in the basis.
+func (b *BSplineBasis) Len() int {
+	return len(b.knots) + b.degree - 1
+}
+
+// Degree returns the polynomial degree of the basis functions.
+func (b *BSplineBasis) Degree() int {
+	return b.degree
+}
+
+// Eval stores in dst the values of the basis functions at x and returns
+// dst. If dst is nil, a new slice is allocated, otherwise Eval panics if
+// len(dst) != b.Len().
+func (b *BSplineBasis) Eval(dst []float64, x float64) []float64 {
+	return b.EvalDerivative(dst, x, 0)
+}
+
+// EvalDerivative stores in dst the derivatives of the given order of the
+// basis functions at x and returns dst. At the interior knots the
+// derivatives from the right are returned, and outside the range of the
+// knots all derivatives of positive order are zero. If dst is nil, a new
+// slice is allocated, otherwise EvalDerivative panics if
+// len(dst) != b.Len(). EvalDerivative panics if order < 0.
+func (b *BSplineBasis) EvalDerivative(dst []float64, x float64, order int) []float64 {
+	if order < 0 {
+		panic("interp: negative derivative order")
+	}
+	n := b.Len()
+	if dst == nil {
+		dst = make([]float64, n)
+	} else {
+		if len(dst) != n {
+			panic(badCoefficientLength)
+		}
+		for i := range dst {
+			dst[i] = 0
+		}
+	}
+	if order > 0 && b.outside(x) {
+		return dst
+	}
+	mu := b.span(x)
+	b.nonzero(dst[mu-b.degree:mu+1], mu, b.clamp(x), order)
+	return dst
+}
+
+// DesignMatrix stores in dst the design matrix of the basis for the
+// points xs, with element (i, j) holding the value of the j-th basis
+// function at xs[i]. If dst is empty, it is resized to len(xs)×b.Len(),
+// otherwise DesignMatrix panics if dst does not have these dimensions.
+func (b *BSplineBasis) DesignMatrix(dst *mat.Dense, xs []float64) {
+	n := b.Len()
+	if dst.IsEmpty() {
+		dst.ReuseAs(len(xs), n)
+	} else {
+		r, c := dst.Dims()
+		if r != len(xs) || c != n {
+			panic(mat.ErrShape)
+		}
+		dst.Zero()
+	}
+	for i, x := range xs {
+		mu := b.span(x)
+		row := dst.RawRowView(i)
+		b.nonzero(row[mu-b.degree:mu+1], mu, b.clamp(x), 0)
+	}
+}
+
+// span returns the index mu into the knot sequence such that the knot span
+// [t[mu], t[mu+1]] contains x clamped to the range of the knots. The basis
+// functions which may be nonzero on the span are mu-degree, ..., mu.
+func (b *BSplineBasis) span(x float64) int {
+	i := findSegment(b.knots, x)
+	switch {
+	case i < 0:
+		i = 0
+	case i >= len(b.knots)-1:
+		i = len(b.knots) - 2
+	}
+	return i + b.degree
+}
+
+// outside returns whether x is outside the range of the knots.
+func (b *BSplineBasis) outside(x float64) bool {
+	return x < b.knots[0] || b.knots[len(b.knots)-1] < x
+}
+
+// clamp returns x clamped to the range of the knots.
+func (b *BSplineBasis) clamp(x float64) float64 {
+	return max(b.knots[0], min(x, b.knots[len(b.knots)-1]))
+}
+
+// nonzero stores in dst the values, or the derivatives of the given order,
+// at x of the degree+1 basis functions mu-degree, ..., mu which may be
+// nonzero on the knot span starting at t[mu].
+func (b *BSplineBasis) nonzero(dst []float64, mu int, x float64, order int) {
+	p := b.degree
+	t := b.t
+	if order > p {
+		for i := range dst {
+			dst[i] = 0
+		}
+		return
+	}
+
+	// Compute the values of the basis functions of degree q = p-order
+	// with the Cox–de Boor recurrence. See Algorithm A2.2 of
+	// Piegl, L. and Tiller, W. The NURBS Book. Springer, 1997.
+	q := p - order
+	dst[0] = 1
+	for j := 1; j <= q; j++ {
+		var saved float64
+		for r := 0; r < j; r++ {
+			right := t[mu+r+1]
+			left := t[mu+r+1-j]
+			tmp := dst[r] / (right - left)
+			dst[r] = saved + (right-x)*tmp
+			saved = (x - left) * tmp
+		}
+		dst[j] = saved
+	}
+
+	// Raise the degree by differentiating with
+	//  d/dx N_{i,r} = r * (N_{i,r-1}/(t[i+r]-t[i]) - N_{i+1,r-1}/(t[i+r+1]-t[i+1])),
+	// where N_{i,r} is the i-th basis function of degree r. The terms
+	// for the functions mu-r and mu+1 of degree r-1, which are zero on
+	// the span, are omitted.
+	for r := q + 1; r <= p; r++ {
+		fr := float64(r)
+		dst[r] = fr * dst[r-1] / (t[mu+r] - t[mu])
+		for j := r - 1; j > 0; j-- {
+			i := mu - r + j
+			dst[j] = fr * (dst[j-1]/(t[i+r]-t[i]) - dst[j]/(t[i+r+1]-t[i+1]))
+		}
+		dst[0] *= -fr / (t[mu+1] - t[mu+1-r])
+	}
+}
+
+// BSpline is a spline function represented as a linear combination of the
+// functions of a B-spline basis. Outside the range of the knots of the
+// basis it takes the value at the nearest end knot.
+type BSpline struct {
+	basis  *BSplineBasis
+	coeffs []float64
+
+	// anti holds the coefficients of the antiderivative of the
+	// spline from the first knot in the basis of one higher degree.
+	antiBasis *BSplineBasis
+	anti      []float64
+}
+
+// NewBSpline returns the spline function with the given coefficients in the
+// B-spline basis. NewBSpline panics if len(coeffs) != basis.Len().
+func NewBSpline(basis *BSplineBasis, coeffs []float64) *BSpline {
+	if len(coeffs) != basis.Len() {
+		panic(badCoefficientLength)
+	}
+	var s BSpline
+	s.set(basis, append([]float64(nil), coeffs...))
+	return &s
+}
+
+// set sets the basis and coefficients of the spline, taking ownership of
+// coeffs, and computes the coefficients of its antiderivative.
+func (s *BSpline) set(basis *BSplineBasis, coeffs []float64) {
+	s.basis = basis
+	s.coeffs = coeffs
+
+	// The antiderivative of the sum of c[i] * N_{i,p} vanishing at the
+	// first knot is the sum of d[i] * N_{i,p+1} where d[0] = 0 and
+	//  d[i+1] = d[i] + c[i] * (t[i+p+1] - t[i]) / (p+1),
+	// and the knot sequence of degree p+1 has the end knots repeated
+	// once more than that of degree p.
+	p := basis.degree
+	t := basis.t
+	s.antiBasis = NewBSplineBasis(p+1, basis.knots)
+	s.anti = make([]float64, len(coeffs)+1)
+	for i, c := range coeffs {
+		s.anti[i+1] = s.anti[i] + c*(t[i+p+1]-t[i])/float64(p+1)
+	}
+}
+
+// Predict returns the value of the spline at x.
+func (s *BSpline) Predict(x float64) float64 {
+	return evalBSpline(s.basis, s.coeffs, x, 0)
+}
+
+// PredictDerivative returns the derivative of the spline at x. At the
+// interior knots the derivative from the right is returned, at the last
+// knot the derivative from the left is returned, and outside the range of
+// the knots it returns zero.
+func (s *BSpline) PredictDerivative(x float64) float64 {
+	if s.basis.outside(x) {
+		return 0
+	}
+	return evalBSpline(s.basis, s.coeffs, x, 1)
+}
+
+// Integrate returns the integral of the spline from a to b.
+func (s *BSpline) Integrate(a, b float64) float64 {
+	return s.antiderivative(b) - s.antiderivative(a)
+}
+
+// antiderivative returns the integral of the spline from the first knot
+// to x.
+func (s *BSpline) antiderivative(x float64) float64 {
+	knots := s.basis.knots
+	lo := knots[0]
+	hi := knots[len(knots)-1]
+	switch {
+	case x < lo:
+		return (x - lo) * s.Predict(lo)
+	case x > hi:
+		return evalBSpline(s.antiBasis, s.anti, hi, 0) + (x-hi)*s.Predict(hi)
+	}
+	return evalBSpline(s.antiBasis, s.anti, x, 0)
+}
+
+// evalBSpline returns the derivative of the given order at x clamped to
+// the range of the knots of the spline with coefficients c in basis b.
+func evalBSpline(b *BSplineBasis, c []float64, x float64, order int) float64 {
+	mu := b.span(x)
+	p := b.degree
+	var buf [4]float64
+	var vals []float64
+	if p < len(buf) {
+		vals = buf[:p+1]
+	} else {
+		vals = make([]float64, p+1)
+	}
+	b.nonzero(vals, mu, b.clamp(x), order)
+	var v float64
+	for j, u := range vals {
+		v += u * c[mu-p+j]
+	}
+	return v
+}
+
+// LeastSquaresBSpline is a spline in a B-spline basis fitted to data by
+// weighted least squares. Outside the range of the knots of the basis it
+// takes the value at the nearest end knot.
+type LeastSquaresBSpline struct {
+	// Basis is the B-spline basis of the fitted spline.
+	// It must be set before calling Fit or FitWeighted.
+	Basis *BSplineBasis
+
+	spline BSpline
+}
+
+// Predict returns the value of the fitted spline at x.
+func (ls *LeastSquaresBSpline) Predict(x float64) float64 {
+	return ls.spline.Predict(x)
+}
+
+// PredictDerivative returns the derivative of the fitted spline at x.
+// Outside the range of the knots it returns zero.
+func (ls *LeastSquaresBSpline) PredictDerivative(x float64) float64 {
+	return ls.spline.PredictDerivative(x)
+}
+
+// Integrate returns the integral of the fitted spline from a to b.
+func (ls *LeastSquaresBSpline) Integrate(a, b float64) float64 {
+	return ls.spline.Integrate(a, b)
+}
+
+// Fit fits the spline to (X, Y) value pairs provided as two slices by
+// least squares. It is equivalent to FitWeighted(xs, ys, nil).
+func (ls *LeastSquaresBSpline) Fit(xs, ys []float64) error {
+	return ls.FitWeighted(xs, ys, nil)
+}
+
+// FitWeighted fits the spline to (X, Y) value pairs provided as two slices
+// by minimizing the weighted sum of squared residuals
+//  Σ_i weights[i] * (ys[i] - f(xs[i]))²
+// If weights is nil, all weights are one. The elements of xs need not be
+// sorted or distinct.
+//
+// FitWeighted panics if ls.Basis is nil, if len(xs) != len(ys), if weights
+// is not nil and len(weights) != len(xs), if any weight is negative or if
+// any of xs is outside the range of the knots. It returns an error if the
+// data do not determine the spline, for example when no point with
+// positive weight lies in the support of one of the basis functions.
+func (ls *LeastSquaresBSpline) FitWeighted(xs, ys, weights []float64) error {
+	if ls.Basis == nil {
+		panic("interp: nil B-spline basis")
+	}
+	validateData(ls.Basis, xs, ys, weights)
+	a, rhs := normalEquations(ls.Basis, xs, ys, weights)
+	_, ok := solveBand(a, rhs)
+	if !ok {
+		return errNotPositiveDefinite
+	}
+	ls.spline.set(ls.Basis, rhs)
+	return nil
+}
+
+// validateData checks that xs, ys and weights have the same length, that
+// the weights are non-negative and that xs are within the range of the
+// knots of b. It panics otherwise. weights may be nil.
+func validateData(b *BSplineBasis, xs, ys, weights []float64) {
+	if len(xs) != len(ys) {
+		panic(differentLengths)
+	}
+	if weights != nil && len(weights) != len(xs) {
+		panic(differentLengths)
+	}
+	for i, x := range xs {
+		if b.outside(x) {
+			panic(xOutsideKnots)
+		}
+		if weights != nil && weights[i] < 0 {
+			panic(negativeWeight)
+		}
+	}
+}
+
+// normalEquations returns the band matrix Bᵀ*W*B and the vector Bᵀ*W*y of
+// the normal equations of the weighted least squares fit of the data in
+// basis b, where B is the design matrix of b at xs and W is the diagonal
+// matrix of the weights.
+func normalEquations(b *BSplineBasis, xs, ys, weights []float64) (a *mat.SymBandDense, rhs []float64) {
+	n := b.Len()
+	p := b.degree
+	a = mat.NewSymBandDense(n, p, nil)
+	raw := a.RawSymBand()
+	rhs = make([]float64, n)
+	vals := make([]float64, p+1)
+	for i, x := range xs {
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		mu := b.span(x)
+		b.nonzero(vals, mu, x, 0)
+		for j, u := range vals {
+			k := mu - p + j
+			rhs[k] += w * u * ys[i]
+			row := raw.Data[k*raw.Stride:]
+			for l, v := range vals[j:] {
+				row[l] += w * u * v
+			}
+		}
+	}
+	return a, rhs
+}
+
+// solveBand solves the symmetric positive definite band system a * x = b,
+// storing the solution in b, and returns the Cholesky factor of a. It
+// returns whether a is positive definite. a is not modified.
+func solveBand(a *mat.SymBandDense, b []float64) (u blas64.TriangularBand, ok bool) {
+	raw := a.RawSymBand()
+	raw.Data = append([]float64(nil), raw.Data...)
+	u, ok = lapack64.Pbtrf(raw)
+	if !ok {
+		return u, false
+	}
+	lapack64.Pbtrs(u, blas64.General{Rows: len(b), Cols: 1, Stride: 1, Data: b})
+	return u, true
+}
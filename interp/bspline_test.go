@@ -0,0 +1,270 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interp
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewBSplineBasisPanics(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		degree int
+		knots  []float64
+	}{
+		{name: "negative degree", degree: -1, knots: []float64{0, 1}},
+		{name: "too few knots", degree: 3, knots: []float64{0}},
+		{name: "repeated knot", degree: 3, knots: []float64{0, 1, 1, 2}},
+		{name: "decreasing knots", degree: 3, knots: []float64{0, 2, 1}},
+	} {
+		if !panics(func() { NewBSplineBasis(test.degree, test.knots) }) {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+}
+
+func TestBSplineBasisLinear(t *testing.T) {
+	// The degree one basis is the hat functions on the knots.
+	b := NewBSplineBasis(1, []float64{0, 1, 3})
+	if b.Len() != 3 {
+		t.Fatalf("unexpected basis length: got:%d want:3", b.Len())
+	}
+	for _, test := range []struct {
+		x    float64
+		want []float64
+	}{
+		{x: -1, want: []float64{1, 0, 0}},
+		{x: 0, want: []float64{1, 0, 0}},
+		{x: 0.25, want: []float64{0.75, 0.25, 0}},
+		{x: 1, want: []float64{0, 1, 0}},
+		{x: 2.5, want: []float64{0, 0.25, 0.75}},
+		{x: 3, want: []float64{0, 0, 1}},
+		{x: 4, want: []float64{0, 0, 1}},
+	} {
+		got := b.Eval(nil, test.x)
+		if !floats.EqualApprox(got, test.want, 1e-15) {
+			t.Errorf("unexpected basis values at %v: got:%v want:%v", test.x, got, test.want)
+		}
+	}
+}
+
+func TestBSplineBasis(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, degree := range []int{0, 1, 2, 3, 5} {
+		for _, nk := range []int{2, 3, 6} {
+			knots, _ := randomData(rnd, nk)
+			b := NewBSplineBasis(degree, knots)
+			name := fmt.Sprintf("degree=%d knots=%d", degree, nk)
+			if got, want := b.Len(), nk+degree-1; got != want {
+				t.Errorf("%s: unexpected length: got:%d want:%d", name, got, want)
+			}
+			if got := b.Degree(); got != degree {
+				t.Errorf("%s: unexpected degree: got:%d want:%d", name, got, degree)
+			}
+
+			xs := make([]float64, 20)
+			for i := range xs {
+				xs[i] = knots[0] + (knots[nk-1]-knots[0])*rnd.Float64()
+			}
+			xs = append(xs, knots...)
+			var design mat.Dense
+			b.DesignMatrix(&design, xs)
+			dst := make([]float64, b.Len())
+			for i, x := range xs {
+				vals := b.Eval(dst, x)
+				for j, v := range vals {
+					if v < 0 {
+						t.Errorf("%s: negative basis function %d at %v: %v", name, j, x, v)
+					}
+				}
+				if sum := floats.Sum(vals); math.Abs(sum-1) > 1e-14 {
+					t.Errorf("%s: basis does not sum to one at %v: got:%v", name, x, sum)
+				}
+				if !floats.Equal(vals, design.RawRowView(i)) {
+					t.Errorf("%s: design matrix row %d does not match basis values", name, i)
+				}
+				if degree == 0 {
+					continue
+				}
+				const h = 1e-6
+				for order := 1; order <= 2 && order < degree; order++ {
+					got := b.EvalDerivative(nil, x, order)
+					lo := b.EvalDerivative(nil, x-h, order-1)
+					hi := b.EvalDerivative(nil, x+h, order-1)
+					for j := range got {
+						want := (hi[j] - lo[j]) / (2 * h)
+						if x-h < knots[0] || knots[nk-1] < x+h {
+							continue
+						}
+						if math.Abs(got[j]-want) > 1e-5*math.Max(1, math.Abs(want)) {
+							t.Errorf("%s: unexpected derivative of order %d of function %d at %v: got:%v want:%v",
+								name, order, j, x, got[j], want)
+						}
+					}
+				}
+			}
+			for _, x := range []float64{knots[0] - 1, knots[nk-1] + 1} {
+				if got := b.EvalDerivative(nil, x, 1); !floats.Equal(got, make([]float64, b.Len())) {
+					t.Errorf("%s: unexpected derivative outside knots at %v: %v", name, x, got)
+				}
+			}
+			if got := b.EvalDerivative(nil, xs[0], degree+1); !floats.Equal(got, make([]float64, b.Len())) {
+				t.Errorf("%s: unexpected derivative of order degree+1: %v", name, got)
+			}
+		}
+	}
+}
+
+func TestBSpline(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, degree := range []int{0, 1, 2, 3, 4} {
+		for _, nk := range []int{2, 3, 6} {
+			knots, _ := randomData(rnd, nk)
+			b := NewBSplineBasis(degree, knots)
+			coeffs := make([]float64, b.Len())
+			for i := range coeffs {
+				coeffs[i] = rnd.NormFloat64()
+			}
+			s := NewBSpline(b, coeffs)
+			name := fmt.Sprintf("degree=%d knots=%d", degree, nk)
+
+			vals := make([]float64, b.Len())
+			for _, x := range []float64{knots[0], (knots[0] + knots[1]) / 2, knots[nk-1]} {
+				want := floats.Dot(b.Eval(vals, x), coeffs)
+				if got := s.Predict(x); math.Abs(got-want) > 1e-14 {
+					t.Errorf("%s: unexpected value at %v: got:%v want:%v", name, x, got, want)
+				}
+			}
+			testExtrapolation(t, name, s, []float64{knots[0], knots[nk-1]}, []float64{s.Predict(knots[0]), s.Predict(knots[nk-1])})
+			if degree > 1 {
+				testDerivative(t, name, s, knots)
+			}
+
+			// Gauss–Legendre quadrature with three points is exact
+			// for polynomials of degree at most five.
+			breaks := append([]float64{knots[0] - 1.5}, knots...)
+			breaks = append(breaks, knots[nk-1]+2)
+			for _, ab := range [][2]float64{
+				{knots[0], knots[nk-1]},
+				{breaks[0], breaks[len(breaks)-1]},
+				{knots[0] + 0.3, knots[nk-1] - 0.2},
+				{knots[nk-1] - 0.2, knots[0] + 0.3},
+			} {
+				want := gaussLegendre(s.Predict, breaks, ab[0], ab[1])
+				if got := s.Integrate(ab[0], ab[1]); math.Abs(got-want) > 1e-12*math.Max(1, math.Abs(want)) {
+					t.Errorf("%s: unexpected integral from %v to %v: got:%v want:%v", name, ab[0], ab[1], got, want)
+				}
+			}
+		}
+	}
+
+	b := NewBSplineBasis(3, []float64{0, 1, 2})
+	if !panics(func() { NewBSpline(b, make([]float64, 4)) }) {
+		t.Errorf("expected panic for wrong number of coefficients")
+	}
+}
+
+func TestLeastSquaresBSpline(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	knots := []float64{-2, -0.5, 0, 1.5, 3}
+	b := NewBSplineBasis(3, knots)
+
+	// Data from a cubic polynomial are fitted exactly.
+	xs := make([]float64, 40)
+	ys := make([]float64, len(xs))
+	for i := range xs {
+		xs[i] = -2 + 5*rnd.Float64()
+		ys[i] = poly3(xs[i])
+	}
+	ls := LeastSquaresBSpline{Basis: b}
+	err := ls.Fit(xs, ys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for x := -2.0; x <= 3; x += 0.1 {
+		if got, want := ls.Predict(x), poly3(x); math.Abs(got-want) > 1e-10 {
+			t.Errorf("unexpected value at %v: got:%v want:%v", x, got, want)
+		}
+		if got, want := ls.PredictDerivative(x), poly3d(x); math.Abs(got-want) > 1e-10 {
+			t.Errorf("unexpected derivative at %v: got:%v want:%v", x, got, want)
+		}
+	}
+
+	// The weighted fit of noisy data agrees with the solution of the
+	// least squares problem using the design matrix.
+	weights := make([]float64, len(xs))
+	for i := range ys {
+		ys[i] += rnd.NormFloat64()
+		weights[i] = rnd.Float64()
+	}
+	err = ls.FitWeighted(xs, ys, weights)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var design mat.Dense
+	b.DesignMatrix(&design, xs)
+	wy := make([]float64, len(ys))
+	for i, w := range weights {
+		floats.Scale(math.Sqrt(w), design.RawRowView(i))
+		wy[i] = math.Sqrt(w) * ys[i]
+	}
+	var coeffs mat.VecDense
+	err = coeffs.SolveVec(&design, mat.NewVecDense(len(wy), wy))
+	if err != nil {
+		t.Fatalf("unexpected error from dense solve: %v", err)
+	}
+	want := NewBSpline(b, coeffs.RawVector().Data)
+	for x := -2.0; x <= 3; x += 0.1 {
+		if got, want := ls.Predict(x), want.Predict(x); math.Abs(got-want) > 1e-10 {
+			t.Errorf("unexpected weighted fit value at %v: got:%v want:%v", x, got, want)
+		}
+	}
+
+	// Data which do not determine the spline are reported.
+	err = ls.Fit([]float64{-2, -1.9, 2, 3}, []float64{1, 2, 3, 4})
+	if err == nil {
+		t.Errorf("expected error for underdetermined fit")
+	}
+
+	for _, test := range []struct {
+		name           string
+		xs, ys, weight []float64
+	}{
+		{name: "different lengths", xs: []float64{0, 1}, ys: []float64{0}},
+		{name: "different weight length", xs: []float64{0, 1}, ys: []float64{0, 1}, weight: []float64{1}},
+		{name: "negative weight", xs: []float64{0, 1}, ys: []float64{0, 1}, weight: []float64{1, -1}},
+		{name: "x outside knots", xs: []float64{0, 4}, ys: []float64{0, 1}},
+	} {
+		if !panics(func() { ls.FitWeighted(test.xs, test.ys, test.weight) }) {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+	if !panics(func() { (&LeastSquaresBSpline{}).Fit(xs, ys) }) {
+		t.Errorf("expected panic for nil basis")
+	}
+}
+
+func TestBSplineBasisDesignMatrixShape(t *testing.T) {
+	b := NewBSplineBasis(2, []float64{0, 1, 2})
+	dst := mat.NewDense(3, b.Len(), nil)
+	dst.Set(0, 0, 100)
+	b.DesignMatrix(dst, []float64{0, 1.5, 2})
+	if got := dst.At(0, 0); got != 1 {
+		t.Errorf("unexpected design matrix element: got:%v want:1", got)
+	}
+	if !panics(func() { b.DesignMatrix(mat.NewDense(2, b.Len(), nil), []float64{0, 1, 2}) }) {
+		t.Errorf("expected panic for wrong number of rows")
+	}
+	if !panics(func() { b.DesignMatrix(mat.NewDense(3, 2, nil), []float64{0, 1, 2}) }) {
+		t.Errorf("expected panic for wrong number of columns")
+	}
+}
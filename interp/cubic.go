@@ -0,0 +1,507 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interp
+
+import (
+	"errors"
+	"math"
+)
+
+// PiecewiseCubic is a piecewise cubic 1-dimensional interpolator with
+// continuous value and first derivative.
+type PiecewiseCubic struct {
+	xs []float64
+
+	// coeffs[i] holds the coefficients of the polynomial
+	//  coeffs[i][0] + coeffs[i][1]*dx + coeffs[i][2]*dx^2 + coeffs[i][3]*dx^3
+	// with dx = x - xs[i] on the segment [xs[i], xs[i+1]).
+	coeffs [][4]float64
+	lastY  float64
+
+	// cum[i] is the integral from xs[0] to xs[i].
+	cum []float64
+}
+
+// Predict returns the interpolation value at x. Outside the range of
+// xs it returns the value at the nearest end point.
+func (pc *PiecewiseCubic) Predict(x float64) float64 {
+	i := findSegment(pc.xs, x)
+	if i < 0 {
+		return pc.coeffs[0][0]
+	}
+	if i == len(pc.xs)-1 {
+		return pc.lastY
+	}
+	dx := x - pc.xs[i]
+	c := &pc.coeffs[i]
+	return c[0] + dx*(c[1]+dx*(c[2]+dx*c[3]))
+}
+
+// PredictDerivative returns the derivative of the interpolated function
+// at x. Outside the range of xs it returns zero.
+func (pc *PiecewiseCubic) PredictDerivative(x float64) float64 {
+	i := findSegment(pc.xs, x)
+	n := len(pc.xs)
+	switch {
+	case i < 0, x > pc.xs[n-1]:
+		return 0
+	case i == n-1:
+		// Evaluate the last segment at its end point.
+		i = n - 2
+	}
+	dx := x - pc.xs[i]
+	c := &pc.coeffs[i]
+	return c[1] + dx*(2*c[2]+3*dx*c[3])
+}
+
+// Integrate returns the integral of the interpolated function from a to b.
+func (pc *PiecewiseCubic) Integrate(a, b float64) float64 {
+	return pc.antiderivative(b) - pc.antiderivative(a)
+}
+
+// antiderivative returns the integral of the interpolated function from
+// xs[0] to x.
+func (pc *PiecewiseCubic) antiderivative(x float64) float64 {
+	i := findSegment(pc.xs, x)
+	if i < 0 {
+		return (x - pc.xs[0]) * pc.coeffs[0][0]
+	}
+	if i == len(pc.xs)-1 {
+		return pc.cum[i] + (x-pc.xs[i])*pc.lastY
+	}
+	dx := x - pc.xs[i]
+	return pc.cum[i] + segmentIntegral(&pc.coeffs[i], dx)
+}
+
+// segmentIntegral returns the integral of the polynomial with coefficients
+// c from 0 to dx.
+func segmentIntegral(c *[4]float64, dx float64) float64 {
+	return dx * (c[0] + dx*(c[1]/2+dx*(c[2]/3+dx*c[3]/4)))
+}
+
+// FitWithDerivatives fits a piecewise cubic predictor to (X, Y, dY/dX) value
+// triples provided as three slices.
+// It panics if len(xs) < 2, elements of xs are not strictly increasing,
+// len(xs) != len(ys) or len(xs) != len(dydxs).
+func (pc *PiecewiseCubic) FitWithDerivatives(xs, ys, dydxs []float64) {
+	if len(dydxs) != len(xs) {
+		panic(differentLengths)
+	}
+	validateXsYs(xs, ys)
+	n := len(xs)
+	pc.xs = make([]float64, n)
+	copy(pc.xs, xs)
+	pc.coeffs = make([][4]float64, n-1)
+	pc.cum = make([]float64, n)
+	for i := 0; i < n-1; i++ {
+		h := xs[i+1] - xs[i]
+		delta := (ys[i+1] - ys[i]) / h
+		c := &pc.coeffs[i]
+		c[0] = ys[i]
+		c[1] = dydxs[i]
+		c[2] = (3*delta - 2*dydxs[i] - dydxs[i+1]) / h
+		c[3] = (dydxs[i] + dydxs[i+1] - 2*delta) / (h * h)
+		pc.cum[i+1] = pc.cum[i] + segmentIntegral(c, h)
+	}
+	pc.lastY = ys[n-1]
+}
+
+// AkimaSpline is a piecewise cubic 1-dimensional interpolator with
+// continuous value and first derivative, which can be fitted to (X, Y)
+// value pairs without providing derivatives.
+// See https://www.iue.tuwien.ac.at/phd/rottinger/node60.html for more details.
+type AkimaSpline struct {
+	cubic PiecewiseCubic
+}
+
+// Predict returns the interpolation value at x.
+func (as *AkimaSpline) Predict(x float64) float64 {
+	return as.cubic.Predict(x)
+}
+
+// PredictDerivative returns the predicted derivative at x.
+func (as *AkimaSpline) PredictDerivative(x float64) float64 {
+	return as.cubic.PredictDerivative(x)
+}
+
+// Integrate returns the integral of the interpolated function from a to b.
+func (as *AkimaSpline) Integrate(a, b float64) float64 {
+	return as.cubic.Integrate(a, b)
+}
+
+// Fit fits a predictor to (X, Y) value pairs provided as two slices.
+// It panics if len(xs) < 2, elements of xs are not strictly increasing
+// or len(xs) != len(ys). Always returns nil.
+func (as *AkimaSpline) Fit(xs, ys []float64) error {
+	validateXsYs(xs, ys)
+	dydxs := make([]float64, len(xs))
+	akimaSlopes(dydxs, xs, ys)
+	as.cubic.FitWithDerivatives(xs, ys, dydxs)
+	return nil
+}
+
+// akimaSlopes stores in dst the derivatives at xs of the Akima spline
+// through (xs, ys).
+func akimaSlopes(dst, xs, ys []float64) {
+	n := len(xs)
+	if n == 2 {
+		delta := (ys[1] - ys[0]) / (xs[1] - xs[0])
+		dst[0] = delta
+		dst[1] = delta
+		return
+	}
+	// m[i+2] holds the slope of the segment [xs[i], xs[i+1]) for
+	// 0 <= i < n-1, extended by two slopes at each end by quadratic
+	// extrapolation.
+	m := make([]float64, n+3)
+	for i := 0; i < n-1; i++ {
+		m[i+2] = (ys[i+1] - ys[i]) / (xs[i+1] - xs[i])
+	}
+	m[1] = 2*m[2] - m[3]
+	m[0] = 2*m[1] - m[2]
+	m[n+1] = 2*m[n] - m[n-1]
+	m[n+2] = 2*m[n+1] - m[n]
+	for i := range dst {
+		// The slopes of the two segments either side of xs[i] are
+		// m[i+1] and m[i+2].
+		wl := math.Abs(m[i+3] - m[i+2])
+		wr := math.Abs(m[i+1] - m[i])
+		if wl+wr == 0 {
+			dst[i] = (m[i+1] + m[i+2]) / 2
+			continue
+		}
+		dst[i] = (wl*m[i+1] + wr*m[i+2]) / (wl + wr)
+	}
+}
+
+// FritschButland is a piecewise cubic 1-dimensional interpolator with
+// continuous value and first derivative, which can be fitted to (X, Y)
+// value pairs without providing derivatives. It is monotone, local and
+// produces no overshoot, so it preserves the shape of monotone data. It is
+// also known as PCHIP, the piecewise cubic Hermite interpolating polynomial.
+// See "A method for constructing local monotone piecewise cubic interpolants"
+// by F. N. Fritsch and J. Butland, SIAM J. Sci. Stat. Comput. 5(2) (1984),
+// for more details.
+type FritschButland struct {
+	cubic PiecewiseCubic
+}
+
+// Predict returns the interpolation value at x.
+func (fb *FritschButland) Predict(x float64) float64 {
+	return fb.cubic.Predict(x)
+}
+
+// PredictDerivative returns the predicted derivative at x.
+func (fb *FritschButland) PredictDerivative(x float64) float64 {
+	return fb.cubic.PredictDerivative(x)
+}
+
+// Integrate returns the integral of the interpolated function from a to b.
+func (fb *FritschButland) Integrate(a, b float64) float64 {
+	return fb.cubic.Integrate(a, b)
+}
+
+// Fit fits a predictor to (X, Y) value pairs provided as two slices.
+// It panics if len(xs) < 2, elements of xs are not strictly increasing
+// or len(xs) != len(ys). Always returns nil.
+func (fb *FritschButland) Fit(xs, ys []float64) error {
+	validateXsYs(xs, ys)
+	dydxs := make([]float64, len(xs))
+	fritschButlandSlopes(dydxs, xs, ys)
+	fb.cubic.FitWithDerivatives(xs, ys, dydxs)
+	return nil
+}
+
+// fritschButlandSlopes stores in dst the derivatives at xs of the
+// Fritsch–Butland interpolant through (xs, ys).
+func fritschButlandSlopes(dst, xs, ys []float64) {
+	n := len(xs)
+	h := make([]float64, n-1)
+	delta := make([]float64, n-1)
+	for i := range h {
+		h[i] = xs[i+1] - xs[i]
+		delta[i] = (ys[i+1] - ys[i]) / h[i]
+	}
+	if n == 2 {
+		dst[0] = delta[0]
+		dst[1] = delta[0]
+		return
+	}
+	for i := 1; i < n-1; i++ {
+		if delta[i-1]*delta[i] <= 0 {
+			// Local extremum or flat segment.
+			dst[i] = 0
+			continue
+		}
+		// Weighted harmonic mean of the adjacent slopes.
+		w1 := 2*h[i] + h[i-1]
+		w2 := h[i] + 2*h[i-1]
+		dst[i] = (w1 + w2) / (w1/delta[i-1] + w2/delta[i])
+	}
+	dst[0] = fritschButlandEdge(h[0], h[1], delta[0], delta[1])
+	dst[n-1] = fritschButlandEdge(h[n-2], h[n-3], delta[n-2], delta[n-3])
+}
+
+// fritschButlandEdge returns the shape-preserving three-point estimate of
+// the derivative at an end point, where h0 and delta0 are the width and
+// slope of the end segment and h1 and delta1 those of its neighbour.
+func fritschButlandEdge(h0, h1, delta0, delta1 float64) float64 {
+	d := ((2*h0+h1)*delta0 - h0*delta1) / (h0 + h1)
+	switch {
+	case d*delta0 <= 0:
+		return 0
+	case delta0*delta1 <= 0 && math.Abs(d) > 3*math.Abs(delta0):
+		return 3 * delta0
+	}
+	return d
+}
+
+// endCondition specifies the end conditions of a cubic spline.
+type endCondition int
+
+const (
+	natural endCondition = iota
+	clamped
+	notAKnot
+)
+
+// NaturalCubic is a piecewise cubic 1-dimensional interpolator with
+// continuous value, first and second derivatives, which can be fitted to (X, Y)
+// value pairs without providing derivatives. It uses the boundary conditions
+// Y′′(left end ) = Y′′(right end) = 0.
+// See e.g. https://www.math.drexel.edu/~tolya/cubicspline.pdf for details.
+type NaturalCubic struct {
+	cubic PiecewiseCubic
+}
+
+// Predict returns the interpolation value at x.
+func (nc *NaturalCubic) Predict(x float64) float64 {
+	return nc.cubic.Predict(x)
+}
+
+// PredictDerivative returns the predicted derivative at x.
+func (nc *NaturalCubic) PredictDerivative(x float64) float64 {
+	return nc.cubic.PredictDerivative(x)
+}
+
+// Integrate returns the integral of the interpolated function from a to b.
+func (nc *NaturalCubic) Integrate(a, b float64) float64 {
+	return nc.cubic.Integrate(a, b)
+}
+
+// Fit fits a predictor to (X, Y) value pairs provided as two slices.
+// It panics if len(xs) < 2, elements of xs are not strictly increasing
+// or len(xs) != len(ys). It returns an error if solving the spline
+// equations fails.
+func (nc *NaturalCubic) Fit(xs, ys []float64) error {
+	return fitSpline(&nc.cubic, xs, ys, natural, 0, 0)
+}
+
+// ClampedCubic is a piecewise cubic 1-dimensional interpolator with
+// continuous value, first and second derivatives, which can be fitted to (X, Y)
+// value pairs without providing derivatives. It uses the boundary conditions
+// Y′(left end) = LeftDerivative and Y′(right end) = RightDerivative, so the
+// zero value has zero derivatives at the end points.
+type ClampedCubic struct {
+	// LeftDerivative and RightDerivative are the derivatives of
+	// the spline at the first and last of the fitted points.
+	LeftDerivative, RightDerivative float64
+
+	cubic PiecewiseCubic
+}
+
+// Predict returns the interpolation value at x.
+func (cc *ClampedCubic) Predict(x float64) float64 {
+	return cc.cubic.Predict(x)
+}
+
+// PredictDerivative returns the predicted derivative at x.
+func (cc *ClampedCubic) PredictDerivative(x float64) float64 {
+	return cc.cubic.PredictDerivative(x)
+}
+
+// Integrate returns the integral of the interpolated function from a to b.
+func (cc *ClampedCubic) Integrate(a, b float64) float64 {
+	return cc.cubic.Integrate(a, b)
+}
+
+// Fit fits a predictor to (X, Y) value pairs provided as two slices.
+// It panics if len(xs) < 2, elements of xs are not strictly increasing
+// or len(xs) != len(ys). It returns an error if solving the spline
+// equations fails.
+func (cc *ClampedCubic) Fit(xs, ys []float64) error {
+	return fitSpline(&cc.cubic, xs, ys, clamped, cc.LeftDerivative, cc.RightDerivative)
+}
+
+// NotAKnotCubic is a piecewise cubic 1-dimensional interpolator with
+// continuous value, first and second derivatives, which can be fitted to (X, Y)
+// value pairs without providing derivatives. It imposes the condition that
+// the third derivative of the interpolant is continuous in the first and
+// last interior node, so the first two and the last two segments are each
+// described by a single cubic. It reproduces cubic polynomials exactly.
+// With three points the interpolant is the parabola through them.
+type NotAKnotCubic struct {
+	cubic PiecewiseCubic
+}
+
+// Predict returns the interpolation value at x.
+func (nak *NotAKnotCubic) Predict(x float64) float64 {
+	return nak.cubic.Predict(x)
+}
+
+// PredictDerivative returns the predicted derivative at x.
+func (nak *NotAKnotCubic) PredictDerivative(x float64) float64 {
+	return nak.cubic.PredictDerivative(x)
+}
+
+// Integrate returns the integral of the interpolated function from a to b.
+func (nak *NotAKnotCubic) Integrate(a, b float64) float64 {
+	return nak.cubic.Integrate(a, b)
+}
+
+// Fit fits a predictor to (X, Y) value pairs provided as two slices.
+// It panics if len(xs) < 2, elements of xs are not strictly increasing
+// or len(xs) != len(ys). It returns an error if solving the spline
+// equations fails.
+func (nak *NotAKnotCubic) Fit(xs, ys []float64) error {
+	return fitSpline(&nak.cubic, xs, ys, notAKnot, 0, 0)
+}
+
+// fitSpline fits pc to the cubic spline through (xs, ys) with the given end
+// conditions. left and right are the end derivatives of a clamped spline.
+func fitSpline(pc *PiecewiseCubic, xs, ys []float64, cond endCondition, left, right float64) error {
+	validateXsYs(xs, ys)
+	dydxs := make([]float64, len(xs))
+	err := splineSlopes(dydxs, xs, ys, cond, left, right)
+	if err != nil {
+		return err
+	}
+	pc.FitWithDerivatives(xs, ys, dydxs)
+	return nil
+}
+
+// splineSlopes stores in dst the derivatives at xs of the cubic spline
+// through (xs, ys) with the given end conditions by solving the tridiagonal
+// system of equations for the continuity of the second derivative. left
+// and right are the end derivatives of a clamped spline.
+func splineSlopes(dst, xs, ys []float64, cond endCondition, left, right float64) error {
+	n := len(xs)
+	h := make([]float64, n-1)
+	delta := make([]float64, n-1)
+	for i := range h {
+		h[i] = xs[i+1] - xs[i]
+		delta[i] = (ys[i+1] - ys[i]) / h[i]
+	}
+
+	switch {
+	case n == 2 && cond != clamped:
+		// The natural and not-a-knot splines are the straight line.
+		dst[0] = delta[0]
+		dst[1] = delta[0]
+		return nil
+	case n == 3 && cond == notAKnot:
+		// The not-a-knot spline is the parabola through the points.
+		c := (delta[1] - delta[0]) / (h[0] + h[1])
+		dst[0] = delta[0] - c*h[0]
+		dst[1] = delta[0] + c*h[0]
+		dst[2] = delta[0] + c*(h[0]+2*h[1])
+		return nil
+	}
+
+	// The system is stored by diagonals: dl holds the subdiagonal,
+	// d the diagonal and du the superdiagonal. The right hand side
+	// is stored in dst.
+	dl := make([]float64, n-1)
+	d := make([]float64, n)
+	du := make([]float64, n-1)
+	for i := 1; i < n-1; i++ {
+		dl[i-1] = h[i]
+		d[i] = 2 * (h[i-1] + h[i])
+		du[i] = h[i-1]
+		dst[i] = 3 * (h[i]*delta[i-1] + h[i-1]*delta[i])
+	}
+	switch cond {
+	case natural:
+		d[0] = 2
+		du[0] = 1
+		dst[0] = 3 * delta[0]
+		dl[n-2] = 1
+		d[n-1] = 2
+		dst[n-1] = 3 * delta[n-2]
+	case clamped:
+		d[0] = 1
+		du[0] = 0
+		dst[0] = left
+		dl[n-2] = 0
+		d[n-1] = 1
+		dst[n-1] = right
+	case notAKnot:
+		s := h[0] + h[1]
+		d[0] = h[1]
+		du[0] = s
+		dst[0] = ((h[0]+2*s)*h[1]*delta[0] + h[0]*h[0]*delta[1]) / s
+		s = h[n-3] + h[n-2]
+		dl[n-2] = s
+		d[n-1] = h[n-3]
+		dst[n-1] = (h[n-2]*h[n-2]*delta[n-3] + (2*s+h[n-2])*h[n-3]*delta[n-2]) / s
+	default:
+		panic("interp: bad end condition")
+	}
+	if !solveTridiagonal(dl, d, du, dst) {
+		return errors.New("interp: singular spline equations")
+	}
+	return nil
+}
+
+// solveTridiagonal solves the tridiagonal system of equations A * x = b,
+// where the subdiagonal, diagonal and superdiagonal of A are given by dl,
+// d and du, using Gaussian elimination with partial pivoting. On return b
+// contains the solution x and dl, d and du are overwritten. It returns
+// whether A is nonsingular.
+//
+// solveTridiagonal follows the LAPACK routine Dgtsv.
+func solveTridiagonal(dl, d, du, b []float64) (ok bool) {
+	n := len(d)
+	for i := 0; i < n-1; i++ {
+		if math.Abs(d[i]) >= math.Abs(dl[i]) {
+			// No row interchange required.
+			if d[i] == 0 {
+				return false
+			}
+			fact := dl[i] / d[i]
+			d[i+1] -= fact * du[i]
+			b[i+1] -= fact * b[i]
+			dl[i] = 0
+			continue
+		}
+		// Interchange rows i and i+1.
+		fact := d[i] / dl[i]
+		d[i] = dl[i]
+		tmp := d[i+1]
+		d[i+1] = du[i] - fact*tmp
+		if i < n-2 {
+			// dl[i] holds the fill-in in the second superdiagonal.
+			dl[i] = du[i+1]
+			du[i+1] = -fact * dl[i]
+		} else {
+			dl[i] = 0
+		}
+		du[i] = tmp
+		b[i], b[i+1] = b[i+1], b[i]-fact*b[i+1]
+	}
+	if d[n-1] == 0 {
+		return false
+	}
+
+	// Back solve with the upper triangular matrix.
+	b[n-1] /= d[n-1]
+	if n > 1 {
+		b[n-2] = (b[n-2] - du[n-2]*b[n-1]) / d[n-2]
+	}
+	for i := n - 3; i >= 0; i-- {
+		b[i] = (b[i] - du[i]*b[i+1] - dl[i]*b[i+2]) / d[i]
+	}
+	return true
+}
@@ -0,0 +1,220 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interp
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestPiecewiseCubicFitWithDerivatives(t *testing.T) {
+	xs := []float64{-1, 0, 0.5, 2}
+	ys := make([]float64, len(xs))
+	dydxs := make([]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = poly3(x)
+		dydxs[i] = poly3d(x)
+	}
+	var pc PiecewiseCubic
+	pc.FitWithDerivatives(xs, ys, dydxs)
+	for x := xs[0]; x <= xs[len(xs)-1]; x += 0.05 {
+		if got, want := pc.Predict(x), poly3(x); math.Abs(got-want) > 1e-13 {
+			t.Errorf("unexpected value at %v: got:%v want:%v", x, got, want)
+		}
+		if got, want := pc.PredictDerivative(x), poly3d(x); math.Abs(got-want) > 1e-13 {
+			t.Errorf("unexpected derivative at %v: got:%v want:%v", x, got, want)
+		}
+	}
+	if !panics(func() { pc.FitWithDerivatives(xs, ys, dydxs[:2]) }) {
+		t.Errorf("expected panic for mismatched derivative length")
+	}
+}
+
+func TestNaturalCubicEnds(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{3, 4, 7} {
+		xs, ys := randomData(rnd, n)
+		var nc NaturalCubic
+		err := nc.Fit(xs, ys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// The second derivatives of the first and last segments
+		// vanish at the ends.
+		c := nc.cubic.coeffs[0]
+		if math.Abs(c[2]) > 1e-12 {
+			t.Errorf("n=%d: unexpected second derivative at left end: got:%v want:0", n, 2*c[2])
+		}
+		c = nc.cubic.coeffs[n-2]
+		h := xs[n-1] - xs[n-2]
+		if d2 := 2*c[2] + 6*c[3]*h; math.Abs(d2) > 1e-12 {
+			t.Errorf("n=%d: unexpected second derivative at right end: got:%v want:0", n, d2)
+		}
+		testSecondDerivativeContinuity(t, "NaturalCubic", &nc.cubic, xs)
+	}
+}
+
+func TestClampedCubicEnds(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{2, 3, 4, 7} {
+		xs, ys := randomData(rnd, n)
+		cc := ClampedCubic{LeftDerivative: -1.5, RightDerivative: 2}
+		err := cc.Fit(xs, ys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := cc.PredictDerivative(xs[0]); math.Abs(got-cc.LeftDerivative) > 1e-12 {
+			t.Errorf("n=%d: unexpected left derivative: got:%v want:%v", n, got, cc.LeftDerivative)
+		}
+		if got := cc.PredictDerivative(xs[n-1]); math.Abs(got-cc.RightDerivative) > 1e-12 {
+			t.Errorf("n=%d: unexpected right derivative: got:%v want:%v", n, got, cc.RightDerivative)
+		}
+		testSecondDerivativeContinuity(t, "ClampedCubic", &cc.cubic, xs)
+	}
+}
+
+func TestNotAKnotCubicEnds(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{4, 5, 8} {
+		xs, ys := randomData(rnd, n)
+		var nak NotAKnotCubic
+		err := nak.Fit(xs, ys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// The third derivative is continuous at the second
+		// and penultimate nodes.
+		c := nak.cubic.coeffs
+		if !floats.EqualWithinAbsOrRel(c[0][3], c[1][3], 1e-10, 1e-10) {
+			t.Errorf("n=%d: discontinuous third derivative at second node: %v != %v", n, c[0][3], c[1][3])
+		}
+		if !floats.EqualWithinAbsOrRel(c[n-3][3], c[n-2][3], 1e-10, 1e-10) {
+			t.Errorf("n=%d: discontinuous third derivative at penultimate node: %v != %v", n, c[n-3][3], c[n-2][3])
+		}
+		testSecondDerivativeContinuity(t, "NotAKnotCubic", &nak.cubic, xs)
+	}
+
+	// With three points the not-a-knot spline is the interpolating parabola.
+	xs := []float64{-1, 0.5, 2}
+	f := func(x float64) float64 { return (2*x-1)*x + 3 }
+	ys := []float64{f(xs[0]), f(xs[1]), f(xs[2])}
+	var nak NotAKnotCubic
+	err := nak.Fit(xs, ys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for x := xs[0]; x <= xs[2]; x += 0.1 {
+		if got, want := nak.Predict(x), f(x); math.Abs(got-want) > 1e-13 {
+			t.Errorf("unexpected value of three point spline at %v: got:%v want:%v", x, got, want)
+		}
+	}
+}
+
+// testSecondDerivativeContinuity checks that the second derivative of pc
+// is continuous at the interior nodes.
+func testSecondDerivativeContinuity(t *testing.T, name string, pc *PiecewiseCubic, xs []float64) {
+	for i := 1; i < len(xs)-1; i++ {
+		c := pc.coeffs[i-1]
+		h := xs[i] - xs[i-1]
+		left := 2*c[2] + 6*c[3]*h
+		right := 2 * pc.coeffs[i][2]
+		if !floats.EqualWithinAbsOrRel(left, right, 1e-10, 1e-10) {
+			t.Errorf("%s: discontinuous second derivative at node %d: left:%v right:%v", name, i, left, right)
+		}
+	}
+}
+
+func TestAkimaSplineLinearSegments(t *testing.T) {
+	// Akima splines reproduce straight line segments between
+	// collinear points without the oscillation of cubic splines.
+	xs := []float64{0, 1, 2, 3, 4, 5, 6}
+	ys := []float64{0, 0, 0, 0, 1, 2, 3}
+	var as AkimaSpline
+	err := as.Fit(xs, ys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for x := 0.0; x <= 2; x += 0.1 {
+		if got := as.Predict(x); got != 0 {
+			t.Errorf("unexpected value at %v: got:%v want:0", x, got)
+		}
+	}
+	for x := 4.0; x <= 6; x += 0.1 {
+		if got, want := as.Predict(x), x-3; math.Abs(got-want) > 1e-14 {
+			t.Errorf("unexpected value at %v: got:%v want:%v", x, got, want)
+		}
+	}
+}
+
+func TestSolveTridiagonal(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 4, 5, 10} {
+		for _, zeroDiag := range []bool{false, true} {
+			dl := make([]float64, n-1)
+			d := make([]float64, n)
+			du := make([]float64, n-1)
+			b := make([]float64, n)
+			for i := range d {
+				d[i] = rnd.NormFloat64()
+				if zeroDiag && i < n-1 {
+					// Force row interchanges.
+					d[i] = 0
+				}
+				b[i] = rnd.NormFloat64()
+			}
+			for i := range dl {
+				dl[i] = rnd.NormFloat64()
+				du[i] = rnd.NormFloat64()
+			}
+
+			a := mat.NewDense(n, n, nil)
+			for i := 0; i < n; i++ {
+				a.Set(i, i, d[i])
+				if i < n-1 {
+					a.Set(i+1, i, dl[i])
+					a.Set(i, i+1, du[i])
+				}
+			}
+			var want mat.VecDense
+			err := want.SolveVec(a, mat.NewVecDense(n, append([]float64(nil), b...)))
+			if err != nil {
+				t.Fatalf("n=%d: unexpected error from dense solve: %v", n, err)
+			}
+
+			ok := solveTridiagonal(dl, d, du, b)
+			if !ok {
+				t.Errorf("n=%d zeroDiag=%t: unexpected singular system", n, zeroDiag)
+				continue
+			}
+			if !floats.EqualApprox(b, want.RawVector().Data, 1e-10) {
+				t.Errorf("n=%d zeroDiag=%t: unexpected solution:\ngot: %v\nwant:%v", n, zeroDiag, b, want.RawVector().Data)
+			}
+		}
+	}
+
+	// A singular system is reported.
+	dl := []float64{1, 1}
+	d := []float64{1, 1, 1}
+	du := []float64{1, 0}
+	b := []float64{1, 2, 3}
+	if solveTridiagonal(dl, d, du, b) {
+		t.Errorf("expected singular system to be reported")
+	}
+}
+
+func randomData(rnd *rand.Rand, n int) (xs, ys []float64) {
+	xs = make([]float64, n)
+	ys = make([]float64, n)
+	for i := range xs {
+		xs[i] = float64(i) + 0.8*rnd.Float64()
+		ys[i] = rnd.NormFloat64()
+	}
+	return xs, ys
+}
@@ -0,0 +1,20 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package interp provides 1-dimensional and gridded 2-dimensional
+// interpolation of functions known at a set of points.
+//
+// The 1-dimensional interpolators are fitted to (x, y) pairs given as two
+// slices with the x values strictly increasing. The 2-dimensional
+// interpolators are fitted to values given on a rectilinear grid. All
+// interpolators predict the value, derivative and definite integral of the
+// interpolated function. Outside the range of the fitted data the
+// interpolated function is extended by the value at the nearest end point,
+// so its derivative there is zero.
+//
+// Besides exact interpolation, the package provides fitting of noisy data
+// by splines in a B-spline basis, either by least squares with given knots
+// or by penalized least squares as smoothing splines. BSplineBasis
+// evaluates the basis functions and the design matrix of a B-spline basis.
+package interp // import "gonum.org/v1/gonum/interp"
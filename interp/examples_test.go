@@ -0,0 +1,39 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interp_test
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/interp"
+)
+
+func Example() {
+	// Monotone data with a flat step.
+	xs := []float64{0, 1, 2, 3, 4}
+	ys := []float64{0, 0, 1, 1, 1}
+
+	for _, test := range []struct {
+		name string
+		ip   interp.Interpolator
+	}{
+		{name: "linear", ip: &interp.PiecewiseLinear{}},
+		{name: "natural", ip: &interp.NaturalCubic{}},
+		{name: "monotone", ip: &interp.FritschButland{}},
+	} {
+		err := test.ip.Fit(xs, ys)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Printf("%-8s f(0.5)=%.4f f(2.5)=%.4f f'(1.5)=%.4f ∫f=%.4f\n", test.name,
+			test.ip.Predict(0.5), test.ip.Predict(2.5), test.ip.PredictDerivative(1.5), test.ip.Integrate(0, 4))
+	}
+
+	// Output:
+	// linear   f(0.5)=0.0000 f(2.5)=1.0000 f'(1.5)=1.0000 ∫f=2.5000
+	// natural  f(0.5)=-0.1272 f(2.5)=1.1004 f'(1.5)=1.1741 ∫f=2.4643
+	// monotone f(0.5)=0.0000 f(2.5)=1.0000 f'(1.5)=1.5000 ∫f=2.5000
+}
@@ -0,0 +1,383 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interp
+
+import "gonum.org/v1/gonum/mat"
+
+// GridPredictor predicts the value of a function of two variables.
+// It handles both interpolation and extrapolation.
+type GridPredictor interface {
+	// Predict returns the predicted value at (x, y).
+	Predict(x, y float64) float64
+}
+
+// GridFitter fits a predictor to data on a rectilinear grid.
+type GridFitter interface {
+	// Fit fits a predictor to the values z on the rectilinear grid
+	// xs×ys, where z.At(i, j) is the value at (xs[i], ys[j]).
+	// It panics if len(xs) < 2 or len(ys) < 2, if the elements of xs or
+	// ys are not strictly increasing, or if the dimensions of z are not
+	// len(xs)×len(ys). Returns an error if fitting fails.
+	Fit(xs, ys []float64, z mat.Matrix) error
+}
+
+// GridInterpolator is a 2-dimensional interpolator on a rectilinear grid
+// which can fit itself to data and predict values, gradients and
+// integrals.
+type GridInterpolator interface {
+	GridFitter
+	GridPredictor
+
+	// PredictGradient returns the predicted partial derivatives
+	// with respect to x and y at (x, y).
+	PredictGradient(x, y float64) (dx, dy float64)
+
+	// Integrate returns the predicted integral of the function
+	// over the rectangle [x0, x1]×[y0, y1].
+	Integrate(x0, x1, y0, y1 float64) float64
+}
+
+// Bilinear is a bilinear interpolator on a rectilinear grid. On each cell
+// of the grid the interpolant is the tensor product of linear functions
+// in x and y.
+type Bilinear struct {
+	xs, ys []float64
+
+	// z holds the values on the grid in row-major order.
+	z []float64
+}
+
+// Fit fits a predictor to the values z on the rectilinear grid xs×ys,
+// where z.At(i, j) is the value at (xs[i], ys[j]).
+// It panics if len(xs) < 2 or len(ys) < 2, if the elements of xs or ys are
+// not strictly increasing, or if the dimensions of z are not
+// len(xs)×len(ys). Always returns nil.
+func (bl *Bilinear) Fit(xs, ys []float64, z mat.Matrix) error {
+	bl.xs, bl.ys, bl.z = fitGrid(xs, ys, z)
+	return nil
+}
+
+// Predict returns the interpolation value at (x, y). Outside the grid
+// it returns the value at the nearest point of the grid.
+func (bl *Bilinear) Predict(x, y float64) float64 {
+	i, wx := linearWeights(bl.xs, x, false)
+	j, wy := linearWeights(bl.ys, y, false)
+	return bl.sum(i, j, wx, wy)
+}
+
+// PredictGradient returns the partial derivatives of the interpolated
+// function with respect to x and y at (x, y). The conventions for points
+// on cell boundaries and outside the grid are those of
+// PiecewiseLinear.PredictDerivative applied in each direction.
+func (bl *Bilinear) PredictGradient(x, y float64) (dx, dy float64) {
+	i, wx := linearWeights(bl.xs, x, false)
+	j, wy := linearWeights(bl.ys, y, false)
+	_, dwx := linearWeights(bl.xs, x, true)
+	_, dwy := linearWeights(bl.ys, y, true)
+	return bl.sum(i, j, dwx, wy), bl.sum(i, j, wx, dwy)
+}
+
+// sum returns the weighted sum of the values at the corners of the cell
+// with lower left corner (xs[i], ys[j]).
+func (bl *Bilinear) sum(i, j int, wx, wy [2]float64) float64 {
+	ny := len(bl.ys)
+	var v float64
+	for a, u := range wx {
+		row := bl.z[(i+a)*ny+j:]
+		v += u * (wy[0]*row[0] + wy[1]*row[1])
+	}
+	return v
+}
+
+// Integrate returns the integral of the interpolated function over the
+// rectangle [x0, x1]×[y0, y1].
+func (bl *Bilinear) Integrate(x0, x1, y0, y1 float64) float64 {
+	wx := linearIntegralWeights(bl.xs, x0, x1)
+	wy := linearIntegralWeights(bl.ys, y0, y1)
+	var v float64
+	for i, u := range wx {
+		if u == 0 {
+			continue
+		}
+		for j, w := range wy {
+			v += u * w * bl.z[i*len(wy)+j]
+		}
+	}
+	return v
+}
+
+// linearWeights returns the index i of the grid cell containing x and the
+// weights of the values at xs[i] and xs[i+1] for the value, or the
+// derivative if deriv is true, of the linear interpolant at x. Outside the
+// grid the interpolant takes the value at the nearest end point.
+func linearWeights(xs []float64, x float64, deriv bool) (i int, w [2]float64) {
+	i, t, inside := cell(xs, x)
+	h := xs[i+1] - xs[i]
+	if deriv {
+		if !inside {
+			return i, w
+		}
+		return i, [2]float64{-1 / h, 1 / h}
+	}
+	return i, [2]float64{1 - t, t}
+}
+
+// linearIntegralWeights returns the weights of the values at xs for the
+// integral of the linear interpolant from a to b.
+func linearIntegralWeights(xs []float64, a, b float64) []float64 {
+	w := make([]float64, len(xs))
+	sign := 1.0
+	if a > b {
+		a, b = b, a
+		sign = -1
+	}
+	n := len(xs)
+	if a < xs[0] {
+		w[0] += sign * (min(b, xs[0]) - a)
+	}
+	if b > xs[n-1] {
+		w[n-1] += sign * (b - max(a, xs[n-1]))
+	}
+	for i := 0; i < n-1; i++ {
+		lo := max(a, xs[i])
+		hi := min(b, xs[i+1])
+		if lo >= hi {
+			continue
+		}
+		h := xs[i+1] - xs[i]
+		t0 := (lo - xs[i]) / h
+		t1 := (hi - xs[i]) / h
+		w[i] += sign * h * ((t1 - t1*t1/2) - (t0 - t0*t0/2))
+		w[i+1] += sign * h * (t1*t1 - t0*t0) / 2
+	}
+	return w
+}
+
+// Bicubic is a bicubic spline interpolator on a rectilinear grid. The
+// interpolant is the tensor product of not-a-knot cubic splines in x and y,
+// so it has continuous value, first and second derivatives, and its
+// restriction to each grid line is the NotAKnotCubic spline through the
+// values on that line.
+type Bicubic struct {
+	xs, ys []float64
+
+	// f, fx, fy and fxy hold the values and the partial derivatives of
+	// the interpolant on the grid in row-major order.
+	f, fx, fy, fxy []float64
+}
+
+// Fit fits a predictor to the values z on the rectilinear grid xs×ys,
+// where z.At(i, j) is the value at (xs[i], ys[j]).
+// It panics if len(xs) < 2 or len(ys) < 2, if the elements of xs or ys are
+// not strictly increasing, or if the dimensions of z are not
+// len(xs)×len(ys). It returns an error if solving the spline equations
+// fails.
+func (bc *Bicubic) Fit(xs, ys []float64, z mat.Matrix) error {
+	xs, ys, f := fitGrid(xs, ys, z)
+	nx := len(xs)
+	ny := len(ys)
+	fx := make([]float64, nx*ny)
+	fy := make([]float64, nx*ny)
+	fxy := make([]float64, nx*ny)
+
+	// The derivatives of the tensor product spline at the grid points
+	// are found by applying the 1-dimensional spline along each grid
+	// line, first to the values and then to the derivatives in y.
+	for i := 0; i < nx; i++ {
+		err := splineSlopes(fy[i*ny:(i+1)*ny], ys, f[i*ny:(i+1)*ny], notAKnot, 0, 0)
+		if err != nil {
+			return err
+		}
+	}
+	col := make([]float64, nx)
+	dcol := make([]float64, nx)
+	for j := 0; j < ny; j++ {
+		for _, src := range []struct{ in, out []float64 }{{f, fx}, {fy, fxy}} {
+			for i := range col {
+				col[i] = src.in[i*ny+j]
+			}
+			err := splineSlopes(dcol, xs, col, notAKnot, 0, 0)
+			if err != nil {
+				return err
+			}
+			for i, v := range dcol {
+				src.out[i*ny+j] = v
+			}
+		}
+	}
+	bc.xs, bc.ys = xs, ys
+	bc.f, bc.fx, bc.fy, bc.fxy = f, fx, fy, fxy
+	return nil
+}
+
+// Predict returns the interpolation value at (x, y). Outside the grid
+// it returns the value at the nearest point of the grid.
+func (bc *Bicubic) Predict(x, y float64) float64 {
+	i, wx := hermiteWeights(bc.xs, x, false)
+	j, wy := hermiteWeights(bc.ys, y, false)
+	return bc.sum(i, j, wx, wy)
+}
+
+// PredictGradient returns the partial derivatives of the interpolated
+// function with respect to x and y at (x, y). Outside the grid the
+// derivative in the direction away from the grid is zero.
+func (bc *Bicubic) PredictGradient(x, y float64) (dx, dy float64) {
+	i, wx := hermiteWeights(bc.xs, x, false)
+	j, wy := hermiteWeights(bc.ys, y, false)
+	_, dwx := hermiteWeights(bc.xs, x, true)
+	_, dwy := hermiteWeights(bc.ys, y, true)
+	return bc.sum(i, j, dwx, wy), bc.sum(i, j, wx, dwy)
+}
+
+// sum returns the weighted sum of the values and derivatives at the
+// corners of the cell with lower left corner (xs[i], ys[j]). The weights
+// are ordered as the value and derivative at the first node followed by
+// the value and derivative at the second node.
+func (bc *Bicubic) sum(i, j int, wx, wy [4]float64) float64 {
+	ny := len(bc.ys)
+	var v float64
+	for a := 0; a < 2; a++ {
+		for b := 0; b < 2; b++ {
+			k := (i+a)*ny + j + b
+			vx, dx := wx[2*a], wx[2*a+1]
+			vy, dy := wy[2*b], wy[2*b+1]
+			v += vx*vy*bc.f[k] + dx*vy*bc.fx[k] + vx*dy*bc.fy[k] + dx*dy*bc.fxy[k]
+		}
+	}
+	return v
+}
+
+// Integrate returns the integral of the interpolated function over the
+// rectangle [x0, x1]×[y0, y1].
+func (bc *Bicubic) Integrate(x0, x1, y0, y1 float64) float64 {
+	vx, dx := hermiteIntegralWeights(bc.xs, x0, x1)
+	vy, dy := hermiteIntegralWeights(bc.ys, y0, y1)
+	ny := len(bc.ys)
+	var v float64
+	for i := range vx {
+		if vx[i] == 0 && dx[i] == 0 {
+			continue
+		}
+		for j := range vy {
+			k := i*ny + j
+			v += vx[i]*vy[j]*bc.f[k] + dx[i]*vy[j]*bc.fx[k] + vx[i]*dy[j]*bc.fy[k] + dx[i]*dy[j]*bc.fxy[k]
+		}
+	}
+	return v
+}
+
+// hermiteWeights returns the index i of the grid cell containing x and the
+// weights of the value and derivative at xs[i] and the value and derivative
+// at xs[i+1] for the value, or the derivative if deriv is true, of the
+// cubic Hermite interpolant at x. Outside the grid the interpolant takes
+// the value at the nearest end point.
+func hermiteWeights(xs []float64, x float64, deriv bool) (i int, w [4]float64) {
+	i, t, inside := cell(xs, x)
+	h := xs[i+1] - xs[i]
+	if deriv {
+		if !inside {
+			return i, w
+		}
+		return i, [4]float64{
+			(6*t*t - 6*t) / h,
+			3*t*t - 4*t + 1,
+			(6*t - 6*t*t) / h,
+			3*t*t - 2*t,
+		}
+	}
+	return i, [4]float64{
+		(2*t-3)*t*t + 1,
+		h * ((t-2)*t + 1) * t,
+		(3 - 2*t) * t * t,
+		h * (t - 1) * t * t,
+	}
+}
+
+// hermiteIntegralWeights returns the weights of the values, v, and the
+// derivatives, d, at xs for the integral of the cubic Hermite interpolant
+// from a to b.
+func hermiteIntegralWeights(xs []float64, a, b float64) (v, d []float64) {
+	n := len(xs)
+	v = make([]float64, n)
+	d = make([]float64, n)
+	sign := 1.0
+	if a > b {
+		a, b = b, a
+		sign = -1
+	}
+	if a < xs[0] {
+		v[0] += sign * (min(b, xs[0]) - a)
+	}
+	if b > xs[n-1] {
+		v[n-1] += sign * (b - max(a, xs[n-1]))
+	}
+	// antiderivatives returns the integrals of the Hermite basis
+	// functions from 0 to t.
+	antiderivatives := func(t float64) [4]float64 {
+		t2 := t * t
+		return [4]float64{
+			t2*t2/2 - t2*t + t,
+			t2*t2/4 - 2*t2*t/3 + t2/2,
+			t2*t - t2*t2/2,
+			t2*t2/4 - t2*t/3,
+		}
+	}
+	for i := 0; i < n-1; i++ {
+		lo := max(a, xs[i])
+		hi := min(b, xs[i+1])
+		if lo >= hi {
+			continue
+		}
+		h := xs[i+1] - xs[i]
+		p0 := antiderivatives((lo - xs[i]) / h)
+		p1 := antiderivatives((hi - xs[i]) / h)
+		v[i] += sign * h * (p1[0] - p0[0])
+		d[i] += sign * h * h * (p1[1] - p0[1])
+		v[i+1] += sign * h * (p1[2] - p0[2])
+		d[i+1] += sign * h * h * (p1[3] - p0[3])
+	}
+	return v, d
+}
+
+// cell returns the index i of the grid cell [xs[i], xs[i+1]] containing x,
+// the position t of x within the cell scaled to [0, 1], and whether x is
+// within the grid. Outside the grid x is clamped to the nearest end point.
+// Interior grid points are placed at the start of the following cell.
+func cell(xs []float64, x float64) (i int, t float64, inside bool) {
+	n := len(xs)
+	switch {
+	case x < xs[0]:
+		return 0, 0, false
+	case x > xs[n-1]:
+		return n - 2, 1, false
+	}
+	i = findSegment(xs, x)
+	if i == n-1 {
+		return n - 2, 1, true
+	}
+	return i, (x - xs[i]) / (xs[i+1] - xs[i]), true
+}
+
+// fitGrid validates the grid xs×ys and the values z on it and returns
+// copies of xs and ys and the values in row-major order.
+func fitGrid(xs, ys []float64, z mat.Matrix) (xsCopy, ysCopy, zData []float64) {
+	validateXs(xs)
+	validateXs(ys)
+	r, c := z.Dims()
+	if r != len(xs) || c != len(ys) {
+		panic(mat.ErrShape)
+	}
+	xsCopy = make([]float64, len(xs))
+	copy(xsCopy, xs)
+	ysCopy = make([]float64, len(ys))
+	copy(ysCopy, ys)
+	zData = make([]float64, r*c)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			zData[i*c+j] = z.At(i, j)
+		}
+	}
+	return xsCopy, ysCopy, zData
+}
@@ -0,0 +1,233 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interp
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// gridInterpolators lists the 2-dimensional interpolators tested for
+// common properties.
+var gridInterpolators = []struct {
+	name string
+	new  func() GridInterpolator
+	// smooth is whether the interpolant has continuous first derivatives.
+	smooth bool
+}{
+	{name: "Bilinear", new: func() GridInterpolator { return &Bilinear{} }},
+	{name: "Bicubic", new: func() GridInterpolator { return &Bicubic{} }, smooth: true},
+}
+
+func TestGridFitPanics(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		xs, ys []float64
+		z      mat.Matrix
+	}{
+		{name: "too few x", xs: []float64{0}, ys: []float64{0, 1}, z: mat.NewDense(1, 2, nil)},
+		{name: "too few y", xs: []float64{0, 1}, ys: []float64{0}, z: mat.NewDense(2, 1, nil)},
+		{name: "x not increasing", xs: []float64{1, 0}, ys: []float64{0, 1}, z: mat.NewDense(2, 2, nil)},
+		{name: "y not increasing", xs: []float64{0, 1}, ys: []float64{0, 0}, z: mat.NewDense(2, 2, nil)},
+		{name: "bad rows", xs: []float64{0, 1, 2}, ys: []float64{0, 1}, z: mat.NewDense(2, 2, nil)},
+		{name: "bad columns", xs: []float64{0, 1}, ys: []float64{0, 1}, z: mat.NewDense(2, 3, nil)},
+	} {
+		for _, fn := range gridInterpolators {
+			gi := fn.new()
+			if !panics(func() { gi.Fit(test.xs, test.ys, test.z) }) {
+				t.Errorf("%s: expected panic for %s", fn.name, test.name)
+			}
+		}
+	}
+}
+
+func TestGridInterpolatorProperties(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, fn := range gridInterpolators {
+		for _, dims := range [][2]int{{2, 2}, {2, 5}, {3, 4}, {6, 5}} {
+			nx, ny := dims[0], dims[1]
+			xs, _ := randomData(rnd, nx)
+			ys, _ := randomData(rnd, ny)
+			z := mat.NewDense(nx, ny, nil)
+			for i := 0; i < nx; i++ {
+				for j := 0; j < ny; j++ {
+					z.Set(i, j, rnd.NormFloat64())
+				}
+			}
+			gi := fn.new()
+			err := gi.Fit(xs, ys, z)
+			if err != nil {
+				t.Errorf("%s %dx%d: unexpected error: %v", fn.name, nx, ny, err)
+				continue
+			}
+			name := fmt.Sprintf("%s %dx%d", fn.name, nx, ny)
+
+			for i, x := range xs {
+				for j, y := range ys {
+					if got, want := gi.Predict(x, y), z.At(i, j); math.Abs(got-want) > 1e-12 {
+						t.Errorf("%s: unexpected value at node (%d,%d): got:%v want:%v", name, i, j, got, want)
+					}
+				}
+			}
+
+			// Outside the grid the value is that at the nearest grid point.
+			for _, test := range []struct {
+				x, y float64
+				i, j int
+			}{
+				{x: xs[0] - 1, y: ys[0] - 1, i: 0, j: 0},
+				{x: xs[nx-1] + 1, y: ys[0] - 1, i: nx - 1, j: 0},
+				{x: xs[0] - 1, y: ys[ny-1] + 1, i: 0, j: ny - 1},
+				{x: math.Inf(1), y: math.Inf(1), i: nx - 1, j: ny - 1},
+			} {
+				if got, want := gi.Predict(test.x, test.y), z.At(test.i, test.j); got != want {
+					t.Errorf("%s: unexpected extrapolated value at (%v,%v): got:%v want:%v", name, test.x, test.y, got, want)
+				}
+				if dx, dy := gi.PredictGradient(test.x, test.y); dx != 0 || dy != 0 {
+					t.Errorf("%s: unexpected extrapolated gradient at (%v,%v): got:(%v,%v) want:(0,0)", name, test.x, test.y, dx, dy)
+				}
+			}
+
+			testGridIntegral(t, name, gi, xs, ys)
+			if fn.smooth {
+				testGridGradient(t, name, gi, xs, ys)
+			}
+		}
+	}
+}
+
+// testGridGradient checks the predicted gradient against central
+// differences of the predicted values.
+func testGridGradient(t *testing.T, name string, gi GridInterpolator, xs, ys []float64) {
+	const h = 1e-6
+	for i := 0; i < len(xs)-1; i++ {
+		for j := 0; j < len(ys)-1; j++ {
+			for _, u := range [][2]float64{{0.1, 0.7}, {0.5, 0.5}, {0.9, 0.2}} {
+				x := xs[i] + u[0]*(xs[i+1]-xs[i])
+				y := ys[j] + u[1]*(ys[j+1]-ys[j])
+				wantDx := (gi.Predict(x+h, y) - gi.Predict(x-h, y)) / (2 * h)
+				wantDy := (gi.Predict(x, y+h) - gi.Predict(x, y-h)) / (2 * h)
+				dx, dy := gi.PredictGradient(x, y)
+				if math.Abs(dx-wantDx) > 1e-6*math.Max(1, math.Abs(wantDx)) {
+					t.Errorf("%s: unexpected x derivative at (%v,%v): got:%v want:%v", name, x, y, dx, wantDx)
+				}
+				if math.Abs(dy-wantDy) > 1e-6*math.Max(1, math.Abs(wantDy)) {
+					t.Errorf("%s: unexpected y derivative at (%v,%v): got:%v want:%v", name, x, y, dy, wantDy)
+				}
+			}
+		}
+	}
+}
+
+// testGridIntegral checks the predicted integral against the integral of
+// the predicted values computed with tensor product Gauss–Legendre
+// quadrature on each grid cell.
+func testGridIntegral(t *testing.T, name string, gi GridInterpolator, xs, ys []float64) {
+	nx := len(xs)
+	ny := len(ys)
+	xBreaks := append(append([]float64{xs[0] - 1}, xs...), xs[nx-1]+1)
+	yBreaks := append(append([]float64{ys[0] - 1}, ys...), ys[ny-1]+1)
+	for _, r := range [][4]float64{
+		{xs[0], xs[nx-1], ys[0], ys[ny-1]},
+		{xBreaks[0], xBreaks[nx+1], yBreaks[0], yBreaks[ny+1]},
+		{xs[0] + 0.3, xs[nx-1] - 0.2, ys[0] + 0.1, ys[ny-1] - 0.4},
+		{xs[nx-1] - 0.2, xs[0] + 0.3, ys[0] + 0.1, ys[ny-1] - 0.4},
+		{xs[0] - 0.5, xs[0] + 0.5, ys[ny-1] + 0.5, ys[ny-1] - 0.5},
+	} {
+		x0, x1, y0, y1 := r[0], r[1], r[2], r[3]
+		want := gaussLegendre(func(x float64) float64 {
+			return gaussLegendre(func(y float64) float64 {
+				return gi.Predict(x, y)
+			}, yBreaks, y0, y1)
+		}, xBreaks, x0, x1)
+		got := gi.Integrate(x0, x1, y0, y1)
+		if math.Abs(got-want) > 1e-12*math.Max(1, math.Abs(want)) {
+			t.Errorf("%s: unexpected integral over [%v,%v]×[%v,%v]: got:%v want:%v", name, x0, x1, y0, y1, got, want)
+		}
+	}
+}
+
+func TestGridPolynomialReproduction(t *testing.T) {
+	xs := []float64{-2, -1.5, 0, 0.25, 1, 2.5}
+	ys := []float64{-1, 0, 0.5, 2, 3}
+	for _, test := range []struct {
+		name string
+		gi   GridInterpolator
+		// f is a polynomial reproduced by the interpolator
+		// and dfdx and dfdy are its partial derivatives.
+		f, dfdx, dfdy func(x, y float64) float64
+	}{
+		{
+			name: "Bilinear",
+			gi:   &Bilinear{},
+			f:    func(x, y float64) float64 { return poly1(x)*(2*y+1) + y },
+			dfdx: func(x, y float64) float64 { return poly1d(x) * (2*y + 1) },
+			dfdy: func(x, y float64) float64 { return 2*poly1(x) + 1 },
+		},
+		{
+			name: "Bicubic",
+			gi:   &Bicubic{},
+			f:    func(x, y float64) float64 { return poly3(x)*poly3(y) + 2*poly3(x) - y*y },
+			dfdx: func(x, y float64) float64 { return poly3d(x)*poly3(y) + 2*poly3d(x) },
+			dfdy: func(x, y float64) float64 { return poly3(x)*poly3d(y) - 2*y },
+		},
+	} {
+		z := mat.NewDense(len(xs), len(ys), nil)
+		for i, x := range xs {
+			for j, y := range ys {
+				z.Set(i, j, test.f(x, y))
+			}
+		}
+		err := test.gi.Fit(xs, ys, z)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		for x := xs[0]; x <= xs[len(xs)-1]; x += 0.3 {
+			for y := ys[0]; y <= ys[len(ys)-1]; y += 0.3 {
+				if got, want := test.gi.Predict(x, y), test.f(x, y); math.Abs(got-want) > 1e-11 {
+					t.Errorf("%s: unexpected value at (%v,%v): got:%v want:%v", test.name, x, y, got, want)
+				}
+				dx, dy := test.gi.PredictGradient(x, y)
+				if want := test.dfdx(x, y); math.Abs(dx-want) > 1e-10 {
+					t.Errorf("%s: unexpected x derivative at (%v,%v): got:%v want:%v", test.name, x, y, dx, want)
+				}
+				if want := test.dfdy(x, y); math.Abs(dy-want) > 1e-10 {
+					t.Errorf("%s: unexpected y derivative at (%v,%v): got:%v want:%v", test.name, x, y, dy, want)
+				}
+			}
+		}
+	}
+}
+
+func TestGridFitCopiesInput(t *testing.T) {
+	for _, fn := range gridInterpolators {
+		xs := []float64{0, 1, 2}
+		ys := []float64{0, 1, 2}
+		z := mat.NewDense(3, 3, []float64{
+			1, 3, 2,
+			4, 0, 1,
+			2, 2, 5,
+		})
+		gi := fn.new()
+		err := gi.Fit(xs, ys, z)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", fn.name, err)
+			continue
+		}
+		want := gi.Predict(1.5, 0.5)
+		xs[1] = 0.5
+		ys[1] = 0.5
+		z.Set(1, 0, 100)
+		if got := gi.Predict(1.5, 0.5); got != want {
+			t.Errorf("%s: prediction changed after modifying input", fn.name)
+		}
+	}
+}
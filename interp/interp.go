@@ -0,0 +1,247 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interp
+
+import "sort"
+
+const (
+	differentLengths        = "interp: input slices have different lengths"
+	tooFewPoints            = "interp: too few points for interpolation"
+	xsNotStrictlyIncreasing = "interp: xs values not strictly increasing"
+)
+
+// Predictor predicts the value of a function. It handles both
+// interpolation and extrapolation.
+type Predictor interface {
+	// Predict returns the predicted value at x.
+	Predict(x float64) float64
+}
+
+// Fitter fits a predictor to data.
+type Fitter interface {
+	// Fit fits a predictor to (X, Y) value pairs provided as two slices.
+	// It panics if len(xs) < 2, elements of xs are not strictly increasing
+	// or len(xs) != len(ys). Returns an error if fitting fails.
+	Fit(xs, ys []float64) error
+}
+
+// FittablePredictor is a Predictor which can fit itself to data.
+type FittablePredictor interface {
+	Fitter
+	Predictor
+}
+
+// DerivativePredictor predicts both the value and the derivative of
+// a function. It handles both interpolation and extrapolation.
+type DerivativePredictor interface {
+	Predictor
+
+	// PredictDerivative returns the predicted derivative at x.
+	PredictDerivative(x float64) float64
+}
+
+// Integrator predicts both the value and the definite integral of
+// a function. It handles both interpolation and extrapolation.
+type Integrator interface {
+	Predictor
+
+	// Integrate returns the predicted integral of the function
+	// between a and b.
+	Integrate(a, b float64) float64
+}
+
+// Interpolator is a 1-dimensional interpolator which can fit itself to
+// data and predict values, derivatives and integrals.
+type Interpolator interface {
+	Fitter
+	DerivativePredictor
+	Integrate(a, b float64) float64
+}
+
+// PiecewiseConstant is a left-continuous, piecewise constant
+// 1-dimensional interpolator.
+type PiecewiseConstant struct {
+	xs []float64
+	ys []float64
+
+	// cum[i] is the integral from xs[0] to xs[i].
+	cum []float64
+}
+
+// Predict returns the interpolation value at x.
+// For xs[i-1] < x <= xs[i] it returns ys[i]. Outside the range of xs
+// it returns the value at the nearest end point.
+func (pc *PiecewiseConstant) Predict(x float64) float64 {
+	n := len(pc.xs)
+	i := sort.SearchFloat64s(pc.xs, x)
+	if i == n {
+		return pc.ys[n-1]
+	}
+	return pc.ys[i]
+}
+
+// PredictDerivative returns the derivative of the interpolated function
+// at x, which is zero everywhere except at the points xs[1:len(xs)-1],
+// where it is undefined and zero is returned.
+func (pc *PiecewiseConstant) PredictDerivative(x float64) float64 {
+	return 0
+}
+
+// Integrate returns the integral of the interpolated function from a to b.
+func (pc *PiecewiseConstant) Integrate(a, b float64) float64 {
+	return pc.antiderivative(b) - pc.antiderivative(a)
+}
+
+// antiderivative returns the integral of the interpolated function from
+// xs[0] to x.
+func (pc *PiecewiseConstant) antiderivative(x float64) float64 {
+	n := len(pc.xs)
+	i := sort.SearchFloat64s(pc.xs, x)
+	switch i {
+	case 0:
+		return (x - pc.xs[0]) * pc.ys[0]
+	case n:
+		return pc.cum[n-1] + (x-pc.xs[n-1])*pc.ys[n-1]
+	}
+	return pc.cum[i-1] + (x-pc.xs[i-1])*pc.ys[i]
+}
+
+// Fit fits a predictor to (X, Y) value pairs provided as two slices.
+// It panics if len(xs) < 2, elements of xs are not strictly increasing
+// or len(xs) != len(ys). Always returns nil.
+func (pc *PiecewiseConstant) Fit(xs, ys []float64) error {
+	validateXsYs(xs, ys)
+	n := len(xs)
+	pc.xs = make([]float64, n)
+	copy(pc.xs, xs)
+	pc.ys = make([]float64, n)
+	copy(pc.ys, ys)
+	pc.cum = make([]float64, n)
+	for i := 1; i < n; i++ {
+		pc.cum[i] = pc.cum[i-1] + (xs[i]-xs[i-1])*ys[i]
+	}
+	return nil
+}
+
+// PiecewiseLinear is a piecewise linear 1-dimensional interpolator.
+type PiecewiseLinear struct {
+	xs     []float64
+	ys     []float64
+	slopes []float64
+
+	// cum[i] is the integral from xs[0] to xs[i].
+	cum []float64
+}
+
+// Predict returns the interpolation value at x. Outside the range of
+// xs it returns the value at the nearest end point.
+func (pl *PiecewiseLinear) Predict(x float64) float64 {
+	i := findSegment(pl.xs, x)
+	if i < 0 {
+		return pl.ys[0]
+	}
+	if i == len(pl.xs)-1 {
+		return pl.ys[i]
+	}
+	return pl.ys[i] + pl.slopes[i]*(x-pl.xs[i])
+}
+
+// PredictDerivative returns the derivative of the interpolated function
+// at x. At the interior points of xs the derivative from the right is
+// returned and at the last point the derivative from the left. Outside
+// the range of xs it returns zero.
+func (pl *PiecewiseLinear) PredictDerivative(x float64) float64 {
+	i := findSegment(pl.xs, x)
+	n := len(pl.xs)
+	switch {
+	case i < 0, x > pl.xs[n-1]:
+		return 0
+	case i == n-1:
+		return pl.slopes[n-2]
+	}
+	return pl.slopes[i]
+}
+
+// Integrate returns the integral of the interpolated function from a to b.
+func (pl *PiecewiseLinear) Integrate(a, b float64) float64 {
+	return pl.antiderivative(b) - pl.antiderivative(a)
+}
+
+// antiderivative returns the integral of the interpolated function from
+// xs[0] to x.
+func (pl *PiecewiseLinear) antiderivative(x float64) float64 {
+	i := findSegment(pl.xs, x)
+	if i < 0 {
+		return (x - pl.xs[0]) * pl.ys[0]
+	}
+	if i == len(pl.xs)-1 {
+		return pl.cum[i] + (x-pl.xs[i])*pl.ys[i]
+	}
+	dx := x - pl.xs[i]
+	return pl.cum[i] + dx*(pl.ys[i]+0.5*pl.slopes[i]*dx)
+}
+
+// Fit fits a predictor to (X, Y) value pairs provided as two slices.
+// It panics if len(xs) < 2, elements of xs are not strictly increasing
+// or len(xs) != len(ys). Always returns nil.
+func (pl *PiecewiseLinear) Fit(xs, ys []float64) error {
+	validateXsYs(xs, ys)
+	n := len(xs)
+	pl.xs = make([]float64, n)
+	copy(pl.xs, xs)
+	pl.ys = make([]float64, n)
+	copy(pl.ys, ys)
+	pl.slopes = make([]float64, n-1)
+	pl.cum = make([]float64, n)
+	for i := 0; i < n-1; i++ {
+		h := xs[i+1] - xs[i]
+		pl.slopes[i] = (ys[i+1] - ys[i]) / h
+		pl.cum[i+1] = pl.cum[i] + 0.5*h*(ys[i]+ys[i+1])
+	}
+	return nil
+}
+
+// findSegment returns 0 <= i < len(xs) such that xs[i] <= x < xs[i + 1],
+// where xs[len(xs)] is assumed to be +Inf. If no such i is found, it
+// returns -1. It assumes that len(xs) >= 2 without checking.
+func findSegment(xs []float64, x float64) int {
+	return sort.Search(len(xs), func(i int) bool { return xs[i] > x }) - 1
+}
+
+// validateXs checks that xs has at least two elements and that they
+// are strictly increasing. It panics otherwise.
+func validateXs(xs []float64) {
+	if len(xs) < 2 {
+		panic(tooFewPoints)
+	}
+	for i := 1; i < len(xs); i++ {
+		if xs[i] <= xs[i-1] {
+			panic(xsNotStrictlyIncreasing)
+		}
+	}
+}
+
+// validateXsYs checks that xs and ys have the same length and that xs
+// is valid according to validateXs. It panics otherwise.
+func validateXsYs(xs, ys []float64) {
+	if len(xs) != len(ys) {
+		panic(differentLengths)
+	}
+	validateXs(xs)
+}
+
+func min(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
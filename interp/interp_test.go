@@ -0,0 +1,402 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interp
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func panics(fun func()) (b bool) {
+	defer func() {
+		err := recover()
+		if err != nil {
+			b = true
+		}
+	}()
+	fun()
+	return
+}
+
+func TestFindSegment(t *testing.T) {
+	xs := []float64{0, 1, 2}
+	for _, test := range []struct {
+		x    float64
+		want int
+	}{
+		{x: -0.5, want: -1},
+		{x: 0, want: 0},
+		{x: 0.3, want: 0},
+		{x: 1, want: 1},
+		{x: 1.5, want: 1},
+		{x: 2, want: 2},
+		{x: 2.5, want: 2},
+	} {
+		if got := findSegment(xs, test.x); got != test.want {
+			t.Errorf("unexpected segment for x=%v: got:%d want:%d", test.x, got, test.want)
+		}
+	}
+}
+
+func TestFitPanics(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		xs, ys []float64
+	}{
+		{name: "too few points", xs: []float64{0}, ys: []float64{0}},
+		{name: "different lengths", xs: []float64{0, 1, 2}, ys: []float64{0, 1}},
+		{name: "not increasing", xs: []float64{0, 2, 1}, ys: []float64{0, 1, 2}},
+		{name: "repeated x", xs: []float64{0, 1, 1}, ys: []float64{0, 1, 2}},
+	} {
+		for _, fn := range interpolators {
+			ip := fn.new()
+			if !panics(func() { ip.Fit(test.xs, test.ys) }) {
+				t.Errorf("%s: expected panic for %s", fn.name, test.name)
+			}
+		}
+	}
+}
+
+func TestPiecewiseConstant(t *testing.T) {
+	var pc PiecewiseConstant
+	xs := []float64{0, 1, 3}
+	ys := []float64{2, -1, 4}
+	err := pc.Fit(xs, ys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, test := range []struct {
+		x    float64
+		want float64
+	}{
+		{x: -1, want: 2},
+		{x: 0, want: 2},
+		{x: 0.5, want: -1},
+		{x: 1, want: -1},
+		{x: 2, want: 4},
+		{x: 3, want: 4},
+		{x: 4, want: 4},
+	} {
+		if got := pc.Predict(test.x); got != test.want {
+			t.Errorf("unexpected value at x=%v: got:%v want:%v", test.x, got, test.want)
+		}
+		if got := pc.PredictDerivative(test.x); got != 0 {
+			t.Errorf("unexpected derivative at x=%v: got:%v want:0", test.x, got)
+		}
+	}
+	for _, test := range []struct {
+		a, b float64
+		want float64
+	}{
+		{a: 0, b: 3, want: -1 + 8},
+		{a: -1, b: 0, want: 2},
+		{a: -1, b: 4, want: 2 - 1 + 8 + 4},
+		{a: 0.5, b: 2, want: -0.5 + 4},
+		{a: 2, b: 0.5, want: 0.5 - 4},
+		{a: 1, b: 1, want: 0},
+	} {
+		if got := pc.Integrate(test.a, test.b); math.Abs(got-test.want) > 1e-14 {
+			t.Errorf("unexpected integral from %v to %v: got:%v want:%v", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestPiecewiseLinear(t *testing.T) {
+	var pl PiecewiseLinear
+	xs := []float64{0, 1, 3}
+	ys := []float64{2, -1, 4}
+	err := pl.Fit(xs, ys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, test := range []struct {
+		x          float64
+		want, dydx float64
+	}{
+		{x: -1, want: 2, dydx: 0},
+		{x: 0, want: 2, dydx: -3},
+		{x: 0.5, want: 0.5, dydx: -3},
+		{x: 1, want: -1, dydx: 2.5},
+		{x: 2, want: 1.5, dydx: 2.5},
+		{x: 3, want: 4, dydx: 2.5},
+		{x: 4, want: 4, dydx: 0},
+	} {
+		if got := pl.Predict(test.x); math.Abs(got-test.want) > 1e-14 {
+			t.Errorf("unexpected value at x=%v: got:%v want:%v", test.x, got, test.want)
+		}
+		if got := pl.PredictDerivative(test.x); math.Abs(got-test.dydx) > 1e-14 {
+			t.Errorf("unexpected derivative at x=%v: got:%v want:%v", test.x, got, test.dydx)
+		}
+	}
+	for _, test := range []struct {
+		a, b float64
+		want float64
+	}{
+		{a: 0, b: 3, want: 0.5 + 3},
+		{a: -1, b: 0, want: 2},
+		{a: 3, b: 5, want: 8},
+		{a: 0.5, b: 2, want: -0.125 + 0.25},
+		{a: 2, b: 0.5, want: 0.125 - 0.25},
+	} {
+		if got := pl.Integrate(test.a, test.b); math.Abs(got-test.want) > 1e-14 {
+			t.Errorf("unexpected integral from %v to %v: got:%v want:%v", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+// interpolators lists the 1-dimensional interpolators tested for common
+// properties.
+var interpolators = []struct {
+	name string
+	new  func() Interpolator
+	// smooth is whether the interpolant has continuous first derivatives.
+	smooth bool
+}{
+	{name: "PiecewiseConstant", new: func() Interpolator { return &PiecewiseConstant{} }},
+	{name: "PiecewiseLinear", new: func() Interpolator { return &PiecewiseLinear{} }},
+	{name: "AkimaSpline", new: func() Interpolator { return &AkimaSpline{} }, smooth: true},
+	{name: "FritschButland", new: func() Interpolator { return &FritschButland{} }, smooth: true},
+	{name: "NaturalCubic", new: func() Interpolator { return &NaturalCubic{} }, smooth: true},
+	{name: "ClampedCubic", new: func() Interpolator { return &ClampedCubic{} }, smooth: true},
+	{name: "NotAKnotCubic", new: func() Interpolator { return &NotAKnotCubic{} }, smooth: true},
+}
+
+func TestInterpolatorProperties(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, fn := range interpolators {
+		for _, n := range []int{2, 3, 4, 5, 10} {
+			xs, ys := randomData(rnd, n)
+			ip := fn.new()
+			err := ip.Fit(xs, ys)
+			if err != nil {
+				t.Errorf("%s n=%d: unexpected error: %v", fn.name, n, err)
+				continue
+			}
+			name := fmt.Sprintf("%s n=%d", fn.name, n)
+			testInterpolatesNodes(t, name, ip, xs, ys)
+			testExtrapolation(t, name, ip, xs, ys)
+			testIntegral(t, name, ip, xs)
+			if fn.smooth {
+				testDerivative(t, name, ip, xs)
+			}
+		}
+	}
+}
+
+func testInterpolatesNodes(t *testing.T, name string, ip Interpolator, xs, ys []float64) {
+	for i, x := range xs {
+		if got := ip.Predict(x); math.Abs(got-ys[i]) > 1e-12 {
+			t.Errorf("%s: unexpected value at node %d: got:%v want:%v", name, i, got, ys[i])
+		}
+	}
+}
+
+func testExtrapolation(t *testing.T, name string, ip DerivativePredictor, xs, ys []float64) {
+	n := len(xs)
+	for _, test := range []struct {
+		x, want float64
+	}{
+		{x: xs[0] - 1, want: ys[0]},
+		{x: math.Inf(-1), want: ys[0]},
+		{x: xs[n-1] + 1, want: ys[n-1]},
+		{x: math.Inf(1), want: ys[n-1]},
+	} {
+		if got := ip.Predict(test.x); got != test.want {
+			t.Errorf("%s: unexpected extrapolated value at %v: got:%v want:%v", name, test.x, got, test.want)
+		}
+		if got := ip.PredictDerivative(test.x); got != 0 {
+			t.Errorf("%s: unexpected extrapolated derivative at %v: got:%v want:0", name, test.x, got)
+		}
+	}
+}
+
+// testDerivative checks the predicted derivative against central
+// differences of the predicted values, and its continuity at the nodes.
+func testDerivative(t *testing.T, name string, ip DerivativePredictor, xs []float64) {
+	const h = 1e-6
+	n := len(xs)
+	for i := 0; i < n-1; i++ {
+		for _, u := range []float64{0.1, 0.5, 0.9} {
+			x := xs[i] + u*(xs[i+1]-xs[i])
+			want := (ip.Predict(x+h) - ip.Predict(x-h)) / (2 * h)
+			if got := ip.PredictDerivative(x); math.Abs(got-want) > 1e-6*math.Max(1, math.Abs(want)) {
+				t.Errorf("%s: unexpected derivative at %v: got:%v want:%v", name, x, got, want)
+			}
+		}
+	}
+	for i := 1; i < n-1; i++ {
+		left := ip.PredictDerivative(xs[i] - 1e-9)
+		right := ip.PredictDerivative(xs[i])
+		if math.Abs(left-right) > 1e-6*math.Max(1, math.Abs(right)) {
+			t.Errorf("%s: discontinuous derivative at node %d: left:%v right:%v", name, i, left, right)
+		}
+	}
+	if got, want := ip.PredictDerivative(xs[n-1]), ip.PredictDerivative(xs[n-1]-1e-9); math.Abs(got-want) > 1e-6*math.Max(1, math.Abs(want)) {
+		t.Errorf("%s: unexpected derivative at last node: got:%v want:%v", name, got, want)
+	}
+}
+
+// testIntegral checks the predicted integral against the integral of the
+// predicted values computed with Gauss–Legendre quadrature on each
+// segment, which is exact for piecewise polynomials of degree at most
+// five.
+func testIntegral(t *testing.T, name string, ip Interpolator, xs []float64) {
+	n := len(xs)
+	// Include the extrapolated regions beyond each end.
+	breaks := append([]float64{xs[0] - 1.5}, xs...)
+	breaks = append(breaks, xs[n-1]+2)
+	for _, ab := range [][2]float64{
+		{xs[0], xs[n-1]},
+		{breaks[0], breaks[len(breaks)-1]},
+		{xs[0] + 0.3, xs[n-1] - 0.2},
+		{xs[n-1] - 0.2, xs[0] + 0.3},
+		{xs[0] - 1, xs[0] - 0.5},
+	} {
+		a, b := ab[0], ab[1]
+		want := gaussLegendre(ip.Predict, breaks, a, b)
+		got := ip.Integrate(a, b)
+		if math.Abs(got-want) > 1e-12*math.Max(1, math.Abs(want)) {
+			t.Errorf("%s: unexpected integral from %v to %v: got:%v want:%v", name, a, b, got, want)
+		}
+	}
+}
+
+// gaussLegendre returns the integral of f from a to b using three-point
+// Gauss–Legendre quadrature on each of the intervals between breaks that
+// overlaps [a, b].
+func gaussLegendre(f func(float64) float64, breaks []float64, a, b float64) float64 {
+	sign := 1.0
+	if a > b {
+		a, b = b, a
+		sign = -1
+	}
+	nodes := []float64{-math.Sqrt(0.6), 0, math.Sqrt(0.6)}
+	weights := []float64{5.0 / 9, 8.0 / 9, 5.0 / 9}
+	var sum float64
+	for i := 0; i < len(breaks)-1; i++ {
+		lo := math.Max(a, breaks[i])
+		hi := math.Min(b, breaks[i+1])
+		if lo >= hi {
+			continue
+		}
+		mid := (lo + hi) / 2
+		half := (hi - lo) / 2
+		for k, u := range nodes {
+			sum += half * weights[k] * f(mid+half*u)
+		}
+	}
+	return sign * sum
+}
+
+func TestPolynomialReproduction(t *testing.T) {
+	xs := []float64{-2, -1.5, 0, 0.25, 1, 2.5, 3}
+	for _, test := range []struct {
+		name   string
+		ip     Interpolator
+		degree int
+	}{
+		{name: "PiecewiseLinear", ip: &PiecewiseLinear{}, degree: 1},
+		{name: "AkimaSpline", ip: &AkimaSpline{}, degree: 1},
+		{name: "FritschButland", ip: &FritschButland{}, degree: 1},
+		{name: "NaturalCubic", ip: &NaturalCubic{}, degree: 1},
+		{name: "NotAKnotCubic", ip: &NotAKnotCubic{}, degree: 3},
+		{name: "ClampedCubic", ip: &ClampedCubic{LeftDerivative: poly3d(xs[0]), RightDerivative: poly3d(xs[len(xs)-1])}, degree: 3},
+	} {
+		f, df := poly1, poly1d
+		if test.degree == 3 {
+			f, df = poly3, poly3d
+		}
+		ys := make([]float64, len(xs))
+		for i, x := range xs {
+			ys[i] = f(x)
+		}
+		err := test.ip.Fit(xs, ys)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		for x := xs[0]; x <= xs[len(xs)-1]; x += 0.1 {
+			if got, want := test.ip.Predict(x), f(x); math.Abs(got-want) > 1e-12 {
+				t.Errorf("%s: unexpected value at %v: got:%v want:%v", test.name, x, got, want)
+			}
+			if got, want := test.ip.PredictDerivative(x), df(x); math.Abs(got-want) > 1e-11 {
+				t.Errorf("%s: unexpected derivative at %v: got:%v want:%v", test.name, x, got, want)
+			}
+		}
+	}
+}
+
+func poly1(x float64) float64  { return 3*x - 2 }
+func poly1d(x float64) float64 { return 3 }
+func poly3(x float64) float64  { return ((0.5*x-1)*x+2)*x - 3 }
+func poly3d(x float64) float64 { return (1.5*x-2)*x + 2 }
+
+func TestFritschButlandMonotone(t *testing.T) {
+	// Monotone data with steps for which cubic splines overshoot.
+	xs := []float64{0, 1, 2, 3, 4, 5, 6, 7}
+	ys := []float64{0, 0, 0, 1, 1, 1, 5, 5.5}
+	var fb FritschButland
+	err := fb.Fit(xs, ys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	prev := fb.Predict(xs[0])
+	for x := xs[0]; x <= xs[len(xs)-1]; x += 0.01 {
+		v := fb.Predict(x)
+		if v < prev {
+			t.Fatalf("interpolant not monotone at %v: %v < %v", x, v, prev)
+		}
+		if fb.PredictDerivative(x) < 0 {
+			t.Fatalf("negative derivative at %v", x)
+		}
+		prev = v
+	}
+	// The interpolant is flat where the data are.
+	for _, x := range []float64{0.5, 1.5, 3.5, 4.5} {
+		want := ys[int(x)]
+		if got := fb.Predict(x); got != want {
+			t.Errorf("unexpected value on flat segment at %v: got:%v want:%v", x, got, want)
+		}
+	}
+
+	var nc NaturalCubic
+	err = nc.Fit(xs, ys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	overshoot := false
+	for x := xs[0]; x <= xs[5]; x += 0.01 {
+		v := nc.Predict(x)
+		if v < 0 || 1 < v {
+			overshoot = true
+			break
+		}
+	}
+	if !overshoot {
+		t.Errorf("expected natural spline to overshoot on the test data")
+	}
+}
+
+func TestFitCopiesInput(t *testing.T) {
+	for _, fn := range interpolators {
+		xs := []float64{0, 1, 2, 3}
+		ys := []float64{1, 3, 2, 4}
+		ip := fn.new()
+		err := ip.Fit(xs, ys)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", fn.name, err)
+			continue
+		}
+		want := ip.Predict(1.5)
+		xs[1] = 0.5
+		ys[1] = 100
+		if got := ip.Predict(1.5); got != want {
+			t.Errorf("%s: prediction changed after modifying input", fn.name)
+		}
+	}
+}
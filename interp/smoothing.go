@@ -0,0 +1,326 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interp
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/mat"
+)
+
+// eps is the machine epsilon.
+const eps = 1.0 / (1 << 53)
+
+// SmoothingSpline is a cubic smoothing spline. It is the cubic spline f
+// on a set of knots which minimizes the penalized sum of squares
+//  Σ_i w_i * (y_i - f(x_i))² + λ * ∫ f''(x)² dx
+// for data (x_i, y_i) with weights w_i and the smoothing parameter λ.
+// Outside the range of the knots it takes the value at the nearest end
+// knot.
+type SmoothingSpline struct {
+	// Knots are the knots of the spline. If Knots is nil, the
+	// distinct values of the fitted xs are used, and the fitted
+	// spline is the natural cubic smoothing spline.
+	Knots []float64
+
+	// Lambda is the smoothing parameter λ. If Lambda is zero, λ is
+	// chosen to minimize the generalized cross-validation score.
+	Lambda float64
+
+	lambda float64
+	spline BSpline
+}
+
+// SmoothingParameter returns the smoothing parameter λ of the fitted
+// spline.
+func (ss *SmoothingSpline) SmoothingParameter() float64 {
+	return ss.lambda
+}
+
+// Predict returns the value of the fitted spline at x.
+func (ss *SmoothingSpline) Predict(x float64) float64 {
+	return ss.spline.Predict(x)
+}
+
+// PredictDerivative returns the derivative of the fitted spline at x.
+// Outside the range of the knots it returns zero.
+func (ss *SmoothingSpline) PredictDerivative(x float64) float64 {
+	return ss.spline.PredictDerivative(x)
+}
+
+// Integrate returns the integral of the fitted spline from a to b.
+func (ss *SmoothingSpline) Integrate(a, b float64) float64 {
+	return ss.spline.Integrate(a, b)
+}
+
+// Fit fits the spline to (X, Y) value pairs provided as two slices.
+// It is equivalent to FitWeighted(xs, ys, nil).
+func (ss *SmoothingSpline) Fit(xs, ys []float64) error {
+	return ss.FitWeighted(xs, ys, nil)
+}
+
+// FitWeighted fits the spline to (X, Y) value pairs provided as two slices
+// with the given weights. If weights is nil, all weights are one. The
+// elements of xs need not be sorted or distinct.
+//
+// If ss.Lambda is zero, the smoothing parameter is chosen to minimize the
+// generalized cross-validation score
+//  V(λ) = n * Σ_i w_i * (y_i - f(x_i))² / (n - tr(H(λ)))²
+// where n is the number of points with positive weight and H(λ) is the
+// matrix mapping the ys to the fitted values. See
+// Craven, P. and Wahba, G. Smoothing noisy data with spline functions.
+// Numer. Math. 31, 377–403 (1979).
+//
+// FitWeighted panics if len(xs) != len(ys), if weights is not nil and
+// len(weights) != len(xs), if any weight is negative, if ss.Lambda is
+// negative, if there are fewer than three distinct values in xs, if
+// ss.Knots is not nil and is not a strictly increasing sequence of at least
+// two elements or if any of xs is outside the range of ss.Knots. It
+// returns an error if the penalized least squares problem cannot be
+// solved.
+func (ss *SmoothingSpline) FitWeighted(xs, ys, weights []float64) error {
+	if ss.Lambda < 0 {
+		panic("interp: negative smoothing parameter")
+	}
+	if len(xs) != len(ys) {
+		panic(differentLengths)
+	}
+	distinct := append([]float64(nil), xs...)
+	sort.Float64s(distinct)
+	n := 0
+	for i, x := range distinct {
+		if i == 0 || x != distinct[n-1] {
+			distinct[n] = x
+			n++
+		}
+	}
+	distinct = distinct[:n]
+	if len(distinct) < 3 {
+		panic(tooFewPoints)
+	}
+
+	knots := ss.Knots
+	if knots == nil {
+		knots = distinct
+	}
+	basis := NewBSplineBasis(3, knots)
+	validateData(basis, xs, ys, weights)
+	g, rhs := normalEquations(basis, xs, ys, weights)
+	omega := cubicPenalty(basis)
+
+	f := &penalizedFit{
+		basis: basis,
+		xs:    xs, ys: ys, weights: weights,
+		g: g, omega: omega, rhs: rhs,
+	}
+
+	lambda := ss.Lambda
+	if lambda == 0 {
+		var ok bool
+		lambda, ok = f.minimizeGCV()
+		if !ok {
+			return errors.New("interp: no finite generalized cross-validation score")
+		}
+	}
+	coeffs, _, ok := f.solve(lambda)
+	if !ok {
+		return errNotPositiveDefinite
+	}
+	ss.lambda = lambda
+	ss.spline.set(basis, coeffs)
+	return nil
+}
+
+// penalizedFit holds the normal equations of a penalized least squares
+// spline fit.
+type penalizedFit struct {
+	basis           *BSplineBasis
+	xs, ys, weights []float64
+	g, omega        *mat.SymBandDense
+	rhs             []float64
+}
+
+// solve returns the coefficients of the spline minimizing the penalized
+// sum of squares with smoothing parameter lambda, and the Cholesky
+// factor of the matrix of the penalized normal equations. It returns
+// whether the equations could be solved.
+func (f *penalizedFit) solve(lambda float64) (coeffs []float64, u blas64.TriangularBand, ok bool) {
+	n, k := f.g.SymBand()
+	a := mat.NewSymBandDense(n, k, nil)
+	ra := a.RawSymBand()
+	rg := f.g.RawSymBand()
+	ro := f.omega.RawSymBand()
+	for i := range ra.Data {
+		ra.Data[i] = rg.Data[i] + lambda*ro.Data[i]
+	}
+	coeffs = append([]float64(nil), f.rhs...)
+	u, ok = solveBand(a, coeffs)
+	return coeffs, u, ok
+}
+
+// gcv returns the generalized cross-validation score of the fit with
+// smoothing parameter lambda. It returns +Inf if the fit cannot be
+// computed or nearly interpolates the data, where the score is dominated
+// by rounding error.
+func (f *penalizedFit) gcv(lambda float64) float64 {
+	coeffs, u, ok := f.solve(lambda)
+	if !ok {
+		return math.Inf(1)
+	}
+	var rss, n float64
+	for i, x := range f.xs {
+		w := 1.0
+		if f.weights != nil {
+			w = f.weights[i]
+		}
+		if w == 0 {
+			continue
+		}
+		r := f.ys[i] - evalBSpline(f.basis, coeffs, x, 0)
+		rss += w * r * r
+		n++
+	}
+	df := n - traceInverseProduct(u, f.g.RawSymBand())
+	if df <= math.Sqrt(eps)*n {
+		return math.Inf(1)
+	}
+	return n * rss / (df * df)
+}
+
+// minimizeGCV returns the smoothing parameter minimizing the generalized
+// cross-validation score and whether a finite score was found. The score
+// is minimized over the logarithm of the smoothing parameter relative to
+// the ratio of the traces of the data and penalty matrices by a grid
+// search followed by golden section search around the best grid point.
+func (f *penalizedFit) minimizeGCV() (lambda float64, ok bool) {
+	const (
+		lo   = -10
+		hi   = 6
+		step = 0.5
+		tol  = 1e-4
+	)
+	scale := f.g.Trace() / f.omega.Trace()
+	score := func(r float64) float64 {
+		return f.gcv(scale * math.Pow(10, r))
+	}
+
+	best := math.NaN()
+	bestScore := math.Inf(1)
+	for r := float64(lo); r <= hi; r += step {
+		s := score(r)
+		if s < bestScore {
+			best = r
+			bestScore = s
+		}
+	}
+	if math.IsInf(bestScore, 1) {
+		return 0, false
+	}
+
+	// Refine the minimum by golden section search.
+	invPhi := (math.Sqrt(5) - 1) / 2
+	a := best - step
+	b := best + step
+	c := b - invPhi*(b-a)
+	d := a + invPhi*(b-a)
+	fc := score(c)
+	fd := score(d)
+	for b-a > tol {
+		if fc < fd {
+			b, d, fd = d, c, fc
+			c = b - invPhi*(b-a)
+			fc = score(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a + invPhi*(b-a)
+			fd = score(d)
+		}
+	}
+	r := (a + b) / 2
+	if score(r) > bestScore {
+		r = best
+	}
+	return scale * math.Pow(10, r), true
+}
+
+// cubicPenalty returns the band matrix with elements
+//  ∫ N_i''(x) * N_j''(x) dx
+// for the functions N_i of the cubic B-spline basis b. The integrals are
+// computed exactly by two-point Gauss–Legendre quadrature on each knot
+// span.
+func cubicPenalty(b *BSplineBasis) *mat.SymBandDense {
+	if b.degree != 3 {
+		panic("interp: penalty basis not cubic")
+	}
+	n := b.Len()
+	omega := mat.NewSymBandDense(n, 3, nil)
+	raw := omega.RawSymBand()
+	var d2 [4]float64
+	for s := 0; s < len(b.knots)-1; s++ {
+		mu := s + 3
+		mid := (b.knots[s] + b.knots[s+1]) / 2
+		half := (b.knots[s+1] - b.knots[s]) / 2
+		for _, u := range []float64{-1 / math.Sqrt(3), 1 / math.Sqrt(3)} {
+			b.nonzero(d2[:], mu, mid+half*u, 2)
+			for j, v := range d2 {
+				row := raw.Data[(mu-3+j)*raw.Stride:]
+				for l, w := range d2[j:] {
+					row[l] += half * v * w
+				}
+			}
+		}
+	}
+	return omega
+}
+
+// traceInverseProduct returns tr(A⁻¹ * G) for the symmetric band matrices
+// A and G with the same bandwidth, where u holds the upper triangular
+// Cholesky factor of A = Uᵀ * U. Only the elements of A⁻¹ within the band
+// are computed, using the recurrence of
+// Hutchinson, M. F. and de Hoog, F. R. Smoothing noisy data with spline
+// functions. Numer. Math. 47, 99–106 (1985).
+func traceInverseProduct(u blas64.TriangularBand, g blas64.SymmetricBand) float64 {
+	n, k := u.N, u.K
+	// sigma[i*(k+1)+d] holds element (i, i+d) of A⁻¹.
+	sigma := make([]float64, n*(k+1))
+	at := func(i, j int) float64 {
+		if i > j {
+			i, j = j, i
+		}
+		return sigma[i*(k+1)+j-i]
+	}
+	var trace float64
+	for i := n - 1; i >= 0; i-- {
+		row := u.Data[i*u.Stride:]
+		m := k
+		if n-1-i < m {
+			m = n - 1 - i
+		}
+		// The elements of Uᵀ⁻¹ above the diagonal are zero, so
+		// for j >= i
+		//  (U * A⁻¹)[i][j] = δ_ij / U[i][i].
+		for d := m; d >= 0; d-- {
+			j := i + d
+			var s float64
+			for l := 1; l <= m; l++ {
+				s += row[l] * at(i+l, j)
+			}
+			v := -s
+			if d == 0 {
+				v += 1 / row[0]
+			}
+			sigma[i*(k+1)+d] = v / row[0]
+		}
+		grow := g.Data[i*g.Stride:]
+		trace += sigma[i*(k+1)] * grow[0]
+		for d := 1; d <= m; d++ {
+			trace += 2 * sigma[i*(k+1)+d] * grow[d]
+		}
+	}
+	return trace
+}
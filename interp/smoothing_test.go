@@ -0,0 +1,235 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interp
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack/lapack64"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+func TestSmoothingSplineLimits(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	xs, ys := randomData(rnd, 12)
+
+	// For small λ the smoothing spline approaches the natural cubic
+	// interpolating spline.
+	var nc NaturalCubic
+	err := nc.Fit(xs, ys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ss := SmoothingSpline{Lambda: 1e-10}
+	err = ss.Fit(xs, ys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := ss.SmoothingParameter(); got != ss.Lambda {
+		t.Errorf("unexpected smoothing parameter: got:%v want:%v", got, ss.Lambda)
+	}
+	for x := xs[0]; x <= xs[len(xs)-1]; x += 0.1 {
+		if got, want := ss.Predict(x), nc.Predict(x); math.Abs(got-want) > 1e-6 {
+			t.Errorf("unexpected value for small λ at %v: got:%v want:%v", x, got, want)
+		}
+	}
+
+	// For large λ the smoothing spline approaches the least squares
+	// regression line.
+	alpha, beta := stat.LinearRegression(xs, ys, nil, false)
+	ss = SmoothingSpline{Lambda: 1e10}
+	err = ss.Fit(xs, ys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for x := xs[0]; x <= xs[len(xs)-1]; x += 0.1 {
+		if got, want := ss.Predict(x), alpha+beta*x; math.Abs(got-want) > 1e-6 {
+			t.Errorf("unexpected value for large λ at %v: got:%v want:%v", x, got, want)
+		}
+	}
+}
+
+func TestSmoothingSplineObjective(t *testing.T) {
+	// The fitted spline minimizes the penalized sum of squares, so
+	// perturbing its coefficients increases the objective.
+	rnd := rand.New(rand.NewSource(1))
+	n := 30
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	weights := make([]float64, n)
+	for i := range xs {
+		xs[i] = 10 * rnd.Float64()
+		ys[i] = math.Sin(xs[i]) + 0.2*rnd.NormFloat64()
+		weights[i] = 0.5 + rnd.Float64()
+	}
+	knots := []float64{0, 1, 2.5, 4, 5, 7, 8.5, 10}
+	ss := SmoothingSpline{Knots: knots, Lambda: 0.3}
+	err := ss.FitWeighted(xs, ys, weights)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	objective := func(s *BSpline) float64 {
+		var v float64
+		for i, x := range xs {
+			r := ys[i] - s.Predict(x)
+			v += weights[i] * r * r
+		}
+		// The second derivative of a cubic spline is piecewise
+		// linear, so the integral of its square is computed
+		// exactly by three-point Gauss–Legendre quadrature.
+		d2 := func(x float64) float64 {
+			return evalBSpline(s.basis, s.coeffs, x, 2)
+		}
+		return v + ss.Lambda*gaussLegendre(func(x float64) float64 { return d2(x) * d2(x) }, knots, knots[0], knots[len(knots)-1])
+	}
+	best := objective(&ss.spline)
+	for i := range ss.spline.coeffs {
+		for _, delta := range []float64{-1e-3, 1e-3} {
+			c := append([]float64(nil), ss.spline.coeffs...)
+			c[i] += delta
+			if got := objective(NewBSpline(ss.spline.basis, c)); got <= best {
+				t.Errorf("perturbing coefficient %d by %v does not increase objective: %v <= %v", i, delta, got, best)
+			}
+		}
+	}
+}
+
+func TestSmoothingSplineGCV(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	const n = 100
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	truth := make([]float64, n)
+	for i := range xs {
+		xs[i] = float64(i) / n * 2 * math.Pi
+		truth[i] = math.Sin(xs[i])
+		ys[i] = truth[i] + 0.3*rnd.NormFloat64()
+	}
+	var ss SmoothingSpline
+	err := ss.Fit(xs, ys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lambda := ss.SmoothingParameter()
+	if lambda <= 0 {
+		t.Fatalf("unexpected smoothing parameter: %v", lambda)
+	}
+
+	var dataErr, fitErr float64
+	for i, x := range xs {
+		dataErr += (ys[i] - truth[i]) * (ys[i] - truth[i])
+		d := ss.Predict(x) - truth[i]
+		fitErr += d * d
+	}
+	if fitErr > dataErr/4 {
+		t.Errorf("smoothing spline does not reduce error: data:%v fit:%v", dataErr, fitErr)
+	}
+
+	// The GCV score at the chosen λ is not larger than at nearby
+	// values or at the extremes.
+	f := newTestPenalizedFit(xs, ys)
+	best := f.gcv(lambda)
+	for _, l := range []float64{lambda / 2, lambda * 2, lambda * 1e-6, lambda * 1e6} {
+		if score := f.gcv(l); score < best {
+			t.Errorf("GCV score at λ=%v smaller than at chosen λ=%v: %v < %v", l, lambda, score, best)
+		}
+	}
+}
+
+func newTestPenalizedFit(xs, ys []float64) *penalizedFit {
+	knots := append([]float64(nil), xs...)
+	b := NewBSplineBasis(3, knots)
+	g, rhs := normalEquations(b, xs, ys, nil)
+	return &penalizedFit{
+		basis: b,
+		xs:    xs, ys: ys,
+		g: g, omega: cubicPenalty(b), rhs: rhs,
+	}
+}
+
+func TestSmoothingSplinePanics(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		ss     SmoothingSpline
+		xs, ys []float64
+	}{
+		{name: "different lengths", xs: []float64{0, 1, 2}, ys: []float64{0, 1}},
+		{name: "too few distinct", xs: []float64{0, 1, 1, 0}, ys: []float64{0, 1, 2, 3}},
+		{name: "negative λ", ss: SmoothingSpline{Lambda: -1}, xs: []float64{0, 1, 2}, ys: []float64{0, 1, 2}},
+		{name: "x outside knots", ss: SmoothingSpline{Knots: []float64{0, 1}}, xs: []float64{0, 1, 2}, ys: []float64{0, 1, 2}},
+		{name: "bad knots", ss: SmoothingSpline{Knots: []float64{0, 2, 1}}, xs: []float64{0, 1, 2}, ys: []float64{0, 1, 2}},
+	} {
+		if !panics(func() { test.ss.Fit(test.xs, test.ys) }) {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+}
+
+func TestCubicPenalty(t *testing.T) {
+	// The coefficients of x³ give the penalty ∫ (6x)² dx.
+	knots := []float64{-1, 0, 0.5, 2}
+	b := NewBSplineBasis(3, knots)
+	xs := []float64{-1, -0.5, 0, 0.25, 0.5, 1, 1.5, 2}
+	ys := make([]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = x * x * x
+	}
+	ls := LeastSquaresBSpline{Basis: b}
+	err := ls.Fit(xs, ys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c := mat.NewVecDense(b.Len(), ls.spline.coeffs)
+	got := mat.Inner(c, cubicPenalty(b), c)
+	want := 12 * (math.Pow(2, 3) - math.Pow(-1, 3))
+	if math.Abs(got-want) > 1e-10 {
+		t.Errorf("unexpected penalty: got:%v want:%v", got, want)
+	}
+}
+
+func TestTraceInverseProduct(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 4, 10} {
+		for _, k := range []int{0, 1, 3} {
+			if k >= n {
+				continue
+			}
+			a := mat.NewSymBandDense(n, k, nil)
+			g := mat.NewSymBandDense(n, k, nil)
+			for i := 0; i < n; i++ {
+				for j := i; j <= i+k && j < n; j++ {
+					v := rnd.NormFloat64()
+					a.SetSymBand(i, j, v)
+					g.SetSymBand(i, j, rnd.NormFloat64())
+				}
+				// Make a diagonally dominant.
+				a.SetSymBand(i, i, float64(2*k+2)+math.Abs(a.At(i, i)))
+			}
+			raw := a.RawSymBand()
+			raw.Data = append([]float64(nil), raw.Data...)
+			u, ok := lapack64.Pbtrf(raw)
+			if !ok {
+				t.Fatalf("n=%d k=%d: unexpected factorization failure", n, k)
+			}
+			got := traceInverseProduct(u, g.RawSymBand())
+
+			var inv, prod mat.Dense
+			err := inv.Inverse(a)
+			if err != nil {
+				t.Fatalf("n=%d k=%d: unexpected error: %v", n, k, err)
+			}
+			prod.Mul(&inv, g)
+			want := mat.Trace(&prod)
+			if !floats.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+				t.Errorf("n=%d k=%d: unexpected trace: got:%v want:%v", n, k, got, want)
+			}
+		}
+	}
+}
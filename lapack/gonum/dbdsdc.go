@@ -0,0 +1,492 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dbdsdc computes the singular value decomposition of a real n×n bidiagonal
+// matrix B using the divide and conquer method,
+//  B = U * S * VT,
+// where S is a diagonal matrix of singular values, and U and VT are orthogonal
+// matrices of left and right singular vectors respectively.
+//
+// uplo specifies whether B is upper or lower bidiagonal.
+//
+// d and e contain the elements of the bidiagonal matrix. On entry, d contains
+// the n diagonal elements and e contains the n-1 off-diagonal elements of B.
+// On exit, d contains the singular values of B in decreasing order and e is
+// overwritten. d must have length at least n and e must have length at least
+// n-1, and Dbdsdc will panic otherwise.
+//
+// If compq == lapack.BDCompute, U and VT are n×n matrices that on exit contain
+// the left and right singular vectors of B. If compq == lapack.BDNone, only the
+// singular values are computed and u and vt are not referenced. The compact
+// representation of the singular vectors used by the reference implementation
+// is not supported.
+//
+// work must have length at least 4*n if compq == lapack.BDNone, and
+// n*(4*n+9) if compq == lapack.BDCompute. iwork must have length at least 8*n.
+// Dbdsdc will panic if there is insufficient working memory.
+//
+// Dbdsdc returns whether the decomposition was successful.
+//
+// Dbdsdc is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dbdsdc(uplo blas.Uplo, compq lapack.BDComp, n int, d, e, u []float64, ldu int, vt []float64, ldvt int, work []float64, iwork []int) (ok bool) {
+	wantVec := compq == lapack.BDCompute
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case compq != lapack.BDNone && compq != lapack.BDCompute:
+		panic(badBDComp)
+	case n < 0:
+		panic(nLT0)
+	case ldu < 1, wantVec && ldu < n:
+		panic(badLdU)
+	case ldvt < 1, wantVec && ldvt < n:
+		panic(badLdVT)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	lwork := 4 * n
+	if wantVec {
+		lwork = n * (4*n + 9)
+	}
+	switch {
+	case len(d) < n:
+		panic(shortD)
+	case len(e) < n-1:
+		panic(shortE)
+	case wantVec && len(u) < (n-1)*ldu+n:
+		panic(shortU)
+	case wantVec && len(vt) < (n-1)*ldvt+n:
+		panic(shortVT)
+	case len(work) < lwork:
+		panic(shortWork)
+	case len(iwork) < 8*n:
+		panic(shortIWork)
+	}
+
+	if n == 1 {
+		if wantVec {
+			u[0] = math.Copysign(1, d[0])
+			vt[0] = 1
+		}
+		d[0] = math.Abs(d[0])
+		return true
+	}
+
+	if !wantVec {
+		return impl.Dbdsqr(uplo, n, 0, 0, 0, d, e, vt, 1, u, 1, nil, 1, work)
+	}
+
+	impl.Dlaset(blas.All, n, n, 0, 1, u, ldu)
+	impl.Dlaset(blas.All, n, n, 0, 1, vt, ldvt)
+
+	// Small problems are solved more efficiently by the implicit QR method.
+	smlsiz := impl.Ilaenv(9, "DBDSDC", " ", 0, 0, 0, 0)
+	if n <= smlsiz {
+		return impl.Dbdsqr(uplo, n, n, n, 0, d, e, vt, ldvt, u, ldu, nil, 1, work)
+	}
+
+	// If B is lower bidiagonal, reduce it to upper bidiagonal form by
+	// applying plane rotations from the left, and keep the rotations so
+	// that they can be applied to U.
+	cs := work[:n-1]
+	sn := work[n-1 : 2*n-2]
+	work = work[2*n-2:]
+	if uplo == blas.Lower {
+		for i := 0; i < n-1; i++ {
+			c, s, r := impl.Dlartg(d[i], e[i])
+			d[i] = r
+			e[i] = s * d[i+1]
+			d[i+1] *= c
+			cs[i] = c
+			sn[i] = -s
+		}
+	}
+
+	// Scale the matrix so that its largest element has unit magnitude.
+	orgnrm := impl.Dlanst(lapack.MaxAbs, n, d, e)
+	if orgnrm == 0 {
+		return true
+	}
+	impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n, 1, d, 1)
+	impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n-1, 1, e, 1)
+
+	// Split the matrix into unreduced blocks at negligible off-diagonal
+	// elements and solve each block independently.
+	eps := dlamchE
+	for start := 0; start < n; {
+		end := start
+		for ; end < n-1; end++ {
+			if math.Abs(e[end]) < eps {
+				e[end] = 0
+				break
+			}
+		}
+		m := end - start + 1
+		ub := u[start*ldu+start:]
+		vtb := vt[start*ldvt+start:]
+		if m > 1 {
+			ok = impl.dlasd0(m, 0, smlsiz, d[start:], e[start:], ub, ldu, vtb, ldvt, work, iwork)
+			if !ok {
+				return false
+			}
+		} else if d[start] < 0 {
+			d[start] = -d[start]
+			ub[0] = -1
+		}
+		start = end + 1
+	}
+	impl.Dlascl(lapack.General, 0, 0, 1, orgnrm, n, 1, d, 1)
+
+	// Sort the singular values into decreasing order, and permute the
+	// singular vectors correspondingly.
+	bi := blas64.Implementation()
+	for i := 0; i < n-1; i++ {
+		k := i
+		p := d[i]
+		for j := i + 1; j < n; j++ {
+			if d[j] > p {
+				k = j
+				p = d[j]
+			}
+		}
+		if k != i {
+			d[k] = d[i]
+			d[i] = p
+			bi.Dswap(n, u[i:], ldu, u[k:], ldu)
+			bi.Dswap(n, vt[i*ldvt:], 1, vt[k*ldvt:], 1)
+		}
+	}
+
+	if uplo == blas.Lower {
+		impl.Dlasr(blas.Left, lapack.Variable, lapack.Backward, n, n, cs, sn, u, ldu)
+	}
+	return true
+}
+
+// dlasd0 computes the singular value decomposition of the n×m upper bidiagonal
+// matrix B, where m = n+sqre and sqre is 0 or 1, given by the n diagonal
+// elements in d and the m-1 off-diagonal elements in e. The n×n left singular
+// vectors are placed in the n×n block of u and the right singular vectors in
+// the m×m block of vt, both of which must hold the identity on entry.
+// Matrices no larger than smlsiz are solved by dlasdq, and larger matrices are
+// split by removing a row, with the solutions of the two halves merged by
+// dlasd1. On return d holds the singular values in ascending order.
+//
+// work must have length at least 4*n*n+7*n+1 and iwork must have length at
+// least 3*n.
+func (impl Implementation) dlasd0(n, sqre, smlsiz int, d, e, u []float64, ldu int, vt []float64, ldvt int, work []float64, iwork []int) (ok bool) {
+	if n <= smlsiz {
+		return impl.dlasdq(n, sqre, d, e, u, ldu, vt, ldvt, work)
+	}
+
+	// Remove row nl of B, leaving the nl×(nl+1) upper bidiagonal block B1
+	// and the nr×(nr+sqre) upper bidiagonal block B2.
+	m := n + sqre
+	nl := n / 2
+	nr := n - nl - 1
+	alpha := d[nl]
+	var beta float64
+	if nl+1 < m {
+		beta = e[nl]
+	}
+
+	if !impl.dlasd0(nl, 1, smlsiz, d, e, u, ldu, vt, ldvt, work, iwork) {
+		return false
+	}
+	if !impl.dlasd0(nr, sqre, smlsiz, d[nl+1:], e[nl+1:], u[(nl+1)*ldu+nl+1:], ldu, vt[(nl+1)*ldvt+nl+1:], ldvt, work, iwork) {
+		return false
+	}
+	return impl.dlasd1(nl, nr, sqre, d, alpha, beta, u, ldu, vt, ldvt, work, iwork)
+}
+
+// dlasdq computes the singular value decomposition of the n×m upper bidiagonal
+// matrix B, where m = n+sqre and sqre is 0 or 1, using the implicit QR method.
+// The arguments are as for dlasd0, except that the blocks of u and vt need not
+// hold the identity on entry. work must have length at least 4*n.
+func (impl Implementation) dlasdq(n, sqre int, d, e, u []float64, ldu int, vt []float64, ldvt int, work []float64) (ok bool) {
+	m := n + sqre
+	impl.Dlaset(blas.All, n, n, 0, 1, u, ldu)
+	impl.Dlaset(blas.All, m, m, 0, 1, vt, ldvt)
+	if n == 0 {
+		return true
+	}
+
+	if sqre == 1 {
+		// Annihilate the last column of B by plane rotations from the
+		// right, chasing the fill-in up to the first row, and accumulate
+		// the transposed rotations in the rows of VT.
+		bi := blas64.Implementation()
+		f := e[n-1]
+		for i := n - 1; i >= 0; i-- {
+			c, s, r := impl.Dlartg(d[i], f)
+			d[i] = r
+			if i > 0 {
+				f = -s * e[i-1]
+				e[i-1] *= c
+			}
+			bi.Drot(m, vt[i*ldvt:], 1, vt[n*ldvt:], 1, c, s)
+		}
+	}
+
+	ok = impl.Dbdsqr(blas.Upper, n, m, n, 0, d, e, vt, ldvt, u, ldu, nil, 1, work)
+	if !ok {
+		return false
+	}
+
+	// Reverse the singular values into ascending order.
+	bi := blas64.Implementation()
+	for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+		d[i], d[j] = d[j], d[i]
+		bi.Dswap(n, u[i:], ldu, u[j:], ldu)
+		bi.Dswap(m, vt[i*ldvt:], 1, vt[j*ldvt:], 1)
+	}
+	return true
+}
+
+// dlasd1 merges the singular value decompositions of the two blocks of an
+// n×m upper bidiagonal matrix B split by dlasd0, where n = nl+nr+1 and
+// m = n+sqre. With the left and right singular vectors of the blocks held in
+// the diagonal blocks of u and vt, B has the form
+//           ( D1  0   0   0 )
+//  B = U * ( z1ᵀ a   z2ᵀ b ) * VT
+//           ( 0   0   D2  0 )
+// where (z1ᵀ a z2ᵀ b) is (alpha*e_nl + beta*e_{nl+1})ᵀ * VTᵀ and b is absent
+// if sqre == 0. On entry, the first nl and last nr elements of d hold the
+// singular values of the blocks in ascending order.
+//
+// On return d holds the singular values of B in ascending order, and u and vt
+// hold the corresponding left and right singular vectors.
+//
+// work must have length at least 4*n*n+7*n+1 and iwork must have length at
+// least 3*n.
+func (impl Implementation) dlasd1(nl, nr, sqre int, d []float64, alpha, beta float64, u []float64, ldu int, vt []float64, ldvt int, work []float64, iwork []int) (ok bool) {
+	n := nl + nr + 1
+	m := n + sqre
+
+	// Scale the problem so that its largest element has unit magnitude.
+	d[nl] = 0
+	orgnrm := math.Max(math.Abs(alpha), math.Abs(beta))
+	for _, v := range d[:n] {
+		orgnrm = math.Max(orgnrm, math.Abs(v))
+	}
+	if orgnrm == 0 {
+		return true
+	}
+	impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n, 1, d, 1)
+	alpha /= orgnrm
+	beta /= orgnrm
+
+	zv := work[:m]
+	dk := work[m : m+n]
+	zk := work[m+n : m+2*n]
+	pole := work[m+2*n : m+3*n]
+	sigma := work[m+3*n : m+4*n]
+	dd := work[m+4*n : m+5*n]
+	qu := work[m+5*n : m+5*n+n*n]
+	qv := work[m+5*n+n*n : m+5*n+n*n+n*m]
+	rest := work[m+5*n+n*n+n*m:]
+	perm := iwork[:n]
+	keep := iwork[n : 2*n]
+	defl := iwork[2*n : 3*n]
+
+	bi := blas64.Implementation()
+
+	// Form the updating row of the inner matrix from the last column of the
+	// right singular vectors of B1 and the first column of those of B2.
+	for j := 0; j <= nl; j++ {
+		zv[j] = alpha * vt[j*ldvt+nl]
+	}
+	for j := nl + 1; j < m; j++ {
+		zv[j] = beta * vt[j*ldvt+nl+1]
+	}
+
+	// The columns nl and m-1 of the inner matrix are both zero apart from
+	// the updating row, so rotate the extra column into column nl.
+	if sqre == 1 {
+		h := math.Hypot(zv[nl], zv[m-1])
+		if h != 0 {
+			bi.Drot(m, vt[nl*ldvt:], 1, vt[(m-1)*ldvt:], 1, zv[nl]/h, zv[m-1]/h)
+		}
+		zv[nl] = h
+		zv[m-1] = 0
+	}
+
+	// Merge the two sorted lists of singular values, excluding position nl
+	// which belongs to the updating row.
+	for i, j, k := 0, nl+1, 0; k < n-1; k++ {
+		if j == n || (i < nl && d[i] <= d[j]) {
+			perm[k] = i
+			i++
+		} else {
+			perm[k] = j
+			j++
+		}
+	}
+
+	// Deflate singular values whose updating component is negligible or
+	// that are close to a neighbouring singular value. keep holds the
+	// indices of the singular values to be updated, starting with the
+	// updating row, and defl holds those that are deflated.
+	var dmax float64
+	if n > 1 {
+		dmax = d[perm[n-2]]
+	}
+	tol := 8 * dlamchE * math.Max(dmax, math.Max(math.Abs(alpha), math.Abs(beta)))
+	keep[0] = nl
+	k, nd := 1, 0
+	for _, idx := range perm[:n-1] {
+		if math.Abs(zv[idx]) <= tol {
+			defl[nd] = idx
+			nd++
+			continue
+		}
+		if k > 1 {
+			p := keep[k-1]
+			if d[idx]-d[p] <= tol {
+				// Rotate the updating component of p into idx, applying
+				// the rotation to both sets of singular vectors.
+				tau := math.Hypot(zv[idx], zv[p])
+				c := zv[idx] / tau
+				s := -zv[p] / tau
+				zv[idx] = tau
+				zv[p] = 0
+				bi.Drot(n, u[p:], ldu, u[idx:], ldu, c, s)
+				bi.Drot(m, vt[p*ldvt:], 1, vt[idx*ldvt:], 1, c, s)
+				keep[k-1] = idx
+				defl[nd] = p
+				nd++
+				continue
+			}
+		}
+		keep[k] = idx
+		k++
+	}
+
+	// Gather the singular vectors to be updated followed by the deflated
+	// ones.
+	for j, idx := range keep[:k] {
+		bi.Dcopy(n, u[idx:], ldu, qu[j:], n)
+		bi.Dcopy(m, vt[idx*ldvt:], 1, qv[j*m:], 1)
+		dk[j] = d[idx]
+		zk[j] = zv[idx]
+	}
+	for j, idx := range defl[:nd] {
+		bi.Dcopy(n, u[idx:], ldu, qu[k+j:], n)
+		bi.Dcopy(m, vt[idx*ldvt:], 1, qv[(k+j)*m:], 1)
+	}
+
+	// Perturb the inner matrix so that the updating component of the first
+	// column is not tiny and the smallest remaining singular value is
+	// separated from zero.
+	dk[0] = 0
+	if math.Abs(zk[0]) <= tol {
+		zk[0] = tol
+	}
+	if k > 1 && dk[1] <= tol/2 {
+		dk[1] = tol / 2
+	}
+
+	// The squares of the singular values of the inner matrix are the
+	// eigenvalues of diag(dk)^2 + zk*zkᵀ. Row i of ua holds the differences
+	// of the squared poles and the i-th eigenvalue.
+	ua := rest[:k*k]
+	vta := rest[k*k : 2*k*k]
+	for j := 0; j < k; j++ {
+		pole[j] = dk[j] * dk[j]
+	}
+	for i := 0; i < k; i++ {
+		var lam float64
+		lam, ok = impl.Dlaed4(k, i, pole, zk, ua[i*k:(i+1)*k], 1)
+		if !ok {
+			return false
+		}
+		sigma[i] = math.Sqrt(lam)
+	}
+
+	// Recompute the updating vector from the computed singular values so
+	// that the singular vectors are numerically orthogonal (Gu and
+	// Eisenstat).
+	for i := 0; i < k; i++ {
+		prod := -ua[(k-1)*k+i]
+		for j := 0; j < i; j++ {
+			prod *= ua[j*k+i] / (pole[i] - pole[j])
+		}
+		for j := i; j < k-1; j++ {
+			prod *= ua[j*k+i] / (pole[i] - pole[j+1])
+		}
+		zv[i] = math.Copysign(math.Sqrt(math.Abs(prod)), zk[i])
+	}
+
+	// Form the singular vectors of the inner matrix. Row i of vta holds the
+	// i-th right singular vector and row i of ua the i-th left singular
+	// vector.
+	for i := 0; i < k; i++ {
+		urow := ua[i*k : (i+1)*k]
+		vrow := vta[i*k : (i+1)*k]
+		for j := range vrow {
+			vrow[j] = zv[j] / urow[j]
+			urow[j] = dk[j] * vrow[j]
+		}
+		urow[0] = -1
+		bi.Dscal(k, 1/bi.Dnrm2(k, urow, 1), urow, 1)
+		bi.Dscal(k, 1/bi.Dnrm2(k, vrow, 1), vrow, 1)
+	}
+	bi.Dgemm(blas.NoTrans, blas.Trans, n, k, k, 1, qu, n, ua, k, 0, u, ldu)
+	bi.Dgemm(blas.NoTrans, blas.NoTrans, k, m, k, 1, vta, k, qv, m, 0, vt, ldvt)
+
+	// Collect the deflated singular values with the indices of their vectors
+	// in qu and qv, and sort them into increasing order.
+	dd = dd[:nd]
+	for j, idx := range defl[:nd] {
+		dd[j] = d[idx]
+		defl[j] = k + j
+	}
+	for j := 1; j < nd; j++ {
+		v, c := dd[j], defl[j]
+		i := j - 1
+		for ; i >= 0 && dd[i] > v; i-- {
+			dd[i+1] = dd[i]
+			defl[i+1] = defl[i]
+		}
+		dd[i+1] = v
+		defl[i+1] = c
+	}
+
+	// Merge the updated and deflated singular triplets in place, working
+	// from the end of d, u and vt. The updated singular vectors only ever
+	// move to the right.
+	r, t := k-1, nd-1
+	for p := n - 1; p >= 0; p-- {
+		if t < 0 || (r >= 0 && sigma[r] > dd[t]) {
+			d[p] = sigma[r]
+			if p != r {
+				bi.Dcopy(n, u[r:], ldu, u[p:], ldu)
+				bi.Dcopy(m, vt[r*ldvt:], 1, vt[p*ldvt:], 1)
+			}
+			r--
+		} else {
+			d[p] = dd[t]
+			bi.Dcopy(n, qu[defl[t]:], n, u[p:], ldu)
+			bi.Dcopy(m, qv[defl[t]*m:], 1, vt[p*ldvt:], 1)
+			t--
+		}
+	}
+
+	impl.Dlascl(lapack.General, 0, 0, 1, orgnrm, n, 1, d, 1)
+	return true
+}
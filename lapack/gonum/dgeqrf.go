@@ -19,6 +19,8 @@ import (
 // Dgeqrf is a blocked QR factorization, but the block size is limited
 // by the temporary space available. If lwork == -1, instead of performing Dgeqrf,
 // the optimal work length will be stored into work[0].
+// For large matrices the block reflectors are applied to the trailing matrix
+// in parallel by at most impl.Workers goroutines.
 //
 // tau must have length at least min(m,n), and this function will panic otherwise.
 func (impl Implementation) Dgeqrf(m, n int, a []float64, lda int, tau, work []float64, lwork int) {
@@ -79,6 +81,11 @@ func (impl Implementation) Dgeqrf(m, n int, a []float64, lda int, tau, work []fl
 	// Compute QR using a blocked algorithm.
 	var i int
 	if nbmin <= nb && nb < k && nx < k {
+		if w := impl.workers(); w > 1 && k >= parMinBlocks*nb {
+			impl.dgeqrfTiled(m, n, a, lda, tau, work, nb, w)
+			work[0] = float64(iws)
+			return
+		}
 		ldwork := nb
 		for i = 0; i < k-nx; i += nb {
 			ib := min(k-i, nb)
@@ -0,0 +1,364 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+const noSDDO = "dgesdd: not coded for overwrite"
+
+// Dgesdd computes the singular value decomposition of the input matrix A
+// using the divide and conquer method.
+//
+// The singular value decomposition is
+//  A = U * Sigma * Vᵀ
+// where Sigma is an m×n diagonal matrix containing the singular values of A,
+// U is an m×m orthogonal matrix and V is an n×n orthogonal matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// jobz specifies the singular vectors that are computed. The behavior is as
+// follows
+//  jobz == lapack.SVDAll   All m columns of U and all n rows of Vᵀ are returned in u and vt
+//  jobz == lapack.SVDStore The first min(m,n) columns of U and rows of Vᵀ are returned in u and vt
+//  jobz == lapack.SVDNone  The singular vectors are not computed.
+// Dgesdd will panic if jobz == lapack.SVDOverwrite.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to Dgesdd
+// the data is overwritten.
+//
+// s is a slice of length at least min(m,n) and on exit contains the singular
+// values in decreasing order.
+//
+// u contains the left singular vectors on exit, stored column-wise. If
+// jobz == lapack.SVDAll, u is of size m×m. If jobz == lapack.SVDStore u is
+// of size m×min(m,n). If jobz == lapack.SVDNone, u is not used.
+//
+// vt contains the right singular vectors on exit, stored row-wise. If
+// jobz == lapack.SVDAll, vt is of size n×n. If jobz == lapack.SVDStore vt is
+// of size min(m,n)×n. If jobz == lapack.SVDNone, vt is not used.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size of
+// the slice. With mn = min(m,n) and mx = max(m,n), lwork must be at least
+//  7*mn                         if jobz == lapack.SVDNone and mx >= 11*mn/6,
+//  3*mn + max(mx, 4*mn)         if jobz == lapack.SVDNone otherwise,
+//  2*mn*mn + 4*mn + max(ncq, b) if jobz != lapack.SVDNone and mx >= 11*mn/6,
+//  3*mn + max(mx, b)            if jobz != lapack.SVDNone otherwise,
+// where b = mn*(4*mn+9), and ncq = mx if jobz == lapack.SVDAll and mn otherwise.
+// If lwork == -1, instead of performing Dgesdd, the optimal work length will be
+// stored into work[0]. Dgesdd will panic if the working memory has insufficient
+// storage.
+//
+// iwork is integer temporary storage and must have length at least 8*min(m,n).
+//
+// Dgesdd returns whether the decomposition successfully completed.
+func (impl Implementation) Dgesdd(jobz lapack.SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ok bool) {
+	if jobz == lapack.SVDOverwrite {
+		panic(noSDDO)
+	}
+	wantA := jobz == lapack.SVDAll
+	wantVec := wantA || jobz == lapack.SVDStore
+	if !wantVec && jobz != lapack.SVDNone {
+		panic(badSVDJob)
+	}
+
+	minmn := min(m, n)
+	maxmn := max(m, n)
+	mnthr := int(float64(minmn) * 11 / 6)
+	ncq := minmn
+	if wantA {
+		ncq = maxmn
+	}
+	bdspac := 4 * minmn
+	if wantVec {
+		bdspac = minmn * (4*minmn + 9)
+	}
+	minwork := 1
+	if minmn > 0 {
+		switch {
+		case !wantVec && maxmn >= mnthr:
+			minwork = 7 * minmn
+		case !wantVec:
+			minwork = 3*minmn + max(maxmn, bdspac)
+		case maxmn >= mnthr:
+			minwork = 2*minmn*minmn + 4*minmn + max(ncq, bdspac)
+		default:
+			minwork = 3*minmn + max(maxmn, bdspac)
+		}
+	}
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	case ldu < 1, wantA && ldu < m, wantVec && ldu < minmn:
+		panic(badLdU)
+	case ldvt < 1, wantVec && ldvt < n:
+		panic(badLdVT)
+	case lwork < minwork && lwork != -1:
+		panic(badLWork)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	}
+
+	// Quick return if possible.
+	if minmn == 0 {
+		work[0] = 1
+		return true
+	}
+
+	// Compute the optimal workspace size from the block sizes of the
+	// subroutines.
+	maxwrk := minwork
+	if m >= n {
+		if m >= mnthr {
+			impl.Dgeqrf(m, n, a, lda, nil, work, -1)
+			maxwrk = max(maxwrk, n+int(work[0]))
+			impl.Dgebrd(n, n, a, lda, s, nil, nil, nil, work, -1)
+			if !wantVec {
+				maxwrk = max(maxwrk, 3*n+int(work[0]))
+			} else {
+				wrkbl := int(work[0])
+				impl.Dorgqr(m, ncq, n, u, ldu, nil, work, -1)
+				wrkbl = max(wrkbl, int(work[0]))
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, n, n, n, a, lda, nil, u, ldu, work, -1)
+				wrkbl = max(wrkbl, int(work[0]))
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, n, a, lda, nil, vt, ldvt, work, -1)
+				wrkbl = max(wrkbl, int(work[0]))
+				wrkbl = max(wrkbl, m*n)
+				maxwrk = max(maxwrk, 2*n*n+4*n+wrkbl)
+			}
+		} else {
+			impl.Dgebrd(m, n, a, lda, s, nil, nil, nil, work, -1)
+			maxwrk = max(maxwrk, 3*n+int(work[0]))
+			if wantVec {
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, ncq, n, a, lda, nil, u, ldu, work, -1)
+				maxwrk = max(maxwrk, 3*n+int(work[0]))
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, n, a, lda, nil, vt, ldvt, work, -1)
+				maxwrk = max(maxwrk, 3*n+int(work[0]))
+			}
+		}
+	} else {
+		if n >= mnthr {
+			impl.Dgelqf(m, n, a, lda, nil, work, -1)
+			maxwrk = max(maxwrk, m+int(work[0]))
+			impl.Dgebrd(m, m, a, lda, s, nil, nil, nil, work, -1)
+			if !wantVec {
+				maxwrk = max(maxwrk, 3*m+int(work[0]))
+			} else {
+				wrkbl := int(work[0])
+				impl.Dorglq(ncq, n, m, vt, ldvt, nil, work, -1)
+				wrkbl = max(wrkbl, int(work[0]))
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, m, a, lda, nil, u, ldu, work, -1)
+				wrkbl = max(wrkbl, int(work[0]))
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, m, m, m, a, lda, nil, vt, ldvt, work, -1)
+				wrkbl = max(wrkbl, int(work[0]))
+				wrkbl = max(wrkbl, m*n)
+				maxwrk = max(maxwrk, 2*m*m+4*m+wrkbl)
+			}
+		} else {
+			impl.Dgebrd(m, n, a, lda, s, nil, nil, nil, work, -1)
+			maxwrk = max(maxwrk, 3*m+int(work[0]))
+			if wantVec {
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, n, a, lda, nil, u, ldu, work, -1)
+				maxwrk = max(maxwrk, 3*m+int(work[0]))
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, ncq, n, m, a, lda, nil, vt, ldvt, work, -1)
+				maxwrk = max(maxwrk, 3*m+int(work[0]))
+			}
+		}
+	}
+	if lwork == -1 {
+		work[0] = float64(maxwrk)
+		return true
+	}
+
+	switch {
+	case len(a) < (m-1)*lda+n:
+		panic(shortA)
+	case len(s) < minmn:
+		panic(shortS)
+	case wantVec && len(u) < (m-1)*ldu+ncq && m >= n:
+		panic(shortU)
+	case wantVec && len(u) < (m-1)*ldu+m && m < n:
+		panic(shortU)
+	case wantVec && len(vt) < (ncq-1)*ldvt+n && m < n:
+		panic(shortVT)
+	case wantVec && len(vt) < (n-1)*ldvt+n && m >= n:
+		panic(shortVT)
+	case len(iwork) < 8*minmn:
+		panic(shortIWork)
+	}
+
+	// Scale A if max element outside range [smlnum, bignum].
+	eps := dlamchE
+	smlnum := math.Sqrt(dlamchS) / eps
+	bignum := 1 / smlnum
+	anrm := impl.Dlange(lapack.MaxAbs, m, n, a, lda, nil)
+	var iscl bool
+	if anrm > 0 && anrm < smlnum {
+		iscl = true
+		impl.Dlascl(lapack.General, 0, 0, anrm, smlnum, m, n, a, lda)
+	} else if anrm > bignum {
+		iscl = true
+		impl.Dlascl(lapack.General, 0, 0, anrm, bignum, m, n, a, lda)
+	}
+
+	compq := lapack.BDNone
+	if wantVec {
+		compq = lapack.BDCompute
+	}
+
+	bi := blas64.Implementation()
+	if m >= n {
+		if m >= mnthr {
+			// A has sufficiently more rows than columns, so first compute
+			// the QR factorization A = Q * R and find the SVD of R.
+			if !wantVec {
+				tau := work[:n]
+				impl.Dgeqrf(m, n, a, lda, tau, work[n:], lwork-n)
+				if n > 1 {
+					impl.Dlaset(blas.Lower, n-1, n-1, 0, 0, a[lda:], lda)
+				}
+				e := work[:n]
+				tauq := work[n : 2*n]
+				taup := work[2*n : 3*n]
+				impl.Dgebrd(n, n, a, lda, s, e, tauq, taup, work[3*n:], lwork-3*n)
+				ok = impl.Dbdsdc(blas.Upper, compq, n, s, e, nil, 1, nil, 1, work[3*n:], iwork)
+			} else {
+				ldr := n
+				r := work[:n*n]
+				ub := work[n*n : 2*n*n]
+				e := work[2*n*n : 2*n*n+n]
+				tauq := work[2*n*n+n : 2*n*n+2*n]
+				taup := work[2*n*n+2*n : 2*n*n+3*n]
+				tau := work[2*n*n+3*n : 2*n*n+4*n]
+				wrk := work[2*n*n+4*n : lwork]
+
+				impl.Dgeqrf(m, n, a, lda, tau, wrk, len(wrk))
+				impl.Dlacpy(blas.Upper, n, n, a, lda, r, ldr)
+				impl.Dlaset(blas.Lower, n-1, n-1, 0, 0, r[ldr:], ldr)
+
+				// Generate the first ncq columns of Q in u.
+				impl.Dlacpy(blas.Lower, m, n, a, lda, u, ldu)
+				impl.Dorgqr(m, ncq, n, u, ldu, tau, wrk, len(wrk))
+
+				// Find the SVD of R as Ub * S * VT.
+				impl.Dgebrd(n, n, r, ldr, s, e, tauq, taup, wrk, len(wrk))
+				ok = impl.Dbdsdc(blas.Upper, compq, n, s, e, ub, n, vt, ldvt, wrk, iwork)
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, n, n, n, r, ldr, tauq, ub, n, wrk, len(wrk))
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, n, r, ldr, taup, vt, ldvt, wrk, len(wrk))
+
+				// Multiply Q by Ub, a block of rows at a time.
+				chunk := min(m, len(wrk)/n)
+				for i := 0; i < m; i += chunk {
+					rows := min(chunk, m-i)
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, rows, n, n, 1, u[i*ldu:], ldu, ub, n, 0, wrk, n)
+					impl.Dlacpy(blas.All, rows, n, wrk, n, u[i*ldu:], ldu)
+				}
+			}
+		} else {
+			// Reduce A directly to upper bidiagonal form.
+			e := work[:n]
+			tauq := work[n : 2*n]
+			taup := work[2*n : 3*n]
+			wrk := work[3*n : lwork]
+			impl.Dgebrd(m, n, a, lda, s, e, tauq, taup, wrk, len(wrk))
+			if !wantVec {
+				ok = impl.Dbdsdc(blas.Upper, compq, n, s, e, nil, 1, nil, 1, wrk, iwork)
+			} else {
+				impl.Dlaset(blas.All, m, ncq, 0, 0, u, ldu)
+				if ncq > n {
+					impl.Dlaset(blas.All, m-n, m-n, 0, 1, u[n*ldu+n:], ldu)
+				}
+				ok = impl.Dbdsdc(blas.Upper, compq, n, s, e, u, ldu, vt, ldvt, wrk, iwork)
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, ncq, n, a, lda, tauq, u, ldu, wrk, len(wrk))
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, n, a, lda, taup, vt, ldvt, wrk, len(wrk))
+			}
+		}
+	} else {
+		if n >= mnthr {
+			// A has sufficiently more columns than rows, so first compute
+			// the LQ factorization A = L * Q and find the SVD of L.
+			if !wantVec {
+				tau := work[:m]
+				impl.Dgelqf(m, n, a, lda, tau, work[m:], lwork-m)
+				impl.Dlaset(blas.Upper, m-1, m-1, 0, 0, a[1:], lda)
+				e := work[:m]
+				tauq := work[m : 2*m]
+				taup := work[2*m : 3*m]
+				impl.Dgebrd(m, m, a, lda, s, e, tauq, taup, work[3*m:], lwork-3*m)
+				ok = impl.Dbdsdc(blas.Upper, compq, m, s, e, nil, 1, nil, 1, work[3*m:], iwork)
+			} else {
+				ldl := m
+				l := work[:m*m]
+				vtb := work[m*m : 2*m*m]
+				e := work[2*m*m : 2*m*m+m]
+				tauq := work[2*m*m+m : 2*m*m+2*m]
+				taup := work[2*m*m+2*m : 2*m*m+3*m]
+				tau := work[2*m*m+3*m : 2*m*m+4*m]
+				wrk := work[2*m*m+4*m : lwork]
+
+				impl.Dgelqf(m, n, a, lda, tau, wrk, len(wrk))
+				impl.Dlacpy(blas.Lower, m, m, a, lda, l, ldl)
+				impl.Dlaset(blas.Upper, m-1, m-1, 0, 0, l[1:], ldl)
+
+				// Generate the first ncq rows of Q in vt.
+				impl.Dlacpy(blas.Upper, m, n, a, lda, vt, ldvt)
+				impl.Dorglq(ncq, n, m, vt, ldvt, tau, wrk, len(wrk))
+
+				// Find the SVD of L as U * S * VTb.
+				impl.Dgebrd(m, m, l, ldl, s, e, tauq, taup, wrk, len(wrk))
+				ok = impl.Dbdsdc(blas.Upper, compq, m, s, e, u, ldu, vtb, m, wrk, iwork)
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, m, l, ldl, tauq, u, ldu, wrk, len(wrk))
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, m, m, m, l, ldl, taup, vtb, m, wrk, len(wrk))
+
+				// Multiply VTb by Q, a block of columns at a time.
+				chunk := min(n, len(wrk)/m)
+				for j := 0; j < n; j += chunk {
+					cols := min(chunk, n-j)
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, m, cols, m, 1, vtb, m, vt[j:], ldvt, 0, wrk, cols)
+					impl.Dlacpy(blas.All, m, cols, wrk, cols, vt[j:], ldvt)
+				}
+			}
+		} else {
+			// Reduce A directly to lower bidiagonal form.
+			e := work[:m]
+			tauq := work[m : 2*m]
+			taup := work[2*m : 3*m]
+			wrk := work[3*m : lwork]
+			impl.Dgebrd(m, n, a, lda, s, e, tauq, taup, wrk, len(wrk))
+			if !wantVec {
+				ok = impl.Dbdsdc(blas.Lower, compq, m, s, e, nil, 1, nil, 1, wrk, iwork)
+			} else {
+				impl.Dlaset(blas.All, ncq, n, 0, 0, vt, ldvt)
+				if ncq > m {
+					impl.Dlaset(blas.All, n-m, n-m, 0, 1, vt[m*ldvt+m:], ldvt)
+				}
+				ok = impl.Dbdsdc(blas.Lower, compq, m, s, e, u, ldu, vt, ldvt, wrk, iwork)
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, n, a, lda, tauq, u, ldu, wrk, len(wrk))
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, ncq, n, m, a, lda, taup, vt, ldvt, wrk, len(wrk))
+			}
+		}
+	}
+
+	// Undo scaling if necessary.
+	if iscl {
+		if anrm > bignum {
+			impl.Dlascl(lapack.General, 0, 0, bignum, anrm, 1, minmn, s, minmn)
+		}
+		if anrm < smlnum {
+			impl.Dlascl(lapack.General, 0, 0, smlnum, anrm, 1, minmn, s, minmn)
+		}
+	}
+	work[0] = float64(maxwrk)
+	return ok
+}
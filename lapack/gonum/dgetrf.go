@@ -20,7 +20,9 @@ import (
 // changed with ipiv[i]. ipiv must have length at least min(m,n), and will panic
 // otherwise. ipiv is zero-indexed.
 //
-// Dgetrf is the blocked version of the algorithm.
+// Dgetrf is the blocked version of the algorithm. For large matrices the
+// update of the trailing matrix is performed in parallel by at most
+// impl.Workers goroutines.
 //
 // Dgetrf returns whether the matrix A is singular. The LU decomposition will
 // be computed regardless of the singularity of A, but division by zero
@@ -56,6 +58,9 @@ func (impl Implementation) Dgetrf(m, n int, a []float64, lda int, ipiv []int) (o
 		// Use the unblocked algorithm.
 		return impl.Dgetf2(m, n, a, lda, ipiv)
 	}
+	if w := impl.workers(); w > 1 && mn >= parMinBlocks*nb {
+		return impl.dgetrfTiled(m, n, a, lda, ipiv, nb, w)
+	}
 	ok = true
 	for j := 0; j < mn; j += nb {
 		jb := min(mn-j, nb)
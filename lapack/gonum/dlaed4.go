@@ -0,0 +1,177 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dlaed4 computes the i-th updated eigenvalue of a symmetric rank-one
+// modification to a diagonal matrix whose elements are given in d. The
+// modified matrix is
+//  D + rho * z * zᵀ
+// where D is diagonal with strictly increasing diagonal elements d and rho > 0.
+// The eigenvalue λ_i is the i-th root, in ascending order, of the secular
+// equation
+//  1 + rho * \sum_j z_j^2 / (d_j - λ) = 0.
+//
+// Dlaed4 returns the eigenvalue in dlam. On return delta[j] holds d[j] - λ_i,
+// computed so that the differences are accurate to high relative precision.
+// These are used to compute the eigenvectors of the modified matrix.
+//
+// The roots are found using the rational approximation method of Bunch,
+// Nielsen and Sorensen safeguarded by bisection. The returned ok is false if
+// the iteration failed to converge.
+//
+// d, z and delta must have length n, and 0 <= i < n. Dlaed4 will panic
+// otherwise.
+//
+// Dlaed4 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaed4(n, i int, d, z, delta []float64, rho float64) (dlam float64, ok bool) {
+	switch {
+	case n < 1:
+		panic(nLT1)
+	case i < 0 || n <= i:
+		panic(badI)
+	case rho <= 0:
+		panic(rhoLE0)
+	case len(d) < n:
+		panic(shortD)
+	case len(z) < n:
+		panic(shortZ)
+	case len(delta) < n:
+		panic(shortDelta)
+	}
+
+	if n == 1 {
+		delta[0] = -rho * z[0] * z[0]
+		return d[0] + rho*z[0]*z[0], true
+	}
+
+	eps := dlamchE
+	rhoinv := 1 / rho
+
+	// Choose the pole closest to the root as the origin, so that the
+	// root can be represented accurately as an offset tau from the origin,
+	// and find an initial bracket [lo, hi] for tau.
+	var origin int
+	var lo, hi float64
+	if i < n-1 {
+		mid := (d[i+1] - d[i]) / 2
+		var w float64
+		for j := 0; j < n; j++ {
+			w += z[j] * z[j] / ((d[j] - d[i]) - mid)
+		}
+		if rhoinv+w >= 0 {
+			// The root is in the left half of the interval.
+			origin = i
+			lo, hi = 0, mid
+		} else {
+			origin = i + 1
+			lo, hi = -mid, 0
+		}
+	} else {
+		origin = n - 1
+		var znorm2 float64
+		for j := 0; j < n; j++ {
+			znorm2 += z[j] * z[j]
+		}
+		lo, hi = 0, rho*znorm2
+	}
+	for j := 0; j < n; j++ {
+		delta[j] = d[j] - d[origin]
+	}
+	// left and right are the indices of the poles bounding the root.
+	left, right := i, i+1
+	if i == n-1 {
+		left, right = n-2, n-1
+	}
+
+	tau := (lo + hi) / 2
+	const maxIter = 100
+	for iter := 0; iter < maxIter; iter++ {
+		// Evaluate the secular function and its derivative split
+		// into the contributions from poles to the left, psi, and
+		// to the right, phi, of the root.
+		var psi, dpsi, phi, dphi float64
+		for j := 0; j <= i; j++ {
+			t := z[j] / (delta[j] - tau)
+			psi += z[j] * t
+			dpsi += t * t
+		}
+		for j := i + 1; j < n; j++ {
+			t := z[j] / (delta[j] - tau)
+			phi += z[j] * t
+			dphi += t * t
+		}
+		w := rhoinv + psi + phi
+		erretm := 8*(phi-psi) + 2*rhoinv
+		if math.Abs(w) <= eps*erretm || w == 0 {
+			ok = true
+			break
+		}
+		if w < 0 {
+			lo = tau
+		} else {
+			hi = tau
+		}
+		if hi-lo <= 2*eps*math.Max(math.Abs(lo), math.Abs(hi)) {
+			ok = true
+			break
+		}
+
+		// Approximate the secular function by
+		//  c + a/(delta[left]-t) + b/(delta[right]-t)
+		// matching its value and derivative at tau, and take the root of
+		// the approximation in the bracket as the next iterate.
+		alpha := delta[left] - tau
+		beta := delta[right] - tau
+		var c, a, b float64
+		if i < n-1 {
+			a = dpsi * alpha * alpha
+			b = dphi * beta * beta
+			c = rhoinv + (psi - dpsi*alpha) + (phi - dphi*beta)
+		} else {
+			// The root lies to the right of all poles. Keep the
+			// contribution of the last pole exact.
+			last := z[n-1] * z[n-1] / beta
+			dlast := last / beta
+			a = (dpsi - dlast) * alpha * alpha
+			b = z[n-1] * z[n-1]
+			c = rhoinv + (psi - last) - (dpsi-dlast)*alpha
+		}
+		// The quadratic c*u^2 - (c*(alpha+beta) + a + b)*u + alpha*beta*w
+		// has the step u = t - tau as a root.
+		a2 := c
+		a1 := -(c*(alpha+beta) + a + b)
+		a0 := alpha * beta * w
+		next := math.NaN()
+		if a2 == 0 {
+			if a1 != 0 {
+				next = tau - a0/a1
+			}
+		} else if disc := a1*a1 - 4*a2*a0; disc >= 0 {
+			q := -(a1 + math.Copysign(math.Sqrt(disc), a1)) / 2
+			for _, u := range []float64{a0 / q, q / a2} {
+				t := tau + u
+				if lo < t && t < hi {
+					next = t
+					break
+				}
+			}
+		}
+		if !(lo < next && next < hi) {
+			next = (lo + hi) / 2
+		}
+		if next == tau {
+			ok = true
+			break
+		}
+		tau = next
+	}
+
+	for j := 0; j < n; j++ {
+		delta[j] -= tau
+	}
+	return d[origin] + tau, ok
+}
@@ -0,0 +1,1088 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/lapack"
+)
+
+// dlarre finds, for each unreduced block of the symmetric tridiagonal matrix
+// T, a shift σ such that T - σ*I = L*D*Lᵀ is a relatively robust
+// representation, and computes approximations to the wanted eigenvalues of
+// L*D*Lᵀ to relative accuracy. It corresponds to the LAPACK routine DLARRE.
+//
+// The wanted eigenvalues are selected by rng, vl, vu, il and iu as described
+// for Dstemr. On entry, d and e hold the diagonal and off-diagonal of T and
+// e2 holds the squares of the off-diagonal elements. e must have length n.
+// On return, d and e hold the diagonal of D and the sub-diagonal of L of the
+// representation of each block, and e[isplit[j]] holds the shift of block j.
+// spltol is the splitting criterion passed to dlarra.
+//
+// On return, the first m elements of w hold the eigenvalue approximations
+// relative to the shift of their block, werr their error bounds and wgap the
+// gaps to their right neighbours. iblock and indexw hold the block of each
+// eigenvalue and its index within the block, isplit[j] is the index of the
+// last row of block j, and gers holds the Gerschgorin intervals of T.
+//
+// work must have length at least 6*n and iwork must have length at least
+// 5*n.
+//
+// dlarre returns the interval (vl,vu] that contains the wanted eigenvalues,
+// the number of blocks, the number of eigenvalues found, the minimum pivot
+// in the Sturm sequence of T and whether the computation succeeded.
+func (impl Implementation) dlarre(rng lapack.EVRange, n int, vl, vu float64, il, iu int, d, e, e2 []float64, rtol1, rtol2, spltol float64, isplit []int, w, werr, wgap []float64, iblock, indexw []int, gers, work []float64, iwork []int) (vlOut, vuOut float64, nsplit, m int, pivmin float64, ok bool) {
+	const (
+		pert      = 8.0
+		fac       = 0.5
+		maxgrowth = 64.0
+		fudge     = 2.0
+		maxtry    = 6
+	)
+
+	if n <= 0 {
+		return vl, vu, 0, 0, 0, true
+	}
+
+	safmin := dlamchS
+	eps := dlamchP
+	rtl := math.Sqrt(eps)
+	bsrtol := math.Sqrt(eps)
+
+	if n == 1 {
+		if rng == lapack.EVRangeAll || (rng == lapack.EVRangeValue && d[0] > vl && d[0] <= vu) || (rng == lapack.EVRangeIndex && il == 0 && iu == 0) {
+			m = 1
+			w[0] = d[0]
+			// The computation error of the eigenvalue is zero.
+			werr[0] = 0
+			wgap[0] = 0
+			iblock[0] = 0
+			indexw[0] = 0
+			gers[0] = d[0]
+			gers[1] = d[0]
+		}
+		// Store the shift for the initial representation, which is
+		// zero in this case.
+		e[0] = 0
+		return vl, vu, 1, m, safmin, true
+	}
+
+	// Compute the Gerschgorin intervals and spectral diameter, the
+	// maximum off-diagonal element and the minimum pivot.
+	gl := d[0]
+	gu := d[0]
+	var eold, emax float64
+	e[n-1] = 0
+	for i := 0; i < n; i++ {
+		werr[i] = 0
+		wgap[i] = 0
+		eabs := math.Abs(e[i])
+		if eabs >= emax {
+			emax = eabs
+		}
+		tmp1 := eabs + eold
+		gers[2*i] = d[i] - tmp1
+		gl = math.Min(gl, gers[2*i])
+		gers[2*i+1] = d[i] + tmp1
+		gu = math.Max(gu, gers[2*i+1])
+		eold = eabs
+	}
+	// The minimum pivot allowed in the Sturm sequence for T.
+	pivmin = safmin * math.Max(1, emax*emax)
+	// The Gerschgorin bounds give an estimate of the spectral diameter
+	// that is wrong by at most a factor of sqrt(2).
+	spdiam := gu - gl
+
+	// Compute the splitting points.
+	nsplit = impl.dlarra(n, d, e, e2, spltol, spdiam, isplit)
+
+	// The eigenvalues are computed by dqds if all are wanted, and
+	// otherwise dlarrd is used to find crude approximations to the wanted
+	// eigenvalues. In the case of an index range, this also finds the
+	// interval (vl,vu] that contains them.
+	usedqd := rng == lapack.EVRangeAll
+	var mm int
+	if rng == lapack.EVRangeAll {
+		vl = gl
+		vu = gu
+	} else {
+		mm, vl, vu, ok = impl.dlarrd(rng, n, vl, vu, il, iu, gers, bsrtol, d, e, e2, pivmin, nsplit, isplit, w, werr, iblock, indexw, work, iwork)
+		if !ok {
+			return vl, vu, nsplit, 0, pivmin, false
+		}
+		// Make sure that the entries mm through n-1 do not refer to
+		// any block.
+		for i := mm; i < n; i++ {
+			w[i] = 0
+			werr[i] = 0
+			iblock[i] = -1
+			indexw[i] = -1
+		}
+	}
+
+	var ibegin, wbegin int
+	for jblk := 0; jblk < nsplit; jblk++ {
+		iend := isplit[jblk]
+		in := iend - ibegin + 1
+
+		if in == 1 {
+			// The block is 1×1.
+			if rng == lapack.EVRangeAll || (rng == lapack.EVRangeValue && d[ibegin] > vl && d[ibegin] <= vu) || (rng == lapack.EVRangeIndex && wbegin < n && iblock[wbegin] == jblk) {
+				w[m] = d[ibegin]
+				werr[m] = 0
+				// The gap for a single block does not matter for
+				// the later algorithm.
+				wgap[m] = 0
+				iblock[m] = jblk
+				indexw[m] = 0
+				m++
+				wbegin++
+			}
+			// e[iend] holds the shift for the initial representation.
+			e[iend] = 0
+			ibegin = iend + 1
+			continue
+		}
+
+		// e[iend] will hold the shift for the initial representation.
+		e[iend] = 0
+
+		// Find the local outer bounds for the block.
+		gl = d[ibegin]
+		gu = d[ibegin]
+		for i := ibegin; i <= iend; i++ {
+			gl = math.Min(gers[2*i], gl)
+			gu = math.Max(gers[2*i+1], gu)
+		}
+		spdiam = gu - gl
+
+		// indl and indu are the local indices of the first and last
+		// wanted eigenvalues of the block, and mb is their number. The
+		// wanted eigenvalues are stored in w[wbegin:wend].
+		var indl, indu, mb, wend int
+		if rng != lapack.EVRangeAll {
+			for i := wbegin; i < mm && iblock[i] == jblk; i++ {
+				mb++
+			}
+			if mb == 0 {
+				// No wanted eigenvalue lies in the block.
+				e[iend] = 0
+				ibegin = iend + 1
+				continue
+			}
+			// Decide whether dqds or bisection is more efficient.
+			usedqd = float64(mb) > fac*float64(in)
+			wend = wbegin + mb
+			// Calculate the gaps for the block.
+			for i := wbegin; i < wend-1; i++ {
+				wgap[i] = math.Max(0, w[i+1]-werr[i+1]-(w[i]+werr[i]))
+			}
+			wgap[wend-1] = math.Max(0, vu-(w[wend-1]+werr[wend-1]))
+			indl = indexw[wbegin]
+			indu = indexw[wend-1]
+		}
+
+		var isleft, isrght float64
+		if rng == lapack.EVRangeAll || usedqd {
+			// Find approximations to the extremal eigenvalues of the
+			// block.
+			tmp, tmp1, ok := impl.dlarrk(in, 0, gl, gu, d[ibegin:], e2[ibegin:], pivmin, rtl)
+			if !ok {
+				return vl, vu, nsplit, m, pivmin, false
+			}
+			isleft = math.Max(gl, tmp-tmp1-100*eps*math.Abs(tmp-tmp1))
+			tmp, tmp1, ok = impl.dlarrk(in, in-1, gl, gu, d[ibegin:], e2[ibegin:], pivmin, rtl)
+			if !ok {
+				return vl, vu, nsplit, m, pivmin, false
+			}
+			isrght = math.Min(gu, tmp+tmp1+100*eps*math.Abs(tmp+tmp1))
+			// Improve the estimate of the spectral diameter.
+			spdiam = isrght - isleft
+		} else {
+			// Find approximations to the wanted extremal eigenvalues.
+			isleft = math.Max(gl, w[wbegin]-werr[wbegin]-100*eps*math.Abs(w[wbegin]-werr[wbegin]))
+			isrght = math.Min(gu, w[wend-1]+werr[wend-1]+100*eps*math.Abs(w[wend-1]+werr[wend-1]))
+		}
+
+		// Decide whether the base representation for the block should be
+		// at the left or the right end of the block by shifting to the
+		// end that is more populated.
+		var s1, s2 float64
+		if rng == lapack.EVRangeAll {
+			usedqd = true
+			indl = 0
+			indu = in - 1
+			mb = in
+			wend = wbegin + mb
+			s1 = isleft + spdiam/4
+			s2 = isrght - spdiam/4
+		} else {
+			if usedqd {
+				s1 = isleft + spdiam/4
+				s2 = isrght - spdiam/4
+			} else {
+				tmp := math.Min(isrght, vu) - math.Max(isleft, vl)
+				s1 = math.Max(isleft, vl) + tmp/4
+				s2 = math.Min(isrght, vu) - tmp/4
+			}
+		}
+
+		// Compute the negcount at the 1/4 and 3/4 points.
+		var cnt1, cnt2 int
+		if mb > 1 {
+			_, cnt1, cnt2 = impl.dlarrc(true, in, s1, s2, d[ibegin:], e[ibegin:], pivmin)
+		}
+
+		var sigma, sgndef float64
+		switch {
+		case mb == 1:
+			sigma = gl
+			sgndef = 1
+		case cnt1-(indl+1) >= (indu+1)-cnt2:
+			switch {
+			case rng == lapack.EVRangeAll:
+				sigma = math.Max(isleft, gl)
+			case usedqd:
+				// Use the Gerschgorin bound as the shift to get a
+				// positive definite matrix for dqds.
+				sigma = isleft
+			default:
+				// Use the approximation of the first wanted
+				// eigenvalue of the block as the shift.
+				sigma = math.Max(isleft, vl)
+			}
+			sgndef = 1
+		default:
+			switch {
+			case rng == lapack.EVRangeAll:
+				sigma = math.Min(isrght, gu)
+			case usedqd:
+				// Use the Gerschgorin bound as the shift to get a
+				// negative definite matrix for dqds.
+				sigma = isrght
+			default:
+				// Use the approximation of the last wanted
+				// eigenvalue of the block as the shift.
+				sigma = math.Min(isrght, vu)
+			}
+			sgndef = -1
+		}
+
+		// Define the increment τ of the shift in case the initial shift
+		// needs to be refined to obtain a factorization with not too
+		// much element growth.
+		var tau float64
+		if usedqd {
+			// The initial shift is at the outer end of the spectrum,
+			// so the matrix is definite and there is no need to
+			// retreat.
+			tau = spdiam*eps*float64(n) + 2*pivmin
+			tau = math.Max(tau, 2*eps*math.Abs(sigma))
+		} else {
+			if mb > 1 {
+				clwdth := w[wend-1] + werr[wend-1] - w[wbegin] - werr[wbegin]
+				avgap := math.Abs(clwdth / float64(wend-1-wbegin))
+				if sgndef == 1 {
+					tau = math.Max(wgap[wbegin], avgap) / 2
+					tau = math.Max(tau, werr[wbegin])
+				} else {
+					tau = math.Max(wgap[wend-2], avgap) / 2
+					tau = math.Max(tau, werr[wend-1])
+				}
+			} else {
+				tau = werr[wbegin]
+			}
+		}
+
+		// Compute the L*D*Lᵀ factorization of T - σ*I, storing D in
+		// work[:in], L in work[in:2*in] and the reciprocals of the
+		// pivots in work[2*in:3*in].
+		var found bool
+		for idum := 0; idum < maxtry; idum++ {
+			dpivot := d[ibegin] - sigma
+			work[0] = dpivot
+			dmax := math.Abs(work[0])
+			j := ibegin
+			for i := 0; i < in-1; i++ {
+				work[2*in+i] = 1 / work[i]
+				tmp := e[j] * work[2*in+i]
+				work[in+i] = tmp
+				dpivot = (d[j+1] - sigma) - tmp*e[j]
+				work[i+1] = dpivot
+				dmax = math.Max(dmax, math.Abs(dpivot))
+				j++
+			}
+			// Check for element growth and, if dqds is used, that
+			// all entries of D have the same sign.
+			norep := dmax > maxgrowth*spdiam
+			if usedqd && !norep {
+				for i := 0; i < in; i++ {
+					if sgndef*work[i] < 0 {
+						norep = true
+					}
+				}
+			}
+			if !norep {
+				found = true
+				break
+			}
+			// In the case of all eigenvalues the Gerschgorin shift
+			// makes the matrix definite, so this point is only
+			// reached for value or index ranges.
+			if idum == maxtry-2 {
+				// The fudged Gerschgorin shift should succeed.
+				if sgndef == 1 {
+					sigma = gl - fudge*spdiam*eps*float64(n) - fudge*2*pivmin
+				} else {
+					sigma = gu + fudge*spdiam*eps*float64(n) + fudge*2*pivmin
+				}
+			} else {
+				sigma -= sgndef * tau
+				tau *= 2
+			}
+		}
+		if !found {
+			// No base representation could be found in maxtry
+			// iterations.
+			return vl, vu, nsplit, m, pivmin, false
+		}
+
+		// A base representation T - σ*I = L*D*Lᵀ with not too much
+		// element growth has been found. Store the shift, D and L.
+		e[iend] = sigma
+		copy(d[ibegin:iend+1], work[:in])
+		copy(e[ibegin:iend], work[in:2*in-1])
+
+		if mb > 1 {
+			// Perturb each entry of the base representation by a
+			// small multiple of its own magnitude to ensure that
+			// the representation is relatively robust. The
+			// perturbations are drawn from a fixed pseudo-random
+			// sequence so that the results are reproducible.
+			seed := uint64(1)
+			for i := 0; i < 2*in-1; i++ {
+				seed = seed*6364136223846793005 + 1442695040888963407
+				work[i] = 2*float64(seed>>11)/(1<<53) - 1
+			}
+			for i := 0; i < in-1; i++ {
+				d[ibegin+i] *= 1 + eps*pert*work[i]
+				e[ibegin+i] *= 1 + eps*pert*work[in+i]
+			}
+			d[iend] *= 1 + eps*4*work[in-1]
+		}
+
+		// The Gerschgorin intervals are not updated because keeping
+		// track of the updates would be too much work in dlarrv. w is
+		// updated instead and used to locate the intervals.
+
+		// Compute the wanted eigenvalues of L*D*Lᵀ by bisection or
+		// dqds.
+		if !usedqd {
+			// Shift the approximations from dlarrd according to the
+			// representation, since dqds computes eigenvalues of the
+			// shifted representation and dlarrv expects them.
+			for j := wbegin; j < wend; j++ {
+				w[j] -= sigma
+				werr[j] += math.Abs(w[j]) * eps
+			}
+			// Reduce the error of the approximations by bisection.
+			for i := ibegin; i < iend; i++ {
+				work[i] = d[i] * e[i] * e[i]
+			}
+			impl.dlarrb(in, d[ibegin:], work[ibegin:], indl, indu, rtol1, rtol2, indl, w[wbegin:], wgap[wbegin:], werr[wbegin:], work[2*n:], iwork, pivmin, spdiam, in-1)
+			// dlarrb computes all gaps correctly except for the last
+			// one. Record the distance to vu.
+			wgap[wend-1] = math.Max(0, (vu-sigma)-(w[wend-1]+werr[wend-1]))
+			for i := indl; i <= indu; i++ {
+				iblock[m] = jblk
+				indexw[m] = i
+				m++
+			}
+		} else {
+			// Compute all eigenvalues with dqds and discard the
+			// unwanted ones. dqds finds the eigenvalues of the
+			// representation to high relative accuracy, which may be
+			// lost when the shift is subtracted. However, T does not
+			// in general define its eigenvalues to high relative
+			// accuracy anyway. rtol is of the order of the tolerance
+			// used in dqds, and is used in dlarrv to declare
+			// eigenvalues converged.
+			rtol := math.Log(float64(in)) * 4 * eps
+			j := ibegin
+			for i := 0; i < in-1; i++ {
+				work[2*i] = math.Abs(d[j])
+				work[2*i+1] = e[j] * e[j] * work[2*i]
+				j++
+			}
+			work[2*in-2] = math.Abs(d[iend])
+			work[2*in-1] = 0
+			if impl.Dlasq2(in, work) != 0 {
+				return vl, vu, nsplit, m, pivmin, false
+			}
+			// Test that all eigenvalues are positive as expected.
+			for i := 0; i < in; i++ {
+				if work[i] < 0 {
+					return vl, vu, nsplit, m, pivmin, false
+				}
+			}
+			// The eigenvalues from dqds are in decreasing order.
+			for i := indl; i <= indu; i++ {
+				if sgndef > 0 {
+					w[m] = work[in-1-i]
+				} else {
+					w[m] = -work[i]
+				}
+				iblock[m] = jblk
+				indexw[m] = i
+				m++
+			}
+			for i := m - mb; i < m; i++ {
+				werr[i] = rtol * math.Abs(w[i])
+			}
+			for i := m - mb; i < m-1; i++ {
+				// Compute the right gap between the intervals.
+				wgap[i] = math.Max(0, w[i+1]-werr[i+1]-(w[i]+werr[i]))
+			}
+			wgap[m-1] = math.Max(0, (vu-sigma)-(w[m-1]+werr[m-1]))
+		}
+		ibegin = iend + 1
+		wbegin = wend
+	}
+	return vl, vu, nsplit, m, pivmin, true
+}
+
+// dlarra computes the splitting points of the symmetric tridiagonal matrix
+// T with the splitting criterion spltol. If spltol is negative, an
+// off-diagonal element is set to zero if it is at most |spltol|*tnrm, and
+// otherwise if it is at most spltol*sqrt(|d[i]|)*sqrt(|d[i+1]|), which
+// guarantees relative accuracy. The zeroed elements are also zeroed in e2.
+//
+// On return, isplit[j] holds the index of the last row of block j. dlarra
+// returns the number of blocks.
+func (Implementation) dlarra(n int, d, e, e2 []float64, spltol, tnrm float64, isplit []int) (nsplit int) {
+	if spltol < 0 {
+		// Criterion based on the absolute off-diagonal value.
+		tmp1 := math.Abs(spltol) * tnrm
+		for i := 0; i < n-1; i++ {
+			if math.Abs(e[i]) <= tmp1 {
+				e[i] = 0
+				e2[i] = 0
+				isplit[nsplit] = i
+				nsplit++
+			}
+		}
+	} else {
+		// Criterion that guarantees relative accuracy.
+		for i := 0; i < n-1; i++ {
+			if math.Abs(e[i]) <= spltol*math.Sqrt(math.Abs(d[i]))*math.Sqrt(math.Abs(d[i+1])) {
+				e[i] = 0
+				e2[i] = 0
+				isplit[nsplit] = i
+				nsplit++
+			}
+		}
+	}
+	isplit[nsplit] = n - 1
+	return nsplit + 1
+}
+
+// dlarrk computes the eigenvalue with index iw of the symmetric tridiagonal
+// matrix T to relative accuracy reltol by bisection, starting from the
+// Gerschgorin interval [gl,gu]. e2 holds the squares of the off-diagonal
+// elements of T.
+//
+// dlarrk returns the eigenvalue approximation, its error bound and whether
+// the interval converged.
+func (Implementation) dlarrk(n, iw int, gl, gu float64, d, e2 []float64, pivmin, reltol float64) (w, werr float64, ok bool) {
+	if n <= 0 {
+		return 0, 0, true
+	}
+
+	const fudge = 2
+
+	eps := dlamchP
+	tnorm := math.Max(math.Abs(gl), math.Abs(gu))
+	rtoli := reltol
+	atoli := fudge * 2 * pivmin
+	itmax := int((math.Log(tnorm+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+
+	left := gl - fudge*tnorm*eps*float64(n) - fudge*2*pivmin
+	right := gu + fudge*tnorm*eps*float64(n) + fudge*2*pivmin
+
+	for it := 0; ; it++ {
+		// Check if the interval converged or the maximum number of
+		// iterations is reached.
+		tmp1 := math.Abs(right - left)
+		tmp2 := math.Max(math.Abs(right), math.Abs(left))
+		if tmp1 < math.Max(atoli, math.Max(pivmin, rtoli*tmp2)) {
+			ok = true
+			break
+		}
+		if it > itmax {
+			break
+		}
+
+		// Count the number of negative pivots for the midpoint.
+		mid := (left + right) / 2
+		var negcnt int
+		tmp1 = d[0] - mid
+		if math.Abs(tmp1) < pivmin {
+			tmp1 = -pivmin
+		}
+		if tmp1 <= 0 {
+			negcnt++
+		}
+		for i := 1; i < n; i++ {
+			tmp1 = d[i] - e2[i-1]/tmp1 - mid
+			if math.Abs(tmp1) < pivmin {
+				tmp1 = -pivmin
+			}
+			if tmp1 <= 0 {
+				negcnt++
+			}
+		}
+		if negcnt > iw {
+			right = mid
+		} else {
+			left = mid
+		}
+	}
+	return (left + right) / 2, math.Abs(right-left) / 2, ok
+}
+
+// dlarrc computes the number of eigenvalues of the symmetric tridiagonal
+// matrix in the interval (vl,vu]. If matt is true, the matrix is T given by
+// its diagonal d and off-diagonal e, and otherwise it is L*D*Lᵀ given by the
+// diagonal of D in d and the sub-diagonal of L in e.
+//
+// dlarrc returns the number of eigenvalues in (vl,vu] and the number of
+// eigenvalues less than or equal to vl and vu.
+func (Implementation) dlarrc(matt bool, n int, vl, vu float64, d, e []float64, pivmin float64) (eigcnt, lcnt, rcnt int) {
+	if n <= 0 {
+		return 0, 0, 0
+	}
+
+	if matt {
+		// Sturm sequence count on T.
+		lpivot := d[0] - vl
+		rpivot := d[0] - vu
+		if lpivot <= 0 {
+			lcnt++
+		}
+		if rpivot <= 0 {
+			rcnt++
+		}
+		for i := 0; i < n-1; i++ {
+			tmp := e[i] * e[i]
+			lpivot = (d[i+1] - vl) - tmp/lpivot
+			rpivot = (d[i+1] - vu) - tmp/rpivot
+			if lpivot <= 0 {
+				lcnt++
+			}
+			if rpivot <= 0 {
+				rcnt++
+			}
+		}
+	} else {
+		// Sturm sequence count on L*D*Lᵀ.
+		sl := -vl
+		su := -vu
+		for i := 0; i < n-1; i++ {
+			lpivot := d[i] + sl
+			rpivot := d[i] + su
+			if lpivot <= 0 {
+				lcnt++
+			}
+			if rpivot <= 0 {
+				rcnt++
+			}
+			tmp := e[i] * d[i] * e[i]
+			tmp2 := tmp / lpivot
+			if tmp2 == 0 {
+				sl = tmp - vl
+			} else {
+				sl = sl*tmp2 - vl
+			}
+			tmp2 = tmp / rpivot
+			if tmp2 == 0 {
+				su = tmp - vu
+			} else {
+				su = su*tmp2 - vu
+			}
+		}
+		lpivot := d[n-1] + sl
+		rpivot := d[n-1] + su
+		if lpivot <= 0 {
+			lcnt++
+		}
+		if rpivot <= 0 {
+			rcnt++
+		}
+	}
+	return rcnt - lcnt, lcnt, rcnt
+}
+
+// dlarrd computes approximations to the eigenvalues of the symmetric
+// tridiagonal matrix T selected by rng, vl, vu, il and iu by bisection to
+// relative accuracy reltol. It corresponds to the LAPACK routine DLARRD with
+// the eigenvalues ordered by block.
+//
+// gers holds the Gerschgorin intervals of T, d and e hold its diagonal and
+// off-diagonal, and e2 the squares of the off-diagonal elements. T is split
+// into nsplit blocks where isplit[j] is the index of the last row of block
+// j.
+//
+// On return, the first m elements of w hold the eigenvalue approximations,
+// werr their error bounds, and iblock and indexw the block of each
+// eigenvalue and its index within the block. work must have length at least
+// 4*n and iwork must have length at least 3*n.
+//
+// dlarrd returns the number of eigenvalues found, the interval (wl,wu] that
+// contains them and whether all intervals converged and the expected number
+// of eigenvalues was found.
+func (impl Implementation) dlarrd(rng lapack.EVRange, n int, vl, vu float64, il, iu int, gers []float64, reltol float64, d, e, e2 []float64, pivmin float64, nsplit int, isplit []int, w, werr []float64, iblock, indexw []int, work []float64, iwork []int) (m int, wl, wu float64, ok bool) {
+	const fudge = 2
+
+	if n <= 0 {
+		return 0, vl, vu, true
+	}
+
+	if rng == lapack.EVRangeIndex && il == 0 && iu == n-1 {
+		rng = lapack.EVRangeAll
+	}
+
+	eps := dlamchP
+	uflow := dlamchS
+
+	if n == 1 {
+		if rng == lapack.EVRangeAll || (rng == lapack.EVRangeValue && d[0] > vl && d[0] <= vu) || (rng == lapack.EVRangeIndex && il == 0 && iu == 0) {
+			m = 1
+			w[0] = d[0]
+			// The computation error of the eigenvalue is zero.
+			werr[0] = 0
+			iblock[0] = 0
+			indexw[0] = 0
+		}
+		return m, vl, vu, true
+	}
+
+	// Find the global spectral radius and the global Gerschgorin bounds.
+	gl := d[0]
+	gu := d[0]
+	for i := 0; i < n; i++ {
+		gl = math.Min(gl, gers[2*i])
+		gu = math.Max(gu, gers[2*i+1])
+	}
+	tnorm := math.Max(math.Abs(gl), math.Abs(gu))
+	gl = gl - fudge*tnorm*eps*float64(n) - fudge*2*pivmin
+	gu = gu + fudge*tnorm*eps*float64(n) + fudge*2*pivmin
+
+	// An interval (a,b] has converged if b-a < reltol*max(|a|,|b|). The
+	// absolute tolerance is set close to zero to force convergence based
+	// on the relative size of the interval, while a small number allows
+	// relatively accurate eigenvalues of strongly graded matrices.
+	rtoli := reltol
+	atoli := fudge*2*uflow + fudge*2*pivmin
+
+	var wlu, wul float64
+	switch rng {
+	case lapack.EVRangeIndex:
+		// Compute an interval containing eigenvalues il through iu by
+		// refining the global Gerschgorin interval.
+		itmax := int((math.Log(tnorm+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+		ab := work[n : n+4]
+		c := work[n+4 : n+6]
+		nab := iwork[:4]
+		nval := iwork[4:6]
+		ab[0], ab[1], ab[2], ab[3] = gl, gl, gu, gu
+		c[0], c[1] = gl, gu
+		nab[0], nab[1], nab[2], nab[3] = -1, -1, n+1, n+1
+		nval[0], nval[1] = il, iu+1
+		_, info := impl.dlaebz(3, itmax, n, 2, 2, atoli, rtoli, pivmin, d, e2, nval, ab, c, nab)
+		if info != 0 {
+			return 0, vl, vu, false
+		}
+		// The output intervals may not be ordered by ascending
+		// negcount. On exit, [wl,wlu] contains a value with negcount
+		// nwl, and [wul,wu] contains a value with negcount nwu.
+		var nwl, nwu int
+		if nval[1] == iu+1 {
+			wl, wlu, nwl = ab[0], ab[2], nab[0]
+			wu, wul, nwu = ab[3], ab[1], nab[3]
+		} else {
+			wl, wlu, nwl = ab[1], ab[3], nab[1]
+			wu, wul, nwu = ab[2], ab[0], nab[2]
+		}
+		if nwl < 0 || nwl >= n || nwu < 1 || nwu > n {
+			return 0, wl, wu, false
+		}
+	case lapack.EVRangeValue:
+		wl = vl
+		wu = vu
+	case lapack.EVRangeAll:
+		wl = gl
+		wu = gu
+	}
+
+	// Find the eigenvalues of each block and recompute nwl and nwu, the
+	// accumulated numbers of eigenvalues less than or equal to wl and wu.
+	var ncnvrg bool
+	var nwl, nwu int
+	var ibegin int
+	for jblk := 0; jblk < nsplit; jblk++ {
+		iend := isplit[jblk]
+		in := iend - ibegin + 1
+
+		if in == 1 {
+			// The block is 1×1.
+			if wl >= d[ibegin]-pivmin {
+				nwl++
+			}
+			if wu >= d[ibegin]-pivmin {
+				nwu++
+			}
+			if rng == lapack.EVRangeAll || (wl < d[ibegin]-pivmin && wu >= d[ibegin]-pivmin) {
+				w[m] = d[ibegin]
+				werr[m] = 0
+				iblock[m] = jblk
+				indexw[m] = 0
+				m++
+			}
+			ibegin = iend + 1
+			continue
+		}
+
+		// Compute the local Gerschgorin interval and use it as the
+		// initial interval for dlaebz.
+		gu = d[ibegin]
+		gl = d[ibegin]
+		for j := ibegin; j <= iend; j++ {
+			gl = math.Min(gl, gers[2*j])
+			gu = math.Max(gu, gers[2*j+1])
+		}
+		gl = gl - fudge*tnorm*eps*float64(in) - fudge*pivmin
+		gu = gu + fudge*tnorm*eps*float64(in) + fudge*pivmin
+
+		if rng != lapack.EVRangeAll {
+			if gu < wl {
+				// The block contains none of the wanted
+				// eigenvalues.
+				nwl += in
+				nwu += in
+				ibegin = iend + 1
+				continue
+			}
+			// Refine the search interval if possible, since only
+			// the range (wl,wu] matters.
+			gl = math.Max(gl, wl)
+			gu = math.Min(gu, wu)
+			if gl >= gu {
+				ibegin = iend + 1
+				continue
+			}
+		}
+
+		// Find the negcounts of the initial interval boundaries.
+		ab := work[n : n+2*in]
+		c := work[n+2*in : n+3*in]
+		nab := iwork[:2*in]
+		ab[0] = gl
+		ab[in] = gu
+		im, _ := impl.dlaebz(1, 0, in, in, 1, atoli, rtoli, pivmin, d[ibegin:], e2[ibegin:], nil, ab, c, nab)
+		nwl += nab[0]
+		nwu += nab[in]
+		iwoff := m - nab[0]
+
+		// Compute the eigenvalues.
+		itmax := int((math.Log(gu-gl+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+		iout, info := impl.dlaebz(2, itmax, in, in, 1, atoli, rtoli, pivmin, d[ibegin:], e2[ibegin:], nil, ab, c, nab)
+		if info > in {
+			return m, wl, wu, false
+		}
+
+		// Copy the eigenvalues into w and iblock, using the midpoints of
+		// the intervals and their semi-widths as error bounds.
+		for j := 0; j < iout; j++ {
+			tmp1 := (ab[j] + ab[in+j]) / 2
+			tmp2 := math.Abs(ab[j]-ab[in+j]) / 2
+			if j >= iout-info {
+				// Flag non-convergence.
+				ncnvrg = true
+			}
+			for je := nab[j] + iwoff; je < nab[in+j]+iwoff; je++ {
+				w[je] = tmp1
+				werr[je] = tmp2
+				indexw[je] = je - iwoff
+				iblock[je] = jblk
+			}
+		}
+		m += im
+		ibegin = iend + 1
+	}
+
+	// If rng is an index range, (wl,wu] contains the eigenvalues with
+	// indices nwl through nwu-1. Discard the extra eigenvalues if nwl < il
+	// or nwu > iu+1.
+	toofew := false
+	if rng == lapack.EVRangeIndex {
+		idiscl := il - nwl
+		idiscu := nwu - (iu + 1)
+
+		if idiscl > 0 {
+			// Remove some of the smallest eigenvalues from the left.
+			var im int
+			for je := 0; je < m; je++ {
+				if w[je] <= wlu && idiscl > 0 {
+					idiscl--
+				} else {
+					w[im] = w[je]
+					werr[im] = werr[je]
+					indexw[im] = indexw[je]
+					iblock[im] = iblock[je]
+					im++
+				}
+			}
+			m = im
+		}
+		if idiscu > 0 {
+			// Remove some of the largest eigenvalues from the right
+			// and move the others to the left.
+			im := m
+			for je := m - 1; je >= 0; je-- {
+				if w[je] >= wul && idiscu > 0 {
+					idiscu--
+				} else {
+					im--
+					w[im] = w[je]
+					werr[im] = werr[je]
+					indexw[im] = indexw[je]
+					iblock[im] = iblock[je]
+				}
+			}
+			jee := 0
+			for je := im; je < m; je++ {
+				w[jee] = w[je]
+				werr[jee] = werr[je]
+				indexw[jee] = indexw[je]
+				iblock[jee] = iblock[je]
+				jee++
+			}
+			m -= im
+		}
+
+		if idiscl > 0 || idiscu > 0 {
+			// Some low eigenvalues to be discarded are not in
+			// (wl,wlu], or high eigenvalues to be discarded are not
+			// in (wul,wu], so just remove the smallest idiscl or
+			// largest idiscu eigenvalues by marking their block as
+			// -1.
+			if idiscl > 0 {
+				wkill := wu
+				for jdisc := 0; jdisc < idiscl; jdisc++ {
+					iw := -1
+					for je := 0; je < m; je++ {
+						if iblock[je] != -1 && (w[je] < wkill || iw == -1) {
+							iw = je
+							wkill = w[je]
+						}
+					}
+					iblock[iw] = -1
+				}
+			}
+			if idiscu > 0 {
+				wkill := wl
+				for jdisc := 0; jdisc < idiscu; jdisc++ {
+					iw := -1
+					for je := 0; je < m; je++ {
+						if iblock[je] != -1 && (w[je] >= wkill || iw == -1) {
+							iw = je
+							wkill = w[je]
+						}
+					}
+					iblock[iw] = -1
+				}
+			}
+			// Remove all marked eigenvalues.
+			var im int
+			for je := 0; je < m; je++ {
+				if iblock[je] != -1 {
+					w[im] = w[je]
+					werr[im] = werr[je]
+					indexw[im] = indexw[je]
+					iblock[im] = iblock[je]
+					im++
+				}
+			}
+			m = im
+		}
+		if idiscl < 0 || idiscu < 0 {
+			toofew = true
+		}
+	}
+
+	if (rng == lapack.EVRangeAll && m != n) || (rng == lapack.EVRangeIndex && m != iu-il+1) {
+		toofew = true
+	}
+
+	return m, wl, wu, !ncnvrg && !toofew
+}
+
+// dlaebz contains the iteration loops which compute and use the function
+// N(w), the number of eigenvalues of the symmetric tridiagonal matrix T less
+// than or equal to its argument w, for bisection. It corresponds to the
+// serial version of the LAPACK routine DLAEBZ. d holds the diagonal of T and
+// e2 the squares of its off-diagonal elements.
+//
+// The intervals are stored in ab, where interval j is [ab[j], ab[mmax+j]],
+// and nab holds N(w) at their ends in the same layout. The first minp
+// intervals are used on entry. The task is given by ijob:
+//  ijob == 1: compute nab for the initial intervals and return the total
+//             number of eigenvalues in them.
+//  ijob == 2: bisect the intervals until each contains a single eigenvalue
+//             or has converged, adding new intervals when both halves
+//             contain eigenvalues. c must hold the trial points on entry.
+//  ijob == 3: bisect the intervals to find points w with N(w) = nval[j],
+//             using the trial points in c on entry.
+// An interval has converged when its width is less than
+// max(abstol, pivmin, reltol*max(|a|,|b|)).
+//
+// dlaebz returns the number of intervals and the number of intervals that
+// have not converged after nitmax iterations. The returned info is greater
+// than mmax if more than mmax intervals would be needed.
+func (Implementation) dlaebz(ijob, nitmax, n, mmax, minp int, abstol, reltol, pivmin float64, d, e2 []float64, nval []int, ab, c []float64, nab []int) (mout, info int) {
+	if ijob == 1 {
+		// Compute the number of eigenvalues in the initial intervals.
+		for ji := 0; ji < minp; ji++ {
+			for jp := 0; jp < 2; jp++ {
+				k := jp*mmax + ji
+				tmp1 := d[0] - ab[k]
+				if math.Abs(tmp1) < pivmin {
+					tmp1 = -pivmin
+				}
+				nab[k] = 0
+				if tmp1 <= 0 {
+					nab[k] = 1
+				}
+				for j := 1; j < n; j++ {
+					tmp1 = d[j] - e2[j-1]/tmp1 - ab[k]
+					if math.Abs(tmp1) < pivmin {
+						tmp1 = -pivmin
+					}
+					if tmp1 <= 0 {
+						nab[k]++
+					}
+				}
+			}
+			mout += nab[mmax+ji] - nab[ji]
+		}
+		return mout, 0
+	}
+
+	// Intervals 0 through kf-1 have converged and intervals kf through
+	// kl-1 still need to be refined.
+	kf := 0
+	kl := minp
+
+	if ijob == 2 {
+		for ji := 0; ji < minp; ji++ {
+			c[ji] = (ab[ji] + ab[mmax+ji]) / 2
+		}
+	}
+
+	for jit := 0; jit < nitmax; jit++ {
+		klnew := kl
+		for ji := kf; ji < kl; ji++ {
+			// Compute N(c), the number of eigenvalues less than c.
+			tmp1 := c[ji]
+			tmp2 := d[0] - tmp1
+			var itmp1 int
+			if tmp2 <= pivmin {
+				itmp1 = 1
+				tmp2 = math.Min(tmp2, -pivmin)
+			}
+			for j := 1; j < n; j++ {
+				tmp2 = d[j] - e2[j-1]/tmp2 - tmp1
+				if tmp2 <= pivmin {
+					itmp1++
+					tmp2 = math.Min(tmp2, -pivmin)
+				}
+			}
+
+			if ijob == 2 {
+				// Choose all intervals containing eigenvalues,
+				// ensuring that N(w) is monotone.
+				itmp1 = min(nab[mmax+ji], max(nab[ji], itmp1))
+				switch {
+				case itmp1 == nab[mmax+ji]:
+					// No eigenvalue in the upper interval, so
+					// use the lower interval.
+					ab[mmax+ji] = tmp1
+				case itmp1 == nab[ji]:
+					// No eigenvalue in the lower interval, so
+					// use the upper interval.
+					ab[ji] = tmp1
+				case klnew < mmax:
+					// Eigenvalues in both intervals, so add the
+					// upper one to the queue.
+					ab[mmax+klnew] = ab[mmax+ji]
+					nab[mmax+klnew] = nab[mmax+ji]
+					ab[klnew] = tmp1
+					nab[klnew] = itmp1
+					klnew++
+					ab[mmax+ji] = tmp1
+					nab[mmax+ji] = itmp1
+				default:
+					return kl, mmax + 1
+				}
+			} else {
+				// Binary search keeping only the interval
+				// containing w such that N(w) = nval.
+				if itmp1 <= nval[ji] {
+					ab[ji] = tmp1
+					nab[ji] = itmp1
+				}
+				if itmp1 >= nval[ji] {
+					ab[mmax+ji] = tmp1
+					nab[mmax+ji] = itmp1
+				}
+			}
+		}
+		kl = klnew
+
+		// Check for convergence.
+		kfnew := kf
+		for ji := kf; ji < kl; ji++ {
+			tmp1 := math.Abs(ab[mmax+ji] - ab[ji])
+			tmp2 := math.Max(math.Abs(ab[mmax+ji]), math.Abs(ab[ji]))
+			if tmp1 < math.Max(abstol, math.Max(pivmin, reltol*tmp2)) || nab[ji] >= nab[mmax+ji] {
+				// The interval has converged. Swap it with
+				// interval kfnew and increment kfnew.
+				if ji > kfnew {
+					ab[ji], ab[kfnew] = ab[kfnew], ab[ji]
+					ab[mmax+ji], ab[mmax+kfnew] = ab[mmax+kfnew], ab[mmax+ji]
+					nab[ji], nab[kfnew] = nab[kfnew], nab[ji]
+					nab[mmax+ji], nab[mmax+kfnew] = nab[mmax+kfnew], nab[mmax+ji]
+					if ijob == 3 {
+						nval[ji], nval[kfnew] = nval[kfnew], nval[ji]
+					}
+				}
+				kfnew++
+			}
+		}
+		kf = kfnew
+
+		// Choose the midpoints.
+		for ji := kf; ji < kl; ji++ {
+			c[ji] = (ab[ji] + ab[mmax+ji]) / 2
+		}
+
+		// Quit if there are no more intervals to refine.
+		if kf >= kl {
+			break
+		}
+	}
+	return kl, max(kl-kf, 0)
+}
@@ -0,0 +1,1061 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// dlarrv computes the eigenvectors of the tridiagonal matrix
+// T = L*D*Lᵀ + σ*I given the L*D*Lᵀ representations of its unreduced blocks
+// and the eigenvalue approximations computed by dlarre. It corresponds to
+// the LAPACK routine DLARRV with all eigenvectors computed.
+//
+// On entry, d and l hold the diagonal of D and the sub-diagonal of the unit
+// bidiagonal L of the root representation of each block, and l[isplit[j]]
+// holds the shift σ of block j. isplit[j] is the index of the last row of
+// block j. d and l are overwritten.
+//
+// w, werr and wgap hold the approximations of the m wanted eigenvalues
+// relative to the shift of their block, their error bounds and the gaps
+// to their right neighbours. iblock and indexw hold the block of each
+// eigenvalue and its index within the block. On return, w holds the refined
+// eigenvalues of T, werr and wgap are updated, and the first m columns of z
+// hold the eigenvectors with their supports in isuppz.
+//
+// gers holds the Gerschgorin intervals of T, vl is a lower bound for the
+// wanted eigenvalues, and minrgp is the relative gap below which eigenvalues
+// are treated as a cluster. work must have length at least 12*n and iwork
+// must have length at least 7*n.
+//
+// dlarrv returns whether the computation succeeded.
+func (impl Implementation) dlarrv(n int, vl float64, d, l []float64, pivmin float64, isplit []int, m int, minrgp, rtol1, rtol2 float64, w, werr, wgap []float64, iblock, indexw []int, gers, z []float64, ldz int, isuppz []int, work []float64, iwork []int) (ok bool) {
+	if n <= 0 || m <= 0 {
+		return true
+	}
+
+	const maxitr = 10
+
+	// work[:n] holds the eigenvalue approximations of the current
+	// representations, ld and lld hold D*L and D*L*L of the current
+	// representation.
+	lambdas := work[:n]
+	ld := work[n : 2*n]
+	lld := work[2*n : 3*n]
+	wrk := work[3*n : 12*n]
+	for i := range work[:12*n] {
+		work[i] = 0
+	}
+	// twist holds the twist indices of the factorizations used for the
+	// eigenvectors, and clusters holds the first and last indices of the
+	// clusters of two consecutive levels of the representation tree.
+	twist := iwork[:n]
+	clusters := iwork[n : 3*n]
+	iwrk := iwork[3*n : 7*n]
+	for i := range twist {
+		twist[i] = -1
+	}
+	for i := range iwork[n : 7*n] {
+		iwork[n+i] = 0
+	}
+
+	impl.Dlaset(blas.All, n, m, 0, 0, z, ldz)
+
+	eps := dlamchP
+	rqtol := 2 * eps
+	bi := blas64.Implementation()
+
+	var ibegin, wbegin int
+	for jblk := 0; jblk <= iblock[m-1]; jblk++ {
+		iend := isplit[jblk]
+		sigma := l[iend]
+		// Find the eigenvalues wbegin through wend-1 of the current
+		// block.
+		wend := wbegin
+		for wend < m && iblock[wend] == jblk {
+			wend++
+		}
+		if wend == wbegin {
+			ibegin = iend + 1
+			continue
+		}
+
+		// Find the local spectral diameter of the block.
+		gl := gers[2*ibegin]
+		gu := gers[2*ibegin+1]
+		for i := ibegin + 1; i <= iend; i++ {
+			gl = math.Min(gers[2*i], gl)
+			gu = math.Max(gers[2*i+1], gu)
+		}
+		spdiam := gu - gl
+
+		in := iend - ibegin + 1
+		im := wend - wbegin
+
+		if in == 1 {
+			// The block is 1×1.
+			z[ibegin*ldz+wbegin] = 1
+			isuppz[2*wbegin] = ibegin
+			isuppz[2*wbegin+1] = ibegin
+			w[wbegin] += sigma
+			lambdas[wbegin] = w[wbegin]
+			ibegin = iend + 1
+			wbegin++
+			continue
+		}
+
+		// The eigenvalues of the current representation are kept in
+		// lambdas and w holds the approximations with respect to T.
+		copy(lambdas[wbegin:wend], w[wbegin:wend])
+		for i := wbegin; i < wend; i++ {
+			w[i] += sigma
+		}
+
+		// Generate the representation tree for the current block
+		// breadth first and compute the eigenvectors. Clusters are
+		// given by their first and last indices relative to wbegin.
+		var ndepth, parity int
+		parity = 1
+		nclus := 1
+		clusters[0] = 0
+		clusters[1] = im - 1
+		var idone int
+		for idone < im {
+			// This is a crude protection against infinitely deep
+			// trees.
+			if ndepth > m {
+				return false
+			}
+			oldncl := nclus
+			nclus = 0
+			parity = 1 - parity
+			oldcls, newcls := clusters[:n], clusters[n:]
+			if parity != 0 {
+				oldcls, newcls = newcls, oldcls
+			}
+			for i := 0; i < oldncl; i++ {
+				oldfst := oldcls[2*i]
+				oldlst := oldcls[2*i+1]
+				if ndepth > 0 {
+					// Retrieve the representation of the cluster
+					// computed at the previous level and stored
+					// in z, and clear its columns.
+					j := wbegin + oldfst
+					bi.Dcopy(in, z[ibegin*ldz+j:], ldz, d[ibegin:], 1)
+					bi.Dcopy(in-1, z[ibegin*ldz+j+1:], ldz, l[ibegin:], 1)
+					sigma = z[iend*ldz+j+1]
+					impl.Dlaset(blas.All, in, 2, 0, 0, z[ibegin*ldz+j:], ldz)
+				}
+
+				// Compute D*L and D*L*L of the current
+				// representation.
+				for j := ibegin; j < iend; j++ {
+					tmp := d[j] * l[j]
+					ld[j] = tmp
+					lld[j] = tmp * l[j]
+				}
+
+				if ndepth > 0 {
+					// Refine the eigenvalues of the cluster by
+					// bisection to the precision needed.
+					p := indexw[wbegin+oldfst]
+					q := indexw[wbegin+oldlst]
+					offset := indexw[wbegin]
+					impl.dlarrb(in, d[ibegin:], lld[ibegin:], p, q, rtol1, rtol2, offset, lambdas[wbegin:], wgap[wbegin:], werr[wbegin:], wrk, iwrk, pivmin, spdiam, in-1)
+					// Recompute the gaps at the ends of the cluster
+					// from w, which holds the eigenvalues of T.
+					// The gaps are only allowed to grow.
+					if oldfst > 0 {
+						k := wbegin + oldfst
+						wgap[k-1] = math.Max(wgap[k-1], w[k]-werr[k]-w[k-1]-werr[k-1])
+					}
+					if wbegin+oldlst < wend-1 {
+						k := wbegin + oldlst
+						wgap[k] = math.Max(wgap[k], w[k+1]-werr[k+1]-w[k]-werr[k])
+					}
+					for j := oldfst; j <= oldlst; j++ {
+						w[wbegin+j] = lambdas[wbegin+j] + sigma
+					}
+				}
+
+				// Process the children of the current node.
+				newfst := oldfst
+				for j := oldfst; j <= oldlst; j++ {
+					if j != oldlst && wgap[wbegin+j] < minrgp*math.Abs(lambdas[wbegin+j]) {
+						// The relative gap to the right is too
+						// small, so j is inside a child cluster.
+						continue
+					}
+					newlst := j
+					// newftt is the column of z where the new
+					// representation or the eigenvector is
+					// stored.
+					newftt := wbegin + newfst
+					if newlst > newfst {
+						// The child is a cluster. Compute and
+						// store its representation.
+						var lgap float64
+						if newfst == 0 {
+							lgap = math.Max(0, w[wbegin]-werr[wbegin]-vl)
+						} else {
+							lgap = wgap[wbegin+newfst-1]
+						}
+						rgap := wgap[wbegin+newlst]
+
+						// Compute the extremal eigenvalues of
+						// the child to high precision so that
+						// the shift is as close as possible.
+						offset := indexw[wbegin]
+						p := indexw[wbegin+newfst]
+						impl.dlarrb(in, d[ibegin:], lld[ibegin:], p, p, rqtol, rqtol, offset, lambdas[wbegin:], wgap[wbegin:], werr[wbegin:], wrk, iwrk, pivmin, spdiam, in-1)
+						p = indexw[wbegin+newlst]
+						impl.dlarrb(in, d[ibegin:], lld[ibegin:], p, p, rqtol, rqtol, offset, lambdas[wbegin:], wgap[wbegin:], werr[wbegin:], wrk, iwrk, pivmin, spdiam, in-1)
+
+						dplus := wrk[2*n : 3*n]
+						lplus := wrk[3*n : 4*n]
+						tau, ok := impl.dlarrf(in, d[ibegin:], l[ibegin:], ld[ibegin:], newfst, newlst, lambdas[wbegin:], wgap[wbegin:], werr[wbegin:], spdiam, lgap, rgap, pivmin, dplus, lplus, wrk)
+						if !ok {
+							return false
+						}
+						// Store the new representation and its
+						// shift in z.
+						bi.Dcopy(in, dplus, 1, z[ibegin*ldz+newftt:], ldz)
+						bi.Dcopy(in-1, lplus, 1, z[ibegin*ldz+newftt+1:], ldz)
+						z[iend*ldz+newftt+1] = sigma + tau
+						// Shift the eigenvalues of the child and
+						// fudge their errors. The gaps are not
+						// fudged, since a zero gap indicates that
+						// a new representation is needed.
+						for k := newfst; k <= newlst; k++ {
+							fudge := 3 * eps * math.Abs(lambdas[wbegin+k])
+							lambdas[wbegin+k] -= tau
+							fudge += 4 * eps * math.Abs(lambdas[wbegin+k])
+							werr[wbegin+k] += fudge
+						}
+						newcls[2*nclus] = newfst
+						newcls[2*nclus+1] = newlst
+						nclus++
+					} else {
+						// The child is a singleton. Compute its
+						// eigenvector.
+						k := newfst
+						windex := wbegin + k
+						windmn := max(windex-1, 0)
+						windpl := min(windex+1, m-1)
+						lambda := lambdas[windex]
+						left := lambdas[windex] - werr[windex]
+						right := lambdas[windex] + werr[windex]
+						indeig := indexw[windex]
+						// All eigenvalues of the child are relative
+						// to the same shift, so lambdas is used for
+						// the gaps. Small gaps are forced at the
+						// ends of the block to prevent premature
+						// convergence of the Rayleigh quotient
+						// iteration.
+						lgap := eps * math.Max(math.Abs(left), math.Abs(right))
+						if k > 0 {
+							lgap = wgap[windmn]
+						}
+						rgap := eps * math.Max(math.Abs(left), math.Abs(right))
+						if k < im-1 {
+							rgap = wgap[windex]
+						}
+						gap := math.Min(lgap, rgap)
+						// The support of the eigenvectors at the
+						// ends of the block can be cut off wrongly
+						// by a large gaptol in dlar1v.
+						var gaptol float64
+						if k > 0 && k < im-1 {
+							gaptol = gap * eps
+						}
+						// wgap temporarily holds the minimum gap to
+						// either side so that bisection refines the
+						// eigenvalue to the required precision.
+						savgap := wgap[windex]
+						wgap[windex] = gap
+
+						// The Rayleigh quotient correction is used
+						// as often as possible since it converges
+						// quadratically, but it can have the wrong
+						// sign and lead away from the eigenvalue. In
+						// this case bisection is used instead.
+						tol := 4 * math.Log(float64(in)) * eps
+						zv := wrk[4*n : 4*n+in]
+						var (
+							usedbs, usedrq, needbs bool
+							bstres, bstw           float64
+							nrminv                 float64
+							iter                   int
+						)
+						for {
+							if needbs {
+								usedbs = true
+								offset := indexw[wbegin]
+								impl.dlarrb(in, d[ibegin:], lld[ibegin:], indeig, indeig, 0, 2*eps, offset, lambdas[wbegin:], wgap[wbegin:], werr[wbegin:], wrk, iwrk, pivmin, spdiam, twist[windex])
+								lambda = lambdas[windex]
+								// Reset the twist index computed
+								// from the inaccurate lambda.
+								twist[windex] = -1
+							}
+							var negcnt int
+							var resid, rqcorr float64
+							negcnt, twist[windex], nrminv, resid, rqcorr = impl.dlar1v(in, 0, in-1, lambda, d[ibegin:], l[ibegin:], ld[ibegin:], lld[ibegin:], pivmin, gaptol, zv, !usedbs, twist[windex], isuppz[2*windex:], wrk[:4*n])
+							if iter == 0 || resid < bstres {
+								bstres = resid
+								bstw = lambda
+							}
+							iter++
+
+							if resid <= tol*gap || math.Abs(rqcorr) <= rqtol*math.Abs(lambda) || usedbs {
+								if usedrq && usedbs && bstres <= resid {
+									// Improve the error angle by a
+									// second step.
+									lambda = bstw
+									_, twist[windex], nrminv, _, _ = impl.dlar1v(in, 0, in-1, lambda, d[ibegin:], l[ibegin:], ld[ibegin:], lld[ibegin:], pivmin, gaptol, zv, !usedbs, twist[windex], isuppz[2*windex:], wrk[:4*n])
+								}
+								lambdas[windex] = lambda
+								break
+							}
+
+							// Check that the correction does not move
+							// the eigenvalue away from the wanted one
+							// towards a neighbour.
+							sgndef := 1.0
+							if indeig < negcnt {
+								// The wanted eigenvalue lies to the
+								// left.
+								sgndef = -1
+							}
+							if rqcorr*sgndef >= 0 && lambda+rqcorr <= right && lambda+rqcorr >= left {
+								usedrq = true
+								if sgndef == 1 {
+									left = lambda
+								} else {
+									right = lambda
+								}
+								lambdas[windex] = (right + left) / 2
+								lambda += rqcorr
+								werr[windex] = (right - left) / 2
+							} else {
+								needbs = true
+							}
+							switch {
+							case right-left < rqtol*math.Abs(lambda):
+								// The eigenvalue is computed to
+								// bisection accuracy.
+								usedbs = true
+							case iter < maxitr:
+							case iter == maxitr:
+								needbs = true
+							default:
+								return false
+							}
+						}
+
+						// Store the eigenvector with its support
+						// relative to the whole matrix.
+						zfrom := isuppz[2*windex]
+						zto := isuppz[2*windex+1]
+						for ii := zfrom; ii <= zto; ii++ {
+							z[(ibegin+ii)*ldz+windex] = nrminv * zv[ii]
+						}
+						isuppz[2*windex] += ibegin
+						isuppz[2*windex+1] += ibegin
+
+						// Update w and recompute the gaps to both
+						// sides, only allowing them to grow.
+						w[windex] = lambda + sigma
+						if k > 0 {
+							wgap[windmn] = math.Max(wgap[windmn], w[windex]-werr[windex]-w[windmn]-werr[windmn])
+						}
+						if windex < wend-1 {
+							wgap[windex] = math.Max(savgap, w[windpl]-werr[windpl]-w[windex]-werr[windex])
+						}
+						idone++
+					}
+					newfst = j + 1
+				}
+			}
+			ndepth++
+		}
+		ibegin = iend + 1
+		wbegin = wend
+	}
+	return true
+}
+
+// dlarrf finds a new relatively robust representation
+// L+*D+*L+ᵀ = L*D*Lᵀ - τ*I for the cluster of eigenvalues clstrt through
+// clend of L*D*Lᵀ such that at least one of the eigenvalues of L+*D+*L+ᵀ
+// is relatively isolated. w, wgap and werr hold the eigenvalue
+// approximations of L*D*Lᵀ, their gaps and error bounds, and clgapl and
+// clgapr are the gaps to the left and right of the cluster. ld holds D*L.
+//
+// dplus and lplus must have length at least n and n-1, and work must have
+// length at least 2*n. dlarrf returns the shift τ and whether a
+// representation was found.
+func (impl Implementation) dlarrf(n int, d, l, ld []float64, clstrt, clend int, w, wgap, werr []float64, spdiam, clgapl, clgapr, pivmin float64, dplus, lplus, work []float64) (sigma float64, ok bool) {
+	if n <= 0 {
+		return 0, true
+	}
+
+	const (
+		quart      = 0.25
+		maxgrowth1 = 8.0
+		maxgrowth2 = 8.0
+		ktrymax    = 1
+		sleft      = 1
+		sright     = 2
+	)
+
+	fact := float64(int(1) << ktrymax)
+	eps := dlamchP
+	var shift int
+	var forcer bool
+
+	// Compute the average gap length of the cluster.
+	clwdth := math.Abs(w[clend]-w[clstrt]) + werr[clend] + werr[clstrt]
+	avgap := clwdth / float64(clend-clstrt)
+	mingap := math.Min(clgapl, clgapr)
+	// Initial values for the shifts to both ends of the cluster, using a
+	// small fudge to make sure that they are really outside.
+	lsigma := math.Min(w[clstrt], w[clend]) - werr[clstrt]
+	rsigma := math.Max(w[clstrt], w[clend]) + werr[clend]
+	lsigma -= math.Abs(lsigma) * 4 * eps
+	rsigma += math.Abs(rsigma) * 4 * eps
+
+	// Compute upper bounds for how much to back off the initial shifts.
+	ldmax := quart*mingap + 2*pivmin
+	rdmax := quart*mingap + 2*pivmin
+
+	ldelta := math.Max(avgap, wgap[clstrt]) / fact
+	rdelta := math.Max(avgap, wgap[clend-1]) / fact
+
+	// Initialize the record of the best representation found.
+	smlgrowth := 1 / dlamchS
+	fail := float64(n-1) * mingap / (spdiam * eps)
+	fail2 := float64(n-1) * mingap / (spdiam * math.Sqrt(eps))
+	bestshift := lsigma
+
+	ktry := 0
+	growthbound := maxgrowth1 * spdiam
+	for {
+		var sawnan1, sawnan2 bool
+		// Ensure that the initial shifts are not backed off too much.
+		ldelta = math.Min(ldmax, ldelta)
+		rdelta = math.Min(rdmax, rdelta)
+
+		// Compute the element growth when shifting to both ends of the
+		// cluster and accept the shift if there is no element growth at
+		// one of the two ends. The factorization at the left end is
+		// stored in dplus and lplus, and that at the right end in work.
+		s := -lsigma
+		dplus[0] = d[0] + s
+		if math.Abs(dplus[0]) < pivmin {
+			dplus[0] = -pivmin
+			// The refined RRR test must not be used in this case.
+			sawnan1 = true
+		}
+		max1 := math.Abs(dplus[0])
+		for i := 0; i < n-1; i++ {
+			lplus[i] = ld[i] / dplus[i]
+			s = s*lplus[i]*l[i] - lsigma
+			dplus[i+1] = d[i+1] + s
+			if math.Abs(dplus[i+1]) < pivmin {
+				dplus[i+1] = -pivmin
+				sawnan1 = true
+			}
+			max1 = math.Max(max1, math.Abs(dplus[i+1]))
+		}
+		sawnan1 = sawnan1 || math.IsNaN(max1)
+		if forcer || (max1 <= growthbound && !sawnan1) {
+			sigma = lsigma
+			shift = sleft
+			break
+		}
+
+		s = -rsigma
+		work[0] = d[0] + s
+		if math.Abs(work[0]) < pivmin {
+			work[0] = -pivmin
+			sawnan2 = true
+		}
+		max2 := math.Abs(work[0])
+		for i := 0; i < n-1; i++ {
+			work[n+i] = ld[i] / work[i]
+			s = s*work[n+i]*l[i] - rsigma
+			work[i+1] = d[i+1] + s
+			if math.Abs(work[i+1]) < pivmin {
+				work[i+1] = -pivmin
+				sawnan2 = true
+			}
+			max2 = math.Max(max2, math.Abs(work[i+1]))
+		}
+		sawnan2 = sawnan2 || math.IsNaN(max2)
+		if forcer || (max2 <= growthbound && !sawnan2) {
+			sigma = rsigma
+			shift = sright
+			break
+		}
+
+		// Both shifts led to too much element growth. Record the
+		// better of the two if it did not lead to NaN.
+		if !sawnan1 || !sawnan2 {
+			var indx int
+			if !sawnan1 {
+				indx = 1
+				if max1 <= smlgrowth {
+					smlgrowth = max1
+					bestshift = lsigma
+				}
+			}
+			if !sawnan2 {
+				if sawnan1 || max2 <= max1 {
+					indx = 2
+				}
+				if max2 <= smlgrowth {
+					smlgrowth = max2
+					bestshift = rsigma
+				}
+			}
+
+			// If the element growth is moderate, the representation
+			// may still be accepted if it passes a refined test for
+			// relative robustness. The test is only used for isolated
+			// clusters and supposes that no NaN occurred.
+			if clwdth < mingap/128 && math.Min(max1, max2) < fail2 && !sawnan1 && !sawnan2 {
+				if indx == 1 {
+					tmp := math.Abs(dplus[n-1])
+					znm2 := 1.0
+					prod := 1.0
+					oldp := 1.0
+					for i := n - 2; i >= 0; i-- {
+						if prod <= eps {
+							prod = ((dplus[i+1] * work[n+i+1]) / (dplus[i] * work[n+i])) * oldp
+						} else {
+							prod *= math.Abs(work[n+i])
+						}
+						oldp = prod
+						znm2 += prod * prod
+						tmp = math.Max(tmp, math.Abs(dplus[i]*prod))
+					}
+					rrr1 := tmp / (spdiam * math.Sqrt(znm2))
+					if rrr1 <= maxgrowth2 {
+						sigma = lsigma
+						shift = sleft
+						break
+					}
+				} else {
+					tmp := math.Abs(work[n-1])
+					znm2 := 1.0
+					prod := 1.0
+					oldp := 1.0
+					for i := n - 2; i >= 0; i-- {
+						if prod <= eps {
+							prod = ((work[i+1] * lplus[i+1]) / (work[i] * lplus[i])) * oldp
+						} else {
+							prod *= math.Abs(lplus[i])
+						}
+						oldp = prod
+						znm2 += prod * prod
+						tmp = math.Max(tmp, math.Abs(work[i]*prod))
+					}
+					rrr2 := tmp / (spdiam * math.Sqrt(znm2))
+					if rrr2 <= maxgrowth2 {
+						sigma = rsigma
+						shift = sright
+						break
+					}
+				}
+			}
+		}
+
+		if ktry < ktrymax {
+			// Both shifts failed the tests. Back off to the outside.
+			lsigma = math.Max(lsigma-ldelta, lsigma-ldmax)
+			rsigma = math.Min(rsigma+rdelta, rsigma+rdmax)
+			ldelta *= 2
+			rdelta *= 2
+			ktry++
+			continue
+		}
+		// None of the representations satisfied the criteria. Take
+		// the best one found.
+		if smlgrowth >= fail {
+			return 0, false
+		}
+		lsigma = bestshift
+		rsigma = bestshift
+		forcer = true
+	}
+
+	if shift == sright {
+		// Store the representation at the right end into dplus and
+		// lplus.
+		copy(dplus[:n], work[:n])
+		copy(lplus[:n-1], work[n:2*n-1])
+	}
+	return sigma, true
+}
+
+// dlar1v computes the (scaled) r-th column of the inverse of the submatrix
+// in rows b1 through bn of the tridiagonal matrix L*D*Lᵀ - λ*I. When λ is
+// close to an eigenvalue, the computed vector is an accurate eigenvector.
+// ld and lld hold D*L and D*L*L.
+//
+// If r is negative, the twist index is chosen as the index in b1 through bn
+// where the diagonal of the inverse is largest in magnitude, and otherwise
+// r is used. gaptol is the tolerance that indicates when the eigenvector
+// entries are negligible with respect to their contribution to the
+// residual.
+//
+// On return, z holds the eigenvector scaled so that z[r] is one, and
+// isuppz[0] and isuppz[1] hold the first and last indices of its support.
+// work must have length at least 4*n.
+//
+// dlar1v returns the number of negative pivots encountered in the
+// factorizations if wantnc is true and -1 otherwise, the twist index, the
+// reciprocal of the norm of z, the residual of the eigenpair and the
+// Rayleigh quotient correction to λ.
+func (impl Implementation) dlar1v(n, b1, bn int, lambda float64, d, l, ld, lld []float64, pivmin, gaptol float64, z []float64, wantnc bool, r int, isuppz []int, work []float64) (negcnt, twist int, nrminv, resid, rqcorr float64) {
+	eps := dlamchP
+
+	r1, r2 := b1, bn
+	if r >= 0 {
+		r1, r2 = r, r
+	}
+
+	// lplus and uminus hold the factors of the stationary and progressive
+	// transforms, and s and p their auxiliary variables.
+	lplus := work[:n]
+	uminus := work[n : 2*n]
+	s := work[2*n : 3*n]
+	p := work[3*n : 4*n]
+
+	if b1 == 0 {
+		s[0] = 0
+	} else {
+		s[b1] = lld[b1-1]
+	}
+
+	// Compute the stationary transform using the differential form until
+	// the index r2.
+	var neg1 int
+	sv := s[b1] - lambda
+	for i := b1; i < r1; i++ {
+		dplus := d[i] + sv
+		lplus[i] = ld[i] / dplus
+		if dplus < 0 {
+			neg1++
+		}
+		s[i+1] = sv * lplus[i] * l[i]
+		sv = s[i+1] - lambda
+	}
+	sawnan1 := math.IsNaN(sv)
+	if !sawnan1 {
+		for i := r1; i < r2; i++ {
+			dplus := d[i] + sv
+			lplus[i] = ld[i] / dplus
+			s[i+1] = sv * lplus[i] * l[i]
+			sv = s[i+1] - lambda
+		}
+		sawnan1 = math.IsNaN(sv)
+	}
+	if sawnan1 {
+		// Run a slower version of the above loops if a NaN is
+		// detected.
+		neg1 = 0
+		sv = s[b1] - lambda
+		for i := b1; i < r1; i++ {
+			dplus := d[i] + sv
+			if math.Abs(dplus) < pivmin {
+				dplus = -pivmin
+			}
+			lplus[i] = ld[i] / dplus
+			if dplus < 0 {
+				neg1++
+			}
+			s[i+1] = sv * lplus[i] * l[i]
+			if lplus[i] == 0 {
+				s[i+1] = lld[i]
+			}
+			sv = s[i+1] - lambda
+		}
+		for i := r1; i < r2; i++ {
+			dplus := d[i] + sv
+			if math.Abs(dplus) < pivmin {
+				dplus = -pivmin
+			}
+			lplus[i] = ld[i] / dplus
+			s[i+1] = sv * lplus[i] * l[i]
+			if lplus[i] == 0 {
+				s[i+1] = lld[i]
+			}
+			sv = s[i+1] - lambda
+		}
+	}
+
+	// Compute the progressive transform using the differential form until
+	// the index r1.
+	var neg2 int
+	p[bn] = d[bn] - lambda
+	for i := bn - 1; i >= r1; i-- {
+		dminus := lld[i] + p[i+1]
+		tmp := d[i] / dminus
+		if dminus < 0 {
+			neg2++
+		}
+		uminus[i] = l[i] * tmp
+		p[i] = p[i+1]*tmp - lambda
+	}
+	sawnan2 := math.IsNaN(p[r1])
+	if sawnan2 {
+		// Run a slower version of the above loop if a NaN is detected.
+		neg2 = 0
+		for i := bn - 1; i >= r1; i-- {
+			dminus := lld[i] + p[i+1]
+			if math.Abs(dminus) < pivmin {
+				dminus = -pivmin
+			}
+			tmp := d[i] / dminus
+			if dminus < 0 {
+				neg2++
+			}
+			uminus[i] = l[i] * tmp
+			p[i] = p[i+1]*tmp - lambda
+			if tmp == 0 {
+				p[i] = d[i] - lambda
+			}
+		}
+	}
+
+	// Find the index from r1 to r2 of the largest in magnitude diagonal
+	// element of the inverse.
+	mingma := s[r1] + p[r1]
+	if mingma < 0 {
+		neg1++
+	}
+	negcnt = -1
+	if wantnc {
+		negcnt = neg1 + neg2
+	}
+	if mingma == 0 {
+		mingma = eps * s[r1]
+	}
+	twist = r1
+	for i := r1; i < r2; i++ {
+		tmp := s[i+1] + p[i+1]
+		if tmp == 0 {
+			tmp = eps * s[i+1]
+		}
+		if math.Abs(tmp) <= math.Abs(mingma) {
+			mingma = tmp
+			twist = i + 1
+		}
+	}
+
+	// Compute the FP vector by solving Nᵀ*v = e_r, first upwards and then
+	// downwards from the twist index.
+	isuppz[0] = b1
+	isuppz[1] = bn
+	z[twist] = 1
+	ztz := 1.0
+	if !sawnan1 && !sawnan2 {
+		for i := twist - 1; i >= b1; i-- {
+			z[i] = -(lplus[i] * z[i+1])
+			if (math.Abs(z[i])+math.Abs(z[i+1]))*math.Abs(ld[i]) < gaptol {
+				z[i] = 0
+				isuppz[0] = i + 1
+				break
+			}
+			ztz += z[i] * z[i]
+		}
+		for i := twist; i < bn; i++ {
+			z[i+1] = -(uminus[i] * z[i])
+			if (math.Abs(z[i])+math.Abs(z[i+1]))*math.Abs(ld[i]) < gaptol {
+				z[i+1] = 0
+				isuppz[1] = i
+				break
+			}
+			ztz += z[i+1] * z[i+1]
+		}
+	} else {
+		// Run slower loops if a NaN occurred.
+		for i := twist - 1; i >= b1; i-- {
+			if z[i+1] == 0 {
+				z[i] = -(ld[i+1] / ld[i]) * z[i+2]
+			} else {
+				z[i] = -(lplus[i] * z[i+1])
+			}
+			if (math.Abs(z[i])+math.Abs(z[i+1]))*math.Abs(ld[i]) < gaptol {
+				z[i] = 0
+				isuppz[0] = i + 1
+				break
+			}
+			ztz += z[i] * z[i]
+		}
+		for i := twist; i < bn; i++ {
+			if z[i] == 0 {
+				z[i+1] = -(ld[i-1] / ld[i]) * z[i-1]
+			} else {
+				z[i+1] = -(uminus[i] * z[i])
+			}
+			if (math.Abs(z[i])+math.Abs(z[i+1]))*math.Abs(ld[i]) < gaptol {
+				z[i+1] = 0
+				isuppz[1] = i
+				break
+			}
+			ztz += z[i+1] * z[i+1]
+		}
+	}
+
+	// Compute the quantities for the convergence test.
+	tmp := 1 / ztz
+	nrminv = math.Sqrt(tmp)
+	resid = math.Abs(mingma) * nrminv
+	rqcorr = mingma * tmp
+	return negcnt, twist, nrminv, resid, rqcorr
+}
+
+// dlarrb refines the approximations of the eigenvalues with indices ifirst
+// through ilast of L*D*Lᵀ by bisection, given lld holding D*L*L. w, werr and
+// wgap hold the approximations, their error bounds and the gaps to their
+// right neighbours, where eigenvalue i is stored at index i-offset. An
+// interval has converged when its width is at most
+// max(rtol1*gap, rtol2*max(|left|,|right|)), where gap is the smaller of the
+// gaps to the neighbours. twist is the twist index used for the Sturm counts;
+// if it is out of range, n-1 is used.
+//
+// work must have length at least 2*n and iwork must have length at least 2*n.
+func (impl Implementation) dlarrb(n int, d, lld []float64, ifirst, ilast int, rtol1, rtol2 float64, offset int, w, wgap, werr, work []float64, iwork []int, pivmin, spdiam float64, twist int) {
+	if n <= 0 {
+		return
+	}
+
+	maxitr := int((math.Log(spdiam+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+	mnwdth := 2 * pivmin
+
+	r := twist
+	if r < 0 || r >= n {
+		r = n - 1
+	}
+
+	// Initialize the unconverged intervals in [work[2*i], work[2*i+1]].
+	// The Sturm count at work[2*i] is arranged to be i, and that at
+	// work[2*i+1] is stored in iwork[2*i+1]. iwork[2*i] is the index of
+	// the next unconverged interval for an unconverged interval, -1 for an
+	// interval that converged during the iteration and -2 for an interval
+	// that had already converged. This sets up a linked list of
+	// unconverged intervals.
+	i1 := ifirst
+	// nint is the number of unconverged intervals and prev is the last
+	// unconverged interval found.
+	nint := 0
+	prev := -1
+
+	rgap := wgap[i1-offset]
+	for i := i1; i <= ilast; i++ {
+		k := 2 * i
+		ii := i - offset
+		left := w[ii] - werr[ii]
+		right := w[ii] + werr[ii]
+		lgap := rgap
+		rgap = wgap[ii]
+		gap := math.Min(lgap, rgap)
+
+		// Make sure that [left,right] contains the wanted eigenvalue.
+		back := werr[ii]
+		for impl.dlaneg(n, d, lld, left, pivmin, r) > i {
+			left -= back
+			back *= 2
+		}
+		var negcnt int
+		back = werr[ii]
+		for {
+			negcnt = impl.dlaneg(n, d, lld, right, pivmin, r)
+			if negcnt > i {
+				break
+			}
+			right += back
+			back *= 2
+		}
+		width := math.Abs(left-right) / 2
+		tmp := math.Max(math.Abs(left), math.Abs(right))
+		cvrgd := math.Max(rtol1*gap, rtol2*tmp)
+		if width <= cvrgd || width <= mnwdth {
+			// The interval has already converged and is removed
+			// from the list.
+			iwork[k] = -2
+			// Make sure that i1 points to the first unconverged
+			// interval.
+			if i == i1 && i < ilast {
+				i1 = i + 1
+			}
+			if prev >= i1 && i <= ilast {
+				iwork[2*prev] = i + 1
+			}
+		} else {
+			prev = i
+			nint++
+			iwork[k] = i + 1
+			iwork[k+1] = negcnt
+		}
+		work[k] = left
+		work[k+1] = right
+	}
+
+	// Bisect the unconverged intervals until all have converged or the
+	// maximum number of iterations is reached.
+	for iter := 0; nint > 0 && iter <= maxitr; iter++ {
+		prev = i1 - 1
+		i := i1
+		olnint := nint
+		for ip := 0; ip < olnint; ip++ {
+			k := 2 * i
+			ii := i - offset
+			rgap := wgap[ii]
+			lgap := rgap
+			if ii > 0 {
+				lgap = wgap[ii-1]
+			}
+			gap := math.Min(lgap, rgap)
+			next := iwork[k]
+			left := work[k]
+			right := work[k+1]
+			mid := (left + right) / 2
+
+			// Semi-width of the interval.
+			width := right - mid
+			tmp := math.Max(math.Abs(left), math.Abs(right))
+			cvrgd := math.Max(rtol1*gap, rtol2*tmp)
+			if width <= cvrgd || width <= mnwdth || iter == maxitr {
+				// Mark the interval as converged and remove it
+				// from the list.
+				nint--
+				iwork[k] = -1
+				if i1 == i {
+					i1 = next
+				} else if prev >= i1 {
+					iwork[2*prev] = next
+				}
+				i = next
+				continue
+			}
+			prev = i
+
+			// Perform one bisection step.
+			if impl.dlaneg(n, d, lld, mid, pivmin, r) <= i {
+				work[k] = mid
+			} else {
+				work[k+1] = mid
+			}
+			i = next
+		}
+	}
+
+	// All intervals have converged.
+	for i := ifirst; i <= ilast; i++ {
+		k := 2 * i
+		ii := i - offset
+		if iwork[k] == -1 {
+			w[ii] = (work[k] + work[k+1]) / 2
+			werr[ii] = work[k+1] - w[ii]
+		}
+	}
+	for i := ifirst + 1; i <= ilast; i++ {
+		ii := i - offset
+		wgap[ii-1] = math.Max(0, w[ii]-werr[ii]-w[ii-1]-werr[ii-1])
+	}
+}
+
+// dlaneg returns the Sturm count, the number of negative pivots encountered
+// in the factorization of L*D*Lᵀ - σ*I, where lld holds D*L*L. The count is
+// computed from the twisted factorization with twist index r, combining a
+// stationary transform from the top with a progressive transform from the
+// bottom.
+func (Implementation) dlaneg(n int, d, lld []float64, sigma, pivmin float64, r int) int {
+	// blklen is the block length after which NaNs are checked for.
+	const blklen = 128
+
+	var negcnt int
+
+	// Upper part: L*D*Lᵀ - σ*I = L+*D+*L+ᵀ.
+	t := -sigma
+	for bj := 0; bj < r; bj += blklen {
+		var neg1 int
+		bsav := t
+		jmax := min(bj+blklen, r)
+		for j := bj; j < jmax; j++ {
+			dplus := d[j] + t
+			if dplus < 0 {
+				neg1++
+			}
+			tmp := t / dplus
+			t = tmp*lld[j] - sigma
+		}
+		if math.IsNaN(t) {
+			// Run a slower version of the above loop if a NaN is
+			// detected.
+			neg1 = 0
+			t = bsav
+			for j := bj; j < jmax; j++ {
+				dplus := d[j] + t
+				if dplus < 0 {
+					neg1++
+				}
+				tmp := t / dplus
+				if math.IsNaN(tmp) {
+					tmp = 1
+				}
+				t = tmp*lld[j] - sigma
+			}
+		}
+		negcnt += neg1
+	}
+
+	// Lower part: L*D*Lᵀ - σ*I = U-*D-*U-ᵀ.
+	p := d[n-1] - sigma
+	for bj := n - 2; bj >= r; bj -= blklen {
+		var neg2 int
+		bsav := p
+		jmin := max(bj-blklen+1, r)
+		for j := bj; j >= jmin; j-- {
+			dminus := lld[j] + p
+			if dminus < 0 {
+				neg2++
+			}
+			tmp := p / dminus
+			p = tmp*d[j] - sigma
+		}
+		if math.IsNaN(p) {
+			// Run a slower version of the above loop if a NaN is
+			// detected.
+			neg2 = 0
+			p = bsav
+			for j := bj; j >= jmin; j-- {
+				dminus := lld[j] + p
+				if dminus < 0 {
+					neg2++
+				}
+				tmp := p / dminus
+				if math.IsNaN(tmp) {
+					tmp = 1
+				}
+				p = tmp*d[j] - sigma
+			}
+		}
+		negcnt += neg2
+	}
+
+	// Twist index.
+	gamma := (t + sigma) + p
+	if gamma < 0 {
+		negcnt++
+	}
+	return negcnt
+}
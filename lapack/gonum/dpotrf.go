@@ -13,7 +13,8 @@ import (
 // matrix a. If ul == blas.Upper, then a is stored as an upper-triangular matrix,
 // and a = Uᵀ U is stored in place into a. If ul == blas.Lower, then a = L Lᵀ
 // is computed and stored in-place into a. If a is not positive definite, false
-// is returned. This is the blocked version of the algorithm.
+// is returned. This is the blocked version of the algorithm. For large matrices
+// the factorization is computed on tiles by at most impl.Workers goroutines.
 func (impl Implementation) Dpotrf(ul blas.Uplo, n int, a []float64, lda int) (ok bool) {
 	switch {
 	case ul != blas.Upper && ul != blas.Lower:
@@ -37,6 +38,9 @@ func (impl Implementation) Dpotrf(ul blas.Uplo, n int, a []float64, lda int) (ok
 	if nb <= 1 || n <= nb {
 		return impl.Dpotf2(ul, n, a, lda)
 	}
+	if w := impl.workers(); w > 1 && n >= parMinBlocks*nb {
+		return impl.dpotrfTiled(ul, n, a, lda, nb, w)
+	}
 	bi := blas64.Implementation()
 	if ul == blas.Upper {
 		for j := 0; j < n; j += nb {
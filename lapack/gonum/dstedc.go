@@ -0,0 +1,389 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dstedc computes all eigenvalues and, optionally, the eigenvectors of a
+// symmetric tridiagonal matrix using the divide and conquer method. The
+// eigenvectors of a full symmetric matrix can also be found if Dsytrd has been
+// used to reduce this matrix to tridiagonal form.
+//
+// d, on entry, contains the diagonal elements of the tridiagonal matrix. On exit,
+// d contains the eigenvalues in ascending order. d must have length n and
+// Dstedc will panic otherwise.
+//
+// e, on entry, contains the off-diagonal elements of the tridiagonal matrix,
+// and is overwritten during the call to Dstedc. e must have length n-1 and
+// Dstedc will panic otherwise.
+//
+// z, on entry, contains the n×n orthogonal matrix used in the reduction to
+// tridiagonal form if compz == lapack.EVOrig. On exit, if
+// compz == lapack.EVOrig, z contains the orthonormal eigenvectors of the
+// original symmetric matrix, and if compz == lapack.EVTridiag, z contains the
+// orthonormal eigenvectors of the symmetric tridiagonal matrix. z is not used
+// if compz == lapack.EVCompNone.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least
+//  1            if n <= 1 or compz == lapack.EVCompNone,
+//  1+4*n+2*n^2  if compz == lapack.EVTridiag,
+//  1+4*n+3*n^2  if compz == lapack.EVOrig,
+// and Dstedc will panic otherwise. iwork is integer temporary storage, and
+// liwork specifies its usable length. liwork must be at least 1 if n <= 1 or
+// compz == lapack.EVCompNone, and 3*n otherwise.
+//
+// If lwork == -1 or liwork == -1, instead of performing Dstedc, the minimum
+// workspace lengths are stored into work[0] and iwork[0].
+//
+// Dstedc returns whether the computation succeeded. If it returns false, the
+// eigenvalues in d and the vectors in z are not valid.
+func (impl Implementation) Dstedc(compz lapack.EVComp, n int, d, e, z []float64, ldz int, work []float64, lwork int, iwork []int, liwork int) (ok bool) {
+	switch {
+	case compz != lapack.EVCompNone && compz != lapack.EVTridiag && compz != lapack.EVOrig:
+		panic(badEVComp)
+	case n < 0:
+		panic(nLT0)
+	case ldz < 1, compz != lapack.EVCompNone && ldz < n:
+		panic(badLdZ)
+	}
+
+	lworkmin, liworkmin := 1, 1
+	if n > 1 {
+		switch compz {
+		case lapack.EVTridiag:
+			lworkmin = 1 + 4*n + 2*n*n
+			liworkmin = 3 * n
+		case lapack.EVOrig:
+			lworkmin = 1 + 4*n + 3*n*n
+			liworkmin = 3 * n
+		}
+	}
+
+	switch {
+	case lwork < lworkmin && lwork != -1:
+		panic(badLWork)
+	case liwork < liworkmin && liwork != -1:
+		panic(badLIWork)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	case len(iwork) < max(1, liwork):
+		panic(shortIWork)
+	}
+
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(lworkmin)
+		iwork[0] = liworkmin
+		return true
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	switch {
+	case len(d) < n:
+		panic(shortD)
+	case len(e) < n-1:
+		panic(shortE)
+	case compz != lapack.EVCompNone && len(z) < (n-1)*ldz+n:
+		panic(shortZ)
+	}
+
+	if n == 1 {
+		if compz != lapack.EVCompNone {
+			z[0] = 1
+		}
+		return true
+	}
+
+	if compz == lapack.EVCompNone {
+		return impl.Dsterf(n, d, e)
+	}
+
+	// Small problems are solved more efficiently by the implicit QL/QR method.
+	smlsiz := impl.Ilaenv(9, "DSTEDC", " ", 0, 0, 0, 0)
+	if n <= smlsiz {
+		return impl.Dsteqr(compz, n, d, e, z, ldz, work)
+	}
+
+	if compz == lapack.EVOrig {
+		// Compute the eigenvectors of the tridiagonal matrix into the
+		// workspace and multiply them into z.
+		ztri := work[:n*n]
+		rest := work[n*n : lwork]
+		ok = impl.Dstedc(lapack.EVTridiag, n, d, e, ztri, n, rest, len(rest), iwork, liwork)
+		if !ok {
+			return false
+		}
+		prod := rest[:n*n]
+		bi := blas64.Implementation()
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, n, n, n, 1, z, ldz, ztri, n, 0, prod, n)
+		impl.Dlacpy(blas.All, n, n, prod, n, z, ldz)
+		return true
+	}
+
+	impl.Dlaset(blas.All, n, n, 0, 1, z, ldz)
+
+	// Split the matrix into unreduced blocks at negligible off-diagonal
+	// elements and solve each block independently.
+	eps := dlamchE
+	for start := 0; start < n; {
+		end := start
+		for ; end < n-1; end++ {
+			tiny := eps * math.Sqrt(math.Abs(d[end])) * math.Sqrt(math.Abs(d[end+1]))
+			if math.Abs(e[end]) <= tiny {
+				e[end] = 0
+				break
+			}
+		}
+		m := end - start + 1
+		zb := z[start*ldz+start:]
+		ok = true
+		switch {
+		case m == 1:
+		case m <= smlsiz:
+			ok = impl.Dsteqr(lapack.EVTridiag, m, d[start:], e[start:], zb, ldz, work)
+		default:
+			// Scale the block so that its largest element has unit magnitude.
+			orgnrm := impl.Dlanst(lapack.MaxAbs, m, d[start:], e[start:])
+			if orgnrm == 0 {
+				break
+			}
+			impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, m, 1, d[start:], 1)
+			impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, m-1, 1, e[start:], 1)
+			ok = impl.dlaed0(m, smlsiz, d[start:], e[start:], zb, ldz, work, iwork)
+			impl.Dlascl(lapack.General, 0, 0, 1, orgnrm, m, 1, d[start:], 1)
+		}
+		if !ok {
+			return false
+		}
+		start = end + 1
+	}
+
+	// Sort the eigenvalues of the blocks into increasing order, and permute
+	// the eigenvectors correspondingly.
+	bi := blas64.Implementation()
+	for i := 0; i < n-1; i++ {
+		k := i
+		p := d[i]
+		for j := i + 1; j < n; j++ {
+			if d[j] < p {
+				k = j
+				p = d[j]
+			}
+		}
+		if k != i {
+			d[k] = d[i]
+			d[i] = p
+			bi.Dswap(n, z[i:], ldz, z[k:], ldz)
+		}
+	}
+	return true
+}
+
+// dlaed0 computes the eigenvalues and eigenvectors of the n×n unreduced
+// symmetric tridiagonal matrix given by d and e, placing the eigenvectors in
+// the n×n block of q. Matrices no larger than smlsiz are solved using Dsteqr,
+// and larger matrices are split into two halves by a rank-one tearing whose
+// solutions are merged by dlaed1. The eigenvalues in d are returned in
+// ascending order.
+func (impl Implementation) dlaed0(n, smlsiz int, d, e, q []float64, ldq int, work []float64, iwork []int) (ok bool) {
+	if n <= smlsiz {
+		return impl.Dsteqr(lapack.EVTridiag, n, d, e, q, ldq, work)
+	}
+
+	// Tear the matrix into
+	//  T = [T1 0; 0 T2] + |β| * v * vᵀ
+	// where v has ones (up to sign) in positions m-1 and m.
+	m := n / 2
+	beta := e[m-1]
+	rho := math.Abs(beta)
+	d[m-1] -= rho
+	d[m] -= rho
+
+	if !impl.dlaed0(m, smlsiz, d, e, q, ldq, work, iwork) {
+		return false
+	}
+	if !impl.dlaed0(n-m, smlsiz, d[m:], e[m:], q[m*ldq+m:], ldq, work, iwork) {
+		return false
+	}
+	return impl.dlaed1(n, m, d, q, ldq, rho, math.Copysign(1, beta), work, iwork)
+}
+
+// dlaed1 merges the eigensystems of the two halves of a torn tridiagonal
+// matrix. On entry, the first m and last n-m elements of d hold the
+// eigenvalues, in ascending order, of the two independent blocks, and the
+// corresponding diagonal blocks of q hold their eigenvectors. rho and sgn
+// give the magnitude and sign of the off-diagonal element removed from the
+// tridiagonal matrix.
+//
+// On return d holds the eigenvalues of the full matrix in ascending order,
+// and q holds the corresponding eigenvectors.
+//
+// work must have length at least 4*n+2*n*n and iwork must have length at
+// least 3*n.
+func (impl Implementation) dlaed1(n, m int, d, q []float64, ldq int, rho, sgn float64, work []float64, iwork []int) (ok bool) {
+	zv := work[:n]
+	dk := work[n : 2*n]
+	lam := work[2*n : 3*n]
+	zhat := work[3*n : 4*n]
+	qtmp := work[4*n : 4*n+n*n]
+	u := work[4*n+n*n : 4*n+2*n*n]
+	perm := iwork[:n]
+	keep := iwork[n : 2*n]
+	defl := iwork[2*n : 3*n]
+
+	bi := blas64.Implementation()
+
+	// Form the updating vector from the last row of the first block and the
+	// first row of the second block of eigenvectors. Normalizing z to unit
+	// length doubles rho.
+	bi.Dcopy(m, q[(m-1)*ldq:], 1, zv, 1)
+	bi.Dcopy(n-m, q[m*ldq+m:], 1, zv[m:], 1)
+	if sgn < 0 {
+		bi.Dscal(n-m, -1, zv[m:], 1)
+	}
+	bi.Dscal(n, 1/math.Sqrt2, zv, 1)
+	rho *= 2
+
+	// Merge the two sorted lists of eigenvalues.
+	for i, j, k := 0, m, 0; k < n; k++ {
+		if j == n || (i < m && d[i] <= d[j]) {
+			perm[k] = i
+			i++
+		} else {
+			perm[k] = j
+			j++
+		}
+	}
+
+	// Deflate eigenvalues whose updating component is negligible or that are
+	// close to a neighbouring eigenvalue. keep holds the indices of the
+	// eigenvalues to be updated and defl holds those that are deflated.
+	dmax := math.Max(math.Abs(d[perm[0]]), math.Abs(d[perm[n-1]]))
+	tol := 8 * dlamchE * math.Max(dmax, rho)
+	var k, nd int
+	for _, idx := range perm {
+		if rho*math.Abs(zv[idx]) <= tol {
+			defl[nd] = idx
+			nd++
+			continue
+		}
+		if k > 0 {
+			p := keep[k-1]
+			tau := math.Hypot(zv[idx], zv[p])
+			c := zv[idx] / tau
+			s := zv[p] / tau
+			if math.Abs((d[idx]-d[p])*c*s) <= tol {
+				// Rotate the updating vector component of p into idx.
+				zv[idx] = tau
+				zv[p] = 0
+				bi.Drot(n, q[p:], ldq, q[idx:], ldq, c, -s)
+				dp, di := d[p], d[idx]
+				d[p] = dp*c*c + di*s*s
+				d[idx] = dp*s*s + di*c*c
+				keep[k-1] = idx
+				defl[nd] = p
+				nd++
+				continue
+			}
+		}
+		keep[k] = idx
+		k++
+	}
+
+	// Gather the columns of the eigenvectors to be updated followed by the
+	// deflated columns.
+	for j, idx := range keep[:k] {
+		bi.Dcopy(n, q[idx:], ldq, qtmp[j:], n)
+		dk[j] = d[idx]
+		zhat[j] = zv[idx]
+	}
+	for j, idx := range defl[:nd] {
+		bi.Dcopy(n, q[idx:], ldq, qtmp[k+j:], n)
+	}
+
+	if k > 0 {
+		// Solve the secular equation for the updated eigenvalues. Row j
+		// of u holds the differences dk - lam[j].
+		for j := 0; j < k; j++ {
+			lam[j], ok = impl.Dlaed4(k, j, dk, zhat, u[j*k:(j+1)*k], rho)
+			if !ok {
+				return false
+			}
+		}
+
+		if k == 1 {
+			u[0] = 1
+		} else {
+			// Recompute the updating vector from the computed eigenvalues
+			// so that the eigenvectors are numerically orthogonal (Gu and
+			// Eisenstat). The product is formed from paired ratios to avoid
+			// overflow and underflow.
+			for i := 0; i < k; i++ {
+				prod := -u[(k-1)*k+i] / rho
+				for j := 0; j < i; j++ {
+					prod *= u[j*k+i] / (dk[i] - dk[j])
+				}
+				for j := i; j < k-1; j++ {
+					prod *= u[j*k+i] / (dk[i] - dk[j+1])
+				}
+				zv[i] = math.Copysign(math.Sqrt(math.Abs(prod)), zhat[i])
+			}
+			for j := 0; j < k; j++ {
+				row := u[j*k : (j+1)*k]
+				for i := range row {
+					row[i] = zv[i] / row[i]
+				}
+				bi.Dscal(k, 1/bi.Dnrm2(k, row, 1), row, 1)
+			}
+		}
+		bi.Dgemm(blas.NoTrans, blas.Trans, n, k, k, 1, qtmp, n, u, k, 0, q, ldq)
+	}
+
+	// Collect the deflated eigenvalues with the indices of their vectors in
+	// qtmp and sort them into increasing order.
+	dd := dk[k : k+nd]
+	for j, idx := range defl[:nd] {
+		dd[j] = d[idx]
+		defl[j] = k + j
+	}
+	for j := 1; j < nd; j++ {
+		v, c := dd[j], defl[j]
+		i := j - 1
+		for ; i >= 0 && dd[i] > v; i-- {
+			dd[i+1] = dd[i]
+			defl[i+1] = defl[i]
+		}
+		dd[i+1] = v
+		defl[i+1] = c
+	}
+
+	// Merge the updated and deflated eigenpairs in place, working from the
+	// end of d and q. The updated eigenvectors only ever move to the right.
+	r, t := k-1, nd-1
+	for p := n - 1; p >= 0; p-- {
+		if t < 0 || (r >= 0 && lam[r] > dd[t]) {
+			d[p] = lam[r]
+			if p != r {
+				bi.Dcopy(n, q[r:], ldq, q[p:], ldq)
+			}
+			r--
+		} else {
+			d[p] = dd[t]
+			bi.Dcopy(n, qtmp[defl[t]:], n, q[p:], ldq)
+			t--
+		}
+	}
+	return true
+}
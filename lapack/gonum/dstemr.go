@@ -0,0 +1,521 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dstemr computes selected eigenvalues and, optionally, eigenvectors of a
+// symmetric tridiagonal matrix using the Multiple Relatively Robust
+// Representations (MRRR) algorithm.
+//
+// The eigenvalues to compute are selected by rng:
+//  rng == lapack.EVRangeAll:   all eigenvalues are computed,
+//  rng == lapack.EVRangeValue: the eigenvalues in the half-open interval
+//                              (vl, vu] are computed,
+//  rng == lapack.EVRangeIndex: the eigenvalues with zero-based indices il
+//                              through iu inclusive, in ascending order, are
+//                              computed.
+// If rng == lapack.EVRangeValue, vl must be less than vu, and if
+// rng == lapack.EVRangeIndex, il and iu must satisfy 0 <= il <= iu < n.
+// Dstemr will panic otherwise.
+//
+// d, on entry, contains the diagonal elements of the tridiagonal matrix, and
+// the first n-1 elements of e contain the off-diagonal elements. d and e must
+// have length at least n, and both are overwritten during the call to Dstemr.
+//
+// On return, the first m elements of w contain the selected eigenvalues in
+// ascending order. w must have length n. If jobz == lapack.EVCompute, the
+// first m columns of z contain the orthonormal eigenvectors corresponding to
+// the eigenvalues in w, and isuppz[2*j] and isuppz[2*j+1] contain the indices
+// of the first and last non-zero elements of the j-th eigenvector. z must
+// have room for n rows and the number of selected eigenvalues columns, and
+// isuppz must have length at least twice the number of selected eigenvalues.
+//
+// For each unreduced block of the matrix, Dstemr finds a shift such that the
+// shifted block has a relatively robust L*D*Lᵀ representation and computes
+// the eigenvalues of the representation to high relative accuracy. Each
+// eigenvector is computed from a twisted factorization of a representation
+// shifted close to its eigenvalue, and clusters of close eigenvalues are
+// resolved by computing new representations shifted close to the cluster.
+// The eigenvalues are refined by bisection on the original matrix so that
+// they are computed to high relative accuracy where the matrix allows it.
+// All intermediate results are held in work and iwork.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least max(1, 18*n) if jobz == lapack.EVCompute and
+// max(1, 12*n) otherwise. iwork is integer temporary storage and liwork must
+// be at least max(1, 10*n) if jobz == lapack.EVCompute and max(1, 8*n)
+// otherwise. If lwork == -1 or liwork == -1, instead of performing Dstemr, the
+// minimum workspace lengths are stored into work[0] and iwork[0].
+//
+// Dstemr returns the number of eigenvalues found and whether the computation
+// succeeded.
+func (impl Implementation) Dstemr(jobz lapack.EVJob, rng lapack.EVRange, n int, d, e []float64, vl, vu float64, il, iu int, w, z []float64, ldz int, isuppz []int, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool) {
+	wantz := jobz == lapack.EVCompute
+	switch {
+	case jobz != lapack.EVNone && !wantz:
+		panic(badEVJob)
+	case rng != lapack.EVRangeAll && rng != lapack.EVRangeValue && rng != lapack.EVRangeIndex:
+		panic(badEVRange)
+	case n < 0:
+		panic(nLT0)
+	case rng == lapack.EVRangeValue && vl >= vu:
+		panic(badInterval)
+	case rng == lapack.EVRangeIndex && (il < 0 || il > max(0, n-1)):
+		panic(badIl)
+	case rng == lapack.EVRangeIndex && (iu < min(n-1, il) || iu >= max(1, n)):
+		panic(badIu)
+	}
+
+	// mmax is the maximum number of eigenvalues that can be selected,
+	// and so the number of columns of z.
+	mmax := n
+	if rng == lapack.EVRangeIndex {
+		mmax = iu - il + 1
+	}
+	if ldz < 1 || wantz && ldz < mmax {
+		panic(badLdZ)
+	}
+
+	lworkmin, liworkmin := max(1, 12*n), max(1, 8*n)
+	if wantz {
+		lworkmin, liworkmin = max(1, 18*n), max(1, 10*n)
+	}
+	switch {
+	case lwork < lworkmin && lwork != -1:
+		panic(badLWork)
+	case liwork < liworkmin && liwork != -1:
+		panic(badLIWork)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	case len(iwork) < max(1, liwork):
+		panic(shortIWork)
+	}
+
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(lworkmin)
+		iwork[0] = liworkmin
+		return 0, true
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0, true
+	}
+
+	switch {
+	case len(d) < n:
+		panic(shortD)
+	case len(e) < n:
+		panic(shortE)
+	case len(w) < n:
+		panic(shortW)
+	case wantz && len(z) < (n-1)*ldz+mmax:
+		panic(shortZ)
+	case wantz && len(isuppz) < 2*mmax:
+		panic(shortIsupp)
+	}
+
+	if n == 1 {
+		if rng == lapack.EVRangeValue && (d[0] <= vl || vu < d[0]) {
+			return 0, true
+		}
+		w[0] = d[0]
+		if wantz {
+			z[0] = 1
+			isuppz[0], isuppz[1] = 0, 0
+		}
+		return 1, true
+	}
+
+	if n == 2 {
+		// Compute the eigenvalues rt2 <= rt1 and the eigenvector
+		// (cs, sn) of rt1 directly.
+		var rt1, rt2, cs, sn float64
+		if wantz {
+			rt1, rt2, cs, sn = impl.Dlaev2(d[0], e[0], d[1])
+		} else {
+			rt1, rt2 = impl.Dlae2(d[0], e[0], d[1])
+		}
+		if rt1 < rt2 {
+			// Dlae2 and Dlaev2 order the eigenvalues by absolute
+			// value.
+			rt1, rt2 = rt2, rt1
+			cs, sn = -sn, cs
+		}
+		if rng == lapack.EVRangeAll || (rng == lapack.EVRangeValue && vl < rt2 && rt2 <= vu) || (rng == lapack.EVRangeIndex && il == 0) {
+			w[m] = rt2
+			if wantz {
+				z[m] = -sn
+				z[ldz+m] = cs
+				setSupport2(isuppz[2*m:], -sn, cs)
+			}
+			m++
+		}
+		if rng == lapack.EVRangeAll || (rng == lapack.EVRangeValue && vl < rt1 && rt1 <= vu) || (rng == lapack.EVRangeIndex && iu == 1) {
+			w[m] = rt1
+			if wantz {
+				z[m] = cs
+				z[ldz+m] = sn
+				setSupport2(isuppz[2*m:], cs, sn)
+			}
+			m++
+		}
+		return m, true
+	}
+
+	const minrgp = 1e-3
+
+	eps := dlamchP
+	smlnum := dlamchS / eps
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Min(math.Sqrt(1/smlnum), 1/math.Sqrt(math.Sqrt(dlamchS)))
+
+	// Partition the workspace.
+	indgrs := 0
+	inderr := 2 * n
+	indgp := 3 * n
+	indd := 4 * n
+	inde2 := 5 * n
+	indwrk := 6 * n
+
+	iinspl := 0
+	iindbl := n
+	iindw := 2 * n
+	iindwk := 3 * n
+
+	// Scale the matrix to the allowable range, if necessary. The
+	// preference for scaling small values up is heuristic.
+	tnrm := impl.Dlanst(lapack.MaxAbs, n, d, e)
+	scale := 1.0
+	if tnrm > 0 && tnrm < rmin {
+		scale = rmin / tnrm
+	} else if tnrm > rmax {
+		scale = rmax / tnrm
+	}
+	bi := blas64.Implementation()
+	if scale != 1 {
+		bi.Dscal(n, scale, d, 1)
+		bi.Dscal(n-1, scale, e, 1)
+		tnrm *= scale
+		if rng == lapack.EVRangeValue {
+			vl *= scale
+			vu *= scale
+		}
+	}
+
+	// Test whether the matrix warrants the more expensive relative
+	// approach. If it does, the matrix is split at off-diagonal elements
+	// that are small relative to their neighbouring diagonal elements and
+	// the eigenvalues are refined to relative accuracy at the end.
+	// Otherwise the old splitting criterion based on the absolute size of
+	// the off-diagonal elements is used.
+	tryrac := impl.dlarrr(n, d, e)
+	thresh := eps
+	if !tryrac {
+		thresh = -eps
+	}
+	if tryrac {
+		// Keep the original diagonal to guarantee relative accuracy.
+		copy(work[indd:indd+n], d[:n])
+	}
+	for j := 0; j < n-1; j++ {
+		work[inde2+j] = e[j] * e[j]
+	}
+
+	// Set the tolerances for bisection. If the eigenvectors are wanted,
+	// dlarrv refines the eigenvalue approximations, so dlarre can use less
+	// accurate bisection.
+	rtol1 := 4 * eps
+	rtol2 := 4 * eps
+	if wantz {
+		rtol1 = math.Sqrt(eps)
+		rtol2 = math.Max(math.Sqrt(eps)*5e-3, 4*eps)
+	}
+
+	isplit := iwork[iinspl : iinspl+n]
+	iblock := iwork[iindbl : iindbl+n]
+	indexw := iwork[iindw : iindw+n]
+	werr := work[inderr : inderr+n]
+	wgap := work[indgp : indgp+n]
+	gers := work[indgrs : indgrs+2*n]
+	vl, vu, nsplit, m, pivmin, ok := impl.dlarre(rng, n, vl, vu, il, iu, d, e, work[inde2:inde2+n], rtol1, rtol2, thresh, isplit, w, werr, wgap, iblock, indexw, gers, work[indwrk:], iwork[iindwk:])
+	if !ok {
+		return 0, false
+	}
+	// All wanted eigenvalues are contained in (vl,vu].
+
+	if wantz {
+		// Compute the eigenvectors of the computed eigenvalues.
+		ok = impl.dlarrv(n, vl, d, e, pivmin, isplit, m, minrgp, rtol1, rtol2, w, werr, wgap, iblock, indexw, gers, z, ldz, isuppz, work[indwrk:], iwork[iindwk:])
+		if !ok {
+			return 0, false
+		}
+	} else {
+		// dlarre computes the eigenvalues of the shifted root
+		// representations, so the shifts must be added back.
+		for j := 0; j < m; j++ {
+			w[j] += e[isplit[iblock[j]]]
+		}
+	}
+
+	if tryrac && m > 0 {
+		// Refine the computed eigenvalues so that they are relatively
+		// accurate with respect to the original matrix.
+		var ibegin, wbegin int
+		for jblk := 0; jblk <= iblock[m-1]; jblk++ {
+			iend := isplit[jblk]
+			in := iend - ibegin + 1
+			wend := wbegin
+			for wend < m && iblock[wend] == jblk {
+				wend++
+			}
+			if wend == wbegin {
+				ibegin = iend + 1
+				continue
+			}
+			ifirst := indexw[wbegin]
+			ilast := indexw[wend-1]
+			impl.dlarrj(in, work[indd+ibegin:], work[inde2+ibegin:], ifirst, ilast, 4*eps, ifirst, w[wbegin:], werr[wbegin:], work[indwrk:], iwork[iindwk:], pivmin, tnrm)
+			ibegin = iend + 1
+			wbegin = wend
+		}
+	}
+
+	if scale != 1 {
+		bi.Dscal(m, 1/scale, w, 1)
+	}
+
+	// The eigenvalues of different blocks are not in increasing order, so
+	// sort them, along with the eigenvectors.
+	if nsplit > 1 {
+		if !wantz {
+			impl.Dlasrt(lapack.SortIncreasing, m, w)
+			return m, true
+		}
+		for j := 0; j < m-1; j++ {
+			i := -1
+			tmp := w[j]
+			for jj := j + 1; jj < m; jj++ {
+				if w[jj] < tmp {
+					i = jj
+					tmp = w[jj]
+				}
+			}
+			if i != -1 {
+				w[i] = w[j]
+				w[j] = tmp
+				bi.Dswap(n, z[i:], ldz, z[j:], ldz)
+				isuppz[2*i], isuppz[2*j] = isuppz[2*j], isuppz[2*i]
+				isuppz[2*i+1], isuppz[2*j+1] = isuppz[2*j+1], isuppz[2*i+1]
+			}
+		}
+	}
+	return m, true
+}
+
+// setSupport2 stores in isuppz the indices of the first and last non-zero
+// elements of the vector (z0, z1), at most one of which is zero.
+func setSupport2(isuppz []int, z0, z1 float64) {
+	switch {
+	case z0 == 0:
+		isuppz[0], isuppz[1] = 1, 1
+	case z1 == 0:
+		isuppz[0], isuppz[1] = 0, 0
+	default:
+		isuppz[0], isuppz[1] = 0, 1
+	}
+}
+
+// dlarrr tests whether the symmetric tridiagonal matrix T with diagonal d
+// and off-diagonal e warrants expensive computations which guarantee high
+// relative accuracy in the eigenvalues. It corresponds to the LAPACK routine
+// DLARRR.
+//
+// The matrix is considered to determine its eigenvalues to high relative
+// accuracy if it is scaled diagonally dominant in the sense that all
+// diagonal elements are not tiny and
+//  |e[i]| / sqrt(|d[i]*d[i+1]|) + |e[i-1]| / sqrt(|d[i-1]*d[i]|) < 0.999
+// for all i.
+func (Implementation) dlarrr(n int, d, e []float64) bool {
+	const relcond = 0.999
+
+	if n <= 0 {
+		return true
+	}
+
+	rmin := math.Sqrt(dlamchS / dlamchP)
+
+	tmp := math.Sqrt(math.Abs(d[0]))
+	if tmp < rmin {
+		return false
+	}
+	var offdig float64
+	for i := 1; i < n; i++ {
+		tmp2 := math.Sqrt(math.Abs(d[i]))
+		if tmp2 < rmin {
+			return false
+		}
+		offdig2 := math.Abs(e[i-1]) / (tmp * tmp2)
+		if offdig+offdig2 >= relcond {
+			return false
+		}
+		tmp = tmp2
+		offdig = offdig2
+	}
+	return true
+}
+
+// dlarrj refines, by bisection, the initial eigenvalue approximations of the
+// symmetric tridiagonal matrix T with diagonal d and squared off-diagonal
+// elements e2 until each has relative accuracy rtol. It corresponds to the
+// LAPACK routine DLARRJ.
+//
+// The eigenvalues with indices ifirst through ilast are refined. The
+// approximation of eigenvalue i is w[i-offset] with error bound
+// werr[i-offset], and both are updated on return. spdiam is the spectral
+// diameter of T and pivmin the minimum pivot in its Sturm sequence. work
+// must have length at least 2*n and iwork must have length at least 2*n.
+func (Implementation) dlarrj(n int, d, e2 []float64, ifirst, ilast int, rtol float64, offset int, w, werr, work []float64, iwork []int, pivmin, spdiam float64) {
+	if n <= 0 {
+		return
+	}
+
+	maxitr := int((math.Log(spdiam+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+
+	// negcount returns the number of eigenvalues of T less than s.
+	negcount := func(s float64) int {
+		var cnt int
+		dplus := d[0] - s
+		if dplus < 0 {
+			cnt++
+		}
+		for j := 1; j < n; j++ {
+			dplus = d[j] - s - e2[j-1]/dplus
+			if dplus < 0 {
+				cnt++
+			}
+		}
+		return cnt
+	}
+
+	// The unconverged interval of eigenvalue i is
+	// [work[2*i], work[2*i+1]], where negcount(work[2*i]) is i and
+	// negcount(work[2*i+1]) is stored in iwork[2*i+1]. iwork[2*i] holds
+	// the index of the next unconverged interval, forming a linked list.
+	// It is set to -2 for intervals that have converged on entry and to -1
+	// for intervals that converge during the iteration.
+	i1 := ifirst
+	// nint is the number of unconverged intervals and prev is the last
+	// unconverged interval found.
+	var nint int
+	prev := -1
+	for i := ifirst; i <= ilast; i++ {
+		k := 2 * i
+		ii := i - offset
+		left := w[ii] - werr[ii]
+		mid := w[ii]
+		right := w[ii] + werr[ii]
+		width := right - mid
+		tmp := math.Max(math.Abs(left), math.Abs(right))
+
+		if width < rtol*tmp {
+			// This interval has already converged and does not need
+			// refinement, so remove it from the list.
+			iwork[k] = -2
+			// Make sure that i1 always points to the first
+			// unconverged interval.
+			if i == i1 && i < ilast {
+				i1 = i + 1
+			}
+			if prev >= i1 && i <= ilast {
+				iwork[2*prev] = i + 1
+			}
+		} else {
+			prev = i
+			// Make sure that [left,right] contains the eigenvalue.
+			fac := 1.0
+			for negcount(left) > i {
+				left -= werr[ii] * fac
+				fac *= 2
+			}
+			fac = 1
+			cnt := negcount(right)
+			for cnt <= i {
+				right += werr[ii] * fac
+				fac *= 2
+				cnt = negcount(right)
+			}
+			nint++
+			iwork[k] = i + 1
+			iwork[k+1] = cnt
+		}
+		work[k] = left
+		work[k+1] = right
+	}
+
+	savi1 := i1
+
+	// Bisect the unconverged intervals until all have converged or the
+	// maximum number of iterations is reached, in which case all intervals
+	// are accepted.
+	for iter := 0; ; {
+		prev = i1 - 1
+		i := i1
+		olnint := nint
+		for p := 0; p < olnint; p++ {
+			k := 2 * i
+			next := iwork[k]
+			left := work[k]
+			right := work[k+1]
+			mid := (left + right) / 2
+
+			// Semi-width of the interval.
+			width := right - mid
+			tmp := math.Max(math.Abs(left), math.Abs(right))
+			if width < rtol*tmp || iter == maxitr {
+				// Mark the interval as converged and remove it
+				// from the list.
+				nint--
+				iwork[k] = -1
+				if i1 == i {
+					i1 = next
+				} else if prev >= i1 {
+					iwork[2*prev] = next
+				}
+				i = next
+				continue
+			}
+			prev = i
+
+			// Perform one bisection step.
+			if negcount(mid) <= i {
+				work[k] = mid
+			} else {
+				work[k+1] = mid
+			}
+			i = next
+		}
+		iter++
+		if nint <= 0 || iter > maxitr {
+			break
+		}
+	}
+
+	// All intervals marked by -1 have been refined.
+	for i := savi1; i <= ilast; i++ {
+		k := 2 * i
+		ii := i - offset
+		if iwork[k] == -1 {
+			w[ii] = (work[k] + work[k+1]) / 2
+			werr[ii] = work[k+1] - w[ii]
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dsyevr computes selected eigenvalues and, optionally, the eigenvectors of a
+// real symmetric matrix A using the Multiple Relatively Robust Representations
+// algorithm implemented by Dstemr.
+//
+// The eigenvalues to compute are selected by rng, vl, vu, il and iu as
+// described in the documentation for Dstemr.
+//
+// On entry, a contains the elements of the symmetric matrix A in the triangular
+// portion specified by uplo. On exit, the contents of a are destroyed.
+//
+// On return, the first m elements of w contain the selected eigenvalues in
+// ascending order. w must have length at least n, and Dsyevr will panic
+// otherwise. If jobz == lapack.EVCompute, the first m columns of z contain the
+// orthonormal eigenvectors corresponding to the eigenvalues in w, and isuppz
+// holds the indices of the first and last non-zero elements of each
+// eigenvector in the basis of the tridiagonal matrix. z and isuppz must be
+// sized for the number of selected eigenvalues as described for Dstemr.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= max(1, 26*n), and Dsyevr will panic otherwise. iwork is
+// integer temporary storage, and liwork must be at least max(1, 10*n). If
+// lwork == -1 or liwork == -1, instead of computing Dsyevr the optimal work
+// lengths are stored into work[0] and iwork[0].
+//
+// Dsyevr returns the number of eigenvalues found and whether the computation
+// succeeded.
+func (impl Implementation) Dsyevr(jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, w, z []float64, ldz int, isuppz []int, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool) {
+	wantz := jobz == lapack.EVCompute
+	switch {
+	case jobz != lapack.EVNone && !wantz:
+		panic(badEVJob)
+	case rng != lapack.EVRangeAll && rng != lapack.EVRangeValue && rng != lapack.EVRangeIndex:
+		panic(badEVRange)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	case rng == lapack.EVRangeValue && vl >= vu:
+		panic(badInterval)
+	case rng == lapack.EVRangeIndex && (il < 0 || il > max(0, n-1)):
+		panic(badIl)
+	case rng == lapack.EVRangeIndex && (iu < min(n-1, il) || iu >= max(1, n)):
+		panic(badIu)
+	}
+
+	// mmax is the maximum number of eigenvalues that can be selected,
+	// and so the number of columns of z.
+	mmax := n
+	if rng == lapack.EVRangeIndex {
+		mmax = iu - il + 1
+	}
+	if ldz < 1 || wantz && ldz < mmax {
+		panic(badLdZ)
+	}
+
+	lworkmin, liworkmin := max(1, 26*n), max(1, 10*n)
+	switch {
+	case lwork < lworkmin && lwork != -1:
+		panic(badLWork)
+	case liwork < liworkmin && liwork != -1:
+		panic(badLIWork)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	case len(iwork) < max(1, liwork):
+		panic(shortIWork)
+	}
+
+	var opts string
+	if uplo == blas.Upper {
+		opts = "U"
+	} else {
+		opts = "L"
+	}
+	nb := max(impl.Ilaenv(1, "DSYTRD", opts, n, -1, -1, -1), impl.Ilaenv(1, "DORMTR", opts, n, -1, -1, -1))
+	lworkopt := max(lworkmin, (nb+3)*n)
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(lworkopt)
+		iwork[0] = liworkmin
+		return 0, true
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0, true
+	}
+
+	switch {
+	case len(a) < (n-1)*lda+n:
+		panic(shortA)
+	case len(w) < n:
+		panic(shortW)
+	case wantz && len(z) < (n-1)*ldz+mmax:
+		panic(shortZ)
+	case wantz && len(isuppz) < 2*mmax:
+		panic(shortIsupp)
+	}
+
+	safmin := dlamchS
+	eps := dlamchP
+	smlnum := safmin / eps
+	bignum := 1 / smlnum
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Min(math.Sqrt(bignum), 1/math.Sqrt(math.Sqrt(safmin)))
+
+	// Scale matrix to allowable range, if necessary.
+	anrm := impl.Dlansy(lapack.MaxAbs, uplo, n, a, lda, work)
+	scaled := false
+	var sigma float64
+	if anrm > 0 && anrm < rmin {
+		scaled = true
+		sigma = rmin / anrm
+	} else if anrm > rmax {
+		scaled = true
+		sigma = rmax / anrm
+	}
+	if scaled {
+		kind := lapack.LowerTri
+		if uplo == blas.Upper {
+			kind = lapack.UpperTri
+		}
+		impl.Dlascl(kind, 0, 0, 1, sigma, n, n, a, lda)
+		if rng == lapack.EVRangeValue {
+			vl *= sigma
+			vu *= sigma
+		}
+	}
+
+	// Reduce A to tridiagonal form and compute the selected eigenpairs of
+	// the tridiagonal matrix.
+	var indtau int
+	indd := indtau + n
+	inde := indd + n
+	indwork := inde + n
+	llwork := lwork - indwork
+	impl.Dsytrd(uplo, n, a, lda, work[indd:], work[inde:], work[indtau:], work[indwork:], llwork)
+	m, ok = impl.Dstemr(jobz, rng, n, work[indd:], work[inde:], vl, vu, il, iu, w, z, ldz, isuppz, work[indwork:], llwork, iwork, liwork)
+	if !ok {
+		return 0, false
+	}
+
+	if wantz && m > 0 {
+		// Back-transform the eigenvectors of the tridiagonal matrix
+		// with the orthogonal matrix used in the reduction to
+		// tridiagonal form. The diagonal and off-diagonal are no
+		// longer needed, so their space is used as workspace.
+		impl.Dormtr(blas.Left, uplo, blas.NoTrans, n, m, a, lda, work[indtau:indtau+n-1], z, ldz, work[indd:], lwork-indd)
+	}
+
+	// If the matrix was scaled, then rescale eigenvalues appropriately.
+	if scaled {
+		blas64.Implementation().Dscal(m, 1/sigma, w, 1)
+	}
+	work[0] = float64(lworkopt)
+	iwork[0] = liworkmin
+	return m, true
+}
@@ -8,12 +8,14 @@ package gonum
 const (
 	// Panic strings for bad enumeration values.
 	badApplyOrtho      = "lapack: bad ApplyOrtho"
+	badBDComp          = "lapack: bad BDComp"
 	badBalanceJob      = "lapack: bad BalanceJob"
 	badDiag            = "lapack: bad Diag"
 	badDirect          = "lapack: bad Direct"
 	badEVComp          = "lapack: bad EVComp"
 	badEVHowMany       = "lapack: bad EVHowMany"
 	badEVJob           = "lapack: bad EVJob"
+	badEVRange         = "lapack: bad EVRange"
 	badEVSide          = "lapack: bad EVSide"
 	badGSVDJob         = "lapack: bad GSVDJob"
 	badGenOrtho        = "lapack: bad GenOrtho"
@@ -34,14 +36,18 @@ const (
 	bothSVDOver        = "lapack: both jobU and jobVT are lapack.SVDOverwrite"
 
 	// Panic strings for bad numerical and string values.
+	badI        = "lapack: i out of range"
 	badIfst     = "lapack: ifst out of range"
 	badIhi      = "lapack: ihi out of range"
 	badIhiz     = "lapack: ihiz out of range"
+	badIl       = "lapack: il out of range"
 	badIlo      = "lapack: ilo out of range"
 	badIloz     = "lapack: iloz out of range"
 	badIlst     = "lapack: ilst out of range"
 	badIsave    = "lapack: bad isave value"
+	badInterval = "lapack: vl >= vu"
 	badIspec    = "lapack: bad ispec value"
+	badIu       = "lapack: iu out of range"
 	badJ1       = "lapack: j1 out of range"
 	badJpvt     = "lapack: bad element of jpvt"
 	badK1       = "lapack: k1 out of range"
@@ -49,6 +55,7 @@ const (
 	badKacc22   = "lapack: invalid value of kacc22"
 	badKbot     = "lapack: kbot out of range"
 	badKtop     = "lapack: ktop out of range"
+	badLIWork   = "lapack: insufficient declared integer workspace length"
 	badLWork    = "lapack: insufficient declared workspace length"
 	badMm       = "lapack: mm out of range"
 	badN1       = "lapack: bad value of n1"
@@ -93,6 +100,7 @@ const (
 	offsetLT0   = "lapack: offset < 0"
 	pLT0        = "lapack: p < 0"
 	recurLT0    = "lapack: recur < 0"
+	rhoLE0      = "lapack: rho <= 0"
 	zeroCFrom   = "lapack: zero cfrom"
 
 	// Panic strings for bad slice lengths.
@@ -116,11 +124,13 @@ const (
 	shortC     = "lapack: insufficient length of c"
 	shortCNorm = "lapack: insufficient length of cnorm"
 	shortD     = "lapack: insufficient length of d"
+	shortDelta = "lapack: insufficient length of delta"
 	shortE     = "lapack: insufficient length of e"
 	shortF     = "lapack: insufficient length of f"
 	shortH     = "lapack: insufficient length of h"
 	shortIWork = "lapack: insufficient length of iwork"
 	shortIsgn  = "lapack: insufficient length of isgn"
+	shortIsupp = "lapack: insufficient length of isuppz"
 	shortQ     = "lapack: insufficient length of q"
 	shortS     = "lapack: insufficient length of s"
 	shortScale = "lapack: insufficient length of scale"
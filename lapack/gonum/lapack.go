@@ -9,7 +9,17 @@ import "gonum.org/v1/gonum/lapack"
 // Implementation is the native Go implementation of LAPACK routines. It
 // is built on top of calls to the return of blas64.Implementation(), so while
 // this code is in pure Go, the underlying BLAS implementation may not be.
-type Implementation struct{}
+//
+// Dgetrf, Dpotrf and Dgeqrf use a tile-based parallel algorithm for large
+// matrices. Workers bounds the number of goroutines used by these routines.
+// If Workers is zero, runtime.GOMAXPROCS(0) goroutines are used and if Workers
+// is one, the serial algorithms are always used. Each goroutine makes BLAS
+// calls on a single tile, which the native BLAS computes serially; a BLAS
+// implementation that is itself multithreaded for tile-sized problems may
+// oversubscribe the processors, in which case Workers should be set to one.
+type Implementation struct {
+	Workers int
+}
 
 var _ lapack.Float64 = Implementation{}
 
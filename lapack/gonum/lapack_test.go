@@ -7,11 +7,50 @@ package gonum
 import (
 	"testing"
 
+	"gonum.org/v1/gonum/blas"
 	"gonum.org/v1/gonum/lapack/testlapack"
 )
 
 var impl = Implementation{}
 
+// tiledNB is the block size used by tiledImpl. It is small so that the
+// tile-based algorithms are exercised with many tiles by the test suites.
+const tiledNB = 8
+
+// tiledImpl is an Implementation that always uses the tile-based parallel
+// variants of Dgetrf, Dpotrf and Dgeqrf.
+type tiledImpl struct {
+	Implementation
+	workers int
+}
+
+func (impl tiledImpl) Dgetrf(m, n int, a []float64, lda int, ipiv []int) bool {
+	if min(m, n) == 0 {
+		return true
+	}
+	return impl.dgetrfTiled(m, n, a, lda, ipiv, tiledNB, impl.workers)
+}
+
+func (impl tiledImpl) Dpotrf(ul blas.Uplo, n int, a []float64, lda int) bool {
+	if n == 0 {
+		return true
+	}
+	return impl.dpotrfTiled(ul, n, a, lda, tiledNB, impl.workers)
+}
+
+func (impl tiledImpl) Dgeqrf(m, n int, a []float64, lda int, tau, work []float64, lwork int) {
+	if lwork == -1 || min(m, n) == 0 || lwork < n*tiledNB {
+		impl.Implementation.Dgeqrf(m, n, a, lda, tau, work, lwork)
+		return
+	}
+	impl.dgeqrfTiled(m, n, a, lda, tau, work, tiledNB, impl.workers)
+}
+
+func TestDbdsdc(t *testing.T) {
+	t.Parallel()
+	testlapack.DbdsdcTest(t, impl)
+}
+
 func TestDbdsqr(t *testing.T) {
 	t.Parallel()
 	testlapack.DbdsqrTest(t, impl)
@@ -107,11 +146,22 @@ func TestDgeqrf(t *testing.T) {
 	testlapack.DgeqrfTest(t, impl)
 }
 
+func TestDgeqrfTiled(t *testing.T) {
+	t.Parallel()
+	testlapack.DgeqrfTest(t, tiledImpl{workers: 3})
+}
+
 func TestDgerqf(t *testing.T) {
 	t.Parallel()
 	testlapack.DgerqfTest(t, impl)
 }
 
+func TestDgesdd(t *testing.T) {
+	t.Parallel()
+	const tol = 1e-13
+	testlapack.DgesddTest(t, impl, tol)
+}
+
 func TestDgesvd(t *testing.T) {
 	t.Parallel()
 	const tol = 1e-13
@@ -133,6 +183,11 @@ func TestDgetrf(t *testing.T) {
 	testlapack.DgetrfTest(t, impl)
 }
 
+func TestDgetrfTiled(t *testing.T) {
+	t.Parallel()
+	testlapack.DgetrfTest(t, tiledImpl{workers: 3})
+}
+
 func TestDgetrs(t *testing.T) {
 	t.Parallel()
 	testlapack.DgetrsTest(t, impl)
@@ -168,6 +223,11 @@ func TestDlae2(t *testing.T) {
 	testlapack.Dlae2Test(t, impl)
 }
 
+func TestDlaed4(t *testing.T) {
+	t.Parallel()
+	testlapack.Dlaed4Test(t, impl)
+}
+
 func TestDlaev2(t *testing.T) {
 	t.Parallel()
 	testlapack.Dlaev2Test(t, impl)
@@ -448,6 +508,11 @@ func TestDormlq(t *testing.T) {
 	testlapack.DormlqTest(t, impl)
 }
 
+func TestDormql(t *testing.T) {
+	t.Parallel()
+	testlapack.DormqlTest(t, impl)
+}
+
 func TestDormqr(t *testing.T) {
 	t.Parallel()
 	testlapack.DormqrTest(t, impl)
@@ -458,6 +523,16 @@ func TestDormr2(t *testing.T) {
 	testlapack.Dormr2Test(t, impl)
 }
 
+func TestDormtr(t *testing.T) {
+	t.Parallel()
+	testlapack.DormtrTest(t, impl)
+}
+
+func TestDorm2l(t *testing.T) {
+	t.Parallel()
+	testlapack.Dorm2lTest(t, impl)
+}
+
 func TestDorm2r(t *testing.T) {
 	t.Parallel()
 	testlapack.Dorm2rTest(t, impl)
@@ -498,6 +573,11 @@ func TestDpotrf(t *testing.T) {
 	testlapack.DpotrfTest(t, impl)
 }
 
+func TestDpotrfTiled(t *testing.T) {
+	t.Parallel()
+	testlapack.DpotrfTest(t, tiledImpl{workers: 3})
+}
+
 func TestDpotri(t *testing.T) {
 	t.Parallel()
 	testlapack.DpotriTest(t, impl)
@@ -513,6 +593,16 @@ func TestDrscl(t *testing.T) {
 	testlapack.DrsclTest(t, impl)
 }
 
+func TestDstedc(t *testing.T) {
+	t.Parallel()
+	testlapack.DstedcTest(t, impl)
+}
+
+func TestDstemr(t *testing.T) {
+	t.Parallel()
+	testlapack.DstemrTest(t, impl)
+}
+
 func TestDsteqr(t *testing.T) {
 	t.Parallel()
 	testlapack.DsteqrTest(t, impl)
@@ -528,6 +618,16 @@ func TestDsyev(t *testing.T) {
 	testlapack.DsyevTest(t, impl)
 }
 
+func TestDsyevd(t *testing.T) {
+	t.Parallel()
+	testlapack.DsyevdTest(t, impl)
+}
+
+func TestDsyevr(t *testing.T) {
+	t.Parallel()
+	testlapack.DsyevrTest(t, impl)
+}
+
 func TestDsytd2(t *testing.T) {
 	t.Parallel()
 	testlapack.Dsytd2Test(t, impl)
@@ -0,0 +1,285 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"runtime"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/internal/parallel"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// parMinBlocks is the minimum number of nb-sized blocks along the shorter
+// dimension of a matrix for the tile-based parallel factorizations to be used.
+const parMinBlocks = 4
+
+// workers returns the maximum number of goroutines that may be used by the
+// tile-based parallel factorizations.
+func (impl Implementation) workers() int {
+	if impl.Workers > 0 {
+		return impl.Workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// blocks returns the number of nb-sized blocks needed to cover n.
+func blocks(n, nb int) int {
+	return (n + nb - 1) / nb
+}
+
+// dgetrfTiled computes the LU decomposition of the m×n matrix A using a
+// right-looking blocked algorithm where the update of the trailing matrix is
+// split into nb×nb tiles that are processed by at most workers goroutines.
+// The panel following the current one is updated first so that its
+// factorization overlaps with the update of the rest of the trailing matrix.
+//
+// The parameters must have been checked by the caller. See Dgetrf for the
+// description of the results.
+func (impl Implementation) dgetrfTiled(m, n int, a []float64, lda int, ipiv []int, nb, workers int) (ok bool) {
+	bi := blas64.Implementation()
+	mn := min(m, n)
+
+	// factor computes the LU factorization of the panel starting at column j.
+	ok = true
+	factor := func(j int) {
+		jb := min(mn-j, nb)
+		if !impl.Dgetf2(m-j, jb, a[j*lda+j:], lda, ipiv[j:j+jb]) {
+			ok = false
+		}
+		for i := j; i < j+jb; i++ {
+			ipiv[i] += j
+		}
+	}
+	// solve applies the row interchanges from the panel starting at column
+	// j to the nc columns starting at column c and computes their block row
+	// of U.
+	solve := func(j, c, nc int) {
+		jb := min(mn-j, nb)
+		impl.Dlaswp(nc, a[c:], lda, j, j+jb-1, ipiv[:j+jb], 1)
+		bi.Dtrsm(blas.Left, blas.Lower, blas.NoTrans, blas.Unit,
+			jb, nc, 1,
+			a[j*lda+j:], lda,
+			a[j*lda+c:], lda)
+	}
+	// update updates the mr×nc block of the trailing matrix at row r and
+	// column c with the panel starting at column j.
+	update := func(j, r, c, mr, nc int) {
+		jb := min(mn-j, nb)
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, mr, nc, jb, -1,
+			a[r*lda+j:], lda,
+			a[j*lda+c:], lda,
+			1, a[r*lda+c:], lda)
+	}
+
+	factor(0)
+	for j := 0; j < mn; j += nb {
+		jb := min(mn-j, nb)
+		// Apply the interchanges to the columns left of the panel.
+		impl.Dlaswp(j, a, lda, j, j+jb-1, ipiv[:j+jb], 1)
+		next := j + jb
+		if next >= n {
+			break
+		}
+		// Update the columns of the next panel ahead of the others.
+		start := next
+		if next < mn {
+			start = next + min(mn-next, nb)
+			solve(j, next, start-next)
+			if next < m {
+				update(j, next, next, m-next, start-next)
+			}
+		}
+		// Update the remaining columns tile by tile while the next panel
+		// is factorized.
+		done := make(chan struct{})
+		go func() {
+			tw := max(1, workers-1)
+			nc := blocks(n-start, nb)
+			parallel.For(tw, nc, func(t int) {
+				c := start + t*nb
+				solve(j, c, min(nb, n-c))
+			})
+			if next < m {
+				nr := blocks(m-next, nb)
+				parallel.For(tw, nr*nc, func(t int) {
+					r := next + (t/nc)*nb
+					c := start + (t%nc)*nb
+					update(j, r, c, min(nb, m-r), min(nb, n-c))
+				})
+			}
+			close(done)
+		}()
+		if next < mn {
+			factor(next)
+		}
+		<-done
+	}
+	return ok
+}
+
+// dpotrfTiled computes the Cholesky factorization of the n×n symmetric
+// positive definite matrix A using a right-looking blocked algorithm on
+// nb×nb tiles. The triangular solves with the diagonal tile and the updates
+// of the trailing tiles are processed by at most workers goroutines.
+//
+// The parameters must have been checked by the caller. See Dpotrf for the
+// description of the results.
+func (impl Implementation) dpotrfTiled(ul blas.Uplo, n int, a []float64, lda int, nb, workers int) (ok bool) {
+	bi := blas64.Implementation()
+	for j := 0; j < n; j += nb {
+		jb := min(nb, n-j)
+		ok = impl.Dpotf2(ul, jb, a[j*lda+j:], lda)
+		if !ok {
+			return false
+		}
+		if j+jb == n {
+			break
+		}
+		rest := j + jb
+		nt := blocks(n-rest, nb)
+		if ul == blas.Upper {
+			// Compute the block row of U to the right of the diagonal tile.
+			parallel.For(workers, nt, func(t int) {
+				c := rest + t*nb
+				bi.Dtrsm(blas.Left, blas.Upper, blas.Trans, blas.NonUnit, jb, min(nb, n-c),
+					1, a[j*lda+j:], lda,
+					a[j*lda+c:], lda)
+			})
+			// Update the upper triangle of the trailing matrix.
+			parallel.For(workers, nt*(nt+1)/2, func(t int) {
+				ti, tk := triTile(t)
+				r := rest + ti*nb
+				c := rest + tk*nb
+				rb := min(nb, n-r)
+				if r == c {
+					bi.Dsyrk(blas.Upper, blas.Trans, rb, jb,
+						-1, a[j*lda+r:], lda,
+						1, a[r*lda+r:], lda)
+					return
+				}
+				bi.Dgemm(blas.Trans, blas.NoTrans, rb, min(nb, n-c), jb,
+					-1, a[j*lda+r:], lda, a[j*lda+c:], lda,
+					1, a[r*lda+c:], lda)
+			})
+			continue
+		}
+		// Compute the block column of L below the diagonal tile.
+		parallel.For(workers, nt, func(t int) {
+			r := rest + t*nb
+			bi.Dtrsm(blas.Right, blas.Lower, blas.Trans, blas.NonUnit, min(nb, n-r), jb,
+				1, a[j*lda+j:], lda,
+				a[r*lda+j:], lda)
+		})
+		// Update the lower triangle of the trailing matrix.
+		parallel.For(workers, nt*(nt+1)/2, func(t int) {
+			ti, tk := triTile(t)
+			r := rest + tk*nb
+			c := rest + ti*nb
+			rb := min(nb, n-r)
+			if r == c {
+				bi.Dsyrk(blas.Lower, blas.NoTrans, rb, jb,
+					-1, a[r*lda+j:], lda,
+					1, a[r*lda+r:], lda)
+				return
+			}
+			bi.Dgemm(blas.NoTrans, blas.Trans, rb, min(nb, n-c), jb,
+				-1, a[r*lda+j:], lda, a[c*lda+j:], lda,
+				1, a[r*lda+c:], lda)
+		})
+	}
+	return true
+}
+
+// triTile returns the indices (i, k) with i <= k of the t-th tile of an
+// upper triangular tiling enumerated column by column.
+func triTile(t int) (i, k int) {
+	for t > k {
+		k++
+		t -= k
+	}
+	return t, k
+}
+
+// dgeqrfTiled computes the QR factorization of the m×n matrix A using a
+// blocked algorithm where the application of each block reflector to the
+// trailing matrix is split into nb×nb tiles that are processed by at most
+// workers goroutines.
+//
+// work must have length at least n*nb. The parameters must have been checked
+// by the caller. See Dgeqrf for the description of the results.
+func (impl Implementation) dgeqrfTiled(m, n int, a []float64, lda int, tau, work []float64, nb, workers int) {
+	bi := blas64.Implementation()
+	k := min(m, n)
+	ldwork := nb
+	for i := 0; i < k; i += nb {
+		ib := min(k-i, nb)
+		impl.Dgeqr2(m-i, ib, a[i*lda+i:], lda, tau[i:], work)
+		rest := i + ib
+		if rest == n {
+			break
+		}
+		// Form the triangular factor T of the block reflector in the first
+		// ib rows of work. The remaining rows of work hold W for each
+		// column tile.
+		impl.Dlarft(lapack.Forward, lapack.ColumnWise, m-i, ib,
+			a[i*lda+i:], lda,
+			tau[i:],
+			work, ldwork)
+
+		// Apply Hᵀ = I - V Tᵀ Vᵀ to the trailing matrix C = [C1; C2] as
+		// in Dlarfb, where V1 and C1 are the first ib rows of V and C.
+		// The steps are split so that every BLAS call operates on a
+		// single tile.
+		v := a[i*lda+i:]
+		nc := blocks(n-rest, nb)
+		// W = (C1ᵀ V1 + C2ᵀ V2) T for each column tile.
+		parallel.For(workers, nc, func(t int) {
+			c := rest + t*nb
+			w := min(nb, n-c)
+			wrk := work[(ib+c-rest)*ldwork:]
+			for j := 0; j < ib; j++ {
+				bi.Dcopy(w, a[(i+j)*lda+c:], 1, wrk[j:], ldwork)
+			}
+			bi.Dtrmm(blas.Right, blas.Lower, blas.NoTrans, blas.Unit, w, ib,
+				1, v, lda,
+				wrk, ldwork)
+			if m-i > ib {
+				bi.Dgemm(blas.Trans, blas.NoTrans, w, ib, m-rest,
+					1, a[rest*lda+c:], lda, v[ib*lda:], lda,
+					1, wrk, ldwork)
+			}
+			bi.Dtrmm(blas.Right, blas.Upper, blas.NoTrans, blas.NonUnit, w, ib,
+				1, work, ldwork,
+				wrk, ldwork)
+		})
+		// C2 -= V2 Wᵀ tile by tile.
+		if m-i > ib {
+			nr := blocks(m-rest, nb)
+			parallel.For(workers, nr*nc, func(t int) {
+				r := rest + (t/nc)*nb
+				c := rest + (t%nc)*nb
+				bi.Dgemm(blas.NoTrans, blas.Trans, min(nb, m-r), min(nb, n-c), ib,
+					-1, a[r*lda+i:], lda, work[(ib+c-rest)*ldwork:], ldwork,
+					1, a[r*lda+c:], lda)
+			})
+		}
+		// C1 -= (W V1ᵀ)ᵀ for each column tile.
+		parallel.For(workers, nc, func(t int) {
+			c := rest + t*nb
+			w := min(nb, n-c)
+			wrk := work[(ib+c-rest)*ldwork:]
+			bi.Dtrmm(blas.Right, blas.Lower, blas.Trans, blas.Unit, w, ib,
+				1, v, lda,
+				wrk, ldwork)
+			for l := 0; l < w; l++ {
+				for j := 0; j < ib; j++ {
+					a[(i+j)*lda+c+l] -= wrk[l*ldwork+j]
+				}
+			}
+		})
+	}
+}
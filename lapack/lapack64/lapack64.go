@@ -26,6 +26,38 @@ func max(a, b int) int {
 	return b
 }
 
+// Pbtrf computes the Cholesky factorization of an n×n symmetric positive
+// definite band matrix
+//  A = Uᵀ * U  if a.Uplo == blas.Upper
+//  A = L * Lᵀ  if a.Uplo == blas.Lower
+// where U and L are upper, respectively lower, triangular band matrices.
+//
+// The triangular matrix U or L is returned in t, and the underlying data
+// between a and t is shared. The returned bool indicates whether A is positive
+// definite and the factorization could be finished.
+func Pbtrf(a blas64.SymmetricBand) (t blas64.TriangularBand, ok bool) {
+	ok = lapack64.Dpbtrf(a.Uplo, a.N, a.K, a.Data, max(1, a.Stride))
+	t.Uplo = a.Uplo
+	t.Diag = blas.NonUnit
+	t.N = a.N
+	t.K = a.K
+	t.Data = a.Data
+	t.Stride = a.Stride
+	return t, ok
+}
+
+// Pbtrs solves a system of linear equations A*X = B with an n×n symmetric
+// positive definite band matrix A using the Cholesky factorization
+//  A = Uᵀ * U  if t.Uplo == blas.Upper
+//  A = L * Lᵀ  if t.Uplo == blas.Lower
+// t contains the corresponding triangular factor as returned by Pbtrf.
+//
+// On entry, b contains the right hand side matrix B. On return, it is
+// overwritten with the solution matrix X.
+func Pbtrs(t blas64.TriangularBand, b blas64.General) {
+	lapack64.Dpbtrs(t.Uplo, t.N, t.K, b.Cols, t.Data, max(1, t.Stride), b.Data, max(1, b.Stride))
+}
+
 // Potrf computes the Cholesky factorization of a.
 // The factorization has the form
 //  A = Uᵀ * U  if a.Uplo == blas.Upper, or
@@ -167,6 +199,43 @@ func Gelqf(a blas64.General, tau, work []float64, lwork int) {
 	lapack64.Dgelqf(a.Rows, a.Cols, a.Data, max(1, a.Stride), tau, work, lwork)
 }
 
+// Gesdd computes the singular value decomposition of the input matrix A using
+// the divide and conquer method.
+//
+// The singular value decomposition is
+//  A = U * Sigma * Vᵀ
+// where Sigma is an m×n diagonal matrix containing the singular values of A,
+// U is an m×m orthogonal matrix and V is an n×n orthogonal matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// jobz specifies the singular vectors that are computed. The behavior is as
+// follows
+//  jobz == lapack.SVDAll   All m columns of U and all n rows of Vᵀ are returned in u and vt
+//  jobz == lapack.SVDStore The first min(m,n) columns of U and rows of Vᵀ are returned in u and vt
+//  jobz == lapack.SVDNone  The singular vectors are not computed.
+// Gesdd will panic if jobz == lapack.SVDOverwrite.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to Gesdd
+// the data is overwritten.
+//
+// s is a slice of length at least min(m,n) and on exit contains the singular
+// values in decreasing order.
+//
+// If jobz == lapack.SVDAll, u is of size m×m and vt is of size n×n. If
+// jobz == lapack.SVDStore, u is of size m×min(m,n) and vt is of size
+// min(m,n)×n. If jobz == lapack.SVDNone, u and vt are not used.
+//
+// work and iwork are temporary storage, and lwork is the usable length of work.
+// iwork must have length at least 8*min(m,n). If lwork == -1, instead of
+// performing Gesdd, the optimal work length will be stored into work[0].
+// Gesdd will panic if the working memory has insufficient storage.
+//
+// Gesdd returns whether the decomposition successfully completed.
+func Gesdd(jobz lapack.SVDJob, a, u, vt blas64.General, s, work []float64, lwork int, iwork []int) (ok bool) {
+	return lapack64.Dgesdd(jobz, a.Rows, a.Cols, a.Data, max(1, a.Stride), s, u.Data, max(1, u.Stride), vt.Data, max(1, vt.Stride), work, lwork, iwork)
+}
+
 // Gesvd computes the singular value decomposition of the input matrix A.
 //
 // The singular value decomposition is
@@ -474,6 +543,76 @@ func Pocon(a blas64.Symmetric, anorm float64, work []float64, iwork []int) float
 	return lapack64.Dpocon(a.Uplo, a.N, a.Data, max(1, a.Stride), anorm, work, iwork)
 }
 
+// Stedc computes all eigenvalues and optionally the eigenvectors of a
+// symmetric tridiagonal matrix using the divide and conquer method. The
+// diagonal of the matrix is stored in d and must have length n, and the
+// off-diagonal is stored in e and must have length at least n-1. On return d
+// contains the eigenvalues in ascending order and e is overwritten.
+//
+// The use of z is as for Steqr.
+//
+// work and iwork are temporary storage with usable lengths lwork and liwork.
+// If lwork == -1 or liwork == -1, instead of performing Stedc, the minimum
+// workspace lengths are stored into work[0] and iwork[0].
+//
+// Stedc returns whether the eigensystem was found successfully.
+func Stedc(compz lapack.EVComp, d, e []float64, z blas64.General, work []float64, lwork int, iwork []int, liwork int) (ok bool) {
+	return lapack64.Dstedc(compz, len(d), d, e, z.Data, max(1, z.Stride), work, lwork, iwork, liwork)
+}
+
+// Stemr computes selected eigenvalues and optionally the eigenvectors of a
+// symmetric tridiagonal matrix using the Multiple Relatively Robust
+// Representations algorithm. The diagonal of the matrix is stored in d and
+// must have length n, and the off-diagonal is stored in the first n-1 elements
+// of e, which must have length at least n. Both d and e are overwritten.
+//
+// The eigenvalues are selected by rng, vl, vu, il and iu as described for
+// lapack.Float64.Dstemr. On return the first m elements of w hold the selected
+// eigenvalues in ascending order and, if jobz == lapack.EVCompute, the first m
+// columns of z hold the corresponding eigenvectors and isuppz holds their
+// support.
+//
+// work and iwork are temporary storage with usable lengths lwork and liwork.
+// If lwork == -1 or liwork == -1, instead of performing Stemr, the minimum
+// workspace lengths are stored into work[0] and iwork[0].
+//
+// Stemr returns the number of eigenvalues found and whether the computation
+// succeeded.
+func Stemr(jobz lapack.EVJob, rng lapack.EVRange, d, e []float64, vl, vu float64, il, iu int, w []float64, z blas64.General, isuppz []int, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool) {
+	return lapack64.Dstemr(jobz, rng, len(d), d, e, vl, vu, il, iu, w, z.Data, max(1, z.Stride), isuppz, work, lwork, iwork, liwork)
+}
+
+// Steqr computes the eigenvalues and optionally the eigenvectors of a symmetric
+// tridiagonal matrix using the implicit QL or QR method. The diagonal of the
+// matrix is stored in d and must have length n, and the off-diagonal is stored
+// in e and must have length at least n-1. On return d contains the eigenvalues
+// in ascending order and e is overwritten.
+//
+// If compz == lapack.EVTridiag, z is set to the orthonormal eigenvectors of the
+// tridiagonal matrix. If compz == lapack.EVOrig, z must contain on entry the
+// orthogonal matrix used to reduce the original matrix to tridiagonal form and
+// on return z contains the eigenvectors of the original matrix. If
+// compz == lapack.EVCompNone, z is not referenced.
+//
+// work must have length at least max(1, 2*n-2) if eigenvectors are computed
+// and Steqr will panic otherwise.
+//
+// Steqr returns whether the eigensystem was found successfully.
+func Steqr(compz lapack.EVComp, d, e []float64, z blas64.General, work []float64) (ok bool) {
+	return lapack64.Dsteqr(compz, len(d), d, e, z.Data, max(1, z.Stride), work)
+}
+
+// Sterf computes all eigenvalues of a symmetric tridiagonal matrix using the
+// Pal-Walker-Kahan variant of the QL or QR algorithm. The diagonal of the
+// matrix is stored in d and must have length n, and the off-diagonal is stored
+// in e and must have length at least n-1. On return d contains the eigenvalues
+// in ascending order and e is overwritten.
+//
+// Sterf returns whether the eigenvalues were found successfully.
+func Sterf(d, e []float64) (ok bool) {
+	return lapack64.Dsterf(len(d), d, e)
+}
+
 // Syev computes all eigenvalues and, optionally, the eigenvectors of a real
 // symmetric matrix A.
 //
@@ -493,6 +632,43 @@ func Syev(jobz lapack.EVJob, a blas64.Symmetric, w, work []float64, lwork int) (
 	return lapack64.Dsyev(jobz, a.Uplo, a.N, a.Data, max(1, a.Stride), w, work, lwork)
 }
 
+// Syevd computes all eigenvalues and, optionally, the eigenvectors of a real
+// symmetric matrix A using the divide and conquer method.
+//
+// w contains the eigenvalues in ascending order upon return. w must have length
+// at least n, and Syevd will panic otherwise.
+//
+// On entry, a contains the elements of the symmetric matrix A in the triangular
+// portion specified by uplo. If jobz == lapack.EVCompute, a contains the
+// orthonormal eigenvectors of A on exit, otherwise jobz must be lapack.EVNone
+// and on exit the specified triangular region is overwritten.
+//
+// work and iwork are temporary storage with usable lengths lwork and liwork.
+// If lwork == -1 or liwork == -1, instead of computing Syevd the optimal
+// workspace lengths are stored into work[0] and iwork[0].
+func Syevd(jobz lapack.EVJob, a blas64.Symmetric, w, work []float64, lwork int, iwork []int, liwork int) (ok bool) {
+	return lapack64.Dsyevd(jobz, a.Uplo, a.N, a.Data, max(1, a.Stride), w, work, lwork, iwork, liwork)
+}
+
+// Syevr computes selected eigenvalues and, optionally, the eigenvectors of a
+// real symmetric matrix A using the Multiple Relatively Robust Representations
+// algorithm.
+//
+// The eigenvalues are selected by rng, vl, vu, il and iu as described for
+// lapack.Float64.Dstemr. On entry, a contains the elements of the symmetric
+// matrix A in the triangular portion specified by uplo, and on exit the
+// contents of a are destroyed. On return the first m elements of w hold the
+// selected eigenvalues in ascending order and, if jobz == lapack.EVCompute,
+// the first m columns of z hold the corresponding orthonormal eigenvectors.
+// w must have length at least n.
+//
+// work and iwork are temporary storage with usable lengths lwork and liwork.
+// If lwork == -1 or liwork == -1, instead of computing Syevr the optimal
+// workspace lengths are stored into work[0] and iwork[0].
+func Syevr(jobz lapack.EVJob, rng lapack.EVRange, a blas64.Symmetric, vl, vu float64, il, iu int, w []float64, z blas64.General, isuppz []int, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool) {
+	return lapack64.Dsyevr(jobz, rng, a.Uplo, a.N, a.Data, max(1, a.Stride), vl, vu, il, iu, w, z.Data, max(1, z.Stride), isuppz, work, lwork, iwork, liwork)
+}
+
 // Trcon estimates the reciprocal of the condition number of a triangular matrix A.
 // The condition number computed may be based on the 1-norm or the ∞-norm.
 //
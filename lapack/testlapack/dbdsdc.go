@@ -0,0 +1,116 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dbdsdcer interface {
+	Dbdsqrer
+	Dbdsdc(uplo blas.Uplo, compq lapack.BDComp, n int, d, e, u []float64, ldu int, vt []float64, ldvt int, work []float64, iwork []int) (ok bool)
+}
+
+func DbdsdcTest(t *testing.T, impl Dbdsdcer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 10, 25, 26, 50, 51, 100, 201} {
+		for typ := 0; typ < numTridiagTypes; typ++ {
+			for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, compq := range []lapack.BDComp{lapack.BDNone, lapack.BDCompute} {
+					dbdsdcTest(t, impl, rnd, uplo, compq, n, typ)
+				}
+			}
+		}
+	}
+}
+
+func dbdsdcTest(t *testing.T, impl Dbdsdcer, rnd *rand.Rand, uplo blas.Uplo, compq lapack.BDComp, n, typ int) {
+	const tol = 1e-13
+
+	name := fmt.Sprintf("uplo=%c,compq=%c,n=%d,type=%d", uplo, compq, n, typ)
+
+	// Use the elements of the tridiagonal test matrices as the elements of
+	// the bidiagonal matrix.
+	d, e := tridiagTestMatrix(n, typ, rnd)
+	dCopy := make([]float64, len(d))
+	copy(dCopy, d)
+	eCopy := make([]float64, len(e))
+	copy(eCopy, e)
+
+	ldu := n + 3
+	ldvt := n + 5
+	u := nanSlice(max(1, n*ldu))
+	vt := nanSlice(max(1, n*ldvt))
+	work := nanSlice(max(1, n*(4*n+9)))
+	iwork := make([]int, 8*n)
+
+	ok := impl.Dbdsdc(uplo, compq, n, d, e, u, ldu, vt, ldvt, work, iwork)
+	if !ok {
+		t.Errorf("%s: Dbdsdc failed", name)
+		return
+	}
+	if n == 0 {
+		return
+	}
+	if !sort.IsSorted(sort.Reverse(sort.Float64Slice(d))) {
+		t.Errorf("%s: singular values not sorted in decreasing order", name)
+	}
+	if floats.Min(d) < 0 {
+		t.Errorf("%s: some singular values are negative", name)
+	}
+
+	// Compare the singular values with those computed by Dbdsqr.
+	want := make([]float64, n)
+	copy(want, dCopy)
+	e2 := make([]float64, len(eCopy))
+	copy(e2, eCopy)
+	impl.Dbdsqr(uplo, n, 0, 0, 0, want, e2, nil, 1, nil, 1, nil, 1, make([]float64, 4*n))
+	bnrm := math.Max(floats.Norm(dCopy, math.Inf(1)), floats.Norm(eCopy, math.Inf(1)))
+	if diff := floats.Distance(d, want, math.Inf(1)); diff > tol*float64(n)*math.Max(1, bnrm) {
+		t.Errorf("%s: unexpected singular values; |dGot-dWant|=%v", name, diff)
+	}
+
+	if compq == lapack.BDNone {
+		return
+	}
+
+	us := blas64.General{Rows: n, Cols: n, Stride: ldu, Data: u}
+	vts := blas64.General{Rows: n, Cols: n, Stride: ldvt, Data: vt}
+	if !hasOrthonormalColumns(us) {
+		t.Errorf("%s: left singular vectors not orthonormal", name)
+	}
+	if !hasOrthonormalRows(vts) {
+		t.Errorf("%s: right singular vectors not orthonormal", name)
+	}
+
+	// Check that U * S * VT reconstructs B.
+	b := constructBidiagonal(uplo, n, dCopy, eCopy)
+	us2 := cloneGeneral(us)
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			us2.Data[i*us2.Stride+j] *= d[j]
+		}
+	}
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, us2, vts, -1, b)
+	var resid float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			resid = math.Max(resid, math.Abs(b.Data[i*b.Stride+j]))
+		}
+	}
+	if resid > tol*float64(n)*math.Max(1, bnrm) {
+		t.Errorf("%s: residual too large; |B - U*S*VT|=%v", name, resid)
+	}
+}
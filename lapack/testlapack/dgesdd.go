@@ -0,0 +1,197 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgesdder interface {
+	Dgesvder
+	Dgesdd(jobz lapack.SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ok bool)
+}
+
+func DgesddTest(t *testing.T, impl Dgesdder, tol float64) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range []int{0, 1, 2, 3, 4, 5, 10, 30, 60, 150} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 30, 60, 150} {
+			for _, mtype := range []int{1, 2, 3, 4, 5} {
+				for _, wl := range []worklen{minimumWork, optimumWork} {
+					dgesddTest(t, impl, rnd, m, n, mtype, wl, tol)
+				}
+			}
+		}
+	}
+}
+
+// dgesddTest tests a Dgesdd implementation on an m×n matrix A generated
+// according to mtype as for dgesvdTest. It computes the full SVD and checks
+// that U and Vᵀ are orthogonal, that U*Sigma*Vᵀ multiply back to A and that
+// the singular values are non-negative, sorted in decreasing order and match
+// those computed by Dgesvd. The thin SVD and the singular values alone are
+// then computed and compared with the full SVD.
+func dgesddTest(t *testing.T, impl Dgesdder, rnd *rand.Rand, m, n, mtype int, wl worklen, tol float64) {
+	lda := n + 3
+	ldu := m + 5
+	ldvt := n + 7
+	minmn := min(m, n)
+
+	a := make([]float64, m*lda)
+	var aNorm float64
+	switch mtype {
+	default:
+		panic("unknown test matrix type")
+	case 1:
+		// Zero matrix.
+		aNorm = 0
+	case 2:
+		// Identity matrix.
+		for i := 0; i < minmn; i++ {
+			a[i*lda+i] = 1
+		}
+		aNorm = 1
+	case 3, 4, 5:
+		// Random matrix with singular values spread linearly between
+		// aNorm/cond and aNorm.
+		s := make([]float64, minmn)
+		Dlatm1(s, 4, float64(max(1, minmn)), false, 1, rnd)
+		aNorm = 1
+		if mtype == 4 {
+			aNorm = dlamchS / dlamchP
+		}
+		if mtype == 5 {
+			aNorm = dlamchP / dlamchS
+		}
+		floats.Scale(aNorm, s)
+		Dlagge(m, n, max(0, m-1), max(0, n-1), s, a, lda, rnd, make([]float64, m+n))
+	}
+	aCopy := make([]float64, len(a))
+	copy(aCopy, a)
+
+	name := fmt.Sprintf("m=%v,n=%v,mtype=%v,work=%v", m, n, mtype, wl)
+
+	sddWork := func(jobz lapack.SVDJob, ldu int) []float64 {
+		work := []float64{0}
+		impl.Dgesdd(jobz, m, n, a, lda, nil, nil, ldu, nil, ldvt, work, -1, nil)
+		lwork := int(work[0])
+		if wl == minimumWork {
+			lwork = dgesddMinWork(jobz, m, n)
+		}
+		return nanSlice(lwork)
+	}
+	iwork := make([]int, 8*minmn)
+
+	// Compute the full SVD.
+	uAll := nanSlice(m * ldu)
+	vtAll := nanSlice(n * ldvt)
+	sAll := nanSlice(minmn)
+	work := sddWork(lapack.SVDAll, ldu)
+	ok := impl.Dgesdd(lapack.SVDAll, m, n, a, lda, sAll, uAll, ldu, vtAll, ldvt, work, len(work), iwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure in full SVD", name)
+		return
+	}
+	if minmn == 0 {
+		return
+	}
+	if resid := svdFullResidual(m, n, aNorm, aCopy, lda, uAll, ldu, sAll, vtAll, ldvt); resid > tol {
+		t.Errorf("%v: original matrix not recovered for full SVD, |A - U*D*VT|=%v", name, resid)
+	}
+	if !hasOrthonormalColumns(blas64.General{Rows: m, Cols: m, Data: uAll, Stride: ldu}) {
+		t.Errorf("%v: UAll is not orthogonal", name)
+	}
+	if !hasOrthonormalRows(blas64.General{Rows: n, Cols: n, Data: vtAll, Stride: ldvt}) {
+		t.Errorf("%v: VTAll is not orthogonal", name)
+	}
+	if !sort.IsSorted(sort.Reverse(sort.Float64Slice(sAll))) {
+		t.Errorf("%v: singular values from full SVD are not decreasing", name)
+	}
+	if floats.Min(sAll) < 0 {
+		t.Errorf("%v: some singular values from full SVD are negative", name)
+	}
+
+	// Compare the singular values with those computed by Dgesvd.
+	copy(a, aCopy)
+	want := nanSlice(minmn)
+	svdWork := []float64{0}
+	impl.Dgesvd(lapack.SVDNone, lapack.SVDNone, m, n, a, lda, want, nil, 1, nil, 1, svdWork, -1)
+	svdWork = nanSlice(int(svdWork[0]))
+	impl.Dgesvd(lapack.SVDNone, lapack.SVDNone, m, n, a, lda, want, nil, 1, nil, 1, svdWork, len(svdWork))
+	if !floats.EqualApprox(sAll, want, tol*math.Max(1, aNorm)) {
+		t.Errorf("%v: singular values differ from Dgesvd", name)
+	}
+
+	// Compute the thin SVD and the singular values alone and compare them
+	// with the full SVD.
+	for _, jobz := range []lapack.SVDJob{lapack.SVDStore, lapack.SVDNone} {
+		name := name + ",jobz=" + svdJobString(jobz)
+
+		copy(a, aCopy)
+		u := nanSlice(m * ldu)
+		vt := nanSlice(n * ldvt)
+		s := nanSlice(minmn)
+		work := sddWork(jobz, ldu)
+		ok := impl.Dgesdd(jobz, m, n, a, lda, s, u, ldu, vt, ldvt, work, len(work), iwork)
+		if !ok {
+			t.Errorf("%v: unexpected failure in partial SVD", name)
+			continue
+		}
+		if !floats.EqualApprox(s, sAll, tol/10*math.Max(1, aNorm)) {
+			t.Errorf("%v: singular values differ between full and partial SVD", name)
+		}
+		if jobz == lapack.SVDNone {
+			continue
+		}
+		if !hasOrthonormalColumns(blas64.General{Rows: m, Cols: minmn, Data: u, Stride: ldu}) {
+			t.Errorf("%v: columns of U are not orthogonal", name)
+		}
+		if res := svdPartialUResidual(m, minmn, u, uAll, ldu); res > tol {
+			t.Errorf("%v: columns of U do not match UAll", name)
+		}
+		if !hasOrthonormalRows(blas64.General{Rows: minmn, Cols: n, Data: vt, Stride: ldvt}) {
+			t.Errorf("%v: rows of VT are not orthogonal", name)
+		}
+		if res := svdPartialVTResidual(minmn, n, vt, vtAll, ldvt); res > tol {
+			t.Errorf("%v: rows of VT do not match VTAll", name)
+		}
+	}
+}
+
+// dgesddMinWork returns the minimum workspace length required by Dgesdd.
+func dgesddMinWork(jobz lapack.SVDJob, m, n int) int {
+	mn := min(m, n)
+	mx := max(m, n)
+	if mn == 0 {
+		return 1
+	}
+	vec := jobz != lapack.SVDNone
+	b := 4 * mn
+	if vec {
+		b = mn * (4*mn + 9)
+	}
+	ncq := mn
+	if jobz == lapack.SVDAll {
+		ncq = mx
+	}
+	switch {
+	case !vec && mx >= mn*11/6:
+		return 7 * mn
+	case !vec:
+		return 3*mn + max(mx, b)
+	case mx >= mn*11/6:
+		return 2*mn*mn + 4*mn + max(ncq, b)
+	default:
+		return 3*mn + max(mx, b)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+type Dlaed4er interface {
+	Dlaed4(n, i int, d, z, delta []float64, rho float64) (dlam float64, ok bool)
+}
+
+func Dlaed4Test(t *testing.T, impl Dlaed4er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 4, 5, 10, 50} {
+		for cas := 0; cas < 10; cas++ {
+			d := make([]float64, n)
+			for i := range d {
+				d[i] = rnd.NormFloat64()
+			}
+			sort.Float64s(d)
+			z := make([]float64, n)
+			var znorm float64
+			for i := range z {
+				z[i] = rnd.NormFloat64()
+				znorm += z[i] * z[i]
+			}
+			znorm = math.Sqrt(znorm)
+			for i := range z {
+				z[i] /= znorm
+			}
+			rho := rnd.Float64() + 0.1
+			if cas%2 == 1 {
+				rho *= 100
+			}
+
+			prev := math.Inf(-1)
+			for i := 0; i < n; i++ {
+				name := fmt.Sprintf("n=%d,case=%d,i=%d", n, cas, i)
+				delta := make([]float64, n)
+				lambda, ok := impl.Dlaed4(n, i, d, z, delta, rho)
+				if !ok {
+					t.Errorf("%s: Dlaed4 did not converge", name)
+					continue
+				}
+
+				// The eigenvalues of D + rho*z*zᵀ interlace the
+				// diagonal elements.
+				if lambda < d[i] || (i < n-1 && d[i+1] < lambda) {
+					t.Errorf("%s: eigenvalue %v not in interval", name, lambda)
+				}
+				if lambda <= prev {
+					t.Errorf("%s: eigenvalues not increasing", name)
+				}
+				prev = lambda
+
+				// Check that delta holds d - lambda.
+				for j := range delta {
+					if math.Abs(delta[j]-(d[j]-lambda)) > 1e-13*math.Max(1, math.Abs(lambda)) {
+						t.Errorf("%s: unexpected delta[%d]: got %v, want %v", name, j, delta[j], d[j]-lambda)
+						break
+					}
+				}
+
+				// Check that the secular equation is satisfied using
+				// the accurate differences in delta.
+				f := 1 / rho
+				scale := 1 / rho
+				for j := range z {
+					f += z[j] * z[j] / delta[j]
+					scale += math.Abs(z[j] * z[j] / delta[j])
+				}
+				if math.Abs(f) > 1e-12*scale {
+					t.Errorf("%s: secular equation not satisfied: f=%v", name, f)
+				}
+			}
+		}
+	}
+}
@@ -166,4 +166,77 @@ func DlarftTest(t *testing.T, impl Dlarfter) {
 			}
 		}
 	}
+	dlarftSparseTest(t, impl, rnd)
+}
+
+// dlarftSparseTest checks Dlarft with reflectors that have trailing zeros
+// of varying length, which exercises the handling of the last non-zero
+// element of previous reflectors.
+func dlarftSparseTest(t *testing.T, impl Dlarfter, rnd *rand.Rand) {
+	for _, store := range []lapack.StoreV{lapack.ColumnWise, lapack.RowWise} {
+		for _, direct := range []lapack.Direct{lapack.Forward, lapack.Backward} {
+			for _, test := range []struct {
+				m, k int
+			}{
+				{8, 6},
+				{9, 7},
+				{10, 4},
+			} {
+				m := test.m
+				k := test.k
+
+				// Generate reflectors in which all but the first are
+				// truncated to two non-zero elements below the diagonal.
+				vMatTmp := blas64.General{
+					Rows:   m,
+					Cols:   k,
+					Stride: k,
+					Data:   make([]float64, m*k),
+				}
+				for i := 0; i < m; i++ {
+					for j := 0; j < k; j++ {
+						if j == 0 || i < j+3 {
+							vMatTmp.Data[i*k+j] = rnd.NormFloat64()
+						}
+					}
+				}
+				tau := make([]float64, k)
+				for i := range tau {
+					tau[i] = rnd.Float64()
+				}
+				vMat := constructVMat(vMatTmp, store, direct)
+				h := constructH(tau, vMat, store, direct)
+
+				ldt := k
+				tm := make([]float64, k*ldt)
+				impl.Dlarft(direct, store, m, k, vMat.Data, vMat.Stride, tau, tm, ldt)
+				for i := 0; i < k; i++ {
+					for j := 0; j < k; j++ {
+						if (direct == lapack.Forward && j < i) || (direct == lapack.Backward && j > i) {
+							tm[i*ldt+j] = 0
+						}
+					}
+				}
+				tMat := blas64.General{Rows: k, Cols: k, Stride: ldt, Data: tm}
+
+				// Compute I - V * T * Vᵀ for column-wise storage and
+				// I - Vᵀ * T * V for row-wise storage.
+				tv := blas64.General{Rows: k, Cols: m, Stride: m, Data: make([]float64, k*m)}
+				comp := blas64.General{Rows: m, Cols: m, Stride: m, Data: make([]float64, m*m)}
+				for i := 0; i < m; i++ {
+					comp.Data[i*m+i] = 1
+				}
+				if store == lapack.ColumnWise {
+					blas64.Gemm(blas.NoTrans, blas.Trans, 1, tMat, vMat, 0, tv)
+					blas64.Gemm(blas.NoTrans, blas.NoTrans, -1, vMat, tv, 1, comp)
+				} else {
+					blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, tMat, vMat, 0, tv)
+					blas64.Gemm(blas.Trans, blas.NoTrans, -1, vMat, tv, 1, comp)
+				}
+				if !floats.EqualApprox(comp.Data, h.Data, 1e-14) {
+					t.Errorf("m=%d,k=%d,store=%v,direct=%v: T does not construct proper H", m, k, string(store), string(direct))
+				}
+			}
+		}
+	}
 }
@@ -0,0 +1,141 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+)
+
+type Dorm2ler interface {
+	Dgeql2er
+	Dorm2l(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64)
+}
+
+func Dorm2lTest(t *testing.T, impl Dorm2ler) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, side := range []blas.Side{blas.Left, blas.Right} {
+		for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, test := range []struct {
+				common, adim, cdim, lda, ldc int
+			}{
+				{3, 3, 5, 0, 0},
+				{4, 3, 5, 0, 0},
+				{5, 3, 4, 0, 0},
+				{5, 4, 3, 0, 0},
+				{4, 3, 5, 6, 20},
+				{5, 3, 4, 6, 20},
+				{5, 4, 3, 6, 20},
+				{4, 3, 5, 20, 6},
+				{5, 3, 4, 20, 6},
+				{5, 4, 3, 20, 6},
+			} {
+				name := fmt.Sprintf("side=%v,trans=%v,common=%v,adim=%v,cdim=%v,lda=%v,ldc=%v",
+					string(side), string(trans), test.common, test.adim, test.cdim, test.lda, test.ldc)
+				ma := test.common
+				na := test.adim
+				mc, nc := test.common, test.cdim
+				if side == blas.Right {
+					mc, nc = test.cdim, test.common
+				}
+				lda := test.lda
+				if lda == 0 {
+					lda = na
+				}
+				ldc := test.ldc
+				if ldc == 0 {
+					ldc = nc
+				}
+
+				// Compute the QL factorization of a random matrix.
+				a := make([]float64, ma*lda)
+				for i := range a {
+					a[i] = rnd.NormFloat64()
+				}
+				k := min(ma, na)
+				tau := make([]float64, k)
+				work := make([]float64, na)
+				impl.Dgeql2(ma, na, a, lda, tau, work)
+
+				// The reflectors are held in the last k columns of a.
+				q := constructQL(ma, k, a[na-k:], lda, tau)
+
+				c := make([]float64, mc*ldc)
+				for i := range c {
+					c[i] = rnd.NormFloat64()
+				}
+				cMat := blas64.General{Rows: mc, Cols: nc, Stride: ldc, Data: make([]float64, len(c))}
+				cCopy := blas64.General{Rows: mc, Cols: nc, Stride: ldc, Data: make([]float64, len(c))}
+				copy(cMat.Data, c)
+				copy(cCopy.Data, c)
+				switch {
+				case side == blas.Left && trans == blas.NoTrans:
+					blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q, cCopy, 0, cMat)
+				case side == blas.Left && trans == blas.Trans:
+					blas64.Gemm(blas.Trans, blas.NoTrans, 1, q, cCopy, 0, cMat)
+				case side == blas.Right && trans == blas.NoTrans:
+					blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, cCopy, q, 0, cMat)
+				case side == blas.Right && trans == blas.Trans:
+					blas64.Gemm(blas.NoTrans, blas.Trans, 1, cCopy, q, 0, cMat)
+				}
+
+				if side == blas.Left {
+					work = make([]float64, nc)
+				} else {
+					work = make([]float64, mc)
+				}
+				aCopy := make([]float64, len(a))
+				copy(aCopy, a)
+				impl.Dorm2l(side, trans, mc, nc, k, a[na-k:], lda, tau, c, ldc, work)
+				if !floats.Equal(a, aCopy) {
+					t.Errorf("%v: a changed in call", name)
+				}
+				if !floats.EqualApprox(cMat.Data, c, 1e-14) {
+					t.Errorf("%v: multiplication mismatch", name)
+				}
+			}
+		}
+	}
+}
+
+// constructQL returns the m×m orthogonal matrix
+//  Q = H_{k-1} * ... * H_1 * H_0
+// where the elementary reflectors H_i are stored in the columns of the m×k
+// matrix a as returned by Dgeql2 and tau holds their scalar factors.
+func constructQL(m, k int, a []float64, lda int, tau []float64) blas64.General {
+	q := eye(m, m)
+	qCopy := eye(m, m)
+	h := eye(m, m)
+	v := blas64.Vector{N: m, Data: make([]float64, m), Inc: 1}
+	for i := 0; i < k; i++ {
+		// v[m-k+i] = 1 and v[m-k+i+1:m] = 0.
+		for j := range v.Data {
+			v.Data[j] = 0
+		}
+		for j := 0; j < m-k+i; j++ {
+			v.Data[j] = a[j*lda+i]
+		}
+		v.Data[m-k+i] = 1
+
+		for j := range h.Data {
+			h.Data[j] = 0
+		}
+		for j := 0; j < m; j++ {
+			h.Data[j*h.Stride+j] = 1
+		}
+		blas64.Ger(-tau[i], v, v, h)
+
+		// Q = H_i * Q.
+		copy(qCopy.Data, q.Data)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, h, qCopy, 0, q)
+	}
+	return q
+}
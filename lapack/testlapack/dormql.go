@@ -0,0 +1,118 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/floats"
+)
+
+type Dormqler interface {
+	Dorm2ler
+	Dormql(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int)
+}
+
+func DormqlTest(t *testing.T, impl Dormqler) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, side := range []blas.Side{blas.Left, blas.Right} {
+		for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, test := range []struct {
+				common, adim, cdim, lda, ldc int
+			}{
+				{6, 6, 8, 0, 0},
+				{7, 6, 8, 0, 0},
+				{8, 6, 7, 0, 0},
+				{8, 7, 6, 0, 0},
+				{100, 100, 300, 0, 0},
+				{200, 100, 300, 0, 0},
+				{300, 100, 200, 0, 0},
+				{300, 200, 100, 0, 0},
+				{200, 100, 300, 400, 500},
+				{300, 200, 100, 400, 500},
+				{200, 100, 300, 500, 400},
+				{300, 200, 100, 500, 400},
+			} {
+				name := fmt.Sprintf("side=%v,trans=%v,common=%v,adim=%v,cdim=%v,lda=%v,ldc=%v",
+					string(side), string(trans), test.common, test.adim, test.cdim, test.lda, test.ldc)
+				ma := test.common
+				na := test.adim
+				mc, nc := test.common, test.cdim
+				if side == blas.Right {
+					mc, nc = test.cdim, test.common
+				}
+				lda := test.lda
+				if lda == 0 {
+					lda = na
+				}
+				ldc := test.ldc
+				if ldc == 0 {
+					ldc = nc
+				}
+
+				// Compute the QL factorization of a random matrix.
+				a := make([]float64, ma*lda)
+				for i := range a {
+					a[i] = rnd.NormFloat64()
+				}
+				k := min(ma, na)
+				tau := make([]float64, k)
+				work := make([]float64, na)
+				impl.Dgeql2(ma, na, a, lda, tau, work)
+				aq := a[na-k:]
+
+				c := make([]float64, mc*ldc)
+				for i := range c {
+					c[i] = rnd.NormFloat64()
+				}
+				cCopy := make([]float64, len(c))
+				copy(cCopy, c)
+
+				// Compute the reference result using the unblocked code.
+				want := make([]float64, len(c))
+				copy(want, c)
+				nw := nc
+				if side == blas.Right {
+					nw = mc
+				}
+				work = make([]float64, nw)
+				impl.Dorm2l(side, trans, mc, nc, k, aq, lda, tau, want, ldc, work)
+
+				// Minimum work.
+				for i := range work {
+					work[i] = rnd.Float64()
+				}
+				impl.Dormql(side, trans, mc, nc, k, aq, lda, tau, c, ldc, work, len(work))
+				if !floats.EqualApprox(c, want, 1e-12) {
+					t.Errorf("%v: Dormql and Dorm2l mismatch for minimum work", name)
+				}
+
+				// Optimum work.
+				copy(c, cCopy)
+				impl.Dormql(side, trans, mc, nc, k, aq, lda, tau, c, ldc, work, -1)
+				work = make([]float64, int(work[0]))
+				for i := range work {
+					work[i] = rnd.Float64()
+				}
+				impl.Dormql(side, trans, mc, nc, k, aq, lda, tau, c, ldc, work, len(work))
+				if !floats.EqualApprox(c, want, 1e-12) {
+					t.Errorf("%v: Dormql and Dorm2l mismatch for optimum work", name)
+				}
+
+				// Less than optimum work that still uses the blocked code.
+				copy(c, cCopy)
+				work = make([]float64, 64*64+3*nw)
+				impl.Dormql(side, trans, mc, nc, k, aq, lda, tau, c, ldc, work, len(work))
+				if !floats.EqualApprox(c, want, 1e-12) {
+					t.Errorf("%v: Dormql and Dorm2l mismatch for medium work", name)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dormtrer interface {
+	Dorgtrer
+	Dormtr(side blas.Side, uplo blas.Uplo, trans blas.Transpose, m, n int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int)
+}
+
+func DormtrTest(t *testing.T, impl Dormtrer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, side := range []blas.Side{blas.Left, blas.Right} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, test := range []struct {
+					nq, nc, lda, ldcExtra int
+				}{
+					{1, 3, 0, 0},
+					{2, 3, 0, 0},
+					{5, 1, 0, 0},
+					{5, 7, 0, 0},
+					{10, 4, 12, 3},
+					{100, 30, 0, 0},
+					{150, 80, 160, 7},
+				} {
+					dormtrTest(t, impl, rnd, side, uplo, trans, test.nq, test.nc, test.lda, test.ldcExtra)
+				}
+			}
+		}
+	}
+}
+
+func dormtrTest(t *testing.T, impl Dormtrer, rnd *rand.Rand, side blas.Side, uplo blas.Uplo, trans blas.Transpose, nq, nc, lda, ldcExtra int) {
+	const tol = 1e-12
+
+	m, n := nq, nc
+	if side == blas.Right {
+		m, n = nc, nq
+	}
+	if lda == 0 {
+		lda = nq
+	}
+	ldc := n + ldcExtra
+	name := fmt.Sprintf("side=%v,uplo=%v,trans=%v,nq=%v,nc=%v,lda=%v,ldc=%v",
+		string(side), string(uplo), string(trans), nq, nc, lda, ldc)
+
+	// Reduce a random symmetric matrix to tridiagonal form.
+	a := randomGeneral(nq, nq, lda, rnd)
+	d := make([]float64, nq)
+	e := make([]float64, max(0, nq-1))
+	tau := make([]float64, max(0, nq-1))
+	work := []float64{0}
+	impl.Dsytrd(uplo, nq, a.Data, lda, d, e, tau, work, -1)
+	work = make([]float64, int(work[0]))
+	impl.Dsytrd(uplo, nq, a.Data, lda, d, e, tau, work, len(work))
+
+	// Generate Q explicitly.
+	q := cloneGeneral(a)
+	q.Cols = nq
+	impl.Dorgtr(uplo, nq, q.Data, q.Stride, tau, work, -1)
+	work = make([]float64, int(work[0]))
+	impl.Dorgtr(uplo, nq, q.Data, q.Stride, tau, work, len(work))
+
+	c := randomGeneral(m, n, ldc, rnd)
+	want := zeros(m, n, ldc)
+	switch {
+	case side == blas.Left && trans == blas.NoTrans:
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q, c, 0, want)
+	case side == blas.Left && trans == blas.Trans:
+		blas64.Gemm(blas.Trans, blas.NoTrans, 1, q, c, 0, want)
+	case side == blas.Right && trans == blas.NoTrans:
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, c, q, 0, want)
+	case side == blas.Right && trans == blas.Trans:
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, c, q, 0, want)
+	}
+
+	nw := n
+	if side == blas.Right {
+		nw = m
+	}
+	for _, wl := range []worklen{minimumWork, mediumWork, optimumWork} {
+		var lwork int
+		switch wl {
+		case minimumWork:
+			lwork = max(1, nw)
+		case mediumWork:
+			work := []float64{0}
+			impl.Dormtr(side, uplo, trans, m, n, a.Data, lda, tau, c.Data, ldc, work, -1)
+			lwork = max(max(1, nw), (int(work[0])+nw)/2)
+		case optimumWork:
+			work := []float64{0}
+			impl.Dormtr(side, uplo, trans, m, n, a.Data, lda, tau, c.Data, ldc, work, -1)
+			lwork = int(work[0])
+		}
+		work = make([]float64, max(1, lwork))
+
+		got := cloneGeneral(c)
+		impl.Dormtr(side, uplo, trans, m, n, a.Data, lda, tau, got.Data, ldc, work, lwork)
+		if !equalApproxGeneral(got, want, tol) {
+			t.Errorf("%v,wl=%v: unexpected result", name, wl)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dstedcer interface {
+	Dorgtrer
+	Dsteqr(compz lapack.EVComp, n int, d, e, z []float64, ldz int, work []float64) (ok bool)
+	Dstedc(compz lapack.EVComp, n int, d, e, z []float64, ldz int, work []float64, lwork int, iwork []int, liwork int) (ok bool)
+}
+
+func DstedcTest(t *testing.T, impl Dstedcer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 10, 25, 26, 50, 51, 100, 201} {
+		for typ := 0; typ < numTridiagTypes; typ++ {
+			for _, compz := range []lapack.EVComp{lapack.EVCompNone, lapack.EVTridiag, lapack.EVOrig} {
+				for _, wl := range []worklen{minimumWork, optimumWork} {
+					dstedcTest(t, impl, rnd, compz, n, typ, wl)
+				}
+			}
+		}
+	}
+}
+
+func dstedcTest(t *testing.T, impl Dstedcer, rnd *rand.Rand, compz lapack.EVComp, n, typ int, wl worklen) {
+	const tol = 1e-13
+
+	name := fmt.Sprintf("compz=%c,n=%d,type=%d,work=%v", compz, n, typ, wl)
+
+	d, e := tridiagTestMatrix(n, typ, rnd)
+	dCopy := make([]float64, len(d))
+	copy(dCopy, d)
+	eCopy := make([]float64, len(e))
+	copy(eCopy, e)
+
+	// For lapack.EVOrig, form a random symmetric matrix and its tridiagonal
+	// reduction, and keep the orthogonal matrix of the reduction in z.
+	var a blas64.General
+	z := nanGeneral(n, n, n+3)
+	if compz == lapack.EVOrig && n > 0 {
+		a = randomGeneral(n, n, n, rnd)
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				a.Data[j*a.Stride+i] = a.Data[i*a.Stride+j]
+			}
+		}
+		copyGeneral(z, a)
+		tau := make([]float64, n)
+		work := make([]float64, 1)
+		impl.Dsytrd(blas.Lower, n, z.Data, z.Stride, d, e, tau, work, -1)
+		work = make([]float64, int(work[0]))
+		impl.Dsytrd(blas.Lower, n, z.Data, z.Stride, d, e, tau, work, len(work))
+		impl.Dorgtr(blas.Lower, n, z.Data, z.Stride, tau, work, len(work))
+		copy(dCopy, d)
+		copy(eCopy, e)
+	}
+
+	work := make([]float64, 1)
+	iwork := make([]int, 1)
+	impl.Dstedc(compz, n, d, e, z.Data, z.Stride, work, -1, iwork, -1)
+	lwork := int(work[0])
+	liwork := iwork[0]
+	if wl == optimumWork {
+		lwork++
+		liwork++
+	}
+	work = nanSlice(lwork)
+	iwork = make([]int, liwork)
+
+	ok := impl.Dstedc(compz, n, d, e, z.Data, z.Stride, work, lwork, iwork, liwork)
+	if !ok {
+		t.Errorf("%s: Dstedc failed", name)
+		return
+	}
+	if n == 0 {
+		return
+	}
+	if !sort.Float64sAreSorted(d) {
+		t.Errorf("%s: eigenvalues not sorted", name)
+	}
+
+	// Compare the eigenvalues with those computed by Dsteqr.
+	want := make([]float64, n)
+	copy(want, dCopy)
+	e2 := make([]float64, len(eCopy))
+	copy(e2, eCopy)
+	impl.Dsteqr(lapack.EVCompNone, n, want, e2, nil, 1, nil)
+	tnrm := tridiagNorm(dCopy, eCopy)
+	if diff := floats.Distance(d, want, math.Inf(1)); diff > tol*float64(n)*math.Max(1, tnrm) {
+		t.Errorf("%s: unexpected eigenvalues; |dGot-dWant|=%v", name, diff)
+	}
+
+	switch compz {
+	case lapack.EVTridiag:
+		zs := blas64.General{Rows: n, Cols: n, Stride: z.Stride, Data: z.Data}
+		resid, orth := tridiagEigenResiduals(dCopy, eCopy, d, zs)
+		if resid > tol*float64(n)*math.Max(1, tnrm) {
+			t.Errorf("%s: residual too large; |T*Z - Z*W|=%v", name, resid)
+		}
+		if orth > tol*float64(n) {
+			t.Errorf("%s: eigenvectors not orthonormal; |Zᵀ*Z - I|=%v", name, orth)
+		}
+	case lapack.EVOrig:
+		zs := blas64.General{Rows: n, Cols: n, Stride: z.Stride, Data: z.Data}
+		if !hasOrthonormalColumns(zs) {
+			t.Errorf("%s: eigenvectors not orthonormal", name)
+		}
+		if !eigenDecompCorrect(d, a, zs) {
+			t.Errorf("%s: eigendecomposition of A not correct", name)
+		}
+	}
+}
+
+// numTridiagTypes is the number of kinds of test matrices generated by
+// tridiagTestMatrix.
+const numTridiagTypes = 7
+
+// tridiagTestMatrix returns the diagonal and off-diagonal elements of an n×n
+// symmetric tridiagonal test matrix of the given type.
+func tridiagTestMatrix(n, typ int, rnd *rand.Rand) (d, e []float64) {
+	d = make([]float64, n)
+	e = make([]float64, max(0, n-1))
+	switch typ {
+	case 0:
+		// Random symmetric tridiagonal matrix.
+		for i := range d {
+			d[i] = rnd.NormFloat64()
+		}
+		for i := range e {
+			e[i] = rnd.NormFloat64()
+		}
+	case 1:
+		// The 1-2-1 matrix with known eigenvalues.
+		for i := range d {
+			d[i] = 2
+		}
+		for i := range e {
+			e[i] = -1
+		}
+	case 2:
+		// The Wilkinson matrix W+, which has pairs of very close
+		// eigenvalues.
+		for i := range d {
+			d[i] = math.Abs(float64(i) - float64(n-1)/2)
+		}
+		for i := range e {
+			e[i] = 1
+		}
+	case 3:
+		// A matrix with tiny off-diagonal elements that splits into
+		// independent blocks.
+		for i := range d {
+			d[i] = rnd.NormFloat64()
+		}
+		for i := range e {
+			if i%7 == 3 {
+				e[i] = 1e-20
+			} else {
+				e[i] = rnd.NormFloat64()
+			}
+		}
+	case 4:
+		// A graded matrix.
+		for i := range d {
+			d[i] = math.Pow(10, -float64(i%16))
+		}
+		for i := range e {
+			e[i] = math.Sqrt(d[i] * d[i+1])
+		}
+	case 5:
+		// A matrix with many equal diagonal elements.
+		for i := range d {
+			d[i] = 1
+		}
+		for i := range e {
+			e[i] = rnd.Float64() * 1e-3
+		}
+	case 6:
+		// The zero matrix.
+	}
+	return d, e
+}
+
+// tridiagNorm returns the maximum absolute row sum of the symmetric
+// tridiagonal matrix with diagonal d and off-diagonal e.
+func tridiagNorm(d, e []float64) float64 {
+	var nrm float64
+	for i := range d {
+		s := math.Abs(d[i])
+		if i > 0 {
+			s += math.Abs(e[i-1])
+		}
+		if i < len(e) {
+			s += math.Abs(e[i])
+		}
+		nrm = math.Max(nrm, s)
+	}
+	return nrm
+}
+
+// tridiagEigenResiduals returns the maximum residual |T*z_j - w_j*z_j| over the
+// columns of z and the maximum deviation of Zᵀ*Z from the identity for the
+// symmetric tridiagonal matrix T with diagonal d and off-diagonal e.
+func tridiagEigenResiduals(d, e, w []float64, z blas64.General) (resid, orth float64) {
+	n, m := z.Rows, z.Cols
+	for j := 0; j < m; j++ {
+		var r float64
+		for i := 0; i < n; i++ {
+			v := (d[i] - w[j]) * z.Data[i*z.Stride+j]
+			if i > 0 {
+				v += e[i-1] * z.Data[(i-1)*z.Stride+j]
+			}
+			if i < n-1 {
+				v += e[i] * z.Data[(i+1)*z.Stride+j]
+			}
+			r += v * v
+		}
+		resid = math.Max(resid, math.Sqrt(r))
+	}
+	ztz := blas64.General{Rows: m, Cols: m, Stride: m, Data: make([]float64, m*m)}
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, z, z, 0, ztz)
+	for i := 0; i < m; i++ {
+		ztz.Data[i*m+i] -= 1
+	}
+	for _, v := range ztz.Data {
+		orth = math.Max(orth, math.Abs(v))
+	}
+	return resid, orth
+}
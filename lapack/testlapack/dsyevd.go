@@ -0,0 +1,99 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dsyevder interface {
+	Dsyev(jobz lapack.EVJob, uplo blas.Uplo, n int, a []float64, lda int, w, work []float64, lwork int) (ok bool)
+	Dsyevd(jobz lapack.EVJob, uplo blas.Uplo, n int, a []float64, lda int, w, work []float64, lwork int, iwork []int, liwork int) (ok bool)
+}
+
+func DsyevdTest(t *testing.T, impl Dsyevder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Lower, blas.Upper} {
+		for _, n := range []int{0, 1, 2, 5, 10, 30, 100} {
+			for _, lda := range []int{n, n + 5} {
+				for cas := 0; cas < 3; cas++ {
+					dsyevdTest(t, impl, rnd, uplo, n, max(1, lda))
+				}
+			}
+		}
+	}
+}
+
+func dsyevdTest(t *testing.T, impl Dsyevder, rnd *rand.Rand, uplo blas.Uplo, n, lda int) {
+	name := fmt.Sprintf("uplo=%c,n=%d,lda=%d", uplo, n, lda)
+
+	a := randomSymmetric(n, lda, rnd)
+	aCopy := make([]float64, len(a))
+	copy(aCopy, a)
+
+	w := nanSlice(n)
+	work := make([]float64, 1)
+	iwork := make([]int, 1)
+	impl.Dsyevd(lapack.EVCompute, uplo, n, a, lda, w, work, -1, iwork, -1)
+	work = nanSlice(int(work[0]))
+	iwork = make([]int, iwork[0])
+	ok := impl.Dsyevd(lapack.EVCompute, uplo, n, a, lda, w, work, len(work), iwork, len(iwork))
+	if !ok {
+		t.Errorf("%s: Dsyevd failed", name)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	orig := blas64.General{Rows: n, Cols: n, Stride: lda, Data: aCopy}
+	v := blas64.General{Rows: n, Cols: n, Stride: lda, Data: a}
+	if !hasOrthonormalColumns(v) {
+		t.Errorf("%s: eigenvectors not orthonormal", name)
+	}
+	if !eigenDecompCorrect(w, orig, v) {
+		t.Errorf("%s: decomposition mismatch", name)
+	}
+
+	// Compare the eigenvalues with those computed by Dsyev, and with those
+	// computed by Dsyevd when the eigenvectors are not wanted.
+	want := make([]float64, n)
+	copy(a, aCopy)
+	swork := make([]float64, 1)
+	impl.Dsyev(lapack.EVNone, uplo, n, a, lda, want, swork, -1)
+	swork = make([]float64, max(1, int(swork[0])))
+	impl.Dsyev(lapack.EVNone, uplo, n, a, lda, want, swork, len(swork))
+	if !floats.EqualApprox(w, want, 1e-12) {
+		t.Errorf("%s: eigenvalue mismatch with Dsyev", name)
+	}
+
+	copy(a, aCopy)
+	wNone := nanSlice(n)
+	impl.Dsyevd(lapack.EVNone, uplo, n, a, lda, wNone, work, len(work), iwork, len(iwork))
+	if !floats.EqualApprox(wNone, want, 1e-12) {
+		t.Errorf("%s: eigenvalue mismatch when vectors not computed", name)
+	}
+}
+
+// randomSymmetric returns a random symmetric n×n matrix with stride lda.
+func randomSymmetric(n, lda int, rnd *rand.Rand) []float64 {
+	a := make([]float64, max(0, (n-1)*lda+n))
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			v := rnd.NormFloat64()
+			a[i*lda+j] = v
+			a[j*lda+i] = v
+		}
+	}
+	return a
+}
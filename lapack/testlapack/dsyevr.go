@@ -0,0 +1,141 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dsyevrer interface {
+	Dsyev(jobz lapack.EVJob, uplo blas.Uplo, n int, a []float64, lda int, w, work []float64, lwork int) (ok bool)
+	Dsyevr(jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, w, z []float64, ldz int, isuppz []int, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool)
+}
+
+func DsyevrTest(t *testing.T, impl Dsyevrer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Lower, blas.Upper} {
+		for _, n := range []int{0, 1, 2, 5, 10, 30, 100} {
+			for _, rng := range []lapack.EVRange{lapack.EVRangeAll, lapack.EVRangeValue, lapack.EVRangeIndex} {
+				for _, jobz := range []lapack.EVJob{lapack.EVNone, lapack.EVCompute} {
+					for _, lda := range []int{n, n + 5} {
+						dsyevrTest(t, impl, rnd, jobz, rng, uplo, n, max(1, lda))
+					}
+				}
+			}
+		}
+	}
+}
+
+func dsyevrTest(t *testing.T, impl Dsyevrer, rnd *rand.Rand, jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n, lda int) {
+	a := randomSymmetric(n, lda, rnd)
+	aCopy := make([]float64, len(a))
+	copy(aCopy, a)
+
+	// Compute the reference eigenvalues.
+	all := make([]float64, n)
+	swork := make([]float64, 1)
+	impl.Dsyev(lapack.EVNone, uplo, n, a, lda, all, swork, -1)
+	swork = make([]float64, max(1, int(swork[0])))
+	impl.Dsyev(lapack.EVNone, uplo, n, a, lda, all, swork, len(swork))
+	copy(a, aCopy)
+
+	var vl, vu float64
+	var il, iu int
+	want := all
+	switch rng {
+	case lapack.EVRangeIndex:
+		iu = -1
+		if n > 0 {
+			il = rnd.Intn(n)
+			iu = il + rnd.Intn(n-il)
+		}
+		want = all[il : iu+1]
+	case lapack.EVRangeValue:
+		vl, vu = -1, 1
+		if n > 0 {
+			lo := rnd.Intn(n + 1)
+			hi := lo + rnd.Intn(n+1-lo)
+			vl = intervalEnd(all, lo)
+			vu = intervalEnd(all, hi)
+			if vl >= vu {
+				vu = vl + 1
+			}
+		}
+		want = nil
+		for _, v := range all {
+			if vl < v && v <= vu {
+				want = append(want, v)
+			}
+		}
+	}
+
+	name := fmt.Sprintf("jobz=%c,range=%c,uplo=%c,n=%d,lda=%d,vl=%v,vu=%v,il=%d,iu=%d", jobz, rng, uplo, n, lda, vl, vu, il, iu)
+
+	ldz := max(1, n+3)
+	work := make([]float64, 1)
+	iwork := make([]int, 1)
+	impl.Dsyevr(jobz, rng, uplo, n, a, lda, vl, vu, il, iu, nil, nil, ldz, nil, work, -1, iwork, -1)
+	work = nanSlice(int(work[0]))
+	iwork = make([]int, iwork[0])
+
+	w := nanSlice(n)
+	z := nanSlice(max(1, n*ldz))
+	isuppz := make([]int, 2*max(1, n))
+	m, ok := impl.Dsyevr(jobz, rng, uplo, n, a, lda, vl, vu, il, iu, w, z, ldz, isuppz, work, len(work), iwork, len(iwork))
+	if !ok {
+		t.Errorf("%s: Dsyevr failed", name)
+		return
+	}
+	if m != len(want) {
+		t.Errorf("%s: unexpected number of eigenvalues: got %d, want %d", name, m, len(want))
+		return
+	}
+	if m == 0 {
+		return
+	}
+	if !floats.EqualApprox(w[:m], want, 1e-12) {
+		t.Errorf("%s: unexpected eigenvalues: got %v, want %v", name, w[:m], want)
+	}
+	if jobz == lapack.EVNone {
+		return
+	}
+
+	// The orthogonality of the eigenvectors computed by the MRRR algorithm
+	// degrades linearly with n.
+	zs := blas64.General{Rows: n, Cols: m, Stride: ldz, Data: z}
+	ztz := blas64.General{Rows: m, Cols: m, Stride: m, Data: make([]float64, m*m)}
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, zs, zs, 0, ztz)
+	var orth float64
+	for i := 0; i < m; i++ {
+		ztz.Data[i*m+i] -= 1
+		for j := 0; j < m; j++ {
+			orth = math.Max(orth, math.Abs(ztz.Data[i*m+j]))
+		}
+	}
+	if orth > 1e-13*float64(n) {
+		t.Errorf("%s: eigenvectors not orthonormal; |Zᵀ*Z - I|=%v", name, orth)
+	}
+	orig := blas64.General{Rows: n, Cols: n, Stride: lda, Data: aCopy}
+	az := blas64.General{Rows: n, Cols: m, Stride: m, Data: make([]float64, n*m)}
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, orig, zs, 0, az)
+	var resid float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			resid = math.Max(resid, math.Abs(az.Data[i*m+j]-w[j]*z[i*ldz+j]))
+		}
+	}
+	if resid > 1e-12*float64(n) {
+		t.Errorf("%s: residual too large; |A*Z - Z*W|=%v", name, resid)
+	}
+}
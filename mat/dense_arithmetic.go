@@ -9,6 +9,7 @@ import (
 
 	"gonum.org/v1/gonum/blas"
 	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/internal/asm/f64"
 	"gonum.org/v1/gonum/lapack/lapack64"
 )
 
@@ -210,6 +211,114 @@ func (m *Dense) DivElem(a, b Matrix) {
 	}
 }
 
+// AddAxis adds the vector v to each row or column of a, placing the result
+// in the receiver. If axis is ByRow, the ith element of v is added to each
+// element of the ith row of a, and v must have length equal to the number
+// of rows of a. If axis is ByCol, the jth element of v is added to each
+// element of the jth column of a, and v must have length equal to the number
+// of columns of a. AddAxis will panic if v does not have the required length.
+func (m *Dense) AddAxis(a Matrix, v Vector, axis Axis) {
+	m.broadcast(a, v, axis,
+		func(dst, x []float64, s float64) {
+			copy(dst, x)
+			f64.AddConst(s, dst)
+		},
+		func(dst, x, y []float64) { f64.AxpyUnitaryTo(dst, 1, y, x) },
+	)
+}
+
+// SubAxis subtracts the vector v from each row or column of a, placing the
+// result in the receiver. The length requirements for v are as for AddAxis.
+func (m *Dense) SubAxis(a Matrix, v Vector, axis Axis) {
+	m.broadcast(a, v, axis,
+		func(dst, x []float64, s float64) {
+			copy(dst, x)
+			f64.AddConst(-s, dst)
+		},
+		func(dst, x, y []float64) { f64.AxpyUnitaryTo(dst, -1, y, x) },
+	)
+}
+
+// MulElemAxis performs element-wise multiplication of each row or column of a
+// by the vector v, placing the result in the receiver. The length requirements
+// for v are as for AddAxis.
+func (m *Dense) MulElemAxis(a Matrix, v Vector, axis Axis) {
+	m.broadcast(a, v, axis,
+		func(dst, x []float64, s float64) { f64.ScalUnitaryTo(dst, s, x) },
+		func(dst, x, y []float64) {
+			for i, v := range x {
+				dst[i] = v * y[i]
+			}
+		},
+	)
+}
+
+// DivElemAxis performs element-wise division of each row or column of a
+// by the vector v, placing the result in the receiver. The length requirements
+// for v are as for AddAxis.
+func (m *Dense) DivElemAxis(a Matrix, v Vector, axis Axis) {
+	m.broadcast(a, v, axis,
+		func(dst, x []float64, s float64) {
+			for i, v := range x {
+				dst[i] = v / s
+			}
+		},
+		func(dst, x, y []float64) { f64.DivTo(dst, x, y) },
+	)
+}
+
+// broadcast applies a binary operation between the elements of a and the
+// vector v broadcast along axis, placing the result in the receiver. The
+// function scalar is called for each row of the result when v is broadcast
+// by row, and the function vector is called for each row of the result when
+// v is broadcast by column.
+func (m *Dense) broadcast(a Matrix, v Vector, axis Axis, scalar func(dst, x []float64, s float64), vector func(dst, x, y []float64)) {
+	r, c := a.Dims()
+	if v.Len() != axisLen(r, c, axis) {
+		panic(ErrShape)
+	}
+
+	// Take a copy of v so that it may alias the receiver.
+	vec := getFloats(v.Len(), false)
+	defer putFloats(vec)
+	if rv, ok := v.(RawVectorer); ok {
+		blas64.Copy(rv.RawVector(), blas64.Vector{N: len(vec), Inc: 1, Data: vec})
+	} else {
+		for i := range vec {
+			vec[i] = v.AtVec(i)
+		}
+	}
+
+	aU, aTrans := untransposeExtract(a)
+	m.reuseAsNonZeroed(r, c)
+	var amat blas64.General
+	if arm, ok := aU.(*Dense); ok && !aTrans {
+		amat = arm.mat
+		if m != aU {
+			m.checkOverlap(amat)
+		}
+	} else {
+		m.checkOverlapMatrix(aU)
+		if m == aU {
+			var restore func()
+			m, restore = m.isolatedWorkspace(aU)
+			defer restore()
+		}
+		m.Copy(a)
+		amat = m.mat
+	}
+
+	for i := 0; i < r; i++ {
+		dst := m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+c]
+		x := amat.Data[i*amat.Stride : i*amat.Stride+c]
+		if axis == ByRow {
+			scalar(dst, x, vec[i])
+		} else {
+			vector(dst, x, vec)
+		}
+	}
+}
+
 // Inverse computes the inverse of the matrix a, storing the result into the
 // receiver. If a is ill-conditioned, a Condition error will be returned.
 // Note that matrix inversion is numerically unstable, and should generally
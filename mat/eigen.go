@@ -5,15 +5,28 @@
 package mat
 
 import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/blas/cblas128"
 	"gonum.org/v1/gonum/lapack"
 	"gonum.org/v1/gonum/lapack/lapack64"
 )
 
 const (
-	badFact   = "mat: use without successful factorization"
-	noVectors = "mat: eigenvectors not computed"
+	badFact     = "mat: use without successful factorization"
+	noVectors   = "mat: eigenvectors not computed"
+	badInterval = "mat: empty eigenvalue interval"
 )
 
+// eigenSymDCMin is the smallest order of matrix for which EigenSym.Factorize
+// uses the divide and conquer method when eigenvectors are requested. Below
+// this size the QR iteration is competitive and needs much less workspace.
+// It is a variable so that tests can exercise both paths.
+var eigenSymDCMin = 64
+
 // EigenSym is a type for creating and manipulating the Eigen decomposition of
 // symmetric matrices.
 type EigenSym struct {
@@ -31,12 +44,17 @@ type EigenSym struct {
 // in ascending order. If the vectors input argument is false, the eigenvectors
 // are not computed.
 //
+// When eigenvectors are requested for larger matrices, the decomposition is
+// computed using the divide and conquer method, otherwise the implicit QL or
+// QR method is used.
+//
 // Factorize returns whether the decomposition succeeded. If the decomposition
 // failed, methods that require a successful factorization will panic.
 func (e *EigenSym) Factorize(a Symmetric, vectors bool) (ok bool) {
 	// kill previous decomposition
 	e.vectorsComputed = false
-	e.values = e.values[:]
+	e.values = nil
+	e.vectors = nil
 
 	n := a.Symmetric()
 	sd := NewSymDense(n, nil)
@@ -47,27 +65,271 @@ func (e *EigenSym) Factorize(a Symmetric, vectors bool) (ok bool) {
 		jobz = lapack.EVCompute
 	}
 	w := make([]float64, n)
+	if vectors && n >= eigenSymDCMin {
+		work := []float64{0}
+		iwork := []int{0}
+		lapack64.Syevd(jobz, sd.mat, w, work, -1, iwork, -1)
+
+		work = getFloats(int(work[0]), false)
+		iwork = getInts(iwork[0], false)
+		ok = lapack64.Syevd(jobz, sd.mat, w, work, len(work), iwork, len(iwork))
+		putFloats(work)
+		putInts(iwork)
+	} else {
+		work := []float64{0}
+		lapack64.Syev(jobz, sd.mat, w, work, -1)
+
+		work = getFloats(int(work[0]), false)
+		ok = lapack64.Syev(jobz, sd.mat, w, work, len(work))
+		putFloats(work)
+	}
+	if !ok {
+		return false
+	}
+	e.vectorsComputed = vectors
+	e.values = w
+	e.vectors = NewDense(n, n, sd.mat.Data)
+	return true
+}
+
+// FactorizeIndex computes a partial eigenvalue decomposition of the symmetric
+// matrix a, finding the eigenvalues with zero-based indices il through iu
+// inclusive, in ascending order, and if vectors is true the corresponding
+// eigenvectors. The indices must satisfy 0 <= il <= iu < n, and
+// FactorizeIndex will panic otherwise.
+//
+// The decomposition is computed using the Multiple Relatively Robust
+// Representations algorithm, which is efficient when only a few eigenpairs are
+// wanted. After a successful factorization, Values returns the iu-il+1
+// selected eigenvalues and VectorsTo returns the n×(iu-il+1) matrix of the
+// corresponding eigenvectors.
+//
+// FactorizeIndex returns whether the decomposition succeeded. If the
+// decomposition failed, methods that require a successful factorization will
+// panic.
+func (e *EigenSym) FactorizeIndex(a Symmetric, il, iu int, vectors bool) (ok bool) {
+	n := a.Symmetric()
+	if il < 0 || iu < il || n <= iu {
+		panic(ErrIndexOutOfRange)
+	}
+	return e.factorizeRange(a, lapack.EVRangeIndex, 0, 0, il, iu, vectors)
+}
+
+// FactorizeValue computes a partial eigenvalue decomposition of the symmetric
+// matrix a, finding the eigenvalues in the half-open interval (vl, vu] in
+// ascending order, and if vectors is true the corresponding eigenvectors.
+// FactorizeValue will panic if vl >= vu.
+//
+// The decomposition is computed using the Multiple Relatively Robust
+// Representations algorithm. After a successful factorization, Values returns
+// the eigenvalues found, which may be none, and VectorsTo returns the matrix
+// of the corresponding eigenvectors.
+//
+// FactorizeValue returns whether the decomposition succeeded. If the
+// decomposition failed, methods that require a successful factorization will
+// panic.
+func (e *EigenSym) FactorizeValue(a Symmetric, vl, vu float64, vectors bool) (ok bool) {
+	if vl >= vu {
+		panic(badInterval)
+	}
+	return e.factorizeRange(a, lapack.EVRangeValue, vl, vu, 0, 0, vectors)
+}
+
+// factorizeRange computes the partial eigenvalue decomposition of a selected
+// by rng using Syevr.
+func (e *EigenSym) factorizeRange(a Symmetric, rng lapack.EVRange, vl, vu float64, il, iu int, vectors bool) (ok bool) {
+	// kill previous decomposition
+	e.vectorsComputed = false
+	e.values = nil
+	e.vectors = nil
+
+	n := a.Symmetric()
+	sd := getWorkspaceSym(n, false)
+	sd.CopySym(a)
+	defer putWorkspaceSym(sd)
+
+	jobz := lapack.EVNone
+	if vectors {
+		jobz = lapack.EVCompute
+	}
+	mmax := n
+	if rng == lapack.EVRangeIndex {
+		mmax = iu - il + 1
+	}
+	w := make([]float64, n)
+	z := blas64.General{Rows: n, Cols: mmax, Stride: max(1, mmax)}
+	if vectors {
+		z.Data = make([]float64, n*mmax)
+	}
+	isuppz := make([]int, 2*mmax)
+
 	work := []float64{0}
-	lapack64.Syev(jobz, sd.mat, w, work, -1)
+	iwork := []int{0}
+	lapack64.Syevr(jobz, rng, sd.mat, vl, vu, il, iu, w, z, isuppz, work, -1, iwork, -1)
 
 	work = getFloats(int(work[0]), false)
-	ok = lapack64.Syev(jobz, sd.mat, w, work, len(work))
+	iwork = getInts(iwork[0], false)
+	m, ok := lapack64.Syevr(jobz, rng, sd.mat, vl, vu, il, iu, w, z, isuppz, work, len(work), iwork, len(iwork))
 	putFloats(work)
+	putInts(iwork)
 	if !ok {
-		e.vectorsComputed = false
-		e.values = nil
-		e.vectors = nil
 		return false
 	}
 	e.vectorsComputed = vectors
-	e.values = w
-	e.vectors = NewDense(n, n, sd.mat.Data)
+	e.values = w[:m:m]
+	if vectors && m > 0 {
+		e.vectors = NewDense(n, m, nil)
+		for i := 0; i < n; i++ {
+			copy(e.vectors.mat.Data[i*m:(i+1)*m], z.Data[i*z.Stride:i*z.Stride+m])
+		}
+	} else if vectors {
+		e.vectors = &Dense{}
+	}
 	return true
 }
 
 // succFact returns whether the receiver contains a successful factorization.
 func (e *EigenSym) succFact() bool {
-	return len(e.values) != 0
+	return e.values != nil
+}
+
+// Values extracts the eigenvalues of the factorized matrix. If dst is
+// non-nil, the values are stored in-place into dst. In this case
+// dst must have length equal to the number of computed eigenvalues, n
+// unless the decomposition was partial, otherwise Values will panic. If dst
+// is nil, then a new slice will be allocated of the proper length and filled
+// with the eigenvalues.
+//
+// Values panics if the Eigen decomposition was not successful.
+func (e *EigenSym) Values(dst []float64) []float64 {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if dst == nil {
+		dst = make([]float64, len(e.values))
+	}
+	if len(dst) != len(e.values) {
+		panic(ErrSliceLengthMismatch)
+	}
+	copy(dst, e.values)
+	return dst
+}
+
+// VectorsTo stores the eigenvectors of the decomposition into the columns of
+// dst.
+//
+// If dst is empty, VectorsTo will resize dst to be n×m where m is the number
+// of computed eigenvalues, n unless the decomposition was partial. When dst is
+// non-empty, VectorsTo will panic if dst is not n×m. If a partial
+// decomposition found no eigenvalues, dst is not modified. VectorsTo will also
+// panic if the eigenvectors were not computed during the factorization,
+// or if the receiver does not contain a successful factorization.
+func (e *EigenSym) VectorsTo(dst *Dense) {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if !e.vectorsComputed {
+		panic(noVectors)
+	}
+	if e.vectors.IsEmpty() {
+		return
+	}
+	r, c := e.vectors.Dims()
+	if dst.IsEmpty() {
+		dst.ReuseAs(r, c)
+	} else {
+		r2, c2 := dst.Dims()
+		if r != r2 || c != c2 {
+			panic(ErrShape)
+		}
+	}
+	dst.Copy(e.vectors)
+}
+
+// EigenHerm is a type for creating and manipulating the Eigen decomposition of
+// Hermitian matrices.
+type EigenHerm struct {
+	vectorsComputed bool
+
+	values  []float64
+	vectors *CDense
+}
+
+// Factorize computes the eigenvalue decomposition of the Hermitian matrix a.
+// The Eigen decomposition is defined as
+//  A = P * D * Pᴴ
+// where D is a real diagonal matrix containing the eigenvalues of the matrix,
+// and P is a unitary matrix of the eigenvectors of A. Factorize computes the
+// eigenvalues in ascending order. If the vectors input argument is false, the
+// eigenvectors are not computed.
+//
+// Factorize returns whether the decomposition succeeded. If the decomposition
+// failed, methods that require a successful factorization will panic.
+func (e *EigenHerm) Factorize(a Hermitian, vectors bool) (ok bool) {
+	// kill previous decomposition
+	e.vectorsComputed = false
+	e.values = nil
+
+	n := a.Hermitian()
+	if n == 0 {
+		e.vectorsComputed = vectors
+		e.values = []float64{}
+		e.vectors = &CDense{}
+		return true
+	}
+	d := make([]float64, n)
+	offDiag := make([]float64, n-1)
+	var q *CDense
+	if vectors {
+		q = NewCDense(n, n, nil)
+	}
+	hermitianTridiagonal(a, d, offDiag, q)
+
+	if !vectors {
+		ok = lapack64.Sterf(d, offDiag)
+		if !ok {
+			e.values = nil
+			e.vectors = nil
+			return false
+		}
+		e.values = d
+		e.vectors = nil
+		return true
+	}
+
+	z := getWorkspace(n, n, false)
+	defer putWorkspace(z)
+	work := getFloats(max(1, 2*n-2), false)
+	defer putFloats(work)
+	ok = lapack64.Steqr(lapack.EVTridiag, d, offDiag, z.mat, work)
+	if !ok {
+		e.values = nil
+		e.vectors = nil
+		return false
+	}
+
+	// The eigenvectors of A are Q*Z where Q is the unitary matrix that
+	// reduces A to real tridiagonal form and Z are the eigenvectors of
+	// the tridiagonal matrix.
+	zc := NewCDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		zrow := z.mat.Data[i*z.mat.Stride : i*z.mat.Stride+n]
+		for j, v := range zrow {
+			zc.set(i, j, complex(v, 0))
+		}
+	}
+	vecs := NewCDense(n, n, nil)
+	cblas128.Gemm(blas.NoTrans, blas.NoTrans, 1, q.mat, zc.mat, 0, vecs.mat)
+
+	e.vectorsComputed = true
+	e.values = d
+	e.vectors = vecs
+	return true
+}
+
+// succFact returns whether the receiver contains a successful factorization.
+func (e *EigenHerm) succFact() bool {
+	return e.values != nil
 }
 
 // Values extracts the eigenvalues of the factorized matrix. If dst is
@@ -77,7 +339,7 @@ func (e *EigenSym) succFact() bool {
 // with the eigenvalues.
 //
 // Values panics if the Eigen decomposition was not successful.
-func (e *EigenSym) Values(dst []float64) []float64 {
+func (e *EigenHerm) Values(dst []float64) []float64 {
 	if !e.succFact() {
 		panic(badFact)
 	}
@@ -98,13 +360,16 @@ func (e *EigenSym) Values(dst []float64) []float64 {
 // non-empty, VectorsTo will panic if dst is not n×n. VectorsTo will also
 // panic if the eigenvectors were not computed during the factorization,
 // or if the receiver does not contain a successful factorization.
-func (e *EigenSym) VectorsTo(dst *Dense) {
+func (e *EigenHerm) VectorsTo(dst *CDense) {
 	if !e.succFact() {
 		panic(badFact)
 	}
 	if !e.vectorsComputed {
 		panic(noVectors)
 	}
+	if e.vectors.IsEmpty() {
+		return
+	}
 	r, c := e.vectors.Dims()
 	if dst.IsEmpty() {
 		dst.ReuseAs(r, c)
@@ -117,6 +382,86 @@ func (e *EigenSym) VectorsTo(dst *Dense) {
 	dst.Copy(e.vectors)
 }
 
+// hermitianTridiagonal reduces the Hermitian matrix a to real symmetric
+// tridiagonal form T by a unitary similarity transformation
+//  Qᴴ * A * Q = T.
+// On return, d holds the n diagonal elements of T and e holds the n-1
+// off-diagonal elements. If q is not nil, it must be n×n and is set to Q.
+func hermitianTridiagonal(a Hermitian, d, e []float64, q *CDense) {
+	n := a.Hermitian()
+
+	// Work on a full copy of a so that the reflectors can be applied
+	// from both sides with general matrix operations.
+	w := NewCDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			v := a.At(i, j)
+			w.set(i, j, v)
+			w.set(j, i, cmplx.Conj(v))
+		}
+	}
+	if q != nil {
+		q.Zero()
+		for i := 0; i < n; i++ {
+			q.set(i, i, 1)
+		}
+	}
+
+	stride := w.mat.Stride
+	v := make([]complex128, n)
+	tmp := make([]complex128, n)
+	for k := 0; k < n-1; k++ {
+		m := n - k - 1
+
+		// Generate the elementary reflector H = I - tau * v * vᴴ
+		// such that Hᴴ * A[k+1:n, k] = beta * e_1 with beta real.
+		x := v[:m]
+		for i := range x {
+			x[i] = w.at(k+1+i, k)
+		}
+		alpha := x[0]
+		xnorm := cblas128.Nrm2(cblas128.Vector{N: m - 1, Inc: 1, Data: x[1:]})
+		if xnorm == 0 && imag(alpha) == 0 {
+			e[k] = real(alpha)
+			d[k] = real(w.at(k, k))
+			continue
+		}
+		beta := -math.Copysign(math.Hypot(cmplx.Abs(alpha), xnorm), real(alpha))
+		tau := complex((beta-real(alpha))/beta, -imag(alpha)/beta)
+		scale := 1 / (alpha - complex(beta, 0))
+		for i := 1; i < m; i++ {
+			x[i] *= scale
+		}
+		x[0] = 1
+		vec := cblas128.Vector{N: m, Inc: 1, Data: x}
+
+		// Apply H from the left to A[k+1:n, k:n].
+		left := cblas128.General{Rows: m, Cols: m + 1, Stride: stride, Data: w.mat.Data[(k+1)*stride+k:]}
+		wv := cblas128.Vector{N: m + 1, Inc: 1, Data: tmp[:m+1]}
+		cblas128.Gemv(blas.ConjTrans, 1, left, vec, 0, wv)
+		cblas128.Gerc(-cmplx.Conj(tau), vec, wv, left)
+
+		// Apply H from the right to A[k:n, k+1:n].
+		right := cblas128.General{Rows: m + 1, Cols: m, Stride: stride, Data: w.mat.Data[k*stride+k+1:]}
+		cblas128.Gemv(blas.NoTrans, 1, right, vec, 0, wv)
+		cblas128.Gerc(-tau, wv, vec, right)
+
+		// Accumulate Q = H_0 * H_1 * ... * H_{n-2}.
+		if q != nil {
+			qs := cblas128.General{Rows: n, Cols: m, Stride: q.mat.Stride, Data: q.mat.Data[k+1:]}
+			qv := cblas128.Vector{N: n, Inc: 1, Data: tmp[:n]}
+			cblas128.Gemv(blas.NoTrans, 1, qs, vec, 0, qv)
+			cblas128.Gerc(-tau, qv, vec, qs)
+		}
+
+		e[k] = beta
+		d[k] = real(w.at(k, k))
+	}
+	if n > 0 {
+		d[n-1] = real(w.at(n-1, n-1))
+	}
+}
+
 // EigenKind specifies the computation of eigenvectors during factorization.
 type EigenKind int
 
@@ -5,6 +5,8 @@
 package mat
 
 import (
+	"math"
+	"math/cmplx"
 	"sort"
 	"testing"
 
@@ -130,6 +132,102 @@ func cmplxEqualTol(v1, v2 []complex128, tol float64) bool {
 	return true
 }
 
+func TestHermEigen(t *testing.T) {
+	// Hand coded test; values computed with numpy.
+	h := NewHermitianDense(2, []complex128{
+		2, 1i,
+		2,
+	})
+	var eh EigenHerm
+	if !eh.Factorize(h, true) {
+		t.Fatalf("bad factorization")
+	}
+	if !floats.EqualApprox(eh.Values(nil), []float64{1, 3}, 1e-14) {
+		t.Errorf("Eigenvalue mismatch: got: %v want: %v", eh.Values(nil), []float64{1, 3})
+	}
+
+	// Randomized tests
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 50} {
+		for cas := 0; cas < 10; cas++ {
+			a := randHermitian(n, rnd)
+			var eh EigenHerm
+			ok := eh.Factorize(a, true)
+			if !ok {
+				t.Errorf("Bad test")
+			}
+			values := eh.Values(nil)
+			if !sort.Float64sAreSorted(values) {
+				t.Errorf("Eigenvalues not ascending")
+			}
+			var vecs CDense
+			eh.VectorsTo(&vecs)
+
+			// Check that the eigenvectors are orthonormal and that
+			// the eigenvalues are actually eigenvalues.
+			for j := 0; j < n; j++ {
+				for k := j; k < n; k++ {
+					var dot complex128
+					for i := 0; i < n; i++ {
+						dot += cmplx.Conj(vecs.At(i, j)) * vecs.At(i, k)
+					}
+					want := complex128(0)
+					if j == k {
+						want = 1
+					}
+					if cmplx.Abs(dot-want) > 1e-10 {
+						t.Errorf("n=%d: eigenvectors %d and %d not orthonormal: %v", n, j, k, dot)
+					}
+				}
+				for i := 0; i < n; i++ {
+					var av complex128
+					for k := 0; k < n; k++ {
+						av += a.At(i, k) * vecs.At(k, j)
+					}
+					if cmplx.Abs(av-complex(values[j], 0)*vecs.At(i, j)) > 1e-10 {
+						t.Errorf("n=%d: eigenvalue %d does not match eigenvector", n, j)
+					}
+				}
+			}
+
+			var eh2 EigenHerm
+			eh2.Factorize(a, false)
+			if !floats.EqualApprox(eh2.Values(nil), values, 1e-10) {
+				t.Errorf("Eigenvalue mismatch when no vectors computed")
+			}
+			if panicked, _ := panics(func() { eh2.VectorsTo(&CDense{}) }); !panicked {
+				t.Errorf("expected panic when vectors not computed")
+			}
+		}
+	}
+}
+
+func TestHermEigenEmpty(t *testing.T) {
+	for _, vectors := range []bool{false, true} {
+		var eh EigenHerm
+		if !eh.Factorize(&HermitianDense{}, vectors) {
+			t.Errorf("vectors=%t: unexpected factorization failure", vectors)
+			continue
+		}
+		values := eh.Values(nil)
+		if values == nil || len(values) != 0 {
+			t.Errorf("vectors=%t: unexpected eigenvalues: got:%v want:[]", vectors, values)
+		}
+		var vecs CDense
+		panicked, message := panics(func() { eh.VectorsTo(&vecs) })
+		if vectors {
+			if panicked {
+				t.Errorf("vectors=%t: unexpected panic: %s", vectors, message)
+			}
+			if !vecs.IsEmpty() {
+				t.Errorf("vectors=%t: unexpected non-empty eigenvectors", vectors)
+			}
+		} else if !panicked {
+			t.Errorf("vectors=%t: expected panic when vectors not computed", vectors)
+		}
+	}
+}
+
 func TestSymEigen(t *testing.T) {
 	// Hand coded tests with results from lapack.
 	for _, test := range []struct {
@@ -208,3 +306,138 @@ func TestSymEigen(t *testing.T) {
 		}
 	}
 }
+
+func TestSymEigenDivideConquer(t *testing.T) {
+	defer func(n int) { eigenSymDCMin = n }(eigenSymDCMin)
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 10, 70, 150} {
+		a := make([]float64, n*n)
+		for i := range a {
+			a[i] = rnd.NormFloat64()
+		}
+		s := NewSymDense(n, a)
+
+		// Force the QR iteration path.
+		eigenSymDCMin = n + 1
+		var qr EigenSym
+		if !qr.Factorize(s, true) {
+			t.Fatalf("n=%d: bad QR factorization", n)
+		}
+
+		// Force the divide and conquer path.
+		eigenSymDCMin = 0
+		var dc EigenSym
+		if !dc.Factorize(s, true) {
+			t.Fatalf("n=%d: bad divide and conquer factorization", n)
+		}
+
+		want := qr.Values(nil)
+		got := dc.Values(nil)
+		if !floats.EqualApprox(got, want, 1e-12) {
+			t.Errorf("n=%d: eigenvalue mismatch: got %v want %v", n, got, want)
+		}
+
+		var vecs Dense
+		dc.VectorsTo(&vecs)
+		if !isOrthonormal(&vecs, 1e-12) {
+			t.Errorf("n=%d: divide and conquer eigenvectors not orthonormal", n)
+		}
+		var av, vd Dense
+		av.Mul(s, &vecs)
+		vd.Mul(&vecs, NewDiagDense(n, got))
+		if !EqualApprox(&av, &vd, 1e-10) {
+			t.Errorf("n=%d: divide and conquer eigenvectors do not satisfy A*V = V*D", n)
+		}
+
+		// The eigenvalues of a random matrix are distinct, so the two sets
+		// of eigenvectors agree up to the sign of each column.
+		var qrVecs, prod Dense
+		qr.VectorsTo(&qrVecs)
+		prod.Mul(qrVecs.T(), &vecs)
+		for i := 0; i < n; i++ {
+			prod.Set(i, i, math.Abs(prod.At(i, i)))
+		}
+		if !EqualApprox(&prod, eye(n), 1e-8) {
+			t.Errorf("n=%d: eigenvector mismatch between QR and divide and conquer", n)
+		}
+	}
+}
+
+func TestSymEigenPartial(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 3, 10, 70} {
+		a := make([]float64, n*n)
+		for i := range a {
+			a[i] = rnd.NormFloat64()
+		}
+		s := NewSymDense(n, a)
+		var full EigenSym
+		if !full.Factorize(s, false) {
+			t.Fatalf("n=%d: bad factorization", n)
+		}
+		all := full.Values(nil)
+
+		il := rnd.Intn(n)
+		iu := il + rnd.Intn(n-il)
+		var es EigenSym
+		if !es.FactorizeIndex(s, il, iu, true) {
+			t.Fatalf("n=%d: bad index factorization", n)
+		}
+		values := es.Values(nil)
+		if !floats.EqualApprox(values, all[il:iu+1], 1e-12) {
+			t.Errorf("n=%d,il=%d,iu=%d: eigenvalue mismatch: got %v want %v", n, il, iu, values, all[il:iu+1])
+		}
+		var vecs Dense
+		es.VectorsTo(&vecs)
+		if r, c := vecs.Dims(); r != n || c != iu-il+1 {
+			t.Fatalf("n=%d,il=%d,iu=%d: unexpected eigenvector dimensions %d×%d", n, il, iu, r, c)
+		}
+		var ata Dense
+		ata.Mul(vecs.T(), &vecs)
+		if !EqualApprox(&ata, eye(iu-il+1), 1e-12) {
+			t.Errorf("n=%d,il=%d,iu=%d: eigenvectors not orthonormal", n, il, iu)
+		}
+		var av, vd Dense
+		av.Mul(s, &vecs)
+		vd.Mul(&vecs, NewDiagDense(len(values), values))
+		if !EqualApprox(&av, &vd, 1e-10) {
+			t.Errorf("n=%d,il=%d,iu=%d: A*V != V*D", n, il, iu)
+		}
+
+		// Select the same eigenvalues by value.
+		vl := all[il] - 1
+		if il > 0 {
+			vl = (all[il-1] + all[il]) / 2
+		}
+		vu := all[iu] + 1
+		if iu < n-1 {
+			vu = (all[iu] + all[iu+1]) / 2
+		}
+		var esv EigenSym
+		if !esv.FactorizeValue(s, vl, vu, false) {
+			t.Fatalf("n=%d: bad value factorization", n)
+		}
+		if got := esv.Values(nil); !floats.EqualApprox(got, values, 1e-12) {
+			t.Errorf("n=%d,vl=%v,vu=%v: eigenvalue mismatch: got %v want %v", n, vl, vu, got, values)
+		}
+		if panicked, _ := panics(func() { esv.VectorsTo(&Dense{}) }); !panicked {
+			t.Errorf("n=%d: expected panic for vectors not computed", n)
+		}
+
+		// An interval containing no eigenvalues.
+		if !esv.FactorizeValue(s, all[n-1]+1, all[n-1]+2, true) {
+			t.Fatalf("n=%d: bad empty value factorization", n)
+		}
+		if got := esv.Values(nil); len(got) != 0 {
+			t.Errorf("n=%d: unexpected eigenvalues in empty interval: %v", n, got)
+		}
+
+		if panicked, _ := panics(func() { es.FactorizeIndex(s, 0, n, true) }); !panicked {
+			t.Errorf("n=%d: expected panic for index out of range", n)
+		}
+		if panicked, _ := panics(func() { es.FactorizeValue(s, 1, 1, true) }); !panicked {
+			t.Errorf("n=%d: expected panic for empty interval", n)
+		}
+	}
+}
@@ -0,0 +1,406 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+var (
+	hermDense *HermitianDense
+
+	_ CMatrix          = hermDense
+	_ allMatrix        = hermDense
+	_ Hermitian        = hermDense
+	_ RawHermitianer   = hermDense
+	_ MutableHermitian = hermDense
+)
+
+const (
+	badHermTriangle = "mat: cblas128.HermitianPacked not upper"
+	badHermCap      = "mat: bad capacity for HermitianDense"
+	badHermDiagonal = "mat: non-real diagonal element of Hermitian matrix"
+)
+
+// HermitianDense is a Hermitian matrix that uses packed storage. HermitianDense
+// matrices are stored in the upper triangle, so an n×n matrix uses n*(n+1)/2
+// elements.
+type HermitianDense struct {
+	mat cblas128.HermitianPacked
+	cap int
+}
+
+// Hermitian represents a Hermitian matrix (where the element at {i, j} equals
+// the conjugate of the element at {j, i}). Hermitian matrices are always square.
+type Hermitian interface {
+	CMatrix
+	// Hermitian returns the number of rows/columns in the matrix.
+	Hermitian() int
+}
+
+// A RawHermitianer can return a view of itself as a packed BLAS Hermitian matrix.
+type RawHermitianer interface {
+	RawHermitian() cblas128.HermitianPacked
+}
+
+// A MutableHermitian can set elements of a Hermitian matrix.
+type MutableHermitian interface {
+	Hermitian
+	SetHerm(i, j int, v complex128)
+}
+
+// NewHermitianDense creates a new Hermitian matrix with n rows and columns. If
+// data == nil, a new slice is allocated for the backing slice. If
+// len(data) == n*(n+1)/2, data is used as the backing slice, and changes to the
+// elements of the returned HermitianDense will be reflected in data. If neither
+// of these is true, NewHermitianDense will panic. NewHermitianDense will panic
+// if n is zero.
+//
+// The data must hold the upper triangle of the matrix packed in row-major
+// order, i.e. the elements {i, i} to {i, n-1} of row i are stored
+// contiguously starting at element i*n - i*(i-1)/2 of the data slice.
+// The imaginary parts of the diagonal elements must be zero.
+func NewHermitianDense(n int, data []complex128) *HermitianDense {
+	if n <= 0 {
+		if n == 0 {
+			panic(ErrZeroLength)
+		}
+		panic(ErrNegativeDimension)
+	}
+	if data != nil && packedLen(n) != len(data) {
+		panic(ErrShape)
+	}
+	if data == nil {
+		data = make([]complex128, packedLen(n))
+	}
+	for i := 0; i < n; i++ {
+		if imag(data[packedIndex(n, i, i)]) != 0 {
+			panic(badHermDiagonal)
+		}
+	}
+	return &HermitianDense{
+		mat: cblas128.HermitianPacked{
+			N:    n,
+			Data: data,
+			Uplo: blas.Upper,
+		},
+		cap: n,
+	}
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (h *HermitianDense) Dims() (r, c int) {
+	return h.mat.N, h.mat.N
+}
+
+// Caps returns the number of rows and columns in the backing matrix.
+func (h *HermitianDense) Caps() (r, c int) {
+	return h.cap, h.cap
+}
+
+// H returns the receiver, the conjugate transpose of a Hermitian matrix.
+func (h *HermitianDense) H() CMatrix {
+	return h
+}
+
+// Hermitian implements the Hermitian interface and returns the number of rows
+// and columns in the matrix.
+func (h *HermitianDense) Hermitian() int {
+	return h.mat.N
+}
+
+// RawHermitian returns the matrix as a cblas128.HermitianPacked. The returned
+// value must be stored in upper triangular format.
+func (h *HermitianDense) RawHermitian() cblas128.HermitianPacked {
+	return h.mat
+}
+
+// SetRawHermitian sets the underlying cblas128.HermitianPacked used by the
+// receiver. Changes to elements in the receiver following the call will be
+// reflected in the input.
+//
+// The supplied HermitianPacked must use blas.Upper storage format.
+func (h *HermitianDense) SetRawHermitian(mat cblas128.HermitianPacked) {
+	if mat.Uplo != blas.Upper {
+		panic(badHermTriangle)
+	}
+	h.cap = mat.N
+	h.mat = mat
+}
+
+// Reset empties the matrix so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+//
+// Reset should not be used when the matrix shares backing data.
+// See the Reseter interface for more information.
+func (h *HermitianDense) Reset() {
+	h.mat.N = 0
+	h.mat.Data = h.mat.Data[:0]
+}
+
+// ReuseAsHerm changes the receiver if it IsEmpty() to be of size n×n.
+//
+// ReuseAsHerm re-uses the backing data slice if it has sufficient capacity,
+// otherwise a new slice is allocated. The backing data is zero on return.
+//
+// ReuseAsHerm panics if the receiver is not empty, and panics if
+// the input size is less than one. To empty the receiver for re-use,
+// Reset should be used.
+func (h *HermitianDense) ReuseAsHerm(n int) {
+	if n <= 0 {
+		if n == 0 {
+			panic(ErrZeroLength)
+		}
+		panic(ErrNegativeDimension)
+	}
+	if !h.IsEmpty() {
+		panic(ErrReuseNonEmpty)
+	}
+	h.reuseAsZeroed(n)
+}
+
+// Zero sets all of the matrix elements to zero.
+func (h *HermitianDense) Zero() {
+	zeroC(h.mat.Data[:packedLen(h.mat.N)])
+}
+
+// IsEmpty returns whether the receiver is empty. Empty matrices can be the
+// receiver for size-restricted operations. The receiver can be emptied using
+// Reset.
+func (h *HermitianDense) IsEmpty() bool {
+	// It must be the case that h.Dims() returns
+	// zeros in this case. See comment in Reset().
+	return h.mat.N == 0
+}
+
+// reuseAsNonZeroed resizes an empty matrix to a n×n matrix,
+// or checks that a non-empty matrix is n×n.
+func (h *HermitianDense) reuseAsNonZeroed(n int) {
+	// reuseAsNonZeroed must be kept in sync with reuseAsZeroed.
+	if n == 0 {
+		panic(ErrZeroLength)
+	}
+	if h.mat.N > h.cap {
+		panic(badHermCap)
+	}
+	if h.IsEmpty() {
+		h.mat = cblas128.HermitianPacked{
+			N:    n,
+			Data: useC(h.mat.Data, packedLen(n)),
+			Uplo: blas.Upper,
+		}
+		h.cap = n
+		return
+	}
+	if h.mat.Uplo != blas.Upper {
+		panic(badHermTriangle)
+	}
+	if h.mat.N != n {
+		panic(ErrShape)
+	}
+}
+
+// reuseAsZeroed resizes an empty matrix to a n×n matrix,
+// or checks that a non-empty matrix is n×n. It then zeros the
+// elements of the matrix.
+func (h *HermitianDense) reuseAsZeroed(n int) {
+	// reuseAsZeroed must be kept in sync with reuseAsNonZeroed.
+	if n == 0 {
+		panic(ErrZeroLength)
+	}
+	if h.mat.N > h.cap {
+		panic(badHermCap)
+	}
+	if h.IsEmpty() {
+		h.mat = cblas128.HermitianPacked{
+			N:    n,
+			Data: useZeroedC(h.mat.Data, packedLen(n)),
+			Uplo: blas.Upper,
+		}
+		h.cap = n
+		return
+	}
+	if h.mat.Uplo != blas.Upper {
+		panic(badHermTriangle)
+	}
+	if h.mat.N != n {
+		panic(ErrShape)
+	}
+	h.Zero()
+}
+
+// CopyHerm makes a copy of elements of a into the receiver. It is similar to
+// the built-in copy; it copies as much as the overlap between the two matrices
+// and returns the number of rows and columns it copied.
+func (h *HermitianDense) CopyHerm(a Hermitian) int {
+	n := a.Hermitian()
+	n = min(n, h.mat.N)
+	if n == 0 {
+		return 0
+	}
+	switch a := a.(type) {
+	case RawHermitianer:
+		amat := a.RawHermitian()
+		if amat.Uplo != blas.Upper {
+			panic(badHermTriangle)
+		}
+		for i := 0; i < n; i++ {
+			hi := packedIndex(h.mat.N, i, i)
+			ai := packedIndex(amat.N, i, i)
+			copy(h.mat.Data[hi:hi+n-i], amat.Data[ai:ai+n-i])
+		}
+	default:
+		for i := 0; i < n; i++ {
+			htmp := h.mat.Data[packedIndex(h.mat.N, i, i):]
+			for j := i; j < n; j++ {
+				htmp[j-i] = a.At(i, j)
+			}
+		}
+	}
+	return n
+}
+
+// HermRankOne performs a Hermitian rank-one update to the matrix a with x,
+// which is treated as a column vector, and stores the result in the receiver
+//  h = a + alpha * x * xᴴ
+func (h *HermitianDense) HermRankOne(a Hermitian, alpha float64, x []complex128) {
+	n := len(x)
+	if a.Hermitian() != n {
+		panic(ErrShape)
+	}
+	h.reuseAsNonZeroed(n)
+
+	if h != a {
+		if rh, ok := a.(RawHermitianer); ok {
+			h.checkOverlap(generalFromHermitian(rh.RawHermitian()))
+		}
+		h.CopyHerm(a)
+	}
+	cblas128.Hpr(alpha, cblas128.Vector{N: n, Inc: 1, Data: x}, h.mat)
+}
+
+// RankTwo performs a Hermitian rank-two update to the matrix a with the
+// vectors x and y, which are treated as column vectors, and stores the
+// result in the receiver
+//  h = a + alpha * x * yᴴ + conj(alpha) * y * xᴴ
+func (h *HermitianDense) RankTwo(a Hermitian, alpha complex128, x, y []complex128) {
+	n := len(x)
+	if a.Hermitian() != n || len(y) != n {
+		panic(ErrShape)
+	}
+	h.reuseAsNonZeroed(n)
+
+	if h != a {
+		if rh, ok := a.(RawHermitianer); ok {
+			h.checkOverlap(generalFromHermitian(rh.RawHermitian()))
+		}
+		h.CopyHerm(a)
+	}
+	cblas128.Hpr2(alpha,
+		cblas128.Vector{N: n, Inc: 1, Data: x},
+		cblas128.Vector{N: n, Inc: 1, Data: y},
+		h.mat,
+	)
+}
+
+// HermRankK performs a Hermitian rank-k update to the matrix a and stores the
+// result into the receiver. If a is zero, see HermOuterK.
+//  h = a + alpha * x * xᴴ
+func (h *HermitianDense) HermRankK(a Hermitian, alpha float64, x CMatrix) {
+	n := a.Hermitian()
+	r, k := x.Dims()
+	if r != n {
+		panic(ErrShape)
+	}
+	if a != h {
+		if rh, ok := a.(RawHermitianer); ok {
+			h.checkOverlap(generalFromHermitian(rh.RawHermitian()))
+		}
+		h.reuseAsNonZeroed(n)
+		h.CopyHerm(a)
+	}
+	if rm, ok := x.(RawCMatrixer); ok {
+		h.checkOverlap(rm.RawCMatrix())
+	}
+
+	// There is no packed rank-k update in BLAS, so the update is applied
+	// as k rank-one updates with the columns of x.
+	col := make([]complex128, n)
+	for l := 0; l < k; l++ {
+		for i := range col {
+			col[i] = x.At(i, l)
+		}
+		cblas128.Hpr(alpha, cblas128.Vector{N: n, Inc: 1, Data: col}, h.mat)
+	}
+}
+
+// HermOuterK calculates the outer product of x with itself and stores
+// the result into the receiver. It is equivalent to the matrix
+// multiplication
+//  h = alpha * x * xᴴ.
+// In order to update an existing matrix, see HermRankK.
+func (h *HermitianDense) HermOuterK(alpha float64, x CMatrix) {
+	n, _ := x.Dims()
+	switch {
+	case h.IsEmpty():
+		h.reuseAsZeroed(n)
+	case h.mat.Uplo != blas.Upper:
+		panic(badHermTriangle)
+	case h.mat.N != n:
+		panic(ErrShape)
+	default:
+		if x == CMatrix(h) {
+			tmp := NewCDense(n, n, nil)
+			tmp.Copy(x)
+			x = tmp
+		} else if rm, ok := x.(RawCMatrixer); ok {
+			h.checkOverlap(rm.RawCMatrix())
+		}
+		h.Zero()
+	}
+	h.HermRankK(h, alpha, x)
+}
+
+// checkOverlap returns false if the receiver does not overlap data elements
+// referenced by the parameter and panics otherwise.
+func (h *HermitianDense) checkOverlap(a cblas128.General) bool {
+	return checkOverlapComplex(generalFromHermitian(h.mat), a)
+}
+
+// generalFromHermitian returns a cblas128.General that views the packed
+// backing data of a as a column vector.
+func generalFromHermitian(a cblas128.HermitianPacked) cblas128.General {
+	l := packedLen(a.N)
+	return cblas128.General{
+		Rows:   l,
+		Cols:   1,
+		Stride: 1,
+		Data:   a.Data[:l],
+	}
+}
+
+// packedLen returns the number of elements needed to store the upper
+// triangle of an n×n matrix in packed format.
+func packedLen(n int) int {
+	return n * (n + 1) / 2
+}
+
+// packedIndex returns the index of the {i, j} element, with i <= j, of an n×n
+// matrix with its upper triangle stored in packed format.
+func packedIndex(n, i, j int) int {
+	return i*n - i*(i-1)/2 + j - i
+}
+
+// hermitianAt returns the {i, j} element of the n×n Hermitian matrix with its
+// upper triangle stored in packed format in data.
+func hermitianAt(data []complex128, n, i, j int) complex128 {
+	if i > j {
+		return cmplx.Conj(data[packedIndex(n, j, i)])
+	}
+	return data[packedIndex(n, i, j)]
+}
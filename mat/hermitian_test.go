@@ -0,0 +1,180 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/cmplx"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestHermitianDenseAtSet(t *testing.T) {
+	data := []complex128{
+		1, 2 + 1i, 3 - 2i,
+		4, 5 + 3i,
+		6,
+	}
+	h := NewHermitianDense(3, data)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if h.At(i, j) != cmplx.Conj(h.At(j, i)) {
+				t.Errorf("matrix not Hermitian at (%d,%d): %v != conj(%v)", i, j, h.At(i, j), h.At(j, i))
+			}
+		}
+	}
+	if h.At(2, 0) != 3+2i {
+		t.Errorf("unexpected value at (2,0): got: %v want: %v", h.At(2, 0), 3+2i)
+	}
+	h.SetHerm(2, 1, 7+8i)
+	if h.At(2, 1) != 7+8i || h.At(1, 2) != 7-8i {
+		t.Errorf("unexpected value after SetHerm: got: %v and %v", h.At(2, 1), h.At(1, 2))
+	}
+	if data[4] != 7-8i {
+		t.Errorf("unexpected packed value after SetHerm: got: %v want: %v", data[4], 7-8i)
+	}
+	if panicked, _ := panics(func() { h.SetHerm(1, 1, 1i) }); !panicked {
+		t.Errorf("expected panic for non-real diagonal")
+	}
+	if panicked, _ := panics(func() { NewHermitianDense(2, []complex128{1i, 0, 1}) }); !panicked {
+		t.Errorf("expected panic for non-real diagonal")
+	}
+	if panicked, _ := panics(func() { NewHermitianDense(2, make([]complex128, 4)) }); !panicked {
+		t.Errorf("expected panic for unpacked data")
+	}
+}
+
+func TestHermRankOne(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 10} {
+		a := randHermitian(n, rnd)
+		x := make([]complex128, n)
+		for i := range x {
+			x[i] = complex(rnd.NormFloat64(), rnd.NormFloat64())
+		}
+		const alpha = 2.5
+		var h HermitianDense
+		h.HermRankOne(a, alpha, x)
+
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				want := a.At(i, j) + alpha*x[i]*cmplx.Conj(x[j])
+				if !cEqualWithinAbsOrRel(h.At(i, j), want, 1e-14, 1e-14) {
+					t.Errorf("n=%d: unexpected value at (%d,%d): got: %v want: %v", n, i, j, h.At(i, j), want)
+				}
+			}
+		}
+	}
+}
+
+func TestHermRankTwo(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 10} {
+		a := randHermitian(n, rnd)
+		x := make([]complex128, n)
+		y := make([]complex128, n)
+		for i := range x {
+			x[i] = complex(rnd.NormFloat64(), rnd.NormFloat64())
+			y[i] = complex(rnd.NormFloat64(), rnd.NormFloat64())
+		}
+		const alpha = 1.5 - 0.5i
+		var h HermitianDense
+		h.RankTwo(a, alpha, x, y)
+
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				want := a.At(i, j) + alpha*x[i]*cmplx.Conj(y[j]) + cmplx.Conj(alpha)*y[i]*cmplx.Conj(x[j])
+				if !cEqualWithinAbsOrRel(h.At(i, j), want, 1e-14, 1e-14) {
+					t.Errorf("n=%d: unexpected value at (%d,%d): got: %v want: %v", n, i, j, h.At(i, j), want)
+				}
+			}
+		}
+	}
+}
+
+func TestHermOuterK(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ n, k int }{
+		{1, 1}, {3, 1}, {3, 5}, {10, 4},
+	} {
+		n, k := test.n, test.k
+		x := NewCDense(n, k, nil)
+		for i := 0; i < n; i++ {
+			for j := 0; j < k; j++ {
+				x.Set(i, j, complex(rnd.NormFloat64(), rnd.NormFloat64()))
+			}
+		}
+		const alpha = 0.5
+		for _, conj := range []bool{false, true} {
+			var h HermitianDense
+			var want complex128
+			if conj {
+				// xᴴ is k×n so use a k×k result.
+				h.HermOuterK(alpha, x.H())
+				for i := 0; i < k; i++ {
+					for j := 0; j < k; j++ {
+						want = 0
+						for l := 0; l < n; l++ {
+							want += cmplx.Conj(x.At(l, i)) * x.At(l, j)
+						}
+						want *= alpha
+						if !cEqualWithinAbsOrRel(h.At(i, j), want, 1e-14, 1e-14) {
+							t.Errorf("n=%d k=%d conj: unexpected value at (%d,%d): got: %v want: %v", n, k, i, j, h.At(i, j), want)
+						}
+					}
+				}
+				continue
+			}
+			h.HermOuterK(alpha, x)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					want = 0
+					for l := 0; l < k; l++ {
+						want += x.At(i, l) * cmplx.Conj(x.At(j, l))
+					}
+					want *= alpha
+					if !cEqualWithinAbsOrRel(h.At(i, j), want, 1e-14, 1e-14) {
+						t.Errorf("n=%d k=%d: unexpected value at (%d,%d): got: %v want: %v", n, k, i, j, h.At(i, j), want)
+					}
+				}
+			}
+		}
+	}
+}
+
+// randHermitian returns a random n×n Hermitian matrix.
+func randHermitian(n int, rnd *rand.Rand) *HermitianDense {
+	h := NewHermitianDense(n, nil)
+	for i := 0; i < n; i++ {
+		h.SetHerm(i, i, complex(rnd.NormFloat64(), 0))
+		for j := i + 1; j < n; j++ {
+			h.SetHerm(i, j, complex(rnd.NormFloat64(), rnd.NormFloat64()))
+		}
+	}
+	return h
+}
+
+func TestHermitianDenseCopyHerm(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	a := randHermitian(5, rnd)
+	for _, n := range []int{3, 5, 7} {
+		h := NewHermitianDense(n, nil)
+		c := h.CopyHerm(a)
+		if c != min(n, 5) {
+			t.Errorf("n=%d: unexpected number of copied rows: got: %d want: %d", n, c, min(n, 5))
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				var want complex128
+				if i < c && j < c {
+					want = a.At(i, j)
+				}
+				if h.At(i, j) != want {
+					t.Errorf("n=%d: unexpected value at (%d,%d): got: %v want: %v", n, i, j, h.At(i, j), want)
+				}
+			}
+		}
+	}
+}
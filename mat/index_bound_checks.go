@@ -8,6 +8,8 @@
 
 package mat
 
+import "math/cmplx"
+
 // At returns the element at row i, column j.
 func (m *Dense) At(i, j int) float64 {
 	return m.at(i, j)
@@ -38,6 +40,43 @@ func (m *Dense) set(i, j int, v float64) {
 	m.mat.Data[i*m.mat.Stride+j] = v
 }
 
+// At returns the element at row i and column j.
+func (h *HermitianDense) At(i, j int) complex128 {
+	return h.at(i, j)
+}
+
+func (h *HermitianDense) at(i, j int) complex128 {
+	if uint(i) >= uint(h.mat.N) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(h.mat.N) {
+		panic(ErrColAccess)
+	}
+	return hermitianAt(h.mat.Data, h.mat.N, i, j)
+}
+
+// SetHerm sets the element at (i,j) to the value v and the element at (j,i)
+// to the conjugate of v. SetHerm will panic if i == j and v is not real.
+func (h *HermitianDense) SetHerm(i, j int, v complex128) {
+	h.set(i, j, v)
+}
+
+func (h *HermitianDense) set(i, j int, v complex128) {
+	if uint(i) >= uint(h.mat.N) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(h.mat.N) {
+		panic(ErrColAccess)
+	}
+	if i > j {
+		i, j = j, i
+		v = cmplx.Conj(v)
+	} else if i == j && imag(v) != 0 {
+		panic(badHermDiagonal)
+	}
+	h.mat.Data[packedIndex(h.mat.N, i, j)] = v
+}
+
 // At returns the element at row i, column j.
 func (m *CDense) At(i, j int) complex128 {
 	return m.at(i, j)
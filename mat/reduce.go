@@ -0,0 +1,361 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/internal/asm/f64"
+)
+
+const badAxis = "mat: invalid axis"
+
+// Axis specifies the direction along which an operation is applied to the
+// elements of a matrix.
+type Axis int
+
+const (
+	// ByRow specifies that an operation is applied to each row of a
+	// matrix. A reduction by row returns one value for each row, and a
+	// vector broadcast by row holds one value for each row.
+	ByRow Axis = iota
+	// ByCol specifies that an operation is applied to each column of a
+	// matrix. A reduction by column returns one value for each column, and
+	// a vector broadcast by column holds one value for each column.
+	ByCol
+)
+
+// axisLen returns the number of values produced by a reduction of an r×c
+// matrix along axis.
+func axisLen(r, c int, axis Axis) int {
+	switch axis {
+	default:
+		panic(badAxis)
+	case ByRow:
+		return r
+	case ByCol:
+		return c
+	}
+}
+
+// axisLengthErr returns the error used when a slice does not match the
+// number of values along axis.
+func axisLengthErr(axis Axis) Error {
+	if axis == ByRow {
+		return ErrColLength
+	}
+	return ErrRowLength
+}
+
+// rawAxis returns the raw representation of a with the axis adjusted for
+// any implicit transpose. If a does not have a raw representation, ok is false.
+func rawAxis(a Matrix, axis Axis) (m blas64.General, ax Axis, ok bool) {
+	aU, aTrans := untranspose(a)
+	rm, ok := aU.(RawMatrixer)
+	if !ok {
+		return blas64.General{}, axis, false
+	}
+	if aTrans {
+		if axis == ByRow {
+			axis = ByCol
+		} else {
+			axis = ByRow
+		}
+	}
+	return rm.RawMatrix(), axis, true
+}
+
+// useAxis returns a slice of length n for the result of a reduction along axis,
+// allocating if dst is nil, and panicking if dst has the wrong length.
+func useAxis(dst []float64, n int, axis Axis) []float64 {
+	if dst == nil {
+		return make([]float64, n)
+	}
+	if len(dst) != n {
+		panic(axisLengthErr(axis))
+	}
+	return dst
+}
+
+// SumAxis computes the sums of the elements of each row or column of a,
+// placing the results into dst. If axis is ByRow, dst holds the sum of each
+// row of a, and if axis is ByCol, dst holds the sum of each column.
+//
+// If dst is nil a new slice is allocated, otherwise its length must equal the
+// number of rows or columns of a, respectively, and SumAxis will panic if it
+// does not. The result is returned.
+func SumAxis(dst []float64, a Matrix, axis Axis) []float64 {
+	r, c := a.Dims()
+	dst = useAxis(dst, axisLen(r, c, axis), axis)
+	if rm, ax, ok := rawAxis(a, axis); ok {
+		if ax == ByRow {
+			for i := range dst {
+				dst[i] = f64.Sum(rm.Data[i*rm.Stride : i*rm.Stride+rm.Cols])
+			}
+			return dst
+		}
+		zero(dst)
+		for i := 0; i < rm.Rows; i++ {
+			f64.Add(dst, rm.Data[i*rm.Stride:i*rm.Stride+rm.Cols])
+		}
+		return dst
+	}
+	zero(dst)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if axis == ByRow {
+				dst[i] += a.At(i, j)
+			} else {
+				dst[j] += a.At(i, j)
+			}
+		}
+	}
+	return dst
+}
+
+// MeanAxis computes the means of the elements of each row or column of a,
+// placing the results into dst. The length requirements for dst are as for
+// SumAxis. The result is returned.
+func MeanAxis(dst []float64, a Matrix, axis Axis) []float64 {
+	dst = SumAxis(dst, a, axis)
+	r, c := a.Dims()
+	n := c
+	if axis == ByCol {
+		n = r
+	}
+	f64.ScalUnitary(1/float64(n), dst)
+	return dst
+}
+
+// MinAxis computes the smallest element of each row or column of a,
+// placing the results into dst. The length requirements for dst are as for
+// SumAxis. MinAxis will panic with ErrShape if the matrix has zero size.
+// The result is returned.
+func MinAxis(dst []float64, a Matrix, axis Axis) []float64 {
+	return extremaAxis(dst, a, axis, func(v, m float64) bool { return v < m })
+}
+
+// MaxAxis computes the largest element of each row or column of a,
+// placing the results into dst. The length requirements for dst are as for
+// SumAxis. MaxAxis will panic with ErrShape if the matrix has zero size.
+// The result is returned.
+func MaxAxis(dst []float64, a Matrix, axis Axis) []float64 {
+	return extremaAxis(dst, a, axis, func(v, m float64) bool { return v > m })
+}
+
+// extremaAxis places the extreme element of each row or column of a into dst
+// according to the ordering given by better.
+func extremaAxis(dst []float64, a Matrix, axis Axis, better func(v, m float64) bool) []float64 {
+	r, c := a.Dims()
+	if r == 0 || c == 0 {
+		panic(ErrShape)
+	}
+	dst = useAxis(dst, axisLen(r, c, axis), axis)
+	if rm, ax, ok := rawAxis(a, axis); ok {
+		if ax == ByRow {
+			for i := range dst {
+				row := rm.Data[i*rm.Stride : i*rm.Stride+rm.Cols]
+				m := row[0]
+				for _, v := range row[1:] {
+					if better(v, m) {
+						m = v
+					}
+				}
+				dst[i] = m
+			}
+			return dst
+		}
+		copy(dst, rm.Data[:rm.Cols])
+		for i := 1; i < rm.Rows; i++ {
+			for j, v := range rm.Data[i*rm.Stride : i*rm.Stride+rm.Cols] {
+				if better(v, dst[j]) {
+					dst[j] = v
+				}
+			}
+		}
+		return dst
+	}
+	if axis == ByRow {
+		for i := range dst {
+			m := a.At(i, 0)
+			for j := 1; j < c; j++ {
+				if v := a.At(i, j); better(v, m) {
+					m = v
+				}
+			}
+			dst[i] = m
+		}
+		return dst
+	}
+	for j := range dst {
+		m := a.At(0, j)
+		for i := 1; i < r; i++ {
+			if v := a.At(i, j); better(v, m) {
+				m = v
+			}
+		}
+		dst[j] = m
+	}
+	return dst
+}
+
+// ArgminAxis computes the index of the smallest element of each row or column
+// of a, placing the results into dst. If axis is ByRow, dst holds the column
+// index of the minimum of each row, and if axis is ByCol, dst holds the row
+// index of the minimum of each column. If an extreme value is repeated, the
+// lowest index is returned.
+//
+// If dst is nil a new slice is allocated, otherwise its length must equal the
+// number of rows or columns of a, respectively, and ArgminAxis will panic if it
+// does not. ArgminAxis will panic with ErrShape if the matrix has zero size.
+// The result is returned.
+func ArgminAxis(dst []int, a Matrix, axis Axis) []int {
+	return argExtremaAxis(dst, a, axis, func(v, m float64) bool { return v < m })
+}
+
+// ArgmaxAxis computes the index of the largest element of each row or column
+// of a, placing the results into dst. The semantics and length requirements for
+// dst are as for ArgminAxis. The result is returned.
+func ArgmaxAxis(dst []int, a Matrix, axis Axis) []int {
+	return argExtremaAxis(dst, a, axis, func(v, m float64) bool { return v > m })
+}
+
+// argExtremaAxis places the index of the extreme element of each row or column
+// of a into dst according to the ordering given by better.
+func argExtremaAxis(dst []int, a Matrix, axis Axis, better func(v, m float64) bool) []int {
+	r, c := a.Dims()
+	if r == 0 || c == 0 {
+		panic(ErrShape)
+	}
+	n := axisLen(r, c, axis)
+	if dst == nil {
+		dst = make([]int, n)
+	} else if len(dst) != n {
+		panic(axisLengthErr(axis))
+	}
+	if rm, ax, ok := rawAxis(a, axis); ok {
+		if ax == ByRow {
+			for i := range dst {
+				row := rm.Data[i*rm.Stride : i*rm.Stride+rm.Cols]
+				idx := 0
+				for j, v := range row {
+					if better(v, row[idx]) {
+						idx = j
+					}
+				}
+				dst[i] = idx
+			}
+			return dst
+		}
+		for j := range dst {
+			dst[j] = 0
+		}
+		for i := 1; i < rm.Rows; i++ {
+			for j, v := range rm.Data[i*rm.Stride : i*rm.Stride+rm.Cols] {
+				if better(v, rm.Data[dst[j]*rm.Stride+j]) {
+					dst[j] = i
+				}
+			}
+		}
+		return dst
+	}
+	if axis == ByRow {
+		for i := range dst {
+			idx := 0
+			m := a.At(i, 0)
+			for j := 1; j < c; j++ {
+				if v := a.At(i, j); better(v, m) {
+					idx, m = j, v
+				}
+			}
+			dst[i] = idx
+		}
+		return dst
+	}
+	for j := range dst {
+		idx := 0
+		m := a.At(0, j)
+		for i := 1; i < r; i++ {
+			if v := a.At(i, j); better(v, m) {
+				idx, m = i, v
+			}
+		}
+		dst[j] = idx
+	}
+	return dst
+}
+
+// NormAxis computes the vector norm of each row or column of a, placing the
+// results into dst. The length requirements for dst are as for SumAxis.
+//
+// Valid norms are:
+//    1 - The sum of the absolute values of the elements.
+//    2 - The Euclidean norm, the square root of the sum of the squares of the elements.
+//  Inf - The maximum absolute value of the elements.
+// NormAxis will panic with ErrNormOrder if an illegal norm order is specified.
+// The result is returned.
+func NormAxis(dst []float64, a Matrix, norm float64, axis Axis) []float64 {
+	if norm != 1 && norm != 2 && !math.IsInf(norm, 1) {
+		panic(ErrNormOrder)
+	}
+	r, c := a.Dims()
+	dst = useAxis(dst, axisLen(r, c, axis), axis)
+	if rm, ax, ok := rawAxis(a, axis); ok {
+		n, inc := rm.Cols, 1
+		if ax == ByCol {
+			n, inc = rm.Rows, rm.Stride
+		}
+		for k := range dst {
+			off := k * rm.Stride
+			if ax == ByCol {
+				off = k
+			}
+			x := rm.Data[off:]
+			switch norm {
+			case 1:
+				if inc == 1 {
+					dst[k] = f64.L1Norm(x[:n])
+				} else {
+					dst[k] = f64.L1NormInc(x, n, inc)
+				}
+			case 2:
+				if inc == 1 {
+					dst[k] = f64.L2NormUnitary(x[:n])
+				} else {
+					dst[k] = f64.L2NormInc(x, uintptr(n), uintptr(inc))
+				}
+			default:
+				var m float64
+				for i := 0; i < n; i++ {
+					m = math.Max(m, math.Abs(x[i*inc]))
+				}
+				dst[k] = m
+			}
+		}
+		return dst
+	}
+	vec := make([]float64, axisLen(c, r, axis))
+	for k := range dst {
+		if axis == ByRow {
+			Row(vec, k, a)
+		} else {
+			Col(vec, k, a)
+		}
+		switch norm {
+		case 1:
+			dst[k] = f64.L1Norm(vec)
+		case 2:
+			dst[k] = f64.L2NormUnitary(vec)
+		default:
+			var m float64
+			for _, v := range vec {
+				m = math.Max(m, math.Abs(v))
+			}
+			dst[k] = m
+		}
+	}
+	return dst
+}
@@ -0,0 +1,201 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// axisVectors returns the rows or columns of a as slices.
+func axisVectors(a Matrix, axis Axis) [][]float64 {
+	r, c := a.Dims()
+	var vecs [][]float64
+	if axis == ByRow {
+		for i := 0; i < r; i++ {
+			vecs = append(vecs, Row(nil, i, a))
+		}
+		return vecs
+	}
+	for j := 0; j < c; j++ {
+		vecs = append(vecs, Col(nil, j, a))
+	}
+	return vecs
+}
+
+// reduceTestMatrices returns a set of differently represented matrices
+// holding the same data.
+func reduceTestMatrices(r, c int, rnd *rand.Rand) map[string]Matrix {
+	a := NewDense(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			a.Set(i, j, rnd.NormFloat64())
+		}
+	}
+	at := NewDense(c, r, nil)
+	at.Copy(a.T())
+	return map[string]Matrix{
+		"Dense":      a,
+		"Transpose":  at.T(),
+		"basic":      asBasicMatrix(a),
+		"SubView":    padded(a, rnd).Slice(1, r+1, 2, c+2),
+		"basicTrans": Transpose{asBasicMatrix(at)},
+	}
+}
+
+// padded returns a matrix with a border around a.
+func padded(a *Dense, rnd *rand.Rand) *Dense {
+	r, c := a.Dims()
+	p := NewDense(r+3, c+4, nil)
+	for i := 0; i < r+3; i++ {
+		for j := 0; j < c+4; j++ {
+			p.Set(i, j, rnd.NormFloat64())
+		}
+	}
+	p.Slice(1, r+1, 2, c+2).(*Dense).Copy(a)
+	return p
+}
+
+func TestReduceAxis(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ r, c int }{
+		{1, 1}, {1, 5}, {5, 1}, {3, 4}, {10, 7},
+	} {
+		for name, a := range reduceTestMatrices(test.r, test.c, rnd) {
+			for _, axis := range []Axis{ByRow, ByCol} {
+				prefix := fmt.Sprintf("%s %dx%d axis=%d", name, test.r, test.c, axis)
+				vecs := axisVectors(a, axis)
+
+				var wantSum, wantMean, wantMin, wantMax, want1, want2, wantInf []float64
+				var wantArgmin, wantArgmax []int
+				for _, v := range vecs {
+					wantSum = append(wantSum, floats.Sum(v))
+					wantMean = append(wantMean, floats.Sum(v)/float64(len(v)))
+					wantMin = append(wantMin, floats.Min(v))
+					wantMax = append(wantMax, floats.Max(v))
+					wantArgmin = append(wantArgmin, floats.MinIdx(v))
+					wantArgmax = append(wantArgmax, floats.MaxIdx(v))
+					want1 = append(want1, floats.Norm(v, 1))
+					want2 = append(want2, floats.Norm(v, 2))
+					wantInf = append(wantInf, floats.Norm(v, math.Inf(1)))
+				}
+
+				for _, f := range []struct {
+					name string
+					got  []float64
+					want []float64
+				}{
+					{name: "SumAxis", got: SumAxis(nil, a, axis), want: wantSum},
+					{name: "MeanAxis", got: MeanAxis(nil, a, axis), want: wantMean},
+					{name: "MinAxis", got: MinAxis(nil, a, axis), want: wantMin},
+					{name: "MaxAxis", got: MaxAxis(nil, a, axis), want: wantMax},
+					{name: "NormAxis 1", got: NormAxis(nil, a, 1, axis), want: want1},
+					{name: "NormAxis 2", got: NormAxis(nil, a, 2, axis), want: want2},
+					{name: "NormAxis Inf", got: NormAxis(nil, a, math.Inf(1), axis), want: wantInf},
+				} {
+					if !floats.EqualApprox(f.got, f.want, 1e-14) {
+						t.Errorf("%s: unexpected %s result: got: %v want: %v", prefix, f.name, f.got, f.want)
+					}
+				}
+				if got := ArgminAxis(nil, a, axis); !intsEqual(got, wantArgmin) {
+					t.Errorf("%s: unexpected ArgminAxis result: got: %v want: %v", prefix, got, wantArgmin)
+				}
+				if got := ArgmaxAxis(nil, a, axis); !intsEqual(got, wantArgmax) {
+					t.Errorf("%s: unexpected ArgmaxAxis result: got: %v want: %v", prefix, got, wantArgmax)
+				}
+
+				if panicked, _ := panics(func() { SumAxis(make([]float64, len(vecs)+1), a, axis) }); !panicked {
+					t.Errorf("%s: expected panic for dst length mismatch", prefix)
+				}
+			}
+		}
+	}
+}
+
+func TestBroadcastAxis(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ r, c int }{
+		{1, 1}, {1, 5}, {5, 1}, {3, 4}, {10, 7},
+	} {
+		for name, a := range reduceTestMatrices(test.r, test.c, rnd) {
+			for _, axis := range []Axis{ByRow, ByCol} {
+				prefix := fmt.Sprintf("%s %dx%d axis=%d", name, test.r, test.c, axis)
+				n := test.c
+				if axis == ByRow {
+					n = test.r
+				}
+				v := NewVecDense(n, nil)
+				for i := 0; i < n; i++ {
+					v.SetVec(i, rnd.Float64()+0.5)
+				}
+				vAt := func(i, j int) float64 {
+					if axis == ByRow {
+						return v.AtVec(i)
+					}
+					return v.AtVec(j)
+				}
+
+				for _, op := range []struct {
+					name string
+					fn   func(m *Dense, a Matrix, v Vector, axis Axis)
+					elem func(a, b float64) float64
+				}{
+					{name: "AddAxis", fn: (*Dense).AddAxis, elem: func(a, b float64) float64 { return a + b }},
+					{name: "SubAxis", fn: (*Dense).SubAxis, elem: func(a, b float64) float64 { return a - b }},
+					{name: "MulElemAxis", fn: (*Dense).MulElemAxis, elem: func(a, b float64) float64 { return a * b }},
+					{name: "DivElemAxis", fn: (*Dense).DivElemAxis, elem: func(a, b float64) float64 { return a / b }},
+				} {
+					want := NewDense(test.r, test.c, nil)
+					for i := 0; i < test.r; i++ {
+						for j := 0; j < test.c; j++ {
+							want.Set(i, j, op.elem(a.At(i, j), vAt(i, j)))
+						}
+					}
+
+					var got Dense
+					op.fn(&got, a, v, axis)
+					if !EqualApprox(&got, want, 1e-14) {
+						t.Errorf("%s: unexpected %s result:\ngot: %v\nwant:%v", prefix, op.name, Formatted(&got), Formatted(want))
+					}
+
+					// Check in-place operation.
+					inPlace := DenseCopyOf(a)
+					op.fn(inPlace, inPlace, v, axis)
+					if !EqualApprox(inPlace, want, 1e-14) {
+						t.Errorf("%s: unexpected in-place %s result", prefix, op.name)
+					}
+
+					// Check operation with a Vector that does not implement RawVectorer.
+					var got2 Dense
+					op.fn(&got2, a, &basicVector{m: v.RawVector().Data}, axis)
+					if !EqualApprox(&got2, want, 1e-14) {
+						t.Errorf("%s: unexpected %s result with basic vector", prefix, op.name)
+					}
+
+					if panicked, _ := panics(func() { op.fn(&Dense{}, a, NewVecDense(n+1, nil), axis) }); !panicked {
+						t.Errorf("%s: expected panic for vector length mismatch in %s", prefix, op.name)
+					}
+				}
+			}
+		}
+	}
+}
+
+func intsEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -42,6 +42,13 @@ const (
 	SVDThin SVDKind = SVDThinU | SVDThinV
 	// SVDFull is a convenience value for computing both full vectors.
 	SVDFull SVDKind = SVDFullU | SVDFullV
+
+	// SVDDivideConquer specifies that the decomposition should be computed
+	// using the divide and conquer method. It may be combined with any of the
+	// other kinds, and is typically several times faster than the default
+	// implicit QR method for large matrices when singular vectors are
+	// computed.
+	SVDDivideConquer SVDKind = SVDFullV << 1
 )
 
 // succFact returns whether the receiver contains a successful factorization.
@@ -68,6 +75,9 @@ func (svd *SVD) succFact() bool {
 // where U~ is of size m×min(m,n), Σ is a diagonal matrix of size min(m,n)×min(m,n)
 // and V~ is of size n×min(m,n).
 //
+// If kind includes SVDDivideConquer, the decomposition is computed using the
+// divide and conquer method. Otherwise the implicit QR method is used.
+//
 // Factorize returns whether the decomposition succeeded. If the decomposition
 // failed, routines that require a successful factorization will panic.
 func (svd *SVD) Factorize(a Matrix, kind SVDKind) (ok bool) {
@@ -75,6 +85,10 @@ func (svd *SVD) Factorize(a Matrix, kind SVDKind) (ok bool) {
 	svd.s = svd.s[:0]
 	svd.kind = kind
 
+	if kind&SVDDivideConquer != 0 {
+		return svd.factorizeDivideConquer(a, kind)
+	}
+
 	m, n := a.Dims()
 	var jobU, jobVT lapack.SVDJob
 
@@ -138,6 +152,64 @@ func (svd *SVD) Factorize(a Matrix, kind SVDKind) (ok bool) {
 	return ok
 }
 
+// factorizeDivideConquer computes the singular value decomposition of a using
+// Gesdd. Gesdd computes either both or neither set of singular vectors, so if
+// any vectors are requested both are computed, and the full vectors if either
+// full set is requested.
+func (svd *SVD) factorizeDivideConquer(a Matrix, kind SVDKind) (ok bool) {
+	m, n := a.Dims()
+	minmn := min(m, n)
+
+	jobz := lapack.SVDNone
+	ur, uc, vr, vc := 0, 0, 0, 0
+	switch {
+	case kind&(SVDFullU|SVDFullV) != 0:
+		jobz = lapack.SVDAll
+		ur, uc, vr, vc = m, m, n, n
+	case kind&(SVDThinU|SVDThinV) != 0:
+		jobz = lapack.SVDStore
+		ur, uc, vr, vc = m, minmn, minmn, n
+	}
+	svd.u = blas64.General{
+		Rows:   ur,
+		Cols:   uc,
+		Stride: max(1, uc),
+		Data:   use(svd.u.Data, ur*uc),
+	}
+	svd.vt = blas64.General{
+		Rows:   vr,
+		Cols:   vc,
+		Stride: max(1, vc),
+		Data:   use(svd.vt.Data, vr*vc),
+	}
+
+	// A is destroyed on call, so copy the matrix.
+	aCopy := DenseCopyOf(a)
+	svd.s = use(svd.s, minmn)
+
+	work := []float64{0}
+	lapack64.Gesdd(jobz, aCopy.mat, svd.u, svd.vt, svd.s, work, -1, nil)
+	work = getFloats(int(work[0]), false)
+	iwork := getInts(8*minmn, false)
+	ok = lapack64.Gesdd(jobz, aCopy.mat, svd.u, svd.vt, svd.s, work, len(work), iwork)
+	putFloats(work)
+	putInts(iwork)
+	if !ok {
+		svd.kind = 0
+		return false
+	}
+
+	// Restrict the computed vectors to the thin vectors if only those were
+	// requested.
+	if kind&SVDThinU != 0 && kind&SVDFullU == 0 {
+		svd.u.Cols = minmn
+	}
+	if kind&SVDThinV != 0 && kind&SVDFullV == 0 {
+		svd.vt.Rows = minmn
+	}
+	return true
+}
+
 // Kind returns the SVDKind of the decomposition. If no decomposition has been
 // computed, Kind returns -1.
 func (svd *SVD) Kind() SVDKind {
@@ -228,7 +300,7 @@ func (svd *SVD) VTo(dst *Dense) {
 		panic(badFact)
 	}
 	kind := svd.kind
-	if kind&SVDThinU == 0 && kind&SVDFullV == 0 {
+	if kind&SVDThinV == 0 && kind&SVDFullV == 0 {
 		panic("svd: v not computed during factorization")
 	}
 	r := svd.vt.Rows
@@ -168,6 +168,129 @@ func TestSVD(t *testing.T) {
 	}
 }
 
+func TestSVDDivideConquer(t *testing.T) {
+	for _, test := range []struct {
+		m, n int
+	}{
+		{5, 5},
+		{5, 3},
+		{3, 5},
+		{150, 150},
+		{200, 150},
+		{150, 200},
+		{400, 60},
+		{60, 400},
+	} {
+		m := test.m
+		n := test.n
+		minmn := min(m, n)
+		a := NewDense(m, n, nil)
+		for i := range a.mat.Data {
+			a.mat.Data[i] = rand.NormFloat64()
+		}
+		aCopy := DenseCopyOf(a)
+
+		var want SVD
+		ok := want.Factorize(a, SVDNone)
+		if !ok {
+			t.Fatalf("SVD factorization failed")
+		}
+		sWant := want.Values(nil)
+
+		for _, kind := range []SVDKind{SVDNone, SVDThin, SVDFull, SVDThinU | SVDFullV, SVDFullU | SVDThinV} {
+			var svd SVD
+			ok := svd.Factorize(a, kind|SVDDivideConquer)
+			if !ok {
+				t.Errorf("m=%d,n=%d,kind=%d: SVD factorization failed", m, n, kind)
+				continue
+			}
+			if !Equal(a, aCopy) {
+				t.Errorf("m=%d,n=%d,kind=%d: A changed during call to SVD", m, n, kind)
+			}
+			if svd.Kind() != kind|SVDDivideConquer {
+				t.Errorf("m=%d,n=%d,kind=%d: unexpected kind %d", m, n, kind, svd.Kind())
+			}
+			s := svd.Values(nil)
+			if !floats.EqualApprox(s, sWant, 1e-10) {
+				t.Errorf("m=%d,n=%d,kind=%d: singular value mismatch with implicit QR", m, n, kind)
+			}
+			if kind == SVDNone {
+				continue
+			}
+
+			var u, v Dense
+			svd.UTo(&u)
+			svd.VTo(&v)
+			wantU, wantV := minmn, minmn
+			if kind&SVDFullU != 0 {
+				wantU = m
+			}
+			if kind&SVDFullV != 0 {
+				wantV = n
+			}
+			if r, c := u.Dims(); r != m || c != wantU {
+				t.Errorf("m=%d,n=%d,kind=%d: unexpected U shape %d×%d", m, n, kind, r, c)
+			}
+			if r, c := v.Dims(); r != n || c != wantV {
+				t.Errorf("m=%d,n=%d,kind=%d: unexpected V shape %d×%d", m, n, kind, r, c)
+			}
+
+			sigma := NewDense(minmn, minmn, nil)
+			for i := 0; i < minmn; i++ {
+				sigma.Set(i, i, s[i])
+			}
+			var ans Dense
+			ans.Product(u.Slice(0, m, 0, minmn), sigma, v.Slice(0, n, 0, minmn).T())
+			if !EqualApprox(&ans, a, 1e-10) {
+				t.Errorf("m=%d,n=%d,kind=%d: A reconstruction mismatch", m, n, kind)
+			}
+		}
+	}
+}
+
+func TestSVDVectorsComputed(t *testing.T) {
+	a := NewDense(5, 3, nil)
+	for i := range a.mat.Data {
+		a.mat.Data[i] = rand.NormFloat64()
+	}
+	for _, test := range []struct {
+		kind         SVDKind
+		hasU, hasV   bool
+		wantU, wantV int
+	}{
+		{kind: SVDThinU, hasU: true, wantU: 3},
+		{kind: SVDFullU, hasU: true, wantU: 5},
+		{kind: SVDThinV, hasV: true, wantV: 3},
+		{kind: SVDFullV, hasV: true, wantV: 3},
+		{kind: SVDThinU | SVDFullV, hasU: true, hasV: true, wantU: 3, wantV: 3},
+	} {
+		var svd SVD
+		ok := svd.Factorize(a, test.kind)
+		if !ok {
+			t.Fatalf("kind=%d: SVD factorization failed", test.kind)
+		}
+		var u, v Dense
+		panicked, _ := panics(func() { svd.UTo(&u) })
+		if panicked == test.hasU {
+			t.Errorf("kind=%d: unexpected UTo panic state: got:%t want:%t", test.kind, panicked, !test.hasU)
+		}
+		if test.hasU {
+			if _, c := u.Dims(); c != test.wantU {
+				t.Errorf("kind=%d: unexpected number of columns of U: got:%d want:%d", test.kind, c, test.wantU)
+			}
+		}
+		panicked, _ = panics(func() { svd.VTo(&v) })
+		if panicked == test.hasV {
+			t.Errorf("kind=%d: unexpected VTo panic state: got:%t want:%t", test.kind, panicked, !test.hasV)
+		}
+		if test.hasV {
+			if _, c := v.Dims(); c != test.wantV {
+				t.Errorf("kind=%d: unexpected number of columns of V: got:%d want:%d", test.kind, c, test.wantV)
+			}
+		}
+	}
+}
+
 func extractSVD(svd *SVD) (s []float64, u, v *Dense) {
 	u = &Dense{}
 	svd.UTo(u)
@@ -0,0 +1,14 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package poly provides real polynomials and finite series of orthogonal
+// polynomials.
+//
+// Polynomials are represented in the power basis by the Polynomial type,
+// which supports evaluation, arithmetic, division, composition, root
+// finding and least squares fitting. The Chebyshev and Legendre types
+// represent finite series of Chebyshev polynomials of the first kind and
+// of Legendre polynomials on [-1, 1], and can be converted to and from
+// the power basis.
+package poly // import "gonum.org/v1/gonum/poly"
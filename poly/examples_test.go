@@ -0,0 +1,48 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package poly_test
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"gonum.org/v1/gonum/poly"
+)
+
+func ExamplePolynomial_Roots() {
+	// p(x) = (x - 1)(x - 2)(x + 3) = x^3 - 7x + 6
+	p := poly.Polynomial{6, -7, 0, 1}
+	roots, err := p.Roots()
+	if err != nil {
+		log.Fatal(err)
+	}
+	re := make([]float64, len(roots))
+	for i, z := range roots {
+		re[i] = real(z)
+	}
+	sort.Float64s(re)
+	fmt.Printf("roots: %.4f\n", re)
+
+	// Output:
+	// roots: [-3.0000 1.0000 2.0000]
+}
+
+func ExampleFit() {
+	xs := []float64{0, 1, 2, 3, 4}
+	ys := []float64{1.1, 1.9, 5.2, 9.8, 17.1}
+	p, err := poly.Fit(xs, ys, nil, 2)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("power coefficients: %.3f\n", p)
+	fmt.Printf("Chebyshev coefficients: %.3f\n", p.Chebyshev())
+	fmt.Printf("p(5) = %.3f\n", p.Eval(5))
+
+	// Output:
+	// power coefficients: [1.083 -0.096 1.021]
+	// Chebyshev coefficients: [1.594 -0.096 0.511]
+	// p(5) = 26.140
+}
@@ -0,0 +1,89 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package poly
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Fit returns the polynomial of at most the given degree which minimizes
+// the weighted sum of squared residuals
+//  Σ_i weights[i] * (ys[i] - p(xs[i]))²
+// If weights is nil, all weights are one.
+//
+// To reduce the effect of ill-conditioning, the least squares problem is
+// solved in the basis of Chebyshev polynomials on the range of xs, and the
+// solution is converted to the power basis.
+//
+// Fit panics if len(xs) != len(ys), if weights is not nil and
+// len(weights) != len(xs), if any weight is negative or if degree < 0. It
+// returns an error if the data do not determine the polynomial, for example
+// if there are fewer than degree+1 distinct values in xs with positive
+// weight.
+func Fit(xs, ys, weights []float64, degree int) (Polynomial, error) {
+	if len(xs) != len(ys) {
+		panic("poly: slice length mismatch")
+	}
+	if weights != nil && len(weights) != len(xs) {
+		panic("poly: slice length mismatch")
+	}
+	if degree < 0 {
+		panic("poly: negative degree")
+	}
+	for _, w := range weights {
+		if w < 0 {
+			panic("poly: negative weight")
+		}
+	}
+	n := degree + 1
+	if len(xs) < n {
+		return nil, errors.New("poly: too few points for degree")
+	}
+
+	lo := math.Inf(1)
+	hi := math.Inf(-1)
+	for _, x := range xs {
+		lo = math.Min(lo, x)
+		hi = math.Max(hi, x)
+	}
+	// Map [lo, hi] to [-1, 1] with t = a + b*x.
+	a, b := 0.0, 1.0
+	if hi > lo {
+		b = 2 / (hi - lo)
+		a = -(hi + lo) / (hi - lo)
+	}
+
+	v := mat.NewDense(len(xs), n, nil)
+	rhs := mat.NewVecDense(len(xs), nil)
+	for i, x := range xs {
+		w := 1.0
+		if weights != nil {
+			w = math.Sqrt(weights[i])
+		}
+		t := a + b*x
+		row := v.RawRowView(i)
+		row[0] = w
+		if n > 1 {
+			row[1] = w * t
+		}
+		for k := 2; k < n; k++ {
+			row[k] = 2*t*row[k-1] - row[k-2]
+		}
+		rhs.SetVec(i, w*ys[i])
+	}
+	var qr mat.QR
+	qr.Factorize(v)
+	var c mat.VecDense
+	err := qr.SolveVecTo(&c, false, rhs)
+	if err != nil {
+		return nil, err
+	}
+	coeffs := make(Chebyshev, n)
+	copy(coeffs, c.RawVector().Data)
+	return Compose(coeffs.Polynomial(), Polynomial{a, b}), nil
+}
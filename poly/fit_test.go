@@ -0,0 +1,108 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package poly
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat"
+)
+
+func TestFit(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	// Exact data are fitted exactly, including on ranges far from zero.
+	for _, test := range []struct {
+		degree int
+		lo, hi float64
+	}{
+		{degree: 0, lo: -1, hi: 1},
+		{degree: 1, lo: 0, hi: 10},
+		{degree: 3, lo: -2, hi: 3},
+		{degree: 5, lo: 9, hi: 11},
+	} {
+		p := randomPolynomial(rnd, test.degree)
+		xs := make([]float64, 30)
+		ys := make([]float64, len(xs))
+		for i := range xs {
+			xs[i] = test.lo + (test.hi-test.lo)*rnd.Float64()
+			ys[i] = p.Eval(xs[i])
+		}
+		for _, degree := range []int{test.degree, test.degree + 2} {
+			got, err := Fit(xs, ys, nil, degree)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				continue
+			}
+			for _, x := range xs {
+				if v, want := got.Eval(x), p.Eval(x); math.Abs(v-want) > 1e-8*math.Max(1, math.Abs(want)) {
+					t.Errorf("degree %d fit of degree %d data: unexpected value at %v: got:%v want:%v",
+						degree, test.degree, x, v, want)
+				}
+			}
+		}
+	}
+
+	// The weighted straight line fit agrees with linear regression.
+	xs := make([]float64, 50)
+	ys := make([]float64, len(xs))
+	weights := make([]float64, len(xs))
+	for i := range xs {
+		xs[i] = 10 * rnd.Float64()
+		ys[i] = 2 - 0.5*xs[i] + rnd.NormFloat64()
+		weights[i] = rnd.Float64()
+	}
+	p, err := Fit(xs, ys, weights, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	alpha, beta := stat.LinearRegression(xs, ys, weights, false)
+	if !floats.EqualApprox(p, []float64{alpha, beta}, 1e-12) {
+		t.Errorf("unexpected line fit: got:%v want:%v", p, []float64{alpha, beta})
+	}
+
+	// A constant fit is the weighted mean, even with a single
+	// distinct x.
+	p, err = Fit([]float64{2, 2, 2}, []float64{1, 2, 6}, []float64{1, 1, 2}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !floats.EqualApprox(p, []float64{3.75}, 1e-14) {
+		t.Errorf("unexpected constant fit: got:%v want:[3.75]", p)
+	}
+
+	// Underdetermined fits are reported.
+	for _, test := range []struct {
+		xs, ys []float64
+		degree int
+	}{
+		{xs: []float64{0, 1}, ys: []float64{0, 1}, degree: 2},
+		{xs: []float64{1, 1, 1, 2}, ys: []float64{0, 1, 2, 3}, degree: 2},
+	} {
+		_, err := Fit(test.xs, test.ys, nil, test.degree)
+		if err == nil {
+			t.Errorf("expected error for underdetermined fit of %v", test.xs)
+		}
+	}
+
+	for _, test := range []struct {
+		name            string
+		xs, ys, weights []float64
+		degree          int
+	}{
+		{name: "different lengths", xs: []float64{0, 1}, ys: []float64{0}},
+		{name: "different weight length", xs: []float64{0, 1}, ys: []float64{0, 1}, weights: []float64{1}},
+		{name: "negative weight", xs: []float64{0, 1}, ys: []float64{0, 1}, weights: []float64{1, -1}},
+		{name: "negative degree", xs: []float64{0, 1}, ys: []float64{0, 1}, degree: -1},
+	} {
+		if !panics(func() { Fit(test.xs, test.ys, test.weights, test.degree) }) {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+}
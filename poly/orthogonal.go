@@ -0,0 +1,176 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package poly
+
+// Chebyshev is a finite series of Chebyshev polynomials of the first kind
+//  c(x) = c[0]*T_0(x) + c[1]*T_1(x) + ... + c[n]*T_n(x)
+// where
+//  T_0(x) = 1, T_1(x) = x, T_{k+1}(x) = 2*x*T_k(x) - T_{k-1}(x).
+// The Chebyshev polynomials are orthogonal on [-1, 1] with respect to the
+// weight 1/sqrt(1-x^2).
+type Chebyshev []float64
+
+// Eval returns the value of the series c at x, computed by Clenshaw's
+// recurrence.
+func (c Chebyshev) Eval(x float64) float64 {
+	// b1 and b2 hold b_{k+1} and b_{k+2} of the recurrence
+	//  b_k = c[k] + 2*x*b_{k+1} - b_{k+2}.
+	var b1, b2 float64
+	for k := len(c) - 1; k >= 1; k-- {
+		b1, b2 = c[k]+2*x*b1-b2, b1
+	}
+	if len(c) == 0 {
+		return 0
+	}
+	return c[0] + x*b1 - b2
+}
+
+// Polynomial returns the series c in the power basis.
+func (c Chebyshev) Polynomial() Polynomial {
+	p := make(Polynomial, len(c))
+	// prev and curr hold the power basis coefficients of T_{k-1}
+	// and T_k.
+	prev := make([]float64, len(c))
+	curr := make([]float64, len(c))
+	for k, v := range c {
+		switch k {
+		case 0:
+			curr[0] = 1
+		case 1:
+			prev[0] = 1
+			curr[0] = 0
+			curr[1] = 1
+		default:
+			// T_{k} = 2*x*T_{k-1} - T_{k-2}, computed in place
+			// into prev.
+			for i := k; i >= 0; i-- {
+				var xt float64
+				if i > 0 {
+					xt = 2 * curr[i-1]
+				}
+				prev[i] = xt - prev[i]
+			}
+			prev, curr = curr, prev
+		}
+		for i, t := range curr[:k+1] {
+			p[i] += v * t
+		}
+	}
+	return p.trim()
+}
+
+// Chebyshev returns p as a series of Chebyshev polynomials of the first
+// kind.
+func (p Polynomial) Chebyshev() Chebyshev {
+	p = p.trim()
+	c := make(Chebyshev, len(p))
+	// Evaluate p by Horner's method in the Chebyshev basis, using
+	//  x*T_0 = T_1 and x*T_k = (T_{k+1} + T_{k-1})/2.
+	tmp := make([]float64, len(p))
+	for i := len(p) - 1; i >= 0; i-- {
+		n := len(p) - 1 - i
+		for k := range tmp[:n+1] {
+			tmp[k] = 0
+		}
+		for k, v := range c[:n] {
+			if k == 0 {
+				tmp[1] += v
+				continue
+			}
+			tmp[k+1] += v / 2
+			tmp[k-1] += v / 2
+		}
+		copy(c, tmp[:n+1])
+		c[0] += p[i]
+	}
+	return Chebyshev(Polynomial(c).trim())
+}
+
+// Legendre is a finite series of Legendre polynomials
+//  l(x) = l[0]*P_0(x) + l[1]*P_1(x) + ... + l[n]*P_n(x)
+// where
+//  P_0(x) = 1, P_1(x) = x, (k+1)*P_{k+1}(x) = (2k+1)*x*P_k(x) - k*P_{k-1}(x).
+// The Legendre polynomials are orthogonal on [-1, 1] with respect to a
+// unit weight.
+type Legendre []float64
+
+// Eval returns the value of the series l at x, computed by Clenshaw's
+// recurrence.
+func (l Legendre) Eval(x float64) float64 {
+	// b1 and b2 hold b_{k+1} and b_{k+2} of the recurrence
+	//  b_k = l[k] + α_k*b_{k+1} + β_{k+1}*b_{k+2}
+	// with α_k = (2k+1)*x/(k+1) and β_k = -k/(k+1).
+	var b1, b2 float64
+	for k := len(l) - 1; k >= 1; k-- {
+		fk := float64(k)
+		alpha := (2*fk + 1) * x / (fk + 1)
+		beta := -(fk + 1) / (fk + 2)
+		b1, b2 = l[k]+alpha*b1+beta*b2, b1
+	}
+	if len(l) == 0 {
+		return 0
+	}
+	return l[0] + x*b1 - b2/2
+}
+
+// Polynomial returns the series l in the power basis.
+func (l Legendre) Polynomial() Polynomial {
+	p := make(Polynomial, len(l))
+	// prev and curr hold the power basis coefficients of P_{k-1}
+	// and P_k.
+	prev := make([]float64, len(l))
+	curr := make([]float64, len(l))
+	for k, v := range l {
+		switch k {
+		case 0:
+			curr[0] = 1
+		case 1:
+			prev[0] = 1
+			curr[0] = 0
+			curr[1] = 1
+		default:
+			// k*P_k = (2k-1)*x*P_{k-1} - (k-1)*P_{k-2}, computed
+			// in place into prev.
+			fk := float64(k)
+			for i := k; i >= 0; i-- {
+				var xp float64
+				if i > 0 {
+					xp = (2*fk - 1) * curr[i-1]
+				}
+				prev[i] = (xp - (fk-1)*prev[i]) / fk
+			}
+			prev, curr = curr, prev
+		}
+		for i, t := range curr[:k+1] {
+			p[i] += v * t
+		}
+	}
+	return p.trim()
+}
+
+// Legendre returns p as a series of Legendre polynomials.
+func (p Polynomial) Legendre() Legendre {
+	p = p.trim()
+	l := make(Legendre, len(p))
+	// Evaluate p by Horner's method in the Legendre basis, using
+	//  x*P_k = ((k+1)*P_{k+1} + k*P_{k-1})/(2k+1).
+	tmp := make([]float64, len(p))
+	for i := len(p) - 1; i >= 0; i-- {
+		n := len(p) - 1 - i
+		for k := range tmp[:n+1] {
+			tmp[k] = 0
+		}
+		for k, v := range l[:n] {
+			fk := float64(k)
+			tmp[k+1] += v * (fk + 1) / (2*fk + 1)
+			if k > 0 {
+				tmp[k-1] += v * fk / (2*fk + 1)
+			}
+		}
+		copy(l, tmp[:n+1])
+		l[0] += p[i]
+	}
+	return Legendre(Polynomial(l).trim())
+}
@@ -0,0 +1,105 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package poly
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestChebyshev(t *testing.T) {
+	// The power basis coefficients of T_0 to T_5.
+	want := []Polynomial{
+		{1},
+		{0, 1},
+		{-1, 0, 2},
+		{0, -3, 0, 4},
+		{1, 0, -8, 0, 8},
+		{0, 5, 0, -20, 0, 16},
+	}
+	for k, w := range want {
+		c := make(Chebyshev, k+1)
+		c[k] = 1
+		if got := c.Polynomial(); !floats.Equal(got, w) {
+			t.Errorf("unexpected power basis of T_%d: got:%v want:%v", k, got, w)
+		}
+		if got := w.Chebyshev(); !floats.Equal(got, c) {
+			t.Errorf("unexpected Chebyshev basis of T_%d: got:%v want:%v", k, got, c)
+		}
+		for _, x := range []float64{-1, -0.3, 0, 0.7, 1} {
+			// T_k(cos θ) = cos(kθ).
+			want := math.Cos(float64(k) * math.Acos(x))
+			if got := c.Eval(x); math.Abs(got-want) > 1e-14 {
+				t.Errorf("unexpected value of T_%d at %v: got:%v want:%v", k, x, got, want)
+			}
+		}
+	}
+	if got := Chebyshev(nil).Eval(0.5); got != 0 {
+		t.Errorf("unexpected value of empty series: got:%v", got)
+	}
+}
+
+func TestLegendre(t *testing.T) {
+	// The power basis coefficients of P_0 to P_5.
+	want := []Polynomial{
+		{1},
+		{0, 1},
+		{-0.5, 0, 1.5},
+		{0, -1.5, 0, 2.5},
+		{3.0 / 8, 0, -30.0 / 8, 0, 35.0 / 8},
+		{0, 15.0 / 8, 0, -70.0 / 8, 0, 63.0 / 8},
+	}
+	for k, w := range want {
+		l := make(Legendre, k+1)
+		l[k] = 1
+		if got := l.Polynomial(); !floats.EqualApprox(got, w, 1e-15) {
+			t.Errorf("unexpected power basis of P_%d: got:%v want:%v", k, got, w)
+		}
+		if got := w.Legendre(); !floats.EqualApprox(got, l, 1e-15) {
+			t.Errorf("unexpected Legendre basis of P_%d: got:%v want:%v", k, got, l)
+		}
+		for _, x := range []float64{-1, -0.3, 0, 0.7, 1} {
+			if got, want := l.Eval(x), w.Eval(x); math.Abs(got-want) > 1e-14 {
+				t.Errorf("unexpected value of P_%d at %v: got:%v want:%v", k, x, got, want)
+			}
+		}
+	}
+	if got := Legendre(nil).Eval(0.5); got != 0 {
+		t.Errorf("unexpected value of empty series: got:%v", got)
+	}
+}
+
+func TestBasisConversion(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for degree := 0; degree < 12; degree++ {
+		p := randomPolynomial(rnd, degree)
+		c := p.Chebyshev()
+		l := p.Legendre()
+		if got := c.Polynomial(); !equalApprox(got, p, 1e-12) {
+			t.Errorf("degree %d: Chebyshev round trip mismatch: got:%v want:%v", degree, got, p)
+		}
+		if got := l.Polynomial(); !equalApprox(got, p, 1e-12) {
+			t.Errorf("degree %d: Legendre round trip mismatch: got:%v want:%v", degree, got, p)
+		}
+		// Conversion between the orthogonal bases through the
+		// power basis.
+		if got := l.Polynomial().Chebyshev(); !equalApprox(Polynomial(got), Polynomial(c), 1e-12) {
+			t.Errorf("degree %d: Legendre to Chebyshev mismatch: got:%v want:%v", degree, got, c)
+		}
+		for _, x := range []float64{-1, -0.5, 0.1, 0.9, 1} {
+			want := p.Eval(x)
+			if got := c.Eval(x); math.Abs(got-want) > 1e-12 {
+				t.Errorf("degree %d: unexpected Chebyshev value at %v: got:%v want:%v", degree, x, got, want)
+			}
+			if got := l.Eval(x); math.Abs(got-want) > 1e-12 {
+				t.Errorf("degree %d: unexpected Legendre value at %v: got:%v want:%v", degree, x, got, want)
+			}
+		}
+	}
+}
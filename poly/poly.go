@@ -0,0 +1,199 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package poly
+
+// Polynomial is a real polynomial
+//  p(x) = p[0] + p[1]*x + p[2]*x^2 + ... + p[n]*x^n
+// stored as its coefficients in order of increasing degree. A Polynomial
+// with no coefficients or only zero coefficients is the zero polynomial.
+//
+// The functions and methods returning a Polynomial allocate a new slice for
+// the result and return it without trailing zero coefficients.
+type Polynomial []float64
+
+// Degree returns the degree of p, ignoring trailing zero coefficients.
+// The degree of the zero polynomial is -1.
+func (p Polynomial) Degree() int {
+	n := len(p) - 1
+	for n >= 0 && p[n] == 0 {
+		n--
+	}
+	return n
+}
+
+// trim returns p without trailing zero coefficients.
+func (p Polynomial) trim() Polynomial {
+	return p[:p.Degree()+1]
+}
+
+// Eval returns the value of p at x, computed by Horner's method.
+func (p Polynomial) Eval(x float64) float64 {
+	var v float64
+	for i := len(p) - 1; i >= 0; i-- {
+		v = v*x + p[i]
+	}
+	return v
+}
+
+// EvalComplex returns the value of p at the complex point z, computed by
+// Horner's method.
+func (p Polynomial) EvalComplex(z complex128) complex128 {
+	var v complex128
+	for i := len(p) - 1; i >= 0; i-- {
+		v = v*z + complex(p[i], 0)
+	}
+	return v
+}
+
+// EvalDerivatives stores in dst the value of p at x followed by the
+// first len(dst)-1 derivatives of p at x, computed by Horner's method,
+// and returns dst. EvalDerivatives panics if len(dst) == 0.
+func (p Polynomial) EvalDerivatives(dst []float64, x float64) []float64 {
+	if len(dst) == 0 {
+		panic("poly: zero length destination")
+	}
+	for i := range dst {
+		dst[i] = 0
+	}
+	nd := len(dst) - 1
+	n := len(p) - 1
+	for i := n; i >= 0; i-- {
+		// Only the first n-i derivatives of the partial
+		// polynomial are nonzero.
+		m := n - i
+		if m > nd {
+			m = nd
+		}
+		for j := m; j > 0; j-- {
+			dst[j] = dst[j]*x + dst[j-1]
+		}
+		dst[0] = dst[0]*x + p[i]
+	}
+	// dst[j] holds the j-th derivative divided by j!.
+	f := 1.0
+	for j := 2; j <= nd; j++ {
+		f *= float64(j)
+		dst[j] *= f
+	}
+	return dst
+}
+
+// Derivative returns the derivative of p.
+func (p Polynomial) Derivative() Polynomial {
+	n := p.Degree()
+	if n < 1 {
+		return Polynomial{}
+	}
+	d := make(Polynomial, n)
+	for i := range d {
+		d[i] = float64(i+1) * p[i+1]
+	}
+	return d
+}
+
+// Integral returns the antiderivative of p which takes the value c at
+// zero.
+func (p Polynomial) Integral(c float64) Polynomial {
+	n := p.Degree()
+	q := make(Polynomial, n+2)
+	q[0] = c
+	for i := 0; i <= n; i++ {
+		q[i+1] = p[i] / float64(i+1)
+	}
+	return q.trim()
+}
+
+// Add returns the sum of p and q.
+func Add(p, q Polynomial) Polynomial {
+	if len(p) < len(q) {
+		p, q = q, p
+	}
+	r := make(Polynomial, len(p))
+	copy(r, p)
+	for i, v := range q {
+		r[i] += v
+	}
+	return r.trim()
+}
+
+// Sub returns the difference p - q.
+func Sub(p, q Polynomial) Polynomial {
+	n := len(p)
+	if len(q) > n {
+		n = len(q)
+	}
+	r := make(Polynomial, n)
+	copy(r, p)
+	for i, v := range q {
+		r[i] -= v
+	}
+	return r.trim()
+}
+
+// Scale returns the polynomial f*p.
+func Scale(f float64, p Polynomial) Polynomial {
+	r := make(Polynomial, len(p))
+	for i, v := range p {
+		r[i] = f * v
+	}
+	return r.trim()
+}
+
+// Mul returns the product of p and q.
+func Mul(p, q Polynomial) Polynomial {
+	p = p.trim()
+	q = q.trim()
+	if len(p) == 0 || len(q) == 0 {
+		return Polynomial{}
+	}
+	r := make(Polynomial, len(p)+len(q)-1)
+	for i, u := range p {
+		for j, v := range q {
+			r[i+j] += u * v
+		}
+	}
+	return r.trim()
+}
+
+// Div returns the quotient and remainder of the polynomial division of p
+// by q, which satisfy
+//  p = quo*q + rem
+// with the degree of rem less than the degree of q. Div panics if q is the
+// zero polynomial.
+func Div(p, q Polynomial) (quo, rem Polynomial) {
+	q = q.trim()
+	m := len(q) - 1
+	if m < 0 {
+		panic("poly: division by zero polynomial")
+	}
+	rem = make(Polynomial, len(p))
+	copy(rem, p)
+	rem = rem.trim()
+	n := len(rem) - 1
+	if n < m {
+		return Polynomial{}, rem
+	}
+	quo = make(Polynomial, n-m+1)
+	lead := q[m]
+	for k := n - m; k >= 0; k-- {
+		c := rem[k+m] / lead
+		quo[k] = c
+		for j := 0; j < m; j++ {
+			rem[k+j] -= c * q[j]
+		}
+		rem[k+m] = 0
+	}
+	return quo.trim(), rem[:m].trim()
+}
+
+// Compose returns the composition p∘q, the polynomial p(q(x)).
+func Compose(p, q Polynomial) Polynomial {
+	p = p.trim()
+	r := Polynomial{}
+	for i := len(p) - 1; i >= 0; i-- {
+		r = Add(Mul(r, q), Polynomial{p[i]})
+	}
+	return r
+}
@@ -0,0 +1,207 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package poly
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		r := recover()
+		panicked = r != nil
+	}()
+	fn()
+	return
+}
+
+func randomPolynomial(rnd *rand.Rand, degree int) Polynomial {
+	p := make(Polynomial, degree+1)
+	for i := range p {
+		p[i] = rnd.NormFloat64()
+	}
+	return p
+}
+
+func equalApprox(p, q Polynomial, tol float64) bool {
+	p = p.trim()
+	q = q.trim()
+	if len(p) != len(q) {
+		return false
+	}
+	return floats.EqualApprox(p, q, tol)
+}
+
+func TestDegree(t *testing.T) {
+	for _, test := range []struct {
+		p    Polynomial
+		want int
+	}{
+		{p: nil, want: -1},
+		{p: Polynomial{}, want: -1},
+		{p: Polynomial{0, 0}, want: -1},
+		{p: Polynomial{3}, want: 0},
+		{p: Polynomial{1, 2, 0}, want: 1},
+		{p: Polynomial{0, 0, 0, 4}, want: 3},
+	} {
+		if got := test.p.Degree(); got != test.want {
+			t.Errorf("unexpected degree of %v: got:%d want:%d", test.p, got, test.want)
+		}
+	}
+}
+
+func TestEval(t *testing.T) {
+	// p(x) = 1 - 2x + 3x^3
+	p := Polynomial{1, -2, 0, 3}
+	for _, test := range []struct {
+		x    float64
+		want []float64
+	}{
+		{x: 0, want: []float64{1, -2, 0, 18, 0, 0}},
+		{x: 1, want: []float64{2, 7, 18, 18, 0, 0}},
+		{x: -2, want: []float64{-19, 34, -36, 18, 0, 0}},
+		{x: 0.5, want: []float64{0.375, 0.25, 9, 18, 0, 0}},
+	} {
+		if got := p.Eval(test.x); got != test.want[0] {
+			t.Errorf("unexpected value at %v: got:%v want:%v", test.x, got, test.want[0])
+		}
+		if got := p.EvalComplex(complex(test.x, 0)); got != complex(test.want[0], 0) {
+			t.Errorf("unexpected complex value at %v: got:%v want:%v", test.x, got, test.want[0])
+		}
+		for n := 1; n <= len(test.want); n++ {
+			got := p.EvalDerivatives(make([]float64, n), test.x)
+			if !floats.EqualApprox(got, test.want[:n], 1e-14) {
+				t.Errorf("unexpected derivatives at %v: got:%v want:%v", test.x, got, test.want[:n])
+			}
+		}
+	}
+	if got := p.EvalComplex(1i); got != complex(1, -5) {
+		t.Errorf("unexpected value at i: got:%v want:%v", got, complex(1, -5))
+	}
+	if got := Polynomial(nil).Eval(2); got != 0 {
+		t.Errorf("unexpected value of zero polynomial: got:%v", got)
+	}
+	if !panics(func() { p.EvalDerivatives(nil, 0) }) {
+		t.Errorf("expected panic for zero length destination")
+	}
+}
+
+func TestDerivativeIntegral(t *testing.T) {
+	p := Polynomial{1, -2, 0, 3}
+	if got, want := p.Derivative(), (Polynomial{-2, 0, 9}); !floats.Equal(got, want) {
+		t.Errorf("unexpected derivative: got:%v want:%v", got, want)
+	}
+	if got, want := p.Integral(5), (Polynomial{5, 1, -1, 0, 0.75}); !floats.Equal(got, want) {
+		t.Errorf("unexpected integral: got:%v want:%v", got, want)
+	}
+	if got := (Polynomial{4}).Derivative(); len(got) != 0 {
+		t.Errorf("unexpected derivative of constant: got:%v", got)
+	}
+	if got := Polynomial(nil).Integral(0); len(got) != 0 {
+		t.Errorf("unexpected integral of zero polynomial: got:%v", got)
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for degree := 0; degree < 8; degree++ {
+		p := randomPolynomial(rnd, degree)
+		if got := p.Integral(rnd.NormFloat64()).Derivative(); !equalApprox(got, p, 1e-14) {
+			t.Errorf("derivative of integral does not match: got:%v want:%v", got, p)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	p := Polynomial{1, 2, 3}
+	q := Polynomial{-1, 0, -3, 4}
+	for _, test := range []struct {
+		name string
+		got  Polynomial
+		want Polynomial
+	}{
+		{name: "Add", got: Add(p, q), want: Polynomial{0, 2, 0, 4}},
+		{name: "Add cancel", got: Add(p, Scale(-1, p)), want: Polynomial{}},
+		{name: "Sub", got: Sub(p, q), want: Polynomial{2, 2, 6, -4}},
+		{name: "Sub same", got: Sub(q, q), want: Polynomial{}},
+		{name: "Scale", got: Scale(2, p), want: Polynomial{2, 4, 6}},
+		{name: "Scale zero", got: Scale(0, p), want: Polynomial{}},
+		{name: "Mul", got: Mul(p, q), want: Polynomial{-1, -2, -6, -2, -1, 12}},
+		{name: "Mul zero", got: Mul(p, nil), want: Polynomial{}},
+		{name: "Compose", got: Compose(p, Polynomial{1, 1}), want: Polynomial{6, 8, 3}},
+		{name: "Compose constant", got: Compose(p, Polynomial{2}), want: Polynomial{17}},
+	} {
+		if !floats.Equal(test.got, test.want) {
+			t.Errorf("unexpected result for %s: got:%v want:%v", test.name, test.got, test.want)
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		p := randomPolynomial(rnd, rnd.Intn(6))
+		q := randomPolynomial(rnd, rnd.Intn(4))
+		for _, x := range []float64{-1.5, 0, 0.3, 2} {
+			if got, want := Mul(p, q).Eval(x), p.Eval(x)*q.Eval(x); math.Abs(got-want) > 1e-12*math.Max(1, math.Abs(want)) {
+				t.Errorf("unexpected product value at %v: got:%v want:%v", x, got, want)
+			}
+			if got, want := Compose(p, q).Eval(x), p.Eval(q.Eval(x)); math.Abs(got-want) > 1e-12*math.Max(1, math.Abs(want)) {
+				t.Errorf("unexpected composition value at %v: got:%v want:%v", x, got, want)
+			}
+		}
+	}
+}
+
+func TestDiv(t *testing.T) {
+	for _, test := range []struct {
+		p, q     Polynomial
+		quo, rem Polynomial
+	}{
+		{
+			// (x^3 - 2x^2 - 4) / (x - 3) = x^2 + x + 3 rem 5
+			p: Polynomial{-4, 0, -2, 1}, q: Polynomial{-3, 1},
+			quo: Polynomial{3, 1, 1}, rem: Polynomial{5},
+		},
+		{
+			p: Polynomial{1, 2}, q: Polynomial{0, 0, 1},
+			quo: Polynomial{}, rem: Polynomial{1, 2},
+		},
+		{
+			p: Polynomial{2, 4, 6, 0}, q: Polynomial{2, 0},
+			quo: Polynomial{1, 2, 3}, rem: Polynomial{},
+		},
+		{
+			// (x^2 - 1) / (x + 1) = x - 1
+			p: Polynomial{-1, 0, 1}, q: Polynomial{1, 1},
+			quo: Polynomial{-1, 1}, rem: Polynomial{},
+		},
+	} {
+		quo, rem := Div(test.p, test.q)
+		if !floats.Equal(quo, test.quo) || !floats.Equal(rem, test.rem) {
+			t.Errorf("unexpected division of %v by %v: got:(%v, %v) want:(%v, %v)",
+				test.p, test.q, quo, rem, test.quo, test.rem)
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		p := randomPolynomial(rnd, rnd.Intn(8))
+		q := randomPolynomial(rnd, rnd.Intn(4))
+		// Keep the division well conditioned.
+		q[len(q)-1] = 1 + math.Abs(q[len(q)-1])
+		quo, rem := Div(p, q)
+		if rem.Degree() >= q.Degree() && rem.Degree() >= 0 {
+			t.Errorf("remainder degree %d not less than divisor degree %d", rem.Degree(), q.Degree())
+		}
+		if got := Add(Mul(quo, q), rem); !equalApprox(got, p, 1e-10) {
+			t.Errorf("quo*q + rem != p: got:%v want:%v", got, p)
+		}
+	}
+
+	if !panics(func() { Div(Polynomial{1}, Polynomial{0}) }) {
+		t.Errorf("expected panic for division by zero polynomial")
+	}
+}
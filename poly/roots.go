@@ -0,0 +1,55 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package poly
+
+import (
+	"errors"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Roots returns the complex roots of p, repeated according to their
+// multiplicity, in no particular order. The roots are computed as the
+// eigenvalues of the companion matrix of p. Roots returns no roots if the
+// degree of p is less than one, and an error if the eigenvalue
+// decomposition fails.
+func (p Polynomial) Roots() ([]complex128, error) {
+	p = p.trim()
+	n := len(p) - 1
+	if n < 1 {
+		return nil, nil
+	}
+	roots := make([]complex128, 0, n)
+
+	// Zero low order coefficients give roots at zero.
+	for len(p) > 1 && p[0] == 0 {
+		roots = append(roots, 0)
+		p = p[1:]
+	}
+	n = len(p) - 1
+	switch n {
+	case 0:
+		return roots, nil
+	case 1:
+		return append(roots, complex(-p[0]/p[1], 0)), nil
+	}
+
+	// The companion matrix of the monic polynomial
+	//  x^n + a[n-1]*x^(n-1) + ... + a[0]
+	// has ones on the subdiagonal and -a in the last column.
+	c := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			c.Set(i, i-1, 1)
+		}
+		c.Set(i, n-1, -p[i]/p[n])
+	}
+	var eig mat.Eigen
+	ok := eig.Factorize(c, mat.EigenNone)
+	if !ok {
+		return nil, errors.New("poly: eigenvalue decomposition failed")
+	}
+	return append(roots, eig.Values(nil)...), nil
+}
@@ -0,0 +1,95 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package poly
+
+import (
+	"math/cmplx"
+	"sort"
+	"testing"
+)
+
+func TestRoots(t *testing.T) {
+	for _, test := range []struct {
+		p    Polynomial
+		want []complex128
+	}{
+		{p: nil, want: nil},
+		{p: Polynomial{3}, want: nil},
+		{p: Polynomial{-6, 2}, want: []complex128{3}},
+		{p: Polynomial{0, 0, 5}, want: []complex128{0, 0}},
+		// (x-1)(x-2)(x+3) = x^3 - 7x + 6
+		{p: Polynomial{6, -7, 0, 1}, want: []complex128{-3, 1, 2}},
+		// x^2 + 1
+		{p: Polynomial{1, 0, 1}, want: []complex128{-1i, 1i}},
+		// x(x^2 + 2x + 5) with trailing zero coefficient.
+		{p: Polynomial{0, 5, 2, 1, 0}, want: []complex128{complex(-1, -2), complex(-1, 2), 0}},
+		// (x-1)^2 (x+1) = x^3 - x^2 - x + 1
+		{p: Polynomial{1, -1, -1, 1}, want: []complex128{-1, 1, 1}},
+	} {
+		got, err := test.p.Roots()
+		if err != nil {
+			t.Errorf("unexpected error for %v: %v", test.p, err)
+			continue
+		}
+		sortComplex(got)
+		if len(got) != len(test.want) {
+			t.Errorf("unexpected number of roots of %v: got:%v want:%v", test.p, got, test.want)
+			continue
+		}
+		for i, z := range got {
+			// Double roots are only determined to about
+			// the square root of the machine epsilon.
+			if cmplx.Abs(z-test.want[i]) > 1e-7 {
+				t.Errorf("unexpected roots of %v: got:%v want:%v", test.p, got, test.want)
+				break
+			}
+		}
+	}
+
+	// The roots of a polynomial with known roots are recovered.
+	want := []complex128{-2.5, -1, 0.5, complex(1, 1), complex(1, -1), 3, 4}
+	p := Polynomial{1}
+	for _, z := range want {
+		if imag(z) < 0 {
+			continue
+		}
+		if imag(z) == 0 {
+			p = Mul(p, Polynomial{-real(z), 1})
+			continue
+		}
+		// (x - z)(x - conj(z)) = x^2 - 2 Re(z) x + |z|^2
+		p = Mul(p, Polynomial{real(z)*real(z) + imag(z)*imag(z), -2 * real(z), 1})
+	}
+	got, err := p.Roots()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, z := range got {
+		if v := p.EvalComplex(z); cmplx.Abs(v) > 1e-9 {
+			t.Errorf("root %v does not zero polynomial: p(z)=%v", z, v)
+		}
+	}
+	sortComplex(got)
+	sortComplex(want)
+	for i := range got {
+		if cmplx.Abs(got[i]-want[i]) > 1e-10 {
+			t.Errorf("unexpected roots: got:%v want:%v", got, want)
+			break
+		}
+	}
+}
+
+func sortComplex(z []complex128) {
+	sort.Slice(z, func(i, j int) bool {
+		if real(z[i]) != real(z[j]) {
+			// Order roots with real parts equal to within
+			// rounding by their imaginary parts.
+			if d := real(z[i]) - real(z[j]); d < -1e-6 || 1e-6 < d {
+				return real(z[i]) < real(z[j])
+			}
+		}
+		return imag(z[i]) < imag(z[j])
+	})
+}
@@ -3,10 +3,20 @@
 // license that can be found in the LICENSE file.
 
 //go:generate ./generate_64bit.sh
+//go:generate go run generate_hllplus_bias.go
 
 package card
 
-import "math"
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash"
+	"math"
+	"path"
+	"reflect"
+)
 
 const (
 	w32 = 32
@@ -43,3 +53,78 @@ func min(a, b uint8) uint8 {
 	}
 	return b
 }
+
+// checkHashes returns an error if the hash functions a and b do not match,
+// or if dst is not nil and does not match them.
+func checkHashes(dst, a, b hash.Hash64) error {
+	ta := reflect.TypeOf(a)
+	if reflect.TypeOf(b) != ta {
+		return errors.New("card: mismatched hash function")
+	}
+	if dst != nil && reflect.TypeOf(dst) != ta {
+		return errors.New("card: mismatched hash function")
+	}
+	return nil
+}
+
+// hashName returns the name of the type of the hash function h.
+func hashName(h hash.Hash64) string {
+	t := reflect.TypeOf(h)
+	var prefix string
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		prefix = "*"
+	}
+	return prefix + path.Join(t.PkgPath(), t.Name())
+}
+
+// marshalHashed encodes the size and name of the hash function h
+// followed by vals.
+func marshalHashed(h hash.Hash64, vals ...interface{}) ([]byte, error) {
+	if h == nil {
+		return nil, errors.New("card: hash function not set")
+	}
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	for _, v := range append([]interface{}{uint8(w64), hashName(h)}, vals...) {
+		err := enc.Encode(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalHashed decodes and checks the size and name of the hash
+// function h from b followed by vals, returning the decoder for decoding
+// the remaining data.
+func unmarshalHashed(b []byte, h hash.Hash64, vals ...interface{}) (*gob.Decoder, error) {
+	if h == nil {
+		return nil, errors.New("card: hash function not set")
+	}
+	dec := gob.NewDecoder(bytes.NewReader(b))
+	var size uint8
+	err := dec.Decode(&size)
+	if err != nil {
+		return nil, err
+	}
+	if size != w64 {
+		return nil, fmt.Errorf("card: mismatched hash function size: dst=%d src=%d", w64, size)
+	}
+	var srcHash string
+	err = dec.Decode(&srcHash)
+	if err != nil {
+		return nil, err
+	}
+	dstHash := hashName(h)
+	if dstHash != srcHash {
+		return nil, fmt.Errorf("card: mismatched hash function: dst=%s src=%s", dstHash, srcHash)
+	}
+	for _, v := range vals {
+		err = dec.Decode(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dec, nil
+}
@@ -61,6 +61,15 @@ var counterTests = []struct {
 	{name: "HyperLogLog64-1e7-16-FNV-1a", count: 1e7, counter: func() counter { return mustCounter(NewHyperLogLog64(16, fnv.New64a())) }, tol: 0.002},
 	{name: "HyperLogLog64-1e7-20-FNV-1a", count: 1e7, counter: func() counter { return mustCounter(NewHyperLogLog64(20, fnv.New64a())) }, tol: 0.001},
 	{name: "HyperLogLog64-1e3-20-FNV-1a", count: 1e3, counter: func() counter { return mustCounter(NewHyperLogLog64(20, fnv.New64a())) }, tol: 0.001},
+
+	{name: "HyperLogLogPlus-0-10-FNV-1a", count: 0, counter: func() counter { return mustCounter(NewHyperLogLogPlus(10, fnv.New64a())) }, tol: 0},
+	{name: "HyperLogLogPlus-10-14-FNV-1a", count: 10, counter: func() counter { return mustCounter(NewHyperLogLogPlus(14, fnv.New64a())) }, tol: 0.0005},
+	{name: "HyperLogLogPlus-1e3-8-FNV-1a", count: 1e3, counter: func() counter { return mustCounter(NewHyperLogLogPlus(8, fnv.New64a())) }, tol: 0.1},
+	{name: "HyperLogLogPlus-1e3-14-FNV-1a", count: 1e3, counter: func() counter { return mustCounter(NewHyperLogLogPlus(14, fnv.New64a())) }, tol: 0.001},
+	{name: "HyperLogLogPlus-1e4-10-FNV-1a", count: 1e4, counter: func() counter { return mustCounter(NewHyperLogLogPlus(10, fnv.New64a())) }, tol: 0.06},
+	{name: "HyperLogLogPlus-4e4-14-FNV-1a", count: 4e4, counter: func() counter { return mustCounter(NewHyperLogLogPlus(14, fnv.New64a())) }, tol: 0.02},
+	{name: "HyperLogLogPlus-1e7-14-FNV-1a", count: 1e7, counter: func() counter { return mustCounter(NewHyperLogLogPlus(14, fnv.New64a())) }, tol: 0.005},
+	{name: "HyperLogLogPlus-1e6-18-FNV-1a", count: 1e6, counter: func() counter { return mustCounter(NewHyperLogLogPlus(18, fnv.New64a())) }, tol: 0.005},
 }
 
 func mustCounter(c counter, err error) counter {
@@ -131,6 +140,8 @@ func TestUnion(t *testing.T) {
 			err = u.Union(cs[0].(*HyperLogLog32), cs[1].(*HyperLogLog32))
 		case *HyperLogLog64:
 			err = u.Union(cs[0].(*HyperLogLog64), cs[1].(*HyperLogLog64))
+		case *HyperLogLogPlus:
+			err = u.Union(cs[0].(*HyperLogLogPlus), cs[1].(*HyperLogLogPlus))
 		}
 		if err != nil {
 			t.Errorf("unexpected error from Union call: %v", err)
@@ -155,6 +166,8 @@ var counterResetTests = []struct {
 	{name: "HyperLogLog64-1e3-4-FNV-1a", count: 1e3, resetCounter: func() resetCounter { return mustResetCounter(NewHyperLogLog64(4, fnv.New64a())) }},
 	{name: "HyperLogLog32-1e4-6-FNV-1a", count: 1e4, resetCounter: func() resetCounter { return mustResetCounter(NewHyperLogLog32(6, fnv.New32a())) }},
 	{name: "HyperLogLog64-1e4-6-FNV-1a", count: 1e4, resetCounter: func() resetCounter { return mustResetCounter(NewHyperLogLog64(6, fnv.New64a())) }},
+	{name: "HyperLogLogPlus-1e3-10-FNV-1a", count: 1e3, resetCounter: func() resetCounter { return mustResetCounter(NewHyperLogLogPlus(10, fnv.New64a())) }},
+	{name: "HyperLogLogPlus-1e4-6-FNV-1a", count: 1e4, resetCounter: func() resetCounter { return mustResetCounter(NewHyperLogLogPlus(6, fnv.New64a())) }},
 }
 
 func mustResetCounter(c resetCounter, err error) resetCounter {
@@ -237,6 +250,16 @@ var counterEncoderTests = []struct {
 		src: func() counterEncoder { return mustCounterEncoder(NewHyperLogLog64(8, fnv.New64a())) },
 		dst: func() counterEncoder { return mustCounterEncoder(NewHyperLogLog64(4, fnv.New64a())) },
 	},
+	{
+		name: "HyperLogLogPlus-sparse-10-4-FNV-1a", count: 1e2,
+		src: func() counterEncoder { return mustCounterEncoder(NewHyperLogLogPlus(10, fnv.New64a())) },
+		dst: func() counterEncoder { return mustCounterEncoder(NewHyperLogLogPlus(4, fnv.New64a())) },
+	},
+	{
+		name: "HyperLogLogPlus-dense-8-14-FNV-1a", count: 1e3,
+		src: func() counterEncoder { return mustCounterEncoder(NewHyperLogLogPlus(8, fnv.New64a())) },
+		dst: func() counterEncoder { return mustCounterEncoder(NewHyperLogLogPlus(14, fnv.New64a())) },
+	},
 }
 
 func mustCounterEncoder(c counterEncoder, err error) counterEncoder {
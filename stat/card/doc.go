@@ -2,5 +2,5 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// Package card provides cardinality estimation functions.
+// Package card provides cardinality and frequency estimation functions.
 package card // import "gonum.org/v1/gonum/stat/card"
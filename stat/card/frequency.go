@@ -0,0 +1,391 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package card
+
+import (
+	"errors"
+	"hash"
+	"math"
+	"sort"
+)
+
+// CountMin implements approximate frequency estimation according to the
+// Count-Min sketch algorithm described in "An improved data stream summary:
+// the count-min sketch and its applications", J. Algorithms 55(1):58–75.
+//
+// A Count-Min sketch with width w and depth d never underestimates the
+// frequency of an item, and overestimates it by at most e/w times the total
+// count of the stream with probability at least 1 - exp(-d). With
+// conservative update, described by Estan and Varghese in "New directions in
+// traffic measurement and accounting", SIGCOMM 2002, the counters are only
+// increased as far as needed to maintain the estimate, which reduces the
+// overestimation for skewed streams.
+type CountMin struct {
+	width, depth int
+	conservative bool
+
+	hash hash.Hash64
+
+	total    uint64
+	counters []uint64
+}
+
+// NewCountMin returns a new CountMin sketch with the given width and depth.
+// If conservative is true, the sketch uses conservative update. The width
+// and depth must be positive.
+func NewCountMin(width, depth int, conservative bool, h hash.Hash64) (*CountMin, error) {
+	if width < 1 || depth < 1 {
+		return nil, errors.New("card: dimensions out of range")
+	}
+	return &CountMin{
+		width: width, depth: depth,
+		conservative: conservative,
+		hash:         h,
+		counters:     make([]uint64, width*depth),
+	}, nil
+}
+
+// Write notes the data in b as a single observation of an item into the
+// sketch held by the receiver.
+//
+// Write satisfies the io.Writer interface. If the hash.Hash64 type passed to
+// NewCountMin or SetHash satisfies the hash.Hash contract, Write will always
+// return a nil error.
+func (c *CountMin) Write(b []byte) (int, error) {
+	return c.Add(b, 1)
+}
+
+// Add notes count observations of the item in b into the sketch held by
+// the receiver. The returned values are those returned by the receiver's
+// hash function when writing b.
+func (c *CountMin) Add(b []byte, count uint64) (int, error) {
+	n, err := c.hash.Write(b)
+	x := c.hash.Sum64()
+	c.hash.Reset()
+	c.total += count
+	if !c.conservative {
+		for i := 0; i < c.depth; i++ {
+			c.counters[c.index(x, i)] += count
+		}
+		return n, err
+	}
+	est := c.estimate(x) + count
+	for i := 0; i < c.depth; i++ {
+		j := c.index(x, i)
+		if c.counters[j] < est {
+			c.counters[j] = est
+		}
+	}
+	return n, err
+}
+
+// Frequency returns an estimate of the number of observations of the item
+// in b noted by the receiver.
+func (c *CountMin) Frequency(b []byte) uint64 {
+	c.hash.Write(b)
+	x := c.hash.Sum64()
+	c.hash.Reset()
+	return c.estimate(x)
+}
+
+// estimate returns the smallest counter for the item with hash x.
+func (c *CountMin) estimate(x uint64) uint64 {
+	est := uint64(math.MaxUint64)
+	for i := 0; i < c.depth; i++ {
+		if v := c.counters[c.index(x, i)]; v < est {
+			est = v
+		}
+	}
+	return est
+}
+
+// index returns the index into the counters of row i for the item with
+// hash x.
+func (c *CountMin) index(x uint64, i int) int {
+	return i*c.width + int(rowHash(x, i)%uint64(c.width))
+}
+
+// Total returns the total count of observations noted by the receiver.
+func (c *CountMin) Total() uint64 {
+	return c.total
+}
+
+// Merge places the sum of the sketches in a and b into the receiver.
+// Merge will return an error if the dimensions, update rules or hash
+// functions of a and b do not match or if the receiver has a hash function
+// that is set and does not match those of a and b. Hash functions match
+// as described for HyperLogLog64.Union.
+//
+// If the receiver does not have a set hash function, it can be set after
+// a call to Merge with the SetHash method.
+func (c *CountMin) Merge(a, b *CountMin) error {
+	if a.width != b.width || a.depth != b.depth {
+		return errors.New("card: mismatched dimensions")
+	}
+	if a.conservative != b.conservative {
+		return errors.New("card: mismatched update rule")
+	}
+	err := checkHashes(c.hash, a.hash, b.hash)
+	if err != nil {
+		return err
+	}
+
+	if c != a && c != b {
+		*c = CountMin{
+			width: a.width, depth: a.depth,
+			conservative: a.conservative,
+			hash:         c.hash,
+			counters:     make([]uint64, len(a.counters)),
+		}
+	}
+	c.total = a.total + b.total
+	for i, v := range a.counters {
+		c.counters[i] = v + b.counters[i]
+	}
+	return nil
+}
+
+// SetHash sets the hash function of the receiver if it is nil. SetHash
+// will return an error if it is called on a receiver with a non-nil
+// hash function.
+func (c *CountMin) SetHash(fn hash.Hash64) error {
+	if c.hash != nil {
+		return errors.New("card: hash function already set")
+	}
+	c.hash = fn
+	return nil
+}
+
+// Reset clears the receiver's counters allowing it to be reused.
+// Reset does not alter the dimensions of the receiver or the hash
+// function that is used.
+func (c *CountMin) Reset() {
+	c.total = 0
+	for i := range c.counters {
+		c.counters[i] = 0
+	}
+}
+
+// MarshalBinary marshals the sketch in the receiver. It encodes the
+// name of the hash function, the dimensions and update rule of the
+// sketch and the sketch data. The receiver must have a non-nil hash
+// function.
+func (c *CountMin) MarshalBinary() ([]byte, error) {
+	return marshalHashed(c.hash, c.width, c.depth, c.conservative, c.total, c.counters)
+}
+
+// UnmarshalBinary unmarshals the binary representation of a sketch
+// into the receiver. The dimensions and update rule of the receiver
+// will be set after return. The receiver must have a non-nil hash
+// function value that is the same type as the one that was stored in
+// the binary data.
+func (c *CountMin) UnmarshalBinary(b []byte) error {
+	var (
+		width, depth int
+		conservative bool
+		total        uint64
+		counters     []uint64
+	)
+	dec, err := unmarshalHashed(b, c.hash, &width, &depth, &conservative, &total)
+	if err != nil {
+		return err
+	}
+	err = dec.Decode(&counters)
+	if err != nil {
+		return err
+	}
+	if width < 1 || depth < 1 || len(counters) != width*depth {
+		return errors.New("card: invalid sketch data")
+	}
+	c.width = width
+	c.depth = depth
+	c.conservative = conservative
+	c.total = total
+	c.counters = counters
+	return nil
+}
+
+// CountSketch implements approximate frequency estimation according to the
+// Count sketch algorithm described in "Finding frequent items in data
+// streams", Theoret. Comput. Sci. 312(1):3–15.
+//
+// Each row of a Count sketch adds the count of an item to one of its
+// counters with a random sign, so the estimates of the frequencies are
+// unbiased. The error of the estimates is proportional to the Euclidean
+// norm of the vector of frequencies of the stream divided by the square
+// root of the width, which is smaller than the error of a Count-Min sketch
+// for streams that are not highly skewed. Counts may be negative, allowing
+// observations to be removed.
+type CountSketch struct {
+	width, depth int
+
+	hash hash.Hash64
+
+	counters []int64
+
+	// est is working storage for the row estimates.
+	est []int64
+}
+
+// NewCountSketch returns a new CountSketch with the given width and depth.
+// The width and depth must be positive.
+func NewCountSketch(width, depth int, h hash.Hash64) (*CountSketch, error) {
+	if width < 1 || depth < 1 {
+		return nil, errors.New("card: dimensions out of range")
+	}
+	return &CountSketch{
+		width: width, depth: depth,
+		hash:     h,
+		counters: make([]int64, width*depth),
+	}, nil
+}
+
+// Write notes the data in b as a single observation of an item into the
+// sketch held by the receiver.
+//
+// Write satisfies the io.Writer interface. If the hash.Hash64 type passed to
+// NewCountSketch or SetHash satisfies the hash.Hash contract, Write will
+// always return a nil error.
+func (c *CountSketch) Write(b []byte) (int, error) {
+	return c.Add(b, 1)
+}
+
+// Add notes count observations of the item in b into the sketch held by
+// the receiver. A negative count removes observations. The returned values
+// are those returned by the receiver's hash function when writing b.
+func (c *CountSketch) Add(b []byte, count int64) (int, error) {
+	n, err := c.hash.Write(b)
+	x := c.hash.Sum64()
+	c.hash.Reset()
+	for i := 0; i < c.depth; i++ {
+		j, sign := c.index(x, i)
+		c.counters[j] += sign * count
+	}
+	return n, err
+}
+
+// Frequency returns an estimate of the number of observations of the item
+// in b noted by the receiver, the median of the estimates of the rows.
+// When the depth is even, the mean of the two central estimates is rounded
+// toward zero.
+func (c *CountSketch) Frequency(b []byte) int64 {
+	c.hash.Write(b)
+	x := c.hash.Sum64()
+	c.hash.Reset()
+	c.est = c.est[:0]
+	for i := 0; i < c.depth; i++ {
+		j, sign := c.index(x, i)
+		c.est = append(c.est, sign*c.counters[j])
+	}
+	sort.Slice(c.est, func(i, j int) bool { return c.est[i] < c.est[j] })
+	mid := len(c.est) / 2
+	if len(c.est)%2 == 1 {
+		return c.est[mid]
+	}
+	return (c.est[mid-1] + c.est[mid]) / 2
+}
+
+// index returns the index into the counters of row i and the sign of the
+// contribution of the item with hash x.
+func (c *CountSketch) index(x uint64, i int) (int, int64) {
+	y := rowHash(x, i)
+	sign := int64(1)
+	if y>>63 != 0 {
+		sign = -1
+	}
+	return i*c.width + int((y&^(1<<63))%uint64(c.width)), sign
+}
+
+// Merge places the sum of the sketches in a and b into the receiver.
+// Merge will return an error if the dimensions or hash functions of a
+// and b do not match or if the receiver has a hash function that is set
+// and does not match those of a and b. Hash functions match as described
+// for HyperLogLog64.Union.
+//
+// If the receiver does not have a set hash function, it can be set after
+// a call to Merge with the SetHash method.
+func (c *CountSketch) Merge(a, b *CountSketch) error {
+	if a.width != b.width || a.depth != b.depth {
+		return errors.New("card: mismatched dimensions")
+	}
+	err := checkHashes(c.hash, a.hash, b.hash)
+	if err != nil {
+		return err
+	}
+
+	if c != a && c != b {
+		*c = CountSketch{
+			width: a.width, depth: a.depth,
+			hash:     c.hash,
+			counters: make([]int64, len(a.counters)),
+		}
+	}
+	for i, v := range a.counters {
+		c.counters[i] = v + b.counters[i]
+	}
+	return nil
+}
+
+// SetHash sets the hash function of the receiver if it is nil. SetHash
+// will return an error if it is called on a receiver with a non-nil
+// hash function.
+func (c *CountSketch) SetHash(fn hash.Hash64) error {
+	if c.hash != nil {
+		return errors.New("card: hash function already set")
+	}
+	c.hash = fn
+	return nil
+}
+
+// Reset clears the receiver's counters allowing it to be reused.
+// Reset does not alter the dimensions of the receiver or the hash
+// function that is used.
+func (c *CountSketch) Reset() {
+	for i := range c.counters {
+		c.counters[i] = 0
+	}
+}
+
+// MarshalBinary marshals the sketch in the receiver. It encodes the
+// name of the hash function, the dimensions of the sketch and the
+// sketch data. The receiver must have a non-nil hash function.
+func (c *CountSketch) MarshalBinary() ([]byte, error) {
+	return marshalHashed(c.hash, c.width, c.depth, c.counters)
+}
+
+// UnmarshalBinary unmarshals the binary representation of a sketch
+// into the receiver. The dimensions of the receiver will be set after
+// return. The receiver must have a non-nil hash function value that is
+// the same type as the one that was stored in the binary data.
+func (c *CountSketch) UnmarshalBinary(b []byte) error {
+	var (
+		width, depth int
+		counters     []int64
+	)
+	dec, err := unmarshalHashed(b, c.hash, &width, &depth)
+	if err != nil {
+		return err
+	}
+	err = dec.Decode(&counters)
+	if err != nil {
+		return err
+	}
+	if width < 1 || depth < 1 || len(counters) != width*depth {
+		return errors.New("card: invalid sketch data")
+	}
+	c.width = width
+	c.depth = depth
+	c.counters = counters
+	return nil
+}
+
+// rowHash returns the hash of x for row i of a frequency sketch. It is the
+// output of the SplitMix64 generator seeded with x after i+1 steps.
+func rowHash(x uint64, i int) uint64 {
+	x += uint64(i+1) * 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,399 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package card
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+// zipfStream returns a stream of n items with Zipf distributed frequencies
+// and the exact frequencies of the items.
+func zipfStream(n int, s float64, src rand.Source) ([][]byte, map[string]int64) {
+	z := rand.NewZipf(rand.New(src), s, 1, 1e6)
+	stream := make([][]byte, n)
+	freq := make(map[string]int64)
+	for i := range stream {
+		stream[i] = strconv.AppendUint(nil, z.Uint64(), 16)
+		freq[string(stream[i])]++
+	}
+	return stream, freq
+}
+
+func mustCountMin(c *CountMin, err error) *CountMin {
+	if err != nil {
+		panic(fmt.Sprintf("bad test: %v", err))
+	}
+	return c
+}
+
+func mustCountSketch(c *CountSketch, err error) *CountSketch {
+	if err != nil {
+		panic(fmt.Sprintf("bad test: %v", err))
+	}
+	return c
+}
+
+func mustSpaceSaving(s *SpaceSaving, err error) *SpaceSaving {
+	if err != nil {
+		panic(fmt.Sprintf("bad test: %v", err))
+	}
+	return s
+}
+
+func TestCountMin(t *testing.T) {
+	const (
+		n     = 1e5
+		width = 2000
+		depth = 5
+	)
+	stream, freq := zipfStream(n, 1.1, rand.NewSource(1))
+	for _, conservative := range []bool{false, true} {
+		c := mustCountMin(NewCountMin(width, depth, conservative, fnv.New64a()))
+		for _, b := range stream {
+			nb, err := c.Write(b)
+			if nb != len(b) || err != nil {
+				t.Fatalf("unexpected write result: n=%d err=%v", nb, err)
+			}
+		}
+		if c.Total() != n {
+			t.Errorf("unexpected total: got:%d want:%d", c.Total(), int(n))
+		}
+		// The error bound holds for each item with probability
+		// 1-exp(-depth), so few items may exceed it.
+		bound := uint64(math.Ceil(math.E / width * n))
+		var bad int
+		for item, f := range freq {
+			got := c.Frequency([]byte(item))
+			if got < uint64(f) {
+				t.Errorf("underestimated frequency of %s for conservative=%t: got:%d want:>=%d", item, conservative, got, f)
+			}
+			if got-uint64(f) > bound {
+				bad++
+			}
+		}
+		if limit := float64(len(freq)) * math.Exp(-depth) * 2; float64(bad) > limit {
+			t.Errorf("too many estimates exceed error bound for conservative=%t: got:%d want:<=%.0f", conservative, bad, limit)
+		}
+	}
+}
+
+func TestCountMinConservative(t *testing.T) {
+	stream, freq := zipfStream(1e5, 1.1, rand.NewSource(1))
+	plain := mustCountMin(NewCountMin(500, 4, false, fnv.New64a()))
+	cons := mustCountMin(NewCountMin(500, 4, true, fnv.New64a()))
+	for _, b := range stream {
+		plain.Write(b)
+		cons.Write(b)
+	}
+	var errPlain, errCons uint64
+	for item, f := range freq {
+		p := plain.Frequency([]byte(item))
+		c := cons.Frequency([]byte(item))
+		if c > p {
+			t.Errorf("conservative estimate greater than plain estimate for %s: %d > %d", item, c, p)
+		}
+		errPlain += p - uint64(f)
+		errCons += c - uint64(f)
+	}
+	if errCons >= errPlain {
+		t.Errorf("conservative update did not reduce error: conservative=%d plain=%d", errCons, errPlain)
+	}
+}
+
+func TestCountSketch(t *testing.T) {
+	const (
+		n     = 1e5
+		width = 2000
+		depth = 5
+	)
+	stream, freq := zipfStream(n, 1.1, rand.NewSource(1))
+	c := mustCountSketch(NewCountSketch(width, depth, fnv.New64a()))
+	for _, b := range stream {
+		c.Write(b)
+	}
+	var l2 float64
+	for _, f := range freq {
+		l2 += float64(f) * float64(f)
+	}
+	bound := int64(3 * math.Sqrt(l2/width))
+	var bad int
+	for item, f := range freq {
+		d := c.Frequency([]byte(item)) - f
+		if d < 0 {
+			d = -d
+		}
+		if d > bound {
+			bad++
+		}
+	}
+	if limit := len(freq) / 100; bad > limit {
+		t.Errorf("too many estimates exceed error bound: got:%d want:<=%d", bad, limit)
+	}
+
+	// Removing the observations leaves an empty sketch.
+	for _, b := range stream {
+		c.Add(b, -1)
+	}
+	for _, v := range c.counters {
+		if v != 0 {
+			t.Fatalf("unexpected non-zero counter after removing observations: %d", v)
+		}
+	}
+}
+
+func TestSpaceSaving(t *testing.T) {
+	const (
+		n = 1e5
+		k = 100
+	)
+	stream, freq := zipfStream(n, 1.1, rand.NewSource(1))
+	s := mustSpaceSaving(NewSpaceSaving(k))
+	for _, b := range stream {
+		s.Write(b)
+	}
+	if s.Total() != n {
+		t.Errorf("unexpected total: got:%d want:%d", s.Total(), int(n))
+	}
+	checkSpaceSaving(t, "stream", s, freq, n)
+}
+
+func checkSpaceSaving(t *testing.T, name string, s *SpaceSaving, freq map[string]int64, n float64) {
+	top := s.Top(s.k)
+	if len(top) != s.k {
+		t.Errorf("unexpected number of monitored items for %s: got:%d want:%d", name, len(top), s.k)
+	}
+	for i, c := range top {
+		f := uint64(freq[c.Item])
+		if c.Count < f || c.Count-c.Error > f {
+			t.Errorf("frequency of %s not in [count-error, count] for %s: %d not in [%d,%d]",
+				c.Item, name, f, c.Count-c.Error, c.Count)
+		}
+		if float64(c.Error) > n/float64(s.k) {
+			t.Errorf("error of %s exceeds bound for %s: got:%d", c.Item, name, c.Error)
+		}
+		if i > 0 && c.Count > top[i-1].Count {
+			t.Errorf("top items not in decreasing order for %s", name)
+		}
+	}
+	const phi = 0.01
+	hh := make(map[string]bool)
+	for _, c := range s.HeavyHitters(phi) {
+		hh[c.Item] = true
+	}
+	for item, f := range freq {
+		if float64(f) > phi*n && !hh[item] {
+			t.Errorf("heavy hitter %s with frequency %d not reported for %s", item, f, name)
+		}
+	}
+}
+
+func TestFrequencyMerge(t *testing.T) {
+	const n = 1e5
+	stream, freq := zipfStream(n, 1.1, rand.NewSource(1))
+	var (
+		cm [2]*CountMin
+		cs [2]*CountSketch
+		ss [2]*SpaceSaving
+	)
+	for i := range cm {
+		cm[i] = mustCountMin(NewCountMin(1000, 4, false, fnv.New64a()))
+		cs[i] = mustCountSketch(NewCountSketch(1000, 5, fnv.New64a()))
+		ss[i] = mustSpaceSaving(NewSpaceSaving(100))
+	}
+	whole := mustCountMin(NewCountMin(1000, 4, false, fnv.New64a()))
+	wholeCS := mustCountSketch(NewCountSketch(1000, 5, fnv.New64a()))
+	for i, b := range stream {
+		cm[i%2].Write(b)
+		cs[i%2].Write(b)
+		ss[i%2].Write(b)
+		whole.Write(b)
+		wholeCS.Write(b)
+	}
+
+	var cmu CountMin
+	err := cmu.Merge(cm[0], cm[1])
+	if err != nil {
+		t.Fatalf("unexpected error merging CountMin: %v", err)
+	}
+	err = cmu.SetHash(fnv.New64a())
+	if err != nil {
+		t.Fatalf("unexpected error setting hash: %v", err)
+	}
+	var csu CountSketch
+	err = csu.Merge(cs[0], cs[1])
+	if err != nil {
+		t.Fatalf("unexpected error merging CountSketch: %v", err)
+	}
+	csu.SetHash(fnv.New64a())
+	for item := range freq {
+		b := []byte(item)
+		if got, want := cmu.Frequency(b), whole.Frequency(b); got != want {
+			t.Errorf("unexpected merged CountMin frequency of %s: got:%d want:%d", item, got, want)
+		}
+		if got, want := csu.Frequency(b), wholeCS.Frequency(b); got != want {
+			t.Errorf("unexpected merged CountSketch frequency of %s: got:%d want:%d", item, got, want)
+		}
+	}
+	if cmu.Total() != n {
+		t.Errorf("unexpected merged CountMin total: got:%d want:%d", cmu.Total(), int(n))
+	}
+
+	var ssu SpaceSaving
+	err = ssu.Merge(ss[0], ss[1])
+	if err != nil {
+		t.Fatalf("unexpected error merging SpaceSaving: %v", err)
+	}
+	checkSpaceSaving(t, "merged", &ssu, freq, n)
+
+	for _, test := range []struct {
+		name string
+		err  error
+	}{
+		{name: "CountMin dimensions", err: cmu.Merge(cm[0], mustCountMin(NewCountMin(10, 4, false, fnv.New64a())))},
+		{name: "CountMin update rule", err: cmu.Merge(cm[0], mustCountMin(NewCountMin(1000, 4, true, fnv.New64a())))},
+		{name: "CountMin hash", err: cmu.Merge(cm[0], mustCountMin(NewCountMin(1000, 4, false, fnv.New64())))},
+		{name: "CountSketch dimensions", err: csu.Merge(cs[0], mustCountSketch(NewCountSketch(1000, 3, fnv.New64a())))},
+		{name: "SpaceSaving capacity", err: ssu.Merge(ss[0], mustSpaceSaving(NewSpaceSaving(10)))},
+		{name: "set hash", err: cmu.SetHash(fnv.New64a())},
+	} {
+		if test.err == nil {
+			t.Errorf("expected error for mismatched %s", test.name)
+		}
+	}
+}
+
+func TestFrequencyBinaryEncoding(t *testing.T) {
+	stream, freq := zipfStream(1e4, 1.1, rand.NewSource(1))
+	cm := mustCountMin(NewCountMin(500, 4, true, fnv.New64a()))
+	cs := mustCountSketch(NewCountSketch(500, 5, fnv.New64a()))
+	ss := mustSpaceSaving(NewSpaceSaving(50))
+	for _, b := range stream {
+		cm.Write(b)
+		cs.Write(b)
+		ss.Write(b)
+	}
+
+	buf, err := cm.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error marshaling CountMin: %v", err)
+	}
+	cmDst := mustCountMin(NewCountMin(1, 1, false, fnv.New64a()))
+	err = cmDst.UnmarshalBinary(buf)
+	if err != nil {
+		t.Fatalf("unexpected error unmarshaling CountMin: %v", err)
+	}
+	err = mustCountMin(NewCountMin(1, 1, false, fnv.New64())).UnmarshalBinary(buf)
+	if err == nil {
+		t.Errorf("expected error unmarshaling CountMin with mismatched hash")
+	}
+
+	buf, err = cs.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error marshaling CountSketch: %v", err)
+	}
+	csDst := mustCountSketch(NewCountSketch(1, 1, fnv.New64a()))
+	err = csDst.UnmarshalBinary(buf)
+	if err != nil {
+		t.Fatalf("unexpected error unmarshaling CountSketch: %v", err)
+	}
+
+	buf, err = ss.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error marshaling SpaceSaving: %v", err)
+	}
+	var ssDst SpaceSaving
+	err = ssDst.UnmarshalBinary(buf)
+	if err != nil {
+		t.Fatalf("unexpected error unmarshaling SpaceSaving: %v", err)
+	}
+
+	for item := range freq {
+		b := []byte(item)
+		if got, want := cmDst.Frequency(b), cm.Frequency(b); got != want {
+			t.Errorf("unexpected CountMin frequency of %s after round trip: got:%d want:%d", item, got, want)
+		}
+		if got, want := csDst.Frequency(b), cs.Frequency(b); got != want {
+			t.Errorf("unexpected CountSketch frequency of %s after round trip: got:%d want:%d", item, got, want)
+		}
+		gotCount, gotErr := ssDst.Frequency(b)
+		wantCount, wantErr := ss.Frequency(b)
+		if gotCount != wantCount || gotErr != wantErr {
+			t.Errorf("unexpected SpaceSaving frequency of %s after round trip: got:(%d,%d) want:(%d,%d)",
+				item, gotCount, gotErr, wantCount, wantErr)
+		}
+	}
+	if cmDst.Total() != cm.Total() || ssDst.Total() != ss.Total() {
+		t.Errorf("unexpected total after round trip")
+	}
+
+	var noHash CountMin
+	if _, err := noHash.MarshalBinary(); err == nil {
+		t.Errorf("expected error marshaling CountMin without hash function")
+	}
+}
+
+func TestFrequencyUnmarshalInvalid(t *testing.T) {
+	stream, _ := zipfStream(1e3, 1.1, rand.NewSource(1))
+	cm := mustCountMin(NewCountMin(100, 5, false, fnv.New64a()))
+	cs := mustCountSketch(NewCountSketch(100, 5, fnv.New64a()))
+	for _, b := range stream {
+		cm.Write(b)
+		cs.Write(b)
+	}
+
+	// Decoding truncated data must fail and leave the receiver usable
+	// with its original dimensions.
+	buf, err := cm.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error marshaling CountMin: %v", err)
+	}
+	cmDst := mustCountMin(NewCountMin(10, 2, false, fnv.New64a()))
+	err = cmDst.UnmarshalBinary(buf[:len(buf)/2])
+	if err == nil {
+		t.Errorf("expected error unmarshaling truncated CountMin")
+	}
+	if cmDst.width != 10 || cmDst.depth != 2 {
+		t.Errorf("unexpected CountMin dimensions after failed unmarshal: got:%d×%d want:10×2", cmDst.width, cmDst.depth)
+	}
+	for _, b := range stream {
+		cmDst.Write(b)
+	}
+
+	buf, err = cs.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error marshaling CountSketch: %v", err)
+	}
+	csDst := mustCountSketch(NewCountSketch(10, 2, fnv.New64a()))
+	err = csDst.UnmarshalBinary(buf[:len(buf)/2])
+	if err == nil {
+		t.Errorf("expected error unmarshaling truncated CountSketch")
+	}
+	if csDst.width != 10 || csDst.depth != 2 {
+		t.Errorf("unexpected CountSketch dimensions after failed unmarshal: got:%d×%d want:10×2", csDst.width, csDst.depth)
+	}
+	for _, b := range stream {
+		csDst.Write(b)
+	}
+}
+
+func TestFrequencyDimensions(t *testing.T) {
+	for _, dims := range [][2]int{{0, 1}, {1, 0}, {-1, 3}} {
+		if _, err := NewCountMin(dims[0], dims[1], false, fnv.New64a()); err == nil {
+			t.Errorf("expected error for CountMin dimensions %v", dims)
+		}
+		if _, err := NewCountSketch(dims[0], dims[1], fnv.New64a()); err == nil {
+			t.Errorf("expected error for CountSketch dimensions %v", dims)
+		}
+	}
+	if _, err := NewSpaceSaving(0); err == nil {
+		t.Errorf("expected error for SpaceSaving capacity 0")
+	}
+}
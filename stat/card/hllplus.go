@@ -0,0 +1,440 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package card
+
+import (
+	"errors"
+	"hash"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// sparsePrec is the precision of the sparse representation of a
+// HyperLogLogPlus sketch.
+const sparsePrec = 25
+
+// maxPlusPrec is the largest precision of a HyperLogLogPlus sketch.
+const maxPlusPrec = 18
+
+// HyperLogLogPlus implements cardinality estimation according to the
+// HyperLogLog++ algorithm described in "HyperLogLog in practice: algorithmic
+// engineering of a state of the art cardinality estimation algorithm",
+// EDBT 2013, pp683–692.
+//
+// While few items have been observed the sketch uses a sparse representation
+// that holds the hashes of the observed items at a precision of 25 bits,
+// which requires much less space than the dense registers of a HyperLogLog64
+// sketch and gives accurate counts by linear counting. The sketch is
+// converted to the dense representation when the sparse representation
+// would need more space than the registers.
+//
+// Counts in the dense representation are estimated with the raw HyperLogLog
+// estimator, less its empirically determined bias for raw estimates up to five
+// times the number of registers. Linear counting over the dense registers is
+// used instead for cardinalities below a threshold that depends on the
+// precision.
+type HyperLogLogPlus struct {
+	p uint8
+	m uint64
+
+	hash hash.Hash64
+
+	// sparse holds the sorted encoded hashes of the
+	// sparse representation, with at most one entry
+	// for each sparse register, and tmp holds entries
+	// that have not yet been merged into sparse.
+	sparse []uint32
+	tmp    []uint32
+
+	// register holds the dense registers. It is nil
+	// while the sketch is sparse.
+	register []uint8
+}
+
+// NewHyperLogLogPlus returns a new HyperLogLogPlus sketch. The value of prec
+// must be in the range [4, 18]. The sketch will use at most a byte slice that
+// is 2^prec long.
+func NewHyperLogLogPlus(prec int, h hash.Hash64) (*HyperLogLogPlus, error) {
+	if prec < 4 || maxPlusPrec < prec {
+		return nil, errors.New("card: precision out of range")
+	}
+	p := uint8(prec)
+	return &HyperLogLogPlus{p: p, m: uint64(1) << p, hash: h}, nil
+}
+
+// Write notes the data in b as a single observation into the sketch held by
+// the receiver.
+//
+// Write satisfies the io.Writer interface. If the hash.Hash64 type passed to
+// NewHyperLogLogPlus or SetHash satisfies the hash.Hash contract, Write will
+// always return a nil error.
+func (h *HyperLogLogPlus) Write(b []byte) (int, error) {
+	n, err := h.hash.Write(b)
+	x := h.hash.Sum64()
+	h.hash.Reset()
+	if h.register != nil {
+		q := w64 - h.p
+		idx := x >> q
+		r := rho64q(x, q)
+		if r > h.register[idx] {
+			h.register[idx] = r
+		}
+		return n, err
+	}
+	h.tmp = append(h.tmp, encodeSparse(x, h.p))
+	if len(h.tmp) >= h.sparseLimit()/4+1 {
+		h.mergeTmp()
+		if len(h.sparse) > h.sparseLimit() {
+			h.toDense()
+		}
+	}
+	return n, err
+}
+
+// sparseLimit returns the largest number of entries of the sparse
+// representation, the number that occupy the same space as the dense
+// registers.
+func (h *HyperLogLogPlus) sparseLimit() int {
+	return int(h.m / 4)
+}
+
+// Sparse returns whether the sketch is using the sparse representation.
+func (h *HyperLogLogPlus) Sparse() bool {
+	return h.register == nil
+}
+
+// mergeTmp merges the pending sparse entries into the sparse
+// representation.
+func (h *HyperLogLogPlus) mergeTmp() {
+	if len(h.tmp) == 0 {
+		return
+	}
+	sortSparse(h.tmp)
+	h.sparse = mergeSparse(nil, h.sparse, h.tmp)
+	h.tmp = h.tmp[:0]
+}
+
+// toDense converts the receiver to the dense representation.
+func (h *HyperLogLogPlus) toDense() {
+	h.mergeTmp()
+	h.register = make([]uint8, h.m)
+	for _, k := range h.sparse {
+		idx, r := decodeSparse(k, h.p)
+		if r > h.register[idx] {
+			h.register[idx] = r
+		}
+	}
+	h.sparse = nil
+	h.tmp = nil
+}
+
+// Union places the union of the sketches in a and b into the receiver.
+// Union will return an error if the precisions or hash functions of a
+// and b do not match or if the receiver has a hash function that is set
+// and does not match those of a and b. Hash functions match as described
+// for HyperLogLog64.Union. The result uses the sparse representation if
+// a and b are both sparse and their union is small enough.
+//
+// If the receiver does not have a set hash function, it can be set after
+// a call to Union with the SetHash method.
+func (h *HyperLogLogPlus) Union(a, b *HyperLogLogPlus) error {
+	if a.p != b.p {
+		return errors.New("card: mismatched precision")
+	}
+	err := checkHashes(h.hash, a.hash, b.hash)
+	if err != nil {
+		return err
+	}
+
+	a.mergeTmp()
+	b.mergeTmp()
+	u := HyperLogLogPlus{p: a.p, m: a.m, hash: h.hash}
+	if a.Sparse() && b.Sparse() {
+		u.sparse = mergeSparse(nil, a.sparse, b.sparse)
+		if len(u.sparse) > u.sparseLimit() {
+			u.toDense()
+		}
+		*h = u
+		return nil
+	}
+	u.register = make([]uint8, u.m)
+	for _, s := range []*HyperLogLogPlus{a, b} {
+		if s.Sparse() {
+			for _, k := range s.sparse {
+				idx, r := decodeSparse(k, s.p)
+				u.register[idx] = max(u.register[idx], r)
+			}
+			continue
+		}
+		for i, r := range s.register {
+			u.register[i] = max(u.register[i], r)
+		}
+	}
+	*h = u
+	return nil
+}
+
+// SetHash sets the hash function of the receiver if it is nil. SetHash
+// will return an error if it is called on a receiver with a non-nil
+// hash function.
+func (h *HyperLogLogPlus) SetHash(fn hash.Hash64) error {
+	if h.hash != nil {
+		return errors.New("card: hash function already set")
+	}
+	h.hash = fn
+	return nil
+}
+
+// Count returns an estimate of the cardinality of the set of items written
+// to the receiver.
+func (h *HyperLogLogPlus) Count() float64 {
+	if h.Sparse() {
+		h.mergeTmp()
+		m := float64(uint64(1) << sparsePrec)
+		return linearCounting(m, m-float64(len(h.sparse)))
+	}
+
+	m := float64(h.m)
+	var (
+		s float64
+		v int
+	)
+	for _, r := range h.register {
+		s += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			v++
+		}
+	}
+	e := alpha(h.m) * m * m / s
+	if e <= 5*m {
+		e -= estimateBias(e, h.p)
+	}
+	if v != 0 {
+		lc := linearCounting(m, float64(v))
+		if lc <= threshold[h.p] {
+			return lc
+		}
+	}
+	return e
+}
+
+// threshold holds the cardinalities, indexed by precision, below which
+// linear counting is more accurate than the bias corrected estimate. The
+// values are those given in the appendix of "HyperLogLog in practice".
+var threshold = [...]float64{
+	4:  10,
+	5:  20,
+	6:  40,
+	7:  80,
+	8:  220,
+	9:  400,
+	10: 900,
+	11: 1800,
+	12: 3100,
+	13: 6500,
+	14: 11500,
+	15: 20000,
+	16: 50000,
+	17: 120000,
+	18: 350000,
+}
+
+// estimateBias returns the bias of the raw estimate e for a sketch with
+// precision p, the mean of the bias of the six raw estimates in
+// rawEstimateData that are nearest to e.
+func estimateBias(e float64, p uint8) float64 {
+	const k = 6
+	raw := rawEstimateData[p]
+	hi := sort.SearchFloat64s(raw, e)
+	lo := hi
+	for hi-lo < k {
+		switch {
+		case lo == 0:
+			hi++
+		case hi == len(raw):
+			lo--
+		case e-raw[lo-1] <= raw[hi]-e:
+			lo--
+		default:
+			hi++
+		}
+	}
+	var sum float64
+	for _, b := range biasData[p][lo:hi] {
+		sum += b
+	}
+	return sum / k
+}
+
+// Intersection returns an estimate of the cardinality of the intersection
+// of the sets of items written to the sketches a and b, calculated from the
+// cardinalities of a, b and their union by the inclusion–exclusion
+// principle. The relative error of the estimate is large when the
+// intersection is small compared to the union. Intersection will return
+// an error if the precisions or hash functions of a and b do not match.
+func Intersection(a, b *HyperLogLogPlus) (float64, error) {
+	var u HyperLogLogPlus
+	err := u.Union(a, b)
+	if err != nil {
+		return 0, err
+	}
+	return math.Max(0, a.Count()+b.Count()-u.Count()), nil
+}
+
+// Jaccard returns an estimate of the Jaccard index of the sets of items
+// written to the sketches a and b, the cardinality of their intersection
+// divided by the cardinality of their union. The intersection is estimated
+// as described for Intersection. Jaccard returns zero if both sketches are
+// empty, and will return an error if the precisions or hash functions of
+// a and b do not match.
+func Jaccard(a, b *HyperLogLogPlus) (float64, error) {
+	var u HyperLogLogPlus
+	err := u.Union(a, b)
+	if err != nil {
+		return 0, err
+	}
+	union := u.Count()
+	if union == 0 {
+		return 0, nil
+	}
+	inter := math.Max(0, a.Count()+b.Count()-union)
+	return math.Min(inter/union, 1), nil
+}
+
+// Reset clears the receiver allowing it to be reused. The receiver is
+// returned to the sparse representation. Reset does not alter the
+// precision of the receiver or the hash function that is used.
+func (h *HyperLogLogPlus) Reset() {
+	h.sparse = h.sparse[:0]
+	h.tmp = h.tmp[:0]
+	h.register = nil
+}
+
+// MarshalBinary marshals the sketch in the receiver. It encodes the
+// name of the hash function, the precision of the sketch, whether the
+// sketch is sparse and the sketch data. The receiver must have a non-nil
+// hash function.
+func (h *HyperLogLogPlus) MarshalBinary() ([]byte, error) {
+	h.mergeTmp()
+	if h.Sparse() {
+		return marshalHashed(h.hash, h.p, true, h.sparse)
+	}
+	return marshalHashed(h.hash, h.p, false, h.register)
+}
+
+// UnmarshalBinary unmarshals the binary representation of a sketch
+// into the receiver. The precision and representation of the receiver
+// will be set after return. The receiver must have a non-nil hash
+// function value that is the same type as the one that was stored in
+// the binary data.
+func (h *HyperLogLogPlus) UnmarshalBinary(b []byte) error {
+	var (
+		p      uint8
+		sparse bool
+	)
+	dec, err := unmarshalHashed(b, h.hash, &p, &sparse)
+	if err != nil {
+		return err
+	}
+	if p < 4 || maxPlusPrec < p {
+		return errors.New("card: precision out of range")
+	}
+	u := HyperLogLogPlus{p: p, m: uint64(1) << p, hash: h.hash}
+	if sparse {
+		err = dec.Decode(&u.sparse)
+	} else {
+		err = dec.Decode(&u.register)
+		if err == nil && uint64(len(u.register)) != u.m {
+			err = errors.New("card: invalid sketch data")
+		}
+	}
+	if err != nil {
+		return err
+	}
+	*h = u
+	return nil
+}
+
+// encodeSparse returns the sparse encoding of the hash x for a sketch with
+// precision p. The encoding holds the index of the sparse register in its
+// high bits. If the bits of the sparse index below the dense index are all
+// zero, the encoding also holds ϱ of the bits of x below the sparse index
+// and has its lowest bit set, otherwise ϱ for the dense register is
+// determined by the sparse index alone.
+func encodeSparse(x uint64, p uint8) uint32 {
+	idx := uint32(x >> (w64 - sparsePrec))
+	if idx&(1<<(sparsePrec-p)-1) == 0 {
+		r := rho64q(x, w64-sparsePrec)
+		return idx<<7 | uint32(r)<<1 | 1
+	}
+	return idx << 1
+}
+
+// sparseIndex returns the index of the sparse register of the encoded
+// hash k.
+func sparseIndex(k uint32) uint32 {
+	if k&1 != 0 {
+		return k >> 7
+	}
+	return k >> 1
+}
+
+// decodeSparse returns the index and value of the dense register for the
+// encoded hash k in a sketch with precision p.
+func decodeSparse(k uint32, p uint8) (idx uint32, r uint8) {
+	sidx := sparseIndex(k)
+	idx = sidx >> (sparsePrec - p)
+	if k&1 != 0 {
+		return idx, uint8(k>>1&0x3f) + (sparsePrec - p)
+	}
+	low := sidx & (1<<(sparsePrec-p) - 1)
+	return idx, uint8(bits.LeadingZeros32(low<<(32-(sparsePrec-p)))) + 1
+}
+
+// sortSparse sorts the encoded hashes in s by sparse index and then by
+// increasing value.
+func sortSparse(s []uint32) {
+	sort.Slice(s, func(i, j int) bool {
+		si, sj := sparseIndex(s[i]), sparseIndex(s[j])
+		if si != sj {
+			return si < sj
+		}
+		return s[i] < s[j]
+	})
+}
+
+// mergeSparse appends the merge of the sorted encoded hashes in a and b to
+// dst, keeping only the largest value for each sparse index, and returns
+// the result. For encoded hashes with the same sparse index, the largest
+// value has the largest ϱ.
+func mergeSparse(dst, a, b []uint32) []uint32 {
+	add := func(k uint32) {
+		if n := len(dst); n != 0 && sparseIndex(dst[n-1]) == sparseIndex(k) {
+			if k > dst[n-1] {
+				dst[n-1] = k
+			}
+			return
+		}
+		dst = append(dst, k)
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if sa, sb := sparseIndex(a[i]), sparseIndex(b[j]); sa < sb || (sa == sb && a[i] <= b[j]) {
+			add(a[i])
+			i++
+		} else {
+			add(b[j])
+			j++
+		}
+	}
+	for _, k := range a[i:] {
+		add(k)
+	}
+	for _, k := range b[j:] {
+		add(k)
+	}
+	return dst
+}
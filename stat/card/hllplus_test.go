@@ -0,0 +1,208 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package card
+
+import (
+	"hash/fnv"
+	"math"
+	"sort"
+	"strconv"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+// writeItems writes n distinct items starting at id to each of the
+// sketches in cs.
+func writeItems(n, id int, rnd *rand.Rand, cs ...counter) {
+	var buf []byte
+	for i := 0; i < n; i++ {
+		buf = strconv.AppendUint(buf[:0], rnd.Uint64(), 16)
+		buf = append(buf, '-')
+		buf = strconv.AppendUint(buf, uint64(id+i), 16)
+		for _, c := range cs {
+			c.Write(buf)
+		}
+	}
+}
+
+func TestHyperLogLogPlusSparse(t *testing.T) {
+	const prec = 12
+	rnd := rand.New(rand.NewSource(1))
+	h, _ := NewHyperLogLogPlus(prec, fnv.New64a())
+	d, _ := NewHyperLogLog64(prec, fnv.New64a())
+	limit := h.sparseLimit()
+	for n := 0; n < 2*limit; n++ {
+		writeItems(1, n, rnd, h, d)
+		if n < limit/2 && !h.Sparse() {
+			t.Fatalf("sketch converted to dense representation early after %d items", n+1)
+		}
+	}
+	if h.Sparse() {
+		t.Fatalf("sketch not converted to dense representation after %d items", 2*limit)
+	}
+
+	// The registers of the converted sketch are the registers
+	// that would have been produced by dense updates.
+	for i, r := range h.register {
+		if r != d.register[i] {
+			t.Fatalf("unexpected register %d after conversion: got:%d want:%d", i, r, d.register[i])
+		}
+	}
+
+	h.Reset()
+	if !h.Sparse() || h.Count() != 0 {
+		t.Errorf("sketch not sparse and empty after reset")
+	}
+}
+
+func TestSparseEncoding(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for p := uint8(4); p <= maxPlusPrec; p++ {
+		for i := 0; i < 10000; i++ {
+			x := rnd.Uint64()
+			switch i % 3 {
+			case 1:
+				// Clear the bits between the dense and sparse
+				// indices.
+				x &^= (1<<(sparsePrec-p) - 1) << (w64 - sparsePrec)
+			case 2:
+				// Also clear most of the remaining bits.
+				x &^= 1<<(w64-sparsePrec) - 1<<uint(rnd.Intn(10))
+			}
+			q := w64 - p
+			wantIdx := uint32(x >> q)
+			wantR := rho64q(x, q)
+			idx, r := decodeSparse(encodeSparse(x, p), p)
+			if idx != wantIdx || r != wantR {
+				t.Fatalf("unexpected decoding of %#x with precision %d: got:(%d,%d) want:(%d,%d)",
+					x, p, idx, r, wantIdx, wantR)
+			}
+		}
+	}
+}
+
+func TestHyperLogLogPlusBias(t *testing.T) {
+	// The original HyperLogLog estimator is biased for
+	// cardinalities of a few times the number of registers.
+	const (
+		prec = 10
+		reps = 50
+	)
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{500, 1000, 2500, 3500, 5000} {
+		var sum float64
+		for r := 0; r < reps; r++ {
+			h, _ := NewHyperLogLogPlus(prec, fnv.New64a())
+			writeItems(n, 0, rnd, h)
+			sum += h.Count()/float64(n) - 1
+		}
+		// The standard error of the mean relative error
+		// is about 1.04/sqrt(2^prec * reps) = 0.0046.
+		if bias := sum / reps; math.Abs(bias) > 0.015 {
+			t.Errorf("unexpected bias for cardinality %d: got:%v", n, bias)
+		}
+	}
+}
+
+func TestHyperLogLogPlusBiasData(t *testing.T) {
+	for p := 4; p <= maxPlusPrec; p++ {
+		raw := rawEstimateData[p]
+		if len(raw) != len(biasData[p]) {
+			t.Fatalf("mismatched bias data lengths for precision %d", p)
+		}
+		if !sort.Float64sAreSorted(raw) {
+			t.Errorf("raw estimates not sorted for precision %d", p)
+		}
+		if m := float64(uint64(1) << uint(p)); raw[len(raw)-1] < 5*m {
+			t.Errorf("raw estimates do not cover the bias corrected range for precision %d", p)
+		}
+	}
+}
+
+func TestHyperLogLogPlusUnion(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	a, _ := NewHyperLogLogPlus(14, fnv.New64a())
+	b, _ := NewHyperLogLogPlus(14, fnv.New64a())
+	writeItems(100, 0, rnd, a)
+	writeItems(100, 100, rnd, b)
+	var u HyperLogLogPlus
+	err := u.Union(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !u.Sparse() {
+		t.Errorf("union of small sparse sketches not sparse")
+	}
+	if got := u.Count(); math.Abs(got-200) > 1 {
+		t.Errorf("unexpected union count: got:%v want:200", got)
+	}
+
+	// Union of sparse and dense sketches.
+	writeItems(10000, 200, rnd, b)
+	if b.Sparse() {
+		t.Fatalf("sketch not dense after 10100 items")
+	}
+	err = a.Union(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Sparse() {
+		t.Errorf("union with dense sketch not dense")
+	}
+	if got := a.Count(); math.Abs(got/10200-1) > 0.03 {
+		t.Errorf("unexpected union count: got:%v want:10200", got)
+	}
+
+	c, _ := NewHyperLogLogPlus(12, fnv.New64a())
+	if err := u.Union(a, c); err == nil {
+		t.Errorf("expected error for mismatched precision")
+	}
+	d, _ := NewHyperLogLogPlus(14, fnv.New64())
+	if err := u.Union(a, d); err == nil {
+		t.Errorf("expected error for mismatched hash function")
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	const prec = 14
+	for _, test := range []struct {
+		a, b, shared int
+		tol          float64
+	}{
+		{a: 0, b: 0, shared: 0},
+		{a: 100, b: 100, shared: 50, tol: 0.01},
+		{a: 1e5, b: 1e5, shared: 1e5, tol: 0.02},
+		{a: 1e5, b: 2e5, shared: 5e4, tol: 0.05},
+		{a: 1e5, b: 1e5, shared: 0, tol: 0.05},
+	} {
+		rnd := rand.New(rand.NewSource(1))
+		a, _ := NewHyperLogLogPlus(prec, fnv.New64a())
+		b, _ := NewHyperLogLogPlus(prec, fnv.New64a())
+		writeItems(test.shared, 0, rnd, a, b)
+		writeItems(test.a-test.shared, test.shared, rnd, a)
+		writeItems(test.b-test.shared, test.a, rnd, b)
+
+		union := float64(test.a + test.b - test.shared)
+		want := 0.0
+		if union != 0 {
+			want = float64(test.shared) / union
+		}
+		got, err := Jaccard(a, b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if math.Abs(got-want) > test.tol {
+			t.Errorf("unexpected Jaccard index for %+v: got:%v want:%v", test, got, want)
+		}
+		inter, err := Intersection(a, b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if math.Abs(inter-float64(test.shared)) > test.tol*union {
+			t.Errorf("unexpected intersection for %+v: got:%v want:%d", test, inter, test.shared)
+		}
+	}
+}
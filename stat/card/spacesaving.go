@@ -0,0 +1,284 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package card
+
+import (
+	"bytes"
+	"container/heap"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// SpaceSaving implements tracking of the most frequent items of a stream
+// according to the Space-Saving algorithm described in "Efficient
+// computation of frequent and top-k elements in data streams", ICDT 2005,
+// pp398–412.
+//
+// A SpaceSaving tracker with capacity k monitors at most k items. When an
+// item that is not monitored is observed while k items are monitored, it
+// replaces the monitored item with the smallest count and inherits that
+// count as its error. Every item with a frequency greater than 1/k of the
+// total count of the stream is monitored, and the count of each monitored
+// item overestimates its frequency by at most its error, which is at most
+// the total count divided by k.
+//
+// Unlike the hashing sketches in this package, a SpaceSaving tracker holds
+// the monitored items themselves so that they can be reported.
+type SpaceSaving struct {
+	k     int
+	total uint64
+
+	// counters is a min-heap of the monitored
+	// items ordered by count, and index maps
+	// items to their position in the heap.
+	counters ssHeap
+	index    map[string]int
+}
+
+// ItemCount is a monitored item of a SpaceSaving tracker. The frequency of
+// the item is in the interval [Count-Error, Count].
+type ItemCount struct {
+	Item  string
+	Count uint64
+	Error uint64
+}
+
+// NewSpaceSaving returns a new SpaceSaving tracker that monitors at most k
+// items. The value of k must be positive.
+func NewSpaceSaving(k int) (*SpaceSaving, error) {
+	if k < 1 {
+		return nil, errors.New("card: capacity out of range")
+	}
+	s := &SpaceSaving{k: k}
+	s.Reset()
+	return s, nil
+}
+
+// Write notes the data in b as a single observation of an item into the
+// tracker held by the receiver. Write satisfies the io.Writer interface
+// and always returns a nil error.
+func (s *SpaceSaving) Write(b []byte) (int, error) {
+	s.Add(b, 1)
+	return len(b), nil
+}
+
+// Add notes count observations of the item in b into the tracker held by
+// the receiver.
+func (s *SpaceSaving) Add(b []byte, count uint64) {
+	if count == 0 {
+		return
+	}
+	s.total += count
+	if i, ok := s.index[string(b)]; ok {
+		s.counters.c[i].Count += count
+		heap.Fix(&s.counters, i)
+		return
+	}
+	if len(s.counters.c) < s.k {
+		heap.Push(&s.counters, ItemCount{Item: string(b), Count: count})
+		return
+	}
+	min := &s.counters.c[0]
+	delete(s.index, min.Item)
+	min.Item = string(b)
+	min.Error = min.Count
+	min.Count += count
+	s.index[min.Item] = 0
+	heap.Fix(&s.counters, 0)
+}
+
+// Frequency returns the estimated number of observations of the item in b
+// noted by the receiver and the maximum overestimation of the frequency.
+// If the item is not monitored, the estimate is the smallest count of the
+// monitored items when the tracker is full, and zero otherwise.
+func (s *SpaceSaving) Frequency(b []byte) (count, err uint64) {
+	if i, ok := s.index[string(b)]; ok {
+		c := s.counters.c[i]
+		return c.Count, c.Error
+	}
+	m := s.minCount()
+	return m, m
+}
+
+// minCount returns the smallest count of the monitored items if the
+// tracker is full, and zero otherwise.
+func (s *SpaceSaving) minCount() uint64 {
+	if len(s.counters.c) < s.k {
+		return 0
+	}
+	return s.counters.c[0].Count
+}
+
+// Total returns the total count of observations noted by the receiver.
+func (s *SpaceSaving) Total() uint64 {
+	return s.total
+}
+
+// Top returns the n monitored items with the largest counts in order of
+// decreasing count. If fewer than n items are monitored, all monitored
+// items are returned.
+func (s *SpaceSaving) Top(n int) []ItemCount {
+	top := append([]ItemCount(nil), s.counters.c...)
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].Item < top[j].Item
+	})
+	if n < len(top) {
+		top = top[:n]
+	}
+	return top
+}
+
+// HeavyHitters returns the monitored items whose counts exceed phi times
+// the total count in order of decreasing count. If phi is at least 1/k,
+// the returned items include every item with frequency greater than phi
+// times the total count. An item whose Count-Error also exceeds phi times
+// the total count is guaranteed to have such a frequency.
+func (s *SpaceSaving) HeavyHitters(phi float64) []ItemCount {
+	top := s.Top(len(s.counters.c))
+	thresh := phi * float64(s.total)
+	n := sort.Search(len(top), func(i int) bool { return float64(top[i].Count) <= thresh })
+	return top[:n]
+}
+
+// Merge places the combination of the trackers a and b into the receiver
+// according to the merge procedure of Agarwal et al., "Mergeable
+// summaries", ACM Trans. Database Syst. 38(4):26. An item not monitored
+// by one of the trackers is counted by it with its smallest count. Merge
+// will return an error if the capacities of a and b do not match.
+func (s *SpaceSaving) Merge(a, b *SpaceSaving) error {
+	if a.k != b.k {
+		return errors.New("card: mismatched capacity")
+	}
+	minA, minB := a.minCount(), b.minCount()
+	merged := make(map[string]ItemCount, len(a.counters.c)+len(b.counters.c))
+	for _, c := range a.counters.c {
+		c.Count += minB
+		c.Error += minB
+		merged[c.Item] = c
+	}
+	for _, c := range b.counters.c {
+		if m, ok := merged[c.Item]; ok {
+			m.Count += c.Count - minB
+			m.Error += c.Error - minB
+			merged[c.Item] = m
+			continue
+		}
+		c.Count += minA
+		c.Error += minA
+		merged[c.Item] = c
+	}
+	all := make([]ItemCount, 0, len(merged))
+	for _, c := range merged {
+		all = append(all, c)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Count != all[j].Count {
+			return all[i].Count > all[j].Count
+		}
+		return all[i].Item < all[j].Item
+	})
+	if len(all) > a.k {
+		all = all[:a.k]
+	}
+
+	total := a.total + b.total
+	*s = SpaceSaving{k: a.k}
+	s.Reset()
+	s.total = total
+	for _, c := range all {
+		heap.Push(&s.counters, c)
+	}
+	return nil
+}
+
+// Reset clears the receiver allowing it to be reused. Reset does not alter
+// the capacity of the receiver.
+func (s *SpaceSaving) Reset() {
+	s.total = 0
+	s.counters = ssHeap{c: make([]ItemCount, 0, s.k)}
+	s.index = make(map[string]int, s.k)
+	s.counters.index = s.index
+}
+
+// MarshalBinary marshals the tracker in the receiver. It encodes the
+// capacity of the tracker, the total count and the monitored items.
+func (s *SpaceSaving) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	for _, v := range []interface{}{uint8(w64), s.k, s.total, s.counters.c} {
+		err := enc.Encode(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary unmarshals the binary representation of a tracker into
+// the receiver. The capacity of the receiver will be set after return.
+func (s *SpaceSaving) UnmarshalBinary(b []byte) error {
+	dec := gob.NewDecoder(bytes.NewReader(b))
+	var size uint8
+	err := dec.Decode(&size)
+	if err != nil {
+		return err
+	}
+	if size != w64 {
+		return fmt.Errorf("card: mismatched size: dst=%d src=%d", w64, size)
+	}
+	var (
+		k        int
+		total    uint64
+		counters []ItemCount
+	)
+	for _, v := range []interface{}{&k, &total, &counters} {
+		err = dec.Decode(v)
+		if err != nil {
+			return err
+		}
+	}
+	if k < 1 || len(counters) > k {
+		return errors.New("card: invalid tracker data")
+	}
+	*s = SpaceSaving{k: k}
+	s.Reset()
+	s.total = total
+	for _, c := range counters {
+		heap.Push(&s.counters, c)
+	}
+	return nil
+}
+
+// ssHeap is a min-heap of monitored items ordered by count that maintains
+// an index of the positions of the items.
+type ssHeap struct {
+	c     []ItemCount
+	index map[string]int
+}
+
+func (h ssHeap) Len() int           { return len(h.c) }
+func (h ssHeap) Less(i, j int) bool { return h.c[i].Count < h.c[j].Count }
+func (h ssHeap) Swap(i, j int) {
+	h.c[i], h.c[j] = h.c[j], h.c[i]
+	h.index[h.c[i].Item] = i
+	h.index[h.c[j].Item] = j
+}
+func (h *ssHeap) Push(x interface{}) {
+	c := x.(ItemCount)
+	h.index[c.Item] = len(h.c)
+	h.c = append(h.c, c)
+}
+func (h *ssHeap) Pop() interface{} {
+	n := len(h.c) - 1
+	c := h.c[n]
+	h.c = h.c[:n]
+	delete(h.index, c.Item)
+	return c
+}
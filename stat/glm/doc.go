@@ -0,0 +1,15 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package glm provides generalized linear models.
+//
+// A generalized linear model relates the mean μ of a response from an
+// exponential dispersion family to a linear predictor η = Xβ + offset
+// through a link function g(μ) = η. Models are fitted by iteratively
+// reweighted least squares, which is Fisher scoring of the likelihood.
+//
+// The package provides the Gaussian, binomial, Poisson, gamma and negative
+// binomial families and the common link functions. Other families and
+// links may be used by implementing the Family and Link interfaces.
+package glm // import "gonum.org/v1/gonum/stat/glm"
@@ -0,0 +1,53 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package glm_test
+
+import (
+	"fmt"
+	"log"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/glm"
+)
+
+func ExampleModel() {
+	// Mortality of budworm moths in batches of twenty exposed
+	// to doses of an insecticide, from Venables and Ripley (2002),
+	// with columns for sex and log dose.
+	x := mat.NewDense(12, 2, []float64{
+		1, 0, 1, 1, 1, 2, 1, 3, 1, 4, 1, 5,
+		0, 0, 0, 1, 0, 2, 0, 3, 0, 4, 0, 5,
+	})
+	dead := []float64{1, 4, 9, 13, 18, 20, 0, 2, 6, 10, 12, 16}
+
+	// The binomial response is the proportion of deaths,
+	// and the weights are the numbers of trials.
+	y := make([]float64, len(dead))
+	trials := make([]float64, len(dead))
+	for i, d := range dead {
+		y[i] = d / 20
+		trials[i] = 20
+	}
+
+	m := glm.Model{Family: glm.Binomial{}, Intercept: true}
+	err := m.Fit(x, y, trials, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	coef := m.CoefficientsTo(nil)
+	se := m.StdErrorsTo(nil)
+	for i, name := range []string{"intercept", "male", "log dose"} {
+		fmt.Printf("%-9s %7.4f (%.4f)\n", name, coef[i], se[i])
+	}
+	fmt.Printf("deviance = %.4f, AIC = %.3f\n", m.Deviance(), m.AIC())
+	fmt.Printf("P(death | male, log dose 3) = %.3f\n", m.Predict([]float64{1, 3}, 0))
+
+	// Output:
+	// intercept -3.4732 (0.4685)
+	// male       1.1007 (0.3558)
+	// log dose   1.0642 (0.1311)
+	// deviance = 6.7571, AIC = 42.867
+	// P(death | male, log dose 3) = 0.694
+}
@@ -0,0 +1,248 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package glm
+
+import (
+	"math"
+)
+
+// Family is an exponential dispersion family of distributions of the
+// response, with variance φ V(μ)/w for an observation with prior weight w,
+// where φ is the dispersion parameter.
+type Family interface {
+	// DefaultLink returns the link used when a model does
+	// not specify one.
+	DefaultLink() Link
+
+	// Variance returns the value of the variance function V(μ).
+	Variance(mu float64) float64
+
+	// Deviance returns the unit deviance d(y, μ) of an observation.
+	// The deviance of a model is Σ_i w_i d(y_i, μ_i).
+	Deviance(y, mu float64) float64
+
+	// LogLikelihood returns the log-likelihood of the observation y
+	// with mean mu, prior weight w and dispersion phi.
+	LogLikelihood(y, mu, w, phi float64) float64
+
+	// Start returns an initial estimate of the mean for the
+	// observation y with prior weight w.
+	Start(y, w float64) float64
+
+	// ValidResponse returns whether y is in the support of the
+	// family.
+	ValidResponse(y float64) bool
+
+	// ValidMean returns whether mu is a valid mean of the family.
+	ValidMean(mu float64) bool
+
+	// FixedDispersion returns whether the dispersion parameter of
+	// the family is fixed at one. Otherwise it is estimated from
+	// the data.
+	FixedDispersion() bool
+}
+
+// ylogy returns y log(y/mu), which is zero when y is zero.
+func ylogy(y, mu float64) float64 {
+	if y == 0 {
+		return 0
+	}
+	return y * math.Log(y/mu)
+}
+
+// Gaussian is the normal family, with V(μ) = 1 and the identity link by
+// default.
+type Gaussian struct{}
+
+// DefaultLink returns the identity link.
+func (Gaussian) DefaultLink() Link { return Identity{} }
+
+// Variance returns 1.
+func (Gaussian) Variance(mu float64) float64 { return 1 }
+
+// Deviance returns (y-mu)^2.
+func (Gaussian) Deviance(y, mu float64) float64 { return (y - mu) * (y - mu) }
+
+// LogLikelihood returns the log of the normal density of y with mean mu
+// and variance phi/w.
+func (Gaussian) LogLikelihood(y, mu, w, phi float64) float64 {
+	v := phi / w
+	return -0.5 * (math.Log(2*math.Pi*v) + (y-mu)*(y-mu)/v)
+}
+
+// Start returns y.
+func (Gaussian) Start(y, w float64) float64 { return y }
+
+// ValidResponse returns whether y is finite.
+func (Gaussian) ValidResponse(y float64) bool { return !math.IsInf(y, 0) && !math.IsNaN(y) }
+
+// ValidMean returns whether mu is finite.
+func (Gaussian) ValidMean(mu float64) bool { return !math.IsInf(mu, 0) && !math.IsNaN(mu) }
+
+// FixedDispersion returns false.
+func (Gaussian) FixedDispersion() bool { return false }
+
+// Binomial is the binomial family, with V(μ) = μ(1-μ) and the logit link by
+// default. The response is the proportion of successes in [0, 1] and the
+// prior weight of an observation is the number of trials.
+type Binomial struct{}
+
+// DefaultLink returns the logit link.
+func (Binomial) DefaultLink() Link { return Logit{} }
+
+// Variance returns mu(1-mu).
+func (Binomial) Variance(mu float64) float64 { return mu * (1 - mu) }
+
+// Deviance returns 2(y log(y/mu) + (1-y) log((1-y)/(1-mu))).
+func (Binomial) Deviance(y, mu float64) float64 {
+	return 2 * (ylogy(y, mu) + ylogy(1-y, 1-mu))
+}
+
+// LogLikelihood returns the log of the binomial probability of w y
+// successes in w trials with success probability mu. The dispersion is
+// ignored.
+func (Binomial) LogLikelihood(y, mu, w, phi float64) float64 {
+	k := w * y
+	lw, _ := math.Lgamma(w + 1)
+	lk, _ := math.Lgamma(k + 1)
+	lwk, _ := math.Lgamma(w - k + 1)
+	ll := lw - lk - lwk
+	if k > 0 {
+		ll += k * math.Log(mu)
+	}
+	if w-k > 0 {
+		ll += (w - k) * math.Log1p(-mu)
+	}
+	return ll
+}
+
+// Start returns (w y + 1/2)/(w + 1).
+func (Binomial) Start(y, w float64) float64 { return (w*y + 0.5) / (w + 1) }
+
+// ValidResponse returns whether y is in [0, 1].
+func (Binomial) ValidResponse(y float64) bool { return 0 <= y && y <= 1 }
+
+// ValidMean returns whether mu is in (0, 1).
+func (Binomial) ValidMean(mu float64) bool { return 0 < mu && mu < 1 }
+
+// FixedDispersion returns true.
+func (Binomial) FixedDispersion() bool { return true }
+
+// Poisson is the Poisson family, with V(μ) = μ and the log link by default.
+type Poisson struct{}
+
+// DefaultLink returns the log link.
+func (Poisson) DefaultLink() Link { return Log{} }
+
+// Variance returns mu.
+func (Poisson) Variance(mu float64) float64 { return mu }
+
+// Deviance returns 2(y log(y/mu) - (y-mu)).
+func (Poisson) Deviance(y, mu float64) float64 { return 2 * (ylogy(y, mu) - (y - mu)) }
+
+// LogLikelihood returns w times the log of the Poisson probability of y
+// with mean mu. The dispersion is ignored.
+func (Poisson) LogLikelihood(y, mu, w, phi float64) float64 {
+	ly, _ := math.Lgamma(y + 1)
+	ll := -mu - ly
+	if y > 0 {
+		ll += y * math.Log(mu)
+	}
+	return w * ll
+}
+
+// Start returns y + 0.1.
+func (Poisson) Start(y, w float64) float64 { return y + 0.1 }
+
+// ValidResponse returns whether y is non-negative.
+func (Poisson) ValidResponse(y float64) bool { return 0 <= y && !math.IsInf(y, 1) }
+
+// ValidMean returns whether mu is positive.
+func (Poisson) ValidMean(mu float64) bool { return 0 < mu && !math.IsInf(mu, 1) }
+
+// FixedDispersion returns true.
+func (Poisson) FixedDispersion() bool { return true }
+
+// Gamma is the gamma family, with V(μ) = μ^2 and the reciprocal link by
+// default.
+type Gamma struct{}
+
+// DefaultLink returns the reciprocal link.
+func (Gamma) DefaultLink() Link { return Reciprocal{} }
+
+// Variance returns mu^2.
+func (Gamma) Variance(mu float64) float64 { return mu * mu }
+
+// Deviance returns -2(log(y/mu) - (y-mu)/mu).
+func (Gamma) Deviance(y, mu float64) float64 { return -2 * (math.Log(y/mu) - (y-mu)/mu) }
+
+// LogLikelihood returns w times the log of the gamma density of y with
+// shape 1/phi and mean mu.
+func (Gamma) LogLikelihood(y, mu, w, phi float64) float64 {
+	shape := 1 / phi
+	scale := mu * phi
+	lg, _ := math.Lgamma(shape)
+	return w * ((shape-1)*math.Log(y) - y/scale - lg - shape*math.Log(scale))
+}
+
+// Start returns y.
+func (Gamma) Start(y, w float64) float64 { return y }
+
+// ValidResponse returns whether y is positive.
+func (Gamma) ValidResponse(y float64) bool { return 0 < y && !math.IsInf(y, 1) }
+
+// ValidMean returns whether mu is positive.
+func (Gamma) ValidMean(mu float64) bool { return 0 < mu && !math.IsInf(mu, 1) }
+
+// FixedDispersion returns false.
+func (Gamma) FixedDispersion() bool { return false }
+
+// NegativeBinomial is the negative binomial family with known shape
+// parameter θ, with V(μ) = μ + μ^2/θ and the log link by default.
+type NegativeBinomial struct {
+	Theta float64
+}
+
+// DefaultLink returns the log link.
+func (NegativeBinomial) DefaultLink() Link { return Log{} }
+
+// Variance returns mu + mu^2/θ.
+func (n NegativeBinomial) Variance(mu float64) float64 { return mu + mu*mu/n.Theta }
+
+// Deviance returns 2(y log(y/mu) - (y+θ) log((y+θ)/(mu+θ))).
+func (n NegativeBinomial) Deviance(y, mu float64) float64 {
+	return 2 * (ylogy(y, mu) - (y+n.Theta)*math.Log((y+n.Theta)/(mu+n.Theta)))
+}
+
+// LogLikelihood returns w times the log of the negative binomial
+// probability of y with mean mu and shape θ. The dispersion is ignored.
+func (n NegativeBinomial) LogLikelihood(y, mu, w, phi float64) float64 {
+	t := n.Theta
+	lyt, _ := math.Lgamma(y + t)
+	lt, _ := math.Lgamma(t)
+	ly, _ := math.Lgamma(y + 1)
+	ll := lyt - lt - ly + t*math.Log(t) - (t+y)*math.Log(t+mu)
+	if y > 0 {
+		ll += y * math.Log(mu)
+	}
+	return w * ll
+}
+
+// Start returns y, or 1/6 if y is zero.
+func (NegativeBinomial) Start(y, w float64) float64 {
+	if y == 0 {
+		return 1.0 / 6
+	}
+	return y
+}
+
+// ValidResponse returns whether y is non-negative.
+func (NegativeBinomial) ValidResponse(y float64) bool { return 0 <= y && !math.IsInf(y, 1) }
+
+// ValidMean returns whether mu is positive.
+func (NegativeBinomial) ValidMean(mu float64) bool { return 0 < mu && !math.IsInf(mu, 1) }
+
+// FixedDispersion returns true.
+func (NegativeBinomial) FixedDispersion() bool { return true }
@@ -0,0 +1,114 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package glm
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/diff/fd"
+)
+
+func TestLink(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		link Link
+		mu   []float64
+	}{
+		{name: "Identity", link: Identity{}, mu: []float64{-3, 0, 0.5, 10}},
+		{name: "Log", link: Log{}, mu: []float64{0.01, 0.5, 1, 20}},
+		{name: "Logit", link: Logit{}, mu: []float64{0.001, 0.2, 0.5, 0.9}},
+		{name: "Probit", link: Probit{}, mu: []float64{0.001, 0.2, 0.5, 0.9}},
+		{name: "CLogLog", link: CLogLog{}, mu: []float64{0.001, 0.2, 0.5, 0.9}},
+		{name: "Reciprocal", link: Reciprocal{}, mu: []float64{0.1, 1, 4}},
+		{name: "Sqrt", link: Sqrt{}, mu: []float64{0.1, 1, 4}},
+	} {
+		for _, mu := range test.mu {
+			eta := test.link.Link(mu)
+			if got := test.link.Mean(eta); math.Abs(got-mu) > 1e-12*math.Max(1, math.Abs(mu)) {
+				t.Errorf("%s link mean does not invert link at %v: got:%v", test.name, mu, got)
+			}
+			want := fd.Derivative(test.link.Mean, eta, &fd.Settings{Formula: fd.Central})
+			if got := test.link.Deriv(eta); math.Abs(got-want) > 1e-6*math.Max(1, math.Abs(want)) {
+				t.Errorf("unexpected derivative of %s link at %v: got:%v want:%v", test.name, eta, got, want)
+			}
+		}
+	}
+
+	// Links to probabilities are bounded away from zero and one.
+	for _, link := range []Link{Logit{}, Probit{}, CLogLog{}} {
+		for _, eta := range []float64{-1000, 1000} {
+			mu := link.Mean(eta)
+			if !(0 < mu && mu < 1) {
+				t.Errorf("mean of %T not in (0, 1) at %v: got:%v", link, eta, mu)
+			}
+			if d := link.Deriv(eta); !(d > 0) {
+				t.Errorf("derivative of %T not positive at %v: got:%v", link, eta, d)
+			}
+		}
+	}
+}
+
+func TestFamily(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		family Family
+		y, mu  []float64
+		phi    float64
+	}{
+		{name: "Gaussian", family: Gaussian{}, y: []float64{-1, 0, 2.5}, mu: []float64{-0.5, 1, 2}, phi: 2},
+		{name: "Binomial", family: Binomial{}, y: []float64{0, 0.25, 1}, mu: []float64{0.1, 0.5, 0.7}, phi: 1},
+		{name: "Poisson", family: Poisson{}, y: []float64{0, 1, 7}, mu: []float64{0.5, 2, 6}, phi: 1},
+		{name: "Gamma", family: Gamma{}, y: []float64{0.5, 1, 7}, mu: []float64{0.7, 2, 6}, phi: 0.5},
+		{name: "NegativeBinomial", family: NegativeBinomial{Theta: 2.5}, y: []float64{0, 1, 7}, mu: []float64{0.5, 2, 6}, phi: 1},
+	} {
+		const w = 4
+		for i, y := range test.y {
+			if !test.family.ValidResponse(y) {
+				t.Errorf("unexpected invalid response for %s family: %v", test.name, y)
+			}
+			if d := test.family.Deviance(y, y); math.Abs(d) > 1e-14 {
+				t.Errorf("unexpected deviance of %s family at y=mu=%v: got:%v want:0", test.name, y, d)
+			}
+			mu := test.mu[i]
+			d := test.family.Deviance(y, mu)
+			if !(d > 0) {
+				t.Errorf("unexpected non-positive deviance of %s family: got:%v", test.name, d)
+			}
+
+			// The scaled deviance is twice the difference between
+			// the saturated and fitted log-likelihoods.
+			want := 2 * (test.family.LogLikelihood(y, y, w, test.phi) - test.family.LogLikelihood(y, mu, w, test.phi))
+			if got := w * d / test.phi; math.Abs(got-want) > 1e-10 {
+				t.Errorf("unexpected deviance of %s family at y=%v mu=%v: got:%v want:%v", test.name, y, mu, got, want)
+			}
+		}
+	}
+
+	// The log-likelihoods sum to one over the support.
+	for _, test := range []struct {
+		name   string
+		family Family
+		w      float64
+		mu     float64
+		max    int
+	}{
+		{name: "Binomial", family: Binomial{}, w: 10, mu: 0.3, max: 10},
+		{name: "Poisson", family: Poisson{}, w: 1, mu: 3.5, max: 100},
+		{name: "NegativeBinomial", family: NegativeBinomial{Theta: 2.5}, w: 1, mu: 3.5, max: 1000},
+	} {
+		var sum float64
+		for k := 0; k <= test.max; k++ {
+			y := float64(k)
+			if test.name == "Binomial" {
+				y /= test.w
+			}
+			sum += math.Exp(test.family.LogLikelihood(y, test.mu, test.w, 1))
+		}
+		if math.Abs(sum-1) > 1e-12 {
+			t.Errorf("probabilities of %s family do not sum to one: got:%v", test.name, sum)
+		}
+	}
+}
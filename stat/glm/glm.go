@@ -0,0 +1,660 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package glm
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/gonum/stat/internal/lsq"
+)
+
+const (
+	badLength   = "glm: slice length mismatch"
+	badResidual = "glm: bad residual kind"
+	badResponse = "glm: response not valid for family"
+	nilFamily   = "glm: nil family"
+	negWeight   = "glm: negative weight"
+	notFitted   = "glm: use of unsuccessful fit"
+	tooFewObs   = "glm: fewer observations than coefficients"
+)
+
+const (
+	// defaultIterations and defaultTolerance are the default
+	// maximum number of iterations and convergence tolerance
+	// of a fit.
+	defaultIterations = 25
+	defaultTolerance  = 1e-8
+
+	// maxHalvings is the maximum number of times a step of
+	// a fit is halved to find valid means.
+	maxHalvings = 25
+)
+
+var (
+	// ErrRankDeficient is returned when the columns of a design
+	// matrix are linearly dependent to within working precision.
+	ErrRankDeficient = errors.New("glm: design matrix is rank deficient")
+
+	// ErrNotConverged is returned when the fit does not converge
+	// within the maximum number of iterations.
+	ErrNotConverged = errors.New("glm: fit did not converge")
+
+	// ErrNoValidStep is returned when no step of the fit gives
+	// valid means and a finite deviance.
+	ErrNoValidStep = errors.New("glm: no valid step")
+)
+
+// ResidualKind specifies a kind of residual of a generalized linear model.
+type ResidualKind int
+
+const (
+	// Response residuals are y - μ.
+	Response ResidualKind = iota
+
+	// Pearson residuals are (y - μ) sqrt(w/V(μ)).
+	Pearson
+
+	// Deviance residuals are sign(y - μ) sqrt(w d(y, μ)), the
+	// signed square roots of the contributions to the deviance.
+	Deviance
+
+	// Working residuals are (y - μ) dη/dμ, the residuals of the
+	// final iteration of the weighted least squares fit.
+	Working
+)
+
+// Model is a generalized linear model
+//  g(μ_i) = x_iᵀβ + o_i
+// for the mean μ_i of the response y_i with a distribution from an
+// exponential dispersion family, a link function g and known offsets o_i.
+//
+// The fields of Model specify the model and must be set before calling Fit.
+// The results of the fit are only valid if the call to Fit was successful.
+type Model struct {
+	// Family is the distribution of the response.
+	Family Family
+
+	// Link is the link function of the model. If Link is nil the
+	// default link of the family is used.
+	Link Link
+
+	// Intercept specifies whether the model includes an intercept.
+	// If Intercept is true, a column of ones is prepended to the
+	// design matrix and the intercept is the first coefficient.
+	Intercept bool
+
+	// MaxIterations is the maximum number of iterations of the
+	// fit. If MaxIterations is zero, 25 is used.
+	MaxIterations int
+
+	// Tolerance is the convergence tolerance for the relative
+	// change in the deviance between iterations. If Tolerance
+	// is zero, 1e-8 is used.
+	Tolerance float64
+
+	ok   bool
+	link Link
+
+	n, p int
+	nobs int
+
+	coef     []float64
+	unscaled *mat.SymDense
+
+	y, w    []float64
+	eta, mu []float64
+	iter    int
+	dev     float64
+	nullDev float64
+	phi     float64
+	logLik  float64
+}
+
+// Fit fits the model to the response y and the n×p design matrix x by
+// iteratively reweighted least squares. Each iteration solves a weighted
+// least squares problem using the QR decomposition of the weighted design
+// matrix.
+//
+// If weights is nil all of the prior weights are one, otherwise the weights
+// must be non-negative. Observations with zero weight do not contribute to
+// the fit. If offset is not nil it holds the known offsets of the linear
+// predictor.
+//
+// Fit will panic if the Family field is nil, the lengths of y, weights and
+// offset do not equal the number of rows of x, any weight is negative, any
+// response is not valid for the family, or there are not more observations
+// with positive weight than coefficients.
+//
+// Fit returns ErrRankDeficient if the columns of the weighted design matrix
+// are linearly dependent and ErrNoValidStep if the iteration fails. In both
+// cases the receiver does not hold a valid fit. If the fit does not converge
+// within the maximum number of iterations, Fit returns ErrNotConverged and
+// the receiver holds the results of the final iteration.
+func (m *Model) Fit(x mat.Matrix, y, weights, offset []float64) error {
+	m.ok = false
+	if m.Family == nil {
+		panic(nilFamily)
+	}
+	n, c := x.Dims()
+	if len(y) != n || (weights != nil && len(weights) != n) || (offset != nil && len(offset) != n) {
+		panic(badLength)
+	}
+	w := make([]float64, n)
+	nobs := 0
+	for i := range w {
+		w[i] = 1
+		if weights != nil {
+			w[i] = weights[i]
+		}
+		if w[i] < 0 {
+			panic(negWeight)
+		}
+		if w[i] > 0 {
+			nobs++
+			if !m.Family.ValidResponse(y[i]) {
+				panic(badResponse)
+			}
+		}
+	}
+	if offset == nil {
+		offset = make([]float64, n)
+	}
+	p := c
+	if m.Intercept {
+		p++
+	}
+	if nobs <= p {
+		panic(tooFewObs)
+	}
+	link := m.Link
+	if link == nil {
+		link = m.Family.DefaultLink()
+	}
+	maxIter := m.MaxIterations
+	if maxIter == 0 {
+		maxIter = defaultIterations
+	}
+	tol := m.Tolerance
+	if tol == 0 {
+		tol = defaultTolerance
+	}
+
+	d := mat.NewDense(n, p, nil)
+	for i := 0; i < n; i++ {
+		row := d.RawRowView(i)
+		off := 0
+		if m.Intercept {
+			row[0] = 1
+			off = 1
+		}
+		for j := 0; j < c; j++ {
+			row[off+j] = x.At(i, j)
+		}
+	}
+
+	f := fitter{family: m.Family, link: link, x: d, y: y, w: w, offset: offset}
+	iter, err := f.irls(maxIter, tol)
+	if err != nil && err != ErrNotConverged {
+		return err
+	}
+	unscaled, ok := f.unscaledCovariance()
+	if !ok {
+		return ErrRankDeficient
+	}
+
+	m.link = link
+	m.n = n
+	m.p = p
+	m.nobs = nobs
+	m.coef = f.beta
+	m.unscaled = unscaled
+	m.y = append(m.y[:0], y...)
+	m.w = w
+	m.eta = f.eta
+	m.mu = f.mu
+	m.iter = iter
+	m.dev = f.dev
+
+	// Fit the null model, which has only the intercept if the
+	// model has one.
+	switch {
+	case !m.Intercept:
+		m.nullDev = 0
+		for i, v := range y {
+			m.nullDev += w[i] * m.Family.Deviance(v, link.Mean(offset[i]))
+		}
+	case isZero(offset):
+		mean := floats.Dot(w, y) / floats.Sum(w)
+		m.nullDev = 0
+		for i, v := range y {
+			m.nullDev += w[i] * m.Family.Deviance(v, mean)
+		}
+	default:
+		m.nullDev = m.fitNull(maxIter, tol, offset)
+	}
+
+	// Estimate the dispersion by the Pearson statistic.
+	m.phi = 1
+	if !m.Family.FixedDispersion() {
+		var pearson float64
+		for i, v := range y {
+			if w[i] == 0 {
+				continue
+			}
+			r := v - f.mu[i]
+			pearson += w[i] * r * r / m.Family.Variance(f.mu[i])
+		}
+		m.phi = pearson / float64(nobs-p)
+	}
+
+	// The log-likelihood uses the maximum likelihood
+	// estimate of the dispersion.
+	phiML := 1.0
+	if !m.Family.FixedDispersion() {
+		phiML = m.dev / float64(nobs)
+	}
+	m.logLik = 0
+	for i, v := range y {
+		if w[i] == 0 {
+			continue
+		}
+		m.logLik += m.Family.LogLikelihood(v, f.mu[i], w[i], phiML)
+	}
+
+	m.ok = true
+	return err
+}
+
+// zero sets all elements of x to zero.
+func zero(x []float64) {
+	for i := range x {
+		x[i] = 0
+	}
+}
+
+// isZero returns whether all elements of x are zero.
+func isZero(x []float64) bool {
+	for _, v := range x {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// fitNull returns the deviance of the model with only an intercept and the
+// given offsets.
+func (m *Model) fitNull(maxIter int, tol float64, offset []float64) float64 {
+	ones := mat.NewDense(m.n, 1, nil)
+	for i := 0; i < m.n; i++ {
+		ones.Set(i, 0, 1)
+	}
+	f := fitter{family: m.Family, link: m.link, x: ones, y: m.y, w: m.w, offset: offset}
+	_, err := f.irls(maxIter, tol)
+	if err != nil && err != ErrNotConverged {
+		return math.NaN()
+	}
+	return f.dev
+}
+
+// fitter holds the state of an iteratively reweighted least squares fit.
+type fitter struct {
+	family Family
+	link   Link
+
+	x      *mat.Dense
+	y, w   []float64
+	offset []float64
+
+	beta    []float64
+	eta, mu []float64
+	dev     float64
+
+	// xw and zw hold the weighted design and
+	// working response.
+	xw *mat.Dense
+	zw []float64
+}
+
+// irls fits the model by iteratively reweighted least squares, returning the
+// number of iterations.
+func (f *fitter) irls(maxIter int, tol float64) (int, error) {
+	n, p := f.x.Dims()
+	f.eta = make([]float64, n)
+	f.mu = make([]float64, n)
+	for i, v := range f.y {
+		f.eta[i] = f.link.Link(f.family.Start(v, f.w[i]))
+		f.mu[i] = f.link.Mean(f.eta[i])
+	}
+	f.dev = f.deviance(f.mu)
+	f.xw = mat.NewDense(n, p, nil)
+	f.zw = make([]float64, n)
+	eta := make([]float64, n)
+	mu := make([]float64, n)
+
+	for iter := 1; iter <= maxIter; iter++ {
+		// Form the weighted working response and design.
+		for i := 0; i < n; i++ {
+			if f.w[i] == 0 {
+				zero(f.xw.RawRowView(i))
+				f.zw[i] = 0
+				continue
+			}
+			d := f.link.Deriv(f.eta[i])
+			z := f.eta[i] - f.offset[i] + (f.y[i]-f.mu[i])/d
+			s := math.Sqrt(f.w[i] * d * d / f.family.Variance(f.mu[i]))
+			floats.ScaleTo(f.xw.RawRowView(i), s, f.x.RawRowView(i))
+			f.zw[i] = s * z
+		}
+		var qr mat.QR
+		qr.Factorize(f.xw)
+		if qr.Cond() > mat.ConditionTolerance {
+			return iter, ErrRankDeficient
+		}
+		var beta mat.VecDense
+		if err := qr.SolveVecTo(&beta, false, mat.NewVecDense(n, f.zw)); err != nil {
+			return iter, ErrRankDeficient
+		}
+		next := beta.RawVector().Data
+
+		// Halve the step until the means are valid and the
+		// deviance is finite.
+		var dev float64
+		for halving := 0; ; halving++ {
+			dev = f.evaluate(next, eta, mu)
+			if !math.IsNaN(dev) && !math.IsInf(dev, 0) {
+				break
+			}
+			if f.beta == nil || halving == maxHalvings {
+				return iter, ErrNoValidStep
+			}
+			for j := range next {
+				next[j] = (next[j] + f.beta[j]) / 2
+			}
+		}
+
+		f.beta = append(f.beta[:0], next...)
+		copy(f.eta, eta)
+		copy(f.mu, mu)
+		old := f.dev
+		f.dev = dev
+		if math.Abs(dev-old)/(math.Abs(dev)+0.1) < tol {
+			return iter, nil
+		}
+	}
+	return maxIter, ErrNotConverged
+}
+
+// evaluate computes the linear predictor and means for the coefficients beta,
+// storing them in eta and mu, and returns the deviance. evaluate returns NaN
+// if any mean is not valid.
+func (f *fitter) evaluate(beta, eta, mu []float64) float64 {
+	for i := range eta {
+		eta[i] = floats.Dot(f.x.RawRowView(i), beta) + f.offset[i]
+		mu[i] = f.link.Mean(eta[i])
+		if f.w[i] > 0 && !f.family.ValidMean(mu[i]) {
+			return math.NaN()
+		}
+	}
+	return f.deviance(mu)
+}
+
+// deviance returns the deviance of the model with means mu.
+func (f *fitter) deviance(mu []float64) float64 {
+	var dev float64
+	for i, v := range f.y {
+		if f.w[i] == 0 {
+			continue
+		}
+		dev += f.w[i] * f.family.Deviance(v, mu[i])
+	}
+	return dev
+}
+
+// unscaledCovariance returns (XᵀWX)⁻¹ for the working weights at the
+// current means.
+func (f *fitter) unscaledCovariance() (*mat.SymDense, bool) {
+	n, _ := f.x.Dims()
+	for i := 0; i < n; i++ {
+		if f.w[i] == 0 {
+			zero(f.xw.RawRowView(i))
+			continue
+		}
+		d := f.link.Deriv(f.eta[i])
+		s := math.Sqrt(f.w[i] * d * d / f.family.Variance(f.mu[i]))
+		floats.ScaleTo(f.xw.RawRowView(i), s, f.x.RawRowView(i))
+	}
+	var qr mat.QR
+	qr.Factorize(f.xw)
+	if qr.Cond() > mat.ConditionTolerance {
+		return nil, false
+	}
+	_, unscaled, ok := lsq.Covariance(&qr)
+	return unscaled, ok
+}
+
+func (m *Model) checkFit() {
+	if !m.ok {
+		panic(notFitted)
+	}
+}
+
+// useSlice returns dst if it is not nil, checking its length, or a new slice
+// of length n.
+func useSlice(dst []float64, n int) []float64 {
+	if dst == nil {
+		return make([]float64, n)
+	}
+	if len(dst) != n {
+		panic(badLength)
+	}
+	return dst
+}
+
+// CoefficientsTo returns the estimated coefficients of the model, with the
+// intercept first if the model has one. If dst is not nil the coefficients
+// are stored in dst, which must have length equal to the number of
+// coefficients. CoefficientsTo will panic if the receiver does not hold a
+// successful fit.
+func (m *Model) CoefficientsTo(dst []float64) []float64 {
+	m.checkFit()
+	dst = useSlice(dst, m.p)
+	copy(dst, m.coef)
+	return dst
+}
+
+// CovarianceTo computes the estimated covariance matrix of the coefficients,
+// φ (XᵀWX)⁻¹ where W holds the working weights of the fit, and stores it in
+// dst. If dst is empty, CovarianceTo will resize dst to be p×p. When dst is
+// non-empty, CovarianceTo will panic if dst is not p×p.
+func (m *Model) CovarianceTo(dst *mat.SymDense) {
+	m.checkFit()
+	if dst.IsEmpty() {
+		dst.ReuseAsSym(m.p)
+	} else if dst.Symmetric() != m.p {
+		panic(mat.ErrShape)
+	}
+	dst.ScaleSym(m.phi, m.unscaled)
+}
+
+// StdErrorsTo returns the standard errors of the estimated coefficients.
+// If dst is not nil the standard errors are stored in dst, which must have
+// length equal to the number of coefficients.
+func (m *Model) StdErrorsTo(dst []float64) []float64 {
+	m.checkFit()
+	dst = useSlice(dst, m.p)
+	for i := range dst {
+		dst[i] = math.Sqrt(m.phi * m.unscaled.At(i, i))
+	}
+	return dst
+}
+
+// StatisticsTo returns the Wald statistics β_i/se(β_i) of the tests of the
+// hypotheses that each of the coefficients is zero. If dst is not nil the
+// statistics are stored in dst, which must have length equal to the number
+// of coefficients.
+func (m *Model) StatisticsTo(dst []float64) []float64 {
+	dst = m.StdErrorsTo(dst)
+	for i, se := range dst {
+		dst[i] = m.coef[i] / se
+	}
+	return dst
+}
+
+// PValuesTo returns the two-sided p-values of the Wald tests of the
+// hypotheses that each of the coefficients is zero. The reference
+// distribution of the statistics is the standard normal distribution if the
+// dispersion of the family is fixed, and otherwise the Student's t
+// distribution with n-p degrees of freedom. If dst is not nil the p-values
+// are stored in dst, which must have length equal to the number of
+// coefficients.
+func (m *Model) PValuesTo(dst []float64) []float64 {
+	dst = m.StatisticsTo(dst)
+	for i, v := range dst {
+		if m.Family.FixedDispersion() {
+			dst[i] = 2 * distuv.UnitNormal.Survival(math.Abs(v))
+		} else {
+			dst[i] = 2 * distuv.StudentsT{Mu: 0, Sigma: 1, Nu: float64(m.nobs - m.p)}.Survival(math.Abs(v))
+		}
+	}
+	return dst
+}
+
+// Dispersion returns the dispersion parameter of the model. The dispersion
+// is one for families with fixed dispersion and is otherwise estimated by
+// the Pearson statistic
+//  φ = Σ_i w_i (y_i - μ_i)^2 / V(μ_i) / (n - p)
+func (m *Model) Dispersion() float64 {
+	m.checkFit()
+	return m.phi
+}
+
+// Deviance returns the deviance of the fitted model, Σ_i w_i d(y_i, μ_i).
+func (m *Model) Deviance() float64 {
+	m.checkFit()
+	return m.dev
+}
+
+// NullDeviance returns the deviance of the null model, which includes only
+// the intercept, if the model has one, and the offsets.
+func (m *Model) NullDeviance() float64 {
+	m.checkFit()
+	return m.nullDev
+}
+
+// DoF returns the residual degrees of freedom of the null model and of the
+// fitted model. Observations with zero weight are not counted.
+func (m *Model) DoF() (null, residual int) {
+	m.checkFit()
+	null = m.nobs
+	if m.Intercept {
+		null--
+	}
+	return null, m.nobs - m.p
+}
+
+// Iterations returns the number of iterations used by the fit.
+func (m *Model) Iterations() int {
+	m.checkFit()
+	return m.iter
+}
+
+// LogLikelihood returns the maximized log-likelihood of the model. For
+// families with estimated dispersion the likelihood is evaluated at the
+// maximum likelihood estimate of the dispersion, the deviance divided by
+// the number of observations.
+func (m *Model) LogLikelihood() float64 {
+	m.checkFit()
+	return m.logLik
+}
+
+// AIC returns Akaike's information criterion
+//  -2 log L + 2k
+// where k is the number of coefficients, plus one if the dispersion is
+// estimated.
+func (m *Model) AIC() float64 {
+	m.checkFit()
+	k := m.p
+	if !m.Family.FixedDispersion() {
+		k++
+	}
+	return -2*m.logLik + 2*float64(k)
+}
+
+// FittedTo returns the fitted means of the response. If dst is not nil the
+// means are stored in dst, which must have length equal to the number of
+// observations.
+func (m *Model) FittedTo(dst []float64) []float64 {
+	m.checkFit()
+	dst = useSlice(dst, m.n)
+	copy(dst, m.mu)
+	return dst
+}
+
+// LinearPredictorTo returns the fitted linear predictor including the
+// offsets. If dst is not nil the values are stored in dst, which must have
+// length equal to the number of observations.
+func (m *Model) LinearPredictorTo(dst []float64) []float64 {
+	m.checkFit()
+	dst = useSlice(dst, m.n)
+	copy(dst, m.eta)
+	return dst
+}
+
+// ResidualsTo returns the residuals of the given kind. If dst is not nil
+// the residuals are stored in dst, which must have length equal to the
+// number of observations. ResidualsTo will panic if kind is not a valid
+// ResidualKind.
+func (m *Model) ResidualsTo(dst []float64, kind ResidualKind) []float64 {
+	m.checkFit()
+	dst = useSlice(dst, m.n)
+	for i, y := range m.y {
+		mu := m.mu[i]
+		r := y - mu
+		switch kind {
+		case Response:
+		case Pearson:
+			r *= math.Sqrt(m.w[i] / m.Family.Variance(mu))
+		case Deviance:
+			d := math.Sqrt(math.Max(m.w[i]*m.Family.Deviance(y, mu), 0))
+			if r < 0 {
+				d = -d
+			}
+			r = d
+		case Working:
+			r /= m.link.Deriv(m.eta[i])
+		default:
+			panic(badResidual)
+		}
+		dst[i] = r
+	}
+	return dst
+}
+
+// Predict returns the predicted mean of the response for the predictors x,
+// which exclude the intercept, and the given offset. Predict will panic if
+// the length of x does not equal the number of columns of the design matrix
+// used in the fit.
+func (m *Model) Predict(x []float64, offset float64) float64 {
+	m.checkFit()
+	c := m.p
+	if m.Intercept {
+		c--
+	}
+	if len(x) != c {
+		panic(badLength)
+	}
+	eta := offset
+	coef := m.coef
+	if m.Intercept {
+		eta += coef[0]
+		coef = coef[1:]
+	}
+	return m.link.Mean(eta + floats.Dot(x, coef))
+}
@@ -0,0 +1,514 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package glm
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/regression"
+)
+
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		r := recover()
+		panicked = r != nil
+	}()
+	fn()
+	return
+}
+
+// dobson returns the Poisson regression example of Dobson (1990) from R's
+// glm documentation, with treatment contrasts for outcome and treatment.
+func dobson() (x *mat.Dense, counts []float64) {
+	counts = []float64{18, 17, 15, 20, 10, 20, 25, 13, 12}
+	x = mat.NewDense(9, 4, nil)
+	for i := range counts {
+		outcome, treatment := i%3, i/3
+		if outcome > 0 {
+			x.Set(i, outcome-1, 1)
+		}
+		if treatment > 0 {
+			x.Set(i, treatment+1, 1)
+		}
+	}
+	return x, counts
+}
+
+func TestModelR(t *testing.T) {
+	type want struct {
+		coef, se, p    []float64
+		dev, nullDev   float64
+		dispersion     float64
+		aic            float64
+		iter           int
+		dfNull, dfResd int
+	}
+
+	// Clotting times of blood from McCullagh and Nelder (1989).
+	clotting := mat.NewDense(9, 1, nil)
+	for i, u := range []float64{5, 10, 15, 20, 30, 40, 60, 80, 100} {
+		clotting.Set(i, 0, math.Log(u))
+	}
+	lot1 := []float64{118, 58, 42, 35, 27, 25, 21, 19, 18}
+
+	// Budworm mortality from Venables and Ripley (2002) with 20
+	// moths in each batch, with columns for sex, log dose and
+	// their interaction.
+	budworm := mat.NewDense(12, 3, nil)
+	dead := []float64{1, 4, 9, 13, 18, 20, 0, 2, 6, 10, 12, 16}
+	prop := make([]float64, 12)
+	trials := make([]float64, 12)
+	for i := range dead {
+		var male float64
+		if i < 6 {
+			male = 1
+		}
+		dose := float64(i % 6)
+		budworm.SetRow(i, []float64{male, dose, male * dose})
+		prop[i] = dead[i] / 20
+		trials[i] = 20
+	}
+
+	x, counts := dobson()
+	for _, test := range []struct {
+		name    string
+		family  Family
+		x       mat.Matrix
+		y, w    []float64
+		want    want
+		coefTol float64
+		seTol   float64
+	}{
+		{
+			// glm(counts ~ outcome + treatment, family = poisson())
+			name:   "Poisson",
+			family: Poisson{},
+			x:      x,
+			y:      counts,
+			want: want{
+				coef:       []float64{3.044522, -0.4542553, -0.2929871, 0, 0},
+				se:         []float64{0.1708987, 0.2021708, 0.1927423, 0.2, 0.2},
+				p:          []float64{5.426767e-71, 0.02464711, 0.1284865, 1, 1},
+				dev:        5.129141,
+				nullDev:    10.58145,
+				dispersion: 1,
+				aic:        56.76132,
+				iter:       4,
+				dfNull:     8,
+				dfResd:     4,
+			},
+			coefTol: 1e-6,
+			seTol:   1e-7,
+		},
+		{
+			// glm(lot1 ~ log(u), family = Gamma)
+			name:   "Gamma",
+			family: Gamma{},
+			x:      clotting,
+			y:      lot1,
+			want: want{
+				coef:       []float64{-0.01655438, 0.01534311},
+				se:         []float64{0.0009275466, 0.0004149596},
+				p:          []float64{4.279e-07, 2.294e-09},
+				dev:        0.01672967,
+				nullDev:    3.51283,
+				dispersion: 0.002446059,
+				aic:        37.99,
+				iter:       3,
+				dfNull:     8,
+				dfResd:     7,
+			},
+			coefTol: 1e-8,
+			seTol:   1e-8,
+		},
+		{
+			// glm(SF ~ sex*ldose, family = binomial)
+			name:   "Binomial",
+			family: Binomial{},
+			x:      budworm,
+			y:      prop,
+			w:      trials,
+			want: want{
+				coef:       []float64{-2.9935418, 0.1749868, 0.9060364, 0.3529130},
+				se:         []float64{0.5526998, 0.7783101, 0.1671017, 0.2699903},
+				p:          []float64{6.09e-08, 0.8221, 5.89e-08, 0.1912},
+				dev:        4.993727,
+				nullDev:    124.8756,
+				dispersion: 1,
+				aic:        43.10413,
+				iter:       4,
+				dfNull:     11,
+				dfResd:     8,
+			},
+			coefTol: 1e-6,
+			seTol:   1e-6,
+		},
+	} {
+		m := Model{Family: test.family, Intercept: true}
+		if err := m.Fit(test.x, test.y, test.w, nil); err != nil {
+			t.Fatalf("unexpected error for %s model: %v", test.name, err)
+		}
+		if got := m.CoefficientsTo(nil); !floats.EqualApprox(got, test.want.coef, test.coefTol) {
+			t.Errorf("unexpected coefficients for %s model: got:%v want:%v", test.name, got, test.want.coef)
+		}
+		if got := m.StdErrorsTo(nil); !floats.EqualApprox(got, test.want.se, test.seTol) {
+			t.Errorf("unexpected standard errors for %s model: got:%v want:%v", test.name, got, test.want.se)
+		}
+		got := m.PValuesTo(nil)
+		for i, p := range got {
+			want := test.want.p[i]
+			if math.Abs(p-want) > 1e-3*want && math.Abs(p-want) > 1e-4 {
+				t.Errorf("unexpected p-value for %s model coefficient %d: got:%v want:%v", test.name, i, p, want)
+			}
+		}
+		for _, v := range []struct {
+			name      string
+			got, want float64
+		}{
+			{name: "deviance", got: m.Deviance(), want: test.want.dev},
+			{name: "null deviance", got: m.NullDeviance(), want: test.want.nullDev},
+			{name: "dispersion", got: m.Dispersion(), want: test.want.dispersion},
+			{name: "AIC", got: m.AIC(), want: test.want.aic},
+		} {
+			if math.Abs(v.got-v.want) > 1e-5*v.want {
+				t.Errorf("unexpected %s for %s model: got:%v want:%v", v.name, test.name, v.got, v.want)
+			}
+		}
+		if got := m.Iterations(); got != test.want.iter {
+			t.Errorf("unexpected number of iterations for %s model: got:%d want:%d", test.name, got, test.want.iter)
+		}
+		if dfNull, dfResid := m.DoF(); dfNull != test.want.dfNull || dfResid != test.want.dfResd {
+			t.Errorf("unexpected degrees of freedom for %s model: got:%d,%d want:%d,%d",
+				test.name, dfNull, dfResid, test.want.dfNull, test.want.dfResd)
+		}
+
+		// The deviance residuals give the deviance and the Pearson
+		// residuals give the dispersion.
+		dr := m.ResidualsTo(nil, Deviance)
+		if dev := floats.Dot(dr, dr); math.Abs(dev-m.Deviance()) > 1e-10*dev {
+			t.Errorf("deviance residuals do not sum to deviance for %s model: got:%v want:%v", test.name, dev, m.Deviance())
+		}
+		if !test.family.FixedDispersion() {
+			pr := m.ResidualsTo(nil, Pearson)
+			_, df := m.DoF()
+			if phi := floats.Dot(pr, pr) / float64(df); math.Abs(phi-m.Dispersion()) > 1e-12 {
+				t.Errorf("unexpected dispersion from Pearson residuals for %s model: got:%v want:%v", test.name, phi, m.Dispersion())
+			}
+		}
+	}
+}
+
+func TestModelGaussian(t *testing.T) {
+	// A Gaussian model with the identity link is a weighted linear
+	// regression.
+	rnd := rand.New(rand.NewSource(1))
+	const n = 30
+	x := mat.NewDense(n, 2, nil)
+	y := make([]float64, n)
+	w := make([]float64, n)
+	for i := 0; i < n; i++ {
+		a, b := rnd.NormFloat64(), rnd.NormFloat64()
+		x.SetRow(i, []float64{a, b})
+		y[i] = 1 + 2*a - b + rnd.NormFloat64()
+		w[i] = 0.5 + rnd.Float64()
+	}
+	m := Model{Family: Gaussian{}, Intercept: true}
+	if err := m.Fit(x, y, w, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var l regression.Linear
+	if err := l.Fit(x, y, w, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := m.CoefficientsTo(nil), l.CoefficientsTo(nil); !floats.EqualApprox(got, want, 1e-12) {
+		t.Errorf("unexpected coefficients: got:%v want:%v", got, want)
+	}
+	if got, want := m.StdErrorsTo(nil), l.StdErrorsTo(nil, regression.Classical); !floats.EqualApprox(got, want, 1e-12) {
+		t.Errorf("unexpected standard errors: got:%v want:%v", got, want)
+	}
+	if got, want := m.PValuesTo(nil), l.PValuesTo(nil, regression.Classical); !floats.EqualApprox(got, want, 1e-12) {
+		t.Errorf("unexpected p-values: got:%v want:%v", got, want)
+	}
+	if got, want := math.Sqrt(m.Dispersion()), l.Sigma(); math.Abs(got-want) > 1e-12 {
+		t.Errorf("unexpected dispersion: got:%v want:%v", got*got, want*want)
+	}
+	if got := m.Iterations(); got > 2 {
+		t.Errorf("unexpected number of iterations for linear model: got:%d", got)
+	}
+	if got, want := m.ResidualsTo(nil, Response), l.ResidualsTo(nil); !floats.EqualApprox(got, want, 1e-12) {
+		t.Errorf("unexpected residuals: got:%v want:%v", got, want)
+	}
+	v := []float64{0.3, -1.2}
+	if got, want := m.Predict(v, 0), l.Predict(v); math.Abs(got-want) > 1e-12 {
+		t.Errorf("unexpected prediction: got:%v want:%v", got, want)
+	}
+}
+
+func TestModelScore(t *testing.T) {
+	// At the maximum likelihood estimate the score equations
+	//  Σ_i w_i (y_i - μ_i) (dμ/dη)_i / V(μ_i) x_i = 0
+	// hold for any family and link.
+	rnd := rand.New(rand.NewSource(1))
+	const n = 200
+	x := mat.NewDense(n, 2, nil)
+	offset := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x.SetRow(i, []float64{rnd.NormFloat64(), rnd.Float64()})
+		offset[i] = 0.1 * rnd.NormFloat64()
+	}
+	for _, test := range []struct {
+		name   string
+		family Family
+		link   Link
+		sample func(eta float64) float64
+	}{
+		{
+			name:   "probit",
+			family: Binomial{},
+			link:   Probit{},
+			sample: func(eta float64) float64 {
+				if rnd.Float64() < (Probit{}).Mean(eta) {
+					return 1
+				}
+				return 0
+			},
+		},
+		{
+			name:   "complementary log-log",
+			family: Binomial{},
+			link:   CLogLog{},
+			sample: func(eta float64) float64 {
+				if rnd.Float64() < (CLogLog{}).Mean(eta) {
+					return 1
+				}
+				return 0
+			},
+		},
+		{
+			name:   "negative binomial",
+			family: NegativeBinomial{Theta: 3},
+			sample: func(eta float64) float64 {
+				// Gamma mixture of Poisson variates.
+				lambda := math.Exp(eta) * gammaUnitMean(rnd, 3)
+				return poisson(rnd, lambda)
+			},
+		},
+		{
+			name:   "Poisson with square root link",
+			family: Poisson{},
+			link:   Sqrt{},
+			sample: func(eta float64) float64 { return poisson(rnd, eta*eta) },
+		},
+		{
+			name:   "Gamma with log link",
+			family: Gamma{},
+			link:   Log{},
+			sample: func(eta float64) float64 { return math.Exp(eta) * gammaUnitMean(rnd, 2) },
+		},
+	} {
+		y := make([]float64, n)
+		for i := range y {
+			eta := 1 + 0.5*x.At(i, 0) - x.At(i, 1) + offset[i]
+			y[i] = test.sample(eta)
+		}
+		// Use a tight tolerance since the deviance converges
+		// faster than the coefficients.
+		m := Model{Family: test.family, Link: test.link, Intercept: true, Tolerance: 1e-14}
+		if err := m.Fit(x, y, nil, offset); err != nil {
+			t.Fatalf("unexpected error for %s model: %v", test.name, err)
+		}
+		link := test.link
+		if link == nil {
+			link = test.family.DefaultLink()
+		}
+		mu := m.FittedTo(nil)
+		eta := m.LinearPredictorTo(nil)
+		score := make([]float64, 3)
+		for i := range y {
+			u := (y[i] - mu[i]) * link.Deriv(eta[i]) / test.family.Variance(mu[i])
+			score[0] += u
+			score[1] += u * x.At(i, 0)
+			score[2] += u * x.At(i, 1)
+		}
+		if floats.Norm(score, 2) > 1e-6 {
+			t.Errorf("score not zero at estimate for %s model: got:%v", test.name, score)
+		}
+		coef := m.CoefficientsTo(nil)
+		for i := range eta {
+			want := coef[0] + coef[1]*x.At(i, 0) + coef[2]*x.At(i, 1) + offset[i]
+			if math.Abs(eta[i]-want) > 1e-12 {
+				t.Errorf("unexpected linear predictor for %s model: got:%v want:%v", test.name, eta[i], want)
+				break
+			}
+		}
+		if got := m.Predict(x.RawRowView(0), offset[0]); math.Abs(got-mu[0]) > 1e-12 {
+			t.Errorf("unexpected prediction for %s model: got:%v want:%v", test.name, got, mu[0])
+		}
+		wr := m.ResidualsTo(nil, Working)
+		for i := range wr {
+			if want := (y[i] - mu[i]) / link.Deriv(eta[i]); math.Abs(wr[i]-want) > 1e-12*math.Max(1, math.Abs(want)) {
+				t.Errorf("unexpected working residual for %s model: got:%v want:%v", test.name, wr[i], want)
+				break
+			}
+		}
+	}
+}
+
+// poisson returns a Poisson random variate with mean lambda.
+func poisson(rnd *rand.Rand, lambda float64) float64 {
+	var k float64
+	for p := rnd.ExpFloat64(); p < lambda; p += rnd.ExpFloat64() {
+		k++
+	}
+	return k
+}
+
+// gammaUnitMean returns a gamma random variate with integer shape k and
+// unit mean.
+func gammaUnitMean(rnd *rand.Rand, k int) float64 {
+	var s float64
+	for i := 0; i < k; i++ {
+		s += rnd.ExpFloat64()
+	}
+	return s / float64(k)
+}
+
+func TestModelOffsetWeights(t *testing.T) {
+	x, counts := dobson()
+
+	// A constant offset shifts the intercept.
+	var base Model
+	base.Family = Poisson{}
+	base.Intercept = true
+	if err := base.Fit(x, counts, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	offset := make([]float64, len(counts))
+	for i := range offset {
+		offset[i] = math.Log(2)
+	}
+	m := Model{Family: Poisson{}, Intercept: true}
+	if err := m.Fit(x, counts, nil, offset); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := base.CoefficientsTo(nil)
+	want[0] -= math.Log(2)
+	if got := m.CoefficientsTo(nil); !floats.EqualApprox(got, want, 1e-8) {
+		t.Errorf("unexpected coefficients with offset: got:%v want:%v", got, want)
+	}
+	if math.Abs(m.Deviance()-base.Deviance()) > 1e-8 || math.Abs(m.NullDeviance()-base.NullDeviance()) > 1e-8 {
+		t.Errorf("unexpected deviances with offset: got:%v,%v want:%v,%v",
+			m.Deviance(), m.NullDeviance(), base.Deviance(), base.NullDeviance())
+	}
+
+	// Observations with zero weight are excluded from the fit.
+	xw := mat.NewDense(10, 4, nil)
+	xw.Slice(0, 9, 0, 4).(*mat.Dense).Copy(x)
+	xw.SetRow(9, []float64{1, 0, 1, 0})
+	y := append(append([]float64(nil), counts...), 100)
+	w := []float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 0}
+	m = Model{Family: Poisson{}, Intercept: true}
+	if err := m.Fit(xw, y, w, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := m.CoefficientsTo(nil), base.CoefficientsTo(nil); !floats.EqualApprox(got, want, 1e-10) {
+		t.Errorf("unexpected coefficients with zero weight: got:%v want:%v", got, want)
+	}
+	if got, want := m.StdErrorsTo(nil), base.StdErrorsTo(nil); !floats.EqualApprox(got, want, 1e-10) {
+		t.Errorf("unexpected standard errors with zero weight: got:%v want:%v", got, want)
+	}
+	if math.Abs(m.AIC()-base.AIC()) > 1e-10 || math.Abs(m.NullDeviance()-base.NullDeviance()) > 1e-10 {
+		t.Errorf("unexpected AIC or null deviance with zero weight")
+	}
+	if dfNull, dfResid := m.DoF(); dfNull != 8 || dfResid != 4 {
+		t.Errorf("unexpected degrees of freedom with zero weight: got:%d,%d want:8,4", dfNull, dfResid)
+	}
+
+	// Integer weights are equivalent to repeated observations for
+	// the coefficients.
+	xr := mat.NewDense(12, 4, nil)
+	xr.Slice(0, 9, 0, 4).(*mat.Dense).Copy(x)
+	yr := append([]float64(nil), counts...)
+	wr := make([]float64, 9)
+	for i := range wr {
+		wr[i] = 1
+	}
+	for _, i := range []int{0, 4, 4} {
+		xr.SetRow(len(yr), x.RawRowView(i))
+		yr = append(yr, counts[i])
+		wr[i]++
+	}
+	var rep, wtd Model
+	rep.Family, wtd.Family = Poisson{}, Poisson{}
+	rep.Intercept, wtd.Intercept = true, true
+	if err := rep.Fit(xr, yr, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := wtd.Fit(x, counts, wr, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := wtd.CoefficientsTo(nil), rep.CoefficientsTo(nil); !floats.EqualApprox(got, want, 1e-8) {
+		t.Errorf("unexpected coefficients with integer weights: got:%v want:%v", got, want)
+	}
+	if math.Abs(wtd.Deviance()-rep.Deviance()) > 1e-8 {
+		t.Errorf("unexpected deviance with integer weights: got:%v want:%v", wtd.Deviance(), rep.Deviance())
+	}
+}
+
+func TestModelErrors(t *testing.T) {
+	x, counts := dobson()
+	var m Model
+	if !panics(func() { m.Fit(x, counts, nil, nil) }) {
+		t.Errorf("expected panic for nil family")
+	}
+	m.Family = Poisson{}
+	m.Intercept = true
+	for _, test := range []struct {
+		name         string
+		x            mat.Matrix
+		y, w, offset []float64
+	}{
+		{name: "response length", x: x, y: counts[:8]},
+		{name: "weights length", x: x, y: counts, w: []float64{1}},
+		{name: "offset length", x: x, y: counts, offset: []float64{1}},
+		{name: "negative weight", x: x, y: counts, w: []float64{1, 1, 1, 1, 1, 1, 1, 1, -1}},
+		{name: "invalid response", x: x, y: []float64{1, 2, 3, 4, 5, 6, 7, 8, -1}},
+		{name: "too few observations", x: x.Slice(0, 5, 0, 4), y: counts[:5]},
+	} {
+		if !panics(func() { m.Fit(test.x, test.y, test.w, test.offset) }) {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+
+	collinear := mat.NewDense(9, 2, nil)
+	for i := 0; i < 9; i++ {
+		collinear.SetRow(i, []float64{float64(i), 2 * float64(i)})
+	}
+	if err := m.Fit(collinear, counts, nil, nil); err != ErrRankDeficient {
+		t.Errorf("unexpected error for collinear design: got:%v want:%v", err, ErrRankDeficient)
+	}
+	if !panics(func() { m.CoefficientsTo(nil) }) {
+		t.Errorf("expected panic for use of unsuccessful fit")
+	}
+
+	m.MaxIterations = 1
+	if err := m.Fit(x, counts, nil, nil); err != ErrNotConverged {
+		t.Errorf("unexpected error for too few iterations: got:%v want:%v", err, ErrNotConverged)
+	}
+	if got := m.Iterations(); got != 1 {
+		t.Errorf("unexpected number of iterations: got:%d want:1", got)
+	}
+	if !panics(func() { m.ResidualsTo(nil, Working+1) }) {
+		t.Errorf("expected panic for bad residual kind")
+	}
+	if !panics(func() { m.Predict([]float64{1}, 0) }) {
+		t.Errorf("expected panic for predictor length mismatch")
+	}
+}
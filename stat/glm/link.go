@@ -0,0 +1,148 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package glm
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// eps is the bound used to keep means and their derivatives away from the
+// boundaries of their domains.
+const eps = 2.220446049250313e-16
+
+// Link is a link function g relating the mean μ of the response to the
+// linear predictor η = g(μ).
+type Link interface {
+	// Link returns the value of the linear predictor η = g(μ).
+	Link(mu float64) float64
+
+	// Mean returns the value of the mean μ = g⁻¹(η).
+	Mean(eta float64) float64
+
+	// Deriv returns the derivative of the mean with respect
+	// to the linear predictor, dμ/dη.
+	Deriv(eta float64) float64
+}
+
+// Identity is the identity link
+//  g(μ) = μ
+type Identity struct{}
+
+// Link returns mu.
+func (Identity) Link(mu float64) float64 { return mu }
+
+// Mean returns eta.
+func (Identity) Mean(eta float64) float64 { return eta }
+
+// Deriv returns 1.
+func (Identity) Deriv(eta float64) float64 { return 1 }
+
+// Log is the log link
+//  g(μ) = log(μ)
+type Log struct{}
+
+// Link returns log(mu).
+func (Log) Link(mu float64) float64 { return math.Log(mu) }
+
+// Mean returns exp(eta), bounded below by machine epsilon.
+func (Log) Mean(eta float64) float64 { return math.Max(math.Exp(eta), eps) }
+
+// Deriv returns exp(eta), bounded below by machine epsilon.
+func (Log) Deriv(eta float64) float64 { return math.Max(math.Exp(eta), eps) }
+
+// Logit is the logit link
+//  g(μ) = log(μ/(1-μ))
+// The mean is kept within machine epsilon of zero and one.
+type Logit struct{}
+
+// logitBound is the magnitude of the linear predictor beyond which the
+// logit link is truncated to keep the mean within [ε, 1-ε].
+var logitBound = -math.Log(eps / (1 - eps))
+
+// Link returns log(mu/(1-mu)).
+func (Logit) Link(mu float64) float64 { return math.Log(mu / (1 - mu)) }
+
+// Mean returns 1/(1+exp(-eta)).
+func (Logit) Mean(eta float64) float64 {
+	eta = math.Max(-logitBound, math.Min(eta, logitBound))
+	return 1 / (1 + math.Exp(-eta))
+}
+
+// Deriv returns exp(eta)/(1+exp(eta))^2.
+func (Logit) Deriv(eta float64) float64 {
+	if math.Abs(eta) > logitBound {
+		return eps
+	}
+	e := math.Exp(-math.Abs(eta))
+	return e / ((1 + e) * (1 + e))
+}
+
+// Probit is the probit link
+//  g(μ) = Φ⁻¹(μ)
+// where Φ is the cumulative distribution function of the standard normal
+// distribution. The mean is kept within machine epsilon of zero and one.
+type Probit struct{}
+
+// probitBound is the magnitude of the linear predictor beyond which the
+// probit link is truncated to keep the mean within [ε, 1-ε].
+var probitBound = -distuv.UnitNormal.Quantile(eps)
+
+// Link returns Φ⁻¹(mu).
+func (Probit) Link(mu float64) float64 { return distuv.UnitNormal.Quantile(mu) }
+
+// Mean returns Φ(eta).
+func (Probit) Mean(eta float64) float64 {
+	return distuv.UnitNormal.CDF(math.Max(-probitBound, math.Min(eta, probitBound)))
+}
+
+// Deriv returns the standard normal density at eta.
+func (Probit) Deriv(eta float64) float64 { return math.Max(distuv.UnitNormal.Prob(eta), eps) }
+
+// CLogLog is the complementary log-log link
+//  g(μ) = log(-log(1-μ))
+// The mean is kept within machine epsilon of zero and one.
+type CLogLog struct{}
+
+// Link returns log(-log(1-mu)).
+func (CLogLog) Link(mu float64) float64 { return math.Log(-math.Log1p(-mu)) }
+
+// Mean returns 1-exp(-exp(eta)).
+func (CLogLog) Mean(eta float64) float64 {
+	return math.Max(math.Min(-math.Expm1(-math.Exp(eta)), 1-eps), eps)
+}
+
+// Deriv returns exp(eta-exp(eta)).
+func (CLogLog) Deriv(eta float64) float64 {
+	eta = math.Min(eta, 700)
+	return math.Max(math.Exp(eta)*math.Exp(-math.Exp(eta)), eps)
+}
+
+// Reciprocal is the reciprocal, or inverse, link
+//  g(μ) = 1/μ
+type Reciprocal struct{}
+
+// Link returns 1/mu.
+func (Reciprocal) Link(mu float64) float64 { return 1 / mu }
+
+// Mean returns 1/eta.
+func (Reciprocal) Mean(eta float64) float64 { return 1 / eta }
+
+// Deriv returns -1/eta^2.
+func (Reciprocal) Deriv(eta float64) float64 { return -1 / (eta * eta) }
+
+// Sqrt is the square root link
+//  g(μ) = sqrt(μ)
+type Sqrt struct{}
+
+// Link returns sqrt(mu).
+func (Sqrt) Link(mu float64) float64 { return math.Sqrt(mu) }
+
+// Mean returns eta^2.
+func (Sqrt) Mean(eta float64) float64 { return eta * eta }
+
+// Deriv returns 2 eta.
+func (Sqrt) Deriv(eta float64) float64 { return 2 * eta }
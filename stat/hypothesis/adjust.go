@@ -0,0 +1,184 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hypothesis
+
+import (
+	"math"
+	"sort"
+)
+
+// Bonferroni returns the p-values p adjusted for multiple comparisons by
+// the Bonferroni correction, which controls the family-wise error rate.
+// The adjusted p-values are
+//  min(1, m*p[i])
+// for m tests.
+//
+// The adjusted p-values are stored in dst in the order of p and dst is
+// returned. If dst is nil a new slice is allocated, otherwise dst must have
+// the same length as p. dst may be p. Bonferroni will panic if any element
+// of p is not in [0, 1].
+func Bonferroni(dst, p []float64) []float64 {
+	dst = adjustDst(dst, p)
+	m := float64(len(p))
+	for i, v := range p {
+		dst[i] = math.Min(1, m*v)
+	}
+	return dst
+}
+
+// Holm returns the p-values p adjusted for multiple comparisons by Holm's
+// step-down procedure, which controls the family-wise error rate and is
+// uniformly more powerful than the Bonferroni correction. The adjusted
+// p-value of the k-th smallest of m p-values is
+//  min(1, max_{j<=k} (m-j+1)*p_(j))
+//
+// The adjusted p-values are stored in dst in the order of p and dst is
+// returned. If dst is nil a new slice is allocated, otherwise dst must have
+// the same length as p. dst may be p. Holm will panic if any element of p
+// is not in [0, 1].
+func Holm(dst, p []float64) []float64 {
+	m := float64(len(p))
+	return stepDown(dst, p, func(k int) float64 { return m - float64(k) + 1 })
+}
+
+// Hochberg returns the p-values p adjusted for multiple comparisons by
+// Hochberg's step-up procedure, which controls the family-wise error rate
+// when the tests are independent or positively dependent. The adjusted
+// p-value of the k-th smallest of m p-values is
+//  min(1, min_{j>=k} (m-j+1)*p_(j))
+//
+// The adjusted p-values are stored in dst in the order of p and dst is
+// returned. If dst is nil a new slice is allocated, otherwise dst must have
+// the same length as p. dst may be p. Hochberg will panic if any element of
+// p is not in [0, 1].
+func Hochberg(dst, p []float64) []float64 {
+	m := float64(len(p))
+	return stepUp(dst, p, func(k int) float64 { return m - float64(k) + 1 })
+}
+
+// BenjaminiHochberg returns the p-values p adjusted for multiple comparisons
+// by the Benjamini–Hochberg procedure, which controls the false discovery
+// rate when the tests are independent or positively dependent. The adjusted
+// p-value of the k-th smallest of m p-values is
+//  min(1, min_{j>=k} m/j*p_(j))
+//
+// The adjusted p-values are stored in dst in the order of p and dst is
+// returned. If dst is nil a new slice is allocated, otherwise dst must have
+// the same length as p. dst may be p. BenjaminiHochberg will panic if any
+// element of p is not in [0, 1].
+func BenjaminiHochberg(dst, p []float64) []float64 {
+	m := float64(len(p))
+	return stepUp(dst, p, func(k int) float64 { return m / float64(k) })
+}
+
+// BenjaminiYekutieli returns the p-values p adjusted for multiple
+// comparisons by the Benjamini–Yekutieli procedure, which controls the
+// false discovery rate under arbitrary dependence between the tests. The
+// adjusted p-value of the k-th smallest of m p-values is
+//  min(1, min_{j>=k} c(m)*m/j*p_(j))
+// where c(m) = Σ_{i=1}^m 1/i.
+//
+// The adjusted p-values are stored in dst in the order of p and dst is
+// returned. If dst is nil a new slice is allocated, otherwise dst must have
+// the same length as p. dst may be p. BenjaminiYekutieli will panic if any
+// element of p is not in [0, 1].
+func BenjaminiYekutieli(dst, p []float64) []float64 {
+	m := float64(len(p))
+	var c float64
+	for i := len(p); i > 0; i-- {
+		c += 1 / float64(i)
+	}
+	return stepUp(dst, p, func(k int) float64 { return c * m / float64(k) })
+}
+
+// StoreyQ returns the q-values of Storey (2002) for the p-values p. The
+// q-value of a test is the smallest false discovery rate at which the test
+// is called significant. The proportion of true null hypotheses is
+// estimated from the p-values greater than lambda with the finite sample
+// correction of Storey, Taylor and Siegmund (2004) as
+//  π0 = min(1, (1 + #{p[i] > lambda}) / (m*(1-lambda)))
+// and the q-value of the k-th smallest of m p-values is
+//  min(1, min_{j>=k} π0*m/j*p_(j))
+// A common choice of lambda is 0.5. With π0 = 1 the q-values are equal to
+// the Benjamini–Hochberg adjusted p-values.
+//
+// The q-values are stored in dst in the order of p and dst is returned. If
+// dst is nil a new slice is allocated, otherwise dst must have the same
+// length as p. dst may be p. StoreyQ will panic if lambda is not in [0, 1)
+// or if any element of p is not in [0, 1].
+func StoreyQ(dst, p []float64, lambda float64) []float64 {
+	if !(0 <= lambda && lambda < 1) {
+		panic(badLambda)
+	}
+	m := float64(len(p))
+	above := 1.0
+	for _, v := range p {
+		if v > lambda {
+			above++
+		}
+	}
+	pi0 := math.Min(1, above/(m*(1-lambda)))
+	return stepUp(dst, p, func(k int) float64 { return pi0 * m / float64(k) })
+}
+
+// adjustDst returns a destination for the adjusted values of p, checking
+// that the values of p are valid probabilities.
+func adjustDst(dst, p []float64) []float64 {
+	for _, v := range p {
+		if !(0 <= v && v <= 1) {
+			panic(badPValue)
+		}
+	}
+	if dst == nil {
+		return make([]float64, len(p))
+	}
+	if len(dst) != len(p) {
+		panic(badLength)
+	}
+	return dst
+}
+
+// sortedPValues returns the indices of the elements of p in increasing
+// order of p and the correspondingly sorted values.
+func sortedPValues(p []float64) (idx []int, sorted []float64) {
+	idx = make([]int, len(p))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool { return p[idx[i]] < p[idx[j]] })
+	sorted = make([]float64, len(p))
+	for k, i := range idx {
+		sorted[k] = p[i]
+	}
+	return idx, sorted
+}
+
+// stepDown adjusts the p-values by the running maximum of the scaled p-values
+// in increasing order of p. The scale function takes the one-based rank of
+// a p-value.
+func stepDown(dst, p []float64, scale func(k int) float64) []float64 {
+	dst = adjustDst(dst, p)
+	idx, sorted := sortedPValues(p)
+	var running float64
+	for k, v := range sorted {
+		running = math.Max(running, math.Min(1, scale(k+1)*v))
+		dst[idx[k]] = running
+	}
+	return dst
+}
+
+// stepUp adjusts the p-values by the running minimum of the scaled p-values
+// in decreasing order of p. The scale function takes the one-based rank of
+// a p-value.
+func stepUp(dst, p []float64, scale func(k int) float64) []float64 {
+	dst = adjustDst(dst, p)
+	idx, sorted := sortedPValues(p)
+	running := 1.0
+	for k := len(sorted) - 1; k >= 0; k-- {
+		running = math.Min(running, scale(k+1)*sorted[k])
+		dst[idx[k]] = running
+	}
+	return dst
+}
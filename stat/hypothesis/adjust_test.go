@@ -0,0 +1,137 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hypothesis
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestAdjust(t *testing.T) {
+	p := []float64{0.04, 0.01, 0.03, 0.2, 0.02}
+	for _, test := range []struct {
+		name   string
+		adjust func(dst, p []float64) []float64
+		want   []float64
+	}{
+		{
+			name:   "Bonferroni",
+			adjust: Bonferroni,
+			want:   []float64{0.2, 0.05, 0.15, 1, 0.1},
+		},
+		{
+			name:   "Holm",
+			adjust: Holm,
+			want:   []float64{0.09, 0.05, 0.09, 0.2, 0.08},
+		},
+		{
+			name:   "Hochberg",
+			adjust: Hochberg,
+			want:   []float64{0.08, 0.05, 0.08, 0.2, 0.08},
+		},
+		{
+			name:   "Benjamini–Hochberg",
+			adjust: BenjaminiHochberg,
+			want:   []float64{0.05, 0.05, 0.05, 0.2, 0.05},
+		},
+		{
+			name:   "Benjamini–Yekutieli",
+			adjust: BenjaminiYekutieli,
+			want:   []float64{0.1141667, 0.1141667, 0.1141667, 0.4566667, 0.1141667},
+		},
+		{
+			name:   "Storey π0=1",
+			adjust: func(dst, p []float64) []float64 { return StoreyQ(dst, p, 0) },
+			want:   []float64{0.05, 0.05, 0.05, 0.2, 0.05},
+		},
+	} {
+		orig := append([]float64(nil), p...)
+		got := test.adjust(nil, p)
+		if !floats.EqualApprox(got, test.want, 1e-7) {
+			t.Errorf("unexpected %s adjustment: got:%v want:%v", test.name, got, test.want)
+		}
+		if !floats.Equal(p, orig) {
+			t.Errorf("%s adjustment modified input", test.name)
+		}
+
+		// Adjustment in place.
+		dst := append([]float64(nil), p...)
+		test.adjust(dst, dst)
+		if !floats.EqualApprox(dst, test.want, 1e-7) {
+			t.Errorf("unexpected in place %s adjustment: got:%v want:%v", test.name, dst, test.want)
+		}
+
+		if got := test.adjust(nil, nil); len(got) != 0 {
+			t.Errorf("unexpected %s adjustment of no p-values: got:%v", test.name, got)
+		}
+
+		for _, bad := range []struct {
+			name   string
+			dst, p []float64
+		}{
+			{name: "length mismatch", dst: make([]float64, 2), p: p},
+			{name: "negative p-value", p: []float64{0.1, -0.1}},
+			{name: "p-value greater than one", p: []float64{0.1, 1.1}},
+		} {
+			if !panics(func() { test.adjust(bad.dst, bad.p) }) {
+				t.Errorf("expected panic for %s %s", test.name, bad.name)
+			}
+		}
+	}
+}
+
+func TestAdjustOrdering(t *testing.T) {
+	// Adjusted p-values preserve the order of the unadjusted values
+	// and satisfy the known orderings between the procedures.
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		p := make([]float64, 1+rnd.Intn(50))
+		for i := range p {
+			p[i] = rnd.Float64()
+			if i%3 == 0 {
+				p[i] *= 0.01
+			}
+		}
+		bonf := Bonferroni(nil, p)
+		holm := Holm(nil, p)
+		hoch := Hochberg(nil, p)
+		bh := BenjaminiHochberg(nil, p)
+		by := BenjaminiYekutieli(nil, p)
+		q := StoreyQ(nil, p, 0.5)
+		for i := range p {
+			if !(q[i] <= bh[i] && p[i] <= bh[i] && bh[i] <= hoch[i] && hoch[i] <= holm[i] && holm[i] <= bonf[i]) {
+				t.Errorf("unexpected ordering of adjustments of %v: q=%v BH=%v Hochberg=%v Holm=%v Bonferroni=%v",
+					p[i], q[i], bh[i], hoch[i], holm[i], bonf[i])
+			}
+			if bh[i] > by[i] {
+				t.Errorf("Benjamini–Hochberg adjustment %v greater than Benjamini–Yekutieli %v", bh[i], by[i])
+			}
+			for j := range p {
+				for _, adj := range [][]float64{bonf, holm, hoch, bh, by, q} {
+					if p[i] < p[j] && adj[i] > adj[j] {
+						t.Errorf("adjustment does not preserve order of %v and %v", p[i], p[j])
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestStoreyQ(t *testing.T) {
+	p := []float64{0.3, 0.001, 0.9, 0.02, 0.6, 0.01, 0.8, 0.03, 0.04, 0.2}
+	// Three of ten p-values exceed one half, so π0 = (1+3)/5.
+	want := []float64{0.3428571428571429, 0.008, 0.72, 0.05333333333333334, 0.6, 0.04, 0.7111111111111111, 0.06, 0.064, 0.26666666666666666}
+	if got := StoreyQ(nil, p, 0.5); !floats.EqualApprox(got, want, 1e-14) {
+		t.Errorf("unexpected q-values: got:%v want:%v", got, want)
+	}
+	for _, lambda := range []float64{-0.1, 1} {
+		if !panics(func() { StoreyQ(nil, p, lambda) }) {
+			t.Errorf("expected panic for lambda=%v", lambda)
+		}
+	}
+}
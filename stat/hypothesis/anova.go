@@ -0,0 +1,82 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hypothesis
+
+import (
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// ANOVAResult holds the outcome of a one-way analysis of variance.
+type ANOVAResult struct {
+	// F is the ratio of the between group mean square to the
+	// within group mean square.
+	F float64
+
+	// PValue is the probability under the null hypothesis of
+	// observing a value of F at least as large as the value
+	// observed.
+	PValue float64
+
+	// DoFBetween and DoFWithin are the between group and within
+	// group degrees of freedom, the numerator and denominator
+	// degrees of freedom of the F distribution.
+	DoFBetween, DoFWithin float64
+
+	// SSBetween and SSWithin are the between group and within
+	// group sums of squares.
+	SSBetween, SSWithin float64
+}
+
+// OneWayANOVA performs a one-way analysis of variance of the null hypothesis
+// that the means of the populations from which the groups are drawn are
+// equal, assuming that the populations are normal with equal variance.
+//
+// OneWayANOVA will panic if there are fewer than two groups, if any group
+// is empty or if the total number of samples is not greater than the
+// number of groups.
+func OneWayANOVA(groups ...[]float64) ANOVAResult {
+	if len(groups) < 2 {
+		panic(tooFewSamples)
+	}
+	var (
+		n     int
+		total float64
+	)
+	means := make([]float64, len(groups))
+	for i, g := range groups {
+		if len(g) == 0 {
+			panic(tooFewSamples)
+		}
+		means[i] = stat.Mean(g, nil)
+		total += means[i] * float64(len(g))
+		n += len(g)
+	}
+	if n <= len(groups) {
+		panic(tooFewSamples)
+	}
+	grand := total / float64(n)
+
+	var ssb, ssw float64
+	for i, g := range groups {
+		d := means[i] - grand
+		ssb += float64(len(g)) * d * d
+		for _, v := range g {
+			d := v - means[i]
+			ssw += d * d
+		}
+	}
+	dfb := float64(len(groups) - 1)
+	dfw := float64(n - len(groups))
+	f := (ssb / dfb) / (ssw / dfw)
+	return ANOVAResult{
+		F:          f,
+		PValue:     distuv.F{D1: dfb, D2: dfw}.Survival(f),
+		DoFBetween: dfb,
+		DoFWithin:  dfw,
+		SSBetween:  ssb,
+		SSWithin:   ssw,
+	}
+}
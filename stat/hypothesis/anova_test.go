@@ -0,0 +1,49 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hypothesis
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestOneWayANOVA(t *testing.T) {
+	// Reference values from R's aov for the PlantGrowth data set.
+	ctrl := []float64{4.17, 5.58, 5.18, 6.11, 4.50, 4.61, 5.17, 4.53, 5.33, 5.14}
+	trt1 := []float64{4.81, 4.17, 4.41, 3.59, 5.87, 3.83, 6.03, 4.89, 4.32, 4.69}
+	trt2 := []float64{6.31, 5.12, 5.54, 5.50, 5.37, 5.29, 4.92, 6.15, 5.80, 5.26}
+	res := OneWayANOVA(ctrl, trt1, trt2)
+	want := ANOVAResult{F: 4.846088, PValue: 0.01590996, DoFBetween: 2, DoFWithin: 27, SSBetween: 3.76634, SSWithin: 10.49209}
+	if !floats.EqualApprox([]float64{res.F, res.PValue, res.DoFBetween, res.DoFWithin, res.SSBetween, res.SSWithin},
+		[]float64{want.F, want.PValue, want.DoFBetween, want.DoFWithin, want.SSBetween, want.SSWithin}, 1e-6) {
+		t.Errorf("unexpected result:\ngot: %+v\nwant:%+v", res, want)
+	}
+
+	// With two groups the F statistic is the square of the pooled
+	// two-sample t statistic and the p-values agree.
+	tres := TwoSampleT(sleep1, sleep2, 0, TwoSided, 0.95)
+	res = OneWayANOVA(sleep1, sleep2)
+	if math.Abs(res.F-tres.Statistic*tres.Statistic) > 1e-12 {
+		t.Errorf("unexpected F statistic: got:%v want:%v", res.F, tres.Statistic*tres.Statistic)
+	}
+	if math.Abs(res.PValue-tres.PValue) > 1e-12 {
+		t.Errorf("unexpected p-value: got:%v want:%v", res.PValue, tres.PValue)
+	}
+
+	for _, test := range []struct {
+		name   string
+		groups [][]float64
+	}{
+		{name: "one group", groups: [][]float64{ctrl}},
+		{name: "empty group", groups: [][]float64{ctrl, nil}},
+		{name: "single samples", groups: [][]float64{{1}, {2}}},
+	} {
+		if !panics(func() { OneWayANOVA(test.groups...) }) {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hypothesis
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/combin"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// ChiSquareGoodnessOfFit performs Pearson's chi-square goodness of fit test
+// of the null hypothesis that the observed frequencies obs are drawn from
+// the distribution with expected frequencies exp. The returned Statistic is
+// the value of stat.ChiSquare(obs, exp), and DoF is len(obs)-1-ddof where
+// ddof is the number of parameters of the expected distribution estimated
+// from the data.
+//
+// ChiSquareGoodnessOfFit will panic if the lengths of obs and exp differ or
+// if the number of degrees of freedom is less than one.
+func ChiSquareGoodnessOfFit(obs, exp []float64, ddof int) Result {
+	if len(obs) != len(exp) {
+		panic(badLength)
+	}
+	dof := float64(len(obs) - 1 - ddof)
+	if dof < 1 {
+		panic(tooFewSamples)
+	}
+	x2 := stat.ChiSquare(obs, exp)
+	return Result{
+		Statistic: x2,
+		PValue:    distuv.ChiSquared{K: dof}.Survival(x2),
+		DoF:       dof,
+		Estimate:  math.NaN(),
+		Lower:     math.NaN(),
+		Upper:     math.NaN(),
+	}
+}
+
+// ChiSquareIndependence performs Pearson's chi-square test of the null
+// hypothesis that the row and column classifications of the contingency
+// table of observed frequencies are independent. The expected frequencies
+// are computed from the row and column totals of the table, and DoF is
+// (r-1)(c-1) for an r×c table. No continuity correction is applied.
+//
+// ChiSquareIndependence will panic if the table has fewer than two rows or
+// columns, if any element of the table is negative or if any row or column
+// total is zero.
+func ChiSquareIndependence(table mat.Matrix) Result {
+	r, c := table.Dims()
+	if r < 2 || c < 2 {
+		panic(tooFewSamples)
+	}
+	rows := make([]float64, r)
+	cols := make([]float64, c)
+	var total float64
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			v := table.At(i, j)
+			if v < 0 {
+				panic(negativeCount)
+			}
+			rows[i] += v
+			cols[j] += v
+			total += v
+		}
+	}
+	for _, v := range rows {
+		if v == 0 {
+			panic(zeroTotal)
+		}
+	}
+	for _, v := range cols {
+		if v == 0 {
+			panic(zeroTotal)
+		}
+	}
+	var x2 float64
+	for i, ri := range rows {
+		for j, cj := range cols {
+			exp := ri * cj / total
+			d := table.At(i, j) - exp
+			x2 += d * d / exp
+		}
+	}
+	dof := float64((r - 1) * (c - 1))
+	return Result{
+		Statistic: x2,
+		PValue:    distuv.ChiSquared{K: dof}.Survival(x2),
+		DoF:       dof,
+		Estimate:  math.NaN(),
+		Lower:     math.NaN(),
+		Upper:     math.NaN(),
+	}
+}
+
+// FisherExact performs Fisher's exact test of the null hypothesis that the
+// row and column classifications of the 2×2 contingency table are
+// independent. The alternatives Less and Greater are that the odds ratio
+//  (table[0][0] * table[1][1]) / (table[0][1] * table[1][0])
+// of the population is less than or greater than one.
+//
+// The returned Statistic is table[0][0], whose distribution conditional on
+// the marginal totals is hypergeometric under the null hypothesis, and
+// Estimate is the conditional maximum likelihood estimate of the odds ratio
+// as computed by R's fisher.test. Unlike the sample odds ratio, the estimate
+// is defined when table[0][1] or table[1][0] is zero; it is zero when
+// table[0][0] takes the smallest value allowed by the marginal totals and
+// +Inf when it takes the largest. The two-sided p-value is the total
+// probability of the tables that are no more probable than the observed
+// table.
+//
+// FisherExact will panic if any element of the table is negative.
+func FisherExact(table [2][2]int, alt Alternative) Result {
+	a, b := table[0][0], table[0][1]
+	c, d := table[1][0], table[1][1]
+	if a < 0 || b < 0 || c < 0 || d < 0 {
+		panic(negativeCount)
+	}
+	row := a + b
+	col := a + c
+	n := a + b + c + d
+
+	// The count in the first cell ranges over [lo, hi] for tables
+	// with the observed marginal totals.
+	lo := col - (c + d)
+	if lo < 0 {
+		lo = 0
+	}
+	hi := row
+	if col < hi {
+		hi = col
+	}
+	logDenom := combin.LogGeneralizedBinomial(float64(n), float64(col))
+	logp := make([]float64, hi-lo+1)
+	p := make([]float64, len(logp))
+	for k := range p {
+		x := lo + k
+		logp[k] = combin.LogGeneralizedBinomial(float64(row), float64(x)) +
+			combin.LogGeneralizedBinomial(float64(n-row), float64(col-x)) - logDenom
+		p[k] = math.Exp(logp[k])
+	}
+
+	var pv float64
+	switch alt {
+	case TwoSided:
+		// Allow for rounding error in the comparison of the
+		// probabilities of tables that are equally probable.
+		const relErr = 1 + 1e-7
+		obs := p[a-lo]
+		for _, q := range p {
+			if q <= obs*relErr {
+				pv += q
+			}
+		}
+		pv = math.Min(pv, 1)
+	case Less, Greater:
+		lower, upper := exactTails(p, a-lo)
+		pv = pValue(alt, lower, upper)
+	default:
+		panic(badAlternative)
+	}
+
+	return Result{
+		Statistic: float64(a),
+		PValue:    pv,
+		DoF:       math.NaN(),
+		Estimate:  fisherOddsRatio(a-lo, logp),
+		Lower:     math.NaN(),
+		Upper:     math.NaN(),
+	}
+}
+
+// fisherOddsRatio returns the conditional maximum likelihood estimate of the
+// odds ratio of a 2×2 table given the log probabilities logp of the values of
+// its first cell under the null hypothesis, in increasing order, and the index
+// k of the observed value.
+func fisherOddsRatio(k int, logp []float64) float64 {
+	if k == 0 {
+		return 0
+	}
+	if k == len(logp)-1 {
+		return math.Inf(1)
+	}
+	// mean returns the mean index under the noncentral hypergeometric
+	// distribution with odds ratio exp(t).
+	mean := func(t float64) float64 {
+		max := math.Inf(-1)
+		for i, l := range logp {
+			max = math.Max(max, l+float64(i)*t)
+		}
+		var sum, sumIdx float64
+		for i, l := range logp {
+			w := math.Exp(l + float64(i)*t - max)
+			sum += w
+			sumIdx += float64(i) * w
+		}
+		return sumIdx / sum
+	}
+	// The mean increases with t, so the estimate equating the mean to
+	// the observed value is found by bisection.
+	x := float64(k)
+	lower, upper := -1.0, 1.0
+	for mean(lower) > x {
+		lower *= 2
+	}
+	for mean(upper) < x {
+		upper *= 2
+	}
+	for {
+		mid := lower + (upper-lower)/2
+		if mid == lower || mid == upper {
+			break
+		}
+		if mean(mid) < x {
+			lower = mid
+		} else {
+			upper = mid
+		}
+	}
+	return math.Exp(lower + (upper-lower)/2)
+}
@@ -0,0 +1,114 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hypothesis
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestChiSquareGoodnessOfFit(t *testing.T) {
+	// Reference values from R's chisq.test.
+	obs := []float64{89, 37, 30, 28, 2}
+	exp := []float64{74.4, 37.2, 37.2, 27.9, 9.3}
+	res := ChiSquareGoodnessOfFit(obs, exp, 0)
+	want := Result{Statistic: 9.9901, PValue: 0.04059, DoF: 4, Estimate: math.NaN(), Lower: math.NaN(), Upper: math.NaN()}
+	if !sameResult(res, want, 1e-4) {
+		t.Errorf("unexpected result:\ngot: %+v\nwant:%+v", res, want)
+	}
+	res = ChiSquareGoodnessOfFit(obs, exp, 1)
+	if res.DoF != 3 {
+		t.Errorf("unexpected degrees of freedom: got:%v want:3", res.DoF)
+	}
+	if !(res.PValue < 0.04059) {
+		t.Errorf("p-value did not decrease with degrees of freedom: got:%v", res.PValue)
+	}
+
+	if !panics(func() { ChiSquareGoodnessOfFit(obs, exp[1:], 0) }) {
+		t.Errorf("expected panic for length mismatch")
+	}
+	if !panics(func() { ChiSquareGoodnessOfFit(obs[:2], exp[:2], 1) }) {
+		t.Errorf("expected panic for no degrees of freedom")
+	}
+}
+
+func TestChiSquareIndependence(t *testing.T) {
+	// Reference values from R's chisq.test for the party affiliation
+	// by gender table of Agresti (2007).
+	table := mat.NewDense(2, 3, []float64{
+		762, 327, 468,
+		484, 239, 477,
+	})
+	res := ChiSquareIndependence(table)
+	want := Result{Statistic: 30.070149, PValue: 2.953589e-07, DoF: 2, Estimate: math.NaN(), Lower: math.NaN(), Upper: math.NaN()}
+	if !sameResult(res, want, 1e-6) {
+		t.Errorf("unexpected result:\ngot: %+v\nwant:%+v", res, want)
+	}
+
+	// The statistic is invariant to transposition of the table.
+	if got := ChiSquareIndependence(table.T()); !sameResult(got, res, 1e-14) {
+		t.Errorf("unexpected result for transposed table:\ngot: %+v\nwant:%+v", got, res)
+	}
+
+	// A table with proportional rows has a statistic of zero.
+	res = ChiSquareIndependence(mat.NewDense(2, 2, []float64{1, 2, 3, 6}))
+	if res.Statistic != 0 || res.PValue != 1 {
+		t.Errorf("unexpected result for independent table: %+v", res)
+	}
+
+	for _, test := range []struct {
+		name  string
+		table *mat.Dense
+	}{
+		{name: "single row", table: mat.NewDense(1, 3, []float64{1, 2, 3})},
+		{name: "negative count", table: mat.NewDense(2, 2, []float64{1, -2, 3, 4})},
+		{name: "zero column", table: mat.NewDense(2, 2, []float64{0, 2, 0, 4})},
+	} {
+		if !panics(func() { ChiSquareIndependence(test.table) }) {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+}
+
+func TestFisherExact(t *testing.T) {
+	// Reference values from R's fisher.test.
+	tea := [2][2]int{{3, 1}, {1, 3}}
+	convictions := [2][2]int{{2, 15}, {10, 3}}
+	for _, test := range []struct {
+		table [2][2]int
+		alt   Alternative
+		p     float64
+		odds  float64
+	}{
+		{table: tea, alt: Greater, p: 0.2428571, odds: 6.408309},
+		{table: tea, alt: TwoSided, p: 0.4857143, odds: 6.408309},
+		{table: tea, alt: Less, p: 0.9857143, odds: 6.408309},
+		{table: convictions, alt: Less, p: 0.0004651786, odds: 0.04693661},
+		{table: convictions, alt: TwoSided, p: 0.0005367241, odds: 0.04693661},
+		{table: [2][2]int{{0, 5}, {5, 0}}, alt: TwoSided, p: 0.007936508, odds: 0},
+		{table: [2][2]int{{3, 0}, {2, 4}}, alt: TwoSided, p: 0.1666667, odds: math.Inf(1)},
+		{table: [2][2]int{{3, 2}, {0, 4}}, alt: Greater, p: 0.1190476, odds: math.Inf(1)},
+		{table: [2][2]int{{4, 0}, {0, 0}}, alt: TwoSided, p: 1, odds: 0},
+	} {
+		res := FisherExact(test.table, test.alt)
+		if !floats.EqualWithinAbsOrRel(res.PValue, test.p, 1e-7, 1e-6) {
+			t.Errorf("unexpected p-value for %v alt=%v: got:%v want:%v", test.table, test.alt, res.PValue, test.p)
+		}
+		// R finds the estimate with a tolerance of about 1e-4.
+		if !sameFloat(res.Estimate, test.odds, 1e-5) {
+			t.Errorf("unexpected odds ratio for %v: got:%v want:%v", test.table, res.Estimate, test.odds)
+		}
+		if res.Statistic != float64(test.table[0][0]) {
+			t.Errorf("unexpected statistic for %v: got:%v", test.table, res.Statistic)
+		}
+	}
+
+	if !panics(func() { FisherExact([2][2]int{{1, -1}, {2, 3}}, TwoSided) }) {
+		t.Errorf("expected panic for negative count")
+	}
+}
@@ -0,0 +1,15 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hypothesis provides classical statistical hypothesis tests.
+//
+// Each test returns the value of its test statistic together with the
+// p-value of the statistic under the null hypothesis, computed from the
+// reference distributions in gonum.org/v1/gonum/stat/distuv. Tests of
+// location also return a confidence interval for the estimated quantity.
+//
+// The package also provides adjustments of collections of p-values for
+// multiple comparisons, controlling either the family-wise error rate or
+// the false discovery rate.
+package hypothesis // import "gonum.org/v1/gonum/stat/hypothesis"
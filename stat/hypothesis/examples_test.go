@@ -0,0 +1,49 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hypothesis_test
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/stat/hypothesis"
+)
+
+func ExampleWelchT() {
+	// Increase in hours of sleep of ten patients given
+	// two soporific drugs from Student (1908).
+	drug1 := []float64{0.7, -1.6, -0.2, -1.2, -0.1, 3.4, 3.7, 0.8, 0.0, 2.0}
+	drug2 := []float64{1.9, 0.8, 1.1, 0.1, -0.1, 4.4, 5.5, 1.6, 4.6, 3.4}
+
+	res := hypothesis.WelchT(drug1, drug2, 0, hypothesis.TwoSided, 0.95)
+	fmt.Printf("t = %.4f, df = %.3f, p-value = %.5f\n", res.Statistic, res.DoF, res.PValue)
+	fmt.Printf("95%% confidence interval: [%.4f, %.4f]\n", res.Lower, res.Upper)
+
+	// The same patients received both drugs, so a paired
+	// test is more appropriate.
+	res = hypothesis.PairedT(drug1, drug2, 0, hypothesis.TwoSided, 0.95)
+	fmt.Printf("t = %.4f, df = %.0f, p-value = %.5f\n", res.Statistic, res.DoF, res.PValue)
+	fmt.Printf("95%% confidence interval: [%.4f, %.4f]\n", res.Lower, res.Upper)
+
+	// Output:
+	// t = -1.8608, df = 17.776, p-value = 0.07939
+	// 95% confidence interval: [-3.3655, 0.2055]
+	// t = -4.0621, df = 9, p-value = 0.00283
+	// 95% confidence interval: [-2.4599, -0.7001]
+}
+
+func ExampleFisherExact() {
+	// Fisher's lady tasting tea. Of eight cups of tea, four
+	// had the milk poured first. The lady correctly identified
+	// three of these four.
+	table := [2][2]int{
+		{3, 1},
+		{1, 3},
+	}
+	res := hypothesis.FisherExact(table, hypothesis.Greater)
+	fmt.Printf("odds ratio = %.4f, p-value = %.4f\n", res.Estimate, res.PValue)
+
+	// Output:
+	// odds ratio = 6.4083, p-value = 0.2429
+}
@@ -0,0 +1,112 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hypothesis
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	badAlternative = "hypothesis: bad alternative"
+	badLevel       = "hypothesis: confidence level out of range"
+	badLambda      = "hypothesis: lambda out of range"
+	badLength      = "hypothesis: slice length mismatch"
+	badPValue      = "hypothesis: p-value out of range"
+	tooFewSamples  = "hypothesis: too few samples"
+	negativeCount  = "hypothesis: negative count"
+	zeroTotal      = "hypothesis: zero marginal total"
+)
+
+// Alternative specifies the alternative hypothesis of a test.
+type Alternative int
+
+const (
+	// TwoSided is the alternative that the tested quantity differs
+	// from its value under the null hypothesis.
+	TwoSided Alternative = iota
+	// Less is the alternative that the tested quantity is less than
+	// its value under the null hypothesis.
+	Less
+	// Greater is the alternative that the tested quantity is greater
+	// than its value under the null hypothesis.
+	Greater
+)
+
+// Result holds the outcome of a hypothesis test.
+type Result struct {
+	// Statistic is the value of the test statistic.
+	Statistic float64
+
+	// PValue is the probability under the null hypothesis of
+	// observing a statistic at least as extreme as Statistic
+	// in the direction of the alternative hypothesis.
+	PValue float64
+
+	// DoF is the number of degrees of freedom of the reference
+	// distribution of the statistic. DoF is NaN for tests whose
+	// reference distribution has no degrees of freedom.
+	DoF float64
+
+	// Estimate is the estimate of the tested quantity. Estimate is
+	// NaN for tests that do not estimate a quantity.
+	Estimate float64
+
+	// Lower and Upper are the bounds of the confidence interval
+	// for Estimate. For one-sided alternatives one of the bounds is
+	// infinite. Lower and Upper are NaN for tests that do not
+	// compute a confidence interval.
+	Lower, Upper float64
+}
+
+// pValue returns the p-value for the alternative given the lower and
+// upper tail probabilities of the observed statistic.
+func pValue(alt Alternative, lower, upper float64) float64 {
+	switch alt {
+	case TwoSided:
+		return math.Min(1, 2*math.Min(lower, upper))
+	case Less:
+		return lower
+	case Greater:
+		return upper
+	default:
+		panic(badAlternative)
+	}
+}
+
+// checkLevel panics if the confidence level is not in (0, 1).
+func checkLevel(level float64) {
+	if !(0 < level && level < 1) {
+		panic(badLevel)
+	}
+}
+
+// ranks returns the mid-ranks of the elements of x, starting from one,
+// and the tie correction sum of t^3-t over the groups of t tied values.
+func ranks(x []float64) (r []float64, ties float64) {
+	idx := make([]int, len(x))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool { return x[idx[i]] < x[idx[j]] })
+	r = make([]float64, len(x))
+	for i := 0; i < len(idx); {
+		j := i + 1
+		for j < len(idx) && x[idx[j]] == x[idx[i]] {
+			j++
+		}
+		// Elements i through j-1 of the sorted order are tied
+		// and share the mean of ranks i+1 through j.
+		mid := float64(i+j+1) / 2
+		for _, k := range idx[i:j] {
+			r[k] = mid
+		}
+		if t := float64(j - i); t > 1 {
+			ties += t*t*t - t
+		}
+		i = j
+	}
+	return r, ties
+}
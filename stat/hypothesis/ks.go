@@ -0,0 +1,182 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hypothesis
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+// exactKSLimit is the largest product of the sample sizes for which the
+// exact null distribution of the two-sample Kolmogorov–Smirnov statistic
+// is used.
+const exactKSLimit = 10000
+
+// KolmogorovSmirnov performs the two-sided two-sample Kolmogorov–Smirnov
+// test of the null hypothesis that x and y are drawn from the same
+// continuous distribution. The returned Statistic is the largest distance
+// between the empirical distribution functions of x and y as computed by
+// stat.KolmogorovSmirnov. x and y need not be sorted.
+//
+// When len(x)*len(y) is at most 10000 and there are no ties between the
+// samples the p-value is computed from the exact null distribution of the
+// statistic, otherwise the asymptotic Kolmogorov distribution is used.
+//
+// KolmogorovSmirnov will panic if len(x) or len(y) is zero.
+func KolmogorovSmirnov(x, y []float64) Result {
+	if len(x) == 0 || len(y) == 0 {
+		panic(tooFewSamples)
+	}
+	xs := append([]float64(nil), x...)
+	ys := append([]float64(nil), y...)
+	sort.Float64s(xs)
+	sort.Float64s(ys)
+	d := stat.KolmogorovSmirnov(xs, nil, ys, nil)
+
+	m := len(xs)
+	n := len(ys)
+	var p float64
+	if m*n <= exactKSLimit && !hasTies(xs, ys) {
+		p = 1 - smirnovCDF(d, m, n)
+	} else {
+		ne := float64(m*n) / float64(m+n)
+		p = kolmogorovSurvival(stephens(ne) * d)
+	}
+	return Result{
+		Statistic: d,
+		PValue:    math.Max(0, math.Min(p, 1)),
+		DoF:       math.NaN(),
+		Estimate:  math.NaN(),
+		Lower:     math.NaN(),
+		Upper:     math.NaN(),
+	}
+}
+
+// KolmogorovSmirnovDist performs the two-sided one-sample Kolmogorov–Smirnov
+// test of the null hypothesis that x is drawn from the continuous
+// distribution with the cumulative distribution function cdf. The returned
+// Statistic is the largest distance between the empirical distribution
+// function of x and cdf. The p-value is computed from the asymptotic
+// Kolmogorov distribution using Stephens' small sample correction.
+//
+// KolmogorovSmirnovDist will panic if len(x) is zero.
+func KolmogorovSmirnovDist(x []float64, cdf func(float64) float64) Result {
+	if len(x) == 0 {
+		panic(tooFewSamples)
+	}
+	xs := append([]float64(nil), x...)
+	sort.Float64s(xs)
+	n := float64(len(xs))
+	var d float64
+	for i, v := range xs {
+		f := cdf(v)
+		d = math.Max(d, math.Max(float64(i+1)/n-f, f-float64(i)/n))
+	}
+	return Result{
+		Statistic: d,
+		PValue:    kolmogorovSurvival(stephens(n) * d),
+		DoF:       math.NaN(),
+		Estimate:  math.NaN(),
+		Lower:     math.NaN(),
+		Upper:     math.NaN(),
+	}
+}
+
+// hasTies returns whether the sorted slices x and y have an element in common.
+func hasTies(x, y []float64) bool {
+	var i, j int
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] < y[j]:
+			i++
+		case y[j] < x[i]:
+			j++
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// stephens returns the scaling factor of the Kolmogorov–Smirnov statistic
+// for n effective samples given by Stephens (1970).
+func stephens(n float64) float64 {
+	sqrtN := math.Sqrt(n)
+	return sqrtN + 0.12 + 0.11/sqrtN
+}
+
+// kolmogorovSurvival returns the survival function of the Kolmogorov
+// distribution at x.
+func kolmogorovSurvival(x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	if x < 1 {
+		// Use the series
+		//  P(K <= x) = sqrt(2π)/x Σ_{k=1}^∞ exp(-(2k-1)^2 π^2 / (8 x^2))
+		// which converges rapidly for small x.
+		w := -math.Pi * math.Pi / (8 * x * x)
+		var sum float64
+		for k := 1; k < 10; k++ {
+			j := float64(2*k - 1)
+			sum += math.Exp(j * j * w)
+		}
+		return 1 - math.Sqrt(2*math.Pi)/x*sum
+	}
+	// Use the alternating series
+	//  P(K > x) = 2 Σ_{k=1}^∞ (-1)^(k-1) exp(-2 k^2 x^2)
+	// which converges rapidly for large x.
+	var sum float64
+	sign := 1.0
+	for k := 1; k < 100; k++ {
+		term := math.Exp(-2 * float64(k*k) * x * x)
+		sum += sign * term
+		if term < 1e-17*sum {
+			break
+		}
+		sign = -sign
+	}
+	return 2 * sum
+}
+
+// smirnovCDF returns the probability that the two-sample Kolmogorov–Smirnov
+// statistic for samples of sizes m and n without ties is less than d.
+func smirnovCDF(d float64, m, n int) float64 {
+	if m > n {
+		m, n = n, m
+	}
+	md := float64(m)
+	nd := float64(n)
+	// The statistic is a multiple of 1/lcm(m, n). Place the threshold
+	// between lattice values to avoid comparisons of rounded values.
+	q := (0.5 + math.Floor(d*md*nd-1e-7)) / (md * nd)
+
+	// Count the lattice paths from (0, 0) to (m, n) that stay within
+	// distance q of the diagonal, scaled by the binomial coefficient.
+	u := make([]float64, n+1)
+	for j := range u {
+		if float64(j)/nd <= q {
+			u[j] = 1
+		}
+	}
+	for i := 1; i <= m; i++ {
+		w := float64(i) / float64(i+n)
+		if float64(i)/md > q {
+			u[0] = 0
+		} else {
+			u[0] *= w
+		}
+		for j := 1; j <= n; j++ {
+			if math.Abs(float64(i)/md-float64(j)/nd) > q {
+				u[j] = 0
+			} else {
+				u[j] = w*u[j] + u[j-1]
+			}
+		}
+	}
+	return u[n]
+}
@@ -0,0 +1,167 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hypothesis
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat/combin"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+func TestKolmogorovSurvival(t *testing.T) {
+	for _, test := range []struct {
+		x, want float64
+	}{
+		// Critical values of the Kolmogorov distribution.
+		{x: 1.2238, want: 0.1},
+		{x: 1.3581, want: 0.05},
+		{x: 1.6276, want: 0.01},
+		{x: 1.9495, want: 0.001},
+		{x: 0, want: 1},
+		{x: 0.1, want: 1},
+	} {
+		if got := kolmogorovSurvival(test.x); math.Abs(got-test.want) > 1e-3*test.want {
+			t.Errorf("unexpected survival at %v: got:%v want:%v", test.x, got, test.want)
+		}
+	}
+	// The two series agree where they meet.
+	if lo, hi := kolmogorovSurvival(math.Nextafter(1, 0)), kolmogorovSurvival(1); math.Abs(lo-hi) > 1e-14 {
+		t.Errorf("series mismatch at 1: %v != %v", lo, hi)
+	}
+}
+
+func TestSmirnovCDF(t *testing.T) {
+	// The exact distribution matches enumeration of the assignments
+	// of the pooled samples to x.
+	for _, test := range []struct{ m, n int }{{1, 1}, {2, 3}, {3, 3}, {4, 2}, {5, 4}} {
+		x := make([]float64, test.m)
+		y := make([]float64, test.n)
+		var stats []float64
+		for _, c := range combin.Combinations(test.m+test.n, test.m) {
+			x = x[:0]
+			y = y[:0]
+			for v, i := 0, 0; v < test.m+test.n; v++ {
+				if i < len(c) && c[i] == v {
+					x = append(x, float64(v))
+					i++
+				} else {
+					y = append(y, float64(v))
+				}
+			}
+			stats = append(stats, KolmogorovSmirnov(x, y).Statistic)
+		}
+		for _, d := range stats {
+			var below int
+			for _, s := range stats {
+				// Equal statistics may differ by rounding error.
+				if s < d-1e-12 {
+					below++
+				}
+			}
+			want := float64(below) / float64(len(stats))
+			if got := smirnovCDF(d, test.m, test.n); math.Abs(got-want) > 1e-14 {
+				t.Errorf("unexpected CDF for m=%d n=%d at %v: got:%v want:%v", test.m, test.n, d, got, want)
+			}
+		}
+	}
+}
+
+func TestKolmogorovSmirnov(t *testing.T) {
+	// Shifted samples of equal size without ties, for which
+	//  P(D >= k/n) = 2 Σ_{j>=1} (-1)^(j+1) C(2n, n-jk) / C(2n, n),
+	// with D = 1/2 for n = 10.
+	x := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	y := []float64{13.5, 12.5, 11.5, 10.5, 9.5, 8.5, 7.5, 6.5, 5.5, 4.5}
+	res := KolmogorovSmirnov(x, y)
+	if want := 0.5; math.Abs(res.Statistic-want) > 1e-14 {
+		t.Errorf("unexpected statistic: got:%v want:%v", res.Statistic, want)
+	}
+	if want := 2 * (15504.0 - 1) / 184756; math.Abs(res.PValue-want) > 1e-14 {
+		t.Errorf("unexpected p-value: got:%v want:%v", res.PValue, want)
+	}
+
+	// The exact distribution for equal sample sizes matches the
+	// closed form.
+	const n = 20
+	for k := 1; k <= n; k++ {
+		var want float64
+		for j := 1; n-j*k >= 0; j++ {
+			term := combin.GeneralizedBinomial(2*n, float64(n-j*k)) / combin.GeneralizedBinomial(2*n, n)
+			if j%2 == 0 {
+				term = -term
+			}
+			want += 2 * term
+		}
+		if got := 1 - smirnovCDF(float64(k)/n, n, n); math.Abs(got-want) > 1e-12 {
+			t.Errorf("unexpected survival at %d/%d: got:%v want:%v", k, n, got, want)
+		}
+	}
+
+	// Identical samples have a statistic of zero.
+	if res := KolmogorovSmirnov(x, x); res.Statistic != 0 || res.PValue != 1 {
+		t.Errorf("unexpected result for identical samples: %+v", res)
+	}
+
+	// Samples from different distributions are distinguished
+	// with the asymptotic distribution.
+	rnd := rand.New(rand.NewSource(1))
+	a := make([]float64, 200)
+	b := make([]float64, 100)
+	for i := range a {
+		a[i] = rnd.NormFloat64()
+	}
+	for i := range b {
+		b[i] = rnd.NormFloat64() + 1
+	}
+	if res := KolmogorovSmirnov(a, b); res.PValue > 1e-6 {
+		t.Errorf("unexpected p-value for shifted samples: got:%v", res.PValue)
+	}
+
+	// The input is not modified.
+	orig := append([]float64(nil), x...)
+	KolmogorovSmirnov(x, y)
+	if !floats.Equal(x, orig) {
+		t.Errorf("input modified")
+	}
+}
+
+func TestKolmogorovSmirnovDist(t *testing.T) {
+	// The statistic is the largest distance between the empirical
+	// distribution function and the uniform distribution function.
+	res := KolmogorovSmirnovDist([]float64{0.9, 0.1, 0.4, 0.5}, distuv.Uniform{Min: 0, Max: 1}.CDF)
+	if want := 0.25; math.Abs(res.Statistic-want) > 1e-14 {
+		t.Errorf("unexpected statistic: got:%v want:%v", res.Statistic, want)
+	}
+	if want := kolmogorovSurvival((2 + 0.12 + 0.11/2) * 0.25); math.Abs(res.PValue-want) > 1e-15 {
+		t.Errorf("unexpected p-value: got:%v want:%v", res.PValue, want)
+	}
+
+	// The p-values of samples from the null distribution are
+	// approximately uniform.
+	rnd := rand.New(rand.NewSource(1))
+	const trials = 1000
+	var reject int
+	x := make([]float64, 20)
+	for i := 0; i < trials; i++ {
+		for j := range x {
+			x[j] = rnd.NormFloat64()
+		}
+		if KolmogorovSmirnovDist(x, distuv.UnitNormal.CDF).PValue < 0.05 {
+			reject++
+		}
+	}
+	if rate := float64(reject) / trials; math.Abs(rate-0.05) > 0.02 {
+		t.Errorf("unexpected rejection rate under the null hypothesis: got:%v want:0.05", rate)
+	}
+
+	if !panics(func() { KolmogorovSmirnovDist(nil, distuv.UnitNormal.CDF) }) {
+		t.Errorf("expected panic for empty sample")
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hypothesis
+
+import (
+	"math"
+	"math/big"
+
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// exactLimit is the sample size below which the exact null distributions
+// of the rank statistics are used when there are no ties.
+const exactLimit = 50
+
+// MannWhitneyU performs the Mann–Whitney U test, also known as the
+// Wilcoxon rank-sum test, of the null hypothesis that the distributions
+// of the populations from which x and y are drawn are equal. The
+// alternatives Less and Greater are that x is stochastically smaller or
+// larger than y.
+//
+// The returned Statistic is the U statistic of x, the number of pairs
+// (x[i], y[j]) with x[i] > y[j], with ties counting one half. When there
+// are fewer than 50 samples in both x and y and there are no ties the
+// p-value is computed from the exact distribution of U, otherwise the
+// normal approximation with tie and continuity corrections is used.
+//
+// MannWhitneyU will panic if len(x) or len(y) is zero.
+func MannWhitneyU(x, y []float64, alt Alternative) Result {
+	if len(x) == 0 || len(y) == 0 {
+		panic(tooFewSamples)
+	}
+	nx := len(x)
+	ny := len(y)
+	r, ties := ranks(append(append(make([]float64, 0, nx+ny), x...), y...))
+	var sum float64
+	for _, v := range r[:nx] {
+		sum += v
+	}
+	u := sum - float64(nx*(nx+1))/2
+
+	res := Result{
+		Statistic: u,
+		DoF:       math.NaN(),
+		Estimate:  math.NaN(),
+		Lower:     math.NaN(),
+		Upper:     math.NaN(),
+	}
+	if ties == 0 && nx < exactLimit && ny < exactLimit {
+		lower, upper := exactTails(mannWhitneyDist(nx, ny), int(u))
+		res.PValue = pValue(alt, lower, upper)
+		return res
+	}
+	n := float64(nx + ny)
+	mean := float64(nx*ny) / 2
+	sd := math.Sqrt(float64(nx*ny) / 12 * ((n + 1) - ties/(n*(n-1))))
+	lower, upper := normalTails(u, mean, sd)
+	res.PValue = pValue(alt, lower, upper)
+	return res
+}
+
+// WilcoxonSignedRank performs the Wilcoxon signed-rank test of the null
+// hypothesis that the population from which x is drawn is symmetric about
+// mu. For a paired test the differences between the pairs of observations
+// should be passed as x.
+//
+// Values of x equal to mu are discarded. The returned Statistic is the sum
+// of the ranks of |x[i]-mu| for which x[i] > mu. When fewer than 50 values
+// remain and there are no ties the p-value is computed from the exact
+// distribution of the statistic, otherwise the normal approximation with
+// tie and continuity corrections is used.
+//
+// WilcoxonSignedRank will panic if all values of x are equal to mu.
+func WilcoxonSignedRank(x []float64, mu float64, alt Alternative) Result {
+	var (
+		abs      []float64
+		positive []bool
+		zeros    bool
+	)
+	for _, v := range x {
+		d := v - mu
+		if d == 0 {
+			zeros = true
+			continue
+		}
+		abs = append(abs, math.Abs(d))
+		positive = append(positive, d > 0)
+	}
+	if len(abs) == 0 {
+		panic(tooFewSamples)
+	}
+	r, ties := ranks(abs)
+	var v float64
+	for i, pos := range positive {
+		if pos {
+			v += r[i]
+		}
+	}
+
+	res := Result{
+		Statistic: v,
+		DoF:       math.NaN(),
+		Estimate:  math.NaN(),
+		Lower:     math.NaN(),
+		Upper:     math.NaN(),
+	}
+	n := len(abs)
+	if ties == 0 && !zeros && n < exactLimit {
+		lower, upper := exactTails(signedRankDist(n), int(v))
+		res.PValue = pValue(alt, lower, upper)
+		return res
+	}
+	nf := float64(n)
+	mean := nf * (nf + 1) / 4
+	sd := math.Sqrt(nf*(nf+1)*(2*nf+1)/24 - ties/48)
+	lower, upper := normalTails(v, mean, sd)
+	res.PValue = pValue(alt, lower, upper)
+	return res
+}
+
+// mannWhitneyDist returns the null distribution of the Mann–Whitney U
+// statistic for samples of sizes m and n. The returned slice holds the
+// probabilities of U = 0, 1, ..., m*n.
+func mannWhitneyDist(m, n int) []float64 {
+	// Under the null hypothesis all orderings of the samples are equally
+	// likely, and the number of orderings with U = u is the coefficient
+	// of q^u in the Gaussian binomial coefficient
+	//  [m+n, m]_q = \prod_{k=1}^m (1 - q^(n+k)) / (1 - q^k),
+	// which is symmetric in m and n. The product is formed in place one
+	// factor at a time; after k factors the coefficients are those of
+	// [n+k, k]_q, of degree n*k. The intermediate coefficients have mixed
+	// signs, so they are held exactly.
+	if n < m {
+		m, n = n, m
+	}
+	c := make([]big.Int, m*n+1)
+	c[0].SetInt64(1)
+	for k := 1; k <= m; k++ {
+		deg := n * k
+		// Multiply by 1 - q^(n+k), dropping the terms above deg that
+		// vanish after the division.
+		for u := deg; u >= n+k; u-- {
+			c[u].Sub(&c[u], &c[u-n-k])
+		}
+		// Divide by 1 - q^k.
+		for u := k; u <= deg; u++ {
+			c[u].Add(&c[u], &c[u-k])
+		}
+	}
+	var total big.Int
+	total.Binomial(int64(m+n), int64(m))
+	p := make([]float64, len(c))
+	var r big.Rat
+	for u := range c {
+		p[u], _ = r.SetFrac(&c[u], &total).Float64()
+	}
+	return p
+}
+
+// signedRankDist returns the null distribution of the Wilcoxon signed-rank
+// statistic for n non-zero differences. The returned slice holds the
+// probabilities of the statistic taking the values 0, 1, ..., n(n+1)/2.
+func signedRankDist(n int) []float64 {
+	p := make([]float64, n*(n+1)/2+1)
+	p[0] = 1
+	// Each rank k is included in the sum with probability one half.
+	for k := 1; k <= n; k++ {
+		for v := k * (k + 1) / 2; v >= 0; v-- {
+			p[v] *= 0.5
+			if v >= k {
+				p[v] += 0.5 * p[v-k]
+			}
+		}
+	}
+	return p
+}
+
+// exactTails returns the probabilities that a statistic with the discrete
+// distribution p is less than or equal to s and greater than or equal to s.
+func exactTails(p []float64, s int) (lower, upper float64) {
+	for v, q := range p {
+		if v <= s {
+			lower += q
+		}
+		if v >= s {
+			upper += q
+		}
+	}
+	return math.Min(lower, 1), math.Min(upper, 1)
+}
+
+// normalTails returns the continuity corrected normal approximations of the
+// probabilities that a statistic with the given mean and standard deviation
+// is less than or equal to s and greater than or equal to s.
+func normalTails(s, mean, sd float64) (lower, upper float64) {
+	return distuv.UnitNormal.CDF((s - mean + 0.5) / sd), distuv.UnitNormal.Survival((s - mean - 0.5) / sd)
+}
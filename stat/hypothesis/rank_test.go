@@ -0,0 +1,168 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hypothesis
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat/combin"
+)
+
+func TestMannWhitneyU(t *testing.T) {
+	// Reference values from R's wilcox.test.
+	x := []float64{0.80, 0.83, 1.89, 1.04, 1.45, 1.38, 1.91, 1.64, 0.73, 1.46}
+	y := []float64{1.15, 0.88, 0.90, 0.74, 1.21}
+	for _, test := range []struct {
+		x, y []float64
+		alt  Alternative
+		u, p float64
+	}{
+		{x: x, y: y, alt: Greater, u: 35, p: 0.1272061},
+		{x: x, y: y, alt: TwoSided, u: 35, p: 0.2544123},
+		{x: y, y: x, alt: Less, u: 15, p: 0.1272061},
+		// With ties the normal approximation is used.
+		{x: []float64{1, 2, 2, 3, 4, 5}, y: []float64{2, 3, 3, 6, 7, 8, 9}, alt: TwoSided, u: 9, p: 0.09667054},
+	} {
+		res := MannWhitneyU(test.x, test.y, test.alt)
+		if res.Statistic != test.u {
+			t.Errorf("unexpected U statistic: got:%v want:%v", res.Statistic, test.u)
+		}
+		if !floats.EqualWithinAbsOrRel(res.PValue, test.p, 1e-6, 1e-6) {
+			t.Errorf("unexpected p-value for U=%v: got:%v want:%v", test.u, res.PValue, test.p)
+		}
+	}
+
+	// The exact distribution matches enumeration of the assignments
+	// of ranks to the samples.
+	for _, test := range []struct{ m, n int }{{1, 1}, {1, 4}, {4, 1}, {3, 2}, {4, 4}, {5, 3}, {3, 7}, {7, 3}} {
+		want := make([]float64, test.m*test.n+1)
+		total := float64(combin.Binomial(test.m+test.n, test.m))
+		for _, c := range combin.Combinations(test.m+test.n, test.m) {
+			u := 0
+			for i, v := range c {
+				// v is the zero-based rank of the ith smallest
+				// element of the first sample.
+				u += v - i
+			}
+			want[u] += 1 / total
+		}
+		if got := mannWhitneyDist(test.m, test.n); !floats.EqualApprox(got, want, 1e-14) {
+			t.Errorf("unexpected distribution for m=%d n=%d:\ngot: %v\nwant:%v", test.m, test.n, got, want)
+		}
+	}
+
+	// The tails of the distribution at the largest sizes for which it
+	// is used hold a few orderings each.
+	const n = exactLimit - 1
+	p := mannWhitneyDist(n, n)
+	total := math.Exp(combin.LogGeneralizedBinomial(2*n, n))
+	for u, count := range []float64{1, 1, 2, 3, 5, 7, 11, 15} {
+		want := count / total
+		if !floats.EqualWithinRel(p[u], want, 1e-12) || !floats.EqualWithinRel(p[n*n-u], want, 1e-12) {
+			t.Errorf("unexpected tail probability for m=n=%d U=%d: got:%v and %v want:%v", n, u, p[u], p[n*n-u], want)
+		}
+	}
+	if sum := floats.Sum(p); math.Abs(sum-1) > 1e-14 {
+		t.Errorf("distribution for m=n=%d does not sum to one: got:%v", n, sum)
+	}
+}
+
+func TestWilcoxonSignedRank(t *testing.T) {
+	// Reference values from R's wilcox.test.
+	x := []float64{1.83, 0.50, 1.62, 2.48, 1.68, 1.88, 1.55, 3.06, 1.30}
+	y := []float64{0.878, 0.647, 0.598, 2.05, 1.06, 1.29, 1.06, 3.14, 1.29}
+	d := make([]float64, len(x))
+	floats.SubTo(d, x, y)
+	for _, test := range []struct {
+		x    []float64
+		mu   float64
+		alt  Alternative
+		v, p float64
+	}{
+		{x: d, alt: Greater, v: 40, p: 0.01953125},
+		{x: d, alt: TwoSided, v: 40, p: 0.0390625},
+		{x: d, alt: Less, v: 40, p: 0.9863281},
+		{x: x, mu: 2, alt: TwoSided, v: 14, p: 0.359375},
+		// With ties and zeros the normal approximation is used.
+		{x: []float64{-2, -1, 0, 1, 3, 3, 4, 5, 6}, alt: TwoSided, v: 31.5, p: 0.06802484},
+	} {
+		res := WilcoxonSignedRank(test.x, test.mu, test.alt)
+		if res.Statistic != test.v {
+			t.Errorf("unexpected V statistic: got:%v want:%v", res.Statistic, test.v)
+		}
+		if !floats.EqualWithinAbsOrRel(res.PValue, test.p, 1e-6, 1e-6) {
+			t.Errorf("unexpected p-value for V=%v: got:%v want:%v", test.v, res.PValue, test.p)
+		}
+	}
+
+	// The exact distribution matches enumeration of the signs.
+	for n := 1; n <= 10; n++ {
+		want := make([]float64, n*(n+1)/2+1)
+		for signs := 0; signs < 1<<uint(n); signs++ {
+			v := 0
+			for k := 0; k < n; k++ {
+				if signs&(1<<uint(k)) != 0 {
+					v += k + 1
+				}
+			}
+			want[v] += 1 / float64(int(1)<<uint(n))
+		}
+		if got := signedRankDist(n); !floats.EqualApprox(got, want, 1e-14) {
+			t.Errorf("unexpected distribution for n=%d:\ngot: %v\nwant:%v", n, got, want)
+		}
+	}
+
+	if !panics(func() { WilcoxonSignedRank([]float64{1, 1}, 1, TwoSided) }) {
+		t.Errorf("expected panic for all values equal to mu")
+	}
+}
+
+func TestRankNormalApproximation(t *testing.T) {
+	// Near the size limit the exact and approximate p-values agree.
+	rnd := rand.New(rand.NewSource(1))
+	x := make([]float64, exactLimit-1)
+	y := make([]float64, exactLimit-5)
+	for i := range x {
+		x[i] = rnd.NormFloat64() + 0.3
+	}
+	for i := range y {
+		y[i] = rnd.NormFloat64()
+	}
+	for _, alt := range []Alternative{TwoSided, Less, Greater} {
+		res := MannWhitneyU(x, y, alt)
+		n := float64(len(x) + len(y))
+		mean := float64(len(x)*len(y)) / 2
+		sd := math.Sqrt(float64(len(x)*len(y)) / 12 * (n + 1))
+		lower, upper := normalTails(res.Statistic, mean, sd)
+		if want := pValue(alt, lower, upper); math.Abs(res.PValue-want) > 2e-3 {
+			t.Errorf("Mann–Whitney exact p-value %v far from approximation %v for alt=%v", res.PValue, want, alt)
+		}
+
+		res = WilcoxonSignedRank(x, 0, alt)
+		nf := float64(len(x))
+		mean = nf * (nf + 1) / 4
+		sd = math.Sqrt(nf * (nf + 1) * (2*nf + 1) / 24)
+		lower, upper = normalTails(res.Statistic, mean, sd)
+		if want := pValue(alt, lower, upper); math.Abs(res.PValue-want) > 2e-3 {
+			t.Errorf("signed-rank exact p-value %v far from approximation %v for alt=%v", res.PValue, want, alt)
+		}
+	}
+}
+
+func TestRanks(t *testing.T) {
+	r, ties := ranks([]float64{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5})
+	want := []float64{4.5, 1.5, 6, 1.5, 8, 11, 3, 10, 8, 4.5, 8}
+	if !floats.Equal(r, want) {
+		t.Errorf("unexpected ranks: got:%v want:%v", r, want)
+	}
+	// Two pairs and one triple of ties.
+	if want := 6.0 + 6 + 24; ties != want {
+		t.Errorf("unexpected tie correction: got:%v want:%v", ties, want)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hypothesis
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// OneSampleT performs Student's one-sample t-test of the null hypothesis
+// that the mean of the population from which x is drawn is mu. The
+// returned Estimate is the sample mean, and Lower and Upper bound the
+// confidence interval for the population mean at the given confidence
+// level.
+//
+// OneSampleT will panic if len(x) < 2 or level is not in (0, 1).
+func OneSampleT(x []float64, mu float64, alt Alternative, level float64) Result {
+	checkLevel(level)
+	if len(x) < 2 {
+		panic(tooFewSamples)
+	}
+	n := float64(len(x))
+	mean, variance := stat.MeanVariance(x, nil)
+	return tTest(mean, mu, math.Sqrt(variance/n), n-1, alt, level)
+}
+
+// PairedT performs Student's paired t-test of the null hypothesis that
+// the mean of the differences x[i]-y[i] is mu. The returned Estimate
+// is the mean difference, and Lower and Upper bound the confidence
+// interval for the mean difference at the given confidence level.
+//
+// PairedT will panic if the lengths of x and y differ, if len(x) < 2 or
+// if level is not in (0, 1).
+func PairedT(x, y []float64, mu float64, alt Alternative, level float64) Result {
+	if len(x) != len(y) {
+		panic(badLength)
+	}
+	d := make([]float64, len(x))
+	for i, v := range x {
+		d[i] = v - y[i]
+	}
+	return OneSampleT(d, mu, alt, level)
+}
+
+// TwoSampleT performs Student's two-sample t-test of the null hypothesis
+// that the difference between the means of the populations from which
+// x and y are drawn is mu, assuming that the populations have equal
+// variance. The returned Estimate is the difference between the sample
+// means, and Lower and Upper bound the confidence interval for the
+// difference at the given confidence level.
+//
+// TwoSampleT will panic if len(x) < 1, len(y) < 1, len(x)+len(y) < 3 or
+// if level is not in (0, 1).
+func TwoSampleT(x, y []float64, mu float64, alt Alternative, level float64) Result {
+	checkLevel(level)
+	if len(x) < 1 || len(y) < 1 || len(x)+len(y) < 3 {
+		panic(tooFewSamples)
+	}
+	nx := float64(len(x))
+	ny := float64(len(y))
+	mx, vx := meanVariance(x)
+	my, vy := meanVariance(y)
+	dof := nx + ny - 2
+	pooled := ((nx-1)*vx + (ny-1)*vy) / dof
+	return tTest(mx-my, mu, math.Sqrt(pooled*(1/nx+1/ny)), dof, alt, level)
+}
+
+// WelchT performs Welch's unequal variances t-test of the null hypothesis
+// that the difference between the means of the populations from which x
+// and y are drawn is mu. The degrees of freedom are estimated by the
+// Welch–Satterthwaite equation and are generally not integer. The
+// returned Estimate is the difference between the sample means, and
+// Lower and Upper bound the confidence interval for the difference at
+// the given confidence level.
+//
+// WelchT will panic if len(x) < 2, len(y) < 2 or if level is not in (0, 1).
+func WelchT(x, y []float64, mu float64, alt Alternative, level float64) Result {
+	checkLevel(level)
+	if len(x) < 2 || len(y) < 2 {
+		panic(tooFewSamples)
+	}
+	nx := float64(len(x))
+	ny := float64(len(y))
+	mx, vx := stat.MeanVariance(x, nil)
+	my, vy := stat.MeanVariance(y, nil)
+	sx := vx / nx
+	sy := vy / ny
+	se2 := sx + sy
+	dof := se2 * se2 / (sx*sx/(nx-1) + sy*sy/(ny-1))
+	return tTest(mx-my, mu, math.Sqrt(se2), dof, alt, level)
+}
+
+// meanVariance returns the mean and unbiased variance of x, with the
+// variance of a single sample taken to be zero.
+func meanVariance(x []float64) (mean, variance float64) {
+	if len(x) == 1 {
+		return x[0], 0
+	}
+	return stat.MeanVariance(x, nil)
+}
+
+// tTest returns the result of a t-test of the estimate est with the given
+// standard error and degrees of freedom against the hypothesized value mu.
+func tTest(est, mu, se, dof float64, alt Alternative, level float64) Result {
+	t := (est - mu) / se
+	dist := distuv.StudentsT{Mu: 0, Sigma: 1, Nu: dof}
+	res := Result{
+		Statistic: t,
+		PValue:    pValue(alt, dist.CDF(t), dist.Survival(t)),
+		DoF:       dof,
+		Estimate:  est,
+	}
+	switch alt {
+	case TwoSided:
+		q := dist.Quantile(0.5 + level/2)
+		res.Lower = est - q*se
+		res.Upper = est + q*se
+	case Less:
+		res.Lower = math.Inf(-1)
+		res.Upper = est + dist.Quantile(level)*se
+	case Greater:
+		res.Lower = est - dist.Quantile(level)*se
+		res.Upper = math.Inf(1)
+	}
+	return res
+}
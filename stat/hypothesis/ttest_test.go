@@ -0,0 +1,127 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hypothesis
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		r := recover()
+		panicked = r != nil
+	}()
+	fn()
+	return
+}
+
+// sleep1 and sleep2 are the increases in hours of sleep of ten patients
+// given two soporific drugs from Student (1908).
+var (
+	sleep1 = []float64{0.7, -1.6, -0.2, -1.2, -0.1, 3.4, 3.7, 0.8, 0.0, 2.0}
+	sleep2 = []float64{1.9, 0.8, 1.1, 0.1, -0.1, 4.4, 5.5, 1.6, 4.6, 3.4}
+)
+
+func sameResult(got, want Result, tol float64) bool {
+	return floats.EqualWithinAbsOrRel(got.Statistic, want.Statistic, tol, tol) &&
+		floats.EqualWithinAbsOrRel(got.PValue, want.PValue, tol, tol) &&
+		sameFloat(got.DoF, want.DoF, tol) &&
+		sameFloat(got.Estimate, want.Estimate, tol) &&
+		sameFloat(got.Lower, want.Lower, tol) &&
+		sameFloat(got.Upper, want.Upper, tol)
+}
+
+func sameFloat(a, b, tol float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return a == b
+	}
+	return floats.EqualWithinAbsOrRel(a, b, tol, tol)
+}
+
+func TestTTest(t *testing.T) {
+	// Reference values from R's t.test.
+	for _, test := range []struct {
+		name string
+		got  Result
+		want Result
+	}{
+		{
+			name: "one sample",
+			got:  OneSampleT(sleep2, 1, TwoSided, 0.95),
+			want: Result{Statistic: 2.1005528, PValue: 0.06505989, DoF: 9, Estimate: 2.33, Lower: 0.8976775, Upper: 3.7623225},
+		},
+		{
+			name: "paired",
+			got:  PairedT(sleep1, sleep2, 0, TwoSided, 0.95),
+			want: Result{Statistic: -4.0621277, PValue: 0.002832890, DoF: 9, Estimate: -1.58, Lower: -2.4598858, Upper: -0.7001142},
+		},
+		{
+			name: "paired less",
+			got:  PairedT(sleep1, sleep2, 0, Less, 0.95),
+			want: Result{Statistic: -4.0621277, PValue: 0.001416445, DoF: 9, Estimate: -1.58, Lower: math.Inf(-1), Upper: -0.8669947},
+		},
+		{
+			name: "pooled",
+			got:  TwoSampleT(sleep1, sleep2, 0, TwoSided, 0.95),
+			want: Result{Statistic: -1.8608135, PValue: 0.07918671, DoF: 18, Estimate: -1.58, Lower: -3.3638740, Upper: 0.2038740},
+		},
+		{
+			name: "Welch",
+			got:  WelchT(sleep1, sleep2, 0, TwoSided, 0.95),
+			want: Result{Statistic: -1.8608135, PValue: 0.07939414, DoF: 17.776474, Estimate: -1.58, Lower: -3.3654832, Upper: 0.2054832},
+		},
+		{
+			name: "Welch greater",
+			got:  WelchT(sleep1, sleep2, -2, Greater, 0.9),
+			want: Result{Statistic: 0.4946466, PValue: 0.3134536, DoF: 17.776474, Estimate: -1.58, Lower: -2.7101645, Upper: math.Inf(1)},
+		},
+	} {
+		if !sameResult(test.got, test.want, 1e-6) {
+			t.Errorf("unexpected result for %s test:\ngot: %+v\nwant:%+v", test.name, test.got, test.want)
+		}
+	}
+
+	// The confidence interval contains the hypothesized value exactly
+	// when the test does not reject at the matching significance level.
+	for _, mu := range []float64{-4, -3.4, -3, -2, -1, 0, 0.2, 0.3, 1} {
+		for _, alt := range []Alternative{TwoSided, Less, Greater} {
+			for _, level := range []float64{0.9, 0.95, 0.99} {
+				for _, res := range []Result{
+					TwoSampleT(sleep1, sleep2, mu, alt, level),
+					WelchT(sleep1, sleep2, mu, alt, level),
+					PairedT(sleep1, sleep2, mu, alt, level),
+				} {
+					inside := res.Lower <= mu && mu <= res.Upper
+					if accept := res.PValue > 1-level; inside != accept {
+						t.Errorf("mismatch between confidence interval [%v, %v] and p-value %v for mu=%v alt=%v level=%v",
+							res.Lower, res.Upper, res.PValue, mu, alt, level)
+					}
+				}
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		name string
+		fn   func()
+	}{
+		{name: "one sample short", fn: func() { OneSampleT([]float64{1}, 0, TwoSided, 0.95) }},
+		{name: "paired length", fn: func() { PairedT(sleep1, sleep2[1:], 0, TwoSided, 0.95) }},
+		{name: "pooled short", fn: func() { TwoSampleT([]float64{1}, []float64{2}, 0, TwoSided, 0.95) }},
+		{name: "Welch short", fn: func() { WelchT(sleep1, []float64{2}, 0, TwoSided, 0.95) }},
+		{name: "bad level", fn: func() { OneSampleT(sleep1, 0, TwoSided, 1) }},
+		{name: "bad alternative", fn: func() { OneSampleT(sleep1, 0, Alternative(-1), 0.95) }},
+	} {
+		if !panics(test.fn) {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package lsq provides least squares routines shared by the regression
+// packages.
+package lsq // import "gonum.org/v1/gonum/stat/internal/lsq"
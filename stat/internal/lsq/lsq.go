@@ -0,0 +1,29 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsq
+
+import "gonum.org/v1/gonum/mat"
+
+// Covariance returns the inverse of the triangular factor R of the QR
+// factorization of an n×p matrix X with n >= p, and the unscaled covariance
+// (XᵀX)⁻¹ = R⁻¹ R⁻ᵀ. ok is false if R is singular.
+func Covariance(qr *mat.QR) (rInv *mat.TriDense, cov *mat.SymDense, ok bool) {
+	var r mat.Dense
+	qr.RTo(&r)
+	_, p := r.Dims()
+	rt := mat.NewTriDense(p, mat.Upper, nil)
+	for i := 0; i < p; i++ {
+		for j := i; j < p; j++ {
+			rt.SetTri(i, j, r.At(i, j))
+		}
+	}
+	rInv = &mat.TriDense{}
+	if err := rInv.InverseTri(rt); err != nil {
+		return nil, nil, false
+	}
+	cov = mat.NewSymDense(p, nil)
+	cov.SymOuterK(1, rInv)
+	return rInv, cov, true
+}
@@ -0,0 +1,287 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kde
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+// Silverman returns the bandwidth for a univariate estimate from the sample
+// x given by Silverman's rule of thumb
+//  h = 0.9 min(σ, IQR/1.34) n^(-1/5)
+// where σ is the standard deviation and IQR the interquartile range of the
+// sample. The rule is robust to outliers, but oversmooths multimodal
+// densities.
+//
+// If weights is not nil the sample is weighted and n is the effective
+// sample size (Σw)^2/Σw^2, which is independent of the scale of the weights.
+// Silverman returns zero if all elements of x are equal, and will panic if
+// len(x) < 2, the lengths of x and weights differ or any weight is negative.
+func Silverman(x, weights []float64) float64 {
+	sd, iqr, n := sampleScale(x, weights)
+	s := sd
+	if iqr > 0 {
+		s = math.Min(sd, iqr/1.34)
+	}
+	return 0.9 * s * math.Pow(n, -0.2)
+}
+
+// Scott returns the bandwidth for a univariate estimate from the sample x
+// given by Scott's normal reference rule
+//  h = 1.06 σ n^(-1/5)
+// where σ is the standard deviation of the sample. The rule minimizes the
+// asymptotic mean integrated squared error when the density is normal.
+//
+// If weights is not nil the sample is weighted and n is the effective
+// sample size (Σw)^2/Σw^2. Scott returns zero if all elements of x are
+// equal, and will panic if len(x) < 2, the lengths of x and weights differ
+// or any weight is negative.
+func Scott(x, weights []float64) float64 {
+	sd, _, n := sampleScale(x, weights)
+	return 1.06 * sd * math.Pow(n, -0.2)
+}
+
+// sheatherJonesBins is the number of bins used to approximate the density
+// functionals in SheatherJones.
+const sheatherJonesBins = 1000
+
+// SheatherJones returns the bandwidth for a univariate estimate from the
+// sample x selected by the solve-the-equation plug-in method of Sheather
+// and Jones (1991). The selected bandwidth adapts to the structure of the
+// density and is generally preferable to the rules of thumb for densities
+// that are far from normal. The density functionals of the method are
+// estimated using a Gaussian kernel on a grid of 1000 bins.
+//
+// If weights is not nil the sample is weighted and n is the effective
+// sample size (Σw)^2/Σw^2. SheatherJones returns NaN if no bandwidth solves
+// the equation, which may happen if all elements of x are equal, and will
+// panic if len(x) < 2, the lengths of x and weights differ or any weight is
+// negative.
+func SheatherJones(x, weights []float64) float64 {
+	sd, iqr, n := sampleScale(x, weights)
+	scale := math.Min(sd, iqr/1.349)
+	if scale == 0 {
+		scale = sd
+	}
+	if scale == 0 {
+		return math.NaN()
+	}
+
+	// Accumulate the weights of pairs of sample points by the number
+	// of bins separating them.
+	w := normalizedWeights(len(x), weights)
+	lo := floats.Min(x)
+	d := 1.01 * (floats.Max(x) - lo) / sheatherJonesBins
+	counts := make([]float64, sheatherJonesBins)
+	for i, v := range x {
+		counts[int((v-lo)/d)] += w[i]
+	}
+	pairs := make([]float64, sheatherJonesBins)
+	for i, ci := range counts {
+		if ci == 0 {
+			continue
+		}
+		pairs[0] += ci * ci
+		for j, cj := range counts[i+1:] {
+			pairs[j+1] += 2 * ci * cj
+		}
+	}
+	// Exclude the pairing of each point with itself from
+	// the normalization of the sums.
+	norm := (1 - floats.Dot(w, w)) * math.Sqrt(2*math.Pi)
+
+	// phi4 and phi6 return estimates of the integrals of the squares
+	// of the second and third derivatives of the density with the
+	// Gaussian kernel and bandwidth h.
+	phi4 := func(h float64) float64 {
+		var sum float64
+		for i, p := range pairs {
+			delta := float64(i) * d / h
+			delta *= delta
+			if delta >= 1000 {
+				break
+			}
+			sum += p * math.Exp(-delta/2) * (delta*delta - 6*delta + 3)
+		}
+		return sum / (norm * math.Pow(h, 5))
+	}
+	phi6 := func(h float64) float64 {
+		var sum float64
+		for i, p := range pairs {
+			delta := float64(i) * d / h
+			delta *= delta
+			if delta >= 1000 {
+				break
+			}
+			sum += p * math.Exp(-delta/2) * (delta*delta*delta - 15*delta*delta + 45*delta - 15)
+		}
+		return sum / (norm * math.Pow(h, 7))
+	}
+
+	// Pilot bandwidths from the normal reference.
+	a := 1.24 * scale * math.Pow(n, -1.0/7)
+	b := 1.23 * scale * math.Pow(n, -1.0/9)
+	c1 := 1 / (2 * math.Sqrt(math.Pi) * n)
+	alpha2 := 1.357 * math.Pow(phi4(a)/-phi6(b), 1.0/7)
+	f := func(h float64) float64 {
+		return math.Pow(c1/phi4(alpha2*math.Pow(h, 5.0/7)), 0.2) - h
+	}
+
+	// Bracket the root, widening the initial interval as necessary,
+	// and refine it by bisection.
+	hmax := 1.144 * scale * math.Pow(n, -0.2)
+	lower, upper := 0.1*hmax, hmax
+	flo, fhi := f(lower), f(upper)
+	for i := 0; !(flo*fhi <= 0); i++ {
+		if i == 100 || math.IsNaN(flo) || math.IsNaN(fhi) {
+			return math.NaN()
+		}
+		if i%2 == 0 {
+			upper *= 1.2
+			fhi = f(upper)
+		} else {
+			lower /= 1.2
+			flo = f(lower)
+		}
+	}
+	for upper-lower > 1e-10*upper {
+		mid := lower + (upper-lower)/2
+		fmid := f(mid)
+		if (fmid <= 0) == (flo <= 0) {
+			lower, flo = mid, fmid
+		} else {
+			upper = mid
+		}
+	}
+	return lower + (upper-lower)/2
+}
+
+// ScottMatrix returns the bandwidth matrix for a multivariate estimate from
+// the sample in the rows of x given by Scott's rule
+//  H = n^(-2/(d+4)) Σ
+// where Σ is the covariance matrix of the sample and d its dimension.
+//
+// If weights is not nil the sample is weighted and n is the effective
+// sample size (Σw)^2/Σw^2. ScottMatrix will panic if x has fewer than two
+// rows, the number of rows of x and the length of weights differ or any
+// weight is negative.
+func ScottMatrix(x mat.Matrix, weights []float64) *mat.SymDense {
+	cov, n := sampleCovariance(x, weights)
+	d := float64(cov.Symmetric())
+	cov.ScaleSym(math.Pow(n, -2/(d+4)), cov)
+	return cov
+}
+
+// SilvermanMatrix returns the bandwidth matrix for a multivariate estimate
+// from the sample in the rows of x given by Silverman's rule
+//  H = (4/(n(d+2)))^(2/(d+4)) Σ
+// where Σ is the covariance matrix of the sample and d its dimension. The
+// rule minimizes the asymptotic mean integrated squared error when the
+// density is normal.
+//
+// If weights is not nil the sample is weighted and n is the effective
+// sample size (Σw)^2/Σw^2. SilvermanMatrix will panic if x has fewer than
+// two rows, the number of rows of x and the length of weights differ or any
+// weight is negative.
+func SilvermanMatrix(x mat.Matrix, weights []float64) *mat.SymDense {
+	cov, n := sampleCovariance(x, weights)
+	d := float64(cov.Symmetric())
+	cov.ScaleSym(math.Pow(4/(n*(d+2)), 2/(d+4)), cov)
+	return cov
+}
+
+// normalizedWeights returns the weights normalized to sum to one, or equal
+// weights if weights is nil.
+func normalizedWeights(n int, weights []float64) []float64 {
+	w := make([]float64, n)
+	if weights == nil {
+		for i := range w {
+			w[i] = 1 / float64(n)
+		}
+		return w
+	}
+	if len(weights) != n {
+		panic(badWeights)
+	}
+	var sum float64
+	for _, v := range weights {
+		if v < 0 {
+			panic(negativeWeight)
+		}
+		sum += v
+	}
+	if sum == 0 {
+		panic(zeroWeights)
+	}
+	for i, v := range weights {
+		w[i] = v / sum
+	}
+	return w
+}
+
+// sampleScale returns the standard deviation, the interquartile range and
+// the effective sample size of the weighted sample x.
+func sampleScale(x, weights []float64) (sd, iqr, n float64) {
+	if len(x) < 2 {
+		panic(tooFewSamples)
+	}
+	w := normalizedWeights(len(x), weights)
+	sumSq := floats.Dot(w, w)
+	mean := floats.Dot(w, x)
+	var ss float64
+	for i, v := range x {
+		d := v - mean
+		ss += w[i] * d * d
+	}
+	sd = math.Sqrt(ss / (1 - sumSq))
+
+	xs := append([]float64(nil), x...)
+	stat.SortWeighted(xs, w)
+	iqr = stat.Quantile(0.75, stat.LinInterp, xs, w) - stat.Quantile(0.25, stat.LinInterp, xs, w)
+
+	return sd, iqr, 1 / sumSq
+}
+
+// sampleCovariance returns the covariance matrix and the effective sample
+// size of the weighted sample in the rows of x.
+func sampleCovariance(x mat.Matrix, weights []float64) (cov *mat.SymDense, n float64) {
+	r, c := x.Dims()
+	if r < 2 {
+		panic(tooFewSamples)
+	}
+	w := normalizedWeights(r, weights)
+	sumSq := floats.Dot(w, w)
+	mean := make([]float64, c)
+	for i, wi := range w {
+		for j := range mean {
+			mean[j] += wi * x.At(i, j)
+		}
+	}
+	centered := mat.NewDense(r, c, nil)
+	for i, wi := range w {
+		s := math.Sqrt(wi / (1 - sumSq))
+		for j, m := range mean {
+			centered.Set(i, j, s*(x.At(i, j)-m))
+		}
+	}
+	cov = mat.NewSymDense(c, nil)
+	cov.SymOuterK(1, centered.T())
+	return cov, 1 / sumSq
+}
+
+// sortedIndex returns the indices of x in increasing order of x.
+func sortedIndex(x []float64) []int {
+	idx := make([]int, len(x))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool { return x[idx[i]] < x[idx[j]] })
+	return idx
+}
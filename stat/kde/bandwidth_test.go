@@ -0,0 +1,159 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kde
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+func TestBandwidth(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		x    []float64
+		bw   func(x, weights []float64) float64
+		want float64
+		tol  float64
+	}{
+		// Values from R bw.nrd0 and bw.SJ.
+		{name: "Silverman eruptions", x: faithful.eruptions, bw: Silverman, want: 0.3348, tol: 1e-4},
+		{name: "Silverman waiting", x: faithful.waiting, bw: Silverman, want: 3.988, tol: 1e-3},
+		{name: "SheatherJones eruptions", x: faithful.eruptions, bw: SheatherJones, want: 0.14, tol: 1e-3},
+
+		// Scott's rule is 1.06 σ n^(-1/5).
+		{name: "Scott", x: []float64{1, 2, 3, 4, 5}, bw: Scott, want: 1.06 * math.Sqrt(2.5) * math.Pow(5, -0.2), tol: 1e-14},
+		// Silverman's rule uses the interquartile range when it is the smaller scale.
+		{name: "Silverman outlier", x: []float64{1, 2, 3, 4, 100}, bw: Silverman, want: 0.9 * 2.5 / 1.34 * math.Pow(5, -0.2), tol: 1e-14},
+	} {
+		got := test.bw(test.x, nil)
+		if math.Abs(got-test.want) > test.tol {
+			t.Errorf("unexpected bandwidth for %s: got:%v want:%v", test.name, got, test.want)
+		}
+
+		// Equal weights of any scale give the unweighted bandwidth.
+		w := make([]float64, len(test.x))
+		for i := range w {
+			w[i] = 3
+		}
+		if gotW := test.bw(test.x, w); math.Abs(gotW-got) > 1e-12*got {
+			t.Errorf("unexpected bandwidth for %s with equal weights: got:%v want:%v", test.name, gotW, got)
+		}
+	}
+
+	for _, bw := range []func(x, weights []float64) float64{Silverman, Scott, SheatherJones} {
+		for _, bad := range []struct {
+			x, w []float64
+		}{
+			{x: []float64{1}},
+			{x: []float64{1, 2}, w: []float64{1}},
+			{x: []float64{1, 2}, w: []float64{1, -1}},
+			{x: []float64{1, 2}, w: []float64{0, 0}},
+		} {
+			if !panics(func() { bw(bad.x, bad.w) }) {
+				t.Errorf("expected panic for x=%v weights=%v", bad.x, bad.w)
+			}
+		}
+	}
+	if got := SheatherJones([]float64{2, 2, 2}, nil); !math.IsNaN(got) {
+		t.Errorf("unexpected Sheather–Jones bandwidth for constant sample: got:%v want:NaN", got)
+	}
+}
+
+func TestSheatherJonesNormal(t *testing.T) {
+	// For a normal sample the plug-in bandwidth is close
+	// to the normal reference bandwidth.
+	rnd := rand.New(rand.NewSource(1))
+	x := make([]float64, 10000)
+	for i := range x {
+		x[i] = 2 + 3*rnd.NormFloat64()
+	}
+	got := SheatherJones(x, nil)
+	want := math.Pow(4.0/3, 0.2) * 3 * math.Pow(float64(len(x)), -0.2)
+	if math.Abs(got-want) > 0.05*want {
+		t.Errorf("unexpected Sheather–Jones bandwidth for normal sample: got:%v want:%v", got, want)
+	}
+}
+
+func TestBandwidthWeighted(t *testing.T) {
+	// Zero weights exclude the corresponding samples.
+	rnd := rand.New(rand.NewSource(1))
+	x := make([]float64, 200)
+	w := make([]float64, len(x))
+	var kept []float64
+	for i := range x {
+		x[i] = rnd.ExpFloat64()
+		if i%4 != 0 {
+			w[i] = 1
+			kept = append(kept, x[i])
+		}
+	}
+	for _, test := range []struct {
+		name string
+		bw   func(x, weights []float64) float64
+	}{
+		{name: "Silverman", bw: Silverman},
+		{name: "Scott", bw: Scott},
+		{name: "SheatherJones", bw: SheatherJones},
+	} {
+		got := test.bw(x, w)
+		want := test.bw(kept, nil)
+		// The binning in SheatherJones depends on the range
+		// of all the samples.
+		tol := 1e-12
+		if test.name == "SheatherJones" {
+			tol = 1e-2
+		}
+		if math.Abs(got-want) > tol*want {
+			t.Errorf("unexpected weighted %s bandwidth: got:%v want:%v", test.name, got, want)
+		}
+	}
+}
+
+func TestBandwidthMatrix(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	const n, d = 100, 3
+	x := mat.NewDense(n, d, nil)
+	for i := 0; i < n; i++ {
+		z := []float64{rnd.NormFloat64(), rnd.NormFloat64(), rnd.NormFloat64()}
+		x.Set(i, 0, z[0])
+		x.Set(i, 1, z[0]+0.5*z[1])
+		x.Set(i, 2, 2*z[2]-z[0])
+	}
+	cov := mat.NewSymDense(d, nil)
+	stat.CovarianceMatrix(cov, x, nil)
+
+	for _, test := range []struct {
+		name  string
+		bw    func(x mat.Matrix, weights []float64) *mat.SymDense
+		scale float64
+	}{
+		{name: "Scott", bw: ScottMatrix, scale: math.Pow(n, -2.0/(d+4))},
+		{name: "Silverman", bw: SilvermanMatrix, scale: math.Pow(4.0/(n*(d+2)), 2.0/(d+4))},
+	} {
+		var want mat.SymDense
+		want.ScaleSym(test.scale, cov)
+		got := test.bw(x, nil)
+		if !mat.EqualApprox(got, &want, 1e-12) {
+			t.Errorf("unexpected %s bandwidth matrix:\ngot: %v\nwant:%v", test.name, mat.Formatted(got), mat.Formatted(&want))
+		}
+
+		w := make([]float64, n)
+		for i := range w {
+			w[i] = 0.5
+		}
+		if gotW := test.bw(x, w); !mat.EqualApprox(gotW, got, 1e-12) {
+			t.Errorf("unexpected %s bandwidth matrix with equal weights", test.name)
+		}
+
+		if !panics(func() { test.bw(mat.NewDense(1, 2, nil), nil) }) {
+			t.Errorf("expected panic for %s with one sample", test.name)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package kde provides kernel density estimation.
+//
+// A kernel density estimate approximates the density from which a sample is
+// drawn by a mixture of copies of a smoothing kernel centered on the sample
+// points and scaled by a bandwidth. The choice of bandwidth matters much more
+// than the choice of kernel; the package provides the rules of thumb of
+// Silverman and Scott and the plug-in selector of Sheather and Jones.
+//
+// Univariate estimates may be evaluated on a regular grid using binning and
+// fast Fourier transforms. Multivariate estimates use a Gaussian kernel with
+// a bandwidth matrix and satisfy the distmv.RandLogProber interface.
+package kde // import "gonum.org/v1/gonum/stat/kde"
@@ -0,0 +1,71 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kde_test
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/kde"
+)
+
+func ExampleUnivariate() {
+	x := []float64{1.2, 1.9, 2.1, 2.4, 3.3, 5.8, 6.1, 6.4, 7.0}
+
+	// Estimate the density with a Gaussian kernel and a
+	// bandwidth chosen by Silverman's rule of thumb.
+	h := kde.Silverman(x, nil)
+	u := kde.NewUnivariate(x, nil, kde.Gaussian{}, h, nil)
+	fmt.Printf("bandwidth = %.4f\n", u.Bandwidth())
+	for _, v := range []float64{2, 4, 6} {
+		fmt.Printf("f(%v) = %.4f\n", v, u.Prob(v))
+	}
+
+	// Evaluate the density on a fine grid and
+	// print every twentieth value.
+	grid := u.ProbGrid(make([]float64, 81), 0, 8)
+	for i := 0; i < len(grid); i += 20 {
+		fmt.Printf("grid f(%v) = %.4f\n", float64(i)/10, grid[i])
+	}
+
+	// Output:
+	// bandwidth = 1.3180
+	// f(2) = 0.1488
+	// f(4) = 0.1018
+	// f(6) = 0.1298
+	// grid f(0) = 0.0514
+	// grid f(2) = 0.1488
+	// grid f(4) = 0.1018
+	// grid f(6) = 0.1298
+	// grid f(8) = 0.0616
+}
+
+func ExampleMultivariate() {
+	x := mat.NewDense(6, 2, []float64{
+		0.1, 1.2,
+		0.4, 0.8,
+		-0.3, 1.1,
+		2.1, -0.4,
+		1.8, -0.1,
+		2.5, 0.2,
+	})
+
+	// Estimate the density with a bandwidth matrix
+	// chosen by Scott's rule.
+	h := kde.ScottMatrix(x, nil)
+	m, ok := kde.NewMultivariate(x, nil, h, nil)
+	if !ok {
+		fmt.Println("bandwidth matrix not positive definite")
+		return
+	}
+	for _, v := range [][]float64{{0, 1}, {1, 0.5}, {2, 0}} {
+		fmt.Printf("f(%v) = %.4f\n", v, m.Prob(v))
+	}
+
+	// Output:
+	// f([0 1]) = 0.3401
+	// f([1 0.5]) = 0.2815
+	// f([2 0]) = 0.1777
+}
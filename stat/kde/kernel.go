@@ -0,0 +1,308 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kde
+
+import (
+	"math"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// Kernel is a smoothing kernel, a probability density that is symmetric
+// about zero and has unit variance. With unit variance kernels the bandwidth
+// of an estimate is the standard deviation of each of its components, so the
+// same bandwidth gives comparable smoothing with any kernel.
+//
+// Random samples from kernels not defined in this package are generated by
+// rejection sampling when the support is bounded, which requires that the
+// density is largest at zero, and otherwise by inversion of the CDF.
+type Kernel interface {
+	// Prob returns the value of the density of the kernel at x.
+	Prob(x float64) float64
+
+	// CDF returns the value of the cumulative distribution
+	// function of the kernel at x.
+	CDF(x float64) float64
+
+	// Support returns the half-width of the support of the
+	// kernel, which is infinite for kernels with unbounded
+	// support.
+	Support() float64
+}
+
+// kernelRander is a Kernel that can generate random samples.
+type kernelRander interface {
+	rand(rnd *rand.Rand) float64
+}
+
+// Gaussian is the Gaussian kernel, the standard normal density
+//  K(x) = exp(-x^2/2) / sqrt(2π)
+type Gaussian struct{}
+
+// Prob returns the value of the density of the kernel at x.
+func (Gaussian) Prob(x float64) float64 { return distuv.UnitNormal.Prob(x) }
+
+// CDF returns the value of the cumulative distribution function of the kernel at x.
+func (Gaussian) CDF(x float64) float64 { return distuv.UnitNormal.CDF(x) }
+
+// Support returns positive infinity.
+func (Gaussian) Support() float64 { return math.Inf(1) }
+
+func (Gaussian) rand(rnd *rand.Rand) float64 { return normFloat64(rnd) }
+
+// The half-widths of the supports of the bounded kernels scaled to unit variance.
+var (
+	epanechnikovWidth = math.Sqrt(5)
+	uniformWidth      = math.Sqrt(3)
+	triangularWidth   = math.Sqrt(6)
+	biweightWidth     = math.Sqrt(7)
+	triweightWidth    = 3.0
+	cosineWidth       = 1 / math.Sqrt(1-8/(math.Pi*math.Pi))
+)
+
+// Epanechnikov is the Epanechnikov kernel
+//  K(x) = 3/(4a) (1 - (x/a)^2) for |x| <= a
+// with a = sqrt(5). The Epanechnikov kernel minimizes the asymptotic mean
+// integrated squared error of the estimate among non-negative kernels.
+type Epanechnikov struct{}
+
+// Prob returns the value of the density of the kernel at x.
+func (Epanechnikov) Prob(x float64) float64 {
+	t := x / epanechnikovWidth
+	if math.Abs(t) > 1 {
+		return 0
+	}
+	return 0.75 / epanechnikovWidth * (1 - t*t)
+}
+
+// CDF returns the value of the cumulative distribution function of the kernel at x.
+func (Epanechnikov) CDF(x float64) float64 {
+	t := clampUnit(x / epanechnikovWidth)
+	return 0.5 + t*(3-t*t)/4
+}
+
+// Support returns sqrt(5).
+func (Epanechnikov) Support() float64 { return epanechnikovWidth }
+
+func (Epanechnikov) rand(rnd *rand.Rand) float64 {
+	// The median of three independent uniform variates on
+	// [-1, 1] has the Epanechnikov distribution.
+	u1 := 2*float64Unit(rnd) - 1
+	u2 := 2*float64Unit(rnd) - 1
+	u3 := 2*float64Unit(rnd) - 1
+	return epanechnikovWidth * math.Max(math.Min(u1, u2), math.Min(math.Max(u1, u2), u3))
+}
+
+// Uniform is the uniform kernel
+//  K(x) = 1/(2a) for |x| <= a
+// with a = sqrt(3).
+type Uniform struct{}
+
+// Prob returns the value of the density of the kernel at x.
+func (Uniform) Prob(x float64) float64 {
+	if math.Abs(x) > uniformWidth {
+		return 0
+	}
+	return 0.5 / uniformWidth
+}
+
+// CDF returns the value of the cumulative distribution function of the kernel at x.
+func (Uniform) CDF(x float64) float64 {
+	return (clampUnit(x/uniformWidth) + 1) / 2
+}
+
+// Support returns sqrt(3).
+func (Uniform) Support() float64 { return uniformWidth }
+
+func (Uniform) rand(rnd *rand.Rand) float64 {
+	return uniformWidth * (2*float64Unit(rnd) - 1)
+}
+
+// Triangular is the triangular kernel
+//  K(x) = (1 - |x|/a) / a for |x| <= a
+// with a = sqrt(6).
+type Triangular struct{}
+
+// Prob returns the value of the density of the kernel at x.
+func (Triangular) Prob(x float64) float64 {
+	t := math.Abs(x) / triangularWidth
+	if t > 1 {
+		return 0
+	}
+	return (1 - t) / triangularWidth
+}
+
+// CDF returns the value of the cumulative distribution function of the kernel at x.
+func (Triangular) CDF(x float64) float64 {
+	t := clampUnit(x / triangularWidth)
+	if t < 0 {
+		return (1 + t) * (1 + t) / 2
+	}
+	return 1 - (1-t)*(1-t)/2
+}
+
+// Support returns sqrt(6).
+func (Triangular) Support() float64 { return triangularWidth }
+
+func (Triangular) rand(rnd *rand.Rand) float64 {
+	// The sum of two independent uniform variates has a
+	// triangular distribution.
+	return triangularWidth * (float64Unit(rnd) + float64Unit(rnd) - 1)
+}
+
+// Biweight is the biweight, or quartic, kernel
+//  K(x) = 15/(16a) (1 - (x/a)^2)^2 for |x| <= a
+// with a = sqrt(7).
+type Biweight struct{}
+
+// Prob returns the value of the density of the kernel at x.
+func (Biweight) Prob(x float64) float64 {
+	t := x / biweightWidth
+	if math.Abs(t) > 1 {
+		return 0
+	}
+	s := 1 - t*t
+	return 15 / (16 * biweightWidth) * s * s
+}
+
+// CDF returns the value of the cumulative distribution function of the kernel at x.
+func (Biweight) CDF(x float64) float64 {
+	t := x / biweightWidth
+	switch {
+	case t <= -1:
+		return 0
+	case t >= 1:
+		return 1
+	}
+	t2 := t * t
+	return 0.5 + 15.0/16*t*(1-t2*(2.0/3-t2/5))
+}
+
+// Support returns sqrt(7).
+func (Biweight) Support() float64 { return biweightWidth }
+
+// Triweight is the triweight kernel
+//  K(x) = 35/(32a) (1 - (x/a)^2)^3 for |x| <= a
+// with a = 3.
+type Triweight struct{}
+
+// Prob returns the value of the density of the kernel at x.
+func (Triweight) Prob(x float64) float64 {
+	t := x / triweightWidth
+	if math.Abs(t) > 1 {
+		return 0
+	}
+	s := 1 - t*t
+	return 35 / (32 * triweightWidth) * s * s * s
+}
+
+// CDF returns the value of the cumulative distribution function of the kernel at x.
+func (Triweight) CDF(x float64) float64 {
+	t := x / triweightWidth
+	switch {
+	case t <= -1:
+		return 0
+	case t >= 1:
+		return 1
+	}
+	t2 := t * t
+	return 0.5 + 35.0/32*t*(1-t2*(1-t2*(3.0/5-t2/7)))
+}
+
+// Support returns 3.
+func (Triweight) Support() float64 { return triweightWidth }
+
+// Cosine is the cosine kernel
+//  K(x) = π/(4a) cos(πx/(2a)) for |x| <= a
+// with a = 1/sqrt(1 - 8/π^2).
+type Cosine struct{}
+
+// Prob returns the value of the density of the kernel at x.
+func (Cosine) Prob(x float64) float64 {
+	t := x / cosineWidth
+	if math.Abs(t) > 1 {
+		return 0
+	}
+	return math.Pi / (4 * cosineWidth) * math.Cos(math.Pi/2*t)
+}
+
+// CDF returns the value of the cumulative distribution function of the kernel at x.
+func (Cosine) CDF(x float64) float64 {
+	return 0.5 + 0.5*math.Sin(math.Pi/2*clampUnit(x/cosineWidth))
+}
+
+// Support returns 1/sqrt(1 - 8/π^2).
+func (Cosine) Support() float64 { return cosineWidth }
+
+func (Cosine) rand(rnd *rand.Rand) float64 {
+	// Invert the cumulative distribution function.
+	return cosineWidth * 2 / math.Pi * math.Asin(2*float64Unit(rnd)-1)
+}
+
+// clampUnit returns t clamped to [-1, 1].
+func clampUnit(t float64) float64 {
+	return math.Max(-1, math.Min(t, 1))
+}
+
+// kernelRand returns a random sample from the kernel k.
+func kernelRand(k Kernel, rnd *rand.Rand) float64 {
+	if r, ok := k.(kernelRander); ok {
+		return r.rand(rnd)
+	}
+	a := k.Support()
+	if !math.IsInf(a, 0) {
+		// Use rejection sampling from the uniform distribution
+		// on the support, bounded by the value of the density
+		// at its mode.
+		peak := k.Prob(0)
+		for {
+			x := a * (2*float64Unit(rnd) - 1)
+			if float64Unit(rnd)*peak <= k.Prob(x) {
+				return x
+			}
+		}
+	}
+	// Invert the cumulative distribution function by bisection.
+	u := float64Unit(rnd)
+	lo, hi := -1.0, 1.0
+	for k.CDF(lo) > u {
+		lo *= 2
+	}
+	for k.CDF(hi) < u {
+		hi *= 2
+	}
+	for i := 0; i < 100 && lo < hi; i++ {
+		mid := lo + (hi-lo)/2
+		if mid == lo || mid == hi {
+			break
+		}
+		if k.CDF(mid) < u {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo + (hi-lo)/2
+}
+
+// float64Unit returns a uniform random number in [0, 1) from rnd or from
+// the global source if rnd is nil.
+func float64Unit(rnd *rand.Rand) float64 {
+	if rnd == nil {
+		return rand.Float64()
+	}
+	return rnd.Float64()
+}
+
+// normFloat64 returns a standard normal random number from rnd or from the
+// global source if rnd is nil.
+func normFloat64(rnd *rand.Rand) float64 {
+	if rnd == nil {
+		return rand.NormFloat64()
+	}
+	return rnd.NormFloat64()
+}
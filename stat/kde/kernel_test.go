@@ -0,0 +1,144 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kde
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/integrate/quad"
+	"gonum.org/v1/gonum/stat"
+)
+
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		r := recover()
+		panicked = r != nil
+	}()
+	fn()
+	return
+}
+
+// quartic is a kernel without a rand method for testing
+// the generic sampling of bounded kernels.
+type quartic struct{ Biweight }
+
+// logistic is the logistic kernel scaled to unit variance without
+// a rand method for testing the generic sampling of unbounded kernels.
+type logistic struct{}
+
+var logisticScale = math.Sqrt(3) / math.Pi
+
+func (logistic) Prob(x float64) float64 {
+	e := math.Exp(-math.Abs(x) / logisticScale)
+	return e / (logisticScale * (1 + e) * (1 + e))
+}
+func (logistic) CDF(x float64) float64 { return 1 / (1 + math.Exp(-x/logisticScale)) }
+func (logistic) Support() float64      { return math.Inf(1) }
+
+var kernels = []struct {
+	name string
+	k    Kernel
+}{
+	{name: "Gaussian", k: Gaussian{}},
+	{name: "Epanechnikov", k: Epanechnikov{}},
+	{name: "Uniform", k: Uniform{}},
+	{name: "Triangular", k: Triangular{}},
+	{name: "Biweight", k: Biweight{}},
+	{name: "Triweight", k: Triweight{}},
+	{name: "Cosine", k: Cosine{}},
+	{name: "quartic", k: quartic{}},
+	{name: "logistic", k: logistic{}},
+}
+
+func TestKernel(t *testing.T) {
+	const tol = 1e-10
+	for _, test := range kernels {
+		k := test.k
+		a := k.Support()
+		if math.IsInf(a, 1) {
+			a = 40
+		}
+		// Integrate each half separately since kernels
+		// may not be smooth at zero.
+		integral := func(f func(float64) float64) float64 {
+			return quad.Fixed(f, -a, 0, 1000, nil, 0) + quad.Fixed(f, 0, a, 1000, nil, 0)
+		}
+		mass := integral(k.Prob)
+		if math.Abs(mass-1) > tol {
+			t.Errorf("unexpected integral of %s kernel: got:%v want:1", test.name, mass)
+		}
+		variance := integral(func(x float64) float64 { return x * x * k.Prob(x) })
+		if math.Abs(variance-1) > tol {
+			t.Errorf("unexpected variance of %s kernel: got:%v want:1", test.name, variance)
+		}
+
+		if k.Prob(1.5*a) != 0 && !math.IsInf(k.Support(), 1) {
+			t.Errorf("unexpected density of %s kernel outside support", test.name)
+		}
+		for _, x := range []float64{-2.5, -1, -0.3, 0, 0.7, 1.2, 2} {
+			if x < -a {
+				continue
+			}
+			if k.Prob(x) != k.Prob(-x) {
+				t.Errorf("%s kernel not symmetric at %v", test.name, x)
+			}
+			lo := -a
+			if x > 0 {
+				lo = 0
+			}
+			want := quad.Fixed(k.Prob, lo, math.Min(x, a), 1000, nil, 0)
+			if x > 0 {
+				want += 0.5
+			}
+			if got := k.CDF(x); math.Abs(got-want) > tol {
+				t.Errorf("unexpected CDF of %s kernel at %v: got:%v want:%v", test.name, x, got, want)
+			}
+		}
+		if got := k.CDF(-2 * a); math.Abs(got) > tol {
+			t.Errorf("unexpected CDF of %s kernel below support: got:%v", test.name, got)
+		}
+		if got := k.CDF(2 * a); math.Abs(got-1) > tol {
+			t.Errorf("unexpected CDF of %s kernel above support: got:%v", test.name, got)
+		}
+	}
+}
+
+func TestKernelRand(t *testing.T) {
+	const n = 100000
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range kernels {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = kernelRand(test.k, rnd)
+		}
+		mean, std := stat.MeanStdDev(x, nil)
+		if math.Abs(mean) > 0.02 {
+			t.Errorf("unexpected mean of %s kernel samples: got:%v want:0", test.name, mean)
+		}
+		if math.Abs(std-1) > 0.02 {
+			t.Errorf("unexpected standard deviation of %s kernel samples: got:%v want:1", test.name, std)
+		}
+		if a := test.k.Support(); floats.Max(x) > a || floats.Min(x) < -a {
+			t.Errorf("%s kernel sample outside support", test.name)
+		}
+		// Compare the empirical distribution at the quartiles.
+		for _, p := range []float64{0.25, 0.5, 0.75} {
+			q := stat.Quantile(p, stat.Empirical, sorted(x), nil)
+			if got := test.k.CDF(q); math.Abs(got-p) > 0.01 {
+				t.Errorf("unexpected CDF of %s kernel at empirical %v quantile: got:%v", test.name, p, got)
+			}
+		}
+	}
+}
+
+func sorted(x []float64) []float64 {
+	s := append([]float64(nil), x...)
+	floats.Argsort(s, make([]int, len(s)))
+	return s
+}
@@ -0,0 +1,177 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kde
+
+import (
+	"math"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+const badDimension = "kde: dimension mismatch"
+
+// Multivariate is a multivariate kernel density estimate with a Gaussian
+// kernel
+//  f(x) = Σ_i w_i N(x; x_i, H)
+// for a sample x_i with normalized weights w_i and a symmetric positive
+// definite bandwidth matrix H, where N(x; μ, Σ) is the density of the
+// multivariate normal distribution with mean μ and covariance Σ.
+//
+// Multivariate satisfies the distmv.RandLogProber interface.
+type Multivariate struct {
+	dim int
+
+	// x holds the sample in its rows and z holds the sample
+	// transformed by the inverse of the Cholesky factor of H.
+	x   *mat.Dense
+	z   *mat.Dense
+	w   []float64
+	cum []float64
+
+	chol mat.Cholesky
+	uInv mat.TriDense
+
+	// logNorm is the log of the normalization constant
+	// of the kernel.
+	logNorm float64
+
+	rnd *rand.Rand
+}
+
+// NewMultivariate returns a kernel density estimate for the sample in the
+// rows of x with the given bandwidth matrix. If weights is nil the sample is
+// unweighted, otherwise weights holds the non-negative weights of the rows
+// of x. If src is nil the global random source is used by Rand. The values
+// in x, weights and bandwidth are copied. If the bandwidth matrix is not
+// positive definite, NewMultivariate returns nil and false.
+//
+// NewMultivariate will panic if x has no rows, the number of rows of x and
+// the length of weights differ, any weight is negative, the weights sum to
+// zero, or the number of columns of x and the size of the bandwidth matrix
+// differ.
+func NewMultivariate(x mat.Matrix, weights []float64, bandwidth mat.Symmetric, src rand.Source) (*Multivariate, bool) {
+	r, c := x.Dims()
+	if r == 0 {
+		panic(noSamples)
+	}
+	if bandwidth.Symmetric() != c {
+		panic(badDimension)
+	}
+	m := &Multivariate{
+		dim: c,
+		x:   mat.DenseCopyOf(x),
+		w:   normalizedWeights(r, weights),
+		cum: make([]float64, r),
+	}
+	if !m.chol.Factorize(bandwidth) {
+		return nil, false
+	}
+	if err := m.uInv.InverseTri(m.chol.RawU()); err != nil {
+		return nil, false
+	}
+	m.z = mat.NewDense(r, c, nil)
+	m.z.Mul(m.x, &m.uInv)
+	m.logNorm = -0.5*float64(c)*math.Log(2*math.Pi) - 0.5*m.chol.LogDet()
+
+	var sum float64
+	for i, v := range m.w {
+		sum += v
+		m.cum[i] = sum
+	}
+	if src != nil {
+		m.rnd = rand.New(src)
+	}
+	return m, true
+}
+
+// Dim returns the dimension of the estimate.
+func (m *Multivariate) Dim() int {
+	return m.dim
+}
+
+// Bandwidth returns the bandwidth matrix of the estimate. If dst is nil a
+// new matrix is allocated, otherwise the result is stored in dst.
+func (m *Multivariate) Bandwidth(dst *mat.SymDense) *mat.SymDense {
+	if dst == nil {
+		dst = mat.NewSymDense(m.dim, nil)
+	}
+	m.chol.ToSym(dst)
+	return dst
+}
+
+// LogProb returns the log of the value of the density estimate at x.
+//
+// LogProb will panic if len(x) is not equal to the dimension of the estimate.
+func (m *Multivariate) LogProb(x []float64) float64 {
+	if len(x) != m.dim {
+		panic(badDimension)
+	}
+	var z mat.VecDense
+	z.MulVec(m.uInv.T(), mat.NewVecDense(m.dim, x))
+	zx := z.RawVector().Data
+
+	terms := make([]float64, 0, len(m.w))
+	for i, w := range m.w {
+		if w == 0 {
+			continue
+		}
+		d := floats.Distance(zx, m.z.RawRowView(i), 2)
+		terms = append(terms, math.Log(w)-0.5*d*d)
+	}
+	return m.logNorm + floats.LogSumExp(terms)
+}
+
+// Prob returns the value of the density estimate at x.
+//
+// Prob will panic if len(x) is not equal to the dimension of the estimate.
+func (m *Multivariate) Prob(x []float64) float64 {
+	return math.Exp(m.LogProb(x))
+}
+
+// Mean returns the mean of the estimate, the weighted mean of the sample.
+// If x is nil a new slice is allocated, otherwise the result is stored in x.
+//
+// Mean will panic if x is not nil and len(x) is not equal to the dimension
+// of the estimate.
+func (m *Multivariate) Mean(x []float64) []float64 {
+	if x == nil {
+		x = make([]float64, m.dim)
+	} else if len(x) != m.dim {
+		panic(badDimension)
+	} else {
+		for i := range x {
+			x[i] = 0
+		}
+	}
+	for i, w := range m.w {
+		floats.AddScaled(x, w, m.x.RawRowView(i))
+	}
+	return x
+}
+
+// Rand generates a random sample from the estimate. If x is nil a new
+// slice is allocated, otherwise the result is stored in x.
+//
+// Rand will panic if x is not nil and len(x) is not equal to the dimension
+// of the estimate.
+func (m *Multivariate) Rand(x []float64) []float64 {
+	if x == nil {
+		x = make([]float64, m.dim)
+	} else if len(x) != m.dim {
+		panic(badDimension)
+	}
+	eps := make([]float64, m.dim)
+	for i := range eps {
+		eps[i] = normFloat64(m.rnd)
+	}
+	// With H = Uᵀ U, Uᵀ ε has covariance H.
+	v := mat.NewVecDense(m.dim, x)
+	v.MulVec(m.chol.RawU().T(), mat.NewVecDense(m.dim, eps))
+	floats.Add(x, m.x.RawRowView(sampleIndex(m.cum, m.rnd)))
+	return x
+}
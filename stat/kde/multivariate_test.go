@@ -0,0 +1,150 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kde
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distmv"
+)
+
+var _ distmv.RandLogProber = (*Multivariate)(nil)
+
+func TestMultivariate(t *testing.T) {
+	x := mat.NewDense(5, 2, []float64{
+		0, 0,
+		1, 2,
+		-1, 0.5,
+		3, -1,
+		0.5, 0.5,
+	})
+	weights := []float64{1, 2, 0.5, 1, 3}
+	h := mat.NewSymDense(2, []float64{
+		0.5, 0.2,
+		0.2, 0.3,
+	})
+	for _, w := range [][]float64{nil, weights} {
+		m, ok := NewMultivariate(x, w, h, nil)
+		if !ok {
+			t.Fatal("unexpected failure for positive definite bandwidth")
+		}
+		if m.Dim() != 2 {
+			t.Errorf("unexpected dimension: got:%d want:2", m.Dim())
+		}
+		if got := m.Bandwidth(nil); !mat.EqualApprox(got, h, 1e-14) {
+			t.Errorf("unexpected bandwidth matrix: got:%v want:%v", mat.Formatted(got), mat.Formatted(h))
+		}
+
+		norm := normalizedWeights(5, w)
+		for _, v := range [][]float64{{0, 0}, {1, 1}, {-2, 3}, {0.5, 0.4}, {10, -10}} {
+			var want float64
+			for i := range norm {
+				n, ok := distmv.NewNormal(x.RawRowView(i), h, nil)
+				if !ok {
+					t.Fatal("unexpected failure creating normal distribution")
+				}
+				want += norm[i] * n.Prob(v)
+			}
+			if got := m.Prob(v); math.Abs(got-want) > 1e-14 {
+				t.Errorf("unexpected density at %v: got:%v want:%v", v, got, want)
+			}
+			if got := m.LogProb(v); math.Abs(got-math.Log(want)) > 1e-10 {
+				t.Errorf("unexpected log density at %v: got:%v want:%v", v, got, math.Log(want))
+			}
+		}
+
+		var mean mat.VecDense
+		mean.MulVec(x.T(), mat.NewVecDense(5, norm))
+		if got := m.Mean(nil); !floats.EqualApprox(got, mean.RawVector().Data, 1e-14) {
+			t.Errorf("unexpected mean: got:%v want:%v", got, mean.RawVector().Data)
+		}
+	}
+
+	// Zero weights exclude the corresponding samples.
+	m, _ := NewMultivariate(x, []float64{1, 0, 0, 0, 0}, h, nil)
+	n, _ := distmv.NewNormal([]float64{0, 0}, h, nil)
+	for _, v := range [][]float64{{0, 0}, {1, 1}, {-2, 3}} {
+		if got, want := m.LogProb(v), n.LogProb(v); math.Abs(got-want) > 1e-12 {
+			t.Errorf("unexpected log density with single nonzero weight at %v: got:%v want:%v", v, got, want)
+		}
+	}
+
+	if _, ok := NewMultivariate(x, nil, mat.NewSymDense(2, []float64{1, 2, 2, 1}), nil); ok {
+		t.Errorf("expected failure for indefinite bandwidth")
+	}
+	for _, bad := range []struct {
+		name string
+		x    mat.Matrix
+		w    []float64
+		h    mat.Symmetric
+	}{
+		{name: "dimension mismatch", x: x, h: mat.NewSymDense(3, nil)},
+		{name: "weights length", x: x, w: []float64{1}, h: h},
+		{name: "negative weight", x: x, w: []float64{1, 1, 1, 1, -1}, h: h},
+	} {
+		if !panics(func() { NewMultivariate(bad.x, bad.w, bad.h, nil) }) {
+			t.Errorf("expected panic for %s", bad.name)
+		}
+	}
+	m, _ = NewMultivariate(x, nil, h, nil)
+	if !panics(func() { m.LogProb([]float64{1}) }) {
+		t.Errorf("expected panic for LogProb dimension mismatch")
+	}
+	if !panics(func() { m.Rand(make([]float64, 3)) }) {
+		t.Errorf("expected panic for Rand dimension mismatch")
+	}
+}
+
+func TestMultivariateRand(t *testing.T) {
+	x := mat.NewDense(3, 2, []float64{
+		0, 0,
+		4, 1,
+		-2, 3,
+	})
+	w := []float64{1, 2, 1}
+	h := mat.NewSymDense(2, []float64{
+		1, 0.5,
+		0.5, 2,
+	})
+	m, ok := NewMultivariate(x, w, h, rand.NewSource(1))
+	if !ok {
+		t.Fatal("unexpected failure for positive definite bandwidth")
+	}
+	const n = 100000
+	samples := mat.NewDense(n, 2, nil)
+	for i := 0; i < n; i++ {
+		m.Rand(samples.RawRowView(i))
+	}
+
+	mean := m.Mean(nil)
+	for j, want := range mean {
+		got := stat.Mean(mat.Col(nil, j, samples), nil)
+		if math.Abs(got-want) > 0.03 {
+			t.Errorf("unexpected mean of samples in dimension %d: got:%v want:%v", j, got, want)
+		}
+	}
+
+	// The covariance of the estimate is the weighted population
+	// covariance of the sample plus the bandwidth matrix.
+	norm := normalizedWeights(3, w)
+	want := mat.NewSymDense(2, nil)
+	for i := range norm {
+		d := mat.NewVecDense(2, nil)
+		d.SubVec(x.RowView(i), mat.NewVecDense(2, mean))
+		want.SymRankOne(want, norm[i], d)
+	}
+	want.AddSym(want, h)
+	var got mat.SymDense
+	stat.CovarianceMatrix(&got, samples, nil)
+	if !mat.EqualApprox(&got, want, 0.1) {
+		t.Errorf("unexpected covariance of samples:\ngot: %v\nwant:%v", mat.Formatted(&got), mat.Formatted(want))
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kde
+
+import (
+	"math"
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/fourier"
+)
+
+const (
+	badBandwidth   = "kde: bandwidth not positive"
+	badGrid        = "kde: bad grid"
+	badWeights     = "kde: weights length mismatch"
+	negativeWeight = "kde: negative weight"
+	noSamples      = "kde: no samples"
+	tooFewSamples  = "kde: too few samples"
+	zeroWeights    = "kde: weights sum to zero"
+)
+
+// unboundedCutoff is the number of bandwidths beyond which the contribution
+// of a kernel with unbounded support is neglected when evaluating an estimate
+// on a grid.
+const unboundedCutoff = 8
+
+// Univariate is a univariate kernel density estimate
+//  f(x) = Σ_i w_i K((x - x_i)/h) / h
+// for a sample x_i with normalized weights w_i, a kernel K and bandwidth h.
+type Univariate struct {
+	// x holds the sample in increasing order, and w and
+	// cum hold the corresponding normalized weights and
+	// their cumulative sums.
+	x   []float64
+	w   []float64
+	cum []float64
+
+	kernel Kernel
+	h      float64
+
+	rnd *rand.Rand
+}
+
+// NewUnivariate returns a kernel density estimate for the sample x with the
+// given kernel and bandwidth. If weights is nil the sample is unweighted,
+// otherwise weights holds the non-negative weights of the elements of x.
+// If src is nil the global random source is used by Rand. The values in x
+// and weights are copied.
+//
+// NewUnivariate will panic if x is empty, the lengths of x and weights
+// differ, any weight is negative, the weights sum to zero, or the bandwidth
+// is not positive.
+func NewUnivariate(x, weights []float64, kernel Kernel, bandwidth float64, src rand.Source) *Univariate {
+	if len(x) == 0 {
+		panic(noSamples)
+	}
+	if !(bandwidth > 0) {
+		panic(badBandwidth)
+	}
+	w := normalizedWeights(len(x), weights)
+	u := &Univariate{
+		x:      make([]float64, len(x)),
+		w:      make([]float64, len(x)),
+		cum:    make([]float64, len(x)),
+		kernel: kernel,
+		h:      bandwidth,
+	}
+	var sum float64
+	for i, j := range sortedIndex(x) {
+		u.x[i] = x[j]
+		u.w[i] = w[j]
+		sum += w[j]
+		u.cum[i] = sum
+	}
+	if src != nil {
+		u.rnd = rand.New(src)
+	}
+	return u
+}
+
+// Bandwidth returns the bandwidth of the estimate.
+func (u *Univariate) Bandwidth() float64 {
+	return u.h
+}
+
+// Kernel returns the kernel of the estimate.
+func (u *Univariate) Kernel() Kernel {
+	return u.kernel
+}
+
+// window returns the range of indices of the sample points whose kernels
+// have support containing x.
+func (u *Univariate) window(x float64) (lo, hi int) {
+	a := u.kernel.Support() * u.h
+	if math.IsInf(a, 1) {
+		return 0, len(u.x)
+	}
+	lo = sort.SearchFloat64s(u.x, x-a)
+	hi = lo + sort.SearchFloat64s(u.x[lo:], math.Nextafter(x+a, math.Inf(1)))
+	return lo, hi
+}
+
+// Prob returns the value of the density estimate at x.
+func (u *Univariate) Prob(x float64) float64 {
+	lo, hi := u.window(x)
+	var p float64
+	for i, v := range u.x[lo:hi] {
+		p += u.w[lo+i] * u.kernel.Prob((x-v)/u.h)
+	}
+	return p / u.h
+}
+
+// LogProb returns the log of the value of the density estimate at x.
+func (u *Univariate) LogProb(x float64) float64 {
+	return math.Log(u.Prob(x))
+}
+
+// CDF returns the value of the cumulative distribution function of the
+// estimate at x.
+func (u *Univariate) CDF(x float64) float64 {
+	lo, hi := u.window(x)
+	var p float64
+	if lo > 0 {
+		// The kernels of all points below the window lie
+		// entirely below x.
+		p = u.cum[lo-1]
+	}
+	for i, v := range u.x[lo:hi] {
+		p += u.w[lo+i] * u.kernel.CDF((x-v)/u.h)
+	}
+	return math.Min(p, 1)
+}
+
+// Mean returns the mean of the estimate, the weighted mean of the sample.
+func (u *Univariate) Mean() float64 {
+	var m float64
+	for i, v := range u.x {
+		m += u.w[i] * v
+	}
+	return m
+}
+
+// Variance returns the variance of the estimate, the weighted population
+// variance of the sample plus the square of the bandwidth.
+func (u *Univariate) Variance() float64 {
+	m := u.Mean()
+	var v float64
+	for i, x := range u.x {
+		d := x - m
+		v += u.w[i] * d * d
+	}
+	return v + u.h*u.h
+}
+
+// Rand returns a random sample drawn from the estimate.
+func (u *Univariate) Rand() float64 {
+	i := sampleIndex(u.cum, u.rnd)
+	return u.x[i] + u.h*kernelRand(u.kernel, u.rnd)
+}
+
+// ProbGrid evaluates the density estimate at len(dst) equally spaced points
+// from min to max inclusive, storing the values in dst and returning it.
+//
+// ProbGrid approximates the estimate by linear binning of the sample onto
+// the grid and computes the values by fast Fourier transform convolution of
+// the binned sample with the kernel. This takes O(n + m log m) time for n
+// samples and m grid points, compared with O(n m) for repeated calls to
+// Prob. The approximation is accurate when the grid spacing is small
+// compared to the bandwidth. Sample points further than the support of the
+// kernel from the grid, or eight bandwidths for kernels with unbounded support,
+// do not contribute to the values.
+//
+// ProbGrid will panic if len(dst) < 2 or min >= max.
+func (u *Univariate) ProbGrid(dst []float64, min, max float64) []float64 {
+	n := len(dst)
+	if n < 2 || !(min < max) {
+		panic(badGrid)
+	}
+	delta := (max - min) / float64(n-1)
+	cut := u.kernel.Support()
+	if math.IsInf(cut, 1) {
+		cut = unboundedCutoff
+	}
+	// Extend the grid by the reach of the kernel on each side.
+	ext := int(math.Ceil(cut * u.h / delta))
+	m := n + 2*ext
+
+	// The length of the transform must exceed the extent of the
+	// linear convolution of the output grid to avoid wrap around.
+	size := 1
+	for size < m+1 {
+		size *= 2
+	}
+
+	// Linearly bin the sample onto the extended grid.
+	bins := make([]float64, size)
+	for i, v := range u.x {
+		pos := (v-min)/delta + float64(ext)
+		if pos < 0 || pos > float64(m-1) {
+			continue
+		}
+		j := int(pos)
+		f := pos - float64(j)
+		bins[j] += u.w[i] * (1 - f)
+		if f > 0 {
+			bins[j+1] += u.w[i] * f
+		}
+	}
+
+	// Evaluate the kernel at the grid offsets, with negative
+	// offsets wrapped to the end of the sequence.
+	kern := make([]float64, size)
+	for s := 0; s <= ext; s++ {
+		v := u.kernel.Prob(float64(s)*delta/u.h) / u.h
+		kern[s] = v
+		if s > 0 {
+			kern[size-s] = v
+		}
+	}
+
+	fft := fourier.NewFFT(size)
+	cb := fft.Coefficients(nil, bins)
+	ck := fft.Coefficients(nil, kern)
+	for i := range cb {
+		cb[i] *= ck[i]
+	}
+	conv := fft.Sequence(bins, cb)
+	for i := range dst {
+		// The transform is unnormalized, and rounding
+		// may give small negative values.
+		dst[i] = math.Max(0, conv[i+ext]/float64(size))
+	}
+	return dst
+}
+
+// sampleIndex returns an index drawn with probabilities given by the
+// cumulative weights cum.
+func sampleIndex(cum []float64, rnd *rand.Rand) int {
+	r := float64Unit(rnd) * cum[len(cum)-1]
+	i := sort.Search(len(cum), func(i int) bool { return cum[i] > r })
+	if i == len(cum) {
+		i--
+	}
+	return i
+}
@@ -0,0 +1,169 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kde
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/integrate/quad"
+	"gonum.org/v1/gonum/stat"
+)
+
+func TestUnivariate(t *testing.T) {
+	x := []float64{3.1, -0.5, 1.2, 1.2, 7, 2.4, 0.3}
+	weights := []float64{1, 2, 0.5, 1.5, 0.2, 3, 1}
+	const h = 0.8
+	for _, test := range kernels {
+		for _, w := range [][]float64{nil, weights} {
+			u := NewUnivariate(x, w, test.k, h, nil)
+			norm := normalizedWeights(len(x), w)
+
+			for _, v := range []float64{-3, -0.5, 0, 1.1, 1.2, 2.9, 5, 7.5, 12} {
+				var want float64
+				for i, xi := range x {
+					want += norm[i] * test.k.Prob((v-xi)/h) / h
+				}
+				if got := u.Prob(v); math.Abs(got-want) > 1e-14 {
+					t.Errorf("unexpected density of %s estimate at %v: got:%v want:%v", test.name, v, got, want)
+				}
+				if got := u.LogProb(v); math.Abs(got-math.Log(want)) > 1e-12 && !(math.IsInf(got, -1) && want == 0) {
+					t.Errorf("unexpected log density of %s estimate at %v: got:%v want:%v", test.name, v, got, math.Log(want))
+				}
+
+				want = 0
+				for i, xi := range x {
+					want += norm[i] * test.k.CDF((v-xi)/h)
+				}
+				if got := u.CDF(v); math.Abs(got-want) > 1e-14 {
+					t.Errorf("unexpected CDF of %s estimate at %v: got:%v want:%v", test.name, v, got, want)
+				}
+			}
+
+			// The quadrature converges slowly for the
+			// discontinuous uniform kernel.
+			tol := 1e-4
+			if test.name == "Uniform" {
+				tol = 1e-2
+			}
+			mass := quad.Fixed(u.Prob, -40, 50, 10000, nil, 0)
+			if math.Abs(mass-1) > tol {
+				t.Errorf("unexpected integral of %s estimate: got:%v want:1", test.name, mass)
+			}
+
+			mean := stat.Mean(x, w)
+			if got := u.Mean(); math.Abs(got-mean) > 1e-14 {
+				t.Errorf("unexpected mean of %s estimate: got:%v want:%v", test.name, got, mean)
+			}
+			variance := quad.Fixed(func(v float64) float64 { return (v - mean) * (v - mean) * u.Prob(v) }, -40, 50, 10000, nil, 0)
+			if got := u.Variance(); math.Abs(got-variance) > 10*tol*variance {
+				t.Errorf("unexpected variance of %s estimate: got:%v want:%v", test.name, got, variance)
+			}
+		}
+	}
+
+	for _, bad := range []struct {
+		name string
+		x, w []float64
+		h    float64
+	}{
+		{name: "no samples", h: 1},
+		{name: "zero bandwidth", x: x, h: 0},
+		{name: "NaN bandwidth", x: x, h: math.NaN()},
+		{name: "weights length", x: x, w: []float64{1}, h: 1},
+		{name: "negative weight", x: []float64{1, 2}, w: []float64{1, -1}, h: 1},
+		{name: "zero weights", x: []float64{1, 2}, w: []float64{0, 0}, h: 1},
+	} {
+		if !panics(func() { NewUnivariate(bad.x, bad.w, Gaussian{}, bad.h, nil) }) {
+			t.Errorf("expected panic for %s", bad.name)
+		}
+	}
+}
+
+func TestUnivariateRand(t *testing.T) {
+	x := []float64{-2, 0, 1, 5}
+	w := []float64{1, 3, 2, 4}
+	for _, test := range kernels {
+		u := NewUnivariate(x, w, test.k, 0.5, rand.NewSource(1))
+		const n = 100000
+		samples := make([]float64, n)
+		for i := range samples {
+			samples[i] = u.Rand()
+		}
+		mean, variance := stat.MeanVariance(samples, nil)
+		if math.Abs(mean-u.Mean()) > 0.05 {
+			t.Errorf("unexpected mean of %s estimate samples: got:%v want:%v", test.name, mean, u.Mean())
+		}
+		if math.Abs(variance-u.Variance()) > 0.05*u.Variance() {
+			t.Errorf("unexpected variance of %s estimate samples: got:%v want:%v", test.name, variance, u.Variance())
+		}
+		floats.Argsort(samples, make([]int, n))
+		for _, p := range []float64{0.1, 0.5, 0.9} {
+			q := stat.Quantile(p, stat.Empirical, samples, nil)
+			if got := u.CDF(q); math.Abs(got-p) > 0.01 {
+				t.Errorf("unexpected CDF of %s estimate at empirical %v quantile: got:%v", test.name, p, got)
+			}
+		}
+	}
+}
+
+func TestUnivariateProbGrid(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	x := make([]float64, 500)
+	for i := range x {
+		x[i] = rnd.NormFloat64()
+		if i%3 == 0 {
+			x[i] = 4 + 0.5*rnd.NormFloat64()
+		}
+	}
+	h := Silverman(x, nil)
+	for _, test := range kernels {
+		u := NewUnivariate(x, nil, test.k, h, nil)
+		for _, grid := range []struct {
+			n        int
+			min, max float64
+		}{
+			{n: 512, min: -5, max: 8},
+			{n: 1000, min: -1, max: 3},
+			{n: 300, min: 2, max: 7},
+		} {
+			got := u.ProbGrid(make([]float64, grid.n), grid.min, grid.max)
+			delta := (grid.max - grid.min) / float64(grid.n-1)
+			var maxErr, peak float64
+			for i, v := range got {
+				want := u.Prob(grid.min + float64(i)*delta)
+				maxErr = math.Max(maxErr, math.Abs(v-want))
+				peak = math.Max(peak, want)
+			}
+			// Linear binning has error of order (δ/h)^2 relative
+			// to the density, except near the discontinuities of
+			// the uniform kernel.
+			tol := 0.01
+			if test.name == "Uniform" {
+				tol = 0.05
+			}
+			if maxErr > tol*peak {
+				t.Errorf("unexpected error of %s estimate on grid %+v: got:%v peak:%v", test.name, grid, maxErr, peak)
+			}
+		}
+	}
+
+	u := NewUnivariate(x, nil, Gaussian{}, h, nil)
+	for _, bad := range []struct {
+		n        int
+		min, max float64
+	}{
+		{n: 1, min: 0, max: 1},
+		{n: 10, min: 1, max: 1},
+		{n: 10, min: 2, max: 1},
+	} {
+		if !panics(func() { u.ProbGrid(make([]float64, bad.n), bad.min, bad.max) }) {
+			t.Errorf("expected panic for grid %+v", bad)
+		}
+	}
+}
@@ -0,0 +1,425 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// onlineVersion is the version of the binary representation of the online
+// accumulators.
+const onlineVersion = 1
+
+// Moments accumulates the weighted mean, variance, skewness, excess kurtosis
+// and extrema of a sample one observation at a time, using the updates of
+// Welford and their generalization to higher moments and weighted data by
+// Pébay (2008). Accumulators of parts of a sample may be merged to give the
+// moments of the whole, so a sample may be accumulated concurrently with
+// one Moments per goroutine.
+//
+// The statistics returned by Moments match those computed by Mean, Variance,
+// Skew and ExKurtosis for the same observations and weights to within
+// rounding error. The zero value of Moments is an empty accumulator ready
+// to use. Moments is not safe for concurrent use.
+type Moments struct {
+	// m2, m3 and m4 are the weighted sums of the second,
+	// third and fourth powers of the deviations from the
+	// mean.
+	sumWeights float64
+	mean       float64
+	m2, m3, m4 float64
+	min, max   float64
+	count      uint64
+}
+
+// Add adds the observation x with the given weight to the accumulator.
+// Observations with zero weight are ignored. Add will panic if weight is
+// negative.
+func (m *Moments) Add(x, weight float64) {
+	if weight < 0 {
+		panic("stat: negative weight")
+	}
+	if weight == 0 {
+		return
+	}
+	m.merge(Moments{sumWeights: weight, mean: x, min: x, max: x, count: 1})
+}
+
+// Merge adds the observations accumulated by a to the receiver.
+func (m *Moments) Merge(a *Moments) {
+	if a.count == 0 {
+		return
+	}
+	m.merge(*a)
+}
+
+// merge combines the moments of b with those of the receiver.
+func (m *Moments) merge(b Moments) {
+	if m.count == 0 {
+		*m = b
+		return
+	}
+	na, nb := m.sumWeights, b.sumWeights
+	n := na + nb
+	d := b.mean - m.mean
+	dn := d / n
+	m2 := m.m2 + b.m2 + d*dn*na*nb
+	m3 := m.m3 + b.m3 + d*dn*dn*na*nb*(na-nb) + 3*dn*(na*b.m2-nb*m.m2)
+	m4 := m.m4 + b.m4 + d*dn*dn*dn*na*nb*(na*na-na*nb+nb*nb) +
+		6*dn*dn*(na*na*b.m2+nb*nb*m.m2) + 4*dn*(na*b.m3-nb*m.m3)
+
+	m.mean += dn * nb
+	m.m2, m.m3, m.m4 = m2, m3, m4
+	m.sumWeights = n
+	m.min = math.Min(m.min, b.min)
+	m.max = math.Max(m.max, b.max)
+	m.count += b.count
+}
+
+// Reset empties the accumulator.
+func (m *Moments) Reset() {
+	*m = Moments{}
+}
+
+// Count returns the number of observations with non-zero weight added to
+// the accumulator.
+func (m *Moments) Count() uint64 {
+	return m.count
+}
+
+// SumWeights returns the sum of the weights of the observations.
+func (m *Moments) SumWeights() float64 {
+	return m.sumWeights
+}
+
+// Mean returns the weighted mean of the observations. Mean returns NaN if
+// the accumulator is empty.
+func (m *Moments) Mean() float64 {
+	if m.count == 0 {
+		return math.NaN()
+	}
+	return m.mean
+}
+
+// Variance returns the unbiased weighted sample variance of the
+// observations as computed by Variance.
+func (m *Moments) Variance() float64 {
+	if m.count == 0 {
+		return math.NaN()
+	}
+	return m.m2 / (m.sumWeights - 1)
+}
+
+// StdDev returns the sample standard deviation of the observations.
+func (m *Moments) StdDev() float64 {
+	return math.Sqrt(m.Variance())
+}
+
+// Skew returns the skewness of the observations as computed by Skew.
+func (m *Moments) Skew() float64 {
+	std := m.StdDev()
+	return m.m3 / (std * std * std) * skewCorrection(m.sumWeights)
+}
+
+// ExKurtosis returns the population excess kurtosis of the observations as
+// computed by ExKurtosis.
+func (m *Moments) ExKurtosis() float64 {
+	v := m.Variance()
+	mul, offset := kurtosisCorrection(m.sumWeights)
+	return m.m4/(v*v)*mul - offset
+}
+
+// Min returns the smallest observation. Min returns NaN if the accumulator
+// is empty.
+func (m *Moments) Min() float64 {
+	if m.count == 0 {
+		return math.NaN()
+	}
+	return m.min
+}
+
+// Max returns the largest observation. Max returns NaN if the accumulator
+// is empty.
+func (m *Moments) Max() float64 {
+	if m.count == 0 {
+		return math.NaN()
+	}
+	return m.max
+}
+
+// MarshalBinary returns the binary representation of the accumulator.
+func (m *Moments) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	for _, v := range []interface{}{
+		uint8(onlineVersion),
+		m.count,
+		[]float64{m.sumWeights, m.mean, m.m2, m.m3, m.m4, m.min, m.max},
+	} {
+		err := enc.Encode(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary unmarshals the binary representation of an accumulator
+// into the receiver.
+func (m *Moments) UnmarshalBinary(b []byte) error {
+	dec := gob.NewDecoder(bytes.NewReader(b))
+	err := decodeOnlineVersion(dec)
+	if err != nil {
+		return err
+	}
+	var count uint64
+	err = dec.Decode(&count)
+	if err != nil {
+		return err
+	}
+	var v []float64
+	err = dec.Decode(&v)
+	if err != nil {
+		return err
+	}
+	if len(v) != 7 {
+		return errors.New("stat: malformed moments data")
+	}
+	*m = Moments{
+		count:      count,
+		sumWeights: v[0],
+		mean:       v[1],
+		m2:         v[2],
+		m3:         v[3],
+		m4:         v[4],
+		min:        v[5],
+		max:        v[6],
+	}
+	return nil
+}
+
+// decodeOnlineVersion decodes and checks the version of the binary
+// representation of an online accumulator.
+func decodeOnlineVersion(dec *gob.Decoder) error {
+	var version uint8
+	err := dec.Decode(&version)
+	if err != nil {
+		return err
+	}
+	if version != onlineVersion {
+		return fmt.Errorf("stat: unsupported accumulator version: %d", version)
+	}
+	return nil
+}
+
+// CovarianceMoments accumulates the weighted mean vector and covariance
+// matrix of a multivariate sample one observation at a time. Accumulators of
+// parts of a sample may be merged to give the covariance of the whole.
+//
+// The covariance matrix returned by CovarianceMoments matches that computed
+// by CovarianceMatrix for the same observations and weights to within
+// rounding error. CovarianceMoments is not safe for concurrent use.
+type CovarianceMoments struct {
+	dim        int
+	sumWeights float64
+	count      uint64
+	mean       []float64
+
+	// comoment holds the weighted sums of the products of
+	// the deviations from the mean.
+	comoment *mat.SymDense
+
+	// diff is working storage.
+	diff []float64
+}
+
+// NewCovarianceMoments returns an empty accumulator for observations of the
+// given dimension. NewCovarianceMoments will panic if dim is not positive.
+func NewCovarianceMoments(dim int) *CovarianceMoments {
+	if dim <= 0 {
+		panic("stat: non-positive dimension")
+	}
+	return &CovarianceMoments{
+		dim:      dim,
+		mean:     make([]float64, dim),
+		comoment: mat.NewSymDense(dim, nil),
+		diff:     make([]float64, dim),
+	}
+}
+
+// Dim returns the dimension of the observations of the accumulator.
+func (c *CovarianceMoments) Dim() int {
+	return c.dim
+}
+
+// Add adds the observation x with the given weight to the accumulator.
+// Observations with zero weight are ignored. Add will panic if the length
+// of x is not the dimension of the accumulator or weight is negative.
+func (c *CovarianceMoments) Add(x []float64, weight float64) {
+	if len(x) != c.dim {
+		panic("stat: slice length mismatch")
+	}
+	if weight < 0 {
+		panic("stat: negative weight")
+	}
+	if weight == 0 {
+		return
+	}
+	n := c.sumWeights + weight
+	for i, v := range x {
+		c.diff[i] = v - c.mean[i]
+		c.mean[i] += c.diff[i] * weight / n
+	}
+	c.comoment.SymRankOne(c.comoment, weight*c.sumWeights/n, mat.NewVecDense(c.dim, c.diff))
+	c.sumWeights = n
+	c.count++
+}
+
+// Merge adds the observations accumulated by a to the receiver. Merge will
+// panic if the dimensions of the accumulators differ.
+func (c *CovarianceMoments) Merge(a *CovarianceMoments) {
+	if a.dim != c.dim {
+		panic(mat.ErrShape)
+	}
+	if a.count == 0 {
+		return
+	}
+	na, nb := c.sumWeights, a.sumWeights
+	n := na + nb
+	for i, v := range a.mean {
+		c.diff[i] = v - c.mean[i]
+		c.mean[i] += c.diff[i] * nb / n
+	}
+	c.comoment.AddSym(c.comoment, a.comoment)
+	c.comoment.SymRankOne(c.comoment, na*nb/n, mat.NewVecDense(c.dim, c.diff))
+	c.sumWeights = n
+	c.count += a.count
+}
+
+// Reset empties the accumulator.
+func (c *CovarianceMoments) Reset() {
+	c.sumWeights = 0
+	c.count = 0
+	for i := range c.mean {
+		c.mean[i] = 0
+	}
+	c.comoment.Zero()
+}
+
+// Count returns the number of observations with non-zero weight added to
+// the accumulator.
+func (c *CovarianceMoments) Count() uint64 {
+	return c.count
+}
+
+// SumWeights returns the sum of the weights of the observations.
+func (c *CovarianceMoments) SumWeights() float64 {
+	return c.sumWeights
+}
+
+// MeanTo returns the weighted mean of the observations. If dst is not nil
+// the mean is stored in dst, which must have length equal to the dimension
+// of the accumulator.
+func (c *CovarianceMoments) MeanTo(dst []float64) []float64 {
+	if dst == nil {
+		dst = make([]float64, c.dim)
+	} else if len(dst) != c.dim {
+		panic("stat: slice length mismatch")
+	}
+	copy(dst, c.mean)
+	return dst
+}
+
+// CovarianceMatrixTo stores the unbiased weighted sample covariance matrix
+// of the observations, as computed by CovarianceMatrix, in dst. If dst is
+// empty it is resized to the dimension of the accumulator, otherwise
+// CovarianceMatrixTo will panic if dst is not of that size.
+func (c *CovarianceMoments) CovarianceMatrixTo(dst *mat.SymDense) {
+	if dst.IsEmpty() {
+		dst.ReuseAsSym(c.dim)
+	} else if dst.Symmetric() != c.dim {
+		panic(mat.ErrShape)
+	}
+	dst.ScaleSym(1/(c.sumWeights-1), c.comoment)
+}
+
+// CorrelationMatrixTo stores the weighted sample correlation matrix of the
+// observations, as computed by CorrelationMatrix, in dst. If dst is empty
+// it is resized to the dimension of the accumulator, otherwise
+// CorrelationMatrixTo will panic if dst is not of that size.
+func (c *CovarianceMoments) CorrelationMatrixTo(dst *mat.SymDense) {
+	c.CovarianceMatrixTo(dst)
+	covToCorr(dst)
+}
+
+// MarshalBinary returns the binary representation of the accumulator.
+func (c *CovarianceMoments) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	comoment := make([]float64, 0, c.dim*(c.dim+1)/2)
+	for i := 0; i < c.dim; i++ {
+		for j := i; j < c.dim; j++ {
+			comoment = append(comoment, c.comoment.At(i, j))
+		}
+	}
+	for _, v := range []interface{}{
+		uint8(onlineVersion),
+		c.dim,
+		c.count,
+		c.sumWeights,
+		c.mean,
+		comoment,
+	} {
+		err := enc.Encode(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary unmarshals the binary representation of an accumulator
+// into the receiver. The dimension of the receiver is set by the data.
+func (c *CovarianceMoments) UnmarshalBinary(b []byte) error {
+	dec := gob.NewDecoder(bytes.NewReader(b))
+	err := decodeOnlineVersion(dec)
+	if err != nil {
+		return err
+	}
+	var (
+		dim        int
+		count      uint64
+		sumWeights float64
+		mean       []float64
+		comoment   []float64
+	)
+	for _, v := range []interface{}{&dim, &count, &sumWeights, &mean, &comoment} {
+		err = dec.Decode(v)
+		if err != nil {
+			return err
+		}
+	}
+	if dim <= 0 || len(mean) != dim || len(comoment) != dim*(dim+1)/2 {
+		return errors.New("stat: malformed covariance moments data")
+	}
+	*c = *NewCovarianceMoments(dim)
+	c.count = count
+	c.sumWeights = sumWeights
+	copy(c.mean, mean)
+	k := 0
+	for i := 0; i < dim; i++ {
+		for j := i; j < dim; j++ {
+			c.comoment.SetSym(i, j, comoment[k])
+			k++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat_test
+
+import (
+	"fmt"
+	"sync"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+func ExampleMoments() {
+	data := [][]float64{
+		{8.2, -3.1, 4.5, 0.7},
+		{6.6, 2.8, -1.9},
+		{5.3, 9.4, 1.2, 3.3, -0.4},
+	}
+
+	// Accumulate the moments of each part of the data in its own
+	// goroutine and merge the results.
+	parts := make([]stat.Moments, len(data))
+	var wg sync.WaitGroup
+	for i, x := range data {
+		wg.Add(1)
+		go func(m *stat.Moments, x []float64) {
+			defer wg.Done()
+			for _, v := range x {
+				m.Add(v, 1)
+			}
+		}(&parts[i], x)
+	}
+	wg.Wait()
+	var m stat.Moments
+	for i := range parts {
+		m.Merge(&parts[i])
+	}
+
+	var all []float64
+	for _, x := range data {
+		all = append(all, x...)
+	}
+	fmt.Printf("mean:     %.4f %.4f\n", m.Mean(), stat.Mean(all, nil))
+	fmt.Printf("variance: %.4f %.4f\n", m.Variance(), stat.Variance(all, nil))
+	fmt.Printf("skew:     %.4f %.4f\n", m.Skew(), stat.Skew(all, nil))
+	fmt.Printf("range:    [%.1f, %.1f]\n", m.Min(), m.Max())
+
+	// Output:
+	// mean:     3.0500 3.0500
+	// variance: 15.4464 15.4464
+	// skew:     0.0675 0.0675
+	// range:    [-3.1, 9.4]
+}
@@ -0,0 +1,260 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestMoments(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n        int
+		weighted bool
+		loc      float64
+	}{
+		{n: 10},
+		{n: 100, weighted: true},
+		{n: 1000, loc: 1e4},
+		{n: 1000, weighted: true, loc: -1e4},
+	} {
+		x := make([]float64, test.n)
+		var w []float64
+		if test.weighted {
+			w = make([]float64, test.n)
+		}
+		for i := range x {
+			x[i] = test.loc + rnd.ExpFloat64()
+			if w != nil {
+				w[i] = 10 * rnd.Float64()
+			}
+		}
+
+		var m Moments
+		for i, v := range x {
+			m.Add(v, weight(w, i))
+		}
+		checkMoments(t, "sequential", &m, x, w)
+
+		// Merge accumulators of contiguous parts of the sample.
+		var merged Moments
+		for lo := 0; lo < len(x); {
+			hi := lo + 1 + rnd.Intn(len(x)/3)
+			if hi > len(x) {
+				hi = len(x)
+			}
+			var part Moments
+			for i := lo; i < hi; i++ {
+				part.Add(x[i], weight(w, i))
+			}
+			merged.Merge(&part)
+			lo = hi
+		}
+		checkMoments(t, "merged", &merged, x, w)
+
+		b, err := m.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error marshaling moments: %v", err)
+		}
+		var got Moments
+		err = got.UnmarshalBinary(b)
+		if err != nil {
+			t.Fatalf("unexpected error unmarshaling moments: %v", err)
+		}
+		if got != m {
+			t.Errorf("unexpected unmarshaled moments: got:%+v want:%+v", got, m)
+		}
+	}
+}
+
+func weight(w []float64, i int) float64 {
+	if w == nil {
+		return 1
+	}
+	return w[i]
+}
+
+func checkMoments(t *testing.T, name string, m *Moments, x, w []float64) {
+	const tol = 1e-10
+	if m.Count() != uint64(len(x)) {
+		t.Errorf("unexpected %s count: got:%d want:%d", name, m.Count(), len(x))
+	}
+	for _, test := range []struct {
+		stat      string
+		got, want float64
+	}{
+		{stat: "sum of weights", got: m.SumWeights(), want: sumWeights(w, len(x))},
+		{stat: "mean", got: m.Mean(), want: Mean(x, w)},
+		{stat: "variance", got: m.Variance(), want: Variance(x, w)},
+		{stat: "standard deviation", got: m.StdDev(), want: StdDev(x, w)},
+		{stat: "skew", got: m.Skew(), want: Skew(x, w)},
+		{stat: "excess kurtosis", got: m.ExKurtosis(), want: ExKurtosis(x, w)},
+		{stat: "min", got: m.Min(), want: floats.Min(x)},
+		{stat: "max", got: m.Max(), want: floats.Max(x)},
+	} {
+		if !floats.EqualWithinAbsOrRel(test.got, test.want, tol, tol) {
+			t.Errorf("unexpected %s %s for n=%d: got:%v want:%v", name, test.stat, len(x), test.got, test.want)
+		}
+	}
+}
+
+func sumWeights(w []float64, n int) float64 {
+	if w == nil {
+		return float64(n)
+	}
+	return floats.Sum(w)
+}
+
+func TestMomentsEmpty(t *testing.T) {
+	var m Moments
+	m.Add(1, 0)
+	if m.Count() != 0 {
+		t.Errorf("zero weight observation added")
+	}
+	for _, v := range []float64{m.Mean(), m.Variance(), m.Min(), m.Max()} {
+		if !math.IsNaN(v) {
+			t.Errorf("unexpected statistic of empty accumulator: got:%v want:NaN", v)
+		}
+	}
+
+	var a Moments
+	a.Add(3, 2)
+	m.Merge(&a)
+	a.Merge(&Moments{})
+	if m != a {
+		t.Errorf("unexpected merge with empty accumulator: got:%+v want:%+v", m, a)
+	}
+	m.Reset()
+	if m != (Moments{}) {
+		t.Errorf("accumulator not empty after reset: %+v", m)
+	}
+
+	if !panics(func() { m.Add(1, -1) }) {
+		t.Errorf("expected panic for negative weight")
+	}
+	if err := m.UnmarshalBinary([]byte("invalid")); err == nil {
+		t.Errorf("expected error unmarshaling invalid data")
+	}
+}
+
+func TestCovarianceMoments(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		r, c     int
+		weighted bool
+		loc      float64
+	}{
+		{r: 10, c: 1},
+		{r: 50, c: 3, weighted: true},
+		{r: 200, c: 5, loc: 1e4},
+		{r: 200, c: 4, weighted: true, loc: -1e3},
+	} {
+		x := mat.NewDense(test.r, test.c, nil)
+		var w []float64
+		if test.weighted {
+			w = make([]float64, test.r)
+		}
+		for i := 0; i < test.r; i++ {
+			for j := 0; j < test.c; j++ {
+				// Correlate each variable with the previous one.
+				v := test.loc + rnd.NormFloat64()
+				if j > 0 {
+					v += x.At(i, j-1) - test.loc
+				}
+				x.Set(i, j, v)
+			}
+			if w != nil {
+				w[i] = 10 * rnd.Float64()
+			}
+		}
+		var wantCov, wantCorr mat.SymDense
+		CovarianceMatrix(&wantCov, x, w)
+		CorrelationMatrix(&wantCorr, x, w)
+		wantMean := make([]float64, test.c)
+		for j := range wantMean {
+			wantMean[j] = Mean(mat.Col(nil, j, x), w)
+		}
+
+		c := NewCovarianceMoments(test.c)
+		for i := 0; i < test.r; i++ {
+			c.Add(x.RawRowView(i), weight(w, i))
+		}
+		checkCovarianceMoments(t, "sequential", c, wantMean, &wantCov, &wantCorr, sumWeights(w, test.r))
+
+		merged := NewCovarianceMoments(test.c)
+		part := NewCovarianceMoments(test.c)
+		for lo := 0; lo < test.r; {
+			hi := lo + 1 + rnd.Intn(test.r/3)
+			if hi > test.r {
+				hi = test.r
+			}
+			part.Reset()
+			for i := lo; i < hi; i++ {
+				part.Add(x.RawRowView(i), weight(w, i))
+			}
+			merged.Merge(part)
+			lo = hi
+		}
+		checkCovarianceMoments(t, "merged", merged, wantMean, &wantCov, &wantCorr, sumWeights(w, test.r))
+
+		b, err := c.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error marshaling covariance moments: %v", err)
+		}
+		var got CovarianceMoments
+		err = got.UnmarshalBinary(b)
+		if err != nil {
+			t.Fatalf("unexpected error unmarshaling covariance moments: %v", err)
+		}
+		if got.Dim() != c.Dim() || got.Count() != c.Count() || got.SumWeights() != c.SumWeights() ||
+			!floats.Equal(got.mean, c.mean) || !mat.Equal(got.comoment, c.comoment) {
+			t.Errorf("unexpected unmarshaled covariance moments")
+		}
+	}
+}
+
+func checkCovarianceMoments(t *testing.T, name string, c *CovarianceMoments, mean []float64, cov, corr *mat.SymDense, sumWeights float64) {
+	const tol = 1e-10
+	if !floats.EqualWithinAbsOrRel(c.SumWeights(), sumWeights, tol, tol) {
+		t.Errorf("unexpected %s sum of weights: got:%v want:%v", name, c.SumWeights(), sumWeights)
+	}
+	if got := c.MeanTo(nil); !floats.EqualApprox(got, mean, tol) {
+		t.Errorf("unexpected %s mean: got:%v want:%v", name, got, mean)
+	}
+	var got mat.SymDense
+	c.CovarianceMatrixTo(&got)
+	if !mat.EqualApprox(&got, cov, tol) {
+		t.Errorf("unexpected %s covariance matrix:\ngot:\n%v\nwant:\n%v", name, mat.Formatted(&got), mat.Formatted(cov))
+	}
+	c.CorrelationMatrixTo(&got)
+	if !mat.EqualApprox(&got, corr, tol) {
+		t.Errorf("unexpected %s correlation matrix:\ngot:\n%v\nwant:\n%v", name, mat.Formatted(&got), mat.Formatted(corr))
+	}
+}
+
+func TestCovarianceMomentsPanics(t *testing.T) {
+	c := NewCovarianceMoments(2)
+	for _, test := range []struct {
+		name string
+		fn   func()
+	}{
+		{name: "non-positive dimension", fn: func() { NewCovarianceMoments(0) }},
+		{name: "length mismatch", fn: func() { c.Add([]float64{1}, 1) }},
+		{name: "negative weight", fn: func() { c.Add([]float64{1, 2}, -1) }},
+		{name: "merge dimension mismatch", fn: func() { c.Merge(NewCovarianceMoments(3)) }},
+		{name: "mean length mismatch", fn: func() { c.MeanTo(make([]float64, 3)) }},
+		{name: "covariance size mismatch", fn: func() { c.CovarianceMatrixTo(mat.NewSymDense(3, nil)) }},
+	} {
+		if !panics(test.fn) {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package quantile provides streaming quantile estimation sketches.
+//
+// The sketches summarize a stream of observations in space much smaller
+// than the stream itself and answer approximate quantile and cumulative
+// distribution queries. Sketches of parts of a stream may be merged to give
+// a sketch of the whole, so a stream may be summarized concurrently.
+package quantile // import "gonum.org/v1/gonum/stat/quantile"
@@ -0,0 +1,63 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quantile_test
+
+import (
+	"fmt"
+	"log"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/stat/quantile"
+)
+
+func ExampleTDigest() {
+	// Summarize response times from two servers in separate
+	// sketches and merge them for a combined summary.
+	rnd := rand.New(rand.NewSource(1))
+	a, err := quantile.NewTDigest(100)
+	if err != nil {
+		log.Fatal(err)
+	}
+	b, err := quantile.NewTDigest(100)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for i := 0; i < 100000; i++ {
+		a.Add(10*rnd.ExpFloat64(), 1)
+		b.Add(20*rnd.ExpFloat64(), 1)
+	}
+	a.Merge(b)
+
+	for _, p := range []float64{0.5, 0.9, 0.99} {
+		fmt.Printf("%v quantile: %.1f ms\n", p, a.Quantile(p))
+	}
+	fmt.Printf("fraction within 50 ms: %.3f\n", a.CDF(50))
+
+	// Output:
+	// 0.5 quantile: 9.6 ms
+	// 0.9 quantile: 35.3 ms
+	// 0.99 quantile: 78.9 ms
+	// fraction within 50 ms: 0.956
+}
+
+func ExampleKLL() {
+	rnd := rand.New(rand.NewSource(1))
+	s, err := quantile.NewKLL(200, rand.NewSource(1))
+	if err != nil {
+		log.Fatal(err)
+	}
+	for i := 0; i < 1000000; i++ {
+		s.Add(rnd.NormFloat64())
+	}
+	fmt.Printf("retained %d of %d observations\n", s.Retained(), s.Count())
+	fmt.Printf("median: %.2f\n", s.Quantile(0.5))
+	fmt.Printf("95th percentile: %.2f\n", s.Quantile(0.95))
+
+	// Output:
+	// retained 612 of 1000000 observations
+	// median: 0.00
+	// 95th percentile: 1.63
+}
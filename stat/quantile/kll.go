@@ -0,0 +1,313 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quantile
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"golang.org/x/exp/rand"
+)
+
+// KLL is a quantile sketch as described by Karnin, Lang and Liberty in
+// "Optimal quantile approximation in streams", FOCS 2016, pp71–78.
+//
+// A KLL sketch retains a sample of the observations in a hierarchy of
+// compactors. Observations retained at level h each represent 2^h
+// observations of the stream. When a level is full it is sorted and a
+// randomly chosen half of its observations, either those in odd or those
+// in even positions, is promoted to the next level. The capacities of the
+// levels decrease geometrically from the top, so the sketch retains O(k)
+// observations. The error of rank estimates is independent of the
+// distribution of the observations and decreases approximately as 1/k;
+// with k = 200 the error in the estimated rank of an observation, as a
+// fraction of the number of observations, is less than about 0.02 with
+// high probability.
+//
+// KLL is not safe for concurrent use.
+type KLL struct {
+	k int
+
+	// levels holds the compactors in increasing
+	// order of the weight of their observations.
+	levels [][]float64
+
+	// size is the number of retained observations,
+	// and maxSize is the sum of the capacities of
+	// the levels.
+	size    int
+	maxSize int
+
+	n        uint64
+	min, max float64
+
+	rnd *rand.Rand
+}
+
+// NewKLL returns a new KLL sketch with the parameter k determining the
+// size and accuracy of the sketch. The value of k must be at least 8; a
+// value of 200 is a common choice. If src is nil the global random source
+// is used to choose the observations that are promoted between levels.
+func NewKLL(k int, src rand.Source) (*KLL, error) {
+	if k < 8 {
+		return nil, errors.New("quantile: k out of range")
+	}
+	s := &KLL{k: k}
+	if src != nil {
+		s.rnd = rand.New(src)
+	}
+	s.grow()
+	return s, nil
+}
+
+// K returns the parameter k of the sketch.
+func (s *KLL) K() int {
+	return s.k
+}
+
+// Add adds the observation x to the sketch. Add will panic if x is NaN.
+func (s *KLL) Add(x float64) {
+	if math.IsNaN(x) {
+		panic(badValue)
+	}
+	if s.n == 0 {
+		s.min, s.max = x, x
+	} else {
+		s.min = math.Min(s.min, x)
+		s.max = math.Max(s.max, x)
+	}
+	s.n++
+	s.levels[0] = append(s.levels[0], x)
+	s.size++
+	if s.size >= s.maxSize {
+		s.compress()
+	}
+}
+
+// Merge adds the observations summarized by a to the receiver. Merge will
+// return an error if the parameters k of the receiver and a do not match.
+func (s *KLL) Merge(a *KLL) error {
+	if a.k != s.k {
+		return errors.New("quantile: mismatched parameter k")
+	}
+	if a.n == 0 {
+		return nil
+	}
+	if s.n == 0 {
+		s.min, s.max = a.min, a.max
+	} else {
+		s.min = math.Min(s.min, a.min)
+		s.max = math.Max(s.max, a.max)
+	}
+	s.n += a.n
+	for len(s.levels) < len(a.levels) {
+		s.grow()
+	}
+	// Iterating over the levels of a before they are extended
+	// allows a to be the receiver.
+	for h, l := range a.levels {
+		s.levels[h] = append(s.levels[h], l...)
+	}
+	s.size = 0
+	for _, l := range s.levels {
+		s.size += len(l)
+	}
+	for s.size >= s.maxSize {
+		s.compress()
+	}
+	return nil
+}
+
+// Reset clears the receiver allowing it to be reused. Reset does not alter
+// the parameter k of the receiver or its random source.
+func (s *KLL) Reset() {
+	s.levels = s.levels[:0]
+	s.size = 0
+	s.n = 0
+	s.min, s.max = 0, 0
+	s.grow()
+}
+
+// Count returns the number of observations added to the sketch.
+func (s *KLL) Count() uint64 {
+	return s.n
+}
+
+// Retained returns the number of observations retained by the sketch.
+func (s *KLL) Retained() int {
+	return s.size
+}
+
+// Min returns the smallest observation added to the sketch. Min returns
+// NaN if the sketch is empty.
+func (s *KLL) Min() float64 {
+	if s.n == 0 {
+		return math.NaN()
+	}
+	return s.min
+}
+
+// Max returns the largest observation added to the sketch. Max returns
+// NaN if the sketch is empty.
+func (s *KLL) Max() float64 {
+	if s.n == 0 {
+		return math.NaN()
+	}
+	return s.max
+}
+
+// Quantile returns an estimate of the p quantile of the observations added
+// to the sketch, the smallest retained observation whose estimated rank is
+// at least p times the number of observations. Quantile returns NaN if the
+// sketch is empty, and will panic if p is not in the interval [0, 1].
+func (s *KLL) Quantile(p float64) float64 {
+	if !(0 <= p && p <= 1) {
+		panic(badQuantile)
+	}
+	if s.n == 0 {
+		return math.NaN()
+	}
+	if p == 0 {
+		return s.min
+	}
+	x, w := s.weighted()
+	sort.Sort(centroids{mean: x, weight: w})
+	target := p * float64(s.n)
+	var cum float64
+	for i, v := range x {
+		cum += w[i]
+		if cum >= target {
+			return v
+		}
+	}
+	return s.max
+}
+
+// CDF returns an estimate of the fraction of the observations added to the
+// sketch that are at most x. CDF returns NaN if the sketch is empty.
+func (s *KLL) CDF(x float64) float64 {
+	if s.n == 0 {
+		return math.NaN()
+	}
+	var rank uint64
+	for h, l := range s.levels {
+		for _, v := range l {
+			if v <= x {
+				rank += 1 << uint(h)
+			}
+		}
+	}
+	return float64(rank) / float64(s.n)
+}
+
+// weighted returns the retained observations and their weights.
+func (s *KLL) weighted() (x, w []float64) {
+	x = make([]float64, 0, s.size)
+	w = make([]float64, 0, s.size)
+	for h, l := range s.levels {
+		x = append(x, l...)
+		for range l {
+			w = append(w, float64(uint64(1)<<uint(h)))
+		}
+	}
+	return x, w
+}
+
+// capacity returns the capacity of level h.
+func (s *KLL) capacity(h int) int {
+	depth := len(s.levels) - h - 1
+	return int(math.Ceil(float64(s.k)*math.Pow(2.0/3, float64(depth)))) + 1
+}
+
+// grow adds a level to the top of the sketch.
+func (s *KLL) grow() {
+	s.levels = append(s.levels, nil)
+	s.maxSize = 0
+	for h := range s.levels {
+		s.maxSize += s.capacity(h)
+	}
+}
+
+// compress compacts the lowest level of the sketch that has reached its
+// capacity.
+func (s *KLL) compress() {
+	for h := 0; h < len(s.levels); h++ {
+		if len(s.levels[h]) < s.capacity(h) {
+			continue
+		}
+		if h+1 == len(s.levels) {
+			s.grow()
+		}
+		l := s.levels[h]
+		sort.Float64s(l)
+
+		// Leave the smallest observation in the level
+		// if the number of observations is odd.
+		start := len(l) % 2
+		for i := start + randomBit(s.rnd); i < len(l); i += 2 {
+			s.levels[h+1] = append(s.levels[h+1], l[i])
+		}
+		s.size -= (len(l) - start) / 2
+		s.levels[h] = l[:start]
+		return
+	}
+}
+
+// MarshalBinary marshals the sketch in the receiver. It encodes the
+// parameter k of the sketch, the number and extrema of the observations
+// and the retained observations.
+func (s *KLL) MarshalBinary() ([]byte, error) {
+	return encode(
+		uint8(encodingVersion),
+		s.k,
+		s.n,
+		s.min,
+		s.max,
+		s.levels,
+	)
+}
+
+// UnmarshalBinary unmarshals the binary representation of a sketch into
+// the receiver. The parameter k of the receiver will be set after return.
+// The random source of the receiver is retained. UnmarshalBinary returns an
+// error if the number of observations does not match the retained
+// observations weighted by their levels.
+func (s *KLL) UnmarshalBinary(b []byte) error {
+	var (
+		k        int
+		n        uint64
+		min, max float64
+		levels   [][]float64
+	)
+	err := decode(b, &k, &n, &min, &max, &levels)
+	if err != nil {
+		return err
+	}
+	if k < 8 || len(levels) == 0 || len(levels) > 64 {
+		return errors.New("quantile: invalid KLL data")
+	}
+	// Each observation retained at level h represents 2^h observations.
+	var count uint64
+	for h, l := range levels {
+		c := uint64(len(l)) << uint(h)
+		if c>>uint(h) != uint64(len(l)) || count+c < count {
+			return errors.New("quantile: invalid KLL data")
+		}
+		count += c
+	}
+	if count != n {
+		return errors.New("quantile: invalid KLL data")
+	}
+	*s = KLL{k: k, n: n, min: min, max: max, rnd: s.rnd}
+	for range levels {
+		s.grow()
+	}
+	for h, l := range levels {
+		s.levels[h] = l
+		s.size += len(l)
+	}
+	return nil
+}
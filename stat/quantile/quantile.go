@@ -0,0 +1,84 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quantile
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+
+	"golang.org/x/exp/rand"
+)
+
+const (
+	badQuantile = "quantile: quantile out of bounds"
+	badValue    = "quantile: NaN value"
+	badWeight   = "quantile: negative weight"
+)
+
+// encodingVersion is the version of the binary encoding of the sketches.
+const encodingVersion = 1
+
+// decodeVersion decodes the encoding version of a sketch from dec and
+// checks that it matches encodingVersion.
+func decodeVersion(dec *gob.Decoder) error {
+	var version uint8
+	err := dec.Decode(&version)
+	if err != nil {
+		return err
+	}
+	if version != encodingVersion {
+		return fmt.Errorf("quantile: mismatched encoding version: dst=%d src=%d", encodingVersion, version)
+	}
+	return nil
+}
+
+// encode encodes each of the values in vals into a byte slice.
+func encode(vals ...interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	for _, v := range vals {
+		err := enc.Encode(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// decode decodes the version of a sketch and then each of the values
+// pointed to by vals from b.
+func decode(b []byte, vals ...interface{}) error {
+	dec := gob.NewDecoder(bytes.NewReader(b))
+	err := decodeVersion(dec)
+	if err != nil {
+		return err
+	}
+	for _, v := range vals {
+		err = dec.Decode(v)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// interpolate returns the value at t of the line through (t0, x0) and
+// (t1, x1).
+func interpolate(t0, x0, t1, x1, t float64) float64 {
+	if t1 == t0 {
+		return x1
+	}
+	return x0 + (x1-x0)*(t-t0)/(t1-t0)
+}
+
+// randomBit returns a random bit from rnd or from the global source if rnd
+// is nil.
+func randomBit(rnd *rand.Rand) int {
+	if rnd == nil {
+		return int(rand.Uint64() & 1)
+	}
+	return int(rnd.Uint64() & 1)
+}
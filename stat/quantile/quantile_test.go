@@ -0,0 +1,408 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quantile
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+// sketch is a streaming quantile sketch.
+type sketch interface {
+	add(x float64)
+	merge(a sketch) error
+	Quantile(p float64) float64
+	CDF(x float64) float64
+	Min() float64
+	Max() float64
+	Reset()
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+type tdigest struct{ *TDigest }
+
+func (t tdigest) add(x float64) { t.Add(x, 1) }
+func (t tdigest) merge(a sketch) error {
+	t.Merge(a.(tdigest).TDigest)
+	return nil
+}
+
+type kll struct{ *KLL }
+
+func (s kll) add(x float64)        { s.Add(x) }
+func (s kll) merge(a sketch) error { return s.Merge(a.(kll).KLL) }
+
+func mustTDigest(t *TDigest, err error) sketch {
+	if err != nil {
+		panic(fmt.Sprintf("bad test: %v", err))
+	}
+	return tdigest{t}
+}
+
+func mustKLL(s *KLL, err error) sketch {
+	if err != nil {
+		panic(fmt.Sprintf("bad test: %v", err))
+	}
+	return kll{s}
+}
+
+var sketchTests = []struct {
+	name   string
+	sketch func() sketch
+
+	// tol is the allowed error in rank, and tailTol is the
+	// allowed error in rank for quantiles in the tails.
+	tol, tailTol float64
+}{
+	{name: "TDigest-100", sketch: func() sketch { return mustTDigest(NewTDigest(100)) }, tol: 0.01, tailTol: 0.001},
+	{name: "TDigest-500", sketch: func() sketch { return mustTDigest(NewTDigest(500)) }, tol: 0.002, tailTol: 0.0002},
+	{name: "KLL-200", sketch: func() sketch { return mustKLL(NewKLL(200, rand.NewSource(1))) }, tol: 0.02, tailTol: 0.02},
+	{name: "KLL-1000", sketch: func() sketch { return mustKLL(NewKLL(1000, rand.NewSource(1))) }, tol: 0.005, tailTol: 0.005},
+}
+
+var distributions = []struct {
+	name string
+	rand func(*rand.Rand) float64
+}{
+	{name: "uniform", rand: (*rand.Rand).Float64},
+	{name: "normal", rand: (*rand.Rand).NormFloat64},
+	{name: "exponential", rand: (*rand.Rand).ExpFloat64},
+	{name: "discrete", rand: func(rnd *rand.Rand) float64 { return float64(rnd.Intn(10)) }},
+}
+
+// rank returns the fraction of the elements of the sorted slice x that
+// are less than v and the fraction that are at most v.
+func rank(x []float64, v float64) (lo, hi float64) {
+	n := float64(len(x))
+	lo = float64(sort.Search(len(x), func(i int) bool { return x[i] >= v })) / n
+	hi = float64(sort.Search(len(x), func(i int) bool { return x[i] > v })) / n
+	return lo, hi
+}
+
+func TestSketches(t *testing.T) {
+	const n = 100000
+	ps := []float64{0, 1e-4, 0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999, 0.9999, 1}
+	for _, test := range sketchTests {
+		for _, dist := range distributions {
+			rnd := rand.New(rand.NewSource(1))
+			x := make([]float64, n)
+			s := test.sketch()
+			for i := range x {
+				x[i] = dist.rand(rnd)
+				s.add(x[i])
+			}
+			sort.Float64s(x)
+			if s.Min() != x[0] || s.Max() != x[n-1] {
+				t.Errorf("unexpected extrema for %s %s: got:[%v,%v] want:[%v,%v]",
+					test.name, dist.name, s.Min(), s.Max(), x[0], x[n-1])
+			}
+			for _, p := range ps {
+				tol := test.tol
+				if p < 0.01 || 0.99 < p {
+					tol = test.tailTol
+				}
+				q := s.Quantile(p)
+				lo, hi := rank(x, q)
+				if p < lo-tol || hi+tol < p {
+					t.Errorf("unexpected %v quantile for %s %s: got:%v with rank in [%v,%v]",
+						p, test.name, dist.name, q, lo, hi)
+				}
+			}
+			for _, p := range ps[1 : len(ps)-1] {
+				v := x[int(p*n)]
+				lo, hi := rank(x, v)
+				got := s.CDF(v)
+				if got < lo-test.tol || hi+test.tol < got {
+					t.Errorf("unexpected CDF for %s %s at %v: got:%v want in [%v,%v]",
+						test.name, dist.name, v, got, lo, hi)
+				}
+			}
+			if s.CDF(x[0]-1) != 0 || s.CDF(x[n-1]) != 1 {
+				t.Errorf("unexpected CDF beyond extrema for %s %s", test.name, dist.name)
+			}
+		}
+	}
+}
+
+func TestSketchMerge(t *testing.T) {
+	const n = 100000
+	for _, test := range sketchTests {
+		rnd := rand.New(rand.NewSource(1))
+		x := make([]float64, n)
+		merged := test.sketch()
+		part := test.sketch()
+		for lo := 0; lo < n; {
+			hi := lo + 1 + rnd.Intn(n/5)
+			if hi > n {
+				hi = n
+			}
+			part.Reset()
+			for i := lo; i < hi; i++ {
+				x[i] = rnd.NormFloat64()
+				part.add(x[i])
+			}
+			err := merged.merge(part)
+			if err != nil {
+				t.Fatalf("unexpected error merging %s: %v", test.name, err)
+			}
+			lo = hi
+		}
+		sort.Float64s(x)
+		for _, p := range []float64{0.001, 0.1, 0.5, 0.9, 0.999} {
+			q := merged.Quantile(p)
+			lo, hi := rank(x, q)
+			if p < lo-test.tol || hi+test.tol < p {
+				t.Errorf("unexpected %v quantile for merged %s: got:%v with rank in [%v,%v]",
+					p, test.name, q, lo, hi)
+			}
+		}
+
+		// Merging a sketch with itself doubles the weight of
+		// each observation, leaving the quantiles unchanged.
+		err := merged.merge(merged)
+		if err != nil {
+			t.Fatalf("unexpected error merging %s with itself: %v", test.name, err)
+		}
+		for _, p := range []float64{0.1, 0.5, 0.9} {
+			q := merged.Quantile(p)
+			lo, hi := rank(x, q)
+			if p < lo-test.tol || hi+test.tol < p {
+				t.Errorf("unexpected %v quantile for %s merged with itself: got:%v with rank in [%v,%v]",
+					p, test.name, q, lo, hi)
+			}
+		}
+	}
+}
+
+func TestSketchEmpty(t *testing.T) {
+	for _, test := range sketchTests {
+		s := test.sketch()
+		for _, v := range []float64{s.Quantile(0.5), s.CDF(0), s.Min(), s.Max()} {
+			if !math.IsNaN(v) {
+				t.Errorf("unexpected statistic of empty %s: got:%v want:NaN", test.name, v)
+			}
+		}
+		err := s.merge(test.sketch())
+		if err != nil {
+			t.Errorf("unexpected error merging empty %s: %v", test.name, err)
+		}
+
+		s.add(2)
+		for _, p := range []float64{0, 0.5, 1} {
+			if q := s.Quantile(p); q != 2 {
+				t.Errorf("unexpected %v quantile of single observation for %s: got:%v want:2", p, test.name, q)
+			}
+		}
+		s.Reset()
+		if !math.IsNaN(s.Quantile(0.5)) {
+			t.Errorf("unexpected quantile after reset of %s", test.name)
+		}
+
+		for _, p := range []float64{-0.1, 1.1, math.NaN()} {
+			if !panics(func() { s.Quantile(p) }) {
+				t.Errorf("expected panic for %s quantile %v", test.name, p)
+			}
+		}
+		if !panics(func() { s.add(math.NaN()) }) {
+			t.Errorf("expected panic for %s NaN observation", test.name)
+		}
+	}
+}
+
+func TestBinaryEncoding(t *testing.T) {
+	for _, test := range sketchTests {
+		rnd := rand.New(rand.NewSource(1))
+		src := test.sketch()
+		for i := 0; i < 10000; i++ {
+			src.add(rnd.ExpFloat64())
+		}
+		b, err := src.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error marshaling %s: %v", test.name, err)
+		}
+		dst := test.sketch()
+		err = dst.UnmarshalBinary(b)
+		if err != nil {
+			t.Fatalf("unexpected error unmarshaling %s: %v", test.name, err)
+		}
+		for _, p := range []float64{0, 0.01, 0.5, 0.99, 1} {
+			if got, want := dst.Quantile(p), src.Quantile(p); got != want {
+				t.Errorf("unexpected %v quantile after round trip of %s: got:%v want:%v", p, test.name, got, want)
+			}
+		}
+		if dst.Min() != src.Min() || dst.Max() != src.Max() {
+			t.Errorf("unexpected extrema after round trip of %s", test.name)
+		}
+
+		// The round-tripped sketch continues to accept observations.
+		for i := 0; i < 10000; i++ {
+			dst.add(rnd.ExpFloat64())
+		}
+
+		if err := dst.UnmarshalBinary([]byte("invalid")); err == nil {
+			t.Errorf("expected error unmarshaling invalid data into %s", test.name)
+		}
+	}
+}
+
+func TestTDigest(t *testing.T) {
+	for _, compression := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		_, err := NewTDigest(compression)
+		if err == nil {
+			t.Errorf("expected error for compression %v", compression)
+		}
+	}
+
+	const compression = 100
+	td, _ := NewTDigest(compression)
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		td.Add(rnd.NormFloat64(), 1+rnd.Float64())
+	}
+	if n := td.Centroids(); n > compression {
+		t.Errorf("unexpected number of centroids: got:%d want:<=%d", n, compression)
+	}
+	var sum float64
+	for _, w := range td.weight {
+		sum += w
+	}
+	if math.Abs(sum-td.SumWeights()) > 1e-9*sum {
+		t.Errorf("centroid weights do not sum to total weight: got:%v want:%v", sum, td.SumWeights())
+	}
+	if !sort.Float64sAreSorted(td.mean) {
+		t.Errorf("centroids not sorted")
+	}
+
+	// Small samples are held exactly, and the quantile and
+	// cumulative distribution functions are inverses between
+	// the extreme observations.
+	td.Reset()
+	x := []float64{3, 1, 4, 1.5, 9, 2.6, 5}
+	for _, v := range x {
+		td.Add(v, 1)
+	}
+	if n := td.Centroids(); n != len(x) {
+		t.Errorf("unexpected number of centroids for small sample: got:%d want:%d", n, len(x))
+	}
+	sorted := append([]float64(nil), x...)
+	sort.Float64s(sorted)
+	for i, want := range sorted {
+		p := (float64(i) + 0.5) / float64(len(x))
+		if got := td.Quantile(p); math.Abs(got-want) > 1e-14 {
+			t.Errorf("unexpected %v quantile of small sample: got:%v want:%v", p, got, want)
+		}
+	}
+	for _, p := range []float64{0.1, 0.3, 0.5, 0.77, 0.9} {
+		q := td.Quantile(p)
+		if got := td.CDF(q); math.Abs(got-p) > 1e-14 {
+			t.Errorf("CDF not inverse of quantile at %v: got:%v", p, got)
+		}
+	}
+
+	if !panics(func() { td.Add(1, -1) }) {
+		t.Errorf("expected panic for negative weight")
+	}
+
+	for _, test := range []struct {
+		name         string
+		sumWeights   float64
+		mean, weight []float64
+		valid        bool
+	}{
+		{name: "valid", sumWeights: 6, mean: []float64{1, 2, 3}, weight: []float64{1, 2, 3}, valid: true},
+		{name: "empty", valid: true},
+		{name: "negative weight", sumWeights: 2, mean: []float64{1, 2, 3}, weight: []float64{1, -2, 3}},
+		{name: "zero weight", sumWeights: 4, mean: []float64{1, 2, 3}, weight: []float64{1, 0, 3}},
+		{name: "NaN weight", sumWeights: 4, mean: []float64{1, 2, 3}, weight: []float64{1, math.NaN(), 3}},
+		{name: "infinite weight", sumWeights: math.Inf(1), mean: []float64{1, 2, 3}, weight: []float64{1, math.Inf(1), 3}},
+		{name: "NaN mean", sumWeights: 6, mean: []float64{1, math.NaN(), 3}, weight: []float64{1, 2, 3}},
+		{name: "mismatched sum", sumWeights: 7, mean: []float64{1, 2, 3}, weight: []float64{1, 2, 3}},
+		{name: "NaN sum", sumWeights: math.NaN(), mean: []float64{1, 2, 3}, weight: []float64{1, 2, 3}},
+	} {
+		b, err := encode(uint8(encodingVersion), float64(compression), test.sumWeights, 1.0, 3.0, test.mean, test.weight)
+		if err != nil {
+			t.Fatalf("unexpected error encoding %s data: %v", test.name, err)
+		}
+		err = td.UnmarshalBinary(b)
+		if test.valid && err != nil {
+			t.Errorf("unexpected error unmarshaling %s data: %v", test.name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("expected error unmarshaling %s data", test.name)
+		}
+	}
+}
+
+func TestKLL(t *testing.T) {
+	if _, err := NewKLL(7, nil); err == nil {
+		t.Errorf("expected error for k < 8")
+	}
+	a, _ := NewKLL(200, nil)
+	b, _ := NewKLL(100, nil)
+	if err := a.Merge(b); err == nil {
+		t.Errorf("expected error merging sketches with mismatched k")
+	}
+
+	const k = 200
+	s, _ := NewKLL(k, rand.NewSource(1))
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000000; i++ {
+		s.Add(rnd.Float64())
+	}
+	if s.Count() != 1000000 {
+		t.Errorf("unexpected count: got:%d want:1000000", s.Count())
+	}
+	if s.Retained() > 3*k+2*len(s.levels) {
+		t.Errorf("unexpected number of retained observations: got:%d", s.Retained())
+	}
+	var w uint64
+	for h, l := range s.levels {
+		w += uint64(len(l)) << uint(h)
+	}
+	if w != s.Count() {
+		t.Errorf("retained weight does not equal count: got:%d want:%d", w, s.Count())
+	}
+
+	for _, test := range []struct {
+		name   string
+		n      uint64
+		levels [][]float64
+		valid  bool
+	}{
+		{name: "valid", n: 7, levels: [][]float64{{1, 2, 3}, {4, 5}}, valid: true},
+		{name: "empty", levels: [][]float64{nil}, valid: true},
+		{name: "count too small", n: 5, levels: [][]float64{{1, 2, 3}, {4, 5}}},
+		{name: "count too large", n: 8, levels: [][]float64{{1, 2, 3}, {4, 5}}},
+		{name: "too many levels", n: 1 << 63, levels: make([][]float64, 65)},
+		{name: "overflow", n: 1 << 63, levels: append(make([][]float64, 63), []float64{1, 2})},
+	} {
+		b, err := encode(uint8(encodingVersion), k, test.n, 1.0, 5.0, test.levels)
+		if err != nil {
+			t.Fatalf("unexpected error encoding %s data: %v", test.name, err)
+		}
+		err = s.UnmarshalBinary(b)
+		if test.valid && err != nil {
+			t.Errorf("unexpected error unmarshaling %s data: %v", test.name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("expected error unmarshaling %s data", test.name)
+		}
+	}
+}
+
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		r := recover()
+		panicked = r != nil
+	}()
+	fn()
+	return
+}
@@ -0,0 +1,338 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quantile
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// TDigest is a t-digest quantile sketch as described by Dunning and Ertl in
+// "Computing extremely accurate quantiles using t-digests",
+// arXiv:1902.04023.
+//
+// A t-digest summarizes weighted observations as a sorted set of centroids,
+// clusters of observations represented by their mean and total weight. The
+// size of the clusters is limited by a scale function that keeps clusters
+// near the extremes of the distribution small, so the relative accuracy of
+// quantile estimates is highest in the tails. The number of centroids is
+// bounded by a small multiple of the compression parameter.
+//
+// TDigest is not safe for concurrent use, including by queries, which may
+// compress observations held in the sketch's buffer.
+type TDigest struct {
+	compression float64
+
+	// mean and weight hold the merged centroids in
+	// increasing order of mean.
+	mean   []float64
+	weight []float64
+
+	// bufMean and bufWeight hold observations that
+	// have not yet been merged into the centroids.
+	bufMean   []float64
+	bufWeight []float64
+
+	// scratchMean and scratchWeight are working
+	// storage for merging centroids.
+	scratchMean   []float64
+	scratchWeight []float64
+
+	sumWeights float64
+	min, max   float64
+}
+
+// NewTDigest returns a new t-digest sketch with the given compression.
+// Larger values of compression give more accurate estimates at the cost
+// of more space; a value of 100 is a common choice. The compression must
+// be positive and finite.
+func NewTDigest(compression float64) (*TDigest, error) {
+	if !(compression > 0) || math.IsInf(compression, 1) {
+		return nil, errors.New("quantile: compression out of range")
+	}
+	bufSize := int(math.Ceil(5 * compression))
+	return &TDigest{
+		compression: compression,
+		bufMean:     make([]float64, 0, bufSize),
+		bufWeight:   make([]float64, 0, bufSize),
+	}, nil
+}
+
+// Compression returns the compression parameter of the sketch.
+func (t *TDigest) Compression() float64 {
+	return t.compression
+}
+
+// Add adds the observation x with the given weight to the sketch.
+// Observations with zero weight are ignored. Add will panic if x is NaN
+// or weight is negative.
+func (t *TDigest) Add(x, weight float64) {
+	if math.IsNaN(x) {
+		panic(badValue)
+	}
+	if weight < 0 {
+		panic(badWeight)
+	}
+	if weight == 0 {
+		return
+	}
+	if t.sumWeights == 0 {
+		t.min, t.max = x, x
+	} else {
+		t.min = math.Min(t.min, x)
+		t.max = math.Max(t.max, x)
+	}
+	t.sumWeights += weight
+	t.bufMean = append(t.bufMean, x)
+	t.bufWeight = append(t.bufWeight, weight)
+	if len(t.bufMean) == cap(t.bufMean) {
+		t.compress()
+	}
+}
+
+// Merge adds the observations summarized by a to the receiver. The
+// compression of the receiver is retained.
+func (t *TDigest) Merge(a *TDigest) {
+	if a.sumWeights == 0 {
+		return
+	}
+	a.compress()
+	if t.sumWeights == 0 {
+		t.min, t.max = a.min, a.max
+	} else {
+		t.min = math.Min(t.min, a.min)
+		t.max = math.Max(t.max, a.max)
+	}
+	t.compress()
+	t.sumWeights += a.sumWeights
+	t.scratchMean = append(append(t.scratchMean[:0], t.mean...), a.mean...)
+	t.scratchWeight = append(append(t.scratchWeight[:0], t.weight...), a.weight...)
+	t.merge(t.scratchMean, t.scratchWeight)
+}
+
+// Reset clears the receiver allowing it to be reused. Reset does not alter
+// the compression of the receiver.
+func (t *TDigest) Reset() {
+	t.mean = t.mean[:0]
+	t.weight = t.weight[:0]
+	t.bufMean = t.bufMean[:0]
+	t.bufWeight = t.bufWeight[:0]
+	t.sumWeights = 0
+	t.min, t.max = 0, 0
+}
+
+// SumWeights returns the sum of the weights of the observations added to
+// the sketch.
+func (t *TDigest) SumWeights() float64 {
+	return t.sumWeights
+}
+
+// Centroids returns the number of centroids held by the sketch after
+// merging any buffered observations.
+func (t *TDigest) Centroids() int {
+	t.compress()
+	return len(t.mean)
+}
+
+// Min returns the smallest observation added to the sketch. Min returns
+// NaN if the sketch is empty.
+func (t *TDigest) Min() float64 {
+	if t.sumWeights == 0 {
+		return math.NaN()
+	}
+	return t.min
+}
+
+// Max returns the largest observation added to the sketch. Max returns
+// NaN if the sketch is empty.
+func (t *TDigest) Max() float64 {
+	if t.sumWeights == 0 {
+		return math.NaN()
+	}
+	return t.max
+}
+
+// Quantile returns an estimate of the p quantile of the observations added
+// to the sketch. The estimate interpolates linearly between the means of
+// adjacent centroids, placing each centroid at the middle of the weight it
+// represents, and between the extreme centroids and the minimum and maximum
+// observations. Quantile returns NaN if the sketch is empty, and will panic
+// if p is not in the interval [0, 1].
+func (t *TDigest) Quantile(p float64) float64 {
+	if !(0 <= p && p <= 1) {
+		panic(badQuantile)
+	}
+	t.compress()
+	if t.sumWeights == 0 {
+		return math.NaN()
+	}
+	target := p * t.sumWeights
+	prevX, prevT := t.min, 0.0
+	var cum float64
+	for i, m := range t.mean {
+		c := cum + t.weight[i]/2
+		if target < c {
+			return interpolate(prevT, prevX, c, m, target)
+		}
+		prevX, prevT = m, c
+		cum += t.weight[i]
+	}
+	return interpolate(prevT, prevX, t.sumWeights, t.max, target)
+}
+
+// CDF returns an estimate of the fraction of the weight of the observations
+// added to the sketch that is at most x, using the same interpolation as
+// Quantile. CDF returns NaN if the sketch is empty.
+func (t *TDigest) CDF(x float64) float64 {
+	t.compress()
+	switch {
+	case t.sumWeights == 0:
+		return math.NaN()
+	case x < t.min:
+		return 0
+	case x >= t.max:
+		return 1
+	}
+	prevX, prevT := t.min, 0.0
+	var cum float64
+	for i, m := range t.mean {
+		c := cum + t.weight[i]/2
+		if x < m {
+			return interpolate(prevX, prevT, m, c, x) / t.sumWeights
+		}
+		prevX, prevT = m, c
+		cum += t.weight[i]
+	}
+	return interpolate(prevX, prevT, t.max, t.sumWeights, x) / t.sumWeights
+}
+
+// compress merges the buffered observations into the centroids.
+func (t *TDigest) compress() {
+	if len(t.bufMean) == 0 {
+		return
+	}
+	t.scratchMean = append(append(t.scratchMean[:0], t.bufMean...), t.mean...)
+	t.scratchWeight = append(append(t.scratchWeight[:0], t.bufWeight...), t.weight...)
+	t.bufMean = t.bufMean[:0]
+	t.bufWeight = t.bufWeight[:0]
+	t.merge(t.scratchMean, t.scratchWeight)
+}
+
+// merge sorts the centroids in mean and weight and merges them into the
+// centroids of the receiver, replacing its existing centroids.
+func (t *TDigest) merge(mean, weight []float64) {
+	sort.Sort(centroids{mean: mean, weight: weight})
+
+	// Merge adjacent centroids while the merged centroid spans
+	// at most one unit of the scale function.
+	t.mean = append(t.mean[:0], mean[0])
+	t.weight = append(t.weight[:0], weight[0])
+	var cum float64
+	limit := t.weightLimit(0)
+	for i, m := range mean[1:] {
+		w := weight[i+1]
+		last := len(t.mean) - 1
+		if cum+t.weight[last]+w <= limit {
+			t.weight[last] += w
+			t.mean[last] += (m - t.mean[last]) * w / t.weight[last]
+			continue
+		}
+		cum += t.weight[last]
+		limit = t.weightLimit(cum)
+		t.mean = append(t.mean, m)
+		t.weight = append(t.weight, w)
+	}
+}
+
+// weightLimit returns the largest cumulative weight that may be reached by
+// a centroid whose preceding centroids have total weight cum. The limit is
+// given by the scale function
+//  k(q) = δ/(2π) asin(2q - 1)
+// where δ is the compression, and is the weight at which k increases by one
+// from its value at cum.
+func (t *TDigest) weightLimit(cum float64) float64 {
+	q := cum / t.sumWeights
+	k := t.compression/(2*math.Pi)*math.Asin(2*q-1) + 1
+	arg := 2 * math.Pi * k / t.compression
+	if arg >= math.Pi/2 {
+		return t.sumWeights
+	}
+	return (math.Sin(arg) + 1) / 2 * t.sumWeights
+}
+
+// centroids sorts centroids by increasing mean.
+type centroids struct {
+	mean, weight []float64
+}
+
+func (c centroids) Len() int           { return len(c.mean) }
+func (c centroids) Less(i, j int) bool { return c.mean[i] < c.mean[j] }
+func (c centroids) Swap(i, j int) {
+	c.mean[i], c.mean[j] = c.mean[j], c.mean[i]
+	c.weight[i], c.weight[j] = c.weight[j], c.weight[i]
+}
+
+// MarshalBinary marshals the sketch in the receiver. It encodes the
+// compression of the sketch, the extrema of the observations and the
+// centroids after merging any buffered observations.
+func (t *TDigest) MarshalBinary() ([]byte, error) {
+	t.compress()
+	return encode(
+		uint8(encodingVersion),
+		t.compression,
+		t.sumWeights,
+		t.min,
+		t.max,
+		t.mean,
+		t.weight,
+	)
+}
+
+// sumWeightsTol is the relative tolerance for the agreement of the total
+// weight of an unmarshaled t-digest with the sum of its centroid weights.
+const sumWeightsTol = 1e-6
+
+// UnmarshalBinary unmarshals the binary representation of a sketch into
+// the receiver. The compression of the receiver will be set after return.
+// UnmarshalBinary returns an error if the centroid weights are not positive
+// and finite or do not sum to the total weight of the sketch.
+func (t *TDigest) UnmarshalBinary(b []byte) error {
+	var (
+		compression, sumWeights, min, max float64
+		mean, weight                      []float64
+	)
+	err := decode(b, &compression, &sumWeights, &min, &max, &mean, &weight)
+	if err != nil {
+		return err
+	}
+	if !(compression > 0) || math.IsInf(compression, 1) || len(mean) != len(weight) {
+		return errors.New("quantile: invalid t-digest data")
+	}
+	var sum float64
+	for i, w := range weight {
+		if math.IsNaN(mean[i]) || !(w > 0) || math.IsInf(w, 1) {
+			return errors.New("quantile: invalid t-digest data")
+		}
+		sum += w
+	}
+	// The total weight is accumulated in a different order from the
+	// weights of the centroids, so allow for rounding error.
+	if !(math.Abs(sumWeights-sum) <= sumWeightsTol*sum) {
+		return errors.New("quantile: invalid t-digest data")
+	}
+	bufSize := int(math.Ceil(5 * compression))
+	*t = TDigest{
+		compression: compression,
+		mean:        mean,
+		weight:      weight,
+		bufMean:     make([]float64, 0, bufSize),
+		bufWeight:   make([]float64, 0, bufSize),
+		sumWeights:  sumWeights,
+		min:         min,
+		max:         max,
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package regression provides linear regression models with statistical
+// inference and penalized regression.
+//
+// Linear fits ordinary and weighted least squares regressions of a response
+// on the columns of a design matrix, and provides standard errors and tests
+// of the coefficients, goodness of fit statistics, residual diagnostics,
+// heteroscedasticity-consistent covariance estimates and prediction
+// intervals.
+//
+// ElasticNet computes regularization paths of lasso, ridge and elastic net
+// penalized linear and logistic regressions by coordinate descent, and
+// selects the penalty by cross-validation.
+package regression // import "gonum.org/v1/gonum/stat/regression"
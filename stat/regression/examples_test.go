@@ -0,0 +1,81 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regression_test
+
+import (
+	"fmt"
+	"log"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/regression"
+)
+
+func ExampleLinear() {
+	// Fuel consumption in litres per 100 km of cars
+	// with the given mass in tonnes and engine power
+	// in kilowatts.
+	x := mat.NewDense(8, 2, []float64{
+		1.1, 55,
+		1.2, 70,
+		1.3, 66,
+		1.4, 85,
+		1.5, 90,
+		1.6, 110,
+		1.8, 105,
+		2.0, 140,
+	})
+	y := []float64{5.2, 5.9, 6.1, 6.8, 7.0, 8.1, 8.3, 9.9}
+
+	var l regression.Linear
+	err := l.Fit(x, y, nil, true)
+	if err != nil {
+		log.Fatal(err)
+	}
+	coef := l.CoefficientsTo(nil)
+	se := l.StdErrorsTo(nil, regression.Classical)
+	p := l.PValuesTo(nil, regression.Classical)
+	for i, name := range []string{"intercept", "mass", "power"} {
+		fmt.Printf("%-9s %7.4f (%.4f) p=%.4f\n", name, coef[i], se[i], p[i])
+	}
+	fmt.Printf("R² = %.4f\n", l.RSquared())
+
+	lo, hi := l.PredictionInterval([]float64{1.7, 100}, 0.95)
+	fmt.Printf("prediction = %.2f [%.2f, %.2f]\n", l.Predict([]float64{1.7, 100}), lo, hi)
+
+	// Output:
+	// intercept  1.1004 (0.2859) p=0.0120
+	// mass       2.0943 (0.4788) p=0.0072
+	// power      0.0327 (0.0053) p=0.0016
+	// R² = 0.9968
+	// prediction = 7.93 [7.62, 8.24]
+}
+
+func ExampleElasticNet() {
+	// Simulate a response that depends on only two of
+	// ten predictors.
+	rnd := rand.New(rand.NewSource(1))
+	x := mat.NewDense(100, 10, nil)
+	y := make([]float64, 100)
+	for i := range y {
+		for j := 0; j < 10; j++ {
+			x.Set(i, j, rnd.NormFloat64())
+		}
+		y[i] = 3*x.At(i, 2) - 2*x.At(i, 7) + rnd.NormFloat64()
+	}
+
+	// Fit the lasso path and choose the penalty by
+	// ten-fold cross-validation.
+	e := regression.ElasticNet{Alpha: 1}
+	cv := e.CrossValidate(x, y, nil, 10, rand.NewSource(1))
+	sol := cv.Path[cv.OneSE]
+	fmt.Printf("selected predictors: %v\n", sol.Index)
+	fmt.Printf("coefficients: %.1f\n", sol.Coef)
+
+	// Output:
+	// selected predictors: [2 7]
+	// coefficients: [2.7 -1.4]
+}
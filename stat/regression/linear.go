@@ -0,0 +1,508 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regression
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/gonum/stat/internal/lsq"
+)
+
+const (
+	badCovariance = "regression: bad covariance kind"
+	badLength     = "regression: slice length mismatch"
+	badLevel      = "regression: confidence level not in (0, 1)"
+	badWeight     = "regression: non-positive weight"
+	notFitted     = "regression: use of unsuccessful fit"
+	tooFewObs     = "regression: fewer observations than coefficients"
+)
+
+// ErrRankDeficient is returned when the columns of a design matrix are
+// linearly dependent to within working precision.
+var ErrRankDeficient = errors.New("regression: design matrix is rank deficient")
+
+// CovarianceKind specifies an estimator of the covariance of regression
+// coefficients.
+type CovarianceKind int
+
+const (
+	// Classical is the estimator σ² (XᵀWX)⁻¹, which assumes
+	// that the errors have equal variance after weighting.
+	Classical CovarianceKind = iota
+
+	// HC0 is White's heteroscedasticity-consistent estimator
+	//  (XᵀWX)⁻¹ XᵀW diag(e_i^2) WX (XᵀWX)⁻¹
+	// which uses the squared residuals as estimates of the
+	// variances of the errors.
+	HC0
+
+	// HC1 scales HC0 by n/(n-p) to correct for the degrees
+	// of freedom used by the fit.
+	HC1
+
+	// HC2 divides the squared residuals of HC0 by 1-h_i,
+	// where h_i is the leverage of the observation.
+	HC2
+
+	// HC3 divides the squared residuals of HC0 by (1-h_i)^2.
+	// HC3 approximates the jackknife estimator and performs
+	// well in small samples.
+	HC3
+)
+
+// Linear is a linear regression model
+//  y_i = x_iᵀβ + ε_i
+// with independent errors ε_i of variance σ²/w_i. The results of the
+// regression are only valid if the call to Fit was successful.
+type Linear struct {
+	ok bool
+
+	n, p      int
+	intercept bool
+
+	coef []float64
+
+	// xw is the design matrix with rows scaled by the square
+	// roots of the weights and unscaled is (XᵀWX)⁻¹.
+	xw       *mat.Dense
+	unscaled *mat.SymDense
+
+	weights  []float64
+	fitted   []float64
+	resid    []float64
+	leverage []float64
+
+	rss, tss float64
+}
+
+// Fit fits the linear regression of the response y on the columns of the
+// n×p design matrix x by weighted least squares, minimizing
+//  Σ_i w_i (y_i - x_iᵀβ)^2
+// The coefficients are computed from the QR decomposition of the weighted
+// design matrix. If intercept is true, a column of ones is prepended to the
+// design matrix and the intercept is the first coefficient of the model.
+//
+// If weights is nil all of the weights are one, otherwise the weights must
+// be positive and are the precisions of the observations relative to each
+// other. Fit will panic if the lengths of y and weights do not equal the
+// number of rows of x, if any weight is not positive or if there are not
+// more observations than coefficients.
+//
+// Fit returns ErrRankDeficient if the columns of the design matrix are
+// linearly dependent, in which case the receiver does not hold a valid fit.
+func (l *Linear) Fit(x mat.Matrix, y, weights []float64, intercept bool) error {
+	l.ok = false
+	n, c := x.Dims()
+	if len(y) != n {
+		panic(badLength)
+	}
+	if weights != nil && len(weights) != n {
+		panic(badLength)
+	}
+	p := c
+	if intercept {
+		p++
+	}
+	if n <= p {
+		panic(tooFewObs)
+	}
+
+	xw := mat.NewDense(n, p, nil)
+	yw := make([]float64, n)
+	for i := 0; i < n; i++ {
+		s := 1.0
+		if weights != nil {
+			if !(weights[i] > 0) {
+				panic(badWeight)
+			}
+			s = math.Sqrt(weights[i])
+		}
+		row := xw.RawRowView(i)
+		off := 0
+		if intercept {
+			row[0] = s
+			off = 1
+		}
+		for j := 0; j < c; j++ {
+			row[off+j] = s * x.At(i, j)
+		}
+		yw[i] = s * y[i]
+	}
+
+	var qr mat.QR
+	qr.Factorize(xw)
+	if qr.Cond() > mat.ConditionTolerance {
+		return ErrRankDeficient
+	}
+	var beta mat.VecDense
+	err := qr.SolveVecTo(&beta, false, mat.NewVecDense(n, yw))
+	if err != nil {
+		return ErrRankDeficient
+	}
+
+	// Compute (XᵀWX)⁻¹ = R⁻¹ R⁻ᵀ from the inverse of the triangular
+	// factor, and the leverages from the squared row norms of
+	// the orthonormal factor W^½ X R⁻¹.
+	rInv, unscaled, ok := lsq.Covariance(&qr)
+	if !ok {
+		return ErrRankDeficient
+	}
+	var q mat.Dense
+	q.Mul(xw, rInv)
+
+	l.n = n
+	l.p = p
+	l.intercept = intercept
+	l.coef = append(l.coef[:0], beta.RawVector().Data...)
+	l.xw = xw
+	l.unscaled = unscaled
+	l.weights = nil
+	if weights != nil {
+		l.weights = append(l.weights, weights...)
+	}
+	l.fitted = make([]float64, n)
+	l.resid = make([]float64, n)
+	l.leverage = make([]float64, n)
+
+	var ybar, sumW float64
+	for i := 0; i < n; i++ {
+		w := l.weight(i)
+		ybar += w * y[i]
+		sumW += w
+	}
+	ybar /= sumW
+	l.rss = 0
+	l.tss = 0
+	for i := 0; i < n; i++ {
+		w := l.weight(i)
+		s := math.Sqrt(w)
+		l.fitted[i] = floats.Dot(xw.RawRowView(i), l.coef) / s
+		l.resid[i] = y[i] - l.fitted[i]
+		l.rss += w * l.resid[i] * l.resid[i]
+		d := y[i]
+		if intercept {
+			d -= ybar
+		}
+		l.tss += w * d * d
+		qi := q.RawRowView(i)
+		l.leverage[i] = floats.Dot(qi, qi)
+	}
+	l.ok = true
+	return nil
+}
+
+// weight returns the weight of the ith observation.
+func (l *Linear) weight(i int) float64 {
+	if l.weights == nil {
+		return 1
+	}
+	return l.weights[i]
+}
+
+func (l *Linear) checkFit() {
+	if !l.ok {
+		panic(notFitted)
+	}
+}
+
+// useSlice returns dst if it is not nil, checking its length, or a new slice
+// of length n.
+func useSlice(dst []float64, n int) []float64 {
+	if dst == nil {
+		return make([]float64, n)
+	}
+	if len(dst) != n {
+		panic(badLength)
+	}
+	return dst
+}
+
+// DoF returns the degrees of freedom of the model, the number of
+// coefficients excluding the intercept, and of the residuals, the number of
+// observations less the number of coefficients.
+func (l *Linear) DoF() (model, residual int) {
+	l.checkFit()
+	model = l.p
+	if l.intercept {
+		model--
+	}
+	return model, l.n - l.p
+}
+
+// CoefficientsTo returns the estimated coefficients of the model, with the
+// intercept first if the model has one. If dst is not nil the coefficients
+// are stored in dst, which must have length equal to the number of
+// coefficients. CoefficientsTo will panic if the receiver does not hold a
+// successful fit.
+func (l *Linear) CoefficientsTo(dst []float64) []float64 {
+	l.checkFit()
+	dst = useSlice(dst, l.p)
+	copy(dst, l.coef)
+	return dst
+}
+
+// Sigma returns the residual standard error, the estimate of the standard
+// deviation of the errors of observations with unit weight
+//  σ = sqrt(Σ_i w_i e_i^2 / (n-p))
+func (l *Linear) Sigma() float64 {
+	l.checkFit()
+	return math.Sqrt(l.rss / float64(l.n-l.p))
+}
+
+// CovarianceTo computes the covariance matrix of the estimated coefficients
+// with the given estimator and stores it in dst. If dst is empty, CovarianceTo
+// will resize dst to be p×p. When dst is non-empty, CovarianceTo will panic
+// if dst is not p×p. CovarianceTo will also panic if the receiver does not
+// hold a successful fit or kind is not a valid CovarianceKind.
+func (l *Linear) CovarianceTo(dst *mat.SymDense, kind CovarianceKind) {
+	l.checkFit()
+	if dst.IsEmpty() {
+		dst.ReuseAsSym(l.p)
+	} else if dst.Symmetric() != l.p {
+		panic(mat.ErrShape)
+	}
+	if kind == Classical {
+		sigma := l.Sigma()
+		dst.ScaleSym(sigma*sigma, l.unscaled)
+		return
+	}
+
+	// Form the sandwich (XᵀWX)⁻¹ Xᵀ W^½ Ω W^½ X (XᵀWX)⁻¹ where Ω
+	// holds the estimated variances of the weighted errors.
+	scaled := mat.NewDense(l.n, l.p, nil)
+	for i := 0; i < l.n; i++ {
+		e := math.Sqrt(l.weight(i)) * l.resid[i]
+		omega := e * e
+		switch kind {
+		case HC0:
+		case HC1:
+			omega *= float64(l.n) / float64(l.n-l.p)
+		case HC2:
+			omega /= 1 - l.leverage[i]
+		case HC3:
+			omega /= (1 - l.leverage[i]) * (1 - l.leverage[i])
+		default:
+			panic(badCovariance)
+		}
+		floats.ScaleTo(scaled.RawRowView(i), math.Sqrt(omega), l.xw.RawRowView(i))
+	}
+	var meat mat.SymDense
+	meat.SymOuterK(1, scaled.T())
+	var tmp, cov mat.Dense
+	tmp.Mul(l.unscaled, &meat)
+	cov.Mul(&tmp, l.unscaled)
+	for i := 0; i < l.p; i++ {
+		for j := i; j < l.p; j++ {
+			dst.SetSym(i, j, (cov.At(i, j)+cov.At(j, i))/2)
+		}
+	}
+}
+
+// StdErrorsTo returns the standard errors of the estimated coefficients
+// computed with the given covariance estimator. If dst is not nil the
+// standard errors are stored in dst, which must have length equal to the
+// number of coefficients.
+func (l *Linear) StdErrorsTo(dst []float64, kind CovarianceKind) []float64 {
+	l.checkFit()
+	dst = useSlice(dst, l.p)
+	var cov mat.SymDense
+	l.CovarianceTo(&cov, kind)
+	for i := range dst {
+		dst[i] = math.Sqrt(cov.At(i, i))
+	}
+	return dst
+}
+
+// TStatisticsTo returns the t statistics of the tests of the hypotheses
+// that each of the coefficients is zero, computed with the given covariance
+// estimator. If dst is not nil the statistics are stored in dst, which must
+// have length equal to the number of coefficients.
+func (l *Linear) TStatisticsTo(dst []float64, kind CovarianceKind) []float64 {
+	dst = l.StdErrorsTo(dst, kind)
+	for i, se := range dst {
+		dst[i] = l.coef[i] / se
+	}
+	return dst
+}
+
+// PValuesTo returns the two-sided p-values of the tests of the hypotheses
+// that each of the coefficients is zero, computed with the given covariance
+// estimator and the Student's t distribution with n-p degrees of freedom.
+// If dst is not nil the p-values are stored in dst, which must have length
+// equal to the number of coefficients.
+func (l *Linear) PValuesTo(dst []float64, kind CovarianceKind) []float64 {
+	dst = l.TStatisticsTo(dst, kind)
+	t := distuv.StudentsT{Mu: 0, Sigma: 1, Nu: float64(l.n - l.p)}
+	for i, v := range dst {
+		dst[i] = 2 * t.Survival(math.Abs(v))
+	}
+	return dst
+}
+
+// RSquared returns the coefficient of determination
+//  R² = 1 - Σ_i w_i e_i^2 / Σ_i w_i (y_i - ȳ)^2
+// where ȳ is the weighted mean of the response. If the model has no
+// intercept, ȳ is taken to be zero.
+func (l *Linear) RSquared() float64 {
+	l.checkFit()
+	return 1 - l.rss/l.tss
+}
+
+// AdjustedRSquared returns the coefficient of determination adjusted for
+// the number of coefficients in the model
+//  1 - (1 - R²) (n - k)/(n - p)
+// where k is one if the model has an intercept and zero otherwise.
+func (l *Linear) AdjustedRSquared() float64 {
+	l.checkFit()
+	k := 0
+	if l.intercept {
+		k = 1
+	}
+	return 1 - (1-l.RSquared())*float64(l.n-k)/float64(l.n-l.p)
+}
+
+// FTest returns the F statistic and p-value of the test of the hypothesis
+// that all of the coefficients other than the intercept are zero.
+func (l *Linear) FTest() (f, p float64) {
+	dfModel, dfResid := l.DoF()
+	f = ((l.tss - l.rss) / float64(dfModel)) / (l.rss / float64(dfResid))
+	return f, distuv.F{D1: float64(dfModel), D2: float64(dfResid)}.Survival(f)
+}
+
+// FittedTo returns the fitted values of the response. If dst is not nil
+// the fitted values are stored in dst, which must have length equal to the
+// number of observations.
+func (l *Linear) FittedTo(dst []float64) []float64 {
+	l.checkFit()
+	dst = useSlice(dst, l.n)
+	copy(dst, l.fitted)
+	return dst
+}
+
+// ResidualsTo returns the residuals e_i = y_i - ŷ_i of the fit. If dst is
+// not nil the residuals are stored in dst, which must have length equal to
+// the number of observations.
+func (l *Linear) ResidualsTo(dst []float64) []float64 {
+	l.checkFit()
+	dst = useSlice(dst, l.n)
+	copy(dst, l.resid)
+	return dst
+}
+
+// LeverageTo returns the leverages of the observations, the diagonal
+// elements of the hat matrix W^½ X (XᵀWX)⁻¹ Xᵀ W^½. If dst is not nil the
+// leverages are stored in dst, which must have length equal to the number
+// of observations.
+func (l *Linear) LeverageTo(dst []float64) []float64 {
+	l.checkFit()
+	dst = useSlice(dst, l.n)
+	copy(dst, l.leverage)
+	return dst
+}
+
+// StandardizedResidualsTo returns the internally studentized residuals
+//  r_i = sqrt(w_i) e_i / (σ sqrt(1 - h_i))
+// where h_i is the leverage of the observation. If dst is not nil the
+// residuals are stored in dst, which must have length equal to the number
+// of observations.
+func (l *Linear) StandardizedResidualsTo(dst []float64) []float64 {
+	l.checkFit()
+	dst = useSlice(dst, l.n)
+	sigma := l.Sigma()
+	for i, e := range l.resid {
+		dst[i] = math.Sqrt(l.weight(i)) * e / (sigma * math.Sqrt(1-l.leverage[i]))
+	}
+	return dst
+}
+
+// StudentizedResidualsTo returns the externally studentized residuals, the
+// standardized residuals computed with σ estimated from the fit with the
+// observation left out,
+//  t_i = r_i sqrt((n - p - 1)/(n - p - r_i^2))
+// If dst is not nil the residuals are stored in dst, which must have length
+// equal to the number of observations.
+func (l *Linear) StudentizedResidualsTo(dst []float64) []float64 {
+	dst = l.StandardizedResidualsTo(dst)
+	df := float64(l.n - l.p)
+	for i, r := range dst {
+		dst[i] = r * math.Sqrt((df-1)/(df-r*r))
+	}
+	return dst
+}
+
+// CooksDistanceTo returns Cook's distances of the observations
+//  D_i = r_i^2 h_i / (p (1 - h_i))
+// which measure the influence of each observation on the fit. If dst is not
+// nil the distances are stored in dst, which must have length equal to the
+// number of observations.
+func (l *Linear) CooksDistanceTo(dst []float64) []float64 {
+	dst = l.StandardizedResidualsTo(dst)
+	for i, r := range dst {
+		h := l.leverage[i]
+		dst[i] = r * r * h / (float64(l.p) * (1 - h))
+	}
+	return dst
+}
+
+// design returns the row of the design matrix corresponding to the
+// predictors x.
+func (l *Linear) design(x []float64) []float64 {
+	l.checkFit()
+	c := l.p
+	if l.intercept {
+		c--
+	}
+	if len(x) != c {
+		panic(badLength)
+	}
+	if !l.intercept {
+		return x
+	}
+	return append([]float64{1}, x...)
+}
+
+// Predict returns the predicted value of the response for the predictors x,
+// which excludes the intercept. Predict will panic if the length of x does
+// not equal the number of columns of the design matrix used in the fit.
+func (l *Linear) Predict(x []float64) float64 {
+	return floats.Dot(l.design(x), l.coef)
+}
+
+// ConfidenceInterval returns the bounds of the confidence interval with
+// the given level for the mean of the response for the predictors x.
+// ConfidenceInterval will panic if level is not in (0, 1) or the length of
+// x does not equal the number of columns of the design matrix used in the
+// fit.
+func (l *Linear) ConfidenceInterval(x []float64, level float64) (lower, upper float64) {
+	return l.interval(x, level, 0)
+}
+
+// PredictionInterval returns the bounds of the prediction interval with
+// the given level for a new observation of the response with unit weight
+// for the predictors x. PredictionInterval will panic if level is not in
+// (0, 1) or the length of x does not equal the number of columns of the
+// design matrix used in the fit.
+func (l *Linear) PredictionInterval(x []float64, level float64) (lower, upper float64) {
+	return l.interval(x, level, 1)
+}
+
+// interval returns the interval with the given level about the predicted
+// response at x, where extra is the variance of a new observation relative
+// to σ².
+func (l *Linear) interval(x []float64, level, extra float64) (lower, upper float64) {
+	if !(0 < level && level < 1) {
+		panic(badLevel)
+	}
+	d := mat.NewVecDense(l.p, l.design(x))
+	pred := floats.Dot(d.RawVector().Data, l.coef)
+	sigma := l.Sigma()
+	se := sigma * math.Sqrt(extra+mat.Inner(d, l.unscaled, d))
+	t := distuv.StudentsT{Mu: 0, Sigma: 1, Nu: float64(l.n - l.p)}.Quantile(0.5 + level/2)
+	return pred - t*se, pred + t*se
+}
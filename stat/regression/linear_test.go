@@ -0,0 +1,404 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regression
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		r := recover()
+		panicked = r != nil
+	}()
+	fn()
+	return
+}
+
+// cars is the cars data set from R.
+var cars = struct{ speed, dist []float64 }{
+	speed: []float64{
+		4, 4, 7, 7, 8, 9, 10, 10, 10, 11,
+		11, 12, 12, 12, 12, 13, 13, 13, 13, 14,
+		14, 14, 14, 15, 15, 15, 16, 16, 17, 17,
+		17, 18, 18, 18, 18, 19, 19, 19, 20, 20,
+		20, 20, 20, 22, 23, 24, 24, 24, 24, 25,
+	},
+	dist: []float64{
+		2, 10, 4, 22, 16, 10, 18, 26, 34, 17,
+		28, 14, 20, 24, 28, 26, 34, 34, 46, 26,
+		36, 60, 80, 20, 26, 54, 32, 40, 32, 40,
+		50, 42, 56, 76, 84, 36, 46, 68, 32, 48,
+		52, 56, 64, 66, 54, 70, 92, 93, 120, 85,
+	},
+}
+
+func TestLinearCars(t *testing.T) {
+	// Values from R summary(lm(dist ~ speed, data = cars)).
+	x := mat.NewDense(len(cars.speed), 1, cars.speed)
+	var l Linear
+	if err := l.Fit(x, cars.dist, nil, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, test := range []struct {
+		name      string
+		got, want []float64
+		tol       float64
+	}{
+		{name: "coefficients", got: l.CoefficientsTo(nil), want: []float64{-17.5791, 3.9324}, tol: 1e-4},
+		{name: "standard errors", got: l.StdErrorsTo(nil, Classical), want: []float64{6.7584, 0.4155}, tol: 1e-4},
+		{name: "t statistics", got: l.TStatisticsTo(nil, Classical), want: []float64{-2.601, 9.464}, tol: 1e-3},
+		{name: "p-values", got: l.PValuesTo(nil, Classical), want: []float64{0.0123, 1.49e-12}, tol: 1e-4},
+	} {
+		if !floats.EqualApprox(test.got, test.want, test.tol) {
+			t.Errorf("unexpected %s: got:%v want:%v", test.name, test.got, test.want)
+		}
+	}
+	for _, test := range []struct {
+		name      string
+		got, want float64
+		tol       float64
+	}{
+		{name: "sigma", got: l.Sigma(), want: 15.38, tol: 1e-2},
+		{name: "R²", got: l.RSquared(), want: 0.6511, tol: 1e-4},
+		{name: "adjusted R²", got: l.AdjustedRSquared(), want: 0.6438, tol: 1e-4},
+	} {
+		if math.Abs(test.got-test.want) > test.tol {
+			t.Errorf("unexpected %s: got:%v want:%v", test.name, test.got, test.want)
+		}
+	}
+	f, p := l.FTest()
+	if math.Abs(f-89.57) > 1e-2 || math.Abs(p-1.49e-12)/1.49e-12 > 1e-2 {
+		t.Errorf("unexpected F test: got:%v,%v want:89.57,1.49e-12", f, p)
+	}
+	if dfModel, dfResid := l.DoF(); dfModel != 1 || dfResid != 48 {
+		t.Errorf("unexpected degrees of freedom: got:%d,%d want:1,48", dfModel, dfResid)
+	}
+
+	// The coefficients agree with the simple regression.
+	alpha, beta := stat.LinearRegression(cars.speed, cars.dist, nil, false)
+	if got := l.CoefficientsTo(nil); !floats.EqualApprox(got, []float64{alpha, beta}, 1e-12) {
+		t.Errorf("coefficients differ from simple regression: got:%v want:%v", got, []float64{alpha, beta})
+	}
+}
+
+// randomRegression returns a random design matrix with c columns and
+// heteroscedastic responses and weights for n observations.
+func randomRegression(rnd *rand.Rand, n, c int) (x *mat.Dense, y, w []float64) {
+	x = mat.NewDense(n, c, nil)
+	y = make([]float64, n)
+	w = make([]float64, n)
+	for i := 0; i < n; i++ {
+		y[i] = 1
+		for j := 0; j < c; j++ {
+			v := rnd.NormFloat64()
+			x.Set(i, j, v)
+			y[i] += float64(j+1) * v
+		}
+		y[i] += (1 + math.Abs(x.At(i, 0))) * rnd.NormFloat64()
+		w[i] = 0.5 + rnd.Float64()
+	}
+	return x, y, w
+}
+
+// withIntercept returns x with a column of ones prepended.
+func withIntercept(x mat.Matrix) *mat.Dense {
+	n, c := x.Dims()
+	d := mat.NewDense(n, c+1, nil)
+	for i := 0; i < n; i++ {
+		d.Set(i, 0, 1)
+		for j := 0; j < c; j++ {
+			d.Set(i, j+1, x.At(i, j))
+		}
+	}
+	return d
+}
+
+func TestLinear(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, c      int
+		intercept bool
+		weighted  bool
+	}{
+		{n: 20, c: 1, intercept: true},
+		{n: 30, c: 3, intercept: true, weighted: true},
+		{n: 25, c: 2, intercept: false},
+		{n: 40, c: 4, intercept: false, weighted: true},
+	} {
+		x, y, w := randomRegression(rnd, test.n, test.c)
+		if !test.weighted {
+			w = nil
+		}
+		var l Linear
+		if err := l.Fit(x, y, w, test.intercept); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		d := mat.DenseCopyOf(x)
+		if test.intercept {
+			d = withIntercept(x)
+		}
+		p := test.c
+		if test.intercept {
+			p++
+		}
+		weight := func(i int) float64 {
+			if w == nil {
+				return 1
+			}
+			return w[i]
+		}
+
+		// Compare with the solution of the normal equations.
+		wd := mat.NewDense(test.n, p, nil)
+		for i := 0; i < test.n; i++ {
+			floats.ScaleTo(wd.RawRowView(i), weight(i), d.RawRowView(i))
+		}
+		var xtwx, inv mat.Dense
+		xtwx.Mul(d.T(), wd)
+		if err := inv.Inverse(&xtwx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var xtwy, beta mat.VecDense
+		xtwy.MulVec(wd.T(), mat.NewVecDense(test.n, y))
+		beta.MulVec(&inv, &xtwy)
+		coef := l.CoefficientsTo(nil)
+		if !floats.EqualApprox(coef, beta.RawVector().Data, 1e-10) {
+			t.Errorf("unexpected coefficients: got:%v want:%v", coef, beta.RawVector().Data)
+		}
+
+		resid := l.ResidualsTo(nil)
+		fitted := l.FittedTo(nil)
+		var rss float64
+		for i := range y {
+			if math.Abs(fitted[i]+resid[i]-y[i]) > 1e-12 {
+				t.Errorf("fitted values and residuals do not sum to response")
+			}
+			rss += weight(i) * resid[i] * resid[i]
+		}
+		sigma2 := rss / float64(test.n-p)
+		if got := l.Sigma(); math.Abs(got*got-sigma2) > 1e-12 {
+			t.Errorf("unexpected sigma: got:%v want:%v", got, math.Sqrt(sigma2))
+		}
+
+		var cov mat.SymDense
+		l.CovarianceTo(&cov, Classical)
+		for i := 0; i < p; i++ {
+			for j := 0; j < p; j++ {
+				if math.Abs(cov.At(i, j)-sigma2*inv.At(i, j)) > 1e-12 {
+					t.Errorf("unexpected classical covariance at %d,%d: got:%v want:%v", i, j, cov.At(i, j), sigma2*inv.At(i, j))
+				}
+			}
+		}
+
+		// Leverages are the diagonal of the hat matrix and sum to
+		// the number of coefficients.
+		lev := l.LeverageTo(nil)
+		for i := range lev {
+			di := mat.NewVecDense(p, d.RawRowView(i))
+			want := weight(i) * mat.Inner(di, &inv, di)
+			if math.Abs(lev[i]-want) > 1e-12 {
+				t.Errorf("unexpected leverage: got:%v want:%v", lev[i], want)
+			}
+		}
+		if sum := floats.Sum(lev); math.Abs(sum-float64(p)) > 1e-10 {
+			t.Errorf("leverages do not sum to number of coefficients: got:%v want:%d", sum, p)
+		}
+
+		// Heteroscedasticity-consistent covariance matrices.
+		for _, kind := range []CovarianceKind{HC0, HC1, HC2, HC3} {
+			meat := mat.NewSymDense(p, nil)
+			for i := 0; i < test.n; i++ {
+				e := weight(i) * resid[i]
+				omega := e * e
+				switch kind {
+				case HC1:
+					omega *= float64(test.n) / float64(test.n-p)
+				case HC2:
+					omega /= 1 - lev[i]
+				case HC3:
+					omega /= (1 - lev[i]) * (1 - lev[i])
+				}
+				meat.SymRankOne(meat, omega, mat.NewVecDense(p, d.RawRowView(i)))
+			}
+			var tmp, want mat.Dense
+			tmp.Mul(&inv, meat)
+			want.Mul(&tmp, &inv)
+			var got mat.SymDense
+			l.CovarianceTo(&got, kind)
+			if !mat.EqualApprox(&got, &want, 1e-10) {
+				t.Errorf("unexpected HC%d covariance:\ngot: %v\nwant:%v", kind-HC0, mat.Formatted(&got), mat.Formatted(&want))
+			}
+			se := l.StdErrorsTo(nil, kind)
+			for i := range se {
+				if math.Abs(se[i]-math.Sqrt(want.At(i, i))) > 1e-10 {
+					t.Errorf("unexpected HC%d standard error: got:%v want:%v", kind-HC0, se[i], math.Sqrt(want.At(i, i)))
+				}
+			}
+		}
+
+		// Studentized residuals and Cook's distances from fits
+		// leaving out each observation in turn.
+		std := l.StandardizedResidualsTo(nil)
+		stud := l.StudentizedResidualsTo(nil)
+		cook := l.CooksDistanceTo(nil)
+		for i := 0; i < test.n; i++ {
+			keep := make([]int, 0, test.n-1)
+			for j := 0; j < test.n; j++ {
+				if j != i {
+					keep = append(keep, j)
+				}
+			}
+			xi := mat.NewDense(test.n-1, test.c, nil)
+			yi := make([]float64, test.n-1)
+			var wi []float64
+			if w != nil {
+				wi = make([]float64, test.n-1)
+			}
+			for k, j := range keep {
+				xi.SetRow(k, x.RawRowView(j))
+				yi[k] = y[j]
+				if w != nil {
+					wi[k] = w[j]
+				}
+			}
+			var li Linear
+			if err := li.Fit(xi, yi, wi, test.intercept); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			s := li.Sigma()
+			want := math.Sqrt(weight(i)) * resid[i] / (s * math.Sqrt(1-lev[i]))
+			if math.Abs(stud[i]-want) > 1e-10 {
+				t.Errorf("unexpected studentized residual: got:%v want:%v", stud[i], want)
+			}
+			wantStd := math.Sqrt(weight(i)) * resid[i] / (math.Sqrt(sigma2) * math.Sqrt(1-lev[i]))
+			if math.Abs(std[i]-wantStd) > 1e-10 {
+				t.Errorf("unexpected standardized residual: got:%v want:%v", std[i], wantStd)
+			}
+			var shift float64
+			for j := 0; j < test.n; j++ {
+				v := x.RawRowView(j)
+				delta := l.Predict(v) - li.Predict(v)
+				shift += weight(j) * delta * delta
+			}
+			wantCook := shift / (float64(p) * sigma2)
+			if math.Abs(cook[i]-wantCook) > 1e-10 {
+				t.Errorf("unexpected Cook's distance: got:%v want:%v", cook[i], wantCook)
+			}
+		}
+
+		// Confidence and prediction intervals.
+		v := make([]float64, test.c)
+		for j := range v {
+			v[j] = rnd.NormFloat64()
+		}
+		dv := v
+		if test.intercept {
+			dv = append([]float64{1}, v...)
+		}
+		pred := floats.Dot(dv, coef)
+		if got := l.Predict(v); math.Abs(got-pred) > 1e-12 {
+			t.Errorf("unexpected prediction: got:%v want:%v", got, pred)
+		}
+		q := distuv.StudentsT{Mu: 0, Sigma: 1, Nu: float64(test.n - p)}.Quantile(0.975)
+		dvec := mat.NewVecDense(p, dv)
+		h := mat.Inner(dvec, &inv, dvec)
+		lo, hi := l.ConfidenceInterval(v, 0.95)
+		if want := q * math.Sqrt(sigma2*h); math.Abs(lo-(pred-want)) > 1e-10 || math.Abs(hi-(pred+want)) > 1e-10 {
+			t.Errorf("unexpected confidence interval: got:[%v,%v] want:[%v,%v]", lo, hi, pred-want, pred+want)
+		}
+		lo, hi = l.PredictionInterval(v, 0.95)
+		if want := q * math.Sqrt(sigma2*(1+h)); math.Abs(lo-(pred-want)) > 1e-10 || math.Abs(hi-(pred+want)) > 1e-10 {
+			t.Errorf("unexpected prediction interval: got:[%v,%v] want:[%v,%v]", lo, hi, pred-want, pred+want)
+		}
+	}
+}
+
+func TestLinearNoIntercept(t *testing.T) {
+	// Without an intercept, R² is computed about zero and the
+	// F test includes all of the coefficients.
+	x := mat.NewDense(5, 1, []float64{1, 2, 3, 4, 5})
+	y := []float64{1.1, 1.9, 3.2, 3.9, 5.1}
+	var l Linear
+	if err := l.Fit(x, y, nil, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	beta := floats.Dot(x.RawMatrix().Data, y) / 55
+	if got := l.CoefficientsTo(nil); math.Abs(got[0]-beta) > 1e-14 {
+		t.Errorf("unexpected coefficient: got:%v want:%v", got[0], beta)
+	}
+	var rss float64
+	for i, v := range y {
+		e := v - beta*float64(i+1)
+		rss += e * e
+	}
+	tss := floats.Dot(y, y)
+	if got, want := l.RSquared(), 1-rss/tss; math.Abs(got-want) > 1e-14 {
+		t.Errorf("unexpected R²: got:%v want:%v", got, want)
+	}
+	f, _ := l.FTest()
+	if want := (tss - rss) / (rss / 4); math.Abs(f-want) > 1e-10*want {
+		t.Errorf("unexpected F statistic: got:%v want:%v", f, want)
+	}
+
+}
+
+func TestLinearErrors(t *testing.T) {
+	x := mat.NewDense(4, 2, []float64{
+		1, 2,
+		2, 4,
+		3, 6,
+		4, 8,
+	})
+	y := []float64{1, 2, 3, 5}
+	var l Linear
+	if err := l.Fit(x, y, nil, true); err != ErrRankDeficient {
+		t.Errorf("unexpected error for collinear design: got:%v want:%v", err, ErrRankDeficient)
+	}
+	if !panics(func() { l.CoefficientsTo(nil) }) {
+		t.Errorf("expected panic for use of unsuccessful fit")
+	}
+
+	x = mat.NewDense(4, 1, []float64{1, 2, 3, 4})
+	for _, test := range []struct {
+		name      string
+		y, w      []float64
+		x         mat.Matrix
+		intercept bool
+	}{
+		{name: "response length", x: x, y: y[:3]},
+		{name: "weights length", x: x, y: y, w: []float64{1}},
+		{name: "zero weight", x: x, y: y, w: []float64{1, 0, 1, 1}},
+		{name: "too few observations", x: mat.NewDense(2, 2, []float64{1, 2, 3, 4}), y: []float64{1, 2}, intercept: true},
+	} {
+		if !panics(func() { l.Fit(test.x, test.y, test.w, test.intercept) }) {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+
+	if err := l.Fit(x, y, nil, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !panics(func() { l.Predict([]float64{1, 2}) }) {
+		t.Errorf("expected panic for predictor length mismatch")
+	}
+	if !panics(func() { l.PredictionInterval([]float64{1}, 1) }) {
+		t.Errorf("expected panic for bad level")
+	}
+	if !panics(func() { l.StdErrorsTo(nil, HC3+1) }) {
+		t.Errorf("expected panic for bad covariance kind")
+	}
+	if !panics(func() { l.ResidualsTo(make([]float64, 3)) }) {
+		t.Errorf("expected panic for destination length mismatch")
+	}
+}
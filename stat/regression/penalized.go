@@ -0,0 +1,748 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regression
+
+import (
+	"math"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	badAlpha    = "regression: alpha not in [0, 1]"
+	badFolds    = "regression: number of folds not in [2, n]"
+	badLambda   = "regression: lambdas not positive and decreasing"
+	badLoss     = "regression: bad loss"
+	badResponse = "regression: logistic response not in [0, 1]"
+	negWeight   = "regression: negative weight"
+	noObs       = "regression: no observations"
+	zeroWeights = "regression: weights sum to zero"
+)
+
+const (
+	// defaultNumLambda is the default length of a regularization path.
+	defaultNumLambda = 100
+
+	// defaultPathTolerance and defaultPathIterations are the default
+	// convergence tolerance and maximum number of passes over the
+	// coefficients of the coordinate descent solver.
+	defaultPathTolerance  = 1e-7
+	defaultPathIterations = 100000
+
+	// minAlpha is the smallest mixing parameter used to compute the
+	// largest penalty of a path, which is infinite for ridge regression.
+	minAlpha = 1e-3
+
+	// minProbVariance bounds the binomial variance used in the
+	// working weights of logistic fits.
+	minProbVariance = 1e-5
+)
+
+// Loss specifies the loss function of a penalized regression.
+type Loss int
+
+const (
+	// SquaredLoss is half the weighted mean squared error of a
+	// linear model.
+	SquaredLoss Loss = iota
+
+	// LogisticLoss is the weighted mean negative log-likelihood
+	// of a logistic regression model for a response in [0, 1].
+	LogisticLoss
+)
+
+// ElasticNet is an elastic net penalized regression, which minimizes
+//  L(β_0, β) + λ ((1-α)/2 ||β||_2^2 + α ||β||_1)
+// over the intercept β_0 and the coefficients β, where L is the loss and λ
+// the strength of the penalty. The mixing parameter α gives the lasso when
+// it is one and ridge regression when it is zero.
+//
+// The predictors are standardized to have zero weighted mean and unit
+// weighted variance before fitting, so the penalty treats the predictors
+// equally. The coefficients are reported on the original scale and the
+// intercept is not penalized.
+//
+// The fields of ElasticNet specify the model and must be set before
+// calling Path or CrossValidate.
+type ElasticNet struct {
+	// Alpha is the mixing parameter of the penalty in [0, 1].
+	Alpha float64
+
+	// Loss is the loss function of the regression.
+	Loss Loss
+
+	// Lambda holds the decreasing penalties of the path. If Lambda
+	// is nil, NumLambda penalties are spaced logarithmically from
+	// the smallest penalty for which all coefficients are zero
+	// down to that penalty times LambdaMinRatio.
+	Lambda []float64
+
+	// NumLambda is the length of a computed path. If NumLambda is
+	// zero, 100 is used.
+	NumLambda int
+
+	// LambdaMinRatio is the ratio of the smallest to the largest
+	// penalty of a computed path. If LambdaMinRatio is zero, 1e-4
+	// is used when there are more observations than predictors
+	// and 1e-2 otherwise.
+	LambdaMinRatio float64
+
+	// Tolerance is the convergence tolerance of the coordinate
+	// descent, the largest weighted root mean square change in
+	// the linear predictor from the update of a single
+	// coefficient in a pass. If Tolerance is zero, 1e-7 is used.
+	Tolerance float64
+
+	// MaxIterations is the maximum number of passes over the
+	// coefficients at each penalty. If MaxIterations is zero,
+	// 100000 is used.
+	MaxIterations int
+}
+
+// Solution is the solution of an elastic net penalized regression at one
+// penalty of a path.
+type Solution struct {
+	// Lambda is the penalty of the solution.
+	Lambda float64
+
+	// Intercept is the estimated intercept.
+	Intercept float64
+
+	// Index holds the indices of the non-zero coefficients in
+	// increasing order and Coef holds their values.
+	Index []int
+	Coef  []float64
+
+	// DevRatio is the fraction of the deviance of the model with
+	// only an intercept that is explained by the solution.
+	DevRatio float64
+
+	// Iterations is the number of passes over the coefficients
+	// used to find the solution.
+	Iterations int
+
+	// Converged is whether the solver converged.
+	Converged bool
+
+	dim  int
+	loss Loss
+}
+
+// CoefficientsTo returns the dense vector of coefficients of the solution,
+// excluding the intercept. If dst is not nil the coefficients are stored in
+// dst, which must have length equal to the number of predictors.
+func (s *Solution) CoefficientsTo(dst []float64) []float64 {
+	if dst == nil {
+		dst = make([]float64, s.dim)
+	} else if len(dst) != s.dim {
+		panic(badLength)
+	} else {
+		for i := range dst {
+			dst[i] = 0
+		}
+	}
+	for k, j := range s.Index {
+		dst[j] = s.Coef[k]
+	}
+	return dst
+}
+
+// Predict returns the prediction of the solution for the predictors x, the
+// value of the linear predictor for squared loss and the probability of a
+// positive response for logistic loss. Predict will panic if the length of
+// x is not equal to the number of predictors.
+func (s *Solution) Predict(x []float64) float64 {
+	if len(x) != s.dim {
+		panic(badLength)
+	}
+	eta := s.Intercept
+	for k, j := range s.Index {
+		eta += s.Coef[k] * x[j]
+	}
+	if s.loss == LogisticLoss {
+		return 1 / (1 + math.Exp(-eta))
+	}
+	return eta
+}
+
+// Path computes the solutions of the elastic net along the regularization
+// path by cyclic coordinate descent, using the solution at each penalty as
+// the starting point at the next. Predictors are screened at each penalty
+// by the sequential strong rule and the Karush–Kuhn–Tucker conditions of
+// the discarded predictors are checked after convergence. Logistic loss is
+// minimized by a sequence of penalized weighted least squares problems.
+//
+// The rows of x hold the observations of the predictors with responses y.
+// If weights is nil all of the weights are one, otherwise the weights must
+// be non-negative and are normalized to sum to one. For logistic loss the
+// responses must be in [0, 1].
+//
+// Path will panic if x has no rows, the lengths of y and weights do not
+// equal the number of rows of x, any weight is negative, the weights sum to
+// zero, Alpha is not in [0, 1], Lambda is not positive and decreasing, Loss
+// is not valid or a response is not valid for the loss.
+func (e *ElasticNet) Path(x mat.Matrix, y, weights []float64) []Solution {
+	p := e.problem(x, y, weights)
+	return p.path(e.lambdas(p))
+}
+
+// problem returns the standardized problem for the data.
+func (e *ElasticNet) problem(x mat.Matrix, y, weights []float64) *penalized {
+	if !(0 <= e.Alpha && e.Alpha <= 1) {
+		panic(badAlpha)
+	}
+	if e.Loss != SquaredLoss && e.Loss != LogisticLoss {
+		panic(badLoss)
+	}
+	n, c := x.Dims()
+	if n == 0 {
+		panic(noObs)
+	}
+	if len(y) != n || (weights != nil && len(weights) != n) {
+		panic(badLength)
+	}
+	w := make([]float64, n)
+	var sum float64
+	for i := range w {
+		w[i] = 1
+		if weights != nil {
+			w[i] = weights[i]
+		}
+		if w[i] < 0 {
+			panic(negWeight)
+		}
+		sum += w[i]
+		if e.Loss == LogisticLoss && !(0 <= y[i] && y[i] <= 1) {
+			panic(badResponse)
+		}
+	}
+	if sum == 0 {
+		panic(zeroWeights)
+	}
+	floats.Scale(1/sum, w)
+
+	p := &penalized{
+		alpha: e.Alpha,
+		loss:  e.Loss,
+		tol:   e.Tolerance,
+		iter:  e.MaxIterations,
+		n:     n,
+		dim:   c,
+		w:     w,
+		y:     append([]float64(nil), y...),
+		mean:  make([]float64, c),
+		scale: make([]float64, c),
+		cols:  make([][]float64, c),
+	}
+	if p.tol == 0 {
+		p.tol = defaultPathTolerance
+	}
+	if p.iter == 0 {
+		p.iter = defaultPathIterations
+	}
+	p.ybar = floats.Dot(w, y)
+	for j := 0; j < c; j++ {
+		col := mat.Col(nil, j, x)
+		m := floats.Dot(w, col)
+		var v float64
+		for i, xi := range col {
+			col[i] = xi - m
+			v += w[i] * col[i] * col[i]
+		}
+		p.mean[j] = m
+		if v > 0 {
+			p.scale[j] = math.Sqrt(v)
+			floats.Scale(1/p.scale[j], col)
+		} else {
+			// Constant predictors are excluded from the fit.
+			for i := range col {
+				col[i] = 0
+			}
+		}
+		p.cols[j] = col
+	}
+	return p
+}
+
+// lambdas returns the penalties of the path for the problem.
+func (e *ElasticNet) lambdas(p *penalized) []float64 {
+	if e.Lambda != nil {
+		for i, l := range e.Lambda {
+			if !(l > 0) || (i > 0 && l >= e.Lambda[i-1]) {
+				panic(badLambda)
+			}
+		}
+		return e.Lambda
+	}
+	num := e.NumLambda
+	if num == 0 {
+		num = defaultNumLambda
+	}
+	ratio := e.LambdaMinRatio
+	if ratio == 0 {
+		ratio = 1e-4
+		if p.n <= p.dim {
+			ratio = 1e-2
+		}
+	}
+
+	// The largest penalty gives zero coefficients.
+	var max float64
+	for _, col := range p.cols {
+		var g float64
+		for i, v := range col {
+			g += p.w[i] * v * (p.y[i] - p.ybar)
+		}
+		max = math.Max(max, math.Abs(g))
+	}
+	// Enlarge the largest penalty slightly so that rounding does
+	// not give non-zero coefficients at the start of the path.
+	max *= (1 + 1e-10) / math.Max(p.alpha, minAlpha)
+	if max == 0 {
+		max = 1
+	}
+	lambda := make([]float64, num)
+	for i := range lambda {
+		if num == 1 {
+			lambda[i] = max
+			break
+		}
+		lambda[i] = max * math.Pow(ratio, float64(i)/float64(num-1))
+	}
+	return lambda
+}
+
+// penalized is a standardized elastic net problem.
+type penalized struct {
+	alpha float64
+	loss  Loss
+	tol   float64
+	iter  int
+
+	n, dim int
+	w      []float64
+	y      []float64
+	ybar   float64
+
+	// cols holds the standardized predictors, and mean
+	// and scale hold their weighted means and standard
+	// deviations.
+	cols  [][]float64
+	mean  []float64
+	scale []float64
+}
+
+// path returns the solutions of the problem at the given penalties.
+func (p *penalized) path(lambda []float64) []Solution {
+	beta := make([]float64, p.dim)
+	var b0 float64
+	if p.loss == SquaredLoss {
+		b0 = p.ybar
+	} else {
+		b0 = logit(p.ybar)
+	}
+
+	// eta holds the linear predictor of the current solution and
+	// grad holds the gradient of the loss with respect to the
+	// standardized coefficients.
+	eta := make([]float64, p.n)
+	for i := range eta {
+		eta[i] = b0
+	}
+	grad := make([]float64, p.dim)
+	p.gradient(grad, eta)
+
+	nullDev := p.deviance(eta)
+	sols := make([]Solution, len(lambda))
+	prev := lambda[0]
+	if len(lambda) > 1 {
+		prev = math.Max(lambda[0], 2*lambda[0]-lambda[1])
+	}
+	active := make([]bool, p.dim)
+	strong := make([]bool, p.dim)
+	for k, lam := range lambda {
+		// Discard predictors by the sequential strong rule.
+		for j := range strong {
+			strong[j] = active[j] || math.Abs(grad[j]) >= p.alpha*(2*lam-prev)
+		}
+		var iters int
+		converged := true
+		for {
+			n, ok := p.solve(beta, &b0, eta, lam, strong)
+			iters += n
+			converged = converged && ok
+
+			// Check the optimality conditions of the
+			// discarded predictors.
+			p.gradient(grad, eta)
+			violated := false
+			for j, s := range strong {
+				if !s && p.scale[j] > 0 && math.Abs(grad[j]) > p.alpha*lam {
+					strong[j] = true
+					violated = true
+				}
+			}
+			if !violated {
+				break
+			}
+		}
+		for j, b := range beta {
+			active[j] = b != 0
+		}
+		prev = lam
+
+		sol := Solution{
+			Lambda:     lam,
+			Intercept:  b0,
+			Iterations: iters,
+			Converged:  converged,
+			dim:        p.dim,
+			loss:       p.loss,
+		}
+		for j, b := range beta {
+			if b == 0 {
+				continue
+			}
+			v := b / p.scale[j]
+			sol.Index = append(sol.Index, j)
+			sol.Coef = append(sol.Coef, v)
+			sol.Intercept -= v * p.mean[j]
+		}
+		if nullDev > 0 {
+			sol.DevRatio = 1 - p.deviance(eta)/nullDev
+		}
+		sols[k] = sol
+	}
+	return sols
+}
+
+// gradient stores in grad the negative gradient of the loss with respect to
+// the standardized coefficients at the linear predictor eta.
+func (p *penalized) gradient(grad, eta []float64) {
+	for j, col := range p.cols {
+		var g float64
+		for i, v := range col {
+			g += p.w[i] * v * (p.y[i] - p.mean01(eta[i]))
+		}
+		grad[j] = g
+	}
+}
+
+// mean01 returns the mean of the response for the linear predictor eta.
+func (p *penalized) mean01(eta float64) float64 {
+	if p.loss == LogisticLoss {
+		return 1 / (1 + math.Exp(-eta))
+	}
+	return eta
+}
+
+// deviance returns the weighted mean deviance of the model with linear
+// predictor eta.
+func (p *penalized) deviance(eta []float64) float64 {
+	var dev float64
+	for i, y := range p.y {
+		dev += p.w[i] * unitDeviance(p.loss, y, eta[i])
+	}
+	return dev
+}
+
+// unitDeviance returns the deviance of an observation y for the linear
+// predictor eta.
+func unitDeviance(loss Loss, y, eta float64) float64 {
+	if loss == SquaredLoss {
+		return (y - eta) * (y - eta)
+	}
+	// -2 (y log μ + (1-y) log(1-μ)) + 2 (y log y + (1-y) log(1-y))
+	// with log μ = -log(1+exp(-η)) and log(1-μ) = -log(1+exp(η)).
+	d := 2 * (y*softplus(-eta) + (1-y)*softplus(eta))
+	if 0 < y && y < 1 {
+		d += 2 * (y*math.Log(y) + (1-y)*math.Log1p(-y))
+	}
+	return d
+}
+
+// softplus returns log(1+exp(x)).
+func softplus(x float64) float64 {
+	if x > 0 {
+		return x + math.Log1p(math.Exp(-x))
+	}
+	return math.Log1p(math.Exp(x))
+}
+
+// logit returns log(p/(1-p)) with p bounded away from zero and one.
+func logit(p float64) float64 {
+	p = math.Max(minProbVariance, math.Min(p, 1-minProbVariance))
+	return math.Log(p / (1 - p))
+}
+
+// solve minimizes the penalized loss over the intercept and the standardized
+// coefficients in the set, updating beta, b0 and the linear predictor eta
+// in place. It returns the number of passes over the coefficients and
+// whether the solver converged.
+func (p *penalized) solve(beta []float64, b0 *float64, eta []float64, lambda float64, set []bool) (int, bool) {
+	l1 := lambda * p.alpha
+	l2 := lambda * (1 - p.alpha)
+	if p.loss == SquaredLoss {
+		return p.descend(beta, b0, eta, p.w, p.y, l1, l2, set, p.iter)
+	}
+
+	// Minimize a sequence of quadratic approximations of the
+	// logistic loss.
+	v := make([]float64, p.n)
+	z := make([]float64, p.n)
+	old := make([]float64, p.dim)
+	var total int
+	for total < p.iter {
+		for i, e := range eta {
+			mu := 1 / (1 + math.Exp(-e))
+			s := math.Max(mu*(1-mu), minProbVariance)
+			v[i] = p.w[i] * s
+			z[i] = e + (p.y[i]-mu)/s
+		}
+		copy(old, beta)
+		oldB0 := *b0
+		n, ok := p.descend(beta, b0, eta, v, z, l1, l2, set, p.iter-total)
+		total += n
+		if !ok {
+			return total, false
+		}
+		change := math.Abs(*b0 - oldB0)
+		for j, b := range beta {
+			change = math.Max(change, math.Abs(b-old[j]))
+		}
+		if change < p.tol {
+			return total, true
+		}
+	}
+	return total, false
+}
+
+// descend minimizes
+//  1/2 Σ_i v_i (z_i - b0 - x_iᵀβ)^2 + l1 ||β||_1 + l2/2 ||β||_2^2
+// by cyclic coordinate descent over the coefficients in the set and the
+// intercept, starting from beta and b0, and updating eta = b0 + Xβ in place.
+// It returns the number of passes and whether the solver converged.
+func (p *penalized) descend(beta []float64, b0 *float64, eta, v, z []float64, l1, l2 float64, set []bool, maxIter int) (int, bool) {
+	r := make([]float64, p.n)
+	for i := range r {
+		r[i] = z[i] - eta[i]
+	}
+	sumV := floats.Sum(v)
+
+	// The curvature of each coordinate is Σ_i v_i x_ij^2, which
+	// is one for squared loss with standardized predictors.
+	curv := make([]float64, p.dim)
+	for j, col := range p.cols {
+		if !set[j] || p.scale[j] == 0 {
+			continue
+		}
+		for i, x := range col {
+			curv[j] += v[i] * x * x
+		}
+	}
+
+	for iter := 1; iter <= maxIter; iter++ {
+		var change float64
+		for j, col := range p.cols {
+			if !set[j] || p.scale[j] == 0 {
+				continue
+			}
+			var g float64
+			for i, x := range col {
+				g += v[i] * x * r[i]
+			}
+			old := beta[j]
+			b := softThreshold(g+curv[j]*old, l1) / (curv[j] + l2)
+			if b == old {
+				continue
+			}
+			beta[j] = b
+			d := b - old
+			for i, x := range col {
+				r[i] -= d * x
+				eta[i] += d * x
+			}
+			change = math.Max(change, math.Abs(d)*math.Sqrt(curv[j]))
+		}
+
+		// Update the unpenalized intercept.
+		if sumV > 0 {
+			d := floats.Dot(v, r) / sumV
+			*b0 += d
+			for i := range r {
+				r[i] -= d
+				eta[i] += d
+			}
+			change = math.Max(change, math.Abs(d)*math.Sqrt(sumV))
+		}
+		if change < p.tol {
+			return iter, true
+		}
+	}
+	return maxIter, false
+}
+
+// softThreshold returns the soft thresholding of x by t, sign(x) max(|x|-t, 0).
+func softThreshold(x, t float64) float64 {
+	switch {
+	case x > t:
+		return x - t
+	case x < -t:
+		return x + t
+	default:
+		return 0
+	}
+}
+
+// CrossValidation holds the results of a cross-validated elastic net
+// regression.
+type CrossValidation struct {
+	// Path holds the solutions fitted to all of the data.
+	Path []Solution
+
+	// Error and StdErr hold the mean cross-validated error at each
+	// penalty of the path and its standard error. The error is the
+	// weighted mean squared error for squared loss and the weighted
+	// mean deviance for logistic loss.
+	Error  []float64
+	StdErr []float64
+
+	// Min is the index of the penalty with the smallest error and
+	// OneSE is the index of the largest penalty with error within
+	// one standard error of the smallest.
+	Min   int
+	OneSE int
+}
+
+// CrossValidate computes the elastic net path for the data and estimates the
+// prediction error of each solution by k-fold cross-validation. The
+// observations are assigned to folds at random using src, or the global
+// random source if src is nil. Each fold is predicted by the path fitted to
+// the other folds with the penalties of the path fitted to all of the data.
+//
+// CrossValidate will panic if folds is not in [2, n] or for any of the
+// reasons Path panics.
+func (e *ElasticNet) CrossValidate(x mat.Matrix, y, weights []float64, folds int, src rand.Source) CrossValidation {
+	full := e.problem(x, y, weights)
+	lambda := e.lambdas(full)
+	cv := CrossValidation{Path: full.path(lambda)}
+
+	n, c := x.Dims()
+	if folds < 2 || folds > n {
+		panic(badFolds)
+	}
+	var perm []int
+	if src == nil {
+		perm = rand.Perm(n)
+	} else {
+		perm = rand.New(src).Perm(n)
+	}
+	fold := make([]int, n)
+	for k, i := range perm {
+		fold[i] = k % folds
+	}
+
+	sub := *e
+	sub.Lambda = lambda
+	foldErr := make([][]float64, folds)
+	foldWeight := make([]float64, folds)
+	for f := 0; f < folds; f++ {
+		var train, test []int
+		for i := 0; i < n; i++ {
+			if fold[i] == f {
+				test = append(test, i)
+			} else {
+				train = append(train, i)
+			}
+		}
+		xt := mat.NewDense(len(train), c, nil)
+		yt := make([]float64, len(train))
+		var wt []float64
+		if weights != nil {
+			wt = make([]float64, len(train))
+		}
+		for k, i := range train {
+			for j := 0; j < c; j++ {
+				xt.Set(k, j, x.At(i, j))
+			}
+			yt[k] = y[i]
+			if weights != nil {
+				wt[k] = weights[i]
+			}
+		}
+		if wt != nil && floats.Sum(wt) == 0 {
+			continue
+		}
+		sols := sub.problem(xt, yt, wt).path(lambda)
+
+		row := make([]float64, c)
+		foldErr[f] = make([]float64, len(lambda))
+		for _, i := range test {
+			w := 1.0
+			if weights != nil {
+				w = weights[i]
+			}
+			foldWeight[f] += w
+			for j := range row {
+				row[j] = x.At(i, j)
+			}
+			for l := range sols {
+				eta := sols[l].Intercept
+				for k, j := range sols[l].Index {
+					eta += sols[l].Coef[k] * row[j]
+				}
+				foldErr[f][l] += w * unitDeviance(e.Loss, y[i], eta)
+			}
+		}
+		if foldWeight[f] > 0 {
+			floats.Scale(1/foldWeight[f], foldErr[f])
+		}
+	}
+
+	// Combine the errors of the folds weighted by the total weight
+	// of their observations.
+	cv.Error = make([]float64, len(lambda))
+	cv.StdErr = make([]float64, len(lambda))
+	var total float64
+	var used int
+	for f, w := range foldWeight {
+		if foldErr[f] != nil && w > 0 {
+			total += w
+			used++
+		}
+	}
+	for l := range lambda {
+		var mean float64
+		for f, w := range foldWeight {
+			if foldErr[f] != nil && w > 0 {
+				mean += w * foldErr[f][l]
+			}
+		}
+		mean /= total
+		var v float64
+		for f, w := range foldWeight {
+			if foldErr[f] != nil && w > 0 {
+				d := foldErr[f][l] - mean
+				v += w * d * d
+			}
+		}
+		cv.Error[l] = mean
+		if used > 1 {
+			cv.StdErr[l] = math.Sqrt(v / total / float64(used-1))
+		}
+	}
+	cv.Min = floats.MinIdx(cv.Error)
+	limit := cv.Error[cv.Min] + cv.StdErr[cv.Min]
+	for l, err := range cv.Error[:cv.Min+1] {
+		if err <= limit {
+			cv.OneSE = l
+			break
+		}
+	}
+	return cv
+}
@@ -0,0 +1,292 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regression
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// sparseRegression returns a random design with correlated predictors and
+// responses depending on the first three predictors for the given loss.
+func sparseRegression(rnd *rand.Rand, n, c int, loss Loss) (x *mat.Dense, y, w []float64) {
+	x = mat.NewDense(n, c, nil)
+	y = make([]float64, n)
+	w = make([]float64, n)
+	for i := 0; i < n; i++ {
+		common := rnd.NormFloat64()
+		for j := 0; j < c; j++ {
+			x.Set(i, j, float64(j%3+1)*(0.5*common+rnd.NormFloat64())+float64(j))
+		}
+		eta := 0.5 + 1.5*(x.At(i, 0)-0) - 0.8*(x.At(i, 1)-1) + 0.3*(x.At(i, 2)-2)
+		switch loss {
+		case SquaredLoss:
+			y[i] = eta + rnd.NormFloat64()
+		case LogisticLoss:
+			if rnd.Float64() < 1/(1+math.Exp(-eta)) {
+				y[i] = 1
+			}
+		}
+		w[i] = 0.5 + rnd.Float64()
+	}
+	return x, y, w
+}
+
+// checkKKT checks the optimality conditions of the elastic net solution
+// on the standardized scale.
+func checkKKT(t *testing.T, name string, x mat.Matrix, y, weights []float64, e *ElasticNet, sol Solution, tol float64) {
+	n, c := x.Dims()
+	w := make([]float64, n)
+	for i := range w {
+		w[i] = 1
+		if weights != nil {
+			w[i] = weights[i]
+		}
+	}
+	floats.Scale(1/floats.Sum(w), w)
+
+	resid := make([]float64, n)
+	for i := range resid {
+		mu := sol.Predict(x.(*mat.Dense).RawRowView(i))
+		resid[i] = y[i] - mu
+	}
+	if g := floats.Dot(w, resid); math.Abs(g) > tol {
+		t.Errorf("%s: intercept gradient not zero at lambda=%v: got:%v", name, sol.Lambda, g)
+	}
+	coef := sol.CoefficientsTo(nil)
+	for j := 0; j < c; j++ {
+		col := mat.Col(nil, j, x)
+		m := floats.Dot(w, col)
+		var v, g float64
+		for i, xi := range col {
+			v += w[i] * (xi - m) * (xi - m)
+			g += w[i] * (xi - m) * resid[i]
+		}
+		sd := math.Sqrt(v)
+		g /= sd
+		b := coef[j] * sd
+		l1 := sol.Lambda * e.Alpha
+		l2 := sol.Lambda * (1 - e.Alpha)
+		if b == 0 {
+			if math.Abs(g) > l1+tol {
+				t.Errorf("%s: zero coefficient %d violates optimality at lambda=%v: |g|=%v > %v", name, j, sol.Lambda, math.Abs(g), l1)
+			}
+			continue
+		}
+		if want := l1*math.Copysign(1, b) + l2*b; math.Abs(g-want) > tol {
+			t.Errorf("%s: non-zero coefficient %d violates optimality at lambda=%v: g=%v want:%v", name, j, sol.Lambda, g, want)
+		}
+	}
+}
+
+func TestElasticNetPath(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		name     string
+		n, c     int
+		alpha    float64
+		loss     Loss
+		weighted bool
+	}{
+		{name: "lasso", n: 100, c: 10, alpha: 1, loss: SquaredLoss},
+		{name: "weighted elastic net", n: 80, c: 8, alpha: 0.5, loss: SquaredLoss, weighted: true},
+		{name: "wide lasso", n: 30, c: 60, alpha: 1, loss: SquaredLoss},
+		{name: "logistic lasso", n: 200, c: 8, alpha: 1, loss: LogisticLoss},
+		{name: "weighted logistic elastic net", n: 150, c: 6, alpha: 0.3, loss: LogisticLoss, weighted: true},
+	} {
+		x, y, w := sparseRegression(rnd, test.n, test.c, test.loss)
+		if !test.weighted {
+			w = nil
+		}
+		e := ElasticNet{Alpha: test.alpha, Loss: test.loss, NumLambda: 30, Tolerance: 1e-12}
+		path := e.Path(x, y, w)
+		if len(path) != 30 {
+			t.Fatalf("%s: unexpected path length: got:%d want:30", test.name, len(path))
+		}
+		if len(path[0].Index) != 0 {
+			t.Errorf("%s: unexpected non-zero coefficients at largest penalty: %v", test.name, path[0].Index)
+		}
+		for k, sol := range path {
+			if !sol.Converged {
+				t.Errorf("%s: solver did not converge at lambda=%v", test.name, sol.Lambda)
+			}
+			if k > 0 && !(sol.Lambda < path[k-1].Lambda) {
+				t.Errorf("%s: penalties not decreasing", test.name)
+			}
+			if k > 0 && sol.DevRatio < path[k-1].DevRatio-1e-10 {
+				t.Errorf("%s: deviance ratio decreased along path", test.name)
+			}
+			for i, j := range sol.Index {
+				if sol.Coef[i] == 0 || (i > 0 && j <= sol.Index[i-1]) {
+					t.Errorf("%s: bad sparse representation: index=%v coef=%v", test.name, sol.Index, sol.Coef)
+					break
+				}
+			}
+			checkKKT(t, test.name, x, y, w, &e, sol, 1e-8)
+		}
+		if r := path[len(path)-1].DevRatio; !(r > 0.3) {
+			t.Errorf("%s: unexpected deviance ratio at smallest penalty: got:%v", test.name, r)
+		}
+
+		// Solutions at individual penalties agree with the path.
+		k := len(path) / 2
+		single := ElasticNet{Alpha: test.alpha, Loss: test.loss, Lambda: []float64{path[k].Lambda}, Tolerance: 1e-12}
+		sol := single.Path(x, y, w)[0]
+		if !floats.EqualApprox(sol.CoefficientsTo(nil), path[k].CoefficientsTo(nil), 1e-7) {
+			t.Errorf("%s: cold start solution differs from path:\ngot: %v\nwant:%v", test.name, sol.CoefficientsTo(nil), path[k].CoefficientsTo(nil))
+		}
+	}
+}
+
+func TestElasticNetRidge(t *testing.T) {
+	// Ridge regression has a closed form solution on the
+	// standardized scale.
+	rnd := rand.New(rand.NewSource(1))
+	const n, c = 50, 4
+	x, y, _ := sparseRegression(rnd, n, c, SquaredLoss)
+	const lambda = 0.3
+	e := ElasticNet{Alpha: 0, Lambda: []float64{lambda}, Tolerance: 1e-14}
+	sol := e.Path(x, y, nil)[0]
+
+	xs := mat.NewDense(n, c, nil)
+	sd := make([]float64, c)
+	for j := 0; j < c; j++ {
+		col := mat.Col(nil, j, x)
+		m := floats.Sum(col) / n
+		var v float64
+		for _, xi := range col {
+			v += (xi - m) * (xi - m)
+		}
+		sd[j] = math.Sqrt(v / n)
+		for i, xi := range col {
+			xs.Set(i, j, (xi-m)/sd[j])
+		}
+	}
+	var a mat.Dense
+	a.Mul(xs.T(), xs)
+	a.Scale(1.0/n, &a)
+	for j := 0; j < c; j++ {
+		a.Set(j, j, a.At(j, j)+lambda)
+	}
+	var b, beta mat.VecDense
+	b.MulVec(xs.T(), mat.NewVecDense(n, y))
+	b.ScaleVec(1.0/n, &b)
+	if err := beta.SolveVec(&a, &b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := sol.CoefficientsTo(nil)
+	for j := range got {
+		if want := beta.AtVec(j) / sd[j]; math.Abs(got[j]-want) > 1e-10 {
+			t.Errorf("unexpected ridge coefficient %d: got:%v want:%v", j, got[j], want)
+		}
+	}
+}
+
+func TestElasticNetLeastSquares(t *testing.T) {
+	// The lasso with a vanishing penalty is least squares.
+	rnd := rand.New(rand.NewSource(1))
+	x, y, w := sparseRegression(rnd, 60, 5, SquaredLoss)
+	e := ElasticNet{Alpha: 1, Lambda: []float64{1e-12}, Tolerance: 1e-14}
+	sol := e.Path(x, y, w)[0]
+	var l Linear
+	if err := l.Fit(x, y, w, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := l.CoefficientsTo(nil)
+	if math.Abs(sol.Intercept-want[0]) > 1e-8 {
+		t.Errorf("unexpected intercept: got:%v want:%v", sol.Intercept, want[0])
+	}
+	if got := sol.CoefficientsTo(nil); !floats.EqualApprox(got, want[1:], 1e-8) {
+		t.Errorf("unexpected coefficients: got:%v want:%v", got, want[1:])
+	}
+	v := x.RawRowView(3)
+	if got := sol.Predict(v); math.Abs(got-l.Predict(v)) > 1e-8 {
+		t.Errorf("unexpected prediction: got:%v want:%v", got, l.Predict(v))
+	}
+}
+
+func TestElasticNetCrossValidate(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, loss := range []Loss{SquaredLoss, LogisticLoss} {
+		x, y, w := sparseRegression(rnd, 120, 10, loss)
+		e := ElasticNet{Alpha: 1, Loss: loss, NumLambda: 40}
+		cv := e.CrossValidate(x, y, w, 5, rand.NewSource(1))
+		if len(cv.Path) != 40 || len(cv.Error) != 40 || len(cv.StdErr) != 40 {
+			t.Fatalf("unexpected lengths of cross-validation results")
+		}
+		for l, err := range cv.Error {
+			if err < cv.Error[cv.Min] {
+				t.Errorf("error at %d less than minimum error", l)
+			}
+			if !(cv.StdErr[l] >= 0) {
+				t.Errorf("unexpected standard error: %v", cv.StdErr[l])
+			}
+		}
+		if cv.OneSE > cv.Min || cv.Error[cv.OneSE] > cv.Error[cv.Min]+cv.StdErr[cv.Min] {
+			t.Errorf("unexpected one standard error index: got:%d min:%d", cv.OneSE, cv.Min)
+		}
+		for l := 0; l < cv.OneSE; l++ {
+			if cv.Error[l] <= cv.Error[cv.Min]+cv.StdErr[cv.Min] {
+				t.Errorf("larger penalty %d within one standard error of minimum", l)
+			}
+		}
+
+		// The penalty selected by cross-validation recovers
+		// the three informative predictors.
+		sol := cv.Path[cv.Min]
+		for _, j := range []int{0, 1} {
+			found := false
+			for _, k := range sol.Index {
+				found = found || k == j
+			}
+			if !found {
+				t.Errorf("informative predictor %d not selected: %v", j, sol.Index)
+			}
+		}
+		if cv.Error[cv.Min] >= cv.Error[0] {
+			t.Errorf("cross-validated error not reduced from null model")
+		}
+
+		again := e.CrossValidate(x, y, w, 5, rand.NewSource(1))
+		if !floats.Equal(again.Error, cv.Error) {
+			t.Errorf("cross-validation not reproducible with same source")
+		}
+	}
+}
+
+func TestElasticNetPanics(t *testing.T) {
+	x := mat.NewDense(4, 1, []float64{1, 2, 3, 4})
+	y := []float64{1, 0, 1, 1}
+	for _, test := range []struct {
+		name string
+		e    ElasticNet
+		y, w []float64
+	}{
+		{name: "alpha", e: ElasticNet{Alpha: 1.5}, y: y},
+		{name: "loss", e: ElasticNet{Loss: LogisticLoss + 1}, y: y},
+		{name: "lambda order", e: ElasticNet{Lambda: []float64{0.1, 0.2}}, y: y},
+		{name: "lambda sign", e: ElasticNet{Lambda: []float64{0}}, y: y},
+		{name: "response length", y: y[:3]},
+		{name: "weights length", y: y, w: []float64{1}},
+		{name: "negative weight", y: y, w: []float64{1, -1, 1, 1}},
+		{name: "zero weights", y: y, w: []float64{0, 0, 0, 0}},
+		{name: "logistic response", e: ElasticNet{Loss: LogisticLoss}, y: []float64{0, 2, 1, 0}},
+	} {
+		if !panics(func() { test.e.Path(x, test.y, test.w) }) {
+			t.Errorf("expected panic for bad %s", test.name)
+		}
+	}
+	var e ElasticNet
+	for _, folds := range []int{1, 5} {
+		if !panics(func() { e.CrossValidate(x, y, nil, folds, nil) }) {
+			t.Errorf("expected panic for %d folds", folds)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resample
+
+import (
+	"math"
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/internal/parallel"
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+const (
+	badAlternative = "resample: bad alternative"
+	badBlock       = "resample: bad block length"
+	badLength      = "resample: slice length mismatch"
+	badLevel       = "resample: confidence level out of range"
+	badStratum     = "resample: negative stratum"
+	negativeWeight = "resample: negative weight"
+	noReplicates   = "resample: no replicates"
+	tooFewObs      = "resample: too few observations"
+	zeroWeights    = "resample: weights sum to zero"
+)
+
+// Statistic returns the value of a statistic of the observations with the
+// indices in idx. An index may appear more than once in idx, in which case
+// the observation is included that many times. The Statistic must not
+// retain or modify idx.
+//
+// A Statistic used by a function with a number of workers other than one
+// must be safe for concurrent use.
+type Statistic func(idx []int) float64
+
+// Bootstrap computes bootstrap replicates of the statistic of n
+// observations, storing them in dst and returning it. The number of
+// replicates is len(dst). Each replicate is the value of the statistic for
+// a resample of the observations generated by the sampler s. If s is nil,
+// the Uniform sampler is used.
+//
+// The replicates are computed by at most workers goroutines. If workers is
+// not positive, runtime.GOMAXPROCS(0) goroutines are used. The resample for
+// each replicate is generated from a random stream seeded by src, so for a
+// given src the replicates do not depend on workers. If src is nil the
+// global source is used to seed the streams.
+//
+// Bootstrap will panic if len(dst) or n is zero.
+func Bootstrap(dst []float64, n int, s Sampler, fn Statistic, workers int, src rand.Source) []float64 {
+	if len(dst) == 0 {
+		panic(noReplicates)
+	}
+	if n < 1 {
+		panic(tooFewObs)
+	}
+	if s == nil {
+		s = Uniform{}
+	}
+	seeds := streamSeeds(len(dst), src)
+	parallel.ForLocal(workers, len(dst), func() func(i int) {
+		idx := make([]int, n)
+		return func(i int) {
+			s.Sample(idx, rand.New(rand.NewSource(seeds[i])))
+			dst[i] = fn(idx)
+		}
+	})
+	return dst
+}
+
+// Jackknife computes the leave-one-out jackknife values of the statistic
+// of n observations. Element i of the result is the value of the statistic
+// for the observations other than observation i. If dst is nil, a new slice
+// is allocated, otherwise the values are stored in dst, which must have
+// length n. The values are computed by at most workers goroutines as
+// described for Bootstrap.
+//
+// Jackknife will panic if n is less than two, or if dst is not nil and its
+// length is not n.
+func Jackknife(dst []float64, n int, fn Statistic, workers int) []float64 {
+	if n < 2 {
+		panic(tooFewObs)
+	}
+	if dst == nil {
+		dst = make([]float64, n)
+	} else if len(dst) != n {
+		panic(badLength)
+	}
+	parallel.ForLocal(workers, n, func() func(i int) {
+		idx := make([]int, n-1)
+		return func(i int) {
+			for j := range idx {
+				if j < i {
+					idx[j] = j
+				} else {
+					idx[j] = j + 1
+				}
+			}
+			dst[i] = fn(idx)
+		}
+	})
+	return dst
+}
+
+// PercentileInterval returns the bootstrap percentile confidence interval
+// at the given level, the (1-level)/2 and (1+level)/2 quantiles of the
+// bootstrap replicates. The p quantile of B replicates is interpolated
+// linearly between the order statistics at position (B+1)p.
+// PercentileInterval will panic if level is not in (0, 1) or replicates
+// is empty.
+func PercentileInterval(replicates []float64, level float64) (lo, hi float64) {
+	checkLevel(level)
+	sorted := sortedCopy(replicates)
+	return quantile((1-level)/2, sorted), quantile((1+level)/2, sorted)
+}
+
+// BCaInterval returns the bias-corrected and accelerated bootstrap
+// confidence interval at the given level described by Efron in "Better
+// bootstrap confidence intervals", J. Amer. Statist. Assoc. 82(397):171–185.
+// The estimate is the value of the statistic for the observations,
+// replicates holds bootstrap replicates of the statistic and jackknife holds
+// its jackknife values, which are used to estimate the acceleration.
+//
+// The BCa interval adjusts the quantiles of the percentile interval for the
+// median bias of the replicates and the rate of change of the standard
+// error of the statistic, and is second order accurate.
+//
+// BCaInterval will panic if level is not in (0, 1), replicates is empty or
+// jackknife has fewer than two elements.
+func BCaInterval(estimate float64, replicates, jackknife []float64, level float64) (lo, hi float64) {
+	checkLevel(level)
+	if len(jackknife) < 2 {
+		panic(tooFewObs)
+	}
+	sorted := sortedCopy(replicates)
+
+	// The bias correction is the normal quantile of the
+	// fraction of replicates below the estimate. The fraction
+	// is limited to [1/(B+1), B/(B+1)] so that the correction
+	// is finite when the estimate is outside the replicates,
+	// as happens for the minimum or maximum of a sample.
+	b := float64(len(sorted))
+	below := float64(sort.SearchFloat64s(sorted, estimate))
+	prop := math.Min(math.Max(below/b, 1/(b+1)), b/(b+1))
+	z0 := distuv.UnitNormal.Quantile(prop)
+
+	// The acceleration is estimated from the skewness of
+	// the jackknife values.
+	mean := stat.Mean(jackknife, nil)
+	var num, den float64
+	for _, v := range jackknife {
+		d := mean - v
+		num += d * d * d
+		den += d * d
+	}
+	var a float64
+	if den > 0 {
+		a = num / (6 * math.Pow(den, 1.5))
+	}
+
+	adjust := func(p float64) float64 {
+		z := z0 + distuv.UnitNormal.Quantile(p)
+		return distuv.UnitNormal.CDF(z0 + z/(1-a*z))
+	}
+	return quantile(adjust((1-level)/2), sorted), quantile(adjust((1+level)/2), sorted)
+}
+
+// StudentizedInterval returns the bootstrap-t confidence interval at the
+// given level. The estimate and stdErr are the value of the statistic and
+// an estimate of its standard error for the observations, and studentized
+// holds the studentized bootstrap replicates
+//  t*_b = (θ*_b - θ) / se*_b
+// where θ*_b and se*_b are the value of the statistic and the estimate of
+// its standard error for resample b and θ is the estimate. The standard
+// errors of the replicates may be estimated analytically or by a nested
+// bootstrap. The interval is
+//  [θ - se t*_{(1+level)/2}, θ - se t*_{(1-level)/2}]
+// where t*_p is the p quantile of the studentized replicates.
+//
+// StudentizedInterval will panic if level is not in (0, 1) or studentized
+// is empty.
+func StudentizedInterval(estimate, stdErr float64, studentized []float64, level float64) (lo, hi float64) {
+	checkLevel(level)
+	sorted := sortedCopy(studentized)
+	return estimate - stdErr*quantile((1+level)/2, sorted), estimate - stdErr*quantile((1-level)/2, sorted)
+}
+
+// checkLevel panics if the confidence level is not in (0, 1).
+func checkLevel(level float64) {
+	if !(0 < level && level < 1) {
+		panic(badLevel)
+	}
+}
+
+// sortedCopy returns a sorted copy of x. It panics if x is empty.
+func sortedCopy(x []float64) []float64 {
+	if len(x) == 0 {
+		panic(noReplicates)
+	}
+	s := append([]float64(nil), x...)
+	sort.Float64s(s)
+	return s
+}
+
+// quantile returns the p quantile of the sorted values in x, interpolating
+// linearly between the order statistics at position (len(x)+1)p, the
+// convention for bootstrap percentiles.
+func quantile(p float64, x []float64) float64 {
+	if math.IsNaN(p) {
+		return math.NaN()
+	}
+	h := float64(len(x)+1) * p
+	switch {
+	case h <= 1:
+		return x[0]
+	case h >= float64(len(x)):
+		return x[len(x)-1]
+	}
+	i := int(h)
+	return x[i-1] + (h-float64(i))*(x[i]-x[i-1])
+}
+
+// streamSeeds returns n seeds for the random streams of resamples drawn
+// from src, or from the global source if src is nil.
+func streamSeeds(n int, src rand.Source) []uint64 {
+	seeds := make([]uint64, n)
+	if src == nil {
+		for i := range seeds {
+			seeds[i] = rand.Uint64()
+		}
+		return seeds
+	}
+	for i := range seeds {
+		seeds[i] = src.Uint64()
+	}
+	return seeds
+}
@@ -0,0 +1,220 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resample
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat"
+)
+
+// meanOf returns a Statistic computing the mean of the elements of x.
+func meanOf(x []float64) Statistic {
+	return func(idx []int) float64 {
+		var sum float64
+		for _, i := range idx {
+			sum += x[i]
+		}
+		return sum / float64(len(idx))
+	}
+}
+
+func TestBootstrapReproducible(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	x := make([]float64, 50)
+	for i := range x {
+		x[i] = rnd.NormFloat64()
+	}
+	for _, s := range []Sampler{nil, Block{Length: 5, Circular: true}} {
+		want := Bootstrap(make([]float64, 200), len(x), s, meanOf(x), 1, rand.NewSource(1))
+		for _, workers := range []int{0, 2, 7} {
+			got := Bootstrap(make([]float64, 200), len(x), s, meanOf(x), workers, rand.NewSource(1))
+			if !floats.Equal(got, want) {
+				t.Errorf("replicates depend on number of workers: workers=%d", workers)
+			}
+		}
+	}
+}
+
+func TestBootstrapStdErr(t *testing.T) {
+	// The bootstrap standard error of the mean is close to
+	// the plug-in estimate sqrt(Σ(x-x̄)²/n)/sqrt(n).
+	rnd := rand.New(rand.NewSource(1))
+	x := make([]float64, 100)
+	for i := range x {
+		x[i] = rnd.ExpFloat64()
+	}
+	rep := Bootstrap(make([]float64, 4000), len(x), Uniform{}, meanOf(x), 0, rand.NewSource(1))
+	n := float64(len(x))
+	sd := stat.StdDev(x, nil) * math.Sqrt((n-1)/n)
+	want := sd / math.Sqrt(n)
+	if got := stat.StdDev(rep, nil); math.Abs(got/want-1) > 0.05 {
+		t.Errorf("unexpected bootstrap standard error: got:%v want:%v", got, want)
+	}
+	if got, want := stat.Mean(rep, nil), stat.Mean(x, nil); math.Abs(got-want) > 0.1*sd/math.Sqrt(n) {
+		t.Errorf("unexpected mean of replicates: got:%v want:%v", got, want)
+	}
+}
+
+func TestJackknife(t *testing.T) {
+	x := []float64{2, 4, 9, 1, 7}
+	got := Jackknife(nil, len(x), meanOf(x), 0)
+	sum := floats.Sum(x)
+	for i, v := range x {
+		want := (sum - v) / float64(len(x)-1)
+		if math.Abs(got[i]-want) > 1e-14 {
+			t.Errorf("unexpected jackknife value %d: got:%v want:%v", i, got[i], want)
+		}
+	}
+}
+
+func TestIntervals(t *testing.T) {
+	// A symmetric set of replicates centred on the estimate with
+	// symmetric jackknife values has no bias or acceleration, so
+	// the BCa interval is the percentile interval.
+	rep := make([]float64, 1001)
+	for i := range rep {
+		rep[i] = float64(i - 500)
+	}
+	jack := []float64{-2, -1, 0, 1, 2}
+	plo, phi := PercentileInterval(rep, 0.9)
+	if math.Abs(plo+450.9) > 1e-10 || math.Abs(phi-450.9) > 1e-10 {
+		t.Errorf("unexpected percentile interval: got:[%v,%v] want:[-450.9,450.9]", plo, phi)
+	}
+	blo, bhi := BCaInterval(0.5, rep, jack, 0.9)
+	if math.Abs(blo-plo) > 1 || math.Abs(bhi-phi) > 1 {
+		t.Errorf("unexpected BCa interval without bias: got:[%v,%v] want:[%v,%v]", blo, bhi, plo, phi)
+	}
+
+	// Shifting the estimate shifts the BCa interval toward it.
+	blo, bhi = BCaInterval(100, rep, jack, 0.9)
+	if !(blo > plo && bhi > phi) {
+		t.Errorf("BCa interval not shifted by bias: got:[%v,%v]", blo, bhi)
+	}
+
+	// The BCa interval is finite for the extremes of a small
+	// sample, where the estimate is at the edge of the replicates.
+	x := []float64{3, 1, 4, 1, 5, 9, 2, 6}
+	for _, test := range []struct {
+		name string
+		fn   func([]float64) float64
+	}{
+		{name: "min", fn: floats.Min},
+		{name: "max", fn: floats.Max},
+	} {
+		fn := func(idx []int) float64 {
+			sub := make([]float64, len(idx))
+			for i, j := range idx {
+				sub[i] = x[j]
+			}
+			return test.fn(sub)
+		}
+		rep := Bootstrap(make([]float64, 200), len(x), nil, fn, 0, rand.NewSource(1))
+		jack := Jackknife(nil, len(x), fn, 0)
+		lo, hi := BCaInterval(test.fn(x), rep, jack, 0.9)
+		if math.IsNaN(lo) || math.IsNaN(hi) || lo < floats.Min(rep) || hi > floats.Max(rep) || lo > hi {
+			t.Errorf("unexpected BCa interval for %s: got:[%v,%v]", test.name, lo, hi)
+		}
+	}
+
+	// The studentized interval reflects the quantiles of the
+	// studentized replicates about the estimate.
+	slo, shi := StudentizedInterval(10, 2, []float64{-3, -1, 0, 1, 5}, 0.5)
+	if slo != 4 || shi != 14 {
+		t.Errorf("unexpected studentized interval: got:[%v,%v] want:[4,14]", slo, shi)
+	}
+
+	for _, level := range []float64{0, 1, -0.5, math.NaN()} {
+		if !panics(func() { PercentileInterval(rep, level) }) {
+			t.Errorf("expected panic for level %v", level)
+		}
+	}
+	if !panics(func() { PercentileInterval(nil, 0.9) }) {
+		t.Errorf("expected panic for no replicates")
+	}
+	if !panics(func() { BCaInterval(0, rep, []float64{1}, 0.9) }) {
+		t.Errorf("expected panic for too few jackknife values")
+	}
+}
+
+func TestIntervalCoverage(t *testing.T) {
+	// The coverage of the intervals for the mean of a skewed
+	// distribution is close to the nominal level, with BCa and
+	// studentized intervals more accurate than the percentile
+	// interval.
+	const (
+		n      = 30
+		trials = 300
+		level  = 0.9
+		reps   = 500
+		mean   = 1
+	)
+	rnd := rand.New(rand.NewSource(1))
+	var percentile, bca, student int
+	x := make([]float64, n)
+	for trial := 0; trial < trials; trial++ {
+		for i := range x {
+			x[i] = rnd.ExpFloat64()
+		}
+		est := stat.Mean(x, nil)
+		fn := meanOf(x)
+		rep := Bootstrap(make([]float64, reps), n, nil, fn, 0, rand.NewSource(uint64(trial)))
+		jack := Jackknife(nil, n, fn, 0)
+
+		tStat := func(idx []int) float64 {
+			sub := make([]float64, len(idx))
+			for i, j := range idx {
+				sub[i] = x[j]
+			}
+			m, sd := stat.MeanStdDev(sub, nil)
+			return (m - est) / (sd / math.Sqrt(n))
+		}
+		studentized := Bootstrap(make([]float64, reps), n, nil, tStat, 0, rand.NewSource(uint64(trial)))
+
+		if lo, hi := PercentileInterval(rep, level); lo < mean && mean < hi {
+			percentile++
+		}
+		if lo, hi := BCaInterval(est, rep, jack, level); lo < mean && mean < hi {
+			bca++
+		}
+		if lo, hi := StudentizedInterval(est, stat.StdDev(x, nil)/math.Sqrt(n), studentized, level); lo < mean && mean < hi {
+			student++
+		}
+	}
+	for _, test := range []struct {
+		name    string
+		covered int
+	}{
+		{name: "percentile", covered: percentile},
+		{name: "BCa", covered: bca},
+		{name: "studentized", covered: student},
+	} {
+		// The standard error of the coverage is about 0.017.
+		if got := float64(test.covered) / trials; math.Abs(got-level) > 0.06 {
+			t.Errorf("unexpected coverage of %s interval: got:%v want:%v", test.name, got, level)
+		}
+	}
+}
+
+func TestBootstrapPanics(t *testing.T) {
+	fn := meanOf([]float64{1, 2, 3})
+	for _, test := range []struct {
+		name string
+		fn   func()
+	}{
+		{name: "no replicates", fn: func() { Bootstrap(nil, 3, nil, fn, 0, nil) }},
+		{name: "no observations", fn: func() { Bootstrap(make([]float64, 3), 0, nil, fn, 0, nil) }},
+		{name: "jackknife of one observation", fn: func() { Jackknife(nil, 1, fn, 0) }},
+		{name: "jackknife length", fn: func() { Jackknife(make([]float64, 2), 3, fn, 0) }},
+	} {
+		if !panics(test.fn) {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+}
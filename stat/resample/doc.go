@@ -0,0 +1,13 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package resample provides bootstrap and permutation resampling methods
+// for estimating the sampling distributions of arbitrary statistics.
+//
+// Statistics are functions of the indices of the observations in a
+// resample, so they may be computed from data of any form held by the
+// caller. Replicates are computed concurrently, with the random numbers for
+// each replicate drawn from a stream seeded from the caller's source, so
+// results are reproducible independent of the number of goroutines used.
+package resample // import "gonum.org/v1/gonum/stat/resample"
@@ -0,0 +1,84 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resample_test
+
+import (
+	"fmt"
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/hypothesis"
+	"gonum.org/v1/gonum/stat/resample"
+)
+
+func ExampleBootstrap() {
+	// Waiting times that follow a skewed distribution.
+	x := []float64{
+		0.8, 2.1, 0.3, 5.6, 1.2, 0.9, 3.4, 0.2, 7.9, 1.5,
+		0.6, 2.8, 0.4, 1.1, 4.2, 0.7, 1.9, 0.5, 2.4, 9.3,
+	}
+
+	// The statistic is the median of the resampled observations.
+	median := func(idx []int) float64 {
+		sub := make([]float64, len(idx))
+		for i, j := range idx {
+			sub[i] = x[j]
+		}
+		sort.Float64s(sub)
+		return stat.Quantile(0.5, stat.Empirical, sub, nil)
+	}
+	all := make([]int, len(x))
+	for i := range all {
+		all[i] = i
+	}
+	est := median(all)
+
+	rep := resample.Bootstrap(make([]float64, 2000), len(x), nil, median, 0, rand.NewSource(1))
+	jack := resample.Jackknife(nil, len(x), median, 0)
+
+	fmt.Printf("median: %.2f\n", est)
+	fmt.Printf("standard error: %.2f\n", stat.StdDev(rep, nil))
+	lo, hi := resample.PercentileInterval(rep, 0.9)
+	fmt.Printf("90%% percentile interval: [%.2f, %.2f]\n", lo, hi)
+	lo, hi = resample.BCaInterval(est, rep, jack, 0.9)
+	fmt.Printf("90%% BCa interval: [%.2f, %.2f]\n", lo, hi)
+
+	// Output:
+	// median: 1.20
+	// standard error: 0.50
+	// 90% percentile interval: [0.70, 2.40]
+	// 90% BCa interval: [0.70, 2.10]
+}
+
+func ExamplePermutationTest() {
+	// Response times under two configurations.
+	a := []float64{12.1, 10.4, 11.8, 13.0, 12.6, 11.1, 12.9, 13.4}
+	b := []float64{10.2, 11.0, 9.8, 10.9, 11.6, 10.1, 10.7}
+
+	// The statistic is the difference between the mean of the
+	// observations labelled by the first len(a) indices and the
+	// mean of the remaining observations.
+	z := append(append([]float64(nil), a...), b...)
+	diff := func(idx []int) float64 {
+		var sa, sb float64
+		for _, i := range idx[:len(a)] {
+			sa += z[i]
+		}
+		for _, i := range idx[len(a):] {
+			sb += z[i]
+		}
+		return sa/float64(len(a)) - sb/float64(len(b))
+	}
+
+	res := resample.PermutationTest(len(z), 9999, diff, hypothesis.TwoSided, 0, rand.NewSource(1))
+	fmt.Printf("difference in means: %.3f\n", res.Statistic)
+	fmt.Printf("p-value: %.4f\n", res.PValue)
+
+	// Output:
+	// difference in means: 1.548
+	// p-value: 0.0052
+}
@@ -0,0 +1,102 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resample
+
+import (
+	"math"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/internal/parallel"
+	"gonum.org/v1/gonum/stat/hypothesis"
+)
+
+// PermutationTest performs a Monte Carlo permutation test of the statistic
+// of n observations. The statistic is evaluated for the identity
+// permutation of the indices, giving the observed value, and for the given
+// number of random permutations, which are generated and evaluated by at
+// most workers goroutines as described for Bootstrap.
+//
+// The null hypothesis of the test is that the distribution of the
+// observations is invariant under permutation of the indices passed to the
+// statistic. For example, to test whether two samples x and y have the same
+// distribution, the statistic may compute the difference between the means
+// of the observations of the concatenation of x and y with the first len(x)
+// indices and those with the remaining indices. The alternatives Less and
+// Greater are that the statistic tends to be smaller or larger than under
+// the null hypothesis.
+//
+// The p-value of the test is
+//  (1 + b) / (1 + permutations)
+// where b is the number of permutations giving a value of the statistic at
+// least as extreme as the observed value, which is valid (conservative)
+// for Monte Carlo sampling of the permutations as shown by Phipson and Smyth in
+// "Permutation p-values should never be zero", Stat. Appl. Genet. Mol.
+// Biol. 9(1):39. The two-sided p-value is twice the smaller of the
+// one-sided p-values, limited to one.
+//
+// The returned Result holds the observed value of the statistic and the
+// p-value. The DoF, Estimate, Lower and Upper fields are NaN.
+//
+// PermutationTest will panic if n is less than two or permutations is not
+// positive.
+func PermutationTest(n, permutations int, fn Statistic, alt hypothesis.Alternative, workers int, src rand.Source) hypothesis.Result {
+	if n < 2 {
+		panic(tooFewObs)
+	}
+	if permutations < 1 {
+		panic(noReplicates)
+	}
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	observed := fn(idx)
+
+	perm := make([]float64, permutations)
+	seeds := streamSeeds(permutations, src)
+	parallel.ForLocal(workers, permutations, func() func(i int) {
+		idx := make([]int, n)
+		return func(i int) {
+			rnd := rand.New(rand.NewSource(seeds[i]))
+			for j := range idx {
+				idx[j] = j
+			}
+			rnd.Shuffle(n, func(a, b int) { idx[a], idx[b] = idx[b], idx[a] })
+			perm[i] = fn(idx)
+		}
+	})
+
+	var less, greater int
+	for _, v := range perm {
+		if v <= observed {
+			less++
+		}
+		if v >= observed {
+			greater++
+		}
+	}
+	pLess := float64(1+less) / float64(1+permutations)
+	pGreater := float64(1+greater) / float64(1+permutations)
+	var p float64
+	switch alt {
+	case hypothesis.TwoSided:
+		p = math.Min(1, 2*math.Min(pLess, pGreater))
+	case hypothesis.Less:
+		p = pLess
+	case hypothesis.Greater:
+		p = pGreater
+	default:
+		panic(badAlternative)
+	}
+	return hypothesis.Result{
+		Statistic: observed,
+		PValue:    p,
+		DoF:       math.NaN(),
+		Estimate:  math.NaN(),
+		Lower:     math.NaN(),
+		Upper:     math.NaN(),
+	}
+}
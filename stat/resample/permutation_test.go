@@ -0,0 +1,91 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resample
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/stat/hypothesis"
+)
+
+// meanDiff returns a Statistic computing the difference between the means
+// of the observations of z with the first n indices and the remaining
+// indices.
+func meanDiff(z []float64, n int) Statistic {
+	return func(idx []int) float64 {
+		var a, b float64
+		for _, i := range idx[:n] {
+			a += z[i]
+		}
+		for _, i := range idx[n:] {
+			b += z[i]
+		}
+		return a/float64(n) - b/float64(len(idx)-n)
+	}
+}
+
+func TestPermutationTest(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, shift := range []float64{0, 0.3, 1} {
+		x := make([]float64, 40)
+		y := make([]float64, 30)
+		for i := range x {
+			x[i] = rnd.NormFloat64() + shift
+		}
+		for i := range y {
+			y[i] = rnd.NormFloat64()
+		}
+		z := append(append([]float64(nil), x...), y...)
+		fn := meanDiff(z, len(x))
+		for _, alt := range []hypothesis.Alternative{hypothesis.TwoSided, hypothesis.Less, hypothesis.Greater} {
+			got := PermutationTest(len(z), 4000, fn, alt, 0, rand.NewSource(1))
+			// For normal data the permutation test
+			// agrees closely with the t-test.
+			want := hypothesis.TwoSampleT(x, y, 0, alt, 0.95)
+			if math.Abs(got.Statistic-want.Estimate) > 1e-12 {
+				t.Errorf("unexpected statistic: got:%v want:%v", got.Statistic, want.Estimate)
+			}
+			if math.Abs(got.PValue-want.PValue) > 0.02 {
+				t.Errorf("unexpected p-value for shift=%v alt=%v: got:%v want:%v", shift, alt, got.PValue, want.PValue)
+			}
+			if !math.IsNaN(got.DoF) || !math.IsNaN(got.Lower) {
+				t.Errorf("unexpected non-NaN fields in result: %+v", got)
+			}
+		}
+	}
+}
+
+func TestPermutationTestExact(t *testing.T) {
+	// With all observations equal, every permutation gives the
+	// observed value.
+	z := make([]float64, 10)
+	got := PermutationTest(len(z), 99, meanDiff(z, 5), hypothesis.Greater, 3, rand.NewSource(1))
+	if got.PValue != 1 {
+		t.Errorf("unexpected p-value: got:%v want:1", got.PValue)
+	}
+
+	// The smallest attainable p-value is 1/(1+permutations).
+	z = []float64{10, 11, 12, 13, 14, 0, 1, 2, 3, 4}
+	got = PermutationTest(len(z), 99, meanDiff(z, 5), hypothesis.Greater, 0, rand.NewSource(1))
+	if got.PValue < 0.01 || got.PValue > 0.05 {
+		t.Errorf("unexpected p-value for separated samples: got:%v", got.PValue)
+	}
+
+	for _, test := range []struct {
+		name string
+		fn   func()
+	}{
+		{name: "too few observations", fn: func() { PermutationTest(1, 10, meanDiff(z, 1), hypothesis.TwoSided, 0, nil) }},
+		{name: "no permutations", fn: func() { PermutationTest(10, 0, meanDiff(z, 5), hypothesis.TwoSided, 0, nil) }},
+		{name: "bad alternative", fn: func() { PermutationTest(10, 10, meanDiff(z, 5), -1, 0, nil) }},
+	} {
+		if !panics(test.fn) {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+}
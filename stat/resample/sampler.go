@@ -0,0 +1,151 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resample
+
+import (
+	"sort"
+
+	"golang.org/x/exp/rand"
+)
+
+// Sampler generates resamples of the indices of a set of observations.
+type Sampler interface {
+	// Sample fills idx with the indices of the observations
+	// in a resample of len(idx) observations, using random
+	// numbers from rnd.
+	Sample(idx []int, rnd *rand.Rand)
+}
+
+// Uniform is the sampler of the nonparametric bootstrap. Each index of a
+// resample is drawn independently and uniformly from the indices of the
+// observations.
+type Uniform struct{}
+
+// Sample fills idx with indices drawn uniformly from [0, len(idx)).
+func (Uniform) Sample(idx []int, rnd *rand.Rand) {
+	n := len(idx)
+	for i := range idx {
+		idx[i] = rnd.Intn(n)
+	}
+}
+
+// Weighted is a sampler that draws each index of a resample independently
+// with probability proportional to the weight of the observation.
+type Weighted struct {
+	cum []float64
+}
+
+// NewWeighted returns a Weighted sampler for observations with the given
+// weights. NewWeighted will panic if any weight is negative or the weights
+// sum to zero.
+func NewWeighted(weights []float64) Weighted {
+	cum := make([]float64, len(weights))
+	var sum float64
+	for i, w := range weights {
+		if w < 0 {
+			panic(negativeWeight)
+		}
+		sum += w
+		cum[i] = sum
+	}
+	if sum == 0 {
+		panic(zeroWeights)
+	}
+	return Weighted{cum: cum}
+}
+
+// Sample fills idx with indices drawn with probability proportional to
+// the weights of the observations. Sample will panic if len(idx) is not
+// equal to the number of weights.
+func (w Weighted) Sample(idx []int, rnd *rand.Rand) {
+	if len(idx) != len(w.cum) {
+		panic(badLength)
+	}
+	total := w.cum[len(w.cum)-1]
+	for i := range idx {
+		u := rnd.Float64() * total
+		j := sort.Search(len(w.cum), func(j int) bool { return w.cum[j] > u })
+		if j == len(w.cum) {
+			j--
+		}
+		idx[i] = j
+	}
+}
+
+// Block is the sampler of the block bootstrap for dependent observations
+// such as time series. A resample is formed by concatenating blocks of
+// Length consecutive observations starting at uniformly chosen positions,
+// truncated to the number of observations.
+//
+// If Circular is false the blocks lie within the observations, giving the
+// moving block bootstrap of Künsch (1989). If Circular is true the
+// observations are wrapped around a circle, giving the circular block
+// bootstrap of Politis and Romano (1992), in which every observation is
+// equally likely to appear in a resample.
+type Block struct {
+	Length   int
+	Circular bool
+}
+
+// Sample fills idx with the indices of a block bootstrap resample. Sample
+// will panic if Length is not positive, or if Circular is false and Length
+// is greater than len(idx).
+func (b Block) Sample(idx []int, rnd *rand.Rand) {
+	n := len(idx)
+	if b.Length < 1 || (!b.Circular && b.Length > n) {
+		panic(badBlock)
+	}
+	starts := n
+	if !b.Circular {
+		starts = n - b.Length + 1
+	}
+	for i := 0; i < n; {
+		s := rnd.Intn(starts)
+		for j := 0; j < b.Length && i < n; j++ {
+			idx[i] = (s + j) % n
+			i++
+		}
+	}
+}
+
+// Stratified is the sampler of the stratified bootstrap. Each stratum of
+// the observations is resampled uniformly, so each resample has the same
+// number of observations in each stratum as the original observations.
+type Stratified struct {
+	strata  []int
+	members [][]int
+}
+
+// NewStratified returns a Stratified sampler for observations where the
+// stratum of observation i is strata[i]. Strata are identified by
+// non-negative integers. NewStratified will panic if any stratum is
+// negative.
+func NewStratified(strata []int) Stratified {
+	var members [][]int
+	for i, s := range strata {
+		if s < 0 {
+			panic(badStratum)
+		}
+		for s >= len(members) {
+			members = append(members, nil)
+		}
+		members[s] = append(members[s], i)
+	}
+	return Stratified{strata: append([]int(nil), strata...), members: members}
+}
+
+// Sample fills idx with the indices of a stratified resample. Element i
+// of idx is drawn uniformly from the observations in the stratum of
+// observation i. Sample will panic if len(idx) is not equal to the number
+// of observations.
+func (s Stratified) Sample(idx []int, rnd *rand.Rand) {
+	if len(idx) != len(s.strata) {
+		panic(badLength)
+	}
+	for i, st := range s.strata {
+		m := s.members[st]
+		idx[i] = m[rnd.Intn(len(m))]
+	}
+}
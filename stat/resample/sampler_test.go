@@ -0,0 +1,142 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resample
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestSamplers(t *testing.T) {
+	const (
+		n    = 20
+		reps = 20000
+	)
+	weights := make([]float64, n)
+	for i := range weights {
+		weights[i] = float64(i % 4)
+	}
+	strata := make([]int, n)
+	for i := range strata {
+		strata[i] = i % 3
+	}
+	uniform := make([]float64, n)
+	for i := range uniform {
+		uniform[i] = 1 / float64(n)
+	}
+	var sum float64
+	for _, w := range weights {
+		sum += w
+	}
+	weighted := make([]float64, n)
+	for i, w := range weights {
+		weighted[i] = w / sum
+	}
+	for _, test := range []struct {
+		name    string
+		sampler Sampler
+
+		// want is the expected frequency of each index, and
+		// check checks the indices of each resample.
+		want  []float64
+		check func(idx []int) bool
+	}{
+		{name: "uniform", sampler: Uniform{}, want: uniform},
+		{name: "weighted", sampler: NewWeighted(weights), want: weighted},
+		{name: "circular block", sampler: Block{Length: 4, Circular: true}, want: uniform,
+			check: func(idx []int) bool { return consecutiveBlocks(idx, 4, true) }},
+		{name: "moving block", sampler: Block{Length: 4},
+			check: func(idx []int) bool { return consecutiveBlocks(idx, 4, false) }},
+		{name: "stratified", sampler: NewStratified(strata), want: uniform,
+			check: func(idx []int) bool {
+				for i, j := range idx {
+					if strata[i] != strata[j] {
+						return false
+					}
+				}
+				return true
+			}},
+	} {
+		rnd := rand.New(rand.NewSource(1))
+		idx := make([]int, n)
+		counts := make([]float64, n)
+		for r := 0; r < reps; r++ {
+			test.sampler.Sample(idx, rnd)
+			for _, j := range idx {
+				if j < 0 || n <= j {
+					t.Fatalf("index out of range for %s sampler: %d", test.name, j)
+				}
+				counts[j]++
+			}
+			if test.check != nil && !test.check(idx) {
+				t.Fatalf("unexpected resample for %s sampler: %v", test.name, idx)
+			}
+		}
+		if test.want == nil {
+			continue
+		}
+		for i, c := range counts {
+			got := c / (n * reps)
+			// Allow five standard errors of the frequency.
+			tol := 5 * math.Sqrt(test.want[i]*(1-test.want[i])/(n*reps))
+			if test.name == "circular block" {
+				// Indices within a block are dependent.
+				tol *= 2
+			}
+			if math.Abs(got-test.want[i]) > tol {
+				t.Errorf("unexpected frequency of index %d for %s sampler: got:%v want:%v", i, test.name, got, test.want[i])
+			}
+		}
+	}
+}
+
+// consecutiveBlocks returns whether idx is formed of blocks of consecutive
+// indices of the given length.
+func consecutiveBlocks(idx []int, length int, circular bool) bool {
+	n := len(idx)
+	for i := 0; i < n; i += length {
+		for j := i + 1; j < i+length && j < n; j++ {
+			next := idx[j-1] + 1
+			if circular {
+				next %= n
+			}
+			if idx[j] != next {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestSamplerPanics(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		name string
+		fn   func()
+	}{
+		{name: "negative weight", fn: func() { NewWeighted([]float64{1, -1}) }},
+		{name: "zero weights", fn: func() { NewWeighted([]float64{0, 0}) }},
+		{name: "weights length", fn: func() { NewWeighted([]float64{1, 2}).Sample(make([]int, 3), rnd) }},
+		{name: "zero block length", fn: func() { Block{}.Sample(make([]int, 3), rnd) }},
+		{name: "long block", fn: func() { Block{Length: 4}.Sample(make([]int, 3), rnd) }},
+		{name: "negative stratum", fn: func() { NewStratified([]int{0, -1}) }},
+		{name: "strata length", fn: func() { NewStratified([]int{0, 1}).Sample(make([]int, 3), rnd) }},
+	} {
+		if !panics(test.fn) {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+}
+
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		r := recover()
+		panicked = r != nil
+	}()
+	fn()
+	return
+}
@@ -6,6 +6,7 @@ package stat
 
 import (
 	"math"
+	"math/big"
 	"sort"
 
 	"gonum.org/v1/gonum/floats"
@@ -1293,3 +1294,130 @@ func MeanVariance(x, weights []float64) (mean, variance float64) {
 	variance = (ss - compensation*compensation/sumWeights) / (sumWeights - 1)
 	return mean, variance
 }
+
+// MeanVariance2 computes the sample mean and unbiased variance as
+// MeanVariance does, but returns the exact mean and variance of the data
+// correctly rounded to the nearest float64. The sums are accumulated exactly
+// in arbitrary precision, so MeanVariance2 is considerably slower than
+// MeanVariance. If any of the values or weights is not finite, the result of
+// MeanVariance is returned.
+// If weights is nil then all of the weights are 1. If weights is not nil, then
+// len(x) must equal len(weights).
+// When weights sum to 1 or less, a biased variance estimator should be used.
+func MeanVariance2(x, weights []float64) (mean, variance float64) {
+	if weights != nil && len(x) != len(weights) {
+		panic("stat: slice length mismatch")
+	}
+
+	// Each value and weight is written as m * 2^e with an integer
+	// mantissa m. Scaling by the smallest exponents ex and ew of the
+	// non-zero values and weights turns the sums
+	//  W = \sum_i w_i, S1 = \sum_i w_i x_i, S2 = \sum_i w_i x_i^2
+	// into the integers a = W/2^ew, b = S1/2^(ew+ex) and c = S2/2^(ew+2ex),
+	// which are accumulated exactly.
+	ex, ew := math.MaxInt32, math.MaxInt32
+	for i, v := range x {
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) || math.IsNaN(w) || math.IsInf(w, 0) {
+			return MeanVariance(x, weights)
+		}
+		if w == 0 {
+			continue
+		}
+		_, e := mantExp(w)
+		ew = min(ew, e)
+		if v != 0 {
+			_, e = mantExp(v)
+			ex = min(ex, e)
+		}
+	}
+	if ew == math.MaxInt32 {
+		ew = 0
+	}
+	if ex == math.MaxInt32 {
+		ex = 0
+	}
+
+	var a, b, c, mw, mx, t big.Int
+	for i, v := range x {
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		if w == 0 {
+			continue
+		}
+		m, e := mantExp(w)
+		mw.SetInt64(m)
+		mw.Lsh(&mw, uint(e-ew))
+		a.Add(&a, &mw)
+		if v == 0 {
+			continue
+		}
+		m, e = mantExp(v)
+		mx.SetInt64(m)
+		mx.Lsh(&mx, uint(e-ex))
+		t.Mul(&mw, &mx)
+		b.Add(&b, &t)
+		t.Mul(&t, &mx)
+		c.Add(&c, &t)
+	}
+
+	// mean = S1 / W = 2^ex b / a.
+	mean = ratQuo(ratScale(&b, ex), ratScale(&a, 0))
+	if a.Sign() == 0 {
+		return mean, math.NaN()
+	}
+
+	// The unbiased variance is
+	//  (W S2 - S1^2) / (W (W - 1)) = 2^(2ew+2ex) (a c - b^2) / (W (W - 1)).
+	var num big.Int
+	num.Mul(&a, &c)
+	t.Mul(&b, &b)
+	num.Sub(&num, &t)
+	sumWeights := ratScale(&a, ew)
+	den := new(big.Rat).Sub(sumWeights, big.NewRat(1, 1))
+	den.Mul(den, sumWeights)
+	variance = ratQuo(ratScale(&num, 2*ew+2*ex), den)
+	return mean, variance
+}
+
+// mantExp returns the integer mantissa m and exponent e of the finite
+// non-zero v such that v == m * 2^e and |m| < 2^53.
+func mantExp(v float64) (m int64, e int) {
+	frac, exp := math.Frexp(v)
+	return int64(frac * (1 << 53)), exp - 53
+}
+
+// ratScale returns x * 2^e.
+func ratScale(x *big.Int, e int) *big.Rat {
+	if e >= 0 {
+		return new(big.Rat).SetInt(new(big.Int).Lsh(x, uint(e)))
+	}
+	return new(big.Rat).SetFrac(x, new(big.Int).Lsh(big.NewInt(1), uint(-e)))
+}
+
+// ratQuo returns num/den correctly rounded to the nearest float64. If den is
+// zero, the result is the rounded num divided by zero.
+func ratQuo(num, den *big.Rat) float64 {
+	if den.Sign() == 0 {
+		f, _ := num.Float64()
+		return f / 0
+	}
+	f, _ := new(big.Rat).Quo(num, den).Float64()
+	return f
+}
+
+// Variance2 computes the unbiased weighted sample variance as Variance
+// does, but returns the exact variance of the data correctly rounded to the
+// nearest float64. See MeanVariance2 for details.
+// If weights is nil then all of the weights are 1. If weights is not nil, then
+// len(x) must equal len(weights).
+// When weights sum to 1 or less, a biased variance estimator should be used.
+func Variance2(x, weights []float64) float64 {
+	_, variance := MeanVariance2(x, weights)
+	return variance
+}
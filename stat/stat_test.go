@@ -7,6 +7,7 @@ package stat
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"reflect"
 	"strconv"
 	"testing"
@@ -1733,6 +1734,138 @@ func TestVariance(t *testing.T) {
 
 }
 
+func TestMeanVariance2(t *testing.T) {
+	for i, test := range []struct {
+		x       []float64
+		weights []float64
+		ans     float64
+	}{
+		{
+			x:       []float64{8, -3, 7, 8, -4},
+			weights: nil,
+			ans:     37.7,
+		},
+		{
+			x:       []float64{8, 3, 7, 8, 4},
+			weights: []float64{2, 1, 2, 1, 1},
+			ans:     4.2857142857142865,
+		},
+		{
+			x:       []float64{1, 4, 9},
+			weights: []float64{1, 1.5, 1},
+			ans:     13.142857142857146,
+		},
+		{
+			x:       []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16},
+			weights: nil,
+			ans:     30,
+		},
+	} {
+		variance := Variance2(test.x, test.weights)
+		if math.Abs(variance-test.ans) > 1e-14*test.ans {
+			t.Errorf("Variance mismatch case %d. Expected %v, Found %v", i, test.ans, variance)
+		}
+	}
+	if !panics(func() { Variance2(make([]float64, 3), make([]float64, 2)) }) {
+		t.Errorf("Variance2 did not panic with x, weights length mismatch")
+	}
+
+	// Compare against the correctly rounded exact mean and variance.
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		name   string
+		n      int
+		value  func() float64
+		weight func() float64
+	}{
+		{
+			name:  "large mean",
+			n:     2000,
+			value: func() float64 { return 1e8 + rnd.NormFloat64() },
+		},
+		{
+			name:   "large mean weighted",
+			n:      2000,
+			value:  func() float64 { return 1e8 + rnd.NormFloat64() },
+			weight: rnd.Float64,
+		},
+		{
+			name:   "wide range",
+			n:      1000,
+			value:  func() float64 { return rnd.NormFloat64() * math.Pow(2, float64(rnd.Intn(200)-100)) },
+			weight: func() float64 { return math.Ldexp(rnd.Float64(), rnd.Intn(40)-20) },
+		},
+		{
+			name:  "subnormal",
+			n:     100,
+			value: func() float64 { return rnd.NormFloat64() * 1e-310 },
+		},
+		{
+			name:  "squares overflow",
+			n:     100,
+			value: func() float64 { return 1e300 + rnd.NormFloat64()*1e140 },
+		},
+		{
+			name:   "negative weights",
+			n:      100,
+			value:  rnd.NormFloat64,
+			weight: func() float64 { return rnd.Float64() - 0.25 },
+		},
+	} {
+		for cas := 0; cas < 10; cas++ {
+			x := make([]float64, test.n)
+			var weights []float64
+			if test.weight != nil {
+				weights = make([]float64, test.n)
+			}
+			for i := range x {
+				x[i] = test.value()
+				if weights != nil {
+					weights[i] = test.weight()
+				}
+			}
+			wantMean, wantVar := exactMeanVariance(x, weights)
+			mean, variance := MeanVariance2(x, weights)
+			if mean != wantMean {
+				t.Errorf("unexpected mean for %s case %d: got:%v want:%v", test.name, cas, mean, wantMean)
+			}
+			if variance != wantVar {
+				t.Errorf("unexpected variance for %s case %d: got:%v want:%v", test.name, cas, variance, wantVar)
+			}
+		}
+	}
+}
+
+// exactMeanVariance returns the weighted mean and unbiased variance of x
+// computed exactly and rounded to the nearest float64.
+func exactMeanVariance(x, weights []float64) (mean, variance float64) {
+	w := func(i int) *big.Rat {
+		if weights == nil {
+			return big.NewRat(1, 1)
+		}
+		return new(big.Rat).SetFloat64(weights[i])
+	}
+	sum := new(big.Rat)
+	sumW := new(big.Rat)
+	for i, v := range x {
+		p := new(big.Rat).SetFloat64(v)
+		sum.Add(sum, p.Mul(p, w(i)))
+		sumW.Add(sumW, w(i))
+	}
+	m := new(big.Rat).Quo(sum, sumW)
+	ss := new(big.Rat)
+	for i, v := range x {
+		d := new(big.Rat).SetFloat64(v)
+		d.Sub(d, m)
+		d.Mul(d, d)
+		ss.Add(ss, d.Mul(d, w(i)))
+	}
+	sumW.Sub(sumW, big.NewRat(1, 1))
+	mean, _ = m.Float64()
+	variance, _ = ss.Quo(ss, sumW).Float64()
+	return mean, variance
+}
+
 func ExampleVariance() {
 	x := []float64{8, 2, -9, 15, 4}
 	variance := Variance(x, nil)